@@ -26,6 +26,7 @@ export const PREDICATES: PredicateDefinition[] = [
     { field: 'file', name: 'contains.content' },
     { field: 'file', name: 'has.content' },
     { field: 'file', name: 'has.owner' },
+    { field: 'file', name: 'has.symbol' },
     { field: 'rev', name: 'at.time' },
 ]
 
@@ -186,6 +187,13 @@ export const predicateCompletion = (field: FilterType): Completion[] => {
                 asSnippet: true,
                 description: 'Search only inside files that have a contributor that matches a pattern',
             },
+            {
+                label: 'has.symbol(...)',
+                insertText: 'has.symbol(${1:kind} ${2:name})',
+                asSnippet: true,
+                description:
+                    'Search only inside files that define a symbol whose name matches a pattern, optionally of a kind like class or interface',
+            },
         ]
     }
     if (field === 'rev') {
@@ -586,7 +586,8 @@ export const scanSearchQuery = (
         case SearchPatternType.codycontext: {
             return scanStandard(query)
         }
-        case SearchPatternType.keyword: {
+        case SearchPatternType.keyword:
+        case SearchPatternType.semantic: {
             return scanKeyword(query)
         }
         case SearchPatternType.literal: {
@@ -49,7 +49,9 @@ describe('selectorCompletion', () => {
             symbol.struct,
             symbol.event,
             symbol.operator,
-            symbol.type-parameter
+            symbol.type-parameter,
+            symbol.references,
+            symbol.callers
         `)
     })
 
@@ -58,7 +60,11 @@ describe('selectorCompletion', () => {
             commit,
             commit.diff,
             commit.diff.added,
-            commit.diff.removed
+            commit.diff.removed,
+            commit.histogram,
+            commit.histogram.day,
+            commit.histogram.week,
+            commit.histogram.month
         `)
     })
 })
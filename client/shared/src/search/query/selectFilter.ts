@@ -45,11 +45,22 @@ export const SELECTORS: Access[] = [
             { name: 'event' },
             { name: 'operator' },
             { name: 'type-parameter' },
+            { name: 'references' },
+            { name: 'callers' },
         ],
     },
     {
         name: 'commit',
-        fields: [{ name: 'diff', fields: [{ name: 'added' }, { name: 'removed' }] }],
+        fields: [
+            { name: 'diff', fields: [{ name: 'added' }, { name: 'removed' }] },
+            {
+                name: 'histogram',
+                fields: ['day', 'week', 'month'].map(name => ({
+                    name,
+                    fields: [{ name: 'repo' }, { name: 'author' }],
+                })),
+            },
+        ],
     },
 ]
 const kinds = new Set(SELECTORS.map(value => value.name))
@@ -49,6 +49,8 @@ export interface PathMatch {
     commit?: string
     language?: string
     debug?: string
+    /** Only set if ranking explanations were requested. */
+    explanation?: RankingExplanation
 }
 
 export interface ContentMatch {
@@ -65,6 +67,8 @@ export interface ContentMatch {
     hunks?: DecoratedHunk[]
     language?: string
     debug?: string
+    /** Only set if ranking explanations were requested. */
+    explanation?: RankingExplanation
 }
 
 export interface DecoratedHunk {
@@ -107,6 +111,25 @@ export interface ChunkMatch {
      * This can only be true when maxLineLength search option is non-zero.
      */
     contentTruncated?: boolean
+
+    /** Only set if ranking explanations were requested and the backend scores chunks. */
+    explanation?: RankingExplanation
+}
+
+/** Explains the score a backend assigned to a match. */
+export interface RankingExplanation {
+    backend: 'zoekt' | 'searcher'
+    scorer?: 'default' | 'bm25'
+    score: number
+    components?: ScoreComponent[]
+}
+
+/** One of the signals which make up a score. */
+export interface ScoreComponent {
+    name: string
+    value: number
+    raw?: number
+    description?: string
 }
 
 export interface SymbolMatch {
@@ -120,6 +143,8 @@ export interface SymbolMatch {
     symbols: MatchedSymbol[]
     language?: string
     debug?: string
+    /** Only set if ranking explanations were requested. */
+    explanation?: RankingExplanation
 }
 
 export interface MatchedSymbol {
@@ -155,6 +180,28 @@ export interface CommitMatch {
     ranges: number[][]
 }
 
+/**
+ * The number of commits in a repository matching a select:commit.histogram
+ * search per time bucket. It is not part of SearchMatch since the result
+ * list doesn't render histograms.
+ */
+export interface CommitHistogramMatch {
+    type: 'commit-histogram'
+    repository: string
+    repositoryID: number
+    interval: 'day' | 'week' | 'month'
+    /** Only set if the histogram is grouped by author. */
+    authorName?: string
+    authorEmail?: string
+    /** The non-empty buckets, ordered by start. */
+    buckets: CommitHistogramBucket[]
+}
+
+export interface CommitHistogramBucket {
+    start: string
+    count: number
+}
+
 export interface RepositoryMatch {
     type: 'repo'
     repository: string
@@ -308,7 +355,7 @@ export interface Alert {
 }
 
 // Same key values from internal/search/alert.go
-export type AnnotationName = 'ResultCount'
+export type AnnotationName = 'ResultCount' | 'SmartSearchRules'
 
 export interface ProposedQuery {
     description?: string | null
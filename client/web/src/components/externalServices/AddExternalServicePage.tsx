@@ -45,6 +45,9 @@ const v2ExternalServiceKinds: { [key in ExternalServiceKind]: number } = {
     PYTHONPACKAGES: 16,
     RUBYPACKAGES: 17,
     RUSTPACKAGES: 18,
+    DOTNETPACKAGES: 19,
+    PHPPACKAGES: 20,
+    ELIXIRPACKAGES: 21,
 }
 
 /**
@@ -6,13 +6,16 @@ import BitbucketIcon from 'mdi-react/BitbucketIcon'
 import GithubIcon from 'mdi-react/GithubIcon'
 import GitIcon from 'mdi-react/GitIcon'
 import GitLabIcon from 'mdi-react/GitlabIcon'
+import LanguageCsharpIcon from 'mdi-react/LanguageCsharpIcon'
 import LanguageGoIcon from 'mdi-react/LanguageGoIcon'
 import LanguageJavaIcon from 'mdi-react/LanguageJavaIcon'
+import LanguagePhpIcon from 'mdi-react/LanguagePhpIcon'
 import LanguagePythonIcon from 'mdi-react/LanguagePythonIcon'
 import LanguageRubyIcon from 'mdi-react/LanguageRubyIcon'
 import LanguageRustIcon from 'mdi-react/LanguageRustIcon'
 import AzureDevOpsIcon from 'mdi-react/MicrosoftAzureDevopsIcon'
 import NpmIcon from 'mdi-react/NpmIcon'
+import PackageVariantClosedIcon from 'mdi-react/PackageVariantClosedIcon'
 
 import { PerforceIcon, PhabricatorIcon } from '@sourcegraph/shared/src/components/icons'
 import { Link, Code, Text } from '@sourcegraph/wildcard'
@@ -21,6 +24,8 @@ import awsCodeCommitSchemaJSON from '../../../../../schema/aws_codecommit.schema
 import azureDevOpsSchemaJSON from '../../../../../schema/azuredevops.schema.json'
 import bitbucketCloudSchemaJSON from '../../../../../schema/bitbucket_cloud.schema.json'
 import bitbucketServerSchemaJSON from '../../../../../schema/bitbucket_server.schema.json'
+import dotnetPackagesSchemaJSON from '../../../../../schema/dotnet-packages.schema.json'
+import elixirPackagesSchemaJSON from '../../../../../schema/elixir-packages.schema.json'
 import gerritSchemaJSON from '../../../../../schema/gerrit.schema.json'
 import githubSchemaJSON from '../../../../../schema/github.schema.json'
 import gitlabSchemaJSON from '../../../../../schema/gitlab.schema.json'
@@ -32,6 +37,7 @@ import otherExternalServiceSchemaJSON from '../../../../../schema/other_external
 import pagureSchemaJSON from '../../../../../schema/pagure.schema.json'
 import perforceSchemaJSON from '../../../../../schema/perforce.schema.json'
 import phabricatorSchemaJSON from '../../../../../schema/phabricator.schema.json'
+import phpPackagesSchemaJSON from '../../../../../schema/php-packages.schema.json'
 import pythonPackagesJSON from '../../../../../schema/python-packages.schema.json'
 import rubyPackagesSchemaJSON from '../../../../../schema/ruby-packages.schema.json'
 import rustPackagesJSON from '../../../../../schema/rust-packages.schema.json'
@@ -1579,6 +1585,105 @@ const RUBY_PACKAGES: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const DOTNET_PACKAGES: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.DOTNETPACKAGES,
+    title: '.NET Dependencies',
+    icon: LanguageCsharpIcon,
+    jsonSchema: dotnetPackagesSchemaJSON,
+    defaultDisplayName: '.NET Dependencies',
+    defaultConfig: `{
+  "repository": "https://api.nuget.org/v3/index.json",
+  "dependencies": ["Newtonsoft.Json@13.0.3"]
+}`,
+    Instructions: () => (
+        <div>
+            <ol>
+                <li>
+                    Set <Code>"repository"</Code> to the service index URL of a NuGet V3 feed. The URL
+                    https://api.nuget.org/v3/index.json is used if the field is empty.
+                </li>
+                <li>
+                    Use the syntax <Code>"PACKAGE_ID@VERSION"</Code> to list a dependency for the{' '}
+                    <Code>"dependencies"</Code> field.
+                </li>
+                <li>
+                    The field <Code>"repository"</Code> is redacted because it can include <Code>admin:password</Code>{' '}
+                    credentials.
+                </li>
+            </ol>
+            <Text>⚠️ .NET package repositories are visible by all users of the Sourcegraph instance.</Text>
+            <Text>⚠️ It is only possible to register one .NET packages code host per Sourcegraph instance.</Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
+const PHP_PACKAGES: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.PHPPACKAGES,
+    title: 'PHP Dependencies',
+    icon: LanguagePhpIcon,
+    jsonSchema: phpPackagesSchemaJSON,
+    defaultDisplayName: 'PHP Dependencies',
+    defaultConfig: `{
+  "repository": "https://repo.packagist.org",
+  "dependencies": ["monolog/monolog@3.5.0"]
+}`,
+    Instructions: () => (
+        <div>
+            <ol>
+                <li>
+                    Set <Code>"repository"</Code> to the URL of a Composer repository. The URL https://repo.packagist.org
+                    is used if the field is empty.
+                </li>
+                <li>
+                    Use the syntax <Code>"VENDOR/PACKAGE@VERSION"</Code> to list a dependency for the{' '}
+                    <Code>"dependencies"</Code> field.
+                </li>
+                <li>
+                    The field <Code>"repository"</Code> is redacted because it can include <Code>admin:password</Code>{' '}
+                    credentials.
+                </li>
+            </ol>
+            <Text>⚠️ PHP package repositories are visible by all users of the Sourcegraph instance.</Text>
+            <Text>⚠️ It is only possible to register one PHP packages code host per Sourcegraph instance.</Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
+const ELIXIR_PACKAGES: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.ELIXIRPACKAGES,
+    title: 'Elixir Dependencies',
+    icon: PackageVariantClosedIcon,
+    jsonSchema: elixirPackagesSchemaJSON,
+    defaultDisplayName: 'Elixir Dependencies',
+    defaultConfig: `{
+  "repository": "https://repo.hex.pm",
+  "dependencies": ["phoenix@1.7.10"]
+}`,
+    Instructions: () => (
+        <div>
+            <ol>
+                <li>
+                    Set <Code>"repository"</Code> to the URL of a Hex repository. The URL https://repo.hex.pm is used if
+                    the field is empty.
+                </li>
+                <li>
+                    Use the syntax <Code>"PACKAGE@VERSION"</Code> to list a dependency for the{' '}
+                    <Code>"dependencies"</Code> field.
+                </li>
+                <li>
+                    The field <Code>"repository"</Code> is redacted because it can include <Code>admin:password</Code>{' '}
+                    credentials.
+                </li>
+            </ol>
+            <Text>⚠️ Elixir package repositories are visible by all users of the Sourcegraph instance.</Text>
+            <Text>⚠️ It is only possible to register one Elixir packages code host per Sourcegraph instance.</Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
 export const codeHostExternalServices: Record<string, AddExternalServiceOptions> = {
     github: GITHUB,
     ghapp: GITHUB_APP,
@@ -1600,6 +1705,9 @@ export const nonCodeHostExternalServices: Record<string, AddExternalServiceOptio
     ...(window.context?.experimentalFeatures?.pythonPackages === 'enabled' ? { pythonPackages: PYTHON_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.rustPackages === 'enabled' ? { rustPackages: RUST_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.rubyPackages === 'enabled' ? { rubyPackages: RUBY_PACKAGES } : {}),
+    ...(window.context?.experimentalFeatures?.dotnetPackages === 'enabled' ? { dotnetPackages: DOTNET_PACKAGES } : {}),
+    ...(window.context?.experimentalFeatures?.phpPackages === 'enabled' ? { phpPackages: PHP_PACKAGES } : {}),
+    ...(window.context?.experimentalFeatures?.elixirPackages === 'enabled' ? { elixirPackages: ELIXIR_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.goPackages === 'enabled' ? { goModules: GO_MODULES } : {}),
     ...(window.context?.experimentalFeatures?.jvmPackages === 'enabled' ? { jvmPackages: JVM_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.npmPackages === 'enabled' ? { npmPackages: NPM_PACKAGES } : {}),
@@ -1629,6 +1737,9 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.PYTHONPACKAGES]: PYTHON_PACKAGES,
     [ExternalServiceKind.RUSTPACKAGES]: RUST_PACKAGES,
     [ExternalServiceKind.RUBYPACKAGES]: RUBY_PACKAGES,
+    [ExternalServiceKind.DOTNETPACKAGES]: DOTNET_PACKAGES,
+    [ExternalServiceKind.PHPPACKAGES]: PHP_PACKAGES,
+    [ExternalServiceKind.ELIXIRPACKAGES]: ELIXIR_PACKAGES,
 }
 
 export const externalRepoIcon = (
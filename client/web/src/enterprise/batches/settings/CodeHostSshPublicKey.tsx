@@ -29,6 +29,9 @@ const configInstructionLinks: Record<ExternalServiceKind, string> = {
     [ExternalServiceKind.PYTHONPACKAGES]: 'unsupported',
     [ExternalServiceKind.RUSTPACKAGES]: 'unsupported',
     [ExternalServiceKind.RUBYPACKAGES]: 'unsupported',
+    [ExternalServiceKind.DOTNETPACKAGES]: 'unsupported',
+    [ExternalServiceKind.PHPPACKAGES]: 'unsupported',
+    [ExternalServiceKind.ELIXIRPACKAGES]: 'unsupported',
 }
 
 export interface CodeHostSshPublicKeyProps {
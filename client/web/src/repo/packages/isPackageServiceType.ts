@@ -13,7 +13,10 @@ export function isPackageServiceType(serviceType?: string): boolean {
         case 'pythonPackages':
         case 'rubyPackages':
         case 'goModules':
-        case 'rustPackages': {
+        case 'rustPackages':
+        case 'dotnetPackages':
+        case 'phpPackages':
+        case 'elixirPackages': {
             return true
         }
         default: {
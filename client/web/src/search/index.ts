@@ -40,7 +40,8 @@ export function parseSearchURLPatternType(query: string): SearchPatternType | un
         case SearchPatternType.structural:
         case SearchPatternType.lucky:
         case SearchPatternType.codycontext:
-        case SearchPatternType.keyword: {
+        case SearchPatternType.keyword:
+        case SearchPatternType.semantic: {
             return patternType
         }
     }
@@ -285,6 +285,7 @@ export const NewSearchContent: FC<NewSearchContentProps> = props => {
                                 alert={results?.alert}
                                 onDisableSmartSearch={onDisableSmartSearch}
                                 className="m-2"
+                                telemetryRecorder={telemetryRecorder}
                             />
                         )}
 
@@ -2,6 +2,7 @@ import type { Meta, StoryFn } from '@storybook/react'
 
 import type { AggregateStreamingSearchResults } from '@sourcegraph/shared/src/search/stream'
 import { MockTemporarySettings } from '@sourcegraph/shared/src/settings/temporary/testUtils'
+import { noOpTelemetryRecorder } from '@sourcegraph/shared/src/telemetry'
 import { H2 } from '@sourcegraph/wildcard'
 
 import { WebStory } from '../../components/WebStory'
@@ -81,25 +82,49 @@ export const DefaultStory: StoryFn = () => (
         {() => (
             <div style={{ padding: '1rem' }}>
                 <H2>One item, additional results</H2>
-                <SmartSearch alert={oneItemAdditionalAlert} onDisableSmartSearch={() => {}} />
+                <SmartSearch
+                    alert={oneItemAdditionalAlert}
+                    onDisableSmartSearch={() => {}}
+                    telemetryRecorder={noOpTelemetryRecorder}
+                />
 
                 <H2>One item, pure results</H2>
-                <SmartSearch alert={oneItemPureAlert} onDisableSmartSearch={() => {}} />
+                <SmartSearch
+                    alert={oneItemPureAlert}
+                    onDisableSmartSearch={() => {}}
+                    telemetryRecorder={noOpTelemetryRecorder}
+                />
 
                 <H2>Many items, additional results</H2>
-                <SmartSearch alert={twoItemAdditionalAlert} onDisableSmartSearch={() => {}} />
+                <SmartSearch
+                    alert={twoItemAdditionalAlert}
+                    onDisableSmartSearch={() => {}}
+                    telemetryRecorder={noOpTelemetryRecorder}
+                />
 
                 <H2>Many items, pure results</H2>
-                <SmartSearch alert={twoItemPureAlert} onDisableSmartSearch={() => {}} />
+                <SmartSearch
+                    alert={twoItemPureAlert}
+                    onDisableSmartSearch={() => {}}
+                    telemetryRecorder={noOpTelemetryRecorder}
+                />
 
                 <H2>Collapsed, additional results</H2>
                 <MockTemporarySettings settings={{ 'search.results.collapseSmartSearch': true }}>
-                    <SmartSearch alert={oneItemAdditionalAlert} onDisableSmartSearch={() => {}} />
+                    <SmartSearch
+                        alert={oneItemAdditionalAlert}
+                        onDisableSmartSearch={() => {}}
+                        telemetryRecorder={noOpTelemetryRecorder}
+                    />
                 </MockTemporarySettings>
 
                 <H2>Collapsed, pure results</H2>
                 <MockTemporarySettings settings={{ 'search.results.collapseSmartSearch': true }}>
-                    <SmartSearch alert={oneItemPureAlert} onDisableSmartSearch={() => {}} />
+                    <SmartSearch
+                        alert={oneItemPureAlert}
+                        onDisableSmartSearch={() => {}}
+                        telemetryRecorder={noOpTelemetryRecorder}
+                    />
                 </MockTemporarySettings>
             </div>
         )}
@@ -8,9 +8,11 @@ import { pluralize, SourcegraphURL } from '@sourcegraph/common'
 import type {
     AggregateStreamingSearchResults,
     AlertKind,
+    ProposedQuery,
     SmartSearchAlertKind,
 } from '@sourcegraph/shared/src/search/stream'
 import { useTemporarySetting } from '@sourcegraph/shared/src/settings/temporary/useTemporarySetting'
+import type { TelemetryV2Props } from '@sourcegraph/shared/src/telemetry'
 import {
     Icon,
     Collapse,
@@ -27,7 +29,7 @@ import { SearchPatternType } from '../../graphql-operations'
 
 import styles from './QuerySuggestion.module.scss'
 
-interface SmartSearchProps {
+interface SmartSearchProps extends TelemetryV2Props {
     alert: Required<AggregateStreamingSearchResults>['alert'] | undefined
     onDisableSmartSearch: () => void
     className?: string
@@ -68,6 +70,7 @@ export const SmartSearch: React.FunctionComponent<React.PropsWithChildren<SmartS
     alert,
     onDisableSmartSearch,
     className,
+    telemetryRecorder,
 }) => {
     const [isCollapsed, setIsCollapsed] = useTemporarySetting('search.results.collapseSmartSearch')
 
@@ -79,6 +82,18 @@ export const SmartSearch: React.FunctionComponent<React.PropsWithChildren<SmartS
         [onDisableSmartSearch]
     )
 
+    const onProposedQueryClick = useCallback(
+        (entry: ProposedQuery) => {
+            // Names of the Smart Search rules from the site configuration that produced the query, if any.
+            const rules = entry.annotations?.find(({ name }) => name === 'SmartSearchRules')?.value
+            telemetryRecorder.recordEvent('search.smartSearch.proposedQuery', 'click', {
+                metadata: { customRules: rules ? rules.split(',').length : 0 },
+                privateMetadata: rules ? { rules } : undefined,
+            })
+        },
+        [telemetryRecorder]
+    )
+
     if (
         !alert?.kind ||
         (alert.kind !== 'smart-search-additional-results' && alert.kind !== 'smart-search-pure-results')
@@ -138,6 +153,7 @@ export const SmartSearch: React.FunctionComponent<React.PropsWithChildren<SmartS
                                         })
                                     )}
                                     className={styles.link}
+                                    onClick={() => onProposedQueryClick(entry)}
                                 >
                                     <Text className="mb-0">
                                         <span className={styles.listItemDescription}>
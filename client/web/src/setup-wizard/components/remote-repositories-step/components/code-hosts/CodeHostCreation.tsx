@@ -43,6 +43,9 @@ const v2CodeHostKind: { [key in ExternalServiceKind]: number } = {
     PYTHONPACKAGES: 15,
     RUBYPACKAGES: 16,
     RUSTPACKAGES: 17,
+    DOTNETPACKAGES: 18,
+    PHPPACKAGES: 19,
+    ELIXIRPACKAGES: 20,
 }
 
 /**
@@ -4,4 +4,7 @@ export const isPackagesEnabled = (): boolean =>
     window.context?.experimentalFeatures?.jvmPackages === 'enabled' ||
     window.context?.experimentalFeatures?.rubyPackages === 'enabled' ||
     window.context?.experimentalFeatures?.pythonPackages === 'enabled' ||
-    window.context?.experimentalFeatures?.rustPackages === 'enabled'
+    window.context?.experimentalFeatures?.rustPackages === 'enabled' ||
+    window.context?.experimentalFeatures?.dotnetPackages === 'enabled' ||
+    window.context?.experimentalFeatures?.phpPackages === 'enabled' ||
+    window.context?.experimentalFeatures?.elixirPackages === 'enabled'
@@ -33,6 +33,18 @@ export const ExternalServicePackageMap: Partial<
         label: 'Rust',
         value: PackageRepoReferenceKind.RUSTPACKAGES,
     },
+    [ExternalServiceKind.DOTNETPACKAGES]: {
+        label: '.NET',
+        value: PackageRepoReferenceKind.DOTNETPACKAGES,
+    },
+    [ExternalServiceKind.PHPPACKAGES]: {
+        label: 'PHP',
+        value: PackageRepoReferenceKind.PHPPACKAGES,
+    },
+    [ExternalServiceKind.ELIXIRPACKAGES]: {
+        label: 'Elixir',
+        value: PackageRepoReferenceKind.ELIXIRPACKAGES,
+    },
 }
 
 export const PackageExternalServiceMap: Partial<
@@ -68,4 +80,16 @@ export const PackageExternalServiceMap: Partial<
         label: 'Rust',
         value: ExternalServiceKind.RUSTPACKAGES,
     },
+    [PackageRepoReferenceKind.DOTNETPACKAGES]: {
+        label: '.NET',
+        value: ExternalServiceKind.DOTNETPACKAGES,
+    },
+    [PackageRepoReferenceKind.PHPPACKAGES]: {
+        label: 'PHP',
+        value: ExternalServiceKind.PHPPACKAGES,
+    },
+    [PackageRepoReferenceKind.ELIXIRPACKAGES]: {
+        label: 'Elixir',
+        value: ExternalServiceKind.ELIXIRPACKAGES,
+    },
 }
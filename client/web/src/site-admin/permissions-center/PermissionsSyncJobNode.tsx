@@ -69,6 +69,7 @@ const JOB_REASON_TO_READABLE_REASON: Record<PermissionsSyncJobReason, string> =
     REASON_USER_ADDED: 'New user added',
     REASON_EXTERNAL_ACCOUNT_ADDED: 'Third-party login service added for the user',
     REASON_EXTERNAL_ACCOUNT_DELETED: 'Third-party login service removed for the user',
+    REASON_USER_PERMS_FRESHNESS_SLO_BREACHED: 'User permissions exceeded the freshness SLO',
 }
 
 export const JOB_STATE_METADATA_MAPPING: Record<PermissionsSyncJobState, JobStateMetadata> = {
@@ -43,6 +43,9 @@ type Services struct {
 	SearchJobsDataExportHandler http.Handler
 	SearchJobsLogsHandler       http.Handler
 
+	// Handler for exporting the difference between saved search snapshots.
+	SavedSearchSnapshotDiffExportHandler http.Handler
+
 	// Handler for completions stream.
 	NewChatCompletionsStreamHandler NewChatCompletionsStreamHandler
 
@@ -121,6 +124,8 @@ func DefaultServices() Services {
 		NewCodeCompletionsHandler:       func() http.Handler { return makeNotFoundHandler("code completions streaming endpoint") },
 		SearchJobsDataExportHandler:     makeNotFoundHandler("search jobs data export handler"),
 		SearchJobsLogsHandler:           makeNotFoundHandler("search jobs logs handler"),
+
+		SavedSearchSnapshotDiffExportHandler: makeNotFoundHandler("saved search snapshot diff export handler"),
 	}
 }
 
@@ -29,6 +29,7 @@ type AuthzResolver interface {
 	AuthzProviderTypes(ctx context.Context) ([]string, error)
 	PermissionsSyncJobs(ctx context.Context, args ListPermissionsSyncJobsArgs) (*graphqlutil.ConnectionResolver[PermissionsSyncJobResolver], error)
 	PermissionsSyncingStats(ctx context.Context) (PermissionsSyncingStatsResolver, error)
+	PermissionsFreshnessReport(ctx context.Context, args *PermissionsFreshnessReportArgs) (PermissionsFreshnessReportResolver, error)
 
 	// RepositoryPermissionsInfo and UserPermissionsInfo are helpers functions.
 	RepositoryPermissionsInfo(ctx context.Context, repoID graphql.ID) (PermissionsInfoResolver, error)
@@ -172,3 +173,27 @@ type PermissionsSyncingStatsResolver interface {
 	UsersWithStalePermissions(ctx context.Context) (int32, error)
 	ReposWithStalePermissions(ctx context.Context) (int32, error)
 }
+
+type PermissionsFreshnessReportArgs struct {
+	First int32
+}
+
+type PermissionsFreshnessReportResolver interface {
+	MaxAgeSeconds() int32
+	UsersBreachingSLOCount(ctx context.Context) (int32, error)
+	ReposBreachingSLOCount(ctx context.Context) (int32, error)
+	UsersBreachingSLO(ctx context.Context) ([]UserPermissionsFreshnessResolver, error)
+	ReposBreachingSLO(ctx context.Context) ([]RepositoryPermissionsFreshnessResolver, error)
+}
+
+type UserPermissionsFreshnessResolver interface {
+	User(ctx context.Context) (*UserResolver, error)
+	LastSyncedAt() *gqlutil.DateTime
+	SyncPending() bool
+}
+
+type RepositoryPermissionsFreshnessResolver interface {
+	Repository() *RepositoryResolver
+	LastSyncedAt() *gqlutil.DateTime
+	SyncPending() bool
+}
@@ -669,6 +669,7 @@ type BatchChangeResolver interface {
 	CreatedAt() gqlutil.DateTime
 	UpdatedAt() gqlutil.DateTime
 	ChangesetsStats(ctx context.Context) (ChangesetsStatsResolver, error)
+	Stages(ctx context.Context) ([]BatchChangeStageResolver, error)
 	Changesets(ctx context.Context, args *ListChangesetsArgs) (ChangesetsConnectionResolver, error)
 	ChangesetCountsOverTime(ctx context.Context, args *ChangesetCountsArgs) ([]ChangesetCountsResolver, error)
 	ClosedAt() *gqlutil.DateTime
@@ -751,6 +752,17 @@ type ChangesetsStatsResolver interface {
 	PercentComplete() int32
 }
 
+type BatchChangeStageResolver interface {
+	Name() string
+	Index() int32
+	Total() int32
+	Merged() int32
+	Unpublished() int32
+	Waiting() int32
+	IsCompleted() bool
+	IsBlocked() bool
+}
+
 type ChangesetsConnectionResolver interface {
 	Nodes(ctx context.Context) ([]ChangesetResolver, error)
 	TotalCount(ctx context.Context) (int32, error)
@@ -823,6 +835,7 @@ type ExternalChangesetResolver interface {
 	Error() *string
 	SyncerError() *string
 	ScheduleEstimateAt(ctx context.Context) (*gqlutil.DateTime, error)
+	WaitingForStage() *int32
 
 	CurrentSpec(ctx context.Context) (VisibleChangesetSpecResolver, error)
 }
@@ -33,6 +33,10 @@ var externalServiceToPackageSchemeMap = map[string]string{
 	extsvc.KindPythonPackages: dependencies.PythonPackagesScheme,
 	extsvc.KindRustPackages:   dependencies.RustPackagesScheme,
 	extsvc.KindRubyPackages:   dependencies.RubyPackagesScheme,
+
+	extsvc.KindDotNetPackages: dependencies.DotNetPackagesScheme,
+	extsvc.KindPHPPackages:    dependencies.PHPPackagesScheme,
+	extsvc.KindElixirPackages: dependencies.ElixirPackagesScheme,
 }
 
 var packageSchemeToExternalServiceMap = map[string]string{
@@ -42,6 +46,9 @@ var packageSchemeToExternalServiceMap = map[string]string{
 	dependencies.PythonPackagesScheme: extsvc.KindPythonPackages,
 	dependencies.RustPackagesScheme:   extsvc.KindRustPackages,
 	dependencies.RubyPackagesScheme:   extsvc.KindRubyPackages,
+	dependencies.DotNetPackagesScheme: extsvc.KindDotNetPackages,
+	dependencies.PHPPackagesScheme:    extsvc.KindPHPPackages,
+	dependencies.ElixirPackagesScheme: extsvc.KindElixirPackages,
 }
 
 func (r *schemaResolver) PackageRepoReferences(ctx context.Context, args *PackageRepoReferenceConnectionArgs) (_ *packageRepoReferenceConnectionResolver, err error) {
@@ -252,6 +259,16 @@ func dependencyRepoToRepoName(dep dependencies.PackageRepoReference) (repoName a
 			return "", err
 		}
 		repoName = pkg.RepoName()
+	case "scip-dotnet":
+		repoName = reposource.ParseDotNetPackageFromName(dep.Name).RepoName()
+	case "scip-php":
+		pkg, err := reposource.ParsePHPPackageFromName(dep.Name)
+		if err != nil {
+			return "", err
+		}
+		repoName = pkg.RepoName()
+	case "hex":
+		repoName = reposource.ParseElixirPackageFromName(dep.Name).RepoName()
 	}
 
 	return repoName, nil
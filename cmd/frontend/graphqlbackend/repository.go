@@ -577,6 +577,10 @@ func (r *RepositoryResolver) PreviewGitObjectFilter(ctx context.Context, args *r
 	return EnterpriseResolvers.codeIntelResolver.PreviewGitObjectFilter(ctx, r.ID(), args)
 }
 
+func (r *RepositoryResolver) SimulateCodeIntelligenceConfigurationPolicies(ctx context.Context, args *resolverstubs.SimulateCodeIntelligenceConfigurationPoliciesArgs) (resolverstubs.CodeIntelligenceConfigurationPolicySimulationResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.SimulateCodeIntelligenceConfigurationPolicies(ctx, r.ID(), args)
+}
+
 type AuthorizedUserArgs struct {
 	RepositoryID graphql.ID
 	Permission   string
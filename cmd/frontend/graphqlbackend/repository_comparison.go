@@ -17,6 +17,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gosyntect"
@@ -197,6 +198,49 @@ func (r *RepositoryComparisonResolver) FileDiffs(ctx context.Context, args *File
 	), nil
 }
 
+type RepositoryComparisonBlastRadiusArgs struct {
+	ReferencesFirst *int32
+}
+
+// BlastRadius returns the symbols whose definitions are modified or removed by
+// the comparison. The diff is applied to the merge-base of the comparison, so
+// that is the revision definitions are read from.
+func (r *RepositoryComparisonResolver) BlastRadius(ctx context.Context, args *RepositoryComparisonBlastRadiusArgs) ([]resolverstubs.AffectedSymbolResolver, error) {
+	if r.base == nil || r.head == nil {
+		// Nothing is defined in an empty base, or removed by an empty head.
+		return []resolverstubs.AffectedSymbolResolver{}, nil
+	}
+
+	return EnterpriseResolvers.codeIntelResolver.DiffBlastRadius(ctx, &resolverstubs.DiffBlastRadiusArgs{
+		Repository:      r.repo.ID(),
+		Base:            string(r.base.OID()),
+		Head:            string(r.head.OID()),
+		ReferencesFirst: args.ReferencesFirst,
+	})
+}
+
+type RepositoryComparisonAPIDiffArgs struct {
+	Root    string
+	Indexer *string
+}
+
+// APIDiff compares the public API of the precise indexes closest to the
+// merge-base and the head of the comparison.
+func (r *RepositoryComparisonResolver) APIDiff(ctx context.Context, args *RepositoryComparisonAPIDiffArgs) (resolverstubs.PreciseIndexAPIDiffResolver, error) {
+	if r.base == nil || r.head == nil {
+		// There is no API to compare against an empty revision.
+		return nil, nil
+	}
+
+	return EnterpriseResolvers.codeIntelResolver.CommitAPIDiff(ctx, &resolverstubs.CommitAPIDiffArgs{
+		Repository: r.repo.ID(),
+		Base:       string(r.base.OID()),
+		Head:       string(r.head.OID()),
+		Root:       args.Root,
+		Indexer:    args.Indexer,
+	})
+}
+
 // repositoryComparisonNewFile is the default NewFileFunc used by
 // RepositoryComparisonResolver to produce the new file in a FileDiffResolver.
 func repositoryComparisonNewFile(db database.DB, r *fileDiffResolver) FileResolver {
@@ -17,6 +17,7 @@ type SavedSearchesResolver interface {
 	// Query
 	SavedSearches(ctx context.Context, args SavedSearchesArgs) (*SavedSearchConnectionResolver, error)
 	SavedSearchByID(ctx context.Context, id graphql.ID) (SavedSearchResolver, error)
+	SavedSearchSnapshotDiff(ctx context.Context, args *SavedSearchSnapshotDiffArgs) (SavedSearchSnapshotDiffResolver, error)
 
 	// Mutations
 	CreateSavedSearch(ctx context.Context, args *CreateSavedSearchArgs) (SavedSearchResolver, error)
@@ -24,6 +25,8 @@ type SavedSearchesResolver interface {
 	DeleteSavedSearch(ctx context.Context, args *DeleteSavedSearchArgs) (*EmptyResponse, error)
 	TransferSavedSearchOwnership(ctx context.Context, args *TransferSavedSearchOwnershipArgs) (SavedSearchResolver, error)
 	ChangeSavedSearchVisibility(ctx context.Context, args *ChangeSavedSearchVisibilityArgs) (SavedSearchResolver, error)
+	CreateSavedSearchSnapshot(ctx context.Context, args *CreateSavedSearchSnapshotArgs) (SavedSearchSnapshotResolver, error)
+	SetSavedSearchSnapshotInterval(ctx context.Context, args *SetSavedSearchSnapshotIntervalArgs) (SavedSearchResolver, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -61,6 +64,35 @@ type SavedSearchResolver interface {
 	UpdatedAt() gqlutil.DateTime
 	URL() string
 	ViewerCanAdminister(context.Context) bool
+	SnapshotIntervalHours() *int32
+	Snapshots(context.Context, *SavedSearchSnapshotsArgs) ([]SavedSearchSnapshotResolver, error)
+}
+
+type SavedSearchSnapshotResolver interface {
+	ID() graphql.ID
+	SavedSearch(context.Context) (SavedSearchResolver, error)
+	Query() string
+	EntryCount() int32
+	LimitHit() bool
+	CreatedBy(context.Context) (*UserResolver, error)
+	CreatedAt() gqlutil.DateTime
+}
+
+type SavedSearchSnapshotDiffResolver interface {
+	Base() SavedSearchSnapshotResolver
+	Head() SavedSearchSnapshotResolver
+	Added(*SavedSearchSnapshotEntriesArgs) []SavedSearchSnapshotEntryResolver
+	Removed(*SavedSearchSnapshotEntriesArgs) []SavedSearchSnapshotEntryResolver
+	AddedCount() int32
+	RemovedCount() int32
+	ExportURL() string
+}
+
+type SavedSearchSnapshotEntryResolver interface {
+	Repository() string
+	Path() *string
+	LineNumber() *int32
+	Line() *string
 }
 
 type SavedSearchesArgs struct {
@@ -108,3 +140,25 @@ type ChangeSavedSearchVisibilityArgs struct {
 	ID            graphql.ID
 	NewVisibility SavedSearchVisibility
 }
+
+type CreateSavedSearchSnapshotArgs struct {
+	SavedSearch graphql.ID
+}
+
+type SetSavedSearchSnapshotIntervalArgs struct {
+	ID            graphql.ID
+	IntervalHours *int32
+}
+
+type SavedSearchSnapshotsArgs struct {
+	First int32
+}
+
+type SavedSearchSnapshotDiffArgs struct {
+	Base graphql.ID
+	Head graphql.ID
+}
+
+type SavedSearchSnapshotEntriesArgs struct {
+	First int32
+}
@@ -51,6 +51,10 @@ type SearchContextResolver interface {
 	ViewerHasStarred(ctx context.Context) bool
 	Repositories(ctx context.Context) ([]SearchContextRepositoryRevisionsResolver, error)
 	Query() string
+	RefreshIntervalHours() *int32
+	RevisionRules() []SearchContextRevisionRuleResolver
+	LastRefreshedAt() *gqlutil.DateTime
+	Refreshes(ctx context.Context, args *SearchContextRefreshesArgs) ([]SearchContextRefreshResolver, error)
 }
 
 type SearchContextConnectionResolver interface {
@@ -64,19 +68,52 @@ type SearchContextRepositoryRevisionsResolver interface {
 	Revisions() []string
 }
 
+type SearchContextRevisionRuleResolver interface {
+	Repo() string
+	Tag() *string
+	Branch() *string
+}
+
+type SearchContextRefreshResolver interface {
+	CreatedAt() gqlutil.DateTime
+	RepositoryCount() int32
+	Changes() []SearchContextRefreshChangeResolver
+	Error() *string
+}
+
+type SearchContextRefreshChangeResolver interface {
+	Repository() *RepositoryResolver
+	Revisions() []string
+	PreviousRevisions() []string
+}
+
+type SearchContextRefreshesArgs struct {
+	First int32
+}
+
 type SearchContextInputArgs struct {
-	Name        string
-	Description string
-	Public      bool
-	Namespace   *graphql.ID
-	Query       string
+	Name                 string
+	Description          string
+	Public               bool
+	Namespace            *graphql.ID
+	Query                string
+	RefreshIntervalHours *int32
+	RevisionRules        *[]SearchContextRevisionRuleInputArgs
 }
 
 type SearchContextEditInputArgs struct {
-	Name        string
-	Description string
-	Public      bool
-	Query       string
+	Name                 string
+	Description          string
+	Public               bool
+	Query                string
+	RefreshIntervalHours *int32
+	RevisionRules        *[]SearchContextRevisionRuleInputArgs
+}
+
+type SearchContextRevisionRuleInputArgs struct {
+	Repo   string
+	Tag    *string
+	Branch *string
 }
 
 type SearchContextRepositoryRevisionsInputArgs struct {
@@ -158,6 +158,8 @@ func matchesToResolvers(db database.DB, matches []result.Match) []SearchResultRe
 			})
 		case *result.OwnerMatch:
 			// todo(own): add OwnerSearchResultResolver
+		case *result.CommitHistogramMatch:
+			// Commit histograms are only available through the streaming API.
 		}
 	}
 	return resolvers
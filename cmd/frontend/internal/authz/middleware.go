@@ -2,12 +2,15 @@ package authz
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/licensing/enforcement"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/authz/permssync"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/licensing"
 )
@@ -62,3 +65,47 @@ func PostAuthMiddleware(logger log.Logger, db database.DB, next http.Handler) ht
 		next.ServeHTTP(w, r)
 	})
 }
+
+// PermissionsFreshnessMiddleware enforces the permissions freshness SLO for
+// authenticated users. If a user's permissions are older than the SLO, a
+// just-in-time permissions sync is scheduled. Only repository, search and
+// content requests wait for the sync, and if it does not complete in time they
+// are restricted to public and unrestricted repositories.
+func PermissionsFreshnessMiddleware(logger log.Logger, db database.DB, next http.Handler) http.Handler {
+	logger = logger.Scoped("permissionsFreshness")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a := actor.FromContext(r.Context())
+		if !a.IsAuthenticated() || a.IsInternal() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if permssync.EnsureFreshPerms(r.Context(), logger, db, a.UID, requiresFreshPerms(r.URL.Path)) {
+			r = r.WithContext(authz.WithStalePermissions(r.Context()))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// freshPermsPathPrefixes are the API endpoints that serve repositories, search
+// results or file contents.
+var freshPermsPathPrefixes = []string{
+	"/.api/graphql",
+	"/.api/search/",
+	"/.api/compute/",
+	"/.api/blame/",
+	"/search/stream",
+}
+
+// requiresFreshPerms reports whether requests to the given path may wait for a
+// just-in-time permissions sync. Other requests, like settings pages and static
+// content, are never held back.
+func requiresFreshPerms(path string) bool {
+	for _, prefix := range freshPermsPathPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	// Raw file contents are served from /<repo>@<rev>/-/raw/<path>.
+	return strings.Contains(path, "/-/raw")
+}
@@ -0,0 +1,24 @@
+package authz
+
+import "testing"
+
+func TestRequiresFreshPerms(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/.api/graphql":                               true,
+		"/.api/search/stream":                         true,
+		"/.api/compute/stream":                        true,
+		"/.api/blame/github.com/a/b/-/stream/main.go": true,
+		"/search/stream":                              true,
+		"/github.com/a/b@main/-/raw/main.go":          true,
+		"/github.com/a/b/-/raw":                       true,
+		"/.api/client-config":                         false,
+		"/.api/src-cli/versions/latest":               false,
+		"/settings":                                   false,
+		"/github.com/a/b":                             false,
+		"/sign-out":                                   false,
+	} {
+		if have := requiresFreshPerms(path); have != want {
+			t.Errorf("requiresFreshPerms(%q): want %v, have %v", path, want, have)
+		}
+	}
+}
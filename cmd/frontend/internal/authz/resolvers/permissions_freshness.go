@@ -0,0 +1,173 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (r *Resolver) PermissionsFreshnessReport(ctx context.Context, args *graphqlbackend.PermissionsFreshnessReportArgs) (graphqlbackend.PermissionsFreshnessReportResolver, error) {
+	// 🚨 SECURITY: Only site admins can query the permissions freshness report.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	var maxAgeSeconds int
+	if slo := conf.Get().PermissionsFreshnessSLO; slo != nil && slo.MaxAgeSeconds > 0 {
+		maxAgeSeconds = slo.MaxAgeSeconds
+	}
+
+	return &permissionsFreshnessReportResolver{
+		db:            r.db,
+		maxAgeSeconds: maxAgeSeconds,
+		first:         int(args.First),
+	}, nil
+}
+
+type permissionsFreshnessReportResolver struct {
+	db            database.DB
+	maxAgeSeconds int
+	first         int
+
+	usersOnce  sync.Once
+	users      []*database.PermsFreshness
+	usersTotal int
+	usersErr   error
+
+	reposOnce  sync.Once
+	repos      []*database.PermsFreshness
+	reposTotal int
+	reposErr   error
+}
+
+func (r *permissionsFreshnessReportResolver) maxAge() time.Duration {
+	return time.Duration(r.maxAgeSeconds) * time.Second
+}
+
+func (r *permissionsFreshnessReportResolver) computeUsers(ctx context.Context) ([]*database.PermsFreshness, int, error) {
+	r.usersOnce.Do(func() {
+		if r.maxAgeSeconds == 0 {
+			return
+		}
+		r.users, r.usersTotal, r.usersErr = r.db.Perms().UsersBreachingPermsSLO(ctx, r.maxAge(), r.first)
+	})
+	return r.users, r.usersTotal, r.usersErr
+}
+
+func (r *permissionsFreshnessReportResolver) computeRepos(ctx context.Context) ([]*database.PermsFreshness, int, error) {
+	r.reposOnce.Do(func() {
+		if r.maxAgeSeconds == 0 {
+			return
+		}
+		r.repos, r.reposTotal, r.reposErr = r.db.Perms().ReposBreachingPermsSLO(ctx, r.maxAge(), r.first)
+	})
+	return r.repos, r.reposTotal, r.reposErr
+}
+
+func (r *permissionsFreshnessReportResolver) MaxAgeSeconds() int32 {
+	return int32(r.maxAgeSeconds)
+}
+
+func (r *permissionsFreshnessReportResolver) UsersBreachingSLOCount(ctx context.Context) (int32, error) {
+	_, total, err := r.computeUsers(ctx)
+	return int32(total), err
+}
+
+func (r *permissionsFreshnessReportResolver) ReposBreachingSLOCount(ctx context.Context) (int32, error) {
+	_, total, err := r.computeRepos(ctx)
+	return int32(total), err
+}
+
+func (r *permissionsFreshnessReportResolver) UsersBreachingSLO(ctx context.Context) ([]graphqlbackend.UserPermissionsFreshnessResolver, error) {
+	users, _, err := r.computeUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.UserPermissionsFreshnessResolver, 0, len(users))
+	for _, f := range users {
+		resolvers = append(resolvers, &userPermissionsFreshnessResolver{db: r.db, freshness: f})
+	}
+	return resolvers, nil
+}
+
+func (r *permissionsFreshnessReportResolver) ReposBreachingSLO(ctx context.Context) ([]graphqlbackend.RepositoryPermissionsFreshnessResolver, error) {
+	freshness, _, err := r.computeRepos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(freshness) == 0 {
+		return []graphqlbackend.RepositoryPermissionsFreshnessResolver{}, nil
+	}
+
+	ids := make([]api.RepoID, 0, len(freshness))
+	for _, f := range freshness {
+		ids = append(ids, api.RepoID(f.ID))
+	}
+	repos, err := r.db.Repos().GetByIDs(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	reposByID := make(map[api.RepoID]*types.Repo, len(repos))
+	for _, repo := range repos {
+		reposByID[repo.ID] = repo
+	}
+
+	gs := gitserver.NewClient("graphql.authz.freshness")
+	resolvers := make([]graphqlbackend.RepositoryPermissionsFreshnessResolver, 0, len(freshness))
+	for _, f := range freshness {
+		// The repository may have been deleted in the meantime.
+		repo, ok := reposByID[api.RepoID(f.ID)]
+		if !ok {
+			continue
+		}
+		resolvers = append(resolvers, &repositoryPermissionsFreshnessResolver{
+			repo:      graphqlbackend.NewRepositoryResolver(r.db, gs, repo),
+			freshness: f,
+		})
+	}
+	return resolvers, nil
+}
+
+type userPermissionsFreshnessResolver struct {
+	db        database.DB
+	freshness *database.PermsFreshness
+}
+
+func (r *userPermissionsFreshnessResolver) User(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	return graphqlbackend.UserByIDInt32(ctx, r.db, r.freshness.ID)
+}
+
+func (r *userPermissionsFreshnessResolver) LastSyncedAt() *gqlutil.DateTime {
+	return gqlutil.FromTime(r.freshness.SyncedAt)
+}
+
+func (r *userPermissionsFreshnessResolver) SyncPending() bool {
+	return r.freshness.SyncPending
+}
+
+type repositoryPermissionsFreshnessResolver struct {
+	repo      *graphqlbackend.RepositoryResolver
+	freshness *database.PermsFreshness
+}
+
+func (r *repositoryPermissionsFreshnessResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return r.repo
+}
+
+func (r *repositoryPermissionsFreshnessResolver) LastSyncedAt() *gqlutil.DateTime {
+	return gqlutil.FromTime(r.freshness.SyncedAt)
+}
+
+func (r *repositoryPermissionsFreshnessResolver) SyncPending() bool {
+	return r.freshness.SyncPending
+}
@@ -2713,3 +2713,108 @@ func TestResolver_PermissionsSyncingStats(t *testing.T) {
 		graphqlbackend.RunTests(t, gqlTests)
 	})
 }
+
+func TestResolver_PermissionsFreshnessReport(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		user := &types.User{ID: 42}
+
+		users := dbmocks.NewStrictMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(user, nil)
+
+		db := dbmocks.NewStrictMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: user.ID})
+		_, err := (&Resolver{db: db}).PermissionsFreshnessReport(ctx, &graphqlbackend.PermissionsFreshnessReportArgs{First: 50})
+		if want := auth.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+	})
+
+	t.Run("successfully query the report", func(t *testing.T) {
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			PermissionsFreshnessSLO: &schema.PermissionsFreshnessSLO{MaxAgeSeconds: 3600},
+		}})
+		t.Cleanup(func() { conf.Mock(nil) })
+
+		admin := &types.User{ID: 42, SiteAdmin: true}
+		stale := &types.User{ID: 7, Username: "alice"}
+
+		users := dbmocks.NewStrictMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(admin, nil)
+		users.GetByIDFunc.SetDefaultReturn(stale, nil)
+
+		syncedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		perms := dbmocks.NewStrictMockPermsStore()
+		perms.UsersBreachingPermsSLOFunc.SetDefaultHook(func(_ context.Context, maxAge time.Duration, limit int) ([]*database.PermsFreshness, int, error) {
+			assert.Equal(t, time.Hour, maxAge)
+			assert.Equal(t, 1, limit)
+			return []*database.PermsFreshness{{ID: 7, SyncedAt: syncedAt}}, 3, nil
+		})
+		perms.ReposBreachingPermsSLOFunc.SetDefaultReturn([]*database.PermsFreshness{{ID: 1, SyncPending: true}}, 1, nil)
+
+		repos := dbmocks.NewStrictMockRepoStore()
+		repos.GetByIDsFunc.SetDefaultReturn([]*types.Repo{{ID: 1, Name: "github.com/sourcegraph/private"}}, nil)
+
+		db := dbmocks.NewStrictMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.PermsFunc.SetDefaultReturn(perms)
+		db.ReposFunc.SetDefaultReturn(repos)
+
+		gqlTests := []*graphqlbackend.Test{{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query: `
+				query {
+					permissionsFreshnessReport(first: 1) {
+						maxAgeSeconds
+						usersBreachingSLOCount
+						reposBreachingSLOCount
+						usersBreachingSLO {
+							user {
+								username
+							}
+							lastSyncedAt
+							syncPending
+						}
+						reposBreachingSLO {
+							repository {
+								name
+							}
+							lastSyncedAt
+							syncPending
+						}
+					}
+				}
+						`,
+			ExpectedResult: `
+				{
+					"permissionsFreshnessReport": {
+						"maxAgeSeconds": 3600,
+						"usersBreachingSLOCount": 3,
+						"reposBreachingSLOCount": 1,
+						"usersBreachingSLO": [
+							{
+								"user": {
+									"username": "alice"
+								},
+								"lastSyncedAt": "2024-01-01T00:00:00Z",
+								"syncPending": false
+							}
+						],
+						"reposBreachingSLO": [
+							{
+								"repository": {
+									"name": "github.com/sourcegraph/private"
+								},
+								"lastSyncedAt": null,
+								"syncPending": true
+							}
+						]
+					}
+				}
+						`,
+		}}
+
+		graphqlbackend.RunTests(t, gqlTests)
+	})
+}
@@ -191,6 +191,27 @@ func (r *batchChangeResolver) ChangesetsStats(ctx context.Context) (graphqlbacke
 	return &changesetsStatsResolver{stats: stats}, nil
 }
 
+func (r *batchChangeResolver) Stages(ctx context.Context) ([]graphqlbackend.BatchChangeStageResolver, error) {
+	batchSpec, err := r.computeBatchSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if batchSpec.Spec == nil || len(batchSpec.Spec.Stages) == 0 {
+		return []graphqlbackend.BatchChangeStageResolver{}, nil
+	}
+
+	stats, err := r.store.GetBatchChangeStagesStats(ctx, r.batchChange.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(batchSpec.Spec.Stages))
+	for _, stage := range batchSpec.Spec.Stages {
+		names = append(names, stage.Name)
+	}
+	return newBatchChangeStageResolvers(names, stats), nil
+}
+
 func (r *batchChangeResolver) Changesets(
 	ctx context.Context,
 	args *graphqlbackend.ListChangesetsArgs,
@@ -0,0 +1,64 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+type batchChangeStageResolver struct {
+	name    string
+	stats   btypes.BatchChangeStageStats
+	blocked bool
+}
+
+var _ graphqlbackend.BatchChangeStageResolver = &batchChangeStageResolver{}
+
+func (r *batchChangeStageResolver) Name() string {
+	return r.name
+}
+func (r *batchChangeStageResolver) Index() int32 {
+	return r.stats.Stage
+}
+func (r *batchChangeStageResolver) Total() int32 {
+	return r.stats.Total
+}
+func (r *batchChangeStageResolver) Merged() int32 {
+	return r.stats.Merged
+}
+func (r *batchChangeStageResolver) Unpublished() int32 {
+	return r.stats.Unpublished
+}
+func (r *batchChangeStageResolver) Waiting() int32 {
+	return r.stats.Waiting
+}
+func (r *batchChangeStageResolver) IsCompleted() bool {
+	return r.stats.Complete()
+}
+func (r *batchChangeStageResolver) IsBlocked() bool {
+	return r.blocked
+}
+
+// newBatchChangeStageResolvers returns a resolver for each of the given stage
+// names, in order, combined with the stats of the stage's changesets. Stages
+// without changesets are considered to be complete.
+func newBatchChangeStageResolvers(names []string, stats []btypes.BatchChangeStageStats) []graphqlbackend.BatchChangeStageResolver {
+	byStage := make(map[int32]btypes.BatchChangeStageStats, len(stats))
+	for _, st := range stats {
+		byStage[st.Stage] = st
+	}
+
+	resolvers := make([]graphqlbackend.BatchChangeStageResolver, 0, len(names))
+	blocked := false
+	for i, name := range names {
+		index := int32(i + 1)
+		st, ok := byStage[index]
+		if !ok {
+			st = btypes.BatchChangeStageStats{Stage: index}
+		}
+		resolvers = append(resolvers, &batchChangeStageResolver{name: name, stats: st, blocked: blocked})
+		if !st.Complete() {
+			blocked = true
+		}
+	}
+	return resolvers
+}
@@ -0,0 +1,45 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+func TestNewBatchChangeStageResolvers(t *testing.T) {
+	names := []string{"library", "services", "consumers"}
+
+	t.Run("first stage incomplete", func(t *testing.T) {
+		rs := newBatchChangeStageResolvers(names, []btypes.BatchChangeStageStats{
+			{Stage: 1, Total: 2, Merged: 1},
+			{Stage: 2, Total: 3, Unpublished: 3},
+			{Stage: 3, Total: 5, Unpublished: 5},
+		})
+
+		require.Len(t, rs, 3)
+		require.Equal(t, "library", rs[0].Name())
+		require.Equal(t, int32(1), rs[0].Index())
+		require.False(t, rs[0].IsCompleted())
+		require.False(t, rs[0].IsBlocked())
+		require.True(t, rs[1].IsBlocked())
+		require.True(t, rs[2].IsBlocked())
+	})
+
+	t.Run("stage without changesets", func(t *testing.T) {
+		rs := newBatchChangeStageResolvers(names, []btypes.BatchChangeStageStats{
+			{Stage: 1, Total: 2, Merged: 2},
+			{Stage: 3, Total: 5, Unpublished: 5},
+		})
+
+		require.Len(t, rs, 3)
+		require.True(t, rs[0].IsCompleted())
+		require.Equal(t, "services", rs[1].Name())
+		require.Equal(t, int32(0), rs[1].Total())
+		require.True(t, rs[1].IsCompleted())
+		require.False(t, rs[1].IsBlocked())
+		require.False(t, rs[2].IsBlocked())
+		require.Equal(t, int32(5), rs[2].Unpublished())
+	})
+}
@@ -421,6 +421,13 @@ func (r *changesetResolver) ScheduleEstimateAt(ctx context.Context) (*gqlutil.Da
 	return gqlutil.DateTimeOrNil(config.ActiveWindow().Estimate(r.store.Clock()(), place)), nil
 }
 
+func (r *changesetResolver) WaitingForStage() *int32 {
+	if r.changeset.WaitingForStage == 0 {
+		return nil
+	}
+	return &r.changeset.WaitingForStage
+}
+
 func (r *changesetResolver) CurrentSpec(ctx context.Context) (graphqlbackend.VisibleChangesetSpecResolver, error) {
 	if r.changeset.CurrentSpecID == 0 {
 		return nil, nil
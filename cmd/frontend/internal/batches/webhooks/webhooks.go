@@ -12,6 +12,7 @@ import (
 	"github.com/inconshreveable/log15" //nolint:logging // TODO move all logging to sourcegraph/log
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/global"
 	"github.com/sourcegraph/sourcegraph/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
@@ -172,11 +173,20 @@ func (h webhook) upsertChangesetEvent(
 	events, _, err := tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
 		ChangesetIDs: []int64{cs.ID},
 	})
+	previousState := cs.ExternalState
 	state.SetDerivedState(ctx, tx.Repos(), h.gitserverClient, cs, events)
 	if err := tx.UpdateChangesetCodeHostState(ctx, cs); err != nil {
 		return err
 	}
 
+	// A newly merged changeset may complete a stage of its batch change, in
+	// which case the changesets of the following stages can be published.
+	if previousState != btypes.ChangesetExternalStateMerged && cs.ExternalState == btypes.ChangesetExternalStateMerged && cs.OwnedByBatchChangeID != 0 {
+		if err := tx.EnqueueChangesetsWaitingForStage(ctx, cs.OwnedByBatchChangeID, global.DefaultReconcilerEnqueueState()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -60,6 +60,7 @@ func newExternalHTTPHandler(
 		return nil, errors.Errorf("create external HTTP API handler: %v", err)
 	}
 	// 🚨 SECURITY: These all run after the auth handler so the client is authenticated.
+	apiHandler = authz.PermissionsFreshnessMiddleware(logger, db, apiHandler)
 	apiHandler = authz.PostAuthMiddleware(logger, db, apiHandler)
 	apiHandler = featureflag.Middleware(db.FeatureFlags(), apiHandler)
 	apiHandler = actor.AnonymousUIDMiddleware(apiHandler)
@@ -81,6 +82,7 @@ func newExternalHTTPHandler(
 	// App handler (HTML pages), the call order of middleware is LIFO.
 	appHandler := app.NewHandler(db, logger)
 	// 🚨 SECURITY: These all run after the auth handler so the client is authenticated.
+	appHandler = authz.PermissionsFreshnessMiddleware(logger, db, appHandler)
 	appHandler = authz.PostAuthMiddleware(logger, db, appHandler)
 	appHandler = featureflag.Middleware(db.FeatureFlags(), appHandler)
 	appHandler = actor.AnonymousUIDMiddleware(appHandler)
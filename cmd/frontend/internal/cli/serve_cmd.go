@@ -353,6 +353,8 @@ func makeExternalAPI(db database.DB, logger sglog.Logger, schema *graphql.Schema
 			NewDotcomLicenseCheckHandler:    enterprise.NewDotcomLicenseCheckHandler,
 			NewChatCompletionsStreamHandler: enterprise.NewChatCompletionsStreamHandler,
 			NewCodeCompletionsHandler:       enterprise.NewCodeCompletionsHandler,
+
+			SavedSearchSnapshotDiffExportHandler: enterprise.SavedSearchSnapshotDiffExportHandler,
 		},
 		enterprise.NewExecutorProxyHandler,
 	)
@@ -22,6 +22,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/references"
+	"github.com/sourcegraph/sourcegraph/internal/search/references/codenavfinder"
 )
 
 func LoadConfig() {
@@ -86,11 +88,12 @@ func Init(
 		ConfigInst.MaximumIndexesPerMonikerSearch,
 	)
 
-	policyRootResolver := policiesgraphql.NewRootResolver(
-		scopedContext("policies"),
-		codeIntelServices.PoliciesService,
+	// Enables select:symbol.references and select:symbol.callers searches
+	references.DefaultFinder = codenavfinder.New(
+		codeIntelServices.CodenavService,
 		repoStore,
-		siteAdminChecker,
+		codeIntelServices.GitserverClient,
+		ConfigInst.MaximumIndexesPerMonikerSearch,
 	)
 
 	uploadRootResolver := uploadgraphql.NewRootResolver(
@@ -104,6 +107,14 @@ func Init(
 		preciseIndexResolverFactory,
 	)
 
+	policyRootResolver := policiesgraphql.NewRootResolver(
+		scopedContext("policies"),
+		codeIntelServices.PoliciesService,
+		repoStore,
+		siteAdminChecker,
+		uploadRootResolver,
+	)
+
 	rankingRootResolver := rankinggraphql.NewRootResolver(
 		scopedContext("ranking"),
 		codeIntelServices.RankingService,
@@ -83,6 +83,9 @@ type Handlers struct {
 	SearchJobsDataExportHandler http.Handler
 	SearchJobsLogsHandler       http.Handler
 
+	// Saved searches
+	SavedSearchSnapshotDiffExportHandler http.Handler
+
 	// Dotcom license check
 	NewDotcomLicenseCheckHandler enterprise.NewDotcomLicenseCheckHandler
 
@@ -170,6 +173,7 @@ func NewHandler(
 	m.Path("/search/stream").Methods("GET").Handler(frontendsearch.StreamHandler(db))
 	m.Path("/search/export/{id}.jsonl").Methods("GET").Handler(handlers.SearchJobsDataExportHandler)
 	m.Path("/search/export/{id}.log").Methods("GET").Handler(handlers.SearchJobsLogsHandler)
+	m.Path("/saved-searches/snapshots/{base}/diff/{head}.csv").Methods("GET").Handler(handlers.SavedSearchSnapshotDiffExportHandler)
 
 	m.Path("/completions/stream").Methods("POST").Handler(handlers.NewChatCompletionsStreamHandler())
 	m.Path("/completions/code").Methods("POST").Handler(handlers.NewCodeCompletionsHandler())
@@ -0,0 +1,76 @@
+package httpapi
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/savedsearches/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/savedsearches/snapshots"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ServeSnapshotDiffExport serves the entries added and removed between two snapshots of a saved
+// search as CSV. The snapshots are identified by the "base" and "head" route variables.
+func ServeSnapshotDiffExport(logger log.Logger, db database.DB, svc *snapshots.Service) http.HandlerFunc {
+	logger = logger.With(log.String("handler", "ServeSnapshotDiffExport"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseID, err := strconv.ParseInt(mux.Vars(r)["base"], 10, 32)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		headID, err := strconv.ParseInt(mux.Vars(r)["head"], 10, 32)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// 🚨 SECURITY: Only users who can administer the saved search may export its snapshots.
+		base, head, err := resolvers.GetAuthorizedSnapshotPair(r.Context(), db, int32(baseID), int32(headID))
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+
+		diff, err := svc.Diff(r.Context(), base, head)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+
+		// Render the CSV before writing the header, so that errors can still be reported.
+		var buf bytes.Buffer
+		if err := snapshots.WriteCSV(&buf, diff); err != nil {
+			httpError(w, err)
+			return
+		}
+
+		filename := fmt.Sprintf("saved-search-%d_snapshots_%d-%d.csv", base.SavedSearchID, base.ID, head.ID)
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+		w.WriteHeader(http.StatusOK)
+		if n, err := buf.WriteTo(w); err != nil {
+			logger.Warn("failed while writing saved search snapshot diff", log.String("filename", filename), log.Int64("bytesWritten", n), log.Error(err))
+		}
+	}
+}
+
+func httpError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, auth.ErrMustBeSiteAdminOrSameUser), errors.Is(err, auth.ErrNotAnOrgMember):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errcode.IsNotFound(err):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
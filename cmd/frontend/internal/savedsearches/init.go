@@ -6,11 +6,16 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/savedsearches/httpapi"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/savedsearches/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/savedsearches/snapshots"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
 )
 
 func Init(
@@ -21,6 +26,20 @@ func Init(
 	_ conftypes.UnifiedWatchable,
 	enterpriseServices *enterprise.Services,
 ) error {
-	enterpriseServices.SavedSearchesResolver = resolvers.NewResolver(log.Scoped("savedSearchesResolver"), db)
+	logger := log.Scoped("savedSearchesResolver")
+
+	// Snapshots are stored alongside the results of search jobs. Saved searches remain available
+	// without snapshots if the object store is misconfigured.
+	var snapshotsService *snapshots.Service
+	uploadStore, err := search.NewObjectStorage(ctx, observationCtx, search.ObjectStorageConfigInst)
+	if err != nil {
+		logger.Warn("saved search snapshots are disabled", log.Error(err))
+	} else {
+		searchClient := client.New(logger, db, gitserver.NewClient("http.savedsearches"))
+		snapshotsService = snapshots.NewService(logger, db, uploadStore, searchClient)
+		enterpriseServices.SavedSearchSnapshotDiffExportHandler = httpapi.ServeSnapshotDiffExport(logger, db, snapshotsService)
+	}
+
+	enterpriseServices.SavedSearchesResolver = resolvers.NewResolver(logger, db, snapshotsService)
 	return nil
 }
@@ -15,18 +15,21 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/savedsearches/snapshots"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-// NewResolver returns a new Resolver that uses the given database.
-func NewResolver(logger log.Logger, db database.DB) graphqlbackend.SavedSearchesResolver {
-	return &Resolver{logger: logger, db: db}
+// NewResolver returns a new Resolver that uses the given database and takes snapshots of saved
+// searches with the given service.
+func NewResolver(logger log.Logger, db database.DB, snapshots *snapshots.Service) graphqlbackend.SavedSearchesResolver {
+	return &Resolver{logger: logger, db: db, snapshots: snapshots}
 }
 
 type Resolver struct {
-	logger log.Logger
-	db     database.DB
+	logger    log.Logger
+	db        database.DB
+	snapshots *snapshots.Service
 }
 
 func (r *Resolver) Now() time.Time {
@@ -0,0 +1,281 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/savedsearches/snapshots"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const savedSearchSnapshotKind = "SavedSearchSnapshot"
+
+func marshalSavedSearchSnapshotID(id int32) graphql.ID {
+	return relay.MarshalID(savedSearchSnapshotKind, id)
+}
+
+func unmarshalSavedSearchSnapshotID(id graphql.ID) (snapshotID int32, err error) {
+	if kind := relay.UnmarshalKind(id); kind != savedSearchSnapshotKind {
+		return 0, errors.Errorf("expected graphql ID to have kind %q; got %q", savedSearchSnapshotKind, kind)
+	}
+	err = relay.UnmarshalSpec(id, &snapshotID)
+	return
+}
+
+// maxSnapshotIntervalHours bounds the snapshot interval to a year.
+const maxSnapshotIntervalHours = 24 * 366
+
+func (r *Resolver) CreateSavedSearchSnapshot(ctx context.Context, args *graphqlbackend.CreateSavedSearchSnapshotArgs) (graphqlbackend.SavedSearchSnapshotResolver, error) {
+	id, err := unmarshalSavedSearchID(args.SavedSearch)
+	if err != nil {
+		return nil, err
+	}
+	ss, err := r.db.SavedSearches().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only users who can administer the saved search may snapshot it, since its
+	// snapshots are visible to all of them.
+	if err := graphqlbackend.CheckAuthorizedForNamespaceByIDs(ctx, r.db, ss.Owner); err != nil {
+		return nil, err
+	}
+
+	if r.snapshots == nil {
+		return nil, errors.New("saved search snapshots are not available")
+	}
+	snapshot, err := r.snapshots.Snapshot(ctx, ss)
+	if err != nil {
+		return nil, err
+	}
+	return &savedSearchSnapshotResolver{db: r.db, s: *snapshot}, nil
+}
+
+func (r *Resolver) SetSavedSearchSnapshotInterval(ctx context.Context, args *graphqlbackend.SetSavedSearchSnapshotIntervalArgs) (graphqlbackend.SavedSearchResolver, error) {
+	id, err := unmarshalSavedSearchID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	ss, err := r.db.SavedSearches().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Check the user can administer saved searches in the owner's namespace.
+	// Scheduled snapshots run with this user's permissions.
+	if err := graphqlbackend.CheckAuthorizedForNamespaceByIDs(ctx, r.db, ss.Owner); err != nil {
+		return nil, err
+	}
+
+	if hours := args.IntervalHours; hours != nil && (*hours <= 0 || *hours > maxSnapshotIntervalHours) {
+		return nil, errors.Errorf("intervalHours must be between 1 and %d", maxSnapshotIntervalHours)
+	}
+
+	ss, err = r.db.SavedSearches().UpdateSnapshotInterval(ctx, id, args.IntervalHours)
+	if err != nil {
+		return nil, err
+	}
+	return r.toSavedSearchResolver(*ss), nil
+}
+
+func (r *Resolver) SavedSearchSnapshotDiff(ctx context.Context, args *graphqlbackend.SavedSearchSnapshotDiffArgs) (graphqlbackend.SavedSearchSnapshotDiffResolver, error) {
+	baseID, err := unmarshalSavedSearchSnapshotID(args.Base)
+	if err != nil {
+		return nil, err
+	}
+	headID, err := unmarshalSavedSearchSnapshotID(args.Head)
+	if err != nil {
+		return nil, err
+	}
+
+	base, head, err := GetAuthorizedSnapshotPair(ctx, r.db, baseID, headID)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.snapshots == nil {
+		return nil, errors.New("saved search snapshots are not available")
+	}
+	diff, err := r.snapshots.Diff(ctx, base, head)
+	if err != nil {
+		return nil, err
+	}
+
+	return &savedSearchSnapshotDiffResolver{
+		base: &savedSearchSnapshotResolver{db: r.db, s: *base},
+		head: &savedSearchSnapshotResolver{db: r.db, s: *head},
+		diff: diff,
+	}, nil
+}
+
+// GetAuthorizedSnapshotPair returns two snapshots of the same saved search, which the current
+// user must be authorized to administer.
+func GetAuthorizedSnapshotPair(ctx context.Context, db database.DB, baseID, headID int32) (base, head *types.SavedSearchSnapshot, err error) {
+	base, err = db.SavedSearches().GetSnapshotByID(ctx, baseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	head, err = db.SavedSearches().GetSnapshotByID(ctx, headID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if base.SavedSearchID != head.SavedSearchID {
+		return nil, nil, errors.New("snapshots must be of the same saved search")
+	}
+
+	ss, err := db.SavedSearches().GetByID(ctx, base.SavedSearchID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 🚨 SECURITY: Snapshots may contain results only visible to the user who took them, so they
+	// are only visible to users who can administer the saved search, regardless of its
+	// visibility.
+	if err := graphqlbackend.CheckAuthorizedForNamespaceByIDs(ctx, db, ss.Owner); err != nil {
+		return nil, nil, err
+	}
+
+	return base, head, nil
+}
+
+func (r *savedSearchResolver) SnapshotIntervalHours() *int32 {
+	return r.s.SnapshotIntervalHours
+}
+
+func (r *savedSearchResolver) Snapshots(ctx context.Context, args *graphqlbackend.SavedSearchSnapshotsArgs) ([]graphqlbackend.SavedSearchSnapshotResolver, error) {
+	// 🚨 SECURITY: Only users who can administer the saved search may list its snapshots.
+	if err := graphqlbackend.CheckAuthorizedForNamespaceByIDs(ctx, r.db, r.s.Owner); err != nil {
+		return nil, err
+	}
+
+	if args.First < 0 {
+		return nil, errors.New("first must be non-negative")
+	}
+	if args.First == 0 {
+		return []graphqlbackend.SavedSearchSnapshotResolver{}, nil
+	}
+
+	snapshots, err := r.db.SavedSearches().ListSnapshots(ctx, r.s.ID, int(args.First))
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.SavedSearchSnapshotResolver, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		resolvers = append(resolvers, &savedSearchSnapshotResolver{db: r.db, s: *snapshot})
+	}
+	return resolvers, nil
+}
+
+type savedSearchSnapshotResolver struct {
+	db database.DB
+	s  types.SavedSearchSnapshot
+}
+
+func (r *savedSearchSnapshotResolver) ID() graphql.ID {
+	return marshalSavedSearchSnapshotID(r.s.ID)
+}
+
+func (r *savedSearchSnapshotResolver) SavedSearch(ctx context.Context) (graphqlbackend.SavedSearchResolver, error) {
+	ss, err := r.db.SavedSearches().GetByID(ctx, r.s.SavedSearchID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Check whether the actor can view the saved search.
+	if err := checkActorCanViewSavedSearch(ctx, r.db, ss); err != nil {
+		return nil, err
+	}
+	return &savedSearchResolver{db: r.db, s: *ss}, nil
+}
+
+func (r *savedSearchSnapshotResolver) Query() string { return r.s.Query }
+
+func (r *savedSearchSnapshotResolver) EntryCount() int32 { return r.s.EntryCount }
+
+func (r *savedSearchSnapshotResolver) LimitHit() bool { return r.s.LimitHit }
+
+func (r *savedSearchSnapshotResolver) CreatedBy(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if userID := r.s.CreatedByUser; userID != nil {
+		return graphqlbackend.UserByIDInt32(ctx, r.db, *userID)
+	}
+	return nil, nil
+}
+
+func (r *savedSearchSnapshotResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.s.CreatedAt}
+}
+
+type savedSearchSnapshotDiffResolver struct {
+	base, head *savedSearchSnapshotResolver
+	diff       snapshots.Diff
+}
+
+func (r *savedSearchSnapshotDiffResolver) Base() graphqlbackend.SavedSearchSnapshotResolver {
+	return r.base
+}
+
+func (r *savedSearchSnapshotDiffResolver) Head() graphqlbackend.SavedSearchSnapshotResolver {
+	return r.head
+}
+
+func (r *savedSearchSnapshotDiffResolver) Added(args *graphqlbackend.SavedSearchSnapshotEntriesArgs) []graphqlbackend.SavedSearchSnapshotEntryResolver {
+	return toEntryResolvers(r.diff.Added, args.First)
+}
+
+func (r *savedSearchSnapshotDiffResolver) Removed(args *graphqlbackend.SavedSearchSnapshotEntriesArgs) []graphqlbackend.SavedSearchSnapshotEntryResolver {
+	return toEntryResolvers(r.diff.Removed, args.First)
+}
+
+func (r *savedSearchSnapshotDiffResolver) AddedCount() int32 { return int32(len(r.diff.Added)) }
+
+func (r *savedSearchSnapshotDiffResolver) RemovedCount() int32 { return int32(len(r.diff.Removed)) }
+
+func (r *savedSearchSnapshotDiffResolver) ExportURL() string {
+	return fmt.Sprintf("/.api/saved-searches/snapshots/%d/diff/%d.csv", r.base.s.ID, r.head.s.ID)
+}
+
+func toEntryResolvers(entries []snapshots.Entry, first int32) []graphqlbackend.SavedSearchSnapshotEntryResolver {
+	if first >= 0 && int(first) < len(entries) {
+		entries = entries[:first]
+	}
+	resolvers := make([]graphqlbackend.SavedSearchSnapshotEntryResolver, 0, len(entries))
+	for _, entry := range entries {
+		resolvers = append(resolvers, savedSearchSnapshotEntryResolver{e: entry})
+	}
+	return resolvers
+}
+
+type savedSearchSnapshotEntryResolver struct {
+	e snapshots.Entry
+}
+
+func (r savedSearchSnapshotEntryResolver) Repository() string { return r.e.Repo }
+
+func (r savedSearchSnapshotEntryResolver) Path() *string {
+	if r.e.Path == "" {
+		return nil
+	}
+	return &r.e.Path
+}
+
+func (r savedSearchSnapshotEntryResolver) LineNumber() *int32 {
+	if r.e.LineNumber == 0 {
+		return nil
+	}
+	lineNumber := int32(r.e.LineNumber)
+	return &lineNumber
+}
+
+func (r savedSearchSnapshotEntryResolver) Line() *string {
+	if r.e.Line == "" {
+		return nil
+	}
+	return &r.e.Line
+}
@@ -47,6 +47,16 @@ func (e *eventWriter) Filters(fs []*streaming.Filter, exhaustive bool) error {
 	return nil
 }
 
+func (e *eventWriter) RankingExplanation(explanation search.RankingExplanation) error {
+	return e.inner.Event("explanation", streamhttp.EventRankingExplanation{
+		Scorer:              explanation.Scorer,
+		DocumentRanks:       explanation.DocumentRanks,
+		DocumentRanksWeight: explanation.DocumentRanksWeight,
+		FlushWallTimeMs:     explanation.FlushWallTime.Milliseconds(),
+		Notes:               explanation.Notes,
+	})
+}
+
 func (e *eventWriter) Error(err error) error {
 	return e.inner.Event("error", streamhttp.EventError{Message: err.Error()})
 }
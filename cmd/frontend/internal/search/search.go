@@ -147,6 +147,11 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr trace.Trace, eventWriter *
 		inputs.Features.ZoektSearchOptionsOverride = args.ZoektSearchOptionsOverride
 	}
 
+	if args.ExplainRanking {
+		inputs.Features.ExplainRanking = true
+		eventWriter.RankingExplanation(search.ExplainRanking(inputs.PatternType))
+	}
+
 	// displayFilter limits the matches we stream to the user. Once we have
 	// hit a display limit the search will continue, but we no longer stream
 	// the actual matches.
@@ -264,6 +269,7 @@ type args struct {
 	SearchMode                 int
 	ContextLines               *int32
 	ZoektSearchOptionsOverride string
+	ExplainRanking             bool
 }
 
 func parseURLQuery(q url.Values) (*args, error) {
@@ -315,6 +321,11 @@ func parseURLQuery(q url.Values) (*args, error) {
 		return nil, errors.Errorf("search mode must be integer, got %q: %w", searchMode, err)
 	}
 
+	explain := get("explain", "f")
+	if a.ExplainRanking, err = strconv.ParseBool(explain); err != nil {
+		return nil, errors.Errorf("explain must be parseable as a boolean, got %q: %w", explain, err)
+	}
+
 	return &a, nil
 }
 
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
 )
 
 func NewResolver(db database.DB) graphqlbackend.SearchContextsResolver {
@@ -91,6 +92,9 @@ func (r *Resolver) CreateSearchContext(ctx context.Context, args graphqlbackend.
 			NamespaceUserID: namespaceUserID,
 			NamespaceOrgID:  namespaceOrgID,
 			Query:           args.SearchContext.Query,
+
+			RefreshIntervalHours: refreshIntervalHoursFromInputArgs(args.SearchContext.RefreshIntervalHours),
+			RevisionRules:        revisionRulesFromInputArgs(args.SearchContext.RevisionRules),
 		},
 		repositoryRevisions,
 	)
@@ -124,6 +128,8 @@ func (r *Resolver) UpdateSearchContext(ctx context.Context, args graphqlbackend.
 	updated.Description = args.SearchContext.Description
 	updated.Public = args.SearchContext.Public
 	updated.Query = args.SearchContext.Query
+	updated.RefreshIntervalHours = refreshIntervalHoursFromInputArgs(args.SearchContext.RefreshIntervalHours)
+	updated.RevisionRules = revisionRulesFromInputArgs(args.SearchContext.RevisionRules)
 
 	searchContext, err := searchcontexts.UpdateSearchContextWithRepositoryRevisions(
 		ctx,
@@ -137,6 +143,28 @@ func (r *Resolver) UpdateSearchContext(ctx context.Context, args graphqlbackend.
 	return &searchContextResolver{searchContext, r.db}, nil
 }
 
+func refreshIntervalHoursFromInputArgs(hours *int32) int32 {
+	if hours == nil {
+		return 0
+	}
+	return *hours
+}
+
+func revisionRulesFromInputArgs(args *[]graphqlbackend.SearchContextRevisionRuleInputArgs) []types.SearchContextRevisionRule {
+	if args == nil {
+		return nil
+	}
+	rules := make([]types.SearchContextRevisionRule, 0, len(*args))
+	for _, arg := range *args {
+		rules = append(rules, types.SearchContextRevisionRule{
+			Repo:   arg.Repo,
+			Tag:    pointers.Deref(arg.Tag, ""),
+			Branch: pointers.Deref(arg.Branch, ""),
+		})
+	}
+	return rules
+}
+
 func (r *Resolver) repositoryRevisionsFromInputArgs(ctx context.Context, args []graphqlbackend.SearchContextRepositoryRevisionsInputArgs) ([]*types.SearchContextRepositoryRevisions, error) {
 	repoIDs := make([]api.RepoID, 0, len(args))
 	for _, repository := range args {
@@ -514,6 +542,62 @@ func (r *searchContextResolver) Query() string {
 	return r.sc.Query
 }
 
+func (r *searchContextResolver) RefreshIntervalHours() *int32 {
+	if r.sc.RefreshIntervalHours == 0 {
+		return nil
+	}
+	return &r.sc.RefreshIntervalHours
+}
+
+func (r *searchContextResolver) RevisionRules() []graphqlbackend.SearchContextRevisionRuleResolver {
+	resolvers := make([]graphqlbackend.SearchContextRevisionRuleResolver, 0, len(r.sc.RevisionRules))
+	for _, rule := range r.sc.RevisionRules {
+		resolvers = append(resolvers, &searchContextRevisionRuleResolver{rule})
+	}
+	return resolvers
+}
+
+func (r *searchContextResolver) LastRefreshedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.sc.LastRefreshedAt)
+}
+
+func (r *searchContextResolver) Refreshes(ctx context.Context, args *graphqlbackend.SearchContextRefreshesArgs) ([]graphqlbackend.SearchContextRefreshResolver, error) {
+	if searchcontexts.IsAutoDefinedSearchContext(r.sc) {
+		return []graphqlbackend.SearchContextRefreshResolver{}, nil
+	}
+
+	refreshes, err := r.db.SearchContexts().ListSearchContextRefreshes(ctx, r.sc.ID, int(args.First))
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Changes are recorded with an internal actor, so only the changes of the
+	// repositories the current user has access to are returned.
+	var repoIDs []api.RepoID
+	for _, refresh := range refreshes {
+		for _, change := range refresh.Changes {
+			repoIDs = append(repoIDs, change.RepoID)
+		}
+	}
+	visible, err := r.db.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.SearchContextRefreshResolver, 0, len(refreshes))
+	for _, refresh := range refreshes {
+		changes := refresh.Changes[:0]
+		for _, change := range refresh.Changes {
+			if _, ok := visible[change.RepoID]; ok {
+				changes = append(changes, change)
+			}
+		}
+		refresh.Changes = changes
+		resolvers = append(resolvers, &searchContextRefreshResolver{refresh, r.db})
+	}
+	return resolvers, nil
+}
+
 type searchContextConnectionResolver struct {
 	afterCursor    int32
 	searchContexts []graphqlbackend.SearchContextResolver
@@ -537,6 +621,70 @@ func (s *searchContextConnectionResolver) PageInfo() *graphqlutil.PageInfo {
 	return graphqlutil.NextPageCursor(marshalSearchContextCursor(s.afterCursor + int32(len(s.searchContexts))))
 }
 
+type searchContextRevisionRuleResolver struct {
+	rule types.SearchContextRevisionRule
+}
+
+func (r *searchContextRevisionRuleResolver) Repo() string {
+	return r.rule.Repo
+}
+
+func (r *searchContextRevisionRuleResolver) Tag() *string {
+	return pointers.NonZeroPtr(r.rule.Tag)
+}
+
+func (r *searchContextRevisionRuleResolver) Branch() *string {
+	return pointers.NonZeroPtr(r.rule.Branch)
+}
+
+type searchContextRefreshResolver struct {
+	refresh *types.SearchContextRefresh
+	db      database.DB
+}
+
+func (r *searchContextRefreshResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.refresh.CreatedAt}
+}
+
+func (r *searchContextRefreshResolver) RepositoryCount() int32 {
+	return r.refresh.RepoCount
+}
+
+func (r *searchContextRefreshResolver) Changes() []graphqlbackend.SearchContextRefreshChangeResolver {
+	gitserverClient := gitserver.NewClient("graphql.searchcontext.refreshes")
+	resolvers := make([]graphqlbackend.SearchContextRefreshChangeResolver, 0, len(r.refresh.Changes))
+	for _, change := range r.refresh.Changes {
+		resolvers = append(resolvers, &searchContextRefreshChangeResolver{
+			repository:        graphqlbackend.NewMinimalRepositoryResolver(r.db, gitserverClient, change.RepoID, change.RepoName),
+			revisions:         change.Revisions,
+			previousRevisions: change.PreviousRevisions,
+		})
+	}
+	return resolvers
+}
+
+func (r *searchContextRefreshResolver) Error() *string {
+	return pointers.NonZeroPtr(r.refresh.Error)
+}
+
+type searchContextRefreshChangeResolver struct {
+	repository        *graphqlbackend.RepositoryResolver
+	revisions         []string
+	previousRevisions []string
+}
+
+func (r *searchContextRefreshChangeResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return r.repository
+}
+
+func (r *searchContextRefreshChangeResolver) Revisions() []string {
+	return r.revisions
+}
+
+func (r *searchContextRefreshChangeResolver) PreviousRevisions() []string {
+	return r.previousRevisions
+}
+
 type searchContextRepositoryRevisionsResolver struct {
 	repository *graphqlbackend.RepositoryResolver
 	revisions  []string
@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
@@ -153,3 +154,51 @@ func TestSearchContextsStarDefaultPermissions(t *testing.T) {
 		t.Fatalf("expected no error, got %s", err)
 	}
 }
+
+func TestSearchContextRefreshes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	sc := dbmocks.NewMockSearchContextsStore()
+	sc.ListSearchContextRefreshesFunc.SetDefaultReturn([]*types.SearchContextRefresh{
+		{
+			SearchContextID: 1,
+			RepoCount:       2,
+			Changes: []types.SearchContextRefreshChange{
+				{RepoID: 1, RepoName: "visible", Revisions: []string{"v1.1.0"}, PreviousRevisions: []string{"v1.0.0"}},
+				{RepoID: 2, RepoName: "private", Revisions: []string{"v2.0.0"}},
+			},
+		},
+		{SearchContextID: 1, Error: "boom"},
+	}, nil)
+
+	repos := dbmocks.NewMockRepoStore()
+	repos.GetReposSetByIDsFunc.SetDefaultReturn(map[api.RepoID]*types.Repo{1: {ID: 1, Name: "visible"}}, nil)
+
+	db := dbmocks.NewMockDB()
+	db.SearchContextsFunc.SetDefaultReturn(sc)
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	r := &searchContextResolver{&types.SearchContext{ID: 1, Name: "ctx", Query: "repo:foo", RefreshIntervalHours: 24}, db}
+	refreshes, err := r.Refreshes(ctx, &graphqlbackend.SearchContextRefreshesArgs{First: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockrequire.CalledOnceWith(t, sc.ListSearchContextRefreshesFunc, mockrequire.Values(mockrequire.Skip, int64(1), 10))
+	if len(refreshes) != 2 {
+		t.Fatalf("expected 2 refreshes, got %d", len(refreshes))
+	}
+
+	changes := refreshes[0].Changes()
+	if len(changes) != 1 {
+		t.Fatalf("expected only the change of the visible repository, got %d changes", len(changes))
+	}
+	if diff := cmp.Diff([]string{"v1.0.0"}, changes[0].PreviousRevisions()); diff != "" {
+		t.Fatalf("unexpected previous revisions (-want +got):\n%s", diff)
+	}
+	if err := refreshes[1].Error(); err == nil || *err != "boom" {
+		t.Fatalf("expected error boom, got %v", err)
+	}
+}
@@ -96,6 +96,12 @@ func cloneURL(ctx context.Context, db database.DB, kind string, parsed any, repo
 		return string(repo.Name), nil
 	case *schema.RubyPackagesConnection:
 		return string(repo.Name), nil
+	case *schema.DotNetPackagesConnection:
+		return string(repo.Name), nil
+	case *schema.PHPPackagesConnection:
+		return string(repo.Name), nil
+	case *schema.ElixirPackagesConnection:
+		return string(repo.Name), nil
 	case *schema.JVMPackagesConnection:
 		if r, ok := repo.Metadata.(*reposource.MavenMetadata); ok {
 			return r.Module.CloneURL(), nil
@@ -52,6 +52,12 @@ func searchWithObservability(ctx context.Context, logger log.Logger, repoDir com
 		attribute.Int("limit", args.Limit),
 		attribute.Bool("include_modified_files", args.IncludeModifiedFiles),
 	)
+	if args.Histogram != nil {
+		tr.SetAttributes(
+			attribute.Stringer("histogram_interval", args.Histogram.Interval),
+			attribute.Stringer("histogram_group_by", args.Histogram.GroupBy),
+		)
+	}
 	defer func() {
 		tr.AddEvent("done", attribute.Bool("limit_hit", limitHit))
 		tr.SetError(err)
@@ -102,9 +108,14 @@ func searchWithObservability(ctx context.Context, logger log.Logger, repoDir com
 // doSearch handles the core logic of the search. It is passed a matchesBuf so it doesn't need to
 // concern itself with event types, and all instrumentation is handled in the calling function.
 func doSearch(ctx context.Context, logger log.Logger, repoDir common.GitDir, args *protocol.SearchRequest, onMatch func(*protocol.CommitMatch) error) (limitHit bool, err error) {
-	if args.Limit == 0 {
+	if args.Limit == 0 || args.Histogram != nil {
 		args.Limit = math.MaxInt32
 	}
+	if args.Histogram != nil {
+		// Histograms only need the author date of each match, so skip
+		// formatting the diff of matching commits.
+		args.IncludeDiff = false
+	}
 
 	mt, err := search.ToMatchTree(args.Query)
 	if err != nil {
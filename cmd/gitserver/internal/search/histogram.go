@@ -0,0 +1,86 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+// HistogramBuilder counts commit matches per time bucket so that a search can
+// return a histogram instead of streaming every matching commit.
+type HistogramBuilder struct {
+	opts   protocol.HistogramOptions
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	author  protocol.Signature
+	buckets map[time.Time]int
+}
+
+func NewHistogramBuilder(opts protocol.HistogramOptions) *HistogramBuilder {
+	return &HistogramBuilder{
+		opts:   opts,
+		series: make(map[string]*histogramSeries),
+	}
+}
+
+// Add counts cm in the bucket of its author date.
+func (b *HistogramBuilder) Add(cm *protocol.CommitMatch) {
+	var key string
+	var author protocol.Signature
+	if b.opts.GroupBy == protocol.HistogramGroupByAuthor {
+		// Authors commonly vary the case of their email, but not the email
+		// itself. git log lists the newest commits first, so a series is
+		// labeled with the most recent name of its author.
+		key = strings.ToLower(cm.Author.Email)
+		author = protocol.Signature{Name: cm.Author.Name, Email: cm.Author.Email}
+	}
+
+	s, ok := b.series[key]
+	if !ok {
+		s = &histogramSeries{author: author, buckets: make(map[time.Time]int)}
+		b.series[key] = s
+	}
+	s.buckets[b.opts.Interval.Truncate(cm.Author.Date)]++
+}
+
+// Histogram returns the counts added so far. Series are ordered by their
+// total count, descending, and buckets by their start.
+func (b *HistogramBuilder) Histogram() *protocol.CommitHistogram {
+	type sortableSeries struct {
+		protocol.HistogramSeries
+		total int
+	}
+
+	all := make([]sortableSeries, 0, len(b.series))
+	for _, s := range b.series {
+		series := sortableSeries{HistogramSeries: protocol.HistogramSeries{
+			Author:  s.author,
+			Buckets: make([]protocol.HistogramBucket, 0, len(s.buckets)),
+		}}
+		for start, count := range s.buckets {
+			series.Buckets = append(series.Buckets, protocol.HistogramBucket{Start: start, Count: count})
+			series.total += count
+		}
+		sort.Slice(series.Buckets, func(i, j int) bool {
+			return series.Buckets[i].Start.Before(series.Buckets[j].Start)
+		})
+		all = append(all, series)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].total != all[j].total {
+			return all[i].total > all[j].total
+		}
+		return strings.ToLower(all[i].Author.Email) < strings.ToLower(all[j].Author.Email)
+	})
+
+	h := &protocol.CommitHistogram{Series: make([]protocol.HistogramSeries, 0, len(all))}
+	for _, s := range all {
+		h.Series = append(h.Series, s.HistogramSeries)
+	}
+	return h
+}
@@ -0,0 +1,63 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+func TestHistogramBuilder(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2023, 3, d, 12, 0, 0, 0, time.UTC)
+	}
+	commit := func(name, email string, date time.Time) *protocol.CommitMatch {
+		return &protocol.CommitMatch{Author: protocol.Signature{Name: name, Email: email, Date: date}}
+	}
+	// git log lists the newest commits first
+	commits := []*protocol.CommitMatch{
+		commit("Alice Smith", "alice@example.com", day(21)),
+		commit("bob", "bob@example.com", day(14)),
+		commit("alice", "Alice@example.com", day(14)),
+		commit("alice", "alice@example.com", day(13)),
+		commit("alice", "alice@example.com", day(2)),
+	}
+	week := func(d int) time.Time {
+		return time.Date(2023, 3, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("repo", func(t *testing.T) {
+		b := NewHistogramBuilder(protocol.HistogramOptions{Interval: protocol.HistogramIntervalWeek, GroupBy: protocol.HistogramGroupByRepo})
+		for _, c := range commits {
+			b.Add(c)
+		}
+		require.Equal(t, &protocol.CommitHistogram{Series: []protocol.HistogramSeries{{
+			Buckets: []protocol.HistogramBucket{
+				{Start: week(27).AddDate(0, -1, 0), Count: 1},
+				{Start: week(13), Count: 3},
+				{Start: week(20), Count: 1},
+			},
+		}}}, b.Histogram())
+	})
+
+	t.Run("author", func(t *testing.T) {
+		b := NewHistogramBuilder(protocol.HistogramOptions{Interval: protocol.HistogramIntervalMonth, GroupBy: protocol.HistogramGroupByAuthor})
+		for _, c := range commits {
+			b.Add(c)
+		}
+		require.Equal(t, &protocol.CommitHistogram{Series: []protocol.HistogramSeries{{
+			Author:  protocol.Signature{Name: "Alice Smith", Email: "alice@example.com"},
+			Buckets: []protocol.HistogramBucket{{Start: week(1), Count: 4}},
+		}, {
+			Author:  protocol.Signature{Name: "bob", Email: "bob@example.com"},
+			Buckets: []protocol.HistogramBucket{{Start: week(1), Count: 1}},
+		}}}, b.Histogram())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		b := NewHistogramBuilder(protocol.HistogramOptions{Interval: protocol.HistogramIntervalDay, GroupBy: protocol.HistogramGroupByRepo})
+		require.Empty(t, b.Histogram().Series)
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/perforce"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -303,6 +304,17 @@ func (gs *grpcServer) Search(req *proto.SearchRequest, ss proto.GitserverService
 		})
 	}
 
+	// For histogram searches, we count the matches on gitserver and only send
+	// the counts once the search is done.
+	var histogram *search.HistogramBuilder
+	if args.Histogram != nil {
+		histogram = search.NewHistogramBuilder(*args.Histogram)
+		onMatch = func(match *protocol.CommitMatch) error {
+			histogram.Add(match)
+			return nil
+		}
+	}
+
 	tr, ctx := trace.New(ss.Context(), "search")
 	defer tr.End()
 
@@ -311,6 +323,14 @@ func (gs *grpcServer) Search(req *proto.SearchRequest, ss proto.GitserverService
 		return err
 	}
 
+	if histogram != nil {
+		if err := ss.Send(&proto.SearchResponse{
+			Message: &proto.SearchResponse_Histogram{Histogram: histogram.Histogram().ToProto()},
+		}); err != nil {
+			return err
+		}
+	}
+
 	return ss.Send(&proto.SearchResponse{
 		Message: &proto.SearchResponse_LimitHit{
 			LimitHit: limitHit,
@@ -0,0 +1,180 @@
+package vcssyncer
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/nuget"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func NewDotNetPackagesSyncer(
+	connection *schema.DotNetPackagesConnection,
+	svc *dependencies.Service,
+	client *nuget.Client,
+	fs gitserverfs.FS,
+	getRemoteURLSource func(ctx context.Context, name api.RepoName) (RemoteURLSource, error),
+) VCSSyncer {
+	return &vcsPackagesSyncer{
+		logger:             log.Scoped("DotNetPackagesSyncer"),
+		typ:                "dotnet_packages",
+		scheme:             dependencies.DotNetPackagesScheme,
+		placeholder:        reposource.NewDotNetVersionedPackage("Sourcegraph.Placeholder", "0.0.0"),
+		svc:                svc,
+		configDeps:         connection.Dependencies,
+		source:             &dotnetDependencySource{client: client, fs: fs},
+		fs:                 fs,
+		getRemoteURLSource: getRemoteURLSource,
+	}
+}
+
+type dotnetDependencySource struct {
+	client *nuget.Client
+	fs     gitserverfs.FS
+}
+
+func (dotnetDependencySource) ParseVersionedPackageFromNameAndVersion(name reposource.PackageName, version string) (reposource.VersionedPackage, error) {
+	return reposource.NewDotNetVersionedPackage(name, version), nil
+}
+
+func (dotnetDependencySource) ParseVersionedPackageFromConfiguration(dep string) (reposource.VersionedPackage, error) {
+	return reposource.ParseDotNetVersionedPackage(dep), nil
+}
+
+func (dotnetDependencySource) ParsePackageFromName(name reposource.PackageName) (reposource.Package, error) {
+	return reposource.ParseDotNetPackageFromName(name), nil
+}
+
+func (dotnetDependencySource) ParsePackageFromRepoName(repoName api.RepoName) (reposource.Package, error) {
+	return reposource.ParseDotNetPackageFromRepoName(repoName)
+}
+
+func (s *dotnetDependencySource) Download(ctx context.Context, dir string, dep reposource.VersionedPackage) error {
+	versions, err := s.client.Versions(ctx, dep.PackageSyntax())
+	if err != nil {
+		return errors.Wrapf(err, "error listing versions of NuGet package %q", dep.PackageSyntax())
+	}
+	version, ok := resolveNuGetVersion(versions, dep.PackageVersion())
+	if !ok {
+		return &nugetVersionNotFoundError{dep: dep}
+	}
+
+	pkgContents, err := s.client.GetPackageContents(ctx, reposource.NewDotNetVersionedPackage(dep.PackageSyntax(), version))
+	if err != nil {
+		return errors.Wrapf(err, "error downloading NuGet package %q", dep.VersionedPackageSyntax())
+	}
+	defer pkgContents.Close()
+
+	mkdirTemp := func() (string, error) {
+		return s.fs.TempDir("nuget-packages")
+	}
+
+	if err = unpackDotNetPackage(pkgContents, mkdirTemp, dir); err != nil {
+		return errors.Wrapf(err, "failed to unzip NuGet package %q", dep.VersionedPackageSyntax())
+	}
+
+	return nil
+}
+
+type nugetVersionNotFoundError struct {
+	dep reposource.VersionedPackage
+}
+
+func (e *nugetVersionNotFoundError) Error() string {
+	return "NuGet package version not found: " + e.dep.VersionedPackageSyntax()
+}
+
+func (e *nugetVersionNotFoundError) NotFound() bool {
+	return true
+}
+
+// resolveNuGetVersion returns the published version that matches the wanted
+// version. NuGet versions are case-insensitive and trailing zero components
+// are insignificant, e.g. "1.0" and "1.0.0.0" both refer to "1.0.0".
+func resolveNuGetVersion(versions []string, want string) (string, bool) {
+	normalized := normalizeNuGetVersion(want)
+	for _, v := range versions {
+		if normalizeNuGetVersion(v) == normalized {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func normalizeNuGetVersion(version string) string {
+	version = strings.ToLower(strings.TrimSpace(version))
+	// Build metadata is not part of the version identity.
+	version, _, _ = strings.Cut(version, "+")
+	release, prerelease, hasPrerelease := strings.Cut(version, "-")
+
+	parts := strings.Split(release, ".")
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+	if len(parts) == 4 && parts[3] == "0" {
+		parts = parts[:3]
+	}
+	for i, p := range parts {
+		if trimmed := strings.TrimLeft(p, "0"); trimmed != "" {
+			parts[i] = trimmed
+		} else {
+			parts[i] = "0"
+		}
+	}
+
+	normalized := strings.Join(parts, ".")
+	if hasPrerelease {
+		normalized += "-" + prerelease
+	}
+	return normalized
+}
+
+// unpackDotNetPackage unpacks the given .nupkg archive into workDir, skipping
+// the packaging metadata that NuGet adds to every package and any files that
+// aren't valid or that are potentially malicious.
+func unpackDotNetPackage(pkg io.Reader, mkdirTemp func() (string, error), workDir string) error {
+	opts := unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			if path == "[Content_Types].xml" || strings.HasPrefix(path, "_rels/") || strings.HasPrefix(path, "package/") {
+				return false
+			}
+
+			const sizeLimit = 15 * 1024 * 1024
+			if file.Size() >= sizeLimit {
+				return false
+			}
+
+			malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+			return !malicious
+		},
+	}
+
+	// We cannot unzip in a streaming fashion, so we write the zip file to
+	// a temporary file.
+	tmpdir, err := mkdirTemp()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpdir)
+
+	zip, zipLen, err := writeZipToTemp(tmpdir, pkg)
+	if err != nil {
+		return err
+	}
+	defer zip.Close()
+
+	return unpack.Zip(zip, zipLen, workDir, opts)
+}
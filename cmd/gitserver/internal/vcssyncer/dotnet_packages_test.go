@@ -0,0 +1,79 @@
+package vcssyncer
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveNuGetVersion(t *testing.T) {
+	versions := []string{"1.0.0", "2.1.0-Beta.1", "3.0.0.1", "13.0.3"}
+
+	for _, tc := range []struct {
+		want    string
+		version string
+		ok      bool
+	}{
+		{want: "1.0.0", version: "1.0.0", ok: true},
+		{want: "1.0", version: "1.0.0", ok: true},
+		{want: "1.0.0.0", version: "1.0.0", ok: true},
+		{want: "01.00.00", version: "1.0.0", ok: true},
+		{want: "2.1.0-beta.1", version: "2.1.0-Beta.1", ok: true},
+		{want: "3.0.0.1", version: "3.0.0.1", ok: true},
+		{want: "13.0.3+sha.abc", version: "13.0.3", ok: true},
+		{want: "2.1.0", ok: false},
+		{want: "3.0.0", ok: false},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			version, ok := resolveNuGetVersion(versions, tc.want)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.version, version)
+		})
+	}
+}
+
+func TestUnpackDotNetPackage(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"package/services/metadata/core-properties/1.psmdcp",
+		"Newtonsoft.Json.nuspec",
+		"lib/net6.0/Newtonsoft.Json.dll",
+		"src/JsonConvert.cs",
+		"../escape.cs",
+	} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(name))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	tmp := t.TempDir()
+	workDir := t.TempDir()
+	require.NoError(t, unpackDotNetPackage(&buf, func() (string, error) { return tmp, nil }, workDir))
+
+	var got []string
+	require.NoError(t, filepath.Walk(workDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		got = append(got, strings.TrimPrefix(path, workDir+"/"))
+		return nil
+	}))
+	sort.Strings(got)
+
+	require.Equal(t, []string{
+		"Newtonsoft.Json.nuspec",
+		"lib/net6.0/Newtonsoft.Json.dll",
+		"src/JsonConvert.cs",
+	}, got)
+}
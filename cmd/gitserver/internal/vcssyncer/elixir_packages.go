@@ -0,0 +1,127 @@
+package vcssyncer
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/hex"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func NewElixirPackagesSyncer(
+	connection *schema.ElixirPackagesConnection,
+	svc *dependencies.Service,
+	client *hex.Client,
+	fs gitserverfs.FS,
+	getRemoteURLSource func(ctx context.Context, name api.RepoName) (RemoteURLSource, error),
+) VCSSyncer {
+	return &vcsPackagesSyncer{
+		logger:             log.Scoped("ElixirPackagesSyncer"),
+		typ:                "elixir_packages",
+		scheme:             dependencies.ElixirPackagesScheme,
+		placeholder:        reposource.NewElixirVersionedPackage("sourcegraph_placeholder", "0.0.0"),
+		svc:                svc,
+		configDeps:         connection.Dependencies,
+		source:             &elixirDependencySource{client: client, fs: fs},
+		fs:                 fs,
+		getRemoteURLSource: getRemoteURLSource,
+	}
+}
+
+type elixirDependencySource struct {
+	client *hex.Client
+	fs     gitserverfs.FS
+}
+
+func (elixirDependencySource) ParseVersionedPackageFromNameAndVersion(name reposource.PackageName, version string) (reposource.VersionedPackage, error) {
+	return reposource.NewElixirVersionedPackage(name, version), nil
+}
+
+func (elixirDependencySource) ParseVersionedPackageFromConfiguration(dep string) (reposource.VersionedPackage, error) {
+	return reposource.ParseElixirVersionedPackage(dep), nil
+}
+
+func (elixirDependencySource) ParsePackageFromName(name reposource.PackageName) (reposource.Package, error) {
+	return reposource.ParseElixirPackageFromName(name), nil
+}
+
+func (elixirDependencySource) ParsePackageFromRepoName(repoName api.RepoName) (reposource.Package, error) {
+	return reposource.ParseElixirPackageFromRepoName(repoName)
+}
+
+func (s *elixirDependencySource) Download(ctx context.Context, dir string, dep reposource.VersionedPackage) error {
+	pkgContents, err := s.client.GetPackageContents(ctx, dep)
+	if err != nil {
+		return errors.Wrapf(err, "error downloading Hex package %q", dep.VersionedPackageSyntax())
+	}
+	defer pkgContents.Close()
+
+	tmpDir, err := s.fs.TempDir("hex-packages")
+	if err != nil {
+		return errors.Wrap(err, "failed to create a temporary directory")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err = unpackElixirPackage(pkgContents, tmpDir, dir); err != nil {
+		return errors.Wrapf(err, "failed to unpack Hex package %q", dep.VersionedPackageSyntax())
+	}
+
+	return nil
+}
+
+// unpackElixirPackage unpacks the given Hex tarball into workDir. The outer
+// tarball holds the package sources in contents.tar.gz and the package
+// metadata in metadata.config, which is kept as hex-metadata.config.
+func unpackElixirPackage(pkg io.Reader, tmpDir, workDir string) error {
+	opts := unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			return path == "contents.tar.gz" || path == "metadata.config"
+		},
+	}
+
+	if err := unpack.Tar(pkg, tmpDir, opts); err != nil {
+		return errors.Wrap(err, "failed to unpack downloaded tar")
+	}
+
+	contents, err := os.Open(filepath.Join(tmpDir, "contents.tar.gz"))
+	if err != nil {
+		return errors.Wrap(err, "failed to read contents archive")
+	}
+	defer contents.Close()
+
+	opts = unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			const sizeLimit = 15 * 1024 * 1024
+			if file.Size() >= sizeLimit {
+				return false
+			}
+
+			malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+			return !malicious
+		},
+	}
+	if err := unpack.Tgz(contents, workDir, opts); err != nil {
+		return err
+	}
+
+	metadata, err := os.ReadFile(filepath.Join(tmpDir, "metadata.config"))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(workDir, "hex-metadata.config"), metadata, 0o644)
+}
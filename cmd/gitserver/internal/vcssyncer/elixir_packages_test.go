@@ -0,0 +1,46 @@
+package vcssyncer
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackElixirPackage(t *testing.T) {
+	contents := createTgz(t, []fileInfo{
+		{path: "mix.exs", contents: []byte("defmodule Phoenix.MixProject do")},
+		{path: "lib/phoenix.ex", contents: []byte("defmodule Phoenix do")},
+		{path: ".git/index", contents: []byte("filter me")},
+	})
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, info := range []fileInfo{
+		{path: "VERSION", contents: []byte("3")},
+		{path: "CHECKSUM", contents: []byte("abc")},
+		{path: "metadata.config", contents: []byte(`{<<"name">>,<<"phoenix">>}.`)},
+		{path: "contents.tar.gz", contents: contents},
+	} {
+		require.NoError(t, addFileToTarball(t, tw, info))
+	}
+	require.NoError(t, tw.Close())
+
+	workDir := t.TempDir()
+	require.NoError(t, unpackElixirPackage(&buf, t.TempDir(), workDir))
+
+	entries, err := os.ReadDir(workDir)
+	require.NoError(t, err)
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	require.Equal(t, []string{"hex-metadata.config", "lib", "mix.exs"}, got)
+
+	b, err := os.ReadFile(filepath.Join(workDir, "lib", "phoenix.ex"))
+	require.NoError(t, err)
+	require.Equal(t, "defmodule Phoenix do", string(b))
+}
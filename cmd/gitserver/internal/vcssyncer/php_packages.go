@@ -0,0 +1,117 @@
+package vcssyncer
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/packagist"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func NewPHPPackagesSyncer(
+	connection *schema.PHPPackagesConnection,
+	svc *dependencies.Service,
+	client *packagist.Client,
+	fs gitserverfs.FS,
+	getRemoteURLSource func(ctx context.Context, name api.RepoName) (RemoteURLSource, error),
+) VCSSyncer {
+	return &vcsPackagesSyncer{
+		logger:             log.Scoped("PHPPackagesSyncer"),
+		typ:                "php_packages",
+		scheme:             dependencies.PHPPackagesScheme,
+		placeholder:        reposource.NewPHPVersionedPackage("sourcegraph/placeholder", "0.0.0"),
+		svc:                svc,
+		configDeps:         connection.Dependencies,
+		source:             &phpDependencySource{client: client, fs: fs},
+		fs:                 fs,
+		getRemoteURLSource: getRemoteURLSource,
+	}
+}
+
+type phpDependencySource struct {
+	client *packagist.Client
+	fs     gitserverfs.FS
+}
+
+func (phpDependencySource) ParseVersionedPackageFromNameAndVersion(name reposource.PackageName, version string) (reposource.VersionedPackage, error) {
+	return reposource.ParsePHPVersionedPackage(string(name) + "@" + version)
+}
+
+func (phpDependencySource) ParseVersionedPackageFromConfiguration(dep string) (reposource.VersionedPackage, error) {
+	return reposource.ParsePHPVersionedPackage(dep)
+}
+
+func (phpDependencySource) ParsePackageFromName(name reposource.PackageName) (reposource.Package, error) {
+	return reposource.ParsePHPPackageFromName(name)
+}
+
+func (phpDependencySource) ParsePackageFromRepoName(repoName api.RepoName) (reposource.Package, error) {
+	return reposource.ParsePHPPackageFromRepoName(repoName)
+}
+
+func (s *phpDependencySource) Download(ctx context.Context, dir string, dep reposource.VersionedPackage) error {
+	pkgContents, err := s.client.GetPackageContents(ctx, dep)
+	if err != nil {
+		return errors.Wrapf(err, "error downloading Composer package %q", dep.VersionedPackageSyntax())
+	}
+	defer pkgContents.Close()
+
+	mkdirTemp := func() (string, error) {
+		return s.fs.TempDir("php-packages")
+	}
+
+	if err = unpackPHPPackage(pkgContents, mkdirTemp, dir); err != nil {
+		return errors.Wrapf(err, "failed to unzip Composer package %q", dep.VersionedPackageSyntax())
+	}
+
+	return nil
+}
+
+// unpackPHPPackage unpacks the given Composer dist archive into workDir. Dist
+// archives contain a single top-level directory named after the source commit,
+// which is stripped.
+func unpackPHPPackage(pkg io.Reader, mkdirTemp func() (string, error), workDir string) error {
+	opts := unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			const sizeLimit = 15 * 1024 * 1024
+			if file.Size() >= sizeLimit {
+				return false
+			}
+
+			malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+			return !malicious
+		},
+	}
+
+	// We cannot unzip in a streaming fashion, so we write the zip file to
+	// a temporary file.
+	tmpdir, err := mkdirTemp()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpdir)
+
+	zip, zipLen, err := writeZipToTemp(tmpdir, pkg)
+	if err != nil {
+		return err
+	}
+	defer zip.Close()
+
+	if err := unpack.Zip(zip, zipLen, workDir, opts); err != nil {
+		return err
+	}
+
+	return stripSingleOutermostDirectory(workDir)
+}
@@ -0,0 +1,50 @@
+package vcssyncer
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackPHPPackage(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{
+		"monolog-monolog-fb2c324/composer.json",
+		"monolog-monolog-fb2c324/README.md",
+		"monolog-monolog-fb2c324/src/Monolog/Logger.php",
+		"../escape.php",
+	} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(name))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	tmp := t.TempDir()
+	workDir := t.TempDir()
+	require.NoError(t, unpackPHPPackage(&buf, func() (string, error) { return tmp, nil }, workDir))
+
+	var got []string
+	require.NoError(t, filepath.Walk(workDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		got = append(got, strings.TrimPrefix(path, workDir+"/"))
+		return nil
+	}))
+	sort.Strings(got)
+
+	require.Equal(t, []string{
+		"README.md",
+		"composer.json",
+		"src/Monolog/Logger.php",
+	}, got)
+}
@@ -19,7 +19,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/crates"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodproxy"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/hex"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/npm"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/nuget"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/packagist"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/pypi"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/rubygems"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
@@ -177,6 +180,39 @@ func NewVCSSyncer(ctx context.Context, opts *NewVCSSyncerOpts) (VCSSyncer, error
 				return nil, err
 			}
 			return NewRubyPackagesSyncer(&c, opts.DepsSvc, cli, opts.FS, opts.GetRemoteURLSource), nil
+		case extsvc.TypeDotNetPackages:
+			var c schema.DotNetPackagesConnection
+			urn, err := extractOptions(&c)
+			if err != nil {
+				return nil, err
+			}
+			cli, err := nuget.NewClient(urn, c.Repository, httpcli.ExternalClientFactory)
+			if err != nil {
+				return nil, err
+			}
+			return NewDotNetPackagesSyncer(&c, opts.DepsSvc, cli, opts.FS, opts.GetRemoteURLSource), nil
+		case extsvc.TypePHPPackages:
+			var c schema.PHPPackagesConnection
+			urn, err := extractOptions(&c)
+			if err != nil {
+				return nil, err
+			}
+			cli, err := packagist.NewClient(urn, c.Repository, httpcli.ExternalClientFactory)
+			if err != nil {
+				return nil, err
+			}
+			return NewPHPPackagesSyncer(&c, opts.DepsSvc, cli, opts.FS, opts.GetRemoteURLSource), nil
+		case extsvc.TypeElixirPackages:
+			var c schema.ElixirPackagesConnection
+			urn, err := extractOptions(&c)
+			if err != nil {
+				return nil, err
+			}
+			cli, err := hex.NewClient(urn, c.Repository, httpcli.ExternalClientFactory)
+			if err != nil {
+				return nil, err
+			}
+			return NewElixirPackagesSyncer(&c, opts.DepsSvc, cli, opts.FS, opts.GetRemoteURLSource), nil
 		}
 
 		return NewGitRepoSyncer(opts.Logger, opts.RecordingCommandFactory, opts.GetRemoteURLSource), nil
@@ -0,0 +1,410 @@
+package search
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/casetransform"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// An ngram index is a sidecar to a zip archive in the store which lets
+// regexSearch skip files which can not contain a match without reading them.
+//
+// For every file in the archive the index contains a bloom filter of the
+// trigrams of its (ASCII lower cased) content. A regex query is turned into
+// the trigrams any match must contain (see toNgramQuery), and files whose
+// filter does not contain them are skipped. Bloom filters can have false
+// positives but no false negatives, so the index only prunes files which
+// can not match.
+//
+// We use a filter per file rather than an inverted index since it can be
+// written in a single pass over the archive with constant memory, and
+// checking a few bits per file is cheap compared to reading the file.
+//
+// The index is written next to the zip when the archive is fetched, and is
+// removed with the zip when it is evicted. The format is
+//
+//	header:  magic (4 bytes) | numFiles (uint32) | zipSize (uint64)
+//	filters: one bloom filter per file, in the order of zipFile.Files
+//	offsets: numFiles+1 uint64 offsets of the filters, relative to the end
+//	         of the header
+//	footer:  offset of the offsets (uint64), relative to the start
+//
+// All integers are little endian. A file has an empty filter if it was not
+// indexed (for example because a contentTransformer applies to it). Such
+// files are always searched.
+
+// ngramIndexMagic identifies ngram index files. Bump the version when the
+// format or the way we compute trigrams changes.
+const ngramIndexMagic = "NGR1"
+
+const (
+	ngramIndexHeaderSize = 4 + 4 + 8
+	ngramIndexFooterSize = 8
+
+	// ngramBitsPerTrigram is the number of filter bits per distinct trigram
+	// in a file. With ngramHashes hash functions this gives a false positive
+	// rate of roughly 1% per trigram.
+	ngramBitsPerTrigram = 10
+	ngramHashes         = 4
+
+	// minNgramFilterBits and maxNgramFilterBits bound the size of a filter.
+	// Filters of very large files are less precise but stay small.
+	minNgramFilterBits = 64
+	maxNgramFilterBits = 1 << 20
+
+	// ngramIndexExt is the extension of ngram index files, which are written
+	// next to the zip archive they index.
+	ngramIndexExt = ".ngrams"
+)
+
+// ngramIndexPath returns the path of the ngram index of the zip archive at
+// zipPath.
+func ngramIndexPath(zipPath string) string {
+	return strings.TrimSuffix(zipPath, ".zip") + ngramIndexExt
+}
+
+// ngramIndex is a loaded ngram index for a zipFile.
+type ngramIndex struct {
+	// data is the mmap'd index file, or the index itself in tests.
+	data []byte
+	// offsets is the slice of data containing the offsets of the filters.
+	offsets []byte
+	f       *os.File
+}
+
+// parseNgramIndex parses the ngram index data of zf.
+func parseNgramIndex(data []byte, zf *zipFile) (*ngramIndex, error) {
+	if len(data) < ngramIndexHeaderSize+ngramIndexFooterSize || string(data[:4]) != ngramIndexMagic {
+		return nil, errors.New("not an ngram index")
+	}
+	numFiles := int(binary.LittleEndian.Uint32(data[4:]))
+	zipSize := binary.LittleEndian.Uint64(data[8:])
+	if numFiles != len(zf.Files) || zipSize != uint64(len(zf.Data)) {
+		return nil, errors.Errorf("ngram index is for a different archive: %d files of %d bytes", numFiles, zipSize)
+	}
+
+	start := binary.LittleEndian.Uint64(data[len(data)-ngramIndexFooterSize:])
+	end := start + uint64(numFiles+1)*8
+	if start < ngramIndexHeaderSize || end != uint64(len(data)-ngramIndexFooterSize) {
+		return nil, errors.New("ngram index is corrupt")
+	}
+	idx := &ngramIndex{data: data, offsets: data[start:end]}
+
+	// Validate the offsets once so that filter does not need to.
+	filters := uint64(start - ngramIndexHeaderSize)
+	prev := uint64(0)
+	for i := 0; i <= numFiles; i++ {
+		off := binary.LittleEndian.Uint64(idx.offsets[i*8:])
+		if off < prev || off > filters {
+			return nil, errors.New("ngram index is corrupt")
+		}
+		if i > 0 {
+			if n := off - prev; n != 0 && (n < minNgramFilterBits/8 || n&(n-1) != 0) {
+				return nil, errors.New("ngram index is corrupt")
+			}
+		}
+		prev = off
+	}
+	return idx, nil
+}
+
+// openNgramIndex loads the ngram index written next to the zip archive at
+// zipPath for zf. It returns an error satisfying os.IsNotExist if there is
+// no index.
+func openNgramIndex(zipPath string, zf *zipFile) (_ *ngramIndex, err error) {
+	f, err := os.Open(ngramIndexPath(zipPath))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+		}
+	}()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	data, err := mmap(f.Name(), f, fi)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := parseNgramIndex(data, zf)
+	if err != nil {
+		_ = unmap(data)
+		return nil, err
+	}
+	idx.f = f
+	return idx, nil
+}
+
+// close releases the resources of an index loaded with openNgramIndex.
+func (idx *ngramIndex) close() error {
+	if idx.f == nil {
+		return nil
+	}
+	err := unmap(idx.data)
+	if err1 := idx.f.Close(); err == nil {
+		err = err1
+	}
+	return err
+}
+
+// filter returns the bloom filter of the i-th file of the zipFile.
+func (idx *ngramIndex) filter(i int) ngramFilter {
+	start := binary.LittleEndian.Uint64(idx.offsets[i*8:])
+	end := binary.LittleEndian.Uint64(idx.offsets[i*8+8:])
+	return ngramFilter(idx.data[ngramIndexHeaderSize+start : ngramIndexHeaderSize+end])
+}
+
+// mayMatch returns false if the i-th file of the zipFile can not match q.
+func (idx *ngramIndex) mayMatch(i int, q ngramQuery) bool {
+	f := idx.filter(i)
+	if len(f) == 0 {
+		// Not indexed
+		return true
+	}
+	return q.mayMatch(f)
+}
+
+// writeNgramIndex writes the ngram index of zf to w.
+func writeNgramIndex(w io.Writer, zf *zipFile) error {
+	bw := bufio.NewWriter(w)
+
+	var header [ngramIndexHeaderSize]byte
+	copy(header[:], ngramIndexMagic)
+	binary.LittleEndian.PutUint32(header[4:], uint32(len(zf.Files)))
+	binary.LittleEndian.PutUint64(header[8:], uint64(len(zf.Data)))
+	_, _ = bw.Write(header[:])
+
+	offsets := make([]byte, 0, (len(zf.Files)+1)*8)
+	offsets = binary.LittleEndian.AppendUint64(offsets, 0)
+
+	var (
+		written  uint64
+		trigrams []uint32
+		filter   ngramFilter
+		lower    []byte
+	)
+	for i := range zf.Files {
+		f := &zf.Files[i]
+
+		// We can not index files which are transformed before they are
+		// searched, since the trigrams of the transformed text are only
+		// known at search time.
+		if f.Transform == 0 {
+			lower = slices.Grow(lower[:0], int(f.Len))[:f.Len]
+			casetransform.BytesToLowerASCII(lower, zf.DataFor(f))
+
+			trigrams = appendTrigrams(trigrams[:0], lower)
+			slices.Sort(trigrams)
+			trigrams = slices.Compact(trigrams)
+
+			filter = newNgramFilter(filter, len(trigrams))
+			for _, t := range trigrams {
+				filter.add(t)
+			}
+			_, _ = bw.Write(filter)
+			written += uint64(len(filter))
+		}
+		offsets = binary.LittleEndian.AppendUint64(offsets, written)
+	}
+
+	_, _ = bw.Write(offsets)
+	var footer [ngramIndexFooterSize]byte
+	binary.LittleEndian.PutUint64(footer[:], ngramIndexHeaderSize+written)
+	_, _ = bw.Write(footer[:])
+	return bw.Flush()
+}
+
+// buildNgramIndex writes the ngram index of the zip archive at zipPath next
+// to it. The index is written atomically, so concurrent builds and readers
+// are safe.
+func buildNgramIndex(zipPath string) (err error) {
+	zf, err := readZipFile(zipPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := unmap(zf.Data); err == nil {
+			err = err1
+		}
+		if err1 := zf.f.Close(); err == nil {
+			err = err1
+		}
+	}()
+
+	path := ngramIndexPath(zipPath)
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	err = writeNgramIndex(tmp, zf)
+	if err1 := tmp.Close(); err == nil {
+		err = err1
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to write ngram index")
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// appendTrigrams appends the trigrams of b to dst.
+func appendTrigrams(dst []uint32, b []byte) []uint32 {
+	for i := 0; i+3 <= len(b); i++ {
+		dst = append(dst, uint32(b[i])<<16|uint32(b[i+1])<<8|uint32(b[i+2]))
+	}
+	return dst
+}
+
+// ngramFilter is a bloom filter of trigrams. Its size in bits is a power of
+// two.
+type ngramFilter []byte
+
+// newNgramFilter returns an empty filter sized for n trigrams, reusing the
+// memory of buf.
+func newNgramFilter(buf ngramFilter, n int) ngramFilter {
+	bits := minNgramFilterBits
+	for bits < n*ngramBitsPerTrigram && bits < maxNgramFilterBits {
+		bits <<= 1
+	}
+	buf = slices.Grow(buf[:0], bits/8)[:bits/8]
+	clear(buf)
+	return buf
+}
+
+// ngramHash returns the two hashes of trigram t we derive the bit positions
+// from (double hashing).
+func ngramHash(t uint32) (h1, h2 uint32) {
+	h := uint64(t) * 0x9e3779b97f4a7c15
+	return uint32(h >> 32), uint32(h) | 1
+}
+
+func (f ngramFilter) add(t uint32) {
+	mask := uint32(len(f)*8 - 1)
+	h1, h2 := ngramHash(t)
+	for i := uint32(0); i < ngramHashes; i++ {
+		bit := (h1 + i*h2) & mask
+		f[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+func (f ngramFilter) contains(t uint32) bool {
+	mask := uint32(len(f)*8 - 1)
+	h1, h2 := ngramHash(t)
+	for i := uint32(0); i < ngramHashes; i++ {
+		bit := (h1 + i*h2) & mask
+		if f[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ngramQuery is a condition on the trigrams of a file which holds for every
+// file a matchTree can match.
+type ngramQuery interface {
+	// mayMatch returns false if a file with filter f can not match.
+	mayMatch(f ngramFilter) bool
+}
+
+// ngramTerms holds if the file contains all of the trigrams.
+type ngramTerms []uint32
+
+func (q ngramTerms) mayMatch(f ngramFilter) bool {
+	for _, t := range q {
+		if !f.contains(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// ngramAnd holds if all of its children hold.
+type ngramAnd []ngramQuery
+
+func (q ngramAnd) mayMatch(f ngramFilter) bool {
+	for _, c := range q {
+		if !c.mayMatch(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// ngramOr holds if any of its children hold.
+type ngramOr []ngramQuery
+
+func (q ngramOr) mayMatch(f ngramFilter) bool {
+	for _, c := range q {
+		if c.mayMatch(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// toNgramQuery returns the ngramQuery for the file contents matched by m, or
+// nil if every file may match.
+func toNgramQuery(m matchTree) ngramQuery {
+	switch m := m.(type) {
+	case *regexMatchTree:
+		if m.isNegated {
+			// Files match if they do not contain the pattern, which we
+			// can't tell from trigrams.
+			return nil
+		}
+		literal, _ := m.re.LiteralPrefix()
+		if literal == "" {
+			literal = string(m.literalSubstring)
+		}
+		if len(literal) < 3 {
+			return nil
+		}
+		// The index is case insensitive, so a case sensitive literal
+		// narrows down the files just as well once lower cased.
+		lower := make([]byte, len(literal))
+		casetransform.BytesToLowerASCII(lower, []byte(literal))
+		trigrams := appendTrigrams(nil, lower)
+		slices.Sort(trigrams)
+		return ngramTerms(slices.Compact(trigrams))
+
+	case *andMatchTree:
+		var q ngramAnd
+		for _, c := range m.children {
+			if cq := toNgramQuery(c); cq != nil {
+				q = append(q, cq)
+			}
+		}
+		if len(q) == 0 {
+			return nil
+		}
+		return q
+
+	case *orMatchTree:
+		q := make(ngramOr, 0, len(m.children))
+		for _, c := range m.children {
+			cq := toNgramQuery(c)
+			if cq == nil {
+				return nil
+			}
+			q = append(q, cq)
+		}
+		if len(q) == 0 {
+			return nil
+		}
+		return q
+
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,149 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/searcher/protocol"
+)
+
+func TestNgramQuery(t *testing.T) {
+	files := map[string]string{
+		"a.go":        "package main\n\nfunc Handler() {}\n",
+		"b.go":        "package main\n\nfunc other() {}\n",
+		"c.txt":       "the quick brown fox\n",
+		"d.ipynb":     `{"cells": [{"cell_type": "code", "source": "Handler()"}]}`,
+		"empty.txt":   "",
+		"notext.data": "ab",
+	}
+	data, err := createZip(files)
+	require.NoError(t, err)
+	zf, err := mockZipFile(data)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeNgramIndex(&buf, zf))
+	idx, err := parseNgramIndex(buf.Bytes(), zf)
+	require.NoError(t, err)
+
+	cases := []struct {
+		query    protocol.QueryNode
+		caseSens bool
+		// want are the files which may match. Notebooks are transformed
+		// before they are searched, so they always may match.
+		want []string
+	}{{
+		query: &protocol.PatternNode{Value: "handler"},
+		want:  []string{"a.go", "d.ipynb"},
+	}, {
+		query:    &protocol.PatternNode{Value: "Handler"},
+		caseSens: true,
+		want:     []string{"a.go", "d.ipynb"},
+	}, {
+		query: &protocol.PatternNode{Value: "func [a-z]+", IsRegExp: true},
+		want:  []string{"a.go", "b.go", "d.ipynb"},
+	}, {
+		query: &protocol.PatternNode{Value: "quick", IsNegated: true},
+		want:  []string{"a.go", "b.go", "c.txt", "d.ipynb", "empty.txt", "notext.data"},
+	}, {
+		query: &protocol.PatternNode{Value: "fo"},
+		want:  []string{"a.go", "b.go", "c.txt", "d.ipynb", "empty.txt", "notext.data"},
+	}, {
+		query: &protocol.AndNode{Children: []protocol.QueryNode{
+			&protocol.PatternNode{Value: "package"},
+			&protocol.PatternNode{Value: "other"},
+		}},
+		want: []string{"b.go", "d.ipynb"},
+	}, {
+		query: &protocol.OrNode{Children: []protocol.QueryNode{
+			&protocol.PatternNode{Value: "other"},
+			&protocol.PatternNode{Value: "brown"},
+		}},
+		want: []string{"b.go", "c.txt", "d.ipynb"},
+	}, {
+		query: &protocol.OrNode{Children: []protocol.QueryNode{
+			&protocol.PatternNode{Value: "other"},
+			&protocol.PatternNode{Value: ".", IsRegExp: true},
+		}},
+		want: []string{"a.go", "b.go", "c.txt", "d.ipynb", "empty.txt", "notext.data"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.query.String(), func(t *testing.T) {
+			m, err := toMatchTree(tc.query, tc.caseSens)
+			require.NoError(t, err)
+			q := toNgramQuery(m)
+
+			var got []string
+			for i := range zf.Files {
+				if q == nil || idx.mayMatch(i, q) {
+					got = append(got, zf.Files[i].Name)
+				}
+			}
+			sort.Strings(got)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseNgramIndex(t *testing.T) {
+	data, err := createZip(map[string]string{"a.go": "package main\n"})
+	require.NoError(t, err)
+	zf, err := mockZipFile(data)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeNgramIndex(&buf, zf))
+	index := buf.Bytes()
+
+	_, err = parseNgramIndex(index, zf)
+	require.NoError(t, err)
+
+	// The index of another archive is rejected
+	other, err := createZip(map[string]string{"a.go": "package other\n"})
+	require.NoError(t, err)
+	otherZf, err := mockZipFile(other)
+	require.NoError(t, err)
+	_, err = parseNgramIndex(index, otherZf)
+	require.Error(t, err)
+
+	// Truncated and corrupt indexes are rejected
+	_, err = parseNgramIndex(index[:len(index)-1], zf)
+	require.Error(t, err)
+	corrupt := bytes.Clone(index)
+	corrupt[len(corrupt)-ngramIndexFooterSize] ^= 0xff
+	_, err = parseNgramIndex(corrupt, zf)
+	require.Error(t, err)
+}
+
+func TestRegexSearchNgramIndex(t *testing.T) {
+	data, err := createZip(map[string]string{
+		"a.go": "package main\n\nfunc Handler() {}\n",
+		"b.go": "package main\n\nfunc other() {}\n",
+	})
+	require.NoError(t, err)
+	path := tempZipFileOnDisk(t, data)
+	require.NoError(t, buildNgramIndex(path))
+
+	var zc zipCache
+	zf, err := zc.Get(path)
+	require.NoError(t, err)
+	require.NotNil(t, zf.ngrams.Load())
+
+	p := &protocol.PatternInfo{Query: &protocol.PatternNode{Value: "handler"}, Limit: 10}
+	matches, err := regexSearchBatch(context.Background(), p, zf, 0)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, "a.go", matches[0].Path)
+	zf.Close()
+
+	// Eviction removes the index with the zip
+	zc.delete(path, nil)
+	_, err = os.Stat(ngramIndexPath(path))
+	require.True(t, os.IsNotExist(err))
+}
@@ -88,10 +88,20 @@ func regexSearch(
 
 	files := zf.Files
 
+	// If the archive has an ngram index, we use it to skip reading the
+	// content of files which can not match.
+	ngrams := zf.ngrams.Load()
+	var nq ngramQuery
+	if ngrams != nil {
+		nq = toNgramQuery(m)
+	}
+	tr.SetAttributes(attribute.Bool("ngramIndex", ngrams != nil), attribute.Bool("ngramQuery", nq != nil))
+
 	var (
-		lastFileIdx   = atomic.NewInt32(-1)
-		filesSkipped  atomic.Uint32
-		filesSearched atomic.Uint32
+		lastFileIdx      = atomic.NewInt32(-1)
+		filesSkipped     atomic.Uint32
+		filesSearched    atomic.Uint32
+		filesPrefiltered atomic.Uint32
 	)
 
 	g, ctx := errgroup.WithContext(ctx)
@@ -148,13 +158,16 @@ func regexSearch(
 					if _, ok := m.(*allMatchTree); ok {
 						// Avoid loading the file if this pattern always matches
 						match = true
+					} else if nq != nil && !ngrams.mayMatch(idx, nq) {
+						// Avoid loading the file if its trigrams rule out a match
+						filesPrefiltered.Inc()
 					} else {
 						l.load(f)
 
 						// find limit+1 matches so we know whether we hit the limit
 						var locs [][]int
 						match, locs = m.MatchesFile(l.fileMatchBuf, sender.Remaining()+1)
-						fm = locsToFileMatch(l.fileBuf, f.Name, locs, contextLines)
+						fm = l.toFileMatch(f.Name, locs, contextLines)
 					}
 				}
 
@@ -181,6 +194,7 @@ func regexSearch(
 		"done",
 		attribute.Int("filesSkipped", int(filesSkipped.Load())),
 		attribute.Int("filesSearched", int(filesSearched.Load())),
+		attribute.Int("filesPrefiltered", int(filesPrefiltered.Load())),
 	)
 
 	return err
@@ -197,7 +211,15 @@ type fileLoader struct {
 
 	// fileBuf is the original data (used for the content preview)
 	fileBuf []byte
-	// fileMatchBuf is what we match against, and may be a lower-cased version of fileBuf
+	// textBuf is the text of the file, which differs from fileBuf if a
+	// contentTransformer applies to the file. It is nil if the content of
+	// the file should not be searched.
+	textBuf []byte
+	// textMap maps offsets in textBuf to offsets in fileBuf. If it is nil
+	// but textBuf differs from fileBuf, textBuf is used for the content
+	// preview.
+	textMap *offsetMap
+	// fileMatchBuf is what we match against, and may be a lower-cased version of textBuf
 	fileMatchBuf []byte
 
 	// scratchBuf is reused between file searches to avoid
@@ -214,19 +236,37 @@ func (l *fileLoader) load(f *srcFile) {
 	l.currFile = f
 
 	l.fileBuf = l.zf.DataFor(f)
-	l.fileMatchBuf = l.fileBuf
+	l.textBuf, l.textMap = l.fileBuf, nil
+	if t := f.transformer(); t != nil {
+		var err error
+		l.textBuf, l.textMap, err = t.Transform(l.fileBuf)
+		if err != nil {
+			l.textBuf, l.textMap = nil, nil
+		}
+	}
+
+	l.fileMatchBuf = l.textBuf
 	if !l.isCaseSensitive {
 		// If we are ignoring case, we transform the input instead of
 		// relying on the regular expression engine which can be
 		// slow. compilePattern has already lowercased the pattern. We also
 		// trade some correctness for perf by using a non-utf8 aware
 		// lowercase function.
-		if l.scratchBuf == nil {
-			l.scratchBuf = make([]byte, l.zf.MaxLen)
+		if len(l.scratchBuf) < len(l.textBuf) {
+			l.scratchBuf = make([]byte, max(l.zf.MaxLen, len(l.textBuf)))
 		}
-		l.fileMatchBuf = l.scratchBuf[:len(l.fileBuf)]
-		casetransform.BytesToLowerASCII(l.fileMatchBuf, l.fileBuf)
+		l.fileMatchBuf = l.scratchBuf[:len(l.textBuf)]
+		casetransform.BytesToLowerASCII(l.fileMatchBuf, l.textBuf)
+	}
+}
+
+// toFileMatch converts the locations of matches in fileMatchBuf to a file
+// match against the content of the loaded file.
+func (l *fileLoader) toFileMatch(name string, locs [][]int, contextLines int32) protocol.FileMatch {
+	if l.textMap != nil {
+		return locsToFileMatch(l.fileBuf, name, l.textMap.mapLocs(locs), contextLines)
 	}
+	return locsToFileMatch(l.textBuf, name, locs, contextLines)
 }
 
 // readAll will read r until EOF into b. It returns the number of bytes
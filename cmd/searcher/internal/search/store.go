@@ -87,6 +87,11 @@ type Store struct {
 	// ObservationCtx is used to configure observability in diskcache.
 	ObservationCtx *observation.Context
 
+	// DisableNgramIndex disables building and using ngram indexes of the
+	// fetched archives, which let repeated searches of an archive skip files
+	// which can not match.
+	DisableNgramIndex bool
+
 	// once protects Start
 	once sync.Once
 
@@ -98,6 +103,9 @@ type Store struct {
 
 	// zipCache provides efficient access to repo zip files.
 	zipCache zipCache
+
+	// ngramBuilds tracks the ngram indexes being built in the background.
+	ngramBuilds sync.WaitGroup
 }
 
 // FilterFunc filters tar files based on their header.
@@ -111,9 +119,11 @@ type FilterFunc func(hdr *tar.Header) bool
 func (s *Store) Start() {
 	s.once.Do(func() {
 		s.fetchLimiter = limiter.NewMutable(15)
+		s.zipCache.disableNgrams = s.DisableNgramIndex
 		s.cache = diskcache.NewStore(s.Path, "store",
 			diskcache.WithBackgroundTimeout(s.BackgroundTimeout),
 			diskcache.WithBeforeEvict(s.zipCache.delete),
+			diskcache.WithSidecarExt(ngramIndexExt),
 			diskcache.WithobservationCtx(s.ObservationCtx),
 		)
 		_ = os.MkdirAll(s.Path, 0o700)
@@ -162,6 +172,7 @@ func (s *Store) PrepareZip(ctx context.Context, repo api.RepoName, commit api.Co
 	h := sha256.New()
 	_, _ = fmt.Fprintf(h, "%q %q", repo, commit)
 	filter.HashKey(h)
+	hashTransformers(h)
 	_, _ = io.WriteString(h, "\x00Paths")
 	for _, p := range paths {
 		_, _ = h.Write([]byte{0})
@@ -198,7 +209,23 @@ func (s *Store) PrepareZip(ctx context.Context, repo api.RepoName, commit api.Co
 		if err != nil {
 			s.Logger.Error("failed to fetch archive", log.String("repo", string(repo)), log.String("commit", string(commit)), log.Duration("duration", time.Since(start)), log.Error(err))
 		}
+		buildNgrams := err == nil && !cacheHit && !s.DisableNgramIndex
+		if buildNgrams {
+			s.ngramBuilds.Add(1)
+		}
 		resC <- result{path, err, cacheHit}
+
+		if buildNgrams {
+			// Build the ngram index after the zip is handed out, so that the
+			// first search does not wait for it. zipCache loads the index
+			// once it exists, for the searches which follow.
+			defer s.ngramBuilds.Done()
+			start := time.Now()
+			if err := buildNgramIndex(path); err != nil {
+				s.Logger.Warn("failed to build ngram index", log.String("repo", string(repo)), log.String("commit", string(commit)), log.Error(err))
+			}
+			metricNgramIndexBuild.Observe(time.Since(start).Seconds())
+		}
 	}()
 
 	select {
@@ -354,8 +381,9 @@ func copySearchable(tr *tar.Reader, zw *zip.Writer, filter *searchableFilter) er
 			}
 
 			// Heuristic: Assume file is binary if first 256 bytes contain a
-			// 0x00. Best effort, so ignore err. We only search names of binary files.
-			if n > 0 && bytes.IndexByte(buf[:n], 0x00) >= 0 {
+			// 0x00. Best effort, so ignore err. We only search names of binary
+			// files, unless a contentTransformer converts them to text.
+			if n > 0 && bytes.IndexByte(buf[:n], 0x00) >= 0 && transformFor(hdr.Name) == 0 {
 				continue
 			}
 
@@ -465,6 +493,11 @@ var (
 		Help:    "Observes the duration to prepare the zip file for searching.",
 		Buckets: prometheus.DefBuckets,
 	}, []string{"cache_hit"})
+	metricNgramIndexBuild = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "searcher_store_ngram_index_build_duration",
+		Help:    "Observes the duration to build the ngram index of a fetched zip file.",
+		Buckets: prometheus.DefBuckets,
+	})
 )
 
 // temporaryError wraps an error but adds the Temporary method. It does not
@@ -294,7 +294,7 @@ func tarArchive(dir string) (*tar.Reader, error) {
 
 func tmpStore(t *testing.T) *Store {
 	d := t.TempDir()
-	return &Store{
+	s := &Store{
 		FilterTar: func(ctx context.Context, repo api.RepoName, commit api.CommitID) (FilterFunc, error) {
 			return func(hdr *tar.Header) bool {
 				return false
@@ -304,6 +304,9 @@ func tmpStore(t *testing.T) *Store {
 		Logger:         logtest.Scoped(t),
 		ObservationCtx: observation.TestContextTB(t),
 	}
+	// Don't write ngram indexes into d while it is being removed.
+	t.Cleanup(s.ngramBuilds.Wait)
+	return s
 }
 
 func emptyTar(t *testing.T) io.ReadCloser {
@@ -0,0 +1,155 @@
+package search
+
+import (
+	"hash"
+	"io"
+	"sort"
+)
+
+// A contentTransformer converts the content of the files it applies to into
+// the text that is searched. This lets us search files whose raw bytes are
+// not useful to match against, such as Jupyter notebooks (JSON with escaped
+// source and base64 encoded outputs) or compressed files.
+//
+// Transformers are applied at search time to the files stored in our zip
+// archives, so the archives keep the original content. Files indexed by zoekt
+// are not transformed.
+type contentTransformer interface {
+	// Name identifies the transformer. It is part of the cache key of
+	// archives, since transformed files are stored even if they look binary.
+	Name() string
+
+	// Applies returns true if the transformer converts the file at path.
+	Applies(path string) bool
+
+	// Transform returns the text to search in content. If the returned
+	// offsetMap is non-nil, it maps offsets in the text back to offsets in
+	// content, and matches are reported against content. Otherwise matches
+	// are reported against the text itself. If Transform returns an error,
+	// the content of the file is not searched.
+	Transform(content []byte) ([]byte, *offsetMap, error)
+}
+
+// contentTransformers are the transformers we apply, in order of precedence.
+// srcFile.Transform is an index into this list, so it must contain fewer than
+// 256 entries.
+var contentTransformers = []contentTransformer{
+	notebookTransformer{},
+	documentTransformer{},
+	gzipTransformer{},
+}
+
+// transformFor returns the 1-based index of the transformer which applies to
+// path in contentTransformers, or 0 if none applies.
+func transformFor(path string) uint8 {
+	for i, t := range contentTransformers {
+		if t.Applies(path) {
+			return uint8(i + 1)
+		}
+	}
+	return 0
+}
+
+// hashTransformers writes the transformers in use to h, so that archives are
+// rebuilt when the transformers change.
+func hashTransformers(h hash.Hash) {
+	_, _ = io.WriteString(h, "\x00Transforms")
+	for _, t := range contentTransformers {
+		_, _ = h.Write([]byte{0})
+		_, _ = io.WriteString(h, t.Name())
+	}
+}
+
+// offsetMap maps byte offsets in transformed content back to byte offsets in
+// the original content.
+type offsetMap struct {
+	// segments are ordered by dst and cover the transformed content without
+	// gaps.
+	segments []offsetSegment
+	// dstLen is the length of the transformed content.
+	dstLen int
+}
+
+// offsetSegment maps dstLen bytes of transformed content starting at dst to
+// srcLen bytes of original content starting at src. If dstLen == srcLen the
+// bytes correspond one to one, otherwise the segment is mapped as a whole
+// (for example an escape sequence which was decoded to a single character).
+type offsetSegment struct {
+	dst, src       int
+	dstLen, srcLen int
+}
+
+func (s offsetSegment) linear() bool {
+	return s.dstLen == s.srcLen
+}
+
+// add records that the next dstLen bytes of transformed content correspond to
+// srcLen bytes of original content starting at src.
+func (m *offsetMap) add(src, srcLen, dstLen int) {
+	if dstLen == 0 {
+		return
+	}
+	seg := offsetSegment{dst: m.dstLen, src: src, dstLen: dstLen, srcLen: srcLen}
+	m.dstLen += dstLen
+
+	// Merge contiguous linear segments to keep the map small.
+	if n := len(m.segments); n > 0 && seg.linear() {
+		last := &m.segments[n-1]
+		if last.linear() && last.src+last.srcLen == seg.src {
+			last.dstLen += seg.dstLen
+			last.srcLen += seg.srcLen
+			return
+		}
+	}
+	m.segments = append(m.segments, seg)
+}
+
+// segmentAt returns the segment containing the transformed offset off.
+func (m *offsetMap) segmentAt(off int) offsetSegment {
+	i := sort.Search(len(m.segments), func(i int) bool {
+		return m.segments[i].dst > off
+	})
+	return m.segments[max(i-1, 0)]
+}
+
+// start maps an offset at which a match starts.
+func (m *offsetMap) start(off int) int {
+	if len(m.segments) == 0 {
+		return 0
+	}
+	if off >= m.dstLen {
+		return m.end(off)
+	}
+	seg := m.segmentAt(off)
+	if seg.linear() {
+		return seg.src + (off - seg.dst)
+	}
+	return seg.src
+}
+
+// end maps an offset at which a match ends (exclusive).
+func (m *offsetMap) end(off int) int {
+	if len(m.segments) == 0 {
+		return 0
+	}
+	if off <= 0 {
+		return m.start(0)
+	}
+	seg := m.segmentAt(min(off, m.dstLen) - 1)
+	if seg.linear() {
+		return seg.src + (min(off, m.dstLen) - seg.dst)
+	}
+	return seg.src + seg.srcLen
+}
+
+// mapLocs maps match locations in transformed content to locations in the
+// original content. The order of locs is preserved.
+func (m *offsetMap) mapLocs(locs [][]int) [][]int {
+	mapped := make([][]int, 0, len(locs))
+	for _, loc := range locs {
+		start := m.start(loc[0])
+		end := max(m.end(loc[1]), start)
+		mapped = append(mapped, []int{start, end})
+	}
+	return mapped
+}
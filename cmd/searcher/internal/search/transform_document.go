@@ -0,0 +1,152 @@
+package search
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// documentTransformer extracts the text of Office Open XML (.docx, .pptx,
+// .xlsx) and OpenDocument (.odt, .odp, .ods) documents, one paragraph per
+// line. Matches are reported against the extracted text, since offsets in the
+// compressed document are meaningless.
+type documentTransformer struct{}
+
+// documentFormats lists the supported formats by extension. parts returns the
+// names of the parts of a document which contain its text, in reading order.
+var documentFormats = []struct {
+	ext   string
+	parts func(names []string) []string
+}{
+	{".docx", exactParts("word/document.xml")},
+	{".xlsx", exactParts("xl/sharedStrings.xml")},
+	{".pptx", numberedParts("ppt/slides/slide", ".xml")},
+	{".odt", exactParts("content.xml")},
+	{".odp", exactParts("content.xml")},
+	{".ods", exactParts("content.xml")},
+}
+
+func (documentTransformer) Name() string { return "document" }
+
+func (documentTransformer) Applies(p string) bool {
+	ext := strings.ToLower(path.Ext(p))
+	for _, format := range documentFormats {
+		if format.ext == ext {
+			return true
+		}
+	}
+	return false
+}
+
+func (documentTransformer) Transform(content []byte) ([]byte, *offsetMap, error) {
+	// We do not know the extension here, but the parts of the formats we
+	// support do not overlap, so we extract from all parts we find.
+	r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(r.File))
+	files := make(map[string]*zip.File, len(r.File))
+	for _, f := range r.File {
+		names = append(names, f.Name)
+		files[f.Name] = f
+	}
+
+	var (
+		text bytes.Buffer
+		seen = map[string]struct{}{}
+	)
+	for _, format := range documentFormats {
+		for _, name := range format.parts(names) {
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			if err := extractXMLText(&text, files[name]); err != nil {
+				return nil, nil, errors.Wrapf(err, "extracting text from %s", name)
+			}
+		}
+	}
+	return text.Bytes(), nil, nil
+}
+
+func exactParts(name string) func([]string) []string {
+	return func(names []string) []string {
+		for _, n := range names {
+			if n == name {
+				return []string{name}
+			}
+		}
+		return nil
+	}
+}
+
+// numberedParts returns the parts named prefix + N + suffix, ordered by N.
+func numberedParts(prefix, suffix string) func([]string) []string {
+	return func(names []string) []string {
+		var parts []string
+		for _, n := range names {
+			if number, ok := strings.CutPrefix(n, prefix); ok {
+				if number, ok = strings.CutSuffix(number, suffix); ok {
+					if _, err := strconv.Atoi(number); err == nil {
+						parts = append(parts, n)
+					}
+				}
+			}
+		}
+		sort.Slice(parts, func(i, j int) bool {
+			a, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(parts[i], prefix), suffix))
+			b, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(parts[j], prefix), suffix))
+			return a < b
+		})
+		return parts
+	}
+}
+
+// documentParagraphs are the local names of XML elements after which we
+// start a new line: paragraphs and headings in both formats, and shared
+// strings (cells) in spreadsheets.
+var documentParagraphs = map[string]struct{}{
+	"p":  {},
+	"h":  {},
+	"si": {},
+}
+
+// extractXMLText writes the character data of the XML document in f to w,
+// with a newline after each paragraph. The output is bounded by maxFileSize.
+func extractXMLText(w *bytes.Buffer, f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	dec := xml.NewDecoder(rc)
+	for w.Len() < maxFileSize {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			w.Write(t)
+		case xml.EndElement:
+			if _, ok := documentParagraphs[t.Name.Local]; ok {
+				w.WriteByte('\n')
+			}
+		}
+	}
+	w.Truncate(maxFileSize)
+	return nil
+}
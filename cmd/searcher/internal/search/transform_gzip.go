@@ -0,0 +1,41 @@
+package search
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// gzipTransformer decompresses gzip compressed files. Compressed archives
+// (.tar.gz, .tgz) are not decompressed. Matches are reported against the
+// decompressed content, which is truncated to maxFileSize.
+type gzipTransformer struct{}
+
+func (gzipTransformer) Name() string { return "gzip" }
+
+func (gzipTransformer) Applies(path string) bool {
+	path = strings.ToLower(path)
+	return strings.HasSuffix(path, ".gz") && !strings.HasSuffix(path, ".tar.gz")
+}
+
+func (gzipTransformer) Transform(content []byte) ([]byte, *offsetMap, error) {
+	r, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Close()
+
+	text, err := io.ReadAll(io.LimitReader(r, maxFileSize))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Like copySearchable, we do not search the content of binary files.
+	if bytes.IndexByte(text[:min(len(text), 256)], 0x00) >= 0 {
+		return nil, nil, errors.New("decompressed content is binary")
+	}
+	return text, nil, nil
+}
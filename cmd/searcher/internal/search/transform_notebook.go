@@ -0,0 +1,214 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// notebookTransformer renders Jupyter notebooks (nbformat 4) to the source of
+// their cells, one cell after another. Outputs and metadata are dropped.
+// Matches are mapped back to the JSON strings of the cell sources. Files which
+// are not valid notebooks are searched as is.
+type notebookTransformer struct{}
+
+func (notebookTransformer) Name() string { return "ipynb" }
+
+func (notebookTransformer) Applies(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), ".ipynb")
+}
+
+func (notebookTransformer) Transform(content []byte) ([]byte, *offsetMap, error) {
+	text, m, err := renderNotebookSources(content)
+	if err != nil {
+		m = &offsetMap{}
+		m.add(0, len(content), len(content))
+		return content, m, nil
+	}
+	return text, m, nil
+}
+
+// renderNotebookSources returns the concatenated sources of the cells of the
+// notebook in content, and the offsets of the sources in content.
+func renderNotebookSources(content []byte) ([]byte, *offsetMap, error) {
+	type frame struct {
+		object    bool
+		key       string
+		expectKey bool
+	}
+
+	var (
+		stack []frame
+		text  []byte
+		m     = &offsetMap{}
+	)
+
+	// isSource returns true if the string at the top of the stack is (part
+	// of) the source of a cell, that is at .cells[i].source or
+	// .cells[i].source[j].
+	isSource := func() bool {
+		switch len(stack) {
+		case 3:
+			return stack[0].key == "cells" && !stack[1].object && stack[2].object && stack[2].key == "source"
+		case 4:
+			return stack[0].key == "cells" && !stack[1].object && stack[2].object && stack[2].key == "source" && !stack[3].object
+		}
+		return false
+	}
+	afterValue := func() {
+		if n := len(stack); n > 0 && stack[n-1].object {
+			stack[n-1].expectKey = true
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+	cellStart := false
+	for {
+		off := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch t := tok.(type) {
+		case json.Delim:
+			switch t {
+			case '{':
+				stack = append(stack, frame{object: true, expectKey: true})
+				if len(stack) == 3 && stack[0].key == "cells" && !stack[1].object {
+					cellStart = true
+				}
+			case '[':
+				stack = append(stack, frame{})
+			case '}', ']':
+				stack = stack[:len(stack)-1]
+				afterValue()
+			}
+
+		case string:
+			if n := len(stack); n > 0 && stack[n-1].object && stack[n-1].expectKey {
+				stack[n-1].key = t
+				stack[n-1].expectKey = false
+				continue
+			}
+			if isSource() {
+				raw := content[off:dec.InputOffset()]
+				quote := bytes.IndexByte(raw, '"')
+				if quote < 0 {
+					return nil, nil, errors.New("string token without quote")
+				}
+				if cellStart && len(text) > 0 && text[len(text)-1] != '\n' {
+					// Separate cells by a newline which does not exist
+					// in the original content.
+					text = append(text, '\n')
+					m.add(off+quote, 0, 1)
+				}
+				cellStart = false
+				text, err = unquoteMapped(text, m, raw[quote:], off+quote)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			afterValue()
+
+		default:
+			afterValue()
+		}
+	}
+
+	if len(stack) != 0 {
+		return nil, nil, errors.New("unexpected end of notebook")
+	}
+	return text, m, nil
+}
+
+// unquoteMapped appends the value of the JSON string quoted, which starts at
+// offset src of the original content, to text. Each byte appended is
+// recorded in m.
+func unquoteMapped(text []byte, m *offsetMap, quoted []byte, src int) ([]byte, error) {
+	if len(quoted) < 2 || quoted[0] != '"' || quoted[len(quoted)-1] != '"' {
+		return nil, errors.New("invalid JSON string")
+	}
+	s := quoted[1 : len(quoted)-1]
+	src++ // skip the opening quote
+
+	for i := 0; i < len(s); {
+		// Copy the run of unescaped bytes.
+		j := bytes.IndexByte(s[i:], '\\')
+		if j < 0 {
+			j = len(s) - i
+		}
+		if j > 0 {
+			text = append(text, s[i:i+j]...)
+			m.add(src+i, j, j)
+			i += j
+			continue
+		}
+
+		// Decode the escape sequence at s[i].
+		if i+1 >= len(s) {
+			return nil, errors.New("invalid escape in JSON string")
+		}
+		escLen := 2
+		var r rune
+		switch s[i+1] {
+		case '"', '\\', '/':
+			r = rune(s[i+1])
+		case 'b':
+			r = '\b'
+		case 'f':
+			r = '\f'
+		case 'n':
+			r = '\n'
+		case 'r':
+			r = '\r'
+		case 't':
+			r = '\t'
+		case 'u':
+			var ok bool
+			r, ok = parseHexRune(s[i:])
+			if !ok {
+				return nil, errors.New("invalid unicode escape in JSON string")
+			}
+			escLen = 6
+			if utf16.IsSurrogate(r) {
+				if r2, ok := parseHexRune(s[i+6:]); ok {
+					if dec := utf16.DecodeRune(r, r2); dec != utf8.RuneError {
+						r = dec
+						escLen = 12
+					}
+				}
+			}
+		default:
+			return nil, errors.New("invalid escape in JSON string")
+		}
+
+		n := len(text)
+		text = utf8.AppendRune(text, r)
+		m.add(src+i, escLen, len(text)-n)
+		i += escLen
+	}
+	return text, nil
+}
+
+// parseHexRune parses the \uXXXX escape at the start of s.
+func parseHexRune(s []byte) (rune, bool) {
+	if len(s) < 6 || s[0] != '\\' || s[1] != 'u' {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(string(s[2:6]), 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	return rune(v), true
+}
@@ -0,0 +1,178 @@
+package search
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/searcher/protocol"
+)
+
+const testNotebook = `{
+ "cells": [
+  {
+   "cell_type": "markdown",
+   "metadata": {},
+   "source": ["# Title \"quoted\"\n", "Some text"]
+  },
+  {
+   "cell_type": "code",
+   "execution_count": 1,
+   "metadata": {},
+   "outputs": [{"output_type": "stream", "text": ["needle in output\n"]}],
+   "source": "x = needle(\"éé\")\nprint(x)"
+  }
+ ],
+ "metadata": {"kernelspec": {"name": "python3"}},
+ "nbformat": 4,
+ "nbformat_minor": 5
+}
+`
+
+func TestNotebookTransformer(t *testing.T) {
+	text, m, err := notebookTransformer{}.Transform([]byte(testNotebook))
+	require.NoError(t, err)
+	require.Equal(t, "# Title \"quoted\"\nSome text\nx = needle(\"éé\")\nprint(x)", string(text))
+
+	// Every match maps back to the same text in the notebook
+	for _, s := range []string{"Title", "Some", "needle", "print(x)"} {
+		i := bytes.Index(text, []byte(s))
+		loc := m.mapLocs([][]int{{i, i + len(s)}})[0]
+		require.Equal(t, s, testNotebook[loc[0]:loc[1]])
+	}
+
+	// Escape sequences map to the whole sequence
+	i := bytes.Index(text, []byte(`"é`))
+	loc := m.mapLocs([][]int{{i, i + len(`"éé"`)}})[0]
+	require.Equal(t, `\"éé\"`, testNotebook[loc[0]:loc[1]])
+
+	// Invalid notebooks are searched as is
+	text, m, err = notebookTransformer{}.Transform([]byte(`{"cells": [`))
+	require.NoError(t, err)
+	require.Equal(t, `{"cells": [`, string(text))
+	require.Equal(t, [][]int{{2, 7}}, m.mapLocs([][]int{{2, 7}}))
+}
+
+func TestOffsetMap(t *testing.T) {
+	// "ab" is copied, "\n" is decoded from two bytes and "c" is synthetic
+	m := &offsetMap{}
+	m.add(10, 2, 2)
+	m.add(12, 2, 1)
+	m.add(14, 0, 1)
+	m.add(14, 3, 3)
+
+	require.Equal(t, [][]int{
+		{10, 12}, // ab
+		{11, 14}, // b\n
+		{12, 14}, // \n
+		{14, 14}, // synthetic
+		{14, 17}, // copied after synthetic
+		{17, 17}, // empty match at end
+	}, m.mapLocs([][]int{{0, 2}, {1, 3}, {2, 3}, {3, 4}, {4, 7}, {7, 7}}))
+}
+
+func TestGzipTransformer(t *testing.T) {
+	require.True(t, gzipTransformer{}.Applies("logs/app.log.gz"))
+	require.False(t, gzipTransformer{}.Applies("release.tar.gz"))
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write([]byte("hello\nworld\n"))
+	require.NoError(t, zw.Close())
+
+	text, m, err := gzipTransformer{}.Transform(buf.Bytes())
+	require.NoError(t, err)
+	require.Nil(t, m)
+	require.Equal(t, "hello\nworld\n", string(text))
+
+	_, _, err = gzipTransformer{}.Transform([]byte("not gzip"))
+	require.Error(t, err)
+}
+
+func createDocument(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestDocumentTransformer(t *testing.T) {
+	require.True(t, documentTransformer{}.Applies("docs/Report.DOCX"))
+	require.False(t, documentTransformer{}.Applies("docs/report.doc"))
+
+	docx := createDocument(t, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="w"><w:body><w:p><w:r><w:t>Hello</w:t></w:r><w:r><w:t> world</w:t></w:r></w:p><w:p><w:r><w:t>Second</w:t></w:r></w:p></w:body></w:document>`,
+		"word/styles.xml":   `<w:styles xmlns:w="w"><w:t>ignored</w:t></w:styles>`,
+	})
+	text, m, err := documentTransformer{}.Transform(docx)
+	require.NoError(t, err)
+	require.Nil(t, m)
+	require.Equal(t, "Hello world\nSecond\n", string(text))
+
+	pptx := createDocument(t, map[string]string{
+		"ppt/slides/slide10.xml": `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:t>ten</a:t></a:p></p:sld>`,
+		"ppt/slides/slide2.xml":  `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:t>two</a:t></a:p></p:sld>`,
+	})
+	text, _, err = documentTransformer{}.Transform(pptx)
+	require.NoError(t, err)
+	require.Equal(t, "two\nten\n", string(text))
+}
+
+func TestRegexSearchTransformed(t *testing.T) {
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, _ = zw.Write([]byte("a needle\n"))
+	require.NoError(t, zw.Close())
+
+	zipData, err := createZip(map[string]string{
+		"notebook.ipynb": testNotebook,
+		"app.log.gz":     gz.String(),
+	})
+	require.NoError(t, err)
+	zf, err := mockZipFile(zipData)
+	require.NoError(t, err)
+
+	p := &protocol.PatternInfo{Query: &protocol.PatternNode{Value: "needle"}}
+	m, err := toMatchTree(p.Query, p.IsCaseSensitive)
+	require.NoError(t, err)
+	pm, err := toPathMatcher(p)
+	require.NoError(t, err)
+
+	ctx, cancel, sender := newLimitedStreamCollector(context.Background(), 100)
+	defer cancel()
+	err = regexSearch(ctx, m, pm, toLangMatcher(p), zf, true, false, false, sender, 0)
+	require.NoError(t, err)
+
+	matches := map[string]protocol.FileMatch{}
+	for _, fm := range sender.collected {
+		matches[fm.Path] = fm
+	}
+	require.Len(t, matches, 2)
+
+	// Matches in notebooks are reported against the notebook, and outputs
+	// are not searched.
+	notebookMatch := matches["notebook.ipynb"]
+	require.Len(t, notebookMatch.ChunkMatches, 1)
+	cm := notebookMatch.ChunkMatches[0]
+	require.Equal(t, `   "source": "x = needle(\"éé\")\nprint(x)"`+"\n", cm.Content)
+	require.Len(t, cm.Ranges, 1)
+	start, end := cm.Ranges[0].Start.Offset, cm.Ranges[0].End.Offset
+	require.Equal(t, "needle", testNotebook[start:end])
+
+	// Matches in compressed files are reported against the decompressed
+	// content.
+	gzMatch := matches["app.log.gz"]
+	require.Len(t, gzMatch.ChunkMatches, 1)
+	require.Equal(t, "a needle\n", gzMatch.ChunkMatches[0].Content)
+}
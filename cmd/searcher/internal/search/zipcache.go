@@ -10,6 +10,8 @@ import (
 	"sort"
 	"sync"
 
+	"go.uber.org/atomic"
+
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -26,6 +28,10 @@ type zipCache struct {
 	// occurs when a file is being deleted, and files are deleted
 	// when no one has used them for a long time. Nevertheless, take care.)
 	shards [64]zipCacheShard
+
+	// disableNgrams if true means we do not load the ngram indexes of zip
+	// files, see ngram.go.
+	disableNgrams bool
 }
 
 type zipCacheShard struct {
@@ -50,6 +56,7 @@ func (c *zipCache) Get(path string) (*zipFile, error) {
 	}
 	zf, ok := shard.m[path]
 	if ok {
+		c.loadNgrams(path, zf)
 		zf.wg.Add(1)
 		return zf, nil
 	}
@@ -60,15 +67,37 @@ func (c *zipCache) Get(path string) (*zipFile, error) {
 	if err != nil {
 		return nil, err
 	}
+	c.loadNgrams(path, zf)
 	shard.m[path] = zf
 	zf.wg.Add(1)
 	return zf, nil
 }
 
+// loadNgrams loads the ngram index of zf if it has not been loaded yet. The
+// index is built after the zip is fetched, so it may show up after zf is
+// first read. It must be called with the lock of the shard of path held.
+func (c *zipCache) loadNgrams(path string, zf *zipFile) {
+	if c.disableNgrams || zf.ngrams.Load() != nil {
+		return
+	}
+	idx, err := openNgramIndex(path, zf)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("failed to load ngram index of %q: %v", path, err)
+		}
+		return
+	}
+	zf.ngrams.Store(idx)
+}
+
 func (c *zipCache) delete(path string, trace observation.TraceLogger) {
 	shard := c.shardFor(path)
 	shard.mu.Lock()
 	defer shard.mu.Unlock()
+
+	// The ngram index file is a sidecar of the zip, which the disk cache
+	// removes along with it. We only need to close it if it was loaded.
+
 	zf, ok := shard.m[path]
 	if !ok {
 		// already deleted?!
@@ -88,6 +117,11 @@ func (c *zipCache) delete(path string, trace observation.TraceLogger) {
 			log.Printf("failed to close %q: %v", zf.f.Name(), err)
 		}
 	}
+	if idx := zf.ngrams.Load(); idx != nil {
+		if err := idx.close(); err != nil {
+			log.Printf("failed to close ngram index of %q: %v", path, err)
+		}
+	}
 	delete(shard.m, path)
 }
 
@@ -100,6 +134,9 @@ type zipFile struct {
 	Data   []byte
 	f      *os.File
 	wg     sync.WaitGroup // ensures underlying file is not munmap'd or closed while in use
+
+	// ngrams is the ngram index of the zip file, or nil if it has none.
+	ngrams atomic.Pointer[ngramIndex]
 }
 
 func readZipFile(path string) (*zipFile, error) {
@@ -145,7 +182,7 @@ func (f *zipFile) PopulateFiles(r *zip.Reader) error {
 		if uint64(size) != file.UncompressedSize64 {
 			return errors.Errorf("file %s has size > 2gb: %v", file.Name, size)
 		}
-		f.Files[i] = srcFile{Name: file.Name, Off: off, Len: int32(size)}
+		f.Files[i] = srcFile{Name: file.Name, Off: off, Len: int32(size), Transform: transformFor(file.Name)}
 		if size > f.MaxLen {
 			f.MaxLen = size
 		}
@@ -177,6 +214,11 @@ type srcFile struct {
 	Name string
 	Off  int64
 	Len  int32
+
+	// Transform is the 1-based index of the contentTransformer to apply to
+	// the contents before searching, or 0 if the contents are searched as
+	// is. It fits into the padding after Len.
+	Transform uint8
 }
 
 // Data returns the contents of s, which is a SrcFile in f.
@@ -186,6 +228,14 @@ func (f *zipFile) DataFor(s *srcFile) []byte {
 	return f.Data[s.Off : s.Off+int64(s.Len)]
 }
 
+// transformer returns the contentTransformer to apply to f, or nil.
+func (f *srcFile) transformer() contentTransformer {
+	if f.Transform == 0 {
+		return nil
+	}
+	return contentTransformers[f.Transform-1]
+}
+
 func (f *srcFile) String() string {
 	return fmt.Sprintf("<%s: %d+%d bytes>", f.Name, f.Off, f.Len)
 }
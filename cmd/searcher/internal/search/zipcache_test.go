@@ -24,11 +24,17 @@ func TestZipCacheDelete(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Make sure it's there.
+	// Make sure it's there, with its ngram index. The index is built in the
+	// background after PrepareZip returns.
 	_, err = os.Stat(path)
 	if err != nil {
 		t.Fatal(err)
 	}
+	s.ngramBuilds.Wait()
+	_, err = os.Stat(ngramIndexPath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	// Load into zip cache.
 	zf, err := s.zipCache.Get(path)
@@ -53,9 +59,13 @@ func TestZipCacheDelete(t *testing.T) {
 		t.Fatalf("expected 0 items in cache, got %d", n)
 	}
 
-	// Make sure the file was successfully deleted on disk.
+	// Make sure the file and its ngram index were successfully deleted on disk.
 	_, err = os.Stat(path)
 	if !os.IsNotExist(err) {
 		t.Errorf("expected non-existence error, got %v", err)
 	}
+	_, err = os.Stat(ngramIndexPath(path))
+	if !os.IsNotExist(err) {
+		t.Errorf("expected non-existence error for ngram index, got %v", err)
+	}
 }
@@ -23,6 +23,7 @@ type Config struct {
 	BackgroundTimeout               time.Duration
 	MaxTotalGitArchivePathsLength   int
 	DisableHybridSearch             bool
+	DisableNgramIndex               bool
 	ExhaustiveRequestLoggingEnabled bool
 }
 
@@ -50,5 +51,6 @@ func (c *Config) Load() {
 		c.AddError(errors.New("MAX_TOTAL_PATHS_LENGTH must be >= 0"))
 	}
 	c.DisableHybridSearch = c.GetBool("DISABLE_HYBRID_SEARCH", "false", "If true, unindexed search will not consult indexed search to speed up searches.")
+	c.DisableNgramIndex = c.GetBool("SEARCHER_DISABLE_NGRAM_INDEX", "false", "If true, searcher will not build trigram indexes of cached archives to speed up repeated searches.")
 	c.ExhaustiveRequestLoggingEnabled = c.GetBool("SRC_SEARCHER_EXHAUSTIVE_LOGGING_ENABLED", "false", "Enable exhaustive request logging in searcher")
 }
@@ -33,6 +33,9 @@ func TestConfigDefaults(t *testing.T) {
 	if have, want := config.DisableHybridSearch, false; have != want {
 		t.Errorf("invalid value for DisableHybridSearch: have=%t want=%t", have, want)
 	}
+	if have, want := config.DisableNgramIndex, false; have != want {
+		t.Errorf("invalid value for DisableNgramIndex: have=%t want=%t", have, want)
+	}
 	if have, want := config.ExhaustiveRequestLoggingEnabled, false; have != want {
 		t.Errorf("invalid value for ExhaustiveRequestLoggingEnabled: have=%t want=%t", have, want)
 	}
@@ -92,6 +92,7 @@ func Start(ctx context.Context, observationCtx *observation.Context, ready servi
 		BackgroundTimeout: cfg.BackgroundTimeout,
 		Logger:            storeObservationCtx.Logger,
 		ObservationCtx:    storeObservationCtx,
+		DisableNgramIndex: cfg.DisableNgramIndex,
 	}
 	store.Start()
 
@@ -0,0 +1,850 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// maxPackageSymbolsGo bounds the number of symbols inspected when searching a Go package for an
+// identifier that needs to satisfy extra conditions, such as being a method of a given type.
+const maxPackageSymbolsGo = 100
+
+func (s *SquirrelService) getDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "package_identifier":
+		// Either the package of a qualified type or the name of an import
+		return s.getPackageGo(ctx, swapNode(node, getRoot(node.Node)), node.Content(node.Contents))
+
+	case "field_identifier":
+		parent := node.Parent()
+		if parent == nil || parent.Type() != "selector_expression" {
+			return nil, nil
+		}
+		operand := parent.ChildByFieldName("operand")
+		if operand == nil {
+			s.breadcrumb(node, "getDefGo: selector_expression has no operand field")
+			return nil, nil
+		}
+		return s.getFieldGo(ctx, swapNode(node, operand), node.Content(node.Contents))
+
+	case "type_identifier":
+		parent := node.Parent()
+		if parent != nil && parent.Type() == "qualified_type" {
+			pkg := parent.ChildByFieldName("package")
+			if pkg == nil {
+				return nil, nil
+			}
+			return s.getQualifiedGo(ctx, swapNode(node, pkg), node.Content(node.Contents))
+		}
+		return s.getDefInScopeGo(ctx, node)
+
+	case "identifier":
+		return s.getDefInScopeGo(ctx, node)
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInScopeGo walks up the tree from an identifier, checking each enclosing scope for a binding,
+// and finally the package and imports of the file.
+func (s *SquirrelService) getDefInScopeGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	ident := node.Content(node.Contents)
+
+	findIn := func(query string, scope *sitter.Node) *Node {
+		if scope == nil {
+			return nil
+		}
+		for _, capture := range allCaptures(query, swapNode(node, scope)) {
+			if capture.Content(capture.Contents) == ident {
+				return swapNodePtr(node, capture.Node)
+			}
+		}
+		return nil
+	}
+
+	cur := node.Node
+	for {
+		prev := cur
+		cur = cur.Parent()
+		if cur == nil {
+			s.breadcrumb(node, "getDefGo: ran out of parents")
+			return nil, nil
+		}
+
+		switch cur.Type() {
+
+		case "source_file":
+			return s.getDefInPackageGo(ctx, swapNode(node, cur), ident)
+
+		case "keyed_element":
+			// T{Field: value}
+			key := cur.NamedChild(0)
+			if key == nil || nodeId(key) != nodeId(prev) {
+				continue
+			}
+			literalValue := cur.Parent()
+			if literalValue == nil || literalValue.Type() != "literal_value" {
+				continue
+			}
+			compositeLiteral := literalValue.Parent()
+			if compositeLiteral == nil || compositeLiteral.Type() != "composite_literal" {
+				continue
+			}
+			ty := compositeLiteral.ChildByFieldName("type")
+			if ty == nil {
+				continue
+			}
+			found, err := s.getFieldGo(ctx, swapNode(node, ty), ident)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+			continue
+
+		case "block":
+			// Declarations in a block are only visible after the statement that declares them
+			for sibling := prev.PrevNamedSibling(); sibling != nil; sibling = sibling.PrevNamedSibling() {
+				query := `[
+					(var_spec              name: (identifier) @ident)
+					(const_spec            name: (identifier) @ident)
+					(type_spec             name: (type_identifier) @ident)
+					(type_alias            name: (type_identifier) @ident)
+					(short_var_declaration left: (expression_list (identifier) @ident))
+				]`
+				if found := findLocalDeclarationGo(swapNode(node, sibling), query, ident); found != nil {
+					return found, nil
+				}
+			}
+			continue
+
+		case "if_statement", "expression_switch_statement":
+			if found := findIn(`(short_var_declaration left: (expression_list (identifier) @ident))`, cur.ChildByFieldName("initializer")); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "type_switch_statement":
+			if found := findIn(`(expression_list (identifier) @ident)`, cur.ChildByFieldName("alias")); found != nil {
+				return found, nil
+			}
+			if found := findIn(`(short_var_declaration left: (expression_list (identifier) @ident))`, cur.ChildByFieldName("initializer")); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "for_statement":
+			for _, child := range children(cur) {
+				switch child.Type() {
+				case "range_clause":
+					if found := findIn(`(range_clause left: (expression_list (identifier) @ident))`, child); found != nil {
+						return found, nil
+					}
+				case "for_clause":
+					if found := findIn(`(short_var_declaration left: (expression_list (identifier) @ident))`, child.ChildByFieldName("initializer")); found != nil {
+						return found, nil
+					}
+				}
+			}
+			continue
+
+		case "communication_case":
+			if found := findIn(`(receive_statement left: (expression_list (identifier) @ident))`, cur.ChildByFieldName("communication")); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "function_declaration", "method_declaration", "func_literal":
+			query := `[
+				(parameter_declaration          name: (identifier) @ident)
+				(variadic_parameter_declaration name: (identifier) @ident)
+			]`
+			for _, field := range []string{"receiver", "type_parameters", "parameters", "result"} {
+				if found := findIn(query, cur.ChildByFieldName(field)); found != nil {
+					return found, nil
+				}
+			}
+			continue
+
+		case "type_spec":
+			if found := findIn(`(parameter_declaration name: (identifier) @ident)`, cur.ChildByFieldName("type_parameters")); found != nil {
+				return found, nil
+			}
+			continue
+
+		// Skip all other nodes
+		default:
+			continue
+		}
+	}
+}
+
+// findLocalDeclarationGo finds a binding for ident among the declarations of a statement without
+// descending into nested blocks or function literals.
+func findLocalDeclarationGo(statement Node, query string, ident string) *Node {
+	switch statement.Type() {
+	case "var_declaration", "const_declaration", "type_declaration", "short_var_declaration":
+	default:
+		return nil
+	}
+	for _, capture := range allCaptures(query, statement) {
+		if capture.Content(capture.Contents) == ident {
+			return &capture
+		}
+	}
+	return nil
+}
+
+// getDefInPackageGo looks for a package-level declaration of ident in the current file, the
+// imports of the current file, and the other files of the package, in that order.
+func (s *SquirrelService) getDefInPackageGo(ctx context.Context, file Node, ident string) (ret *Node, err error) {
+	defer s.onCall(file, &Tuple{String(file.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	if found := findTopLevelGo(file, ident); found != nil {
+		return found, nil
+	}
+
+	found, err := s.getPackageGo(ctx, file, ident)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	found, err = s.findInPackageGo(ctx, file.RepoCommitPath, filepath.Dir(file.RepoCommitPath.Path), ident, isPackageLevelGo)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	// Dot imports bring all exported identifiers of a package into the file scope
+	for _, imp := range getImportsGo(file) {
+		if imp.name != "." {
+			continue
+		}
+		dir, err := s.resolveImportGo(ctx, file.RepoCommitPath, imp.path)
+		if err != nil {
+			return nil, err
+		}
+		if dir == nil {
+			continue
+		}
+		found, err := s.findInPackageGo(ctx, file.RepoCommitPath, dir.RepoCommitPath.Path, ident, isPackageLevelGo)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findTopLevelGo finds a package-level declaration of ident in the given file.
+func findTopLevelGo(file Node, ident string) *Node {
+	for _, child := range children(file.Node) {
+		switch child.Type() {
+		case "function_declaration":
+			name := child.ChildByFieldName("name")
+			if name != nil && name.Content(file.Contents) == ident {
+				return swapNodePtr(file, name)
+			}
+		case "var_declaration", "const_declaration", "type_declaration":
+			query := `[
+				(var_spec   name: (identifier) @ident)
+				(const_spec name: (identifier) @ident)
+				(type_spec  name: (type_identifier) @ident)
+				(type_alias name: (type_identifier) @ident)
+			]`
+			for _, capture := range allCaptures(query, swapNode(file, child)) {
+				if capture.Content(capture.Contents) == ident {
+					return &capture
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isPackageLevelGo returns true if the node is the name of a package-level declaration.
+func isPackageLevelGo(node Node) bool {
+	parent := node.Parent()
+	if parent == nil {
+		return false
+	}
+	switch parent.Type() {
+	case "function_declaration", "type_spec", "type_alias", "var_spec", "const_spec":
+	default:
+		return false
+	}
+	for cur := parent.Parent(); cur != nil; cur = cur.Parent() {
+		if cur.Type() == "block" {
+			return false
+		}
+	}
+	return true
+}
+
+// findInPackageGo searches the files of the package in the given directory for a declaration of
+// ident that satisfies the given predicate.
+func (s *SquirrelService) findInPackageGo(ctx context.Context, repoCommitPath types.RepoCommitPath, dir string, ident string, pred func(Node) bool) (ret *Node, err error) {
+	if s.symbolSearch == nil {
+		return nil, nil
+	}
+
+	symbols, err := s.symbolSearch(ctx, search.SymbolsParameters{
+		Repo:            api.RepoName(repoCommitPath.Repo),
+		CommitID:        api.CommitID(repoCommitPath.Commit),
+		Query:           fmt.Sprintf("^%s$", regexp.QuoteMeta(ident)),
+		IsRegExp:        true,
+		IsCaseSensitive: true,
+		IncludePatterns: []string{packageFilesPatternGo(dir)},
+		First:           maxPackageSymbolsGo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, symbol := range symbols {
+		file, err := s.parse(ctx, types.RepoCommitPath{
+			Repo:   repoCommitPath.Repo,
+			Commit: repoCommitPath.Commit,
+			Path:   symbol.Path,
+		})
+		if err != nil {
+			return nil, err
+		}
+		point := sitter.Point{Row: uint32(symbol.Line), Column: uint32(symbol.Character)}
+		symbolNode := file.NamedDescendantForPointRange(point, point)
+		if symbolNode == nil {
+			continue
+		}
+		found := swapNode(*file, symbolNode)
+		if found.Content(found.Contents) == ident && pred(found) {
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// packageFilesPatternGo returns a pattern that matches the Go files directly inside the given
+// directory.
+func packageFilesPatternGo(dir string) string {
+	if dir == "" || dir == "." {
+		return `^[^/]+\.go$`
+	}
+	return fmt.Sprintf(`^%s/[^/]+\.go$`, regexp.QuoteMeta(dir))
+}
+
+type importGo struct {
+	name string
+	path string
+}
+
+// getImportsGo returns the imports of a file, with the name under which each package is bound.
+func getImportsGo(file Node) []importGo {
+	imports := []importGo{}
+	for _, spec := range allCaptures(`(import_spec) @spec`, file) {
+		pathNode := spec.ChildByFieldName("path")
+		if pathNode == nil {
+			continue
+		}
+		importPath, err := strconv.Unquote(pathNode.Content(file.Contents))
+		if err != nil {
+			continue
+		}
+		name := defaultPackageNameGo(importPath)
+		if nameNode := spec.ChildByFieldName("name"); nameNode != nil {
+			name = nameNode.Content(file.Contents)
+		}
+		imports = append(imports, importGo{name: name, path: importPath})
+	}
+	return imports
+}
+
+var majorVersionSuffixGo = regexp.MustCompile(`^v[0-9]+$`)
+
+// defaultPackageNameGo guesses the name of a package from its import path, which is the last path
+// element unless it is a major version suffix.
+func defaultPackageNameGo(importPath string) string {
+	elements := strings.Split(importPath, "/")
+	name := elements[len(elements)-1]
+	if majorVersionSuffixGo.MatchString(name) && len(elements) > 1 {
+		name = elements[len(elements)-2]
+	}
+	return strings.TrimPrefix(name, "go-")
+}
+
+// getPackageGo returns the directory of the package imported under the given name in the file.
+func (s *SquirrelService) getPackageGo(ctx context.Context, file Node, name string) (ret *Node, err error) {
+	defer s.onCall(file, String(name), lazyNodeStringer(&ret))()
+
+	for _, imp := range getImportsGo(file) {
+		if imp.name == name {
+			return s.resolveImportGo(ctx, file.RepoCommitPath, imp.path)
+		}
+	}
+	return nil, nil
+}
+
+var modulePathGo = regexp.MustCompile(`(?m)^\s*module\s+"?([^"\s]+)"?`)
+
+// resolveImportGo maps an import path to a directory of the repository using the go.mod file of the
+// module that contains the importing file. Packages of other modules can't be resolved.
+func (s *SquirrelService) resolveImportGo(ctx context.Context, from types.RepoCommitPath, importPath string) (*Node, error) {
+	dir := filepath.Dir(from.Path)
+	for {
+		goMod := filepath.Join(dir, "go.mod")
+		contents, err := s.readFile(ctx, types.RepoCommitPath{Repo: from.Repo, Commit: from.Commit, Path: goMod})
+		if err == nil {
+			match := modulePathGo.FindSubmatch(contents)
+			if match == nil {
+				return nil, nil
+			}
+			modulePath := string(match[1])
+			var rel string
+			switch {
+			case importPath == modulePath:
+				rel = "."
+			case strings.HasPrefix(importPath, modulePath+"/"):
+				rel = strings.TrimPrefix(importPath, modulePath+"/")
+			default:
+				return nil, nil
+			}
+			return &Node{
+				RepoCommitPath: types.RepoCommitPath{
+					Repo:   from.Repo,
+					Commit: from.Commit,
+					Path:   filepath.Join(dir, rel),
+				},
+				Node: nil,
+			}, nil
+		}
+		if dir == "." || dir == "/" {
+			return nil, nil
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// getQualifiedGo finds the definition of pkg.name where pkg is an imported package.
+func (s *SquirrelService) getQualifiedGo(ctx context.Context, pkg Node, name string) (*Node, error) {
+	dir, err := s.getDefGo(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+	if dir == nil || dir.Node != nil {
+		return nil, nil
+	}
+	return s.findInPackageGo(ctx, pkg.RepoCommitPath, dir.RepoCommitPath.Path, name, isPackageLevelGo)
+}
+
+func (s *SquirrelService) getFieldGo(ctx context.Context, operand Node, field string) (ret *Node, err error) {
+	defer s.onCall(operand, &Tuple{String(operand.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	if operand.Type() == "identifier" {
+		def, err := s.getDefGo(ctx, operand)
+		if err != nil {
+			return nil, err
+		}
+		if def == nil {
+			return nil, nil
+		}
+		if def.Node == nil {
+			// The operand is an imported package
+			return s.findInPackageGo(ctx, operand.RepoCommitPath, def.RepoCommitPath.Path, field, isPackageLevelGo)
+		}
+		ty, err := s.defToTypeGo(ctx, *def)
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		return s.lookupFieldGo(ctx, *ty, field)
+	}
+
+	ty, err := s.getTypeDefGo(ctx, operand)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return s.lookupFieldGo(ctx, *ty, field)
+}
+
+// lookupFieldGo finds a field or method in the method set of a type, which is either a type_spec or
+// an anonymous struct or interface type. Fields and methods promoted from embedded fields are
+// included.
+func (s *SquirrelService) lookupFieldGo(ctx context.Context, ty Node, field string) (ret *Node, err error) {
+	defer s.onCall(ty, &Tuple{String(ty.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty.Type() {
+	case "type_spec":
+		name := ty.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		typeName := name.Content(ty.Contents)
+
+		// Methods declared on the named type
+		isMethod := func(node Node) bool {
+			parent := node.Parent()
+			if parent == nil || parent.Type() != "method_declaration" {
+				return false
+			}
+			return receiverTypeNameGo(swapNode(node, parent)) == typeName
+		}
+		root := swapNode(ty, getRoot(ty.Node))
+		for _, method := range children(root.Node) {
+			if method.Type() != "method_declaration" {
+				continue
+			}
+			methodName := method.ChildByFieldName("name")
+			if methodName != nil && methodName.Content(ty.Contents) == field && isMethod(swapNode(ty, methodName)) {
+				return swapNodePtr(ty, methodName), nil
+			}
+		}
+		found, err := s.findInPackageGo(ctx, ty.RepoCommitPath, filepath.Dir(ty.RepoCommitPath.Path), field, isMethod)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		// Fields of the underlying type
+		underlying := ty.ChildByFieldName("type")
+		if underlying == nil {
+			return nil, nil
+		}
+		underlyingTy, err := s.getTypeDefGo(ctx, swapNode(ty, underlying))
+		if err != nil {
+			return nil, err
+		}
+		if underlyingTy == nil || nodeId(underlyingTy.Node) == nodeId(ty.Node) {
+			return nil, nil
+		}
+		return s.lookupFieldGo(ctx, *underlyingTy, field)
+
+	case "struct_type":
+		var embedded []Node
+		for _, list := range children(ty.Node) {
+			for _, decl := range children(list) {
+				if decl.Type() != "field_declaration" {
+					continue
+				}
+				names := childrenForFieldName(decl, "name")
+				for _, name := range names {
+					if name.Content(ty.Contents) == field {
+						return swapNodePtr(ty, name), nil
+					}
+				}
+				if len(names) > 0 {
+					continue
+				}
+				// Embedded fields are named after their type
+				fieldTy := decl.ChildByFieldName("type")
+				if fieldTy == nil {
+					continue
+				}
+				if embeddedTypeNameGo(fieldTy, ty.Contents) == field {
+					return swapNodePtr(ty, fieldTy), nil
+				}
+				embedded = append(embedded, swapNode(ty, fieldTy))
+			}
+		}
+		return s.lookupPromotedGo(ctx, embedded, field)
+
+	case "interface_type":
+		var embedded []Node
+		for _, child := range children(ty.Node) {
+			switch child.Type() {
+			case "method_spec":
+				name := child.ChildByFieldName("name")
+				if name != nil && name.Content(ty.Contents) == field {
+					return swapNodePtr(ty, name), nil
+				}
+			case "interface_type_name":
+				if child.NamedChildCount() > 0 {
+					embedded = append(embedded, swapNode(ty, child.NamedChild(0)))
+				}
+			}
+		}
+		return s.lookupPromotedGo(ctx, embedded, field)
+
+	default:
+		s.breadcrumb(ty, fmt.Sprintf("lookupFieldGo: unexpected type node %q", ty.Type()))
+		return nil, nil
+	}
+}
+
+// lookupPromotedGo looks for a field or method in the given embedded types.
+func (s *SquirrelService) lookupPromotedGo(ctx context.Context, embedded []Node, field string) (*Node, error) {
+	for _, embeddedType := range embedded {
+		ty, err := s.getTypeDefGo(ctx, embeddedType)
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			continue
+		}
+		found, err := s.lookupFieldGo(ctx, *ty, field)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+	return nil, nil
+}
+
+// receiverTypeNameGo returns the name of the receiver type of a method declaration.
+func receiverTypeNameGo(method Node) string {
+	receiver := method.ChildByFieldName("receiver")
+	if receiver == nil {
+		return ""
+	}
+	for _, param := range children(receiver) {
+		if param.Type() != "parameter_declaration" {
+			continue
+		}
+		ty := param.ChildByFieldName("type")
+		if ty == nil {
+			return ""
+		}
+		return embeddedTypeNameGo(ty, method.Contents)
+	}
+	return ""
+}
+
+// embeddedTypeNameGo returns the unqualified name of a possibly pointer, generic or qualified type.
+func embeddedTypeNameGo(ty *sitter.Node, contents []byte) string {
+	for ty != nil {
+		switch ty.Type() {
+		case "type_identifier":
+			return ty.Content(contents)
+		case "pointer_type":
+			ty = ty.NamedChild(0)
+		case "generic_type":
+			ty = ty.ChildByFieldName("type")
+		case "qualified_type":
+			ty = ty.ChildByFieldName("name")
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// getTypeDefGo returns the type of an expression or the definition of a type expression, which is
+// either a type_spec or an anonymous struct or interface type.
+func (s *SquirrelService) getTypeDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier":
+		def, err := s.getDefGo(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if def == nil || def.Node == nil {
+			return nil, nil
+		}
+		return s.defToTypeGo(ctx, *def)
+	case "qualified_type":
+		pkg := node.ChildByFieldName("package")
+		name := node.ChildByFieldName("name")
+		if pkg == nil || name == nil {
+			return nil, nil
+		}
+		def, err := s.getQualifiedGo(ctx, swapNode(node, pkg), name.Content(node.Contents))
+		if err != nil {
+			return nil, err
+		}
+		if def == nil {
+			return nil, nil
+		}
+		return s.defToTypeGo(ctx, *def)
+	case "selector_expression":
+		operand := node.ChildByFieldName("operand")
+		field := node.ChildByFieldName("field")
+		if operand == nil || field == nil {
+			return nil, nil
+		}
+		def, err := s.getFieldGo(ctx, swapNode(node, operand), field.Content(node.Contents))
+		if err != nil {
+			return nil, err
+		}
+		if def == nil || def.Node == nil {
+			return nil, nil
+		}
+		return s.defToTypeGo(ctx, *def)
+	case "pointer_type", "parenthesized_type", "parenthesized_expression":
+		if node.NamedChildCount() == 0 {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, node.NamedChild(0)))
+	case "generic_type", "composite_literal":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, ty))
+	case "unary_expression":
+		operand := node.ChildByFieldName("operand")
+		if operand == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, operand))
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		return s.getCallTypeGo(ctx, node, swapNode(node, fn))
+	case "struct_type", "interface_type":
+		return &node, nil
+	default:
+		s.breadcrumb(node, fmt.Sprintf("getTypeDefGo: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+// getCallTypeGo returns the type of the first result of a call, or of the operand of a conversion.
+func (s *SquirrelService) getCallTypeGo(ctx context.Context, call Node, fn Node) (*Node, error) {
+	var name Node
+	switch fn.Type() {
+	case "identifier":
+		name = fn
+	case "selector_expression":
+		field := fn.ChildByFieldName("field")
+		if field == nil {
+			return nil, nil
+		}
+		name = swapNode(fn, field)
+	default:
+		// Conversions like (*T)(x)
+		return s.getTypeDefGo(ctx, fn)
+	}
+
+	def, err := s.getDefGo(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if def == nil || def.Node == nil {
+		// new(T) returns a *T
+		args := call.ChildByFieldName("arguments")
+		if def == nil && name.Content(name.Contents) == "new" && args != nil && args.NamedChildCount() > 0 {
+			return s.getTypeDefGo(ctx, swapNode(call, args.NamedChild(0)))
+		}
+		return nil, nil
+	}
+
+	parent := def.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+	switch parent.Type() {
+	case "function_declaration", "method_declaration", "method_spec":
+		result := parent.ChildByFieldName("result")
+		if result == nil {
+			return nil, nil
+		}
+		if result.Type() == "parameter_list" {
+			first := result.NamedChild(0)
+			if first == nil {
+				return nil, nil
+			}
+			result = first.ChildByFieldName("type")
+			if result == nil {
+				return nil, nil
+			}
+		}
+		return s.getTypeDefGo(ctx, swapNode(*def, result))
+	default:
+		return s.defToTypeGo(ctx, *def)
+	}
+}
+
+// defToTypeGo returns the type of the entity declared by a definition.
+func (s *SquirrelService) defToTypeGo(ctx context.Context, def Node) (*Node, error) {
+	parent := def.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "type_spec":
+		return swapNodePtr(def, parent), nil
+	case "type_alias", "parameter_declaration", "field_declaration":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(def, ty))
+	case "var_spec", "const_spec":
+		if ty := parent.ChildByFieldName("type"); ty != nil {
+			return s.getTypeDefGo(ctx, swapNode(def, ty))
+		}
+		index := 0
+		for _, name := range childrenForFieldName(parent, "name") {
+			if nodeId(name) == nodeId(def.Node) {
+				break
+			}
+			index++
+		}
+		return s.valueTypeGo(ctx, swapNode(def, parent.ChildByFieldName("value")), index)
+	case "expression_list":
+		declaration := parent.Parent()
+		if declaration == nil || declaration.Type() != "short_var_declaration" {
+			return nil, nil
+		}
+		left := declaration.ChildByFieldName("left")
+		if left == nil || nodeId(left) != nodeId(parent) {
+			return nil, nil
+		}
+		index := 0
+		for _, child := range children(parent) {
+			if nodeId(child) == nodeId(def.Node) {
+				break
+			}
+			index++
+		}
+		return s.valueTypeGo(ctx, swapNode(def, declaration.ChildByFieldName("right")), index)
+	default:
+		return nil, nil
+	}
+}
+
+// valueTypeGo returns the type of the value at the given index of an expression list.
+func (s *SquirrelService) valueTypeGo(ctx context.Context, values Node, index int) (*Node, error) {
+	if values.Node == nil || index >= int(values.NamedChildCount()) {
+		return nil, nil
+	}
+	return s.getTypeDefGo(ctx, swapNode(values, values.NamedChild(index)))
+}
@@ -0,0 +1,867 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// maxTSConfigExtendsDepth bounds the length of chains of tsconfig.json files that extend each other.
+const maxTSConfigExtendsDepth = 5
+
+func (s *SquirrelService) getDefTypeScript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "property_identifier":
+		parent := node.Parent()
+		if parent == nil || parent.Type() != "member_expression" {
+			return nil, nil
+		}
+		object := parent.ChildByFieldName("object")
+		if object == nil {
+			s.breadcrumb(node, "getDefTypeScript: member_expression has no object field")
+			return nil, nil
+		}
+		return s.getFieldTypeScript(ctx, swapNode(node, object), node.Content(node.Contents))
+
+	case "type_identifier":
+		// ns.Type
+		parent := node.Parent()
+		if parent != nil && parent.Type() == "nested_type_identifier" {
+			module := parent.ChildByFieldName("module")
+			if module == nil {
+				return nil, nil
+			}
+			return s.getFieldTypeScript(ctx, swapNode(node, module), node.Content(node.Contents))
+		}
+		return s.getDefInScopeTypeScript(ctx, node)
+
+	case "identifier", "shorthand_property_identifier":
+		return s.getDefInScopeTypeScript(ctx, node)
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInScopeTypeScript walks up the tree from an identifier, checking each enclosing scope for a
+// binding, and finally the imports of the module.
+func (s *SquirrelService) getDefInScopeTypeScript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	ident := node.Content(node.Contents)
+
+	findIn := func(names []*sitter.Node) *Node {
+		for _, name := range names {
+			if name.Content(node.Contents) == ident {
+				return swapNodePtr(node, name)
+			}
+		}
+		return nil
+	}
+
+	cur := node.Node
+	for {
+		cur = cur.Parent()
+		if cur == nil {
+			s.breadcrumb(node, "getDefTypeScript: ran out of parents")
+			return nil, nil
+		}
+
+		switch cur.Type() {
+
+		case "program":
+			if found := findIn(getScopeNamesTypeScript(cur)); found != nil {
+				return found, nil
+			}
+			return s.getDefInImportsTypeScript(ctx, swapNode(node, cur), ident)
+
+		case "statement_block":
+			// Function and class declarations are hoisted, so all declarations of the block are
+			// considered
+			if found := findIn(getScopeNamesTypeScript(cur)); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "function_declaration", "generator_function_declaration", "function", "function_expression",
+			"generator_function", "arrow_function", "method_definition":
+			if found := findIn(getParameterNamesTypeScript(cur)); found != nil {
+				return found, nil
+			}
+			// Named function expressions are bound inside of their body
+			if cur.Type() != "function_declaration" && cur.Type() != "generator_function_declaration" && cur.Type() != "method_definition" {
+				if name := cur.ChildByFieldName("name"); name != nil && name.Content(node.Contents) == ident {
+					return swapNodePtr(node, name), nil
+				}
+			}
+			continue
+
+		case "class_declaration", "abstract_class_declaration", "class", "interface_declaration", "type_alias_declaration":
+			if found := findIn(getTypeParameterNamesTypeScript(cur)); found != nil {
+				return found, nil
+			}
+			if cur.Type() == "class" {
+				if name := cur.ChildByFieldName("name"); name != nil && name.Content(node.Contents) == ident {
+					return swapNodePtr(node, name), nil
+				}
+			}
+			continue
+
+		case "for_statement":
+			if found := findIn(getDeclarationNamesTypeScript(cur.ChildByFieldName("initializer"))); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "for_in_statement":
+			if found := findIn(getPatternNamesTypeScript(cur.ChildByFieldName("left"))); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "catch_clause":
+			if found := findIn(getPatternNamesTypeScript(cur.ChildByFieldName("parameter"))); found != nil {
+				return found, nil
+			}
+			continue
+
+		// Skip all other nodes
+		default:
+			continue
+		}
+	}
+}
+
+// getScopeNamesTypeScript returns the names declared by the statements of a program or block.
+func getScopeNamesTypeScript(scope *sitter.Node) []*sitter.Node {
+	var names []*sitter.Node
+	for _, statement := range children(scope) {
+		names = append(names, getDeclarationNamesTypeScript(statement)...)
+	}
+	return names
+}
+
+// getDeclarationNamesTypeScript returns the names declared by a statement.
+func getDeclarationNamesTypeScript(declaration *sitter.Node) []*sitter.Node {
+	if declaration == nil {
+		return nil
+	}
+
+	switch declaration.Type() {
+	case "function_declaration", "generator_function_declaration", "function_signature", "class_declaration",
+		"abstract_class_declaration", "interface_declaration", "type_alias_declaration", "enum_declaration",
+		"internal_module", "module":
+		if name := declaration.ChildByFieldName("name"); name != nil {
+			return []*sitter.Node{name}
+		}
+		return nil
+	case "lexical_declaration", "variable_declaration":
+		var names []*sitter.Node
+		for _, declarator := range children(declaration) {
+			if declarator.Type() == "variable_declarator" {
+				names = append(names, getPatternNamesTypeScript(declarator.ChildByFieldName("name"))...)
+			}
+		}
+		return names
+	case "export_statement":
+		return getDeclarationNamesTypeScript(declaration.ChildByFieldName("declaration"))
+	case "ambient_declaration":
+		var names []*sitter.Node
+		for _, child := range children(declaration) {
+			names = append(names, getDeclarationNamesTypeScript(child)...)
+		}
+		return names
+	default:
+		return nil
+	}
+}
+
+// getPatternNamesTypeScript returns the identifiers bound by a destructuring pattern.
+func getPatternNamesTypeScript(pattern *sitter.Node) []*sitter.Node {
+	if pattern == nil {
+		return nil
+	}
+
+	switch pattern.Type() {
+	case "identifier", "shorthand_property_identifier_pattern":
+		return []*sitter.Node{pattern}
+	case "lexical_declaration", "variable_declaration":
+		return getDeclarationNamesTypeScript(pattern)
+	case "pair_pattern":
+		return getPatternNamesTypeScript(pattern.ChildByFieldName("value"))
+	case "object_assignment_pattern", "assignment_pattern":
+		return getPatternNamesTypeScript(pattern.ChildByFieldName("left"))
+	case "object_pattern", "array_pattern", "rest_pattern":
+		var names []*sitter.Node
+		for _, child := range children(pattern) {
+			names = append(names, getPatternNamesTypeScript(child)...)
+		}
+		return names
+	default:
+		return nil
+	}
+}
+
+// getParameterNamesTypeScript returns the names bound by the parameters and type parameters of a
+// function.
+func getParameterNamesTypeScript(fn *sitter.Node) []*sitter.Node {
+	names := getTypeParameterNamesTypeScript(fn)
+	if parameter := fn.ChildByFieldName("parameter"); parameter != nil {
+		// x => ...
+		names = append(names, parameter)
+	}
+	for _, parameter := range children(fn.ChildByFieldName("parameters")) {
+		switch parameter.Type() {
+		case "required_parameter", "optional_parameter":
+			names = append(names, getPatternNamesTypeScript(parameter.ChildByFieldName("pattern"))...)
+		}
+	}
+	return names
+}
+
+// getTypeParameterNamesTypeScript returns the names of the type parameters of a generic declaration.
+func getTypeParameterNamesTypeScript(declaration *sitter.Node) []*sitter.Node {
+	var names []*sitter.Node
+	for _, parameter := range children(declaration.ChildByFieldName("type_parameters")) {
+		if name := parameter.ChildByFieldName("name"); name != nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// getDefInImportsTypeScript finds the definition of a name bound by an import statement. When the
+// imported module can't be resolved, e.g. because it is a dependency, the binding in the import
+// statement is returned instead.
+func (s *SquirrelService) getDefInImportsTypeScript(ctx context.Context, program Node, ident string) (ret *Node, err error) {
+	defer s.onCall(program, &Tuple{String(program.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, statement := range children(program.Node) {
+		if statement.Type() != "import_statement" {
+			continue
+		}
+
+		var binding *sitter.Node
+		exported := ""
+		for _, clause := range children(statement) {
+			if clause.Type() != "import_clause" {
+				continue
+			}
+			for _, child := range children(clause) {
+				switch child.Type() {
+				case "identifier":
+					// import x from '...'
+					if child.Content(program.Contents) == ident {
+						binding, exported = child, "default"
+					}
+				case "namespace_import":
+					// import * as x from '...'
+					for _, name := range children(child) {
+						if name.Type() == "identifier" && name.Content(program.Contents) == ident {
+							binding = name
+						}
+					}
+				case "named_imports":
+					// import { x, y as z } from '...'
+					for _, specifier := range children(child) {
+						if specifier.Type() != "import_specifier" {
+							continue
+						}
+						name := specifier.ChildByFieldName("name")
+						local := name
+						if alias := specifier.ChildByFieldName("alias"); alias != nil {
+							local = alias
+						}
+						if name != nil && local.Content(program.Contents) == ident {
+							binding, exported = local, name.Content(program.Contents)
+						}
+					}
+				}
+			}
+		}
+		if binding == nil {
+			continue
+		}
+
+		module, err := s.resolveModuleTypeScript(ctx, program.RepoCommitPath, swapNode(program, statement.ChildByFieldName("source")))
+		if err != nil {
+			return nil, err
+		}
+		if module == nil {
+			return swapNodePtr(program, binding), nil
+		}
+		if exported == "" {
+			return module, nil
+		}
+		found, err := s.findExportTypeScript(ctx, *module, exported)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return swapNodePtr(program, binding), nil
+		}
+		return found, nil
+	}
+
+	return nil, nil
+}
+
+// findExportTypeScript finds the definition of a name exported by a module, following re-exports.
+func (s *SquirrelService) findExportTypeScript(ctx context.Context, module Node, name string) (ret *Node, err error) {
+	defer s.onCall(module, String(name), lazyNodeStringer(&ret))()
+
+	var exportAll []*sitter.Node
+	for _, statement := range children(module.Node) {
+		if statement.Type() != "export_statement" {
+			continue
+		}
+
+		isDefault := false
+		for i := range int(statement.ChildCount()) {
+			if statement.Child(i).Type() == "default" {
+				isDefault = true
+			}
+		}
+		source := statement.ChildByFieldName("source")
+
+		// export const x = ..., export default class X { ... }
+		if declaration := statement.ChildByFieldName("declaration"); declaration != nil {
+			names := getDeclarationNamesTypeScript(declaration)
+			if isDefault {
+				if name == "default" && len(names) > 0 {
+					return swapNodePtr(module, names[0]), nil
+				}
+				continue
+			}
+			for _, declared := range names {
+				if declared.Content(module.Contents) == name {
+					return swapNodePtr(module, declared), nil
+				}
+			}
+			continue
+		}
+
+		// export default x
+		if isDefault {
+			value := statement.ChildByFieldName("value")
+			if name != "default" || value == nil {
+				continue
+			}
+			if value.Type() == "identifier" {
+				return s.getDefTypeScript(ctx, swapNode(module, value))
+			}
+			return swapNodePtr(module, value), nil
+		}
+
+		isExportAll := source != nil
+		for _, child := range children(statement) {
+			switch child.Type() {
+			case "export_clause":
+				// export { x, y as z } (from '...')
+				isExportAll = false
+				for _, specifier := range children(child) {
+					if specifier.Type() != "export_specifier" {
+						continue
+					}
+					local := specifier.ChildByFieldName("name")
+					if local == nil {
+						continue
+					}
+					exported := local
+					if alias := specifier.ChildByFieldName("alias"); alias != nil {
+						exported = alias
+					}
+					if exported.Content(module.Contents) != name {
+						continue
+					}
+					if source == nil {
+						return s.getDefTypeScript(ctx, swapNode(module, local))
+					}
+					target, err := s.resolveModuleTypeScript(ctx, module.RepoCommitPath, swapNode(module, source))
+					if err != nil {
+						return nil, err
+					}
+					if target == nil {
+						return swapNodePtr(module, exported), nil
+					}
+					return s.findExportTypeScript(ctx, *target, local.Content(module.Contents))
+				}
+			case "namespace_export":
+				// export * as x from '...'
+				isExportAll = false
+				for _, exported := range children(child) {
+					if exported.Content(module.Contents) == name {
+						return s.resolveModuleTypeScript(ctx, module.RepoCommitPath, swapNode(module, source))
+					}
+				}
+			}
+		}
+		if isExportAll {
+			exportAll = append(exportAll, source)
+		}
+	}
+
+	// export * from '...' re-exports everything except the default export
+	if name == "default" {
+		return nil, nil
+	}
+	for _, source := range exportAll {
+		target, err := s.resolveModuleTypeScript(ctx, module.RepoCommitPath, swapNode(module, source))
+		if err != nil {
+			return nil, err
+		}
+		if target == nil {
+			continue
+		}
+		found, err := s.findExportTypeScript(ctx, *target, name)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveModuleTypeScript parses the module imported by the given module specifier string. Relative
+// specifiers are resolved against the importing file, and others using the baseUrl and paths of the
+// nearest tsconfig.json. Modules in node_modules are not resolved.
+func (s *SquirrelService) resolveModuleTypeScript(ctx context.Context, from types.RepoCommitPath, source Node) (ret *Node, err error) {
+	if source.Node == nil || source.Type() != "string" {
+		return nil, nil
+	}
+	defer s.onCall(source, String(from.Path), lazyNodeStringer(&ret))()
+
+	specifier := strings.Trim(source.Content(source.Contents), "'\"`")
+
+	var bases []string
+	if specifier == "." || specifier == ".." || strings.HasPrefix(specifier, "./") || strings.HasPrefix(specifier, "../") {
+		bases = []string{filepath.Join(filepath.Dir(from.Path), specifier)}
+	} else {
+		config, err := s.findTSConfigTypeScript(ctx, from)
+		if err != nil {
+			return nil, err
+		}
+		if config == nil {
+			return nil, nil
+		}
+		bases = config.resolve(specifier)
+	}
+
+	for _, base := range bases {
+		for _, candidate := range moduleFileCandidatesTypeScript(base) {
+			module, err := s.parse(ctx, types.RepoCommitPath{Repo: from.Repo, Commit: from.Commit, Path: candidate})
+			if err == nil {
+				return module, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// moduleFileCandidatesTypeScript returns the files that a module path without extension may refer to,
+// in the order TypeScript tries them.
+func moduleFileCandidatesTypeScript(base string) []string {
+	var candidates []string
+	switch filepath.Ext(base) {
+	case ".ts", ".tsx":
+		candidates = append(candidates, base)
+	case ".js", ".jsx":
+		// ES modules import the emitted .js file of a .ts file
+		trimmed := strings.TrimSuffix(base, filepath.Ext(base))
+		candidates = append(candidates, trimmed+".ts", trimmed+".tsx", trimmed+".d.ts")
+	}
+	for _, ext := range []string{".ts", ".tsx", ".d.ts"} {
+		candidates = append(candidates, base+ext)
+	}
+	for _, ext := range []string{".ts", ".tsx", ".d.ts"} {
+		candidates = append(candidates, filepath.Join(base, "index"+ext))
+	}
+	return candidates
+}
+
+// tsConfigTypeScript contains the module resolution options of a tsconfig.json file, with paths
+// relative to the root of the repository.
+type tsConfigTypeScript struct {
+	baseURL  string
+	paths    map[string][]string
+	pathsDir string
+}
+
+// resolve returns the paths that a non-relative module specifier may refer to.
+func (c *tsConfigTypeScript) resolve(specifier string) []string {
+	var bases []string
+
+	// The pattern with the longest prefix before the wildcard wins
+	bestPattern, bestPrefix, bestWildcard := "", -1, ""
+	for pattern := range c.paths {
+		prefix, suffix, hasWildcard := strings.Cut(pattern, "*")
+		if !hasWildcard {
+			if pattern == specifier {
+				bestPattern, bestPrefix, bestWildcard = pattern, len(pattern)+1, ""
+				break
+			}
+			continue
+		}
+		if len(specifier) < len(prefix)+len(suffix) || !strings.HasPrefix(specifier, prefix) || !strings.HasSuffix(specifier, suffix) {
+			continue
+		}
+		if len(prefix) > bestPrefix || (len(prefix) == bestPrefix && pattern < bestPattern) {
+			bestPattern, bestPrefix, bestWildcard = pattern, len(prefix), specifier[len(prefix):len(specifier)-len(suffix)]
+		}
+	}
+	if bestPrefix >= 0 {
+		dir := c.pathsDir
+		if c.baseURL != "" {
+			dir = c.baseURL
+		}
+		for _, substitution := range c.paths[bestPattern] {
+			bases = append(bases, filepath.Join(dir, strings.Replace(substitution, "*", bestWildcard, 1)))
+		}
+	}
+
+	if c.baseURL != "" {
+		bases = append(bases, filepath.Join(c.baseURL, specifier))
+	}
+
+	return bases
+}
+
+// findTSConfigTypeScript loads the tsconfig.json file closest to the given file.
+func (s *SquirrelService) findTSConfigTypeScript(ctx context.Context, from types.RepoCommitPath) (*tsConfigTypeScript, error) {
+	dir := filepath.Dir(from.Path)
+	for {
+		config, err := s.loadTSConfigTypeScript(ctx, types.RepoCommitPath{Repo: from.Repo, Commit: from.Commit, Path: filepath.Join(dir, "tsconfig.json")}, 0)
+		if err != nil {
+			return nil, err
+		}
+		if config != nil {
+			return config, nil
+		}
+		if dir == "." || dir == "/" {
+			return nil, nil
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// loadTSConfigTypeScript reads a tsconfig.json file and the files it extends. It returns nil if the
+// file does not exist.
+func (s *SquirrelService) loadTSConfigTypeScript(ctx context.Context, path types.RepoCommitPath, depth int) (*tsConfigTypeScript, error) {
+	contents, err := s.readFile(ctx, path)
+	if err != nil {
+		return nil, nil
+	}
+
+	var raw struct {
+		Extends         string `json:"extends"`
+		CompilerOptions struct {
+			BaseURL *string             `json:"baseUrl"`
+			Paths   map[string][]string `json:"paths"`
+		} `json:"compilerOptions"`
+	}
+	if err := jsonc.Unmarshal(string(contents), &raw); err != nil {
+		// Malformed configs are treated like missing ones rather than failing navigation
+		return nil, nil
+	}
+
+	dir := filepath.Dir(path.Path)
+	config := &tsConfigTypeScript{}
+
+	// Only configs in the repository can be extended, not the ones of packages in node_modules
+	if strings.HasPrefix(raw.Extends, "./") || strings.HasPrefix(raw.Extends, "../") {
+		if depth >= maxTSConfigExtendsDepth {
+			return nil, nil
+		}
+		extends := filepath.Join(dir, raw.Extends)
+		if filepath.Ext(extends) != ".json" {
+			extends += ".json"
+		}
+		parent, err := s.loadTSConfigTypeScript(ctx, types.RepoCommitPath{Repo: path.Repo, Commit: path.Commit, Path: extends}, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if parent != nil {
+			*config = *parent
+		}
+	}
+
+	if raw.CompilerOptions.BaseURL != nil {
+		config.baseURL = filepath.Join(dir, *raw.CompilerOptions.BaseURL)
+	}
+	if raw.CompilerOptions.Paths != nil {
+		config.paths = raw.CompilerOptions.Paths
+		config.pathsDir = dir
+	}
+
+	return config, nil
+}
+
+func (s *SquirrelService) getFieldTypeScript(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer s.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := s.getTypeDefTypeScript(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return s.lookupFieldTypeScript(ctx, *ty, field)
+}
+
+// lookupFieldTypeScript finds a member of a type, which is a module namespace, class, interface,
+// object type, enum or object literal. Members inherited through extends clauses are included.
+func (s *SquirrelService) lookupFieldTypeScript(ctx context.Context, ty Node, field string) (ret *Node, err error) {
+	defer s.onCall(ty, &Tuple{String(ty.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	findMember := func(body *sitter.Node) *Node {
+		for _, member := range children(body) {
+			switch member.Type() {
+			case "method_definition", "method_signature", "abstract_method_signature", "public_field_definition",
+				"property_signature":
+				name := member.ChildByFieldName("name")
+				if name != nil && name.Content(ty.Contents) == field {
+					return swapNodePtr(ty, name)
+				}
+				if name == nil || name.Content(ty.Contents) != "constructor" {
+					continue
+				}
+				// constructor(private x: number) declares a property
+				for _, parameter := range children(member.ChildByFieldName("parameters")) {
+					isProperty := false
+					for _, child := range children(parameter) {
+						if child.Type() == "accessibility_modifier" {
+							isProperty = true
+						}
+					}
+					pattern := parameter.ChildByFieldName("pattern")
+					if isProperty && pattern != nil && pattern.Content(ty.Contents) == field {
+						return swapNodePtr(ty, pattern)
+					}
+				}
+			case "pair":
+				key := member.ChildByFieldName("key")
+				if key != nil && key.Content(ty.Contents) == field {
+					return swapNodePtr(ty, key)
+				}
+			case "shorthand_property_identifier", "property_identifier":
+				if member.Content(ty.Contents) == field {
+					return swapNodePtr(ty, member)
+				}
+			case "enum_assignment":
+				name := member.ChildByFieldName("name")
+				if name != nil && name.Content(ty.Contents) == field {
+					return swapNodePtr(ty, name)
+				}
+			}
+		}
+		return nil
+	}
+
+	lookupSupers := func(supers []*sitter.Node) (*Node, error) {
+		for _, super := range supers {
+			superTy, err := s.getTypeDefTypeScript(ctx, swapNode(ty, super))
+			if err != nil {
+				return nil, err
+			}
+			if superTy == nil {
+				continue
+			}
+			found, err := s.lookupFieldTypeScript(ctx, *superTy, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+		return nil, nil
+	}
+
+	switch ty.Type() {
+	case "program":
+		return s.findExportTypeScript(ctx, ty, field)
+
+	case "class_declaration", "abstract_class_declaration", "class":
+		if found := findMember(ty.ChildByFieldName("body")); found != nil {
+			return found, nil
+		}
+		var supers []*sitter.Node
+		for _, heritage := range children(ty.Node) {
+			if heritage.Type() != "class_heritage" {
+				continue
+			}
+			for _, clause := range children(heritage) {
+				if clause.Type() == "extends_clause" {
+					supers = append(supers, childrenForFieldName(clause, "value")...)
+				}
+			}
+		}
+		return lookupSupers(supers)
+
+	case "interface_declaration":
+		if found := findMember(ty.ChildByFieldName("body")); found != nil {
+			return found, nil
+		}
+		var supers []*sitter.Node
+		for _, clause := range children(ty.Node) {
+			if clause.Type() == "extends_type_clause" {
+				supers = append(supers, childrenForFieldName(clause, "type")...)
+			}
+		}
+		return lookupSupers(supers)
+
+	case "enum_declaration":
+		return findMember(ty.ChildByFieldName("body")), nil
+
+	case "object_type", "object":
+		return findMember(ty.Node), nil
+
+	default:
+		s.breadcrumb(ty, fmt.Sprintf("lookupFieldTypeScript: unexpected type node %q", ty.Type()))
+		return nil, nil
+	}
+}
+
+// getTypeDefTypeScript returns the type of an expression or the definition of a type expression.
+func (s *SquirrelService) getTypeDefTypeScript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier", "nested_type_identifier", "member_expression":
+		var def *Node
+		switch node.Type() {
+		case "nested_type_identifier":
+			module := node.ChildByFieldName("module")
+			name := node.ChildByFieldName("name")
+			if module == nil || name == nil {
+				return nil, nil
+			}
+			def, err = s.getFieldTypeScript(ctx, swapNode(node, module), name.Content(node.Contents))
+		case "member_expression":
+			object := node.ChildByFieldName("object")
+			property := node.ChildByFieldName("property")
+			if object == nil || property == nil {
+				return nil, nil
+			}
+			def, err = s.getFieldTypeScript(ctx, swapNode(node, object), property.Content(node.Contents))
+		default:
+			def, err = s.getDefTypeScript(ctx, node)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if def == nil {
+			return nil, nil
+		}
+		return s.defToTypeTypeScript(ctx, *def)
+	case "this":
+		for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+			switch cur.Type() {
+			case "class_declaration", "abstract_class_declaration", "class":
+				return swapNodePtr(node, cur), nil
+			}
+		}
+		return nil, nil
+	case "new_expression":
+		constructor := node.ChildByFieldName("constructor")
+		if constructor == nil {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, constructor))
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		var def *Node
+		switch fn.Type() {
+		case "identifier":
+			def, err = s.getDefTypeScript(ctx, swapNode(node, fn))
+		case "member_expression":
+			property := fn.ChildByFieldName("property")
+			if property == nil {
+				return nil, nil
+			}
+			def, err = s.getDefTypeScript(ctx, swapNode(node, property))
+		}
+		if err != nil {
+			return nil, err
+		}
+		if def == nil || def.Node == nil || def.Parent() == nil {
+			return nil, nil
+		}
+		returnType := def.Parent().ChildByFieldName("return_type")
+		if returnType == nil {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(*def, returnType))
+	case "parenthesized_expression", "non_null_expression", "type_annotation":
+		if node.NamedChildCount() == 0 {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, node.NamedChild(0)))
+	case "as_expression":
+		if node.NamedChildCount() == 0 {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, node.NamedChild(int(node.NamedChildCount())-1)))
+	case "generic_type":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, name))
+	case "class", "object_type", "object":
+		return &node, nil
+	default:
+		s.breadcrumb(node, fmt.Sprintf("getTypeDefTypeScript: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+// defToTypeTypeScript returns the type of the entity declared by a definition.
+func (s *SquirrelService) defToTypeTypeScript(ctx context.Context, def Node) (*Node, error) {
+	if def.Type() == "program" {
+		return &def, nil
+	}
+
+	parent := def.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "class_declaration", "abstract_class_declaration", "class", "interface_declaration", "enum_declaration":
+		return swapNodePtr(def, parent), nil
+	case "type_alias_declaration":
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(def, value))
+	case "variable_declarator", "required_parameter", "optional_parameter", "public_field_definition", "property_signature":
+		if ty := parent.ChildByFieldName("type"); ty != nil {
+			return s.getTypeDefTypeScript(ctx, swapNode(def, ty))
+		}
+		if value := parent.ChildByFieldName("value"); value != nil {
+			return s.getTypeDefTypeScript(ctx, swapNode(def, value))
+		}
+		return nil, nil
+	default:
+		return nil, nil
+	}
+}
@@ -144,11 +144,12 @@ func (s *SquirrelService) getDef(ctx context.Context, node Node) (*Node, error)
 		return s.getDefStarlark(ctx, node)
 	case "python":
 		return s.getDefPython(ctx, node)
-	// case "go":
+	case "go":
+		return s.getDefGo(ctx, node)
+	case "typescript":
+		return s.getDefTypeScript(ctx, node)
 	// case "csharp":
-	// case "python":
 	// case "javascript":
-	// case "typescript":
 	// case "cpp":
 	// case "ruby":
 	default:
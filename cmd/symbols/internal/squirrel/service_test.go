@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func init() {
@@ -61,6 +62,10 @@ func TestNonLocalDefinition(t *testing.T) {
 			annotations = append(annotations, collectAnnotations(repoCommitPath, string(contents))...)
 
 			symbols, err := tempSquirrel.getSymbols(context.Background(), repoCommitPath)
+			if errors.Is(err, UnsupportedLanguageError) || errors.Is(err, UnrecognizedFileExtensionError) {
+				// Files like go.mod and tsconfig.json are read during resolution, but never parsed
+				return nil
+			}
 			fatalIfErrorLabel(t, err, "getSymbols")
 			allSymbols = append(allSymbols, symbols...)
 
@@ -0,0 +1,3 @@
+module example.com/squirrel
+
+go 1.22
@@ -0,0 +1,3 @@
+package main
+
+func helper() {} // < "helper" go.helper def
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/squirrel/shapes"
+	geo "example.com/squirrel/shapes/geometry"
+)
+
+type Canvas struct {
+	shapes.Circle           // < "shapes" go.Canvas.Circle def
+	Origin        geo.Point // < "Origin" go.Canvas.Origin def
+}
+
+func draw(canvas *Canvas, scale int) int { // < "draw" go.draw def // < "canvas" go.draw.canvas def // < "scale" go.draw.scale def
+	total := canvas.Area() // < "total" go.draw.total def
+	//       ^^^^^^ go.draw.canvas ref
+	//              ^^^^ go.Circle.Area ref
+	total += canvas.Origin.X
+	//              ^^^^^^ go.Canvas.Origin ref
+	//                     ^ go.Point.X ref
+	var s shapes.Shape = &canvas.Circle
+	//  ^ go.draw.s def
+	//           ^^^^^ go.Shape ref
+	//                           ^^^^^^ go.Canvas.Circle ref
+	if area := s.Area(); area > total { // < "area" go.draw.area def // < "Area" go.Shape.Area ref
+		return area * scale
+		//     ^^^^ go.draw.area ref
+		//            ^^^^^ go.draw.scale ref
+	}
+	return total * scale
+	//     ^^^^^ go.draw.total ref
+}
+
+func main() {
+	c := shapes.NewCircle(2) // < "c" go.main.c def
+	//   ^^^^^^ shapes path
+	//          ^^^^^^^^^ go.NewCircle ref
+	p := geo.Point{X: 1, Y: 2}
+	//   ^^^ shapes/geometry path
+	//       ^^^^^ go.Point ref
+	//                   ^ go.Point.Y ref
+	fmt.Println(c.Area(), c.Radius, p.Dist(), draw(&Canvas{Circle: *c}, 1))
+	//  ^^^^^^^ go.fmt.Println ref,nodef
+	//          ^ go.main.c ref
+	//            ^^^^ go.Circle.Area ref
+	//                      ^^^^^^ go.Circle.Radius ref
+	//                                ^^^^ go.Point.Dist ref
+	//                                        ^^^^ go.draw ref
+	//                                                     ^^^^^^ go.Canvas.Circle ref
+	helper() // < "helper" go.helper ref
+}
@@ -0,0 +1,6 @@
+package shapes
+
+func (c *Circle) Area() int { // < "Area" go.Circle.Area def
+	return 3 * c.Radius * c.Radius
+	//           ^^^^^^ go.Circle.Radius ref
+}
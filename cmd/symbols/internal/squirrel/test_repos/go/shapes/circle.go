@@ -0,0 +1,11 @@
+package shapes
+
+type Circle struct {
+	Radius int // < "Radius" go.Circle.Radius def
+}
+
+func NewCircle(radius int) *Circle { // < "NewCircle" go.NewCircle def // < "radius" go.NewCircle.radius def
+	return &Circle{Radius: radius}
+	//             ^^^^^^ go.Circle.Radius ref
+	//                     ^^^^^^ go.NewCircle.radius ref
+}
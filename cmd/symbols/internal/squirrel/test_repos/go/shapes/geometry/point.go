@@ -0,0 +1,11 @@
+package geometry
+
+type Point struct { // < "Point" go.Point def
+	X, Y int // < "X" go.Point.X def
+	// ^ go.Point.Y def
+}
+
+func (p Point) Dist() int { // < "Dist" go.Point.Dist def
+	return p.X + p.Y
+	//       ^ go.Point.X ref
+}
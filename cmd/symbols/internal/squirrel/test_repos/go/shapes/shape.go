@@ -0,0 +1,5 @@
+package shapes
+
+type Shape interface { // < "Shape" go.Shape def
+	Area() int // < "Area" go.Shape.Area def
+}
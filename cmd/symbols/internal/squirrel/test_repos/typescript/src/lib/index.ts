@@ -0,0 +1,3 @@
+export * from './shapes'
+export { default as unit } from './shapes'
+export * as strings from '../util/strings'
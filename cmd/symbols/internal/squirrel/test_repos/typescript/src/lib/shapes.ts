@@ -0,0 +1,18 @@
+export interface Shape { // < "Shape" ts.Shape def
+    area(): number // < "area" ts.Shape.area def
+}
+
+export class Circle implements Shape { // < "Circle" ts.Circle def // < "Shape" ts.Shape ref
+    constructor(public radius: number) {}
+    //                 ^^^^^^ ts.Circle.radius def
+
+    area(): number { // < "area" ts.Circle.area def
+        return 3 * this.radius * this.radius
+        //              ^^^^^^ ts.Circle.radius ref
+    }
+}
+
+export default function unit(): Circle { // < "unit" ts.unit def // < "Circle" ts.Circle ref
+    return new Circle(1)
+    //         ^^^^^^ ts.Circle ref
+}
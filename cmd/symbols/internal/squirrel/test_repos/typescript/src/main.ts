@@ -0,0 +1,37 @@
+import { Circle, unit as makeUnit, strings } from '@lib/index' // < "Circle" ts.Circle ref // < "makeUnit" ts.unit ref // < "strings" ts.strings ref
+import * as str from 'util/strings' // < "str" ts.str ref
+import makeUnit2 from './lib/shapes' // < "makeUnit2" ts.unit ref
+import { readFileSync } from 'fs' // < "readFileSync" ts.readFileSync def
+
+class Square extends Circle { // < "Square" ts.Square def // < "Circle" ts.Circle ref
+    side = 2 // < "side" ts.Square.side def
+}
+
+function total(shapes: Shape[], scale: number): number { // < "total" ts.total def // < "scale" ts.total.scale def // < "Shape" ts.main.Shape ref
+    let sum = 0
+    //  ^^^ ts.total.sum def
+    for (const shape of shapes) { // < "shape" ts.total.shape def
+        sum += shape.area() * scale // < "sum" ts.total.sum ref
+        //     ^^^^^ ts.total.shape ref
+        //                    ^^^^^ ts.total.scale ref
+    }
+    return sum
+    //     ^^^ ts.total.sum ref
+}
+
+interface Shape { // < "Shape" ts.main.Shape def
+    area(): number
+}
+
+const circle: Circle = new Circle(2) // < "circle" ts.circle def
+circle.area() // < "circle" ts.circle ref // < "area" ts.Circle.area ref
+circle.radius // < "radius" ts.Circle.radius ref
+makeUnit().radius // < "radius" ts.Circle.radius ref
+str.shout(str.greeting) // < "shout" ts.shout ref // < "greeting" ts.greeting ref
+strings.shout('x') // < "shout" ts.shout ref
+const square = new Square() // < "Square" ts.Square ref
+square.area() // < "area" ts.Circle.area ref
+square.side // < "side" ts.Square.side ref
+makeUnit2()
+total([circle, square], 2) // < "total" ts.total ref
+readFileSync('x') // < "readFileSync" ts.readFileSync ref
@@ -0,0 +1,6 @@
+export const greeting = 'hello' // < "export" ts.str def // < "export" ts.strings def // < "greeting" ts.greeting def
+
+export function shout(text: string): string { // < "shout" ts.shout def // < "text" ts.shout.text def
+    return text.toUpperCase()
+    //     ^^^^ ts.shout.text ref
+}
@@ -319,6 +319,24 @@ func children(node *sitter.Node) []*sitter.Node {
 	return children
 }
 
+// childrenForFieldName returns all the children of the node with the given field name, as opposed to
+// ChildByFieldName which only returns the first one.
+func childrenForFieldName(node *sitter.Node, field string) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+	// FieldNameForChild is unreliable for repeated fields, so walk the children with a cursor
+	cursor := sitter.NewTreeCursor(node)
+	defer cursor.Close()
+	var children []*sitter.Node
+	for ok := cursor.GoToFirstChild(); ok; ok = cursor.GoToNextSibling() {
+		if cursor.CurrentFieldName() == field {
+			children = append(children, cursor.CurrentNode())
+		}
+	}
+	return children
+}
+
 func snippet(node *Node) string {
 	contextChars := 5
 	start := int(node.StartByte()) - contextChars
@@ -0,0 +1,37 @@
+package codeintel
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/codeintel"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type uploadUnusedSymbolsJob struct{}
+
+func NewUploadUnusedSymbolsJob() job.Job {
+	return &uploadUnusedSymbolsJob{}
+}
+
+func (j *uploadUnusedSymbolsJob) Description() string {
+	return "Computes the symbols defined by precise indexes that are never referenced."
+}
+
+func (j *uploadUnusedSymbolsJob) Config() []env.Config {
+	return []env.Config{
+		uploads.UnusedSymbolsConfigInst,
+	}
+}
+
+func (j *uploadUnusedSymbolsJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	services, err := codeintel.InitServices(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return uploads.NewUnusedSymbolReporter(observationCtx, services.UploadsService), nil
+}
@@ -0,0 +1,73 @@
+package savedsearches
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/savedsearches/snapshots"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+)
+
+type snapshotJob struct{}
+
+func NewSnapshotJob() job.Job {
+	return &snapshotJob{}
+}
+
+func (j *snapshotJob) Description() string {
+	return "takes scheduled snapshots of saved search results"
+}
+
+func (j *snapshotJob) Config() []env.Config {
+	return []env.Config{search.ObjectStorageConfigInst}
+}
+
+func (j *snapshotJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	uploadStore, err := search.NewObjectStorage(ctx, observationCtx, search.ObjectStorageConfigInst)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := observationCtx.Logger.Scoped("savedsearches.snapshots")
+	searchClient := client.New(logger, db, gitserver.NewClient("savedsearches.snapshots"))
+	svc := snapshots.NewService(logger, db, uploadStore, searchClient)
+
+	handler := goroutine.HandlerFunc(func(ctx context.Context) error {
+		return svc.SnapshotDue(ctx)
+	})
+
+	operation := observationCtx.Operation(observation.Op{
+		Name: "saved_searches.snapshots.run",
+		Metrics: metrics.NewREDMetrics(
+			observationCtx.Registerer,
+			"saved_searches_snapshots",
+			metrics.WithCountHelp("Total number of scheduled saved search snapshot executions"),
+		),
+	})
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(
+			ctx,
+			handler,
+			goroutine.WithName("saved_search_snapshots"),
+			goroutine.WithDescription("takes scheduled snapshots of saved search results"),
+			goroutine.WithInterval(10*time.Minute),
+			goroutine.WithOperation(operation),
+		),
+	}, nil
+}
@@ -0,0 +1,65 @@
+package searchcontexts
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+)
+
+type materializeJob struct{}
+
+func NewMaterializeJob() job.Job {
+	return &materializeJob{}
+}
+
+func (j *materializeJob) Description() string {
+	return "materializes the repositories of query-based search contexts with a refresh interval"
+}
+
+func (j *materializeJob) Config() []env.Config {
+	return nil
+}
+
+func (j *materializeJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := observationCtx.Logger.Scoped("searchcontexts.materialize")
+	materializer := searchcontexts.NewMaterializer(db, gitserver.NewClient("searchcontexts.materialize"), logger)
+
+	handler := goroutine.HandlerFunc(func(ctx context.Context) error {
+		_, err := materializer.RefreshDue(ctx)
+		return err
+	})
+
+	operation := observationCtx.Operation(observation.Op{
+		Name: "search_contexts.materialize.run",
+		Metrics: metrics.NewREDMetrics(
+			observationCtx.Registerer,
+			"search_contexts_materialize",
+			metrics.WithCountHelp("Total number of scheduled search context materializations"),
+		),
+	})
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(
+			actor.WithInternalActor(context.Background()),
+			handler,
+			goroutine.WithName("search_contexts_materialize"),
+			goroutine.WithDescription("materializes the repositories of query-based search contexts"),
+			goroutine.WithInterval(5*time.Minute),
+			goroutine.WithOperation(operation),
+		),
+	}, nil
+}
@@ -35,7 +35,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/permissions"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/repostatistics"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/savedsearches"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/search"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/searchcontexts"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/sourcegraphaccounts"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/telemetry"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/telemetrygatewayexporter"
@@ -122,6 +124,7 @@ func LoadConfig(registerEnterpriseMigrators oobmigration.RegisterMigratorsFunc)
 		"codeintel-upload-backfiller":                 codeintel.NewUploadBackfillerJob(),
 		"codeintel-upload-expirer":                    codeintel.NewUploadExpirerJob(),
 		"codeintel-upload-janitor":                    codeintel.NewUploadJanitorJob(),
+		"codeintel-upload-unused-symbols":             codeintel.NewUploadUnusedSymbolsJob(),
 		"codeintel-ranking-file-reference-counter":    codeintel.NewRankingFileReferenceCounter(),
 		"codeintel-uploadstore-expirer":               codeintel.NewPreciseCodeIntelUploadExpirer(),
 		"codeintel-package-filter-applicator":         codeintel.NewPackagesFilterApplicatorJob(),
@@ -140,6 +143,10 @@ func LoadConfig(registerEnterpriseMigrators oobmigration.RegisterMigratorsFunc)
 
 		"exhaustive-search-job": search.NewSearchJob(),
 
+		"saved-search-snapshots": savedsearches.NewSnapshotJob(),
+
+		"search-context-materializer": searchcontexts.NewMaterializeJob(),
+
 		"repo-perms-syncer":          workerauthz.NewPermsSyncerJob(),
 		"perforce-changelist-mapper": perforce.NewPerforceChangelistMappingJob(),
 
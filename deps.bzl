@@ -471,6 +471,13 @@ def go_dependencies():
         sum = "h1:iEqboXubLCABYFoClUyX/Bv8DfhmV39hPKdRbs21/kI=",
         version = "v1.11.0",
     )
+    go_repository(
+        name = "com_github_aws_aws_sdk_go_v2_service_iam",
+        build_file_proto_mode = "disable_global",
+        importpath = "github.com/aws/aws-sdk-go-v2/service/iam",
+        sum = "h1:9vCynoqC+dgxZKrsjvAniyIopsv3RZFsZ6wkQ+yxtj8=",
+        version = "v1.19.0",
+    )
     go_repository(
         name = "com_github_aws_aws_sdk_go_v2_service_internal_accept_encoding",
         build_file_proto_mode = "disable_global",
@@ -0,0 +1,23 @@
+package authz
+
+import "context"
+
+type stalePermissionsKey struct{}
+
+// WithStalePermissions returns a context that marks the permissions of the
+// current actor as stale. Repository queries made with such a context only
+// return repositories that do not require explicit permissions, i.e. public and
+// unrestricted ones.
+//
+// It is set by the frontend when a user's permissions breach the configured
+// freshness SLO and a just-in-time sync did not complete in time.
+func WithStalePermissions(ctx context.Context) context.Context {
+	return context.WithValue(ctx, stalePermissionsKey{}, true)
+}
+
+// HasStalePermissions reports whether the context was marked with
+// WithStalePermissions.
+func HasStalePermissions(ctx context.Context) bool {
+	stale, _ := ctx.Value(stalePermissionsKey{}).(bool)
+	return stale
+}
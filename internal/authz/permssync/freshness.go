@@ -0,0 +1,218 @@
+package permssync
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	defaultFreshnessBlockTimeout = 10 * time.Second
+	freshnessCacheSize           = 10000
+
+	// freshnessCacheTTL is how long the freshness of a user's permissions is
+	// cached before it is read from the database again.
+	freshnessCacheTTL = 30 * time.Second
+)
+
+var (
+	metricFreshnessSLOBreached = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_authz_perms_freshness_slo_breached_total",
+		Help: "The number of requests made by users whose permissions were older than the freshness SLO.",
+	})
+	metricFreshnessSyncsScheduled = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_authz_perms_freshness_syncs_scheduled_total",
+		Help: "The number of just-in-time permissions syncs scheduled because of the freshness SLO.",
+	})
+	metricFreshnessRestricted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_authz_perms_freshness_restricted_total",
+		Help: "The number of requests restricted to public and unrestricted repositories because a just-in-time permissions sync did not complete in time.",
+	})
+	metricFreshnessWaitDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_authz_perms_freshness_wait_duration_seconds",
+		Help:    "Time spent by requests waiting for a just-in-time permissions sync.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+	}, []string{"synced"})
+)
+
+// freshnessEntry is the cached permissions freshness of a user.
+type freshnessEntry struct {
+	// checkedAt is when the entry was last read from the database.
+	checkedAt time.Time
+
+	siteAdmin   bool
+	syncedAt    time.Time
+	syncPending bool
+
+	// waitFailed is set once a request blocked on a just-in-time sync for
+	// this user and the sync did not complete in time. Later requests are
+	// restricted without blocking until the permissions are fresh again.
+	waitFailed bool
+}
+
+var (
+	// freshnessCache maps user IDs to the freshness of their permissions, so
+	// that requests don't hit the database more than once per
+	// freshnessCacheTTL for each user. Entries are never mutated in place,
+	// since they are shared between concurrent requests.
+	freshnessCache, _ = lru.New[int32, freshnessEntry](freshnessCacheSize)
+
+	// freshnessPollInterval is how often the state of a just-in-time sync is
+	// checked while a request is blocked on it.
+	freshnessPollInterval = 500 * time.Millisecond
+
+	timeNow = time.Now
+)
+
+// EnsureFreshPerms enforces the "permissions.freshnessSLO" site configuration
+// for the given user. If the user's permissions were last synced successfully
+// longer ago than the SLO allows, a high-priority permissions sync is scheduled
+// unless one is already pending. Site admins are exempt.
+//
+// When blocking is configured and block is true, EnsureFreshPerms waits for the
+// sync to complete. It returns true if the sync did not complete in time, in
+// which case the caller must only show repositories that don't require
+// permissions for the rest of the request (see authz.WithStalePermissions).
+// Once a wait has failed, later requests of the same user are restricted
+// without waiting until their permissions are fresh again.
+//
+// Errors are logged rather than returned. If blocking is configured they cause
+// the request to be restricted since we can't prove the permissions are fresh.
+func EnsureFreshPerms(ctx context.Context, logger log.Logger, db database.DB, userID int32, block bool) (restrict bool) {
+	slo := conf.Get().PermissionsFreshnessSLO
+	if slo == nil || slo.MaxAgeSeconds <= 0 || userID == 0 {
+		return false
+	}
+	maxAge := time.Duration(slo.MaxAgeSeconds) * time.Second
+	block = block && slo.BlockPrivateResults
+
+	entry, ok := freshnessCache.Get(userID)
+	if !ok || timeNow().Sub(entry.checkedAt) >= freshnessCacheTTL {
+		var err error
+		entry, err = loadFreshness(ctx, db, userID, maxAge, entry)
+		if err != nil {
+			logger.Warn("checking permissions freshness", log.Int32("userID", userID), log.Error(err))
+			return block
+		}
+		freshnessCache.Add(userID, entry)
+	}
+	if entry.siteAdmin || isFresh(entry.syncedAt, maxAge) {
+		return false
+	}
+
+	metricFreshnessSLOBreached.Inc()
+	if !entry.syncPending {
+		metricFreshnessSyncsScheduled.Inc()
+		SchedulePermsSync(ctx, logger, db, ScheduleSyncOpts{
+			UserIDs: []int32{userID},
+			Options: authz.FetchPermsOptions{InvalidateCaches: true},
+			Reason:  database.ReasonUserPermsFreshnessSLOBreached,
+		})
+		entry.syncPending = true
+		freshnessCache.Add(userID, entry)
+	}
+
+	if !block {
+		return false
+	}
+	if entry.waitFailed {
+		metricFreshnessRestricted.Inc()
+		return true
+	}
+	if waitForFreshPerms(ctx, logger, db, userID, maxAge, blockTimeout(slo)) {
+		return false
+	}
+	entry.waitFailed = true
+	freshnessCache.Add(userID, entry)
+	return true
+}
+
+// loadFreshness reads the freshness of the given user's permissions from the
+// database. A failed wait recorded in the previous entry is kept as long as
+// the permissions are still stale.
+func loadFreshness(ctx context.Context, db database.DB, userID int32, maxAge time.Duration, prev freshnessEntry) (freshnessEntry, error) {
+	user, err := db.Users().GetByID(ctx, userID)
+	if err != nil {
+		return freshnessEntry{}, err
+	}
+	if user.SiteAdmin {
+		return freshnessEntry{checkedAt: timeNow(), siteAdmin: true}, nil
+	}
+
+	f, err := db.Perms().UserPermsFreshness(ctx, userID)
+	if err != nil {
+		return freshnessEntry{}, err
+	}
+	return freshnessEntry{
+		checkedAt:   timeNow(),
+		syncedAt:    f.SyncedAt,
+		syncPending: f.SyncPending,
+		waitFailed:  prev.waitFailed && !isFresh(f.SyncedAt, maxAge),
+	}, nil
+}
+
+// waitForFreshPerms polls the permissions freshness of the given user until it
+// is within maxAge, the timeout expires or the sync finishes without
+// refreshing the permissions, and reports whether it succeeded.
+func waitForFreshPerms(ctx context.Context, logger log.Logger, db database.DB, userID int32, maxAge, timeout time.Duration) (synced bool) {
+	start := timeNow()
+	defer func() {
+		metricFreshnessWaitDuration.WithLabelValues(strconv.FormatBool(synced)).Observe(timeNow().Sub(start).Seconds())
+		if !synced {
+			metricFreshnessRestricted.Inc()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(freshnessPollInterval)
+	defer ticker.Stop()
+
+	sawPending := false
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+
+		f, err := db.Perms().UserPermsFreshness(ctx, userID)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Warn("checking permissions freshness", log.Int32("userID", userID), log.Error(err))
+			}
+			continue
+		}
+		if isFresh(f.SyncedAt, maxAge) {
+			freshnessCache.Add(userID, freshnessEntry{checkedAt: timeNow(), syncedAt: f.SyncedAt})
+			return true
+		}
+		if sawPending && !f.SyncPending {
+			// The sync finished but failed, there is nothing left to wait for.
+			return false
+		}
+		sawPending = sawPending || f.SyncPending
+	}
+}
+
+func isFresh(syncedAt time.Time, maxAge time.Duration) bool {
+	return !syncedAt.IsZero() && timeNow().Sub(syncedAt) < maxAge
+}
+
+func blockTimeout(slo *schema.PermissionsFreshnessSLO) time.Duration {
+	if slo.BlockTimeoutSeconds <= 0 {
+		return defaultFreshnessBlockTimeout
+	}
+	return time.Duration(slo.BlockTimeoutSeconds) * time.Second
+}
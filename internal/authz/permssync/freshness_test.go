@@ -0,0 +1,170 @@
+package permssync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestEnsureFreshPerms(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	timeNow = func() time.Time { return now }
+	freshnessPollInterval = time.Millisecond
+	t.Cleanup(func() {
+		timeNow = time.Now
+		freshnessPollInterval = 500 * time.Millisecond
+	})
+
+	setupUser := func(t *testing.T, user *types.User, slo *schema.PermissionsFreshnessSLO, freshness ...*database.PermsFreshness) (database.DB, *dbmocks.MockPermsStore, *dbmocks.MockPermissionSyncJobStore) {
+		t.Helper()
+
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{PermissionsFreshnessSLO: slo}})
+		t.Cleanup(func() { conf.Mock(nil) })
+
+		cache, err := lru.New[int32, freshnessEntry](freshnessCacheSize)
+		require.NoError(t, err)
+		freshnessCache = cache
+
+		perms := dbmocks.NewMockPermsStore()
+		for _, f := range freshness {
+			perms.UserPermsFreshnessFunc.PushReturn(f, nil)
+		}
+		perms.UserPermsFreshnessFunc.SetDefaultReturn(freshness[len(freshness)-1], nil)
+
+		syncJobs := dbmocks.NewMockPermissionSyncJobStore()
+
+		users := dbmocks.NewMockUserStore()
+		users.GetByIDFunc.SetDefaultReturn(user, nil)
+
+		db := dbmocks.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.PermsFunc.SetDefaultReturn(perms)
+		db.PermissionSyncJobsFunc.SetDefaultReturn(syncJobs)
+		return db, perms, syncJobs
+	}
+	setup := func(t *testing.T, slo *schema.PermissionsFreshnessSLO, freshness ...*database.PermsFreshness) (database.DB, *dbmocks.MockPermsStore, *dbmocks.MockPermissionSyncJobStore) {
+		t.Helper()
+		return setupUser(t, &types.User{ID: 1}, slo, freshness...)
+	}
+
+	stale := &database.PermsFreshness{ID: 1, SyncedAt: now.Add(-2 * time.Hour)}
+	fresh := &database.PermsFreshness{ID: 1, SyncedAt: now.Add(-time.Minute)}
+
+	t.Run("disabled", func(t *testing.T) {
+		db, perms, _ := setup(t, nil, stale)
+		assert.False(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+		assert.Empty(t, perms.UserPermsFreshnessFunc.History())
+	})
+
+	t.Run("fresh permissions are cached", func(t *testing.T) {
+		db, perms, syncJobs := setup(t, &schema.PermissionsFreshnessSLO{MaxAgeSeconds: 3600}, fresh)
+		assert.False(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+		assert.False(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+		assert.Len(t, perms.UserPermsFreshnessFunc.History(), 1)
+		assert.Empty(t, syncJobs.CreateUserSyncJobFunc.History())
+	})
+
+	t.Run("stale permissions schedule a sync", func(t *testing.T) {
+		db, _, syncJobs := setup(t, &schema.PermissionsFreshnessSLO{MaxAgeSeconds: 3600}, stale)
+		assert.False(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+		require.Len(t, syncJobs.CreateUserSyncJobFunc.History(), 1)
+		opts := syncJobs.CreateUserSyncJobFunc.History()[0].Arg2
+		assert.Equal(t, database.ReasonUserPermsFreshnessSLOBreached, opts.Reason)
+		assert.Equal(t, database.HighPriorityPermissionsSync, opts.Priority)
+		assert.True(t, opts.InvalidateCaches)
+	})
+
+	t.Run("pending sync is not scheduled again", func(t *testing.T) {
+		pending := &database.PermsFreshness{ID: 1, SyncPending: true}
+		db, _, syncJobs := setup(t, &schema.PermissionsFreshnessSLO{MaxAgeSeconds: 3600}, pending)
+		assert.False(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+		assert.Empty(t, syncJobs.CreateUserSyncJobFunc.History())
+	})
+
+	t.Run("blocking waits for the sync to complete", func(t *testing.T) {
+		slo := &schema.PermissionsFreshnessSLO{MaxAgeSeconds: 3600, BlockPrivateResults: true, BlockTimeoutSeconds: 10}
+		db, perms, _ := setup(t, slo, stale, stale, fresh)
+		assert.False(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+		assert.Len(t, perms.UserPermsFreshnessFunc.History(), 3)
+	})
+
+	t.Run("blocking restricts results on timeout", func(t *testing.T) {
+		slo := &schema.PermissionsFreshnessSLO{MaxAgeSeconds: 3600, BlockPrivateResults: true, BlockTimeoutSeconds: 1}
+		db, _, _ := setup(t, slo, stale)
+		assert.True(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+	})
+
+	t.Run("stale permissions are cached", func(t *testing.T) {
+		t.Cleanup(func() { now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC) })
+
+		db, perms, syncJobs := setup(t, &schema.PermissionsFreshnessSLO{MaxAgeSeconds: 3600}, stale)
+		assert.False(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+		assert.False(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+		assert.Len(t, perms.UserPermsFreshnessFunc.History(), 1)
+		assert.Len(t, syncJobs.CreateUserSyncJobFunc.History(), 1)
+
+		now = now.Add(freshnessCacheTTL)
+		assert.False(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+		assert.Len(t, perms.UserPermsFreshnessFunc.History(), 2)
+		assert.Len(t, syncJobs.CreateUserSyncJobFunc.History(), 2)
+	})
+
+	t.Run("site admins are exempt", func(t *testing.T) {
+		slo := &schema.PermissionsFreshnessSLO{MaxAgeSeconds: 3600, BlockPrivateResults: true, BlockTimeoutSeconds: 1}
+		db, perms, syncJobs := setupUser(t, &types.User{ID: 1, SiteAdmin: true}, slo, stale)
+		assert.False(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+		assert.Empty(t, perms.UserPermsFreshnessFunc.History())
+		assert.Empty(t, syncJobs.CreateUserSyncJobFunc.History())
+	})
+
+	t.Run("non-blocking requests are not held", func(t *testing.T) {
+		slo := &schema.PermissionsFreshnessSLO{MaxAgeSeconds: 3600, BlockPrivateResults: true, BlockTimeoutSeconds: 10}
+		db, perms, syncJobs := setup(t, slo, stale)
+		assert.False(t, EnsureFreshPerms(ctx, logger, db, 1, false))
+		assert.Len(t, perms.UserPermsFreshnessFunc.History(), 1)
+		assert.Len(t, syncJobs.CreateUserSyncJobFunc.History(), 1)
+	})
+
+	t.Run("blocking stops waiting when the sync fails", func(t *testing.T) {
+		slo := &schema.PermissionsFreshnessSLO{MaxAgeSeconds: 3600, BlockPrivateResults: true, BlockTimeoutSeconds: 10}
+		pending := &database.PermsFreshness{ID: 1, SyncedAt: stale.SyncedAt, SyncPending: true}
+		db, perms, _ := setup(t, slo, stale, pending, stale)
+
+		start := time.Now()
+		assert.True(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Len(t, perms.UserPermsFreshnessFunc.History(), 3)
+	})
+
+	t.Run("blocking falls back to restricted results after a failed wait", func(t *testing.T) {
+		slo := &schema.PermissionsFreshnessSLO{MaxAgeSeconds: 3600, BlockPrivateResults: true, BlockTimeoutSeconds: 1}
+		db, perms, _ := setup(t, slo, stale)
+		assert.True(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+
+		calls := len(perms.UserPermsFreshnessFunc.History())
+		start := time.Now()
+		assert.True(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+		assert.Len(t, perms.UserPermsFreshnessFunc.History(), calls)
+
+		// Once the permissions are fresh the user is no longer restricted.
+		t.Cleanup(func() { now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC) })
+		now = now.Add(freshnessCacheTTL)
+		perms.UserPermsFreshnessFunc.SetDefaultReturn(&database.PermsFreshness{ID: 1, SyncedAt: now.Add(-time.Minute)}, nil)
+		assert.False(t, EnsureFreshPerms(ctx, logger, db, 1, true))
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/authz/providers/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/authz/providers/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/authz/providers/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/authz/providers/bitbucketserver"
@@ -58,6 +59,7 @@ func ProvidersFromConfig(
 
 	opt := database.ExternalServicesListOptions{
 		Kinds: []string{
+			extsvc.VariantAWSCodeCommit.AsKind(),
 			extsvc.VariantAzureDevOps.AsKind(),
 			extsvc.VariantBitbucketCloud.AsKind(),
 			extsvc.VariantBitbucketServer.AsKind(),
@@ -79,6 +81,7 @@ func ProvidersFromConfig(
 		bitbucketCloudConns  []*types.BitbucketCloudConnection
 		gerritConns          []*types.GerritConnection
 		azuredevopsConns     []*types.AzureDevOpsConnection
+		awsCodeCommitConns   []*types.AWSCodeCommitConnection
 	)
 	for {
 		svcs, err := db.ExternalServices().List(ctx, opt)
@@ -99,6 +102,11 @@ func ProvidersFromConfig(
 			}
 
 			switch c := cfg.(type) {
+			case *schema.AWSCodeCommitConnection:
+				awsCodeCommitConns = append(awsCodeCommitConns, &types.AWSCodeCommitConnection{
+					URN:                     svc.URN(),
+					AWSCodeCommitConnection: c,
+				})
 			case *schema.AzureDevOpsConnection:
 				azuredevopsConns = append(azuredevopsConns, &types.AzureDevOpsConnection{
 					URN:                   svc.URN(),
@@ -163,6 +171,7 @@ func ProvidersFromConfig(
 	initResult.Append(bitbucketcloud.NewAuthzProviders(db, bitbucketCloudConns, cfg.SiteConfig().AuthProviders))
 	initResult.Append(gerrit.NewAuthzProviders(gerritConns, cfg.SiteConfig().AuthProviders))
 	initResult.Append(azuredevops.NewAuthzProviders(db, azuredevopsConns, httpcli.ExternalClient))
+	initResult.Append(awscodecommit.NewAuthzProviders(awsCodeCommitConns))
 
 	return allowAccessByDefault, initResult.Providers, initResult.Problems, initResult.Warnings, initResult.InvalidConnections
 }
@@ -0,0 +1,112 @@
+package awscodecommit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/codecommit"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	atypes "github.com/sourcegraph/sourcegraph/internal/authz/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewAuthzProviders returns the set of AWS CodeCommit authz providers derived
+// from the connections.
+//
+// It also returns any simple validation problems with the config, separating these into "serious problems"
+// and "warnings". "Serious problems" are those that should make Sourcegraph set authz.allowAccessByDefault
+// to false. "Warnings" are all other validation problems.
+//
+// This constructor does not and should not directly check connectivity to external services - if
+// desired, callers should use `(*Provider).ValidateConnection` directly to get warnings related
+// to connection issues.
+func NewAuthzProviders(conns []*types.AWSCodeCommitConnection) *atypes.ProviderInitResult {
+	initResults := &atypes.ProviderInitResult{}
+	for _, c := range conns {
+		p, err := newAuthzProvider(c)
+		if err != nil {
+			initResults.InvalidConnections = append(initResults.InvalidConnections, extsvc.TypeAWSCodeCommit)
+			initResults.Problems = append(initResults.Problems, err.Error())
+		} else if p != nil {
+			initResults.Providers = append(initResults.Providers, p)
+		}
+	}
+	return initResults
+}
+
+func newAuthzProvider(c *types.AWSCodeCommitConnection) (authz.Provider, error) {
+	if c.Authorization == nil {
+		return nil, nil
+	}
+
+	if err := licensing.Check(licensing.FeatureACLs); err != nil {
+		return nil, err
+	}
+
+	if err := validateIdentityProvider(c.Authorization.IdentityProvider); err != nil {
+		return nil, err
+	}
+
+	endpoint, err := codecommit.NewDefaultEndpointResolver().ResolveEndpoint(c.Region, codecommit.EndpointResolverOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to resolve AWS region %q", c.Region))
+	}
+	serviceID := awscodecommit.ServiceID(endpoint.PartitionID, endpoint.SigningRegion, c.Authorization.AccountID)
+	baseURL, err := url.Parse(endpoint.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion(c.Region),
+		config.WithCredentialsProvider(
+			awscredentials.StaticCredentialsProvider{
+				Value: aws.Credentials{
+					AccessKeyID:     c.AccessKeyID,
+					SecretAccessKey: c.SecretAccessKey,
+					Source:          "sourcegraph-site-configuration",
+				},
+			},
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewProvider(
+		c.URN,
+		c.Authorization.AccountID,
+		serviceID,
+		baseURL,
+		c.Authorization.IdentityProvider,
+		iam.NewFromConfig(awsConfig),
+		sts.NewFromConfig(awsConfig),
+		awscodecommit.NewClient(awsConfig),
+	), nil
+}
+
+func validateIdentityProvider(idp schema.AWSCodeCommitIdentityProvider) error {
+	switch idp.Type {
+	case "username":
+		return nil
+	case "external":
+		if idp.AuthProviderID == "" || idp.AuthProviderType == "" {
+			return errors.New("authorization.identityProvider of type \"external\" requires authProviderID and authProviderType")
+		}
+		return nil
+	default:
+		return errors.Errorf("unknown authorization.identityProvider type %q", idp.Type)
+	}
+}
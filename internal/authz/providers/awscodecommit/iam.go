@@ -0,0 +1,176 @@
+package awscodecommit
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// gitPullAction is the IAM action that grants read access to the contents of a
+// repository.
+const gitPullAction = "codecommit:GitPull"
+
+// principal is an IAM user or role with the policies that apply to it.
+type principal struct {
+	ARN string
+	// Policies are the inline and managed policies of the principal, including
+	// those of the groups a user is a member of.
+	Policies []*PolicyDocument
+	// Boundary is the permissions boundary of the principal, if any, which caps
+	// the permissions granted by Policies.
+	Boundary *PolicyDocument
+}
+
+// isAllowed reports whether the principal is allowed to perform action on
+// resource.
+func (p *principal) isAllowed(action, resource string) bool {
+	if EvaluatePolicies(p.Policies, action, resource) != PolicyDecisionAllow {
+		return false
+	}
+	return p.Boundary == nil || p.Boundary.Evaluate(action, resource) == PolicyDecisionAllow
+}
+
+// accountAuthorization holds the IAM principals of an AWS account.
+type accountAuthorization struct {
+	users map[string]*principal // keyed by user name
+	roles map[string]*principal // keyed by role name
+}
+
+// newAccountAuthorization resolves the policies of all IAM principals from the
+// pages returned by the GetAccountAuthorizationDetails API.
+func newAccountAuthorization(pages []*iam.GetAccountAuthorizationDetailsOutput) (*accountAuthorization, error) {
+	managed := make(map[string]*PolicyDocument)
+	groups := make(map[string][]*PolicyDocument)
+	for _, page := range pages {
+		for _, p := range page.Policies {
+			for _, v := range p.PolicyVersionList {
+				if !v.IsDefaultVersion {
+					continue
+				}
+				doc, err := parseEncodedPolicy(aws.ToString(v.Document))
+				if err != nil {
+					return nil, errors.Wrapf(err, "managed policy %q", aws.ToString(p.Arn))
+				}
+				managed[aws.ToString(p.Arn)] = doc
+			}
+		}
+	}
+
+	// attached resolves the documents of inline and attached managed policies.
+	// Managed policies that are not part of the response have no document we
+	// could evaluate, so they grant nothing.
+	attached := func(inline []iamtypes.PolicyDetail, attachedPolicies []iamtypes.AttachedPolicy) ([]*PolicyDocument, error) {
+		var docs []*PolicyDocument
+		for _, p := range inline {
+			doc, err := parseEncodedPolicy(aws.ToString(p.PolicyDocument))
+			if err != nil {
+				return nil, errors.Wrapf(err, "inline policy %q", aws.ToString(p.PolicyName))
+			}
+			docs = append(docs, doc)
+		}
+		for _, p := range attachedPolicies {
+			if doc, ok := managed[aws.ToString(p.PolicyArn)]; ok {
+				docs = append(docs, doc)
+			}
+		}
+		return docs, nil
+	}
+
+	// boundary resolves a permissions boundary. A boundary whose document is
+	// unknown is treated as denying everything.
+	boundary := func(b *iamtypes.AttachedPermissionsBoundary) *PolicyDocument {
+		if b == nil || b.PermissionsBoundaryArn == nil {
+			return nil
+		}
+		if doc, ok := managed[aws.ToString(b.PermissionsBoundaryArn)]; ok {
+			return doc
+		}
+		return &PolicyDocument{}
+	}
+
+	for _, page := range pages {
+		for _, g := range page.GroupDetailList {
+			docs, err := attached(g.GroupPolicyList, g.AttachedManagedPolicies)
+			if err != nil {
+				return nil, errors.Wrapf(err, "group %q", aws.ToString(g.GroupName))
+			}
+			groups[aws.ToString(g.GroupName)] = docs
+		}
+	}
+
+	a := &accountAuthorization{
+		users: make(map[string]*principal),
+		roles: make(map[string]*principal),
+	}
+	for _, page := range pages {
+		for _, u := range page.UserDetailList {
+			docs, err := attached(u.UserPolicyList, u.AttachedManagedPolicies)
+			if err != nil {
+				return nil, errors.Wrapf(err, "user %q", aws.ToString(u.UserName))
+			}
+			for _, g := range u.GroupList {
+				docs = append(docs, groups[g]...)
+			}
+			a.users[aws.ToString(u.UserName)] = &principal{
+				ARN:      aws.ToString(u.Arn),
+				Policies: docs,
+				Boundary: boundary(u.PermissionsBoundary),
+			}
+		}
+		for _, r := range page.RoleDetailList {
+			docs, err := attached(r.RolePolicyList, r.AttachedManagedPolicies)
+			if err != nil {
+				return nil, errors.Wrapf(err, "role %q", aws.ToString(r.RoleName))
+			}
+			a.roles[aws.ToString(r.RoleName)] = &principal{
+				ARN:      aws.ToString(r.Arn),
+				Policies: docs,
+				Boundary: boundary(r.PermissionsBoundary),
+			}
+		}
+	}
+	return a, nil
+}
+
+// lookup returns the principal identified by name, which is either the name
+// of an IAM user or the ARN of an IAM user, role or assumed role session.
+func (a *accountAuthorization) lookup(name string) *principal {
+	if !strings.HasPrefix(name, "arn:") {
+		return a.users[name]
+	}
+
+	// The resource part of an ARN is the sixth field, e.g. "user/path/name",
+	// "role/name" or "assumed-role/name/session".
+	fields := strings.SplitN(name, ":", 6)
+	if len(fields) != 6 {
+		return nil
+	}
+	resource := strings.Split(fields[5], "/")
+	if len(resource) < 2 {
+		return nil
+	}
+	switch resource[0] {
+	case "user":
+		return a.users[resource[len(resource)-1]]
+	case "role":
+		return a.roles[resource[len(resource)-1]]
+	case "assumed-role":
+		return a.roles[resource[1]]
+	}
+	return nil
+}
+
+// parseEncodedPolicy parses a policy document as returned by the IAM API,
+// which URL-encodes documents.
+func parseEncodedPolicy(document string) (*PolicyDocument, error) {
+	decoded, err := url.QueryUnescape(document)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding policy document")
+	}
+	return ParsePolicyDocument(decoded)
+}
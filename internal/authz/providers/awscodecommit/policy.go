@@ -0,0 +1,227 @@
+package awscodecommit
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// PolicyDocument is an IAM policy document. Only the elements of identity-based
+// policies that are relevant for evaluating access to a resource are kept.
+//
+// Docs: https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_grammar.html
+type PolicyDocument struct {
+	Version   string
+	Statement []PolicyStatement
+}
+
+// PolicyStatement is a single statement of an IAM policy document.
+type PolicyStatement struct {
+	Sid         string
+	Effect      PolicyEffect
+	Action      policyValues
+	NotAction   policyValues
+	Resource    policyValues
+	NotResource policyValues
+	Condition   map[string]json.RawMessage
+}
+
+type PolicyEffect string
+
+const (
+	PolicyEffectAllow PolicyEffect = "Allow"
+	PolicyEffectDeny  PolicyEffect = "Deny"
+)
+
+// PolicyDecision is the result of evaluating policies for a request.
+type PolicyDecision int
+
+const (
+	// PolicyDecisionImplicitDeny means that no statement applies to the request.
+	PolicyDecisionImplicitDeny PolicyDecision = iota
+	// PolicyDecisionAllow means that at least one statement allows the request
+	// and none denies it.
+	PolicyDecisionAllow
+	// PolicyDecisionExplicitDeny means that at least one statement denies the
+	// request, which overrides any allow.
+	PolicyDecisionExplicitDeny
+)
+
+// ParsePolicyDocument parses the JSON of an IAM policy document.
+func ParsePolicyDocument(document string) (*PolicyDocument, error) {
+	var doc PolicyDocument
+	if err := json.Unmarshal([]byte(document), &doc); err != nil {
+		return nil, errors.Wrap(err, "parsing policy document")
+	}
+	for i, s := range doc.Statement {
+		if s.Effect != PolicyEffectAllow && s.Effect != PolicyEffectDeny {
+			return nil, errors.Errorf("statement %d has invalid effect %q", i, s.Effect)
+		}
+	}
+	return &doc, nil
+}
+
+func (d *PolicyDocument) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Version   string
+		Statement json.RawMessage
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	d.Version = raw.Version
+
+	// A policy may contain a single statement instead of a list of statements.
+	trimmed := strings.TrimSpace(string(raw.Statement))
+	switch {
+	case trimmed == "" || trimmed == "null":
+		d.Statement = nil
+	case strings.HasPrefix(trimmed, "{"):
+		var s PolicyStatement
+		if err := json.Unmarshal(raw.Statement, &s); err != nil {
+			return err
+		}
+		d.Statement = []PolicyStatement{s}
+	default:
+		return json.Unmarshal(raw.Statement, &d.Statement)
+	}
+	return nil
+}
+
+// Evaluate returns the decision of this policy for performing action on
+// resource.
+func (d *PolicyDocument) Evaluate(action, resource string) PolicyDecision {
+	decision := PolicyDecisionImplicitDeny
+	for _, s := range d.Statement {
+		if !s.applies(action, resource) {
+			continue
+		}
+		if s.Effect == PolicyEffectDeny {
+			return PolicyDecisionExplicitDeny
+		}
+		decision = PolicyDecisionAllow
+	}
+	return decision
+}
+
+// EvaluatePolicies returns the combined decision of the given policies for
+// performing action on resource. An explicit deny in any policy overrides
+// allows in others.
+func EvaluatePolicies(policies []*PolicyDocument, action, resource string) PolicyDecision {
+	decision := PolicyDecisionImplicitDeny
+	for _, p := range policies {
+		switch p.Evaluate(action, resource) {
+		case PolicyDecisionExplicitDeny:
+			return PolicyDecisionExplicitDeny
+		case PolicyDecisionAllow:
+			decision = PolicyDecisionAllow
+		}
+	}
+	return decision
+}
+
+// applies reports whether the statement matches the request.
+//
+// 🚨 SECURITY: Conditions and policy variables depend on the context of a
+// request, which we don't have. They are evaluated in the most restrictive way:
+// allow statements using them never apply, deny statements using them always
+// apply when their actions match.
+func (s PolicyStatement) applies(action, resource string) bool {
+	if !matchesAny(s.Action, s.NotAction, action, false) {
+		return false
+	}
+
+	if s.Effect == PolicyEffectDeny {
+		if len(s.Condition) > 0 || s.Resource.hasVariables() || s.NotResource.hasVariables() {
+			return true
+		}
+	} else if len(s.Condition) > 0 || s.Resource.hasVariables() || s.NotResource.hasVariables() {
+		return false
+	}
+
+	return matchesAny(s.Resource, s.NotResource, resource, true)
+}
+
+// matchesAny reports whether value matches one of the patterns, or, if only
+// notPatterns are given, none of them.
+func matchesAny(patterns, notPatterns policyValues, value string, caseSensitive bool) bool {
+	if len(patterns) > 0 {
+		for _, p := range patterns {
+			if wildcardMatch(p, value, caseSensitive) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(notPatterns) > 0 {
+		for _, p := range notPatterns {
+			if wildcardMatch(p, value, caseSensitive) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// wildcardMatch matches value against an IAM pattern, in which "*" matches any
+// sequence of characters and "?" matches a single character.
+func wildcardMatch(pattern, value string, caseSensitive bool) bool {
+	if !caseSensitive {
+		pattern, value = strings.ToLower(pattern), strings.ToLower(value)
+	}
+
+	// Iterative matching with backtracking to the last "*".
+	p, v := 0, 0
+	star, match := -1, 0
+	for v < len(value) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == value[v]):
+			p++
+			v++
+		case p < len(pattern) && pattern[p] == '*':
+			star, match = p, v
+			p++
+		case star != -1:
+			p = star + 1
+			match++
+			v = match
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// policyValues is a policy element that is either a single string or a list of
+// strings.
+type policyValues []string
+
+func (v *policyValues) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*v = policyValues{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*v = list
+	return nil
+}
+
+// hasVariables reports whether any value uses policy variables such as
+// "${aws:username}".
+func (v policyValues) hasVariables() bool {
+	for _, s := range v {
+		if strings.Contains(s, "${") {
+			return true
+		}
+	}
+	return false
+}
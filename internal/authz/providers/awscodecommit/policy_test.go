@@ -0,0 +1,214 @@
+package awscodecommit
+
+import (
+	"testing"
+)
+
+func TestParsePolicyDocument(t *testing.T) {
+	t.Run("single statement", func(t *testing.T) {
+		doc, err := ParsePolicyDocument(`{
+  "Version": "2012-10-17",
+  "Statement": {"Effect": "Allow", "Action": "codecommit:*", "Resource": "*"}
+}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(doc.Statement) != 1 {
+			t.Fatalf("want 1 statement, got %d", len(doc.Statement))
+		}
+		if got := doc.Statement[0].Action; len(got) != 1 || got[0] != "codecommit:*" {
+			t.Fatalf("unexpected action: %v", got)
+		}
+	})
+
+	t.Run("list of statements", func(t *testing.T) {
+		doc, err := ParsePolicyDocument(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": ["codecommit:GitPull", "codecommit:GitPush"], "Resource": "*"},
+    {"Effect": "Deny", "NotAction": "codecommit:GitPull", "Resource": "*"}
+  ]
+}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(doc.Statement) != 2 {
+			t.Fatalf("want 2 statements, got %d", len(doc.Statement))
+		}
+	})
+
+	t.Run("invalid effect", func(t *testing.T) {
+		_, err := ParsePolicyDocument(`{"Statement": [{"Effect": "Maybe", "Action": "*", "Resource": "*"}]}`)
+		if err == nil {
+			t.Fatal("want error, got nil")
+		}
+	})
+}
+
+func TestPolicyDocument_Evaluate(t *testing.T) {
+	const repoARN = "arn:aws:codecommit:us-west-1:123456789012:my-repo"
+
+	tests := []struct {
+		name     string
+		policy   string
+		action   string
+		resource string
+		want     PolicyDecision
+	}{
+		{
+			name:     "no statements",
+			policy:   `{"Statement": []}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionImplicitDeny,
+		},
+		{
+			name:     "allow all",
+			policy:   `{"Statement": {"Effect": "Allow", "Action": "*", "Resource": "*"}}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionAllow,
+		},
+		{
+			name:     "action is case insensitive",
+			policy:   `{"Statement": {"Effect": "Allow", "Action": "CodeCommit:gitpull", "Resource": "*"}}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionAllow,
+		},
+		{
+			name:     "action wildcard",
+			policy:   `{"Statement": {"Effect": "Allow", "Action": "codecommit:Git*", "Resource": "*"}}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionAllow,
+		},
+		{
+			name:     "other action",
+			policy:   `{"Statement": {"Effect": "Allow", "Action": "codecommit:GitPush", "Resource": "*"}}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionImplicitDeny,
+		},
+		{
+			name:     "resource wildcard",
+			policy:   `{"Statement": {"Effect": "Allow", "Action": "codecommit:GitPull", "Resource": "arn:aws:codecommit:*:123456789012:my-*"}}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionAllow,
+		},
+		{
+			name:     "resource single character wildcard",
+			policy:   `{"Statement": {"Effect": "Allow", "Action": "codecommit:GitPull", "Resource": "arn:aws:codecommit:us-west-?:123456789012:my-repo"}}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionAllow,
+		},
+		{
+			name:     "resource is case sensitive",
+			policy:   `{"Statement": {"Effect": "Allow", "Action": "codecommit:GitPull", "Resource": "arn:aws:codecommit:us-west-1:123456789012:My-Repo"}}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionImplicitDeny,
+		},
+		{
+			name: "explicit deny overrides allow",
+			policy: `{"Statement": [
+  {"Effect": "Allow", "Action": "codecommit:*", "Resource": "*"},
+  {"Effect": "Deny", "Action": "codecommit:GitPull", "Resource": "arn:aws:codecommit:us-west-1:123456789012:my-repo"}
+]}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionExplicitDeny,
+		},
+		{
+			name:     "not action",
+			policy:   `{"Statement": {"Effect": "Allow", "NotAction": "iam:*", "Resource": "*"}}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionAllow,
+		},
+		{
+			name:     "not action excludes action",
+			policy:   `{"Statement": {"Effect": "Allow", "NotAction": "codecommit:*", "Resource": "*"}}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionImplicitDeny,
+		},
+		{
+			name:     "not resource excludes resource",
+			policy:   `{"Statement": {"Effect": "Allow", "Action": "codecommit:GitPull", "NotResource": "arn:aws:codecommit:*:*:my-repo"}}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionImplicitDeny,
+		},
+		{
+			name:     "allow with condition never applies",
+			policy:   `{"Statement": {"Effect": "Allow", "Action": "codecommit:GitPull", "Resource": "*", "Condition": {"Bool": {"aws:MultiFactorAuthPresent": "true"}}}}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionImplicitDeny,
+		},
+		{
+			name: "deny with condition always applies",
+			policy: `{"Statement": [
+  {"Effect": "Allow", "Action": "codecommit:GitPull", "Resource": "*"},
+  {"Effect": "Deny", "Action": "codecommit:GitPull", "Resource": "arn:aws:codecommit:*:*:other", "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}}
+]}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionExplicitDeny,
+		},
+		{
+			name:     "allow with policy variable never applies",
+			policy:   `{"Statement": {"Effect": "Allow", "Action": "codecommit:GitPull", "Resource": "arn:aws:codecommit:*:*:${aws:username}-*"}}`,
+			action:   gitPullAction,
+			resource: repoARN,
+			want:     PolicyDecisionImplicitDeny,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := ParsePolicyDocument(test.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := doc.Evaluate(test.action, test.resource); got != test.want {
+				t.Fatalf("want %d, got %d", test.want, got)
+			}
+		})
+	}
+}
+
+func TestEvaluatePolicies(t *testing.T) {
+	const repoARN = "arn:aws:codecommit:us-west-1:123456789012:my-repo"
+
+	mustParse := func(policy string) *PolicyDocument {
+		doc, err := ParsePolicyDocument(policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return doc
+	}
+	allow := mustParse(`{"Statement": {"Effect": "Allow", "Action": "codecommit:GitPull", "Resource": "*"}}`)
+	deny := mustParse(`{"Statement": {"Effect": "Deny", "Action": "codecommit:*", "Resource": "*"}}`)
+	unrelated := mustParse(`{"Statement": {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "*"}}`)
+
+	tests := []struct {
+		name     string
+		policies []*PolicyDocument
+		want     PolicyDecision
+	}{
+		{name: "no policies", want: PolicyDecisionImplicitDeny},
+		{name: "unrelated policy", policies: []*PolicyDocument{unrelated}, want: PolicyDecisionImplicitDeny},
+		{name: "allow in any policy", policies: []*PolicyDocument{unrelated, allow}, want: PolicyDecisionAllow},
+		{name: "deny in any policy", policies: []*PolicyDocument{allow, deny}, want: PolicyDecisionExplicitDeny},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := EvaluatePolicies(test.policies, gitPullAction, repoARN); got != test.want {
+				t.Fatalf("want %d, got %d", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+// Package awscodecommit contains an authorization provider for AWS CodeCommit
+// that evaluates the IAM policies of the principals users map to.
+package awscodecommit
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const cacheTTL = 10 * time.Minute
+
+// iamClient is the subset of the IAM API used by the provider.
+type iamClient interface {
+	iam.GetAccountAuthorizationDetailsAPIClient
+}
+
+// stsClient is the subset of the STS API used by the provider.
+type stsClient interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// repoLister lists the CodeCommit repositories of the account.
+type repoLister interface {
+	ListRepositories(ctx context.Context, nextToken string) ([]*awscodecommit.Repository, string, error)
+}
+
+// Provider implements authz.Provider for AWS CodeCommit repositories. Access
+// to a repository is granted to the IAM principals that are allowed the
+// "codecommit:GitPull" action on it.
+type Provider struct {
+	urn              string
+	accountID        string
+	codeHost         *extsvc.CodeHost
+	identityProvider schema.AWSCodeCommitIdentityProvider
+
+	iam   iamClient
+	sts   stsClient
+	repos repoLister
+
+	cacheMutex      sync.Mutex
+	cachedAuthz     *accountAuthorization
+	authzLastUpdate time.Time
+	cachedRepos     []*awscodecommit.Repository
+	reposLastUpdate time.Time
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new AWS CodeCommit authorization provider for the
+// repositories of the given AWS account, which belong to the code host
+// identified by serviceID.
+func NewProvider(urn, accountID, serviceID string, baseURL *url.URL, identityProvider schema.AWSCodeCommitIdentityProvider, iamCli iamClient, stsCli stsClient, repos repoLister) *Provider {
+	return &Provider{
+		urn:       urn,
+		accountID: accountID,
+		codeHost: &extsvc.CodeHost{
+			ServiceID:   serviceID,
+			ServiceType: extsvc.TypeAWSCodeCommit,
+			BaseURL:     baseURL,
+		},
+		identityProvider: identityProvider,
+		iam:              iamCli,
+		sts:              stsCli,
+		repos:            repos,
+	}
+}
+
+// accountData is stored as the data of the external accounts of this provider.
+type accountData struct {
+	// ARN is the ARN of the IAM user or role the account maps to.
+	ARN string `json:"arn"`
+}
+
+// FetchAccount maps the user to an IAM user or role, as configured by the
+// identity provider of the code host connection.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, current []*extsvc.Account, _ []string) (_ *extsvc.Account, err error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	tr, ctx := trace.New(ctx, "awscodecommit.authz.provider.FetchAccount")
+	defer func() {
+		tr.SetAttributes(
+			attribute.String("user.name", user.Username),
+			attribute.Int("user.id", int(user.ID)))
+		tr.EndWithErr(&err)
+	}()
+
+	name, err := p.principalName(ctx, user, current)
+	if err != nil || name == "" {
+		return nil, err
+	}
+
+	a, err := p.accountAuthorization(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	principal := a.lookup(name)
+	if principal == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(accountData{ARN: principal.ARN})
+	if err != nil {
+		return nil, err
+	}
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   principal.ARN,
+		},
+		AccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(data),
+		},
+	}, nil
+}
+
+// samlValues mirrors the account data stored for SAML external accounts.
+type samlValues struct {
+	Values map[string]struct {
+		Values []struct {
+			Value string
+		}
+	}
+}
+
+// principalName returns the name or ARN of the IAM principal the user maps to,
+// or an empty string if there is none.
+func (p *Provider) principalName(ctx context.Context, user *types.User, current []*extsvc.Account) (string, error) {
+	if p.identityProvider.Type == "username" {
+		return user.Username, nil
+	}
+
+	authnProvider := providers.GetProviderByConfigID(providers.ConfigID{
+		Type: p.identityProvider.AuthProviderType,
+		ID:   p.identityProvider.AuthProviderID,
+	})
+	if authnProvider == nil {
+		return "", nil
+	}
+	var authnAcct *extsvc.Account
+	for _, acct := range current {
+		if acct.ServiceID == authnProvider.CachedInfo().ServiceID && acct.ServiceType == authnProvider.ConfigID().Type {
+			authnAcct = acct
+			break
+		}
+	}
+	if authnAcct == nil {
+		return "", nil
+	}
+	if p.identityProvider.Attribute == "" {
+		return authnAcct.AccountID, nil
+	}
+
+	if authnAcct.Data == nil {
+		return "", nil
+	}
+	values, err := encryption.DecryptJSON[samlValues](ctx, authnAcct.Data)
+	if err != nil {
+		return "", errors.Wrap(err, "decoding SAML account data")
+	}
+	for _, v := range values.Values[p.identityProvider.Attribute].Values {
+		// The AWS role attribute holds pairs of role and identity provider
+		// ARNs, separated by a comma.
+		for _, part := range strings.Split(v.Value, ",") {
+			part = strings.TrimSpace(part)
+			if part != "" && !strings.Contains(part, ":saml-provider/") {
+				return part, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// FetchUserPerms returns the IDs of the repositories the IAM principal of the
+// given account is allowed to pull from.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			account.AccountSpec.ServiceID, p.codeHost.ServiceID)
+	}
+
+	a, err := p.accountAuthorization(ctx, opts.InvalidateCaches)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := p.repositories(ctx, opts.InvalidateCaches)
+	if err != nil {
+		return nil, err
+	}
+
+	extIDs := []extsvc.RepoID{}
+	// A principal that no longer exists has no access.
+	if principal := a.lookup(account.AccountID); principal != nil {
+		for _, r := range repos {
+			if principal.isAllowed(gitPullAction, r.ARN) {
+				extIDs = append(extIDs, extsvc.RepoID(r.ID))
+			}
+		}
+	}
+
+	return &authz.ExternalUserPermissions{
+		Exacts: extIDs,
+	}, nil
+}
+
+// FetchRepoPerms returns the ARNs of the IAM users and roles that are allowed
+// to pull from the given repository.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repository provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, errors.Errorf("not a code host of the repository: want %q but have %q",
+			repo.ServiceID, p.codeHost.ServiceID)
+	}
+
+	repos, err := p.repositories(ctx, opts.InvalidateCaches)
+	if err != nil {
+		return nil, err
+	}
+	var arn string
+	for _, r := range repos {
+		if r.ID == repo.ID {
+			arn = r.ARN
+			break
+		}
+	}
+	if arn == "" {
+		return nil, errors.Errorf("repository %q not found", repo.ID)
+	}
+
+	a, err := p.accountAuthorization(ctx, opts.InvalidateCaches)
+	if err != nil {
+		return nil, err
+	}
+	var accountIDs []extsvc.AccountID
+	for _, principals := range []map[string]*principal{a.users, a.roles} {
+		for _, principal := range principals {
+			if principal.isAllowed(gitPullAction, arn) {
+				accountIDs = append(accountIDs, extsvc.AccountID(principal.ARN))
+			}
+		}
+	}
+	return accountIDs, nil
+}
+
+// accountAuthorization returns the IAM principals of the account, which are
+// cached for cacheTTL unless invalidateCache is set.
+func (p *Provider) accountAuthorization(ctx context.Context, invalidateCache bool) (*accountAuthorization, error) {
+	p.cacheMutex.Lock()
+	defer p.cacheMutex.Unlock()
+
+	if !invalidateCache && p.cachedAuthz != nil && time.Since(p.authzLastUpdate) < cacheTTL {
+		return p.cachedAuthz, nil
+	}
+
+	var pages []*iam.GetAccountAuthorizationDetailsOutput
+	paginator := iam.NewGetAccountAuthorizationDetailsPaginator(p.iam, &iam.GetAccountAuthorizationDetailsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "get account authorization details")
+		}
+		pages = append(pages, page)
+	}
+
+	a, err := newAccountAuthorization(pages)
+	if err != nil {
+		return nil, err
+	}
+	p.cachedAuthz = a
+	p.authzLastUpdate = time.Now()
+	return a, nil
+}
+
+// repositories returns all repositories of the account, which are cached for
+// cacheTTL unless invalidateCache is set.
+func (p *Provider) repositories(ctx context.Context, invalidateCache bool) ([]*awscodecommit.Repository, error) {
+	p.cacheMutex.Lock()
+	defer p.cacheMutex.Unlock()
+
+	if !invalidateCache && p.cachedRepos != nil && time.Since(p.reposLastUpdate) < cacheTTL {
+		return p.cachedRepos, nil
+	}
+
+	repos := []*awscodecommit.Repository{}
+	var nextToken string
+	for {
+		batch, token, err := p.repos.ListRepositories(ctx, nextToken)
+		if err != nil {
+			return nil, errors.Wrap(err, "list repositories")
+		}
+		// The service ID of the code host is derived from the configured
+		// account ID, so repositories of another account would never match
+		// the permissions we compute. Fail loudly instead of granting nothing.
+		for _, r := range batch {
+			if r.AccountID != p.accountID {
+				return nil, errors.Errorf("repository %q belongs to AWS account %q, but authorization.accountID is %q", r.Name, r.AccountID, p.accountID)
+			}
+		}
+		repos = append(repos, batch...)
+		if len(batch) == 0 || token == "" {
+			break
+		}
+		nextToken = token
+	}
+
+	p.cachedRepos = repos
+	p.reposLastUpdate = time.Now()
+	return repos, nil
+}
+
+func (p *Provider) ServiceType() string {
+	return p.codeHost.ServiceType
+}
+
+func (p *Provider) ServiceID() string {
+	return p.codeHost.ServiceID
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ValidateConnection checks that the configured credentials belong to the
+// configured account and are allowed to read its authorization details.
+func (p *Provider) ValidateConnection(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	identity, err := p.sts.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return errors.Wrap(err, "get caller identity of the AWS credentials")
+	}
+	if accountID := aws.ToString(identity.Account); accountID != p.accountID {
+		return errors.Errorf("AWS credentials belong to account %q, but authorization.accountID is %q", accountID, p.accountID)
+	}
+
+	_, err = p.iam.GetAccountAuthorizationDetails(ctx, &iam.GetAccountAuthorizationDetailsInput{MaxItems: aws.Int32(1)})
+	if err != nil {
+		return errors.Wrap(err, "AWS credentials are not allowed iam:GetAccountAuthorizationDetails")
+	}
+	return nil
+}
@@ -0,0 +1,385 @@
+package awscodecommit
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	testAccountID = "123456789012"
+	testServiceID = "arn:aws:codecommit:us-west-1:123456789012:"
+
+	allowPullPolicyARN = "arn:aws:iam::123456789012:policy/allow-pull"
+	boundaryPolicyARN  = "arn:aws:iam::123456789012:policy/only-public"
+)
+
+type fakeIAMClient struct {
+	pages []*iam.GetAccountAuthorizationDetailsOutput
+	// calls counts the requests for the first page.
+	calls int
+}
+
+func (c *fakeIAMClient) GetAccountAuthorizationDetails(_ context.Context, input *iam.GetAccountAuthorizationDetailsInput, _ ...func(*iam.Options)) (*iam.GetAccountAuthorizationDetailsOutput, error) {
+	i := 0
+	if input.Marker != nil {
+		i = int(aws.ToString(input.Marker)[0] - '0')
+	} else {
+		c.calls++
+	}
+	page := *c.pages[i]
+	if i+1 < len(c.pages) {
+		page.IsTruncated = true
+		page.Marker = aws.String(string(rune('0' + i + 1)))
+	}
+	return &page, nil
+}
+
+type fakeSTSClient struct {
+	account string
+}
+
+func (c *fakeSTSClient) GetCallerIdentity(context.Context, *sts.GetCallerIdentityInput, ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{Account: aws.String(c.account)}, nil
+}
+
+type fakeRepoLister struct {
+	pages [][]*awscodecommit.Repository
+}
+
+func (l *fakeRepoLister) ListRepositories(_ context.Context, nextToken string) ([]*awscodecommit.Repository, string, error) {
+	i := 0
+	if nextToken != "" {
+		i = int(nextToken[0] - '0')
+	}
+	var next string
+	if i+1 < len(l.pages) {
+		next = string(rune('0' + i + 1))
+	}
+	return l.pages[i], next, nil
+}
+
+// encodedPolicy URL-encodes a policy document the way the IAM API returns it.
+func encodedPolicy(document string) *string {
+	return aws.String(url.QueryEscape(document))
+}
+
+func newTestProvider(t *testing.T) (*Provider, *fakeIAMClient) {
+	t.Helper()
+
+	iamCli := &fakeIAMClient{
+		pages: []*iam.GetAccountAuthorizationDetailsOutput{
+			{
+				Policies: []iamtypes.ManagedPolicyDetail{
+					{
+						Arn: aws.String(allowPullPolicyARN),
+						PolicyVersionList: []iamtypes.PolicyVersion{
+							{
+								IsDefaultVersion: false,
+								Document:         encodedPolicy(`{"Statement": {"Effect": "Allow", "Action": "*", "Resource": "*"}}`),
+							},
+							{
+								IsDefaultVersion: true,
+								Document:         encodedPolicy(`{"Statement": {"Effect": "Allow", "Action": "codecommit:GitPull", "Resource": "*"}}`),
+							},
+						},
+					},
+					{
+						Arn: aws.String(boundaryPolicyARN),
+						PolicyVersionList: []iamtypes.PolicyVersion{
+							{
+								IsDefaultVersion: true,
+								Document:         encodedPolicy(`{"Statement": {"Effect": "Allow", "Action": "codecommit:*", "Resource": "arn:aws:codecommit:*:*:public-*"}}`),
+							},
+						},
+					},
+				},
+				GroupDetailList: []iamtypes.GroupDetail{
+					{
+						GroupName:               aws.String("developers"),
+						AttachedManagedPolicies: []iamtypes.AttachedPolicy{{PolicyArn: aws.String(allowPullPolicyARN)}},
+					},
+				},
+				UserDetailList: []iamtypes.UserDetail{
+					{
+						UserName:  aws.String("alice"),
+						Arn:       aws.String("arn:aws:iam::123456789012:user/alice"),
+						GroupList: []string{"developers"},
+						UserPolicyList: []iamtypes.PolicyDetail{
+							{
+								PolicyName:     aws.String("no-secrets"),
+								PolicyDocument: encodedPolicy(`{"Statement": {"Effect": "Deny", "Action": "codecommit:*", "Resource": "arn:aws:codecommit:us-west-1:123456789012:secret"}}`),
+							},
+						},
+					},
+				},
+			},
+			{
+				UserDetailList: []iamtypes.UserDetail{
+					{
+						UserName:                aws.String("bob"),
+						Arn:                     aws.String("arn:aws:iam::123456789012:user/team/bob"),
+						AttachedManagedPolicies: []iamtypes.AttachedPolicy{{PolicyArn: aws.String(allowPullPolicyARN)}},
+						PermissionsBoundary: &iamtypes.AttachedPermissionsBoundary{
+							PermissionsBoundaryArn: aws.String(boundaryPolicyARN),
+						},
+					},
+					{
+						UserName: aws.String("carol"),
+						Arn:      aws.String("arn:aws:iam::123456789012:user/carol"),
+					},
+				},
+				RoleDetailList: []iamtypes.RoleDetail{
+					{
+						RoleName: aws.String("ci"),
+						Arn:      aws.String("arn:aws:iam::123456789012:role/ci"),
+						RolePolicyList: []iamtypes.PolicyDetail{
+							{
+								PolicyName:     aws.String("pull-everything"),
+								PolicyDocument: encodedPolicy(`{"Statement": [{"Effect": "Allow", "Action": "codecommit:Git*", "Resource": "*"}]}`),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	repos := &fakeRepoLister{
+		pages: [][]*awscodecommit.Repository{
+			{
+				{ID: "id-public", AccountID: testAccountID, ARN: "arn:aws:codecommit:us-west-1:123456789012:public-docs"},
+				{ID: "id-private", AccountID: testAccountID, ARN: "arn:aws:codecommit:us-west-1:123456789012:private"},
+			},
+			{
+				{ID: "id-secret", AccountID: testAccountID, ARN: "arn:aws:codecommit:us-west-1:123456789012:secret"},
+			},
+		},
+	}
+
+	baseURL, _ := url.Parse("https://git-codecommit.us-west-1.amazonaws.com")
+	p := NewProvider("", testAccountID, testServiceID, baseURL, schema.AWSCodeCommitIdentityProvider{Type: "username"}, iamCli, &fakeSTSClient{account: testAccountID}, repos)
+	return p, iamCli
+}
+
+func account(arn string) *extsvc.Account {
+	return &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: extsvc.TypeAWSCodeCommit,
+			ServiceID:   testServiceID,
+			AccountID:   arn,
+		},
+	}
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	ctx := context.Background()
+	p, _ := newTestProvider(t)
+
+	t.Run("user with IAM user", func(t *testing.T) {
+		acct, err := p.FetchAccount(ctx, &types.User{ID: 1, Username: "bob"}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acct == nil {
+			t.Fatal("want account, got nil")
+		}
+		want := extsvc.AccountSpec{
+			ServiceType: extsvc.TypeAWSCodeCommit,
+			ServiceID:   testServiceID,
+			AccountID:   "arn:aws:iam::123456789012:user/team/bob",
+		}
+		if diff := cmp.Diff(want, acct.AccountSpec); diff != "" {
+			t.Fatalf("AccountSpec mismatch (-want +got):\n%s", diff)
+		}
+		if acct.UserID != 1 {
+			t.Fatalf("want user ID 1, got %d", acct.UserID)
+		}
+	})
+
+	t.Run("user without IAM user", func(t *testing.T) {
+		acct, err := p.FetchAccount(ctx, &types.User{ID: 2, Username: "dave"}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acct != nil {
+			t.Fatalf("want nil account, got %+v", acct)
+		}
+	})
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		arn  string
+		want []extsvc.RepoID
+	}{
+		{
+			name: "group policy allows pull and inline policy denies a repository",
+			arn:  "arn:aws:iam::123456789012:user/alice",
+			want: []extsvc.RepoID{"id-public", "id-private"},
+		},
+		{
+			name: "permissions boundary caps managed policy",
+			arn:  "arn:aws:iam::123456789012:user/team/bob",
+			want: []extsvc.RepoID{"id-public"},
+		},
+		{
+			name: "user without policies",
+			arn:  "arn:aws:iam::123456789012:user/carol",
+			want: []extsvc.RepoID{},
+		},
+		{
+			name: "assumed role",
+			arn:  "arn:aws:sts::123456789012:assumed-role/ci/session",
+			want: []extsvc.RepoID{"id-public", "id-private", "id-secret"},
+		},
+		{
+			name: "unknown principal",
+			arn:  "arn:aws:iam::123456789012:user/mallory",
+			want: []extsvc.RepoID{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, _ := newTestProvider(t)
+			perms, err := p.FetchUserPerms(ctx, account(test.arn), authz.FetchPermsOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, perms.Exacts); diff != "" {
+				t.Fatalf("Exacts mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("account of another code host", func(t *testing.T) {
+		p, _ := newTestProvider(t)
+		acct := account("arn:aws:iam::123456789012:user/alice")
+		acct.ServiceID = "arn:aws:codecommit:eu-west-1:123456789012:"
+		if _, err := p.FetchUserPerms(ctx, acct, authz.FetchPermsOptions{}); err == nil {
+			t.Fatal("want error, got nil")
+		}
+	})
+
+	t.Run("account authorization is cached", func(t *testing.T) {
+		p, iamCli := newTestProvider(t)
+		acct := account("arn:aws:iam::123456789012:user/alice")
+		for range 2 {
+			if _, err := p.FetchUserPerms(ctx, acct, authz.FetchPermsOptions{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if iamCli.calls != 1 {
+			t.Fatalf("want 1 IAM call, got %d", iamCli.calls)
+		}
+
+		if _, err := p.FetchUserPerms(ctx, acct, authz.FetchPermsOptions{InvalidateCaches: true}); err != nil {
+			t.Fatal(err)
+		}
+		if iamCli.calls != 2 {
+			t.Fatalf("want 2 IAM calls, got %d", iamCli.calls)
+		}
+	})
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	ctx := context.Background()
+	p, _ := newTestProvider(t)
+
+	repo := func(id string) *extsvc.Repository {
+		return &extsvc.Repository{
+			ExternalRepoSpec: api.ExternalRepoSpec{
+				ID:          id,
+				ServiceType: extsvc.TypeAWSCodeCommit,
+				ServiceID:   testServiceID,
+			},
+		}
+	}
+
+	tests := []struct {
+		repoID string
+		want   []extsvc.AccountID
+	}{
+		{
+			repoID: "id-public",
+			want: []extsvc.AccountID{
+				"arn:aws:iam::123456789012:role/ci",
+				"arn:aws:iam::123456789012:user/alice",
+				"arn:aws:iam::123456789012:user/team/bob",
+			},
+		},
+		{
+			repoID: "id-secret",
+			want: []extsvc.AccountID{
+				"arn:aws:iam::123456789012:role/ci",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.repoID, func(t *testing.T) {
+			got, err := p.FetchRepoPerms(ctx, repo(test.repoID), authz.FetchPermsOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Fatalf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("unknown repository", func(t *testing.T) {
+		if _, err := p.FetchRepoPerms(ctx, repo("id-unknown"), authz.FetchPermsOptions{}); err == nil {
+			t.Fatal("want error, got nil")
+		}
+	})
+}
+
+func TestProvider_AccountID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("repositories of another account", func(t *testing.T) {
+		p, _ := newTestProvider(t)
+		p.repos = &fakeRepoLister{
+			pages: [][]*awscodecommit.Repository{{
+				{ID: "id-other", Name: "other", AccountID: "210987654321", ARN: "arn:aws:codecommit:us-west-1:210987654321:other"},
+			}},
+		}
+		_, err := p.FetchUserPerms(ctx, account("arn:aws:iam::123456789012:user/alice"), authz.FetchPermsOptions{})
+		if err == nil {
+			t.Fatal("want error, got nil")
+		}
+	})
+
+	t.Run("credentials of the configured account", func(t *testing.T) {
+		p, _ := newTestProvider(t)
+		if err := p.ValidateConnection(ctx); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("credentials of another account", func(t *testing.T) {
+		p, _ := newTestProvider(t)
+		p.sts = &fakeSTSClient{account: "210987654321"}
+		if err := p.ValidateConnection(ctx); err == nil {
+			t.Fatal("want error, got nil")
+		}
+	})
+}
@@ -20,6 +20,10 @@ type Provider struct {
 	urn      string
 	client   gerrit.Client
 	codeHost *extsvc.CodeHost
+
+	// subRepoPermissions enables translating path access sections of projects
+	// into sub-repo permissions.
+	subRepoPermissions bool
 }
 
 func NewProvider(conn *types.GerritConnection) (*Provider, error) {
@@ -35,9 +39,10 @@ func NewProvider(conn *types.GerritConnection) (*Provider, error) {
 		return nil, err
 	}
 	return &Provider{
-		urn:      conn.URN,
-		client:   gClient,
-		codeHost: extsvc.NewCodeHost(baseURL, extsvc.TypeGerrit),
+		urn:                conn.URN,
+		client:             gClient,
+		codeHost:           extsvc.NewCodeHost(baseURL, extsvc.TypeGerrit),
+		subRepoPermissions: conn.Authorization != nil && conn.Authorization.SubRepoPermissions,
 	}, nil
 }
 
@@ -74,14 +79,18 @@ func (p Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, o
 		Cursor: &gerrit.Pagination{PerPage: 100, Page: 1},
 	}
 	extIDs := []extsvc.RepoID{}
+	// projectNames maps the IDs of the projects to their names, which are the
+	// keys of the list projects response.
+	projectNames := make(map[extsvc.RepoID]string)
 	for {
 		projects, nextPage, err := client.ListProjects(ctx, queryArgs)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, project := range projects {
+		for name, project := range projects {
 			extIDs = append(extIDs, extsvc.RepoID(project.ID))
+			projectNames[extsvc.RepoID(project.ID)] = name
 		}
 
 		if !nextPage {
@@ -90,9 +99,57 @@ func (p Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, o
 		queryArgs.Cursor.Page++
 	}
 
-	return &authz.ExternalUserPermissions{
-		Exacts: extIDs,
-	}, nil
+	if !p.subRepoPermissions {
+		return &authz.ExternalUserPermissions{
+			Exacts: extIDs,
+		}, nil
+	}
+
+	return p.fetchSubRepoPerms(ctx, client, extIDs, projectNames)
+}
+
+// fetchSubRepoPerms evaluates the path access sections of the given projects,
+// including the ones they inherit from parent projects, for the user of the
+// given client. Projects whose access sections cannot be fetched are left out
+// of the result, since granting access to the whole repository could expose
+// restricted paths.
+func (p Provider) fetchSubRepoPerms(ctx context.Context, client gerrit.Client, extIDs []extsvc.RepoID, projectNames map[extsvc.RepoID]string) (*authz.ExternalUserPermissions, error) {
+	accountGroups, err := client.ListAccountGroups(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "list account groups")
+	}
+	groups := make(map[string]struct{}, len(accountGroups)+len(builtinGroups))
+	for _, g := range accountGroups {
+		groups[g.ID] = struct{}{}
+	}
+	for _, g := range builtinGroups {
+		groups[g] = struct{}{}
+	}
+
+	perms := &authz.ExternalUserPermissions{
+		Exacts:             make([]extsvc.RepoID, 0, len(extIDs)),
+		SubRepoPermissions: make(map[extsvc.RepoID]*authz.SubRepoPermissions),
+	}
+	// Access sections are read with the credentials of the code host
+	// connection, as users can usually not read the project configuration.
+	accessCache := newProjectAccessCache(p.client)
+	var errs error
+	for _, id := range extIDs {
+		chain, err := accessCache.inheritanceChain(ctx, projectNames[id])
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+
+		perms.Exacts = append(perms.Exacts, id)
+		if rules := subRepoRulesFromAccess(chain, groups); rules != nil {
+			perms.SubRepoPermissions[id] = &authz.SubRepoPermissions{Paths: rules}
+		}
+	}
+
+	// As per interface definition for this method, implementation should return
+	// partial but valid results even when something went wrong.
+	return perms, errs
 }
 
 func (p Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -100,10 +101,11 @@ func TestProvider_FetchUserPerms(t *testing.T) {
 	})
 
 	testCases := map[string]struct {
-		clientFunc func() gerrit.Client
-		account    *extsvc.Account
-		wantErr    bool
-		wantPerms  *authz.ExternalUserPermissions
+		clientFunc         func() gerrit.Client
+		subRepoPermissions bool
+		account            *extsvc.Account
+		wantErr            bool
+		wantPerms          *authz.ExternalUserPermissions
 	}{
 		"nil account gives error": {
 			account: nil,
@@ -165,6 +167,86 @@ func TestProvider_FetchUserPerms(t *testing.T) {
 				return client
 			},
 		},
+		"sub-repo permissions are derived from path access sections": {
+			account: &extsvc.Account{
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: "gerrit",
+					ServiceID:   "https://gerrit.sgdev.org/",
+				},
+				AccountData: accountData,
+			},
+			subRepoPermissions: true,
+			wantPerms: &authz.ExternalUserPermissions{
+				Exacts: []extsvc.RepoID{"test%2Fproject"},
+				SubRepoPermissions: map[extsvc.RepoID]*authz.SubRepoPermissions{
+					"test%2Fproject": {
+						Paths: []string{"/**", "-/secrets", "-/secrets/**"},
+					},
+				},
+			},
+			clientFunc: func() gerrit.Client {
+				client := NewStrictMockGerritClient()
+				client.ListProjectsFunc.SetDefaultHook(func(ctx context.Context, args gerrit.ListProjectsArgs) (gerrit.ListProjectsResponse, bool, error) {
+					resp := gerrit.ListProjectsResponse{
+						"test/project": &gerrit.Project{
+							ID: "test%2Fproject",
+						},
+					}
+
+					return resp, false, nil
+				})
+				client.ListAccountGroupsFunc.SetDefaultReturn([]gerrit.Group{{ID: "contractors"}}, nil)
+				client.GetProjectAccessFunc.SetDefaultHook(func(ctx context.Context, name string) (*gerrit.ProjectAccessInfo, error) {
+					if name != "test/project" {
+						return nil, errors.Newf("unexpected project %q", name)
+					}
+					return &gerrit.ProjectAccessInfo{
+						Local: map[string]gerrit.AccessSectionInfo{
+							"refs/paths/secrets": {
+								Permissions: map[string]gerrit.PermissionInfo{
+									gerrit.PermissionRead: {
+										Rules: map[string]gerrit.PermissionRuleInfo{
+											"employees": {Action: gerrit.PermissionRuleActionAllow},
+										},
+									},
+								},
+							},
+						},
+					}, nil
+				})
+				client.WithAuthenticatorFunc.SetDefaultHook(func(authenticator auth.Authenticator) (gerrit.Client, error) {
+					return client, nil
+				})
+				return client
+			},
+		},
+		"projects with unknown access are left out": {
+			account: &extsvc.Account{
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: "gerrit",
+					ServiceID:   "https://gerrit.sgdev.org/",
+				},
+				AccountData: accountData,
+			},
+			subRepoPermissions: true,
+			wantErr:            true,
+			wantPerms: &authz.ExternalUserPermissions{
+				Exacts:             []extsvc.RepoID{},
+				SubRepoPermissions: map[extsvc.RepoID]*authz.SubRepoPermissions{},
+			},
+			clientFunc: func() gerrit.Client {
+				client := NewStrictMockGerritClient()
+				client.ListProjectsFunc.SetDefaultReturn(gerrit.ListProjectsResponse{
+					"test-project": &gerrit.Project{ID: "test-project"},
+				}, false, nil)
+				client.ListAccountGroupsFunc.SetDefaultReturn(nil, nil)
+				client.GetProjectAccessFunc.SetDefaultReturn(nil, errors.New("forbidden"))
+				client.WithAuthenticatorFunc.SetDefaultHook(func(authenticator auth.Authenticator) (gerrit.Client, error) {
+					return client, nil
+				})
+				return client
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -173,6 +255,7 @@ func TestProvider_FetchUserPerms(t *testing.T) {
 			if tc.clientFunc != nil {
 				p = NewTestProvider(tc.clientFunc())
 			}
+			p.subRepoPermissions = tc.subRepoPermissions
 			perms, err := p.FetchUserPerms(context.Background(), tc.account, authz.FetchPermsOptions{})
 			if err != nil && !tc.wantErr {
 				t.Fatalf("unexpected error: %s", err)
@@ -187,6 +270,78 @@ func TestProvider_FetchUserPerms(t *testing.T) {
 	}
 }
 
+func TestProvider_FetchUserPerms_InheritedAccess(t *testing.T) {
+	accountData := extsvc.AccountData{}
+	err := gerrit.SetExternalAccountData(&accountData, &gerrit.Account{}, &gerrit.AccountCredentials{
+		Username: "test-user",
+		Password: "test-password",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewStrictMockGerritClient()
+	client.ListProjectsFunc.SetDefaultReturn(gerrit.ListProjectsResponse{
+		"frontend": &gerrit.Project{ID: "frontend"},
+		"backend":  &gerrit.Project{ID: "backend"},
+	}, false, nil)
+	client.ListAccountGroupsFunc.SetDefaultReturn(nil, nil)
+	client.GetProjectAccessFunc.SetDefaultHook(func(ctx context.Context, name string) (*gerrit.ProjectAccessInfo, error) {
+		switch name {
+		case "frontend", "backend":
+			return &gerrit.ProjectAccessInfo{
+				InheritsFrom: &gerrit.Project{ID: "All-Projects", Name: "All-Projects"},
+			}, nil
+		case "All-Projects":
+			return &gerrit.ProjectAccessInfo{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/paths/secrets": {
+						Permissions: map[string]gerrit.PermissionInfo{
+							gerrit.PermissionRead: {
+								Rules: map[string]gerrit.PermissionRuleInfo{
+									"employees": {Action: gerrit.PermissionRuleActionAllow},
+								},
+							},
+						},
+					},
+				},
+			}, nil
+		}
+		return nil, errors.Newf("unexpected project %q", name)
+	})
+	client.WithAuthenticatorFunc.SetDefaultReturn(client, nil)
+
+	p := NewTestProvider(client)
+	p.subRepoPermissions = true
+	perms, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: "gerrit",
+			ServiceID:   "https://gerrit.sgdev.org/",
+		},
+		AccountData: accountData,
+	}, authz.FetchPermsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Slice(perms.Exacts, func(i, j int) bool { return perms.Exacts[i] < perms.Exacts[j] })
+	want := &authz.ExternalUserPermissions{
+		Exacts: []extsvc.RepoID{"backend", "frontend"},
+		SubRepoPermissions: map[extsvc.RepoID]*authz.SubRepoPermissions{
+			"frontend": {Paths: []string{"/**", "-/secrets", "-/secrets/**"}},
+			"backend":  {Paths: []string{"/**", "-/secrets", "-/secrets/**"}},
+		},
+	}
+	if diff := cmp.Diff(want, perms); diff != "" {
+		t.Fatalf("permissions did not match (-want +got):\n%s", diff)
+	}
+
+	// The access of All-Projects is fetched once per sync, not once per project.
+	if have, want := len(client.GetProjectAccessFunc.History()), 3; have != want {
+		t.Fatalf("wrong number of project access requests. want=%d, have=%d", want, have)
+	}
+}
+
 func NewTestProvider(client gerrit.Client) *Provider {
 	baseURL, _ := url.Parse("https://gerrit.sgdev.org")
 	return &Provider{
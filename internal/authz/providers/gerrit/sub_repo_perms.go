@@ -0,0 +1,189 @@
+package gerrit
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// pathRefPrefix is the ref namespace of access sections that describe path
+// restrictions. Gerrit itself only knows ref-level access rights, so paths
+// are modelled as pseudo-refs, e.g. "refs/paths/docs/internal".
+const pathRefPrefix = "refs/paths/"
+
+// maxInheritanceDepth bounds the number of parent projects that are evaluated,
+// which protects against cycles in a misconfigured project hierarchy.
+const maxInheritanceDepth = 32
+
+// builtinGroups are the system groups every authenticated user is a member of.
+var builtinGroups = []string{
+	"global:Anonymous-Users",
+	"global:Registered-Users",
+}
+
+// projectAccessCache fetches the access configuration of projects and keeps it
+// for the duration of a single permissions sync, so that parent projects like
+// All-Projects are only fetched once.
+type projectAccessCache struct {
+	client gerrit.Client
+	access map[string]projectAccessResult
+}
+
+type projectAccessResult struct {
+	access *gerrit.ProjectAccessInfo
+	err    error
+}
+
+func newProjectAccessCache(client gerrit.Client) *projectAccessCache {
+	return &projectAccessCache{
+		client: client,
+		access: make(map[string]projectAccessResult),
+	}
+}
+
+func (c *projectAccessCache) get(ctx context.Context, projectName string) (*gerrit.ProjectAccessInfo, error) {
+	if r, ok := c.access[projectName]; ok {
+		return r.access, r.err
+	}
+	access, err := c.client.GetProjectAccess(ctx, projectName)
+	c.access[projectName] = projectAccessResult{access: access, err: err}
+	return access, err
+}
+
+// inheritanceChain returns the access configuration of the given project,
+// followed by the ones of the projects it inherits from, up to All-Projects.
+func (c *projectAccessCache) inheritanceChain(ctx context.Context, projectName string) ([]*gerrit.ProjectAccessInfo, error) {
+	var chain []*gerrit.ProjectAccessInfo
+	seen := make(map[string]struct{})
+	for projectName != "" && len(chain) < maxInheritanceDepth {
+		if _, ok := seen[projectName]; ok {
+			break
+		}
+		seen[projectName] = struct{}{}
+
+		access, err := c.get(ctx, projectName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get access of project %q", projectName)
+		}
+		chain = append(chain, access)
+		projectName = parentProjectName(access)
+	}
+	return chain, nil
+}
+
+// parentProjectName returns the name of the project the given access
+// configuration inherits from, or an empty string for the root project.
+func parentProjectName(access *gerrit.ProjectAccessInfo) string {
+	if access == nil || access.InheritsFrom == nil {
+		return ""
+	}
+	if access.InheritsFrom.Name != "" {
+		return access.InheritsFrom.Name
+	}
+	// The ID is the URL encoded name of the project.
+	name, err := url.PathUnescape(access.InheritsFrom.ID)
+	if err != nil {
+		return access.InheritsFrom.ID
+	}
+	return name
+}
+
+// subRepoRulesFromAccess translates the path access sections of a project into
+// sub-repo permission rules for a user that is a member of the given groups.
+// The chain starts with the access configuration of the project itself,
+// followed by the ones of the projects it inherits from. It returns nil if
+// none of them has path access sections, meaning the whole repository is
+// accessible.
+func subRepoRulesFromAccess(chain []*gerrit.ProjectAccessInfo, groups map[string]struct{}) []string {
+	// reads holds the read permissions of each path, from all levels of the
+	// project hierarchy.
+	reads := make(map[string][]inheritedPermission)
+	for level, access := range chain {
+		if access == nil {
+			continue
+		}
+		for ref, section := range access.Local {
+			path, ok := strings.CutPrefix(ref, pathRefPrefix)
+			if !ok {
+				continue
+			}
+			// Strip ref wildcards, a section on "refs/paths/dir/*" covers the
+			// same files as one on "refs/paths/dir".
+			path = strings.TrimRight(strings.TrimSuffix(path, "*"), "/")
+			if path == "" {
+				continue
+			}
+			read, ok := section.Permissions[gerrit.PermissionRead]
+			if !ok {
+				continue
+			}
+			reads[path] = append(reads[path], inheritedPermission{level: level, PermissionInfo: read})
+		}
+	}
+	if len(reads) == 0 {
+		return nil
+	}
+
+	// Sorting by path puts parent directories before their children, so that
+	// rules on nested paths take precedence since the last matching rule wins.
+	paths := make([]string, 0, len(reads))
+	for path := range reads {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	rules := []string{"/**"}
+	for _, path := range paths {
+		prefix := ""
+		if !canRead(reads[path], groups) {
+			prefix = "-"
+		}
+		// Match both the path itself, in case it is a file, and everything
+		// below it, in case it is a directory.
+		rules = append(rules, prefix+"/"+path, prefix+"/"+path+"/**")
+	}
+	return rules
+}
+
+// inheritedPermission is a permission defined on the project at the given
+// level of the project hierarchy, where 0 is the project itself.
+type inheritedPermission struct {
+	level int
+	gerrit.PermissionInfo
+}
+
+// canRead reports whether a member of the given groups is granted the read
+// permission. Like in Gerrit, BLOCK rules always win, even when inherited,
+// and an exclusive permission ignores the ALLOW rules inherited from parent
+// projects. The absence of a matching ALLOW rule means no access since the
+// section exists to restrict the path.
+func canRead(reads []inheritedPermission, groups map[string]struct{}) bool {
+	exclusiveLevel := -1
+	for _, read := range reads {
+		if read.Exclusive && (exclusiveLevel == -1 || read.level < exclusiveLevel) {
+			exclusiveLevel = read.level
+		}
+	}
+
+	allowed := false
+	for _, read := range reads {
+		for groupID, rule := range read.Rules {
+			if _, ok := groups[groupID]; !ok {
+				continue
+			}
+			switch rule.Action {
+			case gerrit.PermissionRuleActionBlock:
+				return false
+			case gerrit.PermissionRuleActionAllow:
+				if exclusiveLevel == -1 || read.level <= exclusiveLevel {
+					allowed = true
+				}
+			}
+		}
+	}
+	return allowed
+}
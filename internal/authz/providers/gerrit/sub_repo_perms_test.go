@@ -0,0 +1,193 @@
+package gerrit
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+func TestSubRepoRulesFromAccess(t *testing.T) {
+	readRules := func(rules map[string]gerrit.PermissionRuleAction) gerrit.AccessSectionInfo {
+		info := gerrit.PermissionInfo{Rules: make(map[string]gerrit.PermissionRuleInfo, len(rules))}
+		for group, action := range rules {
+			info.Rules[group] = gerrit.PermissionRuleInfo{Action: action}
+		}
+		return gerrit.AccessSectionInfo{
+			Permissions: map[string]gerrit.PermissionInfo{gerrit.PermissionRead: info},
+		}
+	}
+
+	groups := map[string]struct{}{
+		"contractors":             {},
+		"global:Registered-Users": {},
+	}
+
+	exclusive := func(section gerrit.AccessSectionInfo) gerrit.AccessSectionInfo {
+		read := section.Permissions[gerrit.PermissionRead]
+		read.Exclusive = true
+		section.Permissions[gerrit.PermissionRead] = read
+		return section
+	}
+
+	testCases := []struct {
+		name    string
+		access  *gerrit.ProjectAccessInfo
+		parents []*gerrit.ProjectAccessInfo
+		want    []string
+	}{
+		{
+			name:   "no access info",
+			access: nil,
+			want:   nil,
+		},
+		{
+			name: "no path sections",
+			access: &gerrit.ProjectAccessInfo{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/heads/*": readRules(map[string]gerrit.PermissionRuleAction{
+						"employees": gerrit.PermissionRuleActionAllow,
+					}),
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "path sections without read permission are ignored",
+			access: &gerrit.ProjectAccessInfo{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/paths/docs": {
+						Permissions: map[string]gerrit.PermissionInfo{
+							"push": {},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "restricted and allowed paths",
+			access: &gerrit.ProjectAccessInfo{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/paths/secrets/*": readRules(map[string]gerrit.PermissionRuleAction{
+						"employees": gerrit.PermissionRuleActionAllow,
+					}),
+					"refs/paths/secrets/shared": readRules(map[string]gerrit.PermissionRuleAction{
+						"contractors": gerrit.PermissionRuleActionAllow,
+					}),
+					"refs/paths/docs": readRules(map[string]gerrit.PermissionRuleAction{
+						"global:Registered-Users": gerrit.PermissionRuleActionAllow,
+					}),
+				},
+			},
+			want: []string{
+				"/**",
+				"/docs",
+				"/docs/**",
+				"-/secrets",
+				"-/secrets/**",
+				"/secrets/shared",
+				"/secrets/shared/**",
+			},
+		},
+		{
+			name: "block wins over allow",
+			access: &gerrit.ProjectAccessInfo{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/paths/internal": readRules(map[string]gerrit.PermissionRuleAction{
+						"global:Registered-Users": gerrit.PermissionRuleActionAllow,
+						"contractors":             gerrit.PermissionRuleActionBlock,
+					}),
+				},
+			},
+			want: []string{"/**", "-/internal", "-/internal/**"},
+		},
+		{
+			name: "deny for the user's group",
+			access: &gerrit.ProjectAccessInfo{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/paths/internal": readRules(map[string]gerrit.PermissionRuleAction{
+						"contractors": gerrit.PermissionRuleActionDeny,
+					}),
+				},
+			},
+			want: []string{"/**", "-/internal", "-/internal/**"},
+		},
+		{
+			name:   "path sections inherited from All-Projects",
+			access: &gerrit.ProjectAccessInfo{},
+			parents: []*gerrit.ProjectAccessInfo{{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/paths/secrets": readRules(map[string]gerrit.PermissionRuleAction{
+						"employees": gerrit.PermissionRuleActionAllow,
+					}),
+				},
+			}},
+			want: []string{"/**", "-/secrets", "-/secrets/**"},
+		},
+		{
+			name: "inherited allow rules are combined with local ones",
+			access: &gerrit.ProjectAccessInfo{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/paths/docs": readRules(map[string]gerrit.PermissionRuleAction{
+						"employees": gerrit.PermissionRuleActionAllow,
+					}),
+				},
+			},
+			parents: []*gerrit.ProjectAccessInfo{{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/paths/docs": readRules(map[string]gerrit.PermissionRuleAction{
+						"contractors": gerrit.PermissionRuleActionAllow,
+					}),
+				},
+			}},
+			want: []string{"/**", "/docs", "/docs/**"},
+		},
+		{
+			name: "exclusive permissions ignore inherited allow rules",
+			access: &gerrit.ProjectAccessInfo{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/paths/docs": exclusive(readRules(map[string]gerrit.PermissionRuleAction{
+						"employees": gerrit.PermissionRuleActionAllow,
+					})),
+				},
+			},
+			parents: []*gerrit.ProjectAccessInfo{{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/paths/docs": readRules(map[string]gerrit.PermissionRuleAction{
+						"contractors": gerrit.PermissionRuleActionAllow,
+					}),
+				},
+			}},
+			want: []string{"/**", "-/docs", "-/docs/**"},
+		},
+		{
+			name: "inherited block wins over local allow",
+			access: &gerrit.ProjectAccessInfo{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/paths/internal": exclusive(readRules(map[string]gerrit.PermissionRuleAction{
+						"contractors": gerrit.PermissionRuleActionAllow,
+					})),
+				},
+			},
+			parents: []*gerrit.ProjectAccessInfo{{
+				Local: map[string]gerrit.AccessSectionInfo{
+					"refs/paths/internal": readRules(map[string]gerrit.PermissionRuleAction{
+						"contractors": gerrit.PermissionRuleActionBlock,
+					}),
+				},
+			}},
+			want: []string{"/**", "-/internal", "-/internal/**"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := subRepoRulesFromAccess(append([]*gerrit.ProjectAccessInfo{tc.access}, tc.parents...), groups)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("rules mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -97,6 +97,10 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 		return nil, err
 	}
 
+	if err := holdForStage(ctx, logger, tx, plan); err != nil {
+		return nil, err
+	}
+
 	logger.Info("Reconciler processing changeset", log.Int64("changeset", ch.ID), log.String("operations", fmt.Sprintf("%+v", plan.Ops)))
 
 	return executePlan(
@@ -110,6 +114,60 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 	)
 }
 
+// holdForStage removes the operations that would publish the changeset from
+// the given plan if the changeset belongs to a stage whose preceding stages
+// still contain changesets that haven't been merged. Held back changesets are
+// marked as waiting for their stage, and enqueued again once a changeset of the
+// batch change gets merged.
+func holdForStage(ctx context.Context, logger log.Logger, tx *store.Store, plan *Plan) error {
+	waitingForStage, err := stageToWaitFor(ctx, tx, plan)
+	if err != nil {
+		return err
+	}
+
+	if waitingForStage != 0 {
+		logger.Info("Holding back changeset until preceding stages are merged",
+			log.Int64("changeset", plan.Changeset.ID),
+			log.Int32("stage", waitingForStage),
+		)
+
+		ops := Operations{}
+		for _, op := range plan.Ops {
+			switch op {
+			case btypes.ReconcilerOperationPublish, btypes.ReconcilerOperationPublishDraft, btypes.ReconcilerOperationPush:
+				continue
+			}
+			ops = append(ops, op)
+		}
+		plan.Ops = ops
+	}
+
+	if plan.Changeset.WaitingForStage == waitingForStage {
+		return nil
+	}
+	plan.Changeset.WaitingForStage = waitingForStage
+	return tx.UpdateChangesetWaitingForStage(ctx, plan.Changeset)
+}
+
+// stageToWaitFor returns the stage of the changeset's spec if the plan would
+// publish the changeset while changesets of preceding stages are still
+// blocking it, and 0 otherwise.
+func stageToWaitFor(ctx context.Context, tx *store.Store, plan *Plan) (int32, error) {
+	spec := plan.ChangesetSpec
+	if spec == nil || spec.Stage <= 1 || plan.Changeset.OwnedByBatchChangeID == 0 {
+		return 0, nil
+	}
+	if !plan.Ops.Contains(btypes.ReconcilerOperationPublish) && !plan.Ops.Contains(btypes.ReconcilerOperationPublishDraft) {
+		return 0, nil
+	}
+
+	blocking, err := tx.CountChangesetsBlockingStage(ctx, plan.Changeset.OwnedByBatchChangeID, spec.Stage)
+	if err != nil || blocking == 0 {
+		return 0, err
+	}
+	return spec.Stage, nil
+}
+
 func loadChangesetSpecs(ctx context.Context, tx *store.Store, ch *btypes.Changeset) (prev, curr *btypes.ChangesetSpec, err error) {
 	if ch.CurrentSpecID != 0 {
 		curr, err = tx.GetChangesetSpecByID(ctx, ch.CurrentSpecID)
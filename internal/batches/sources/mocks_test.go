@@ -9002,12 +9002,18 @@ type MockGerritClient struct {
 	// GetGroupFunc is an instance of a mock function object controlling the
 	// behavior of the method GetGroup.
 	GetGroupFunc *GerritClientGetGroupFunc
+	// GetProjectAccessFunc is an instance of a mock function object
+	// controlling the behavior of the method GetProjectAccess.
+	GetProjectAccessFunc *GerritClientGetProjectAccessFunc
 	// GetSSHInfoFunc is an instance of a mock function object controlling
 	// the behavior of the method GetSSHInfo.
 	GetSSHInfoFunc *GerritClientGetSSHInfoFunc
 	// GetURLFunc is an instance of a mock function object controlling the
 	// behavior of the method GetURL.
 	GetURLFunc *GerritClientGetURLFunc
+	// ListAccountGroupsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListAccountGroups.
+	ListAccountGroupsFunc *GerritClientListAccountGroupsFunc
 	// ListProjectsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListProjects.
 	ListProjectsFunc *GerritClientListProjectsFunc
@@ -9076,6 +9082,11 @@ func NewMockGerritClient() *MockGerritClient {
 				return
 			},
 		},
+		GetProjectAccessFunc: &GerritClientGetProjectAccessFunc{
+			defaultHook: func(context.Context, string) (r0 *gerrit.ProjectAccessInfo, r1 error) {
+				return
+			},
+		},
 		GetSSHInfoFunc: &GerritClientGetSSHInfoFunc{
 			defaultHook: func(context.Context) (r0 string, r1 int, r2 error) {
 				return
@@ -9086,6 +9097,11 @@ func NewMockGerritClient() *MockGerritClient {
 				return
 			},
 		},
+		ListAccountGroupsFunc: &GerritClientListAccountGroupsFunc{
+			defaultHook: func(context.Context) (r0 []gerrit.Group, r1 error) {
+				return
+			},
+		},
 		ListProjectsFunc: &GerritClientListProjectsFunc{
 			defaultHook: func(context.Context, gerrit.ListProjectsArgs) (r0 gerrit.ListProjectsResponse, r1 bool, r2 error) {
 				return
@@ -9173,6 +9189,11 @@ func NewStrictMockGerritClient() *MockGerritClient {
 				panic("unexpected invocation of MockGerritClient.GetGroup")
 			},
 		},
+		GetProjectAccessFunc: &GerritClientGetProjectAccessFunc{
+			defaultHook: func(context.Context, string) (*gerrit.ProjectAccessInfo, error) {
+				panic("unexpected invocation of MockGerritClient.GetProjectAccess")
+			},
+		},
 		GetSSHInfoFunc: &GerritClientGetSSHInfoFunc{
 			defaultHook: func(context.Context) (string, int, error) {
 				panic("unexpected invocation of MockGerritClient.GetSSHInfo")
@@ -9183,6 +9204,11 @@ func NewStrictMockGerritClient() *MockGerritClient {
 				panic("unexpected invocation of MockGerritClient.GetURL")
 			},
 		},
+		ListAccountGroupsFunc: &GerritClientListAccountGroupsFunc{
+			defaultHook: func(context.Context) ([]gerrit.Group, error) {
+				panic("unexpected invocation of MockGerritClient.ListAccountGroups")
+			},
+		},
 		ListProjectsFunc: &GerritClientListProjectsFunc{
 			defaultHook: func(context.Context, gerrit.ListProjectsArgs) (gerrit.ListProjectsResponse, bool, error) {
 				panic("unexpected invocation of MockGerritClient.ListProjects")
@@ -9257,12 +9283,18 @@ func NewMockGerritClientFrom(i gerrit.Client) *MockGerritClient {
 		GetGroupFunc: &GerritClientGetGroupFunc{
 			defaultHook: i.GetGroup,
 		},
+		GetProjectAccessFunc: &GerritClientGetProjectAccessFunc{
+			defaultHook: i.GetProjectAccess,
+		},
 		GetSSHInfoFunc: &GerritClientGetSSHInfoFunc{
 			defaultHook: i.GetSSHInfo,
 		},
 		GetURLFunc: &GerritClientGetURLFunc{
 			defaultHook: i.GetURL,
 		},
+		ListAccountGroupsFunc: &GerritClientListAccountGroupsFunc{
+			defaultHook: i.ListAccountGroups,
+		},
 		ListProjectsFunc: &GerritClientListProjectsFunc{
 			defaultHook: i.ListProjects,
 		},
@@ -10039,6 +10071,115 @@ func (c GerritClientGetGroupFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GerritClientGetProjectAccessFunc describes the behavior when the
+// GetProjectAccess method of the parent MockGerritClient instance is
+// invoked.
+type GerritClientGetProjectAccessFunc struct {
+	defaultHook func(context.Context, string) (*gerrit.ProjectAccessInfo, error)
+	hooks       []func(context.Context, string) (*gerrit.ProjectAccessInfo, error)
+	history     []GerritClientGetProjectAccessFuncCall
+	mutex       sync.Mutex
+}
+
+// GetProjectAccess delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGerritClient) GetProjectAccess(v0 context.Context, v1 string) (*gerrit.ProjectAccessInfo, error) {
+	r0, r1 := m.GetProjectAccessFunc.nextHook()(v0, v1)
+	m.GetProjectAccessFunc.appendCall(GerritClientGetProjectAccessFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetProjectAccess
+// method of the parent MockGerritClient instance is invoked and the hook
+// queue is empty.
+func (f *GerritClientGetProjectAccessFunc) SetDefaultHook(hook func(context.Context, string) (*gerrit.ProjectAccessInfo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetProjectAccess method of the parent MockGerritClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GerritClientGetProjectAccessFunc) PushHook(hook func(context.Context, string) (*gerrit.ProjectAccessInfo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GerritClientGetProjectAccessFunc) SetDefaultReturn(r0 *gerrit.ProjectAccessInfo, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (*gerrit.ProjectAccessInfo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GerritClientGetProjectAccessFunc) PushReturn(r0 *gerrit.ProjectAccessInfo, r1 error) {
+	f.PushHook(func(context.Context, string) (*gerrit.ProjectAccessInfo, error) {
+		return r0, r1
+	})
+}
+
+func (f *GerritClientGetProjectAccessFunc) nextHook() func(context.Context, string) (*gerrit.ProjectAccessInfo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GerritClientGetProjectAccessFunc) appendCall(r0 GerritClientGetProjectAccessFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GerritClientGetProjectAccessFuncCall
+// objects describing the invocations of this function.
+func (f *GerritClientGetProjectAccessFunc) History() []GerritClientGetProjectAccessFuncCall {
+	f.mutex.Lock()
+	history := make([]GerritClientGetProjectAccessFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GerritClientGetProjectAccessFuncCall is an object that describes an
+// invocation of method GetProjectAccess on an instance of MockGerritClient.
+type GerritClientGetProjectAccessFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *gerrit.ProjectAccessInfo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GerritClientGetProjectAccessFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GerritClientGetProjectAccessFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GerritClientGetSSHInfoFunc describes the behavior when the GetSSHInfo
 // method of the parent MockGerritClient instance is invoked.
 type GerritClientGetSSHInfoFunc struct {
@@ -10245,6 +10386,113 @@ func (c GerritClientGetURLFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// GerritClientListAccountGroupsFunc describes the behavior when the
+// ListAccountGroups method of the parent MockGerritClient instance is
+// invoked.
+type GerritClientListAccountGroupsFunc struct {
+	defaultHook func(context.Context) ([]gerrit.Group, error)
+	hooks       []func(context.Context) ([]gerrit.Group, error)
+	history     []GerritClientListAccountGroupsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListAccountGroups delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGerritClient) ListAccountGroups(v0 context.Context) ([]gerrit.Group, error) {
+	r0, r1 := m.ListAccountGroupsFunc.nextHook()(v0)
+	m.ListAccountGroupsFunc.appendCall(GerritClientListAccountGroupsFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListAccountGroups
+// method of the parent MockGerritClient instance is invoked and the hook
+// queue is empty.
+func (f *GerritClientListAccountGroupsFunc) SetDefaultHook(hook func(context.Context) ([]gerrit.Group, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListAccountGroups method of the parent MockGerritClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GerritClientListAccountGroupsFunc) PushHook(hook func(context.Context) ([]gerrit.Group, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GerritClientListAccountGroupsFunc) SetDefaultReturn(r0 []gerrit.Group, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]gerrit.Group, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GerritClientListAccountGroupsFunc) PushReturn(r0 []gerrit.Group, r1 error) {
+	f.PushHook(func(context.Context) ([]gerrit.Group, error) {
+		return r0, r1
+	})
+}
+
+func (f *GerritClientListAccountGroupsFunc) nextHook() func(context.Context) ([]gerrit.Group, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GerritClientListAccountGroupsFunc) appendCall(r0 GerritClientListAccountGroupsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GerritClientListAccountGroupsFuncCall
+// objects describing the invocations of this function.
+func (f *GerritClientListAccountGroupsFunc) History() []GerritClientListAccountGroupsFuncCall {
+	f.mutex.Lock()
+	history := make([]GerritClientListAccountGroupsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GerritClientListAccountGroupsFuncCall is an object that describes an
+// invocation of method ListAccountGroups on an instance of
+// MockGerritClient.
+type GerritClientListAccountGroupsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []gerrit.Group
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GerritClientListAccountGroupsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GerritClientListAccountGroupsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GerritClientListProjectsFunc describes the behavior when the ListProjects
 // method of the parent MockGerritClient instance is invoked.
 type GerritClientListProjectsFunc struct {
@@ -11239,6 +11487,9 @@ type MockGitserverClient struct {
 	// CheckPerforceCredentialsFunc is an instance of a mock function object
 	// controlling the behavior of the method CheckPerforceCredentials.
 	CheckPerforceCredentialsFunc *GitserverClientCheckPerforceCredentialsFunc
+	// CommitHistogramFunc is an instance of a mock function object
+	// controlling the behavior of the method CommitHistogram.
+	CommitHistogramFunc *GitserverClientCommitHistogramFunc
 	// CommitsFunc is an instance of a mock function object controlling the
 	// behavior of the method Commits.
 	CommitsFunc *GitserverClientCommitsFunc
@@ -11363,6 +11614,11 @@ func NewMockGitserverClient() *MockGitserverClient {
 				return
 			},
 		},
+		CommitHistogramFunc: &GitserverClientCommitHistogramFunc{
+			defaultHook: func(context.Context, *protocol.SearchRequest) (r0 *protocol.CommitHistogram, r1 error) {
+				return
+			},
+		},
 		CommitsFunc: &GitserverClientCommitsFunc{
 			defaultHook: func(context.Context, api.RepoName, gitserver.CommitsOptions) (r0 []*gitdomain.Commit, r1 error) {
 				return
@@ -11550,6 +11806,11 @@ func NewStrictMockGitserverClient() *MockGitserverClient {
 				panic("unexpected invocation of MockGitserverClient.CheckPerforceCredentials")
 			},
 		},
+		CommitHistogramFunc: &GitserverClientCommitHistogramFunc{
+			defaultHook: func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error) {
+				panic("unexpected invocation of MockGitserverClient.CommitHistogram")
+			},
+		},
 		CommitsFunc: &GitserverClientCommitsFunc{
 			defaultHook: func(context.Context, api.RepoName, gitserver.CommitsOptions) ([]*gitdomain.Commit, error) {
 				panic("unexpected invocation of MockGitserverClient.Commits")
@@ -11728,6 +11989,9 @@ func NewMockGitserverClientFrom(i gitserver.Client) *MockGitserverClient {
 		CheckPerforceCredentialsFunc: &GitserverClientCheckPerforceCredentialsFunc{
 			defaultHook: i.CheckPerforceCredentials,
 		},
+		CommitHistogramFunc: &GitserverClientCommitHistogramFunc{
+			defaultHook: i.CommitHistogram,
+		},
 		CommitsFunc: &GitserverClientCommitsFunc{
 			defaultHook: i.Commits,
 		},
@@ -12379,6 +12643,116 @@ func (c GitserverClientCheckPerforceCredentialsFuncCall) Results() []interface{}
 	return []interface{}{c.Result0}
 }
 
+// GitserverClientCommitHistogramFunc describes the behavior when the
+// CommitHistogram method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientCommitHistogramFunc struct {
+	defaultHook func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error)
+	hooks       []func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error)
+	history     []GitserverClientCommitHistogramFuncCall
+	mutex       sync.Mutex
+}
+
+// CommitHistogram delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverClient) CommitHistogram(v0 context.Context, v1 *protocol.SearchRequest) (*protocol.CommitHistogram, error) {
+	r0, r1 := m.CommitHistogramFunc.nextHook()(v0, v1)
+	m.CommitHistogramFunc.appendCall(GitserverClientCommitHistogramFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CommitHistogram
+// method of the parent MockGitserverClient instance is invoked and the hook
+// queue is empty.
+func (f *GitserverClientCommitHistogramFunc) SetDefaultHook(hook func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitHistogram method of the parent MockGitserverClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverClientCommitHistogramFunc) PushHook(hook func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientCommitHistogramFunc) SetDefaultReturn(r0 *protocol.CommitHistogram, r1 error) {
+	f.SetDefaultHook(func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientCommitHistogramFunc) PushReturn(r0 *protocol.CommitHistogram, r1 error) {
+	f.PushHook(func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientCommitHistogramFunc) nextHook() func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientCommitHistogramFunc) appendCall(r0 GitserverClientCommitHistogramFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientCommitHistogramFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverClientCommitHistogramFunc) History() []GitserverClientCommitHistogramFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientCommitHistogramFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientCommitHistogramFuncCall is an object that describes an
+// invocation of method CommitHistogram on an instance of
+// MockGitserverClient.
+type GitserverClientCommitHistogramFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *protocol.SearchRequest
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *protocol.CommitHistogram
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientCommitHistogramFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientCommitHistogramFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GitserverClientCommitsFunc describes the behavior when the Commits method
 // of the parent MockGitserverClient instance is invoked.
 type GitserverClientCommitsFunc struct {
@@ -78,6 +78,7 @@ var changesetStringColumns = SQLColumns{
 	"syncer_error",
 	"detached_at",
 	"previous_failure_message",
+	"waiting_for_stage",
 }
 
 // ChangesetColumns are used by the changeset related Store methods and by
@@ -123,6 +124,7 @@ var ChangesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.syncer_error"),
 	sqlf.Sprintf("changesets.detached_at"),
 	sqlf.Sprintf("changesets.previous_failure_message"),
+	sqlf.Sprintf("changesets.waiting_for_stage"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -1084,6 +1086,33 @@ func (s *Store) UpdateChangesetUiPublicationState(ctx context.Context, cs *btype
 	return s.updateChangesetColumn(ctx, cs, "ui_publication_state", uiPublicationState)
 }
 
+// UpdateChangesetWaitingForStage records whether the publication of the given
+// changeset is held back until the stages preceding cs.WaitingForStage are
+// completed. A value of 0 means that the changeset isn't held back.
+//
+// Unlike the other narrow update methods, it doesn't reload the changeset, so
+// it can be used by the reconciler while it has unsaved changes to cs.
+func (s *Store) UpdateChangesetWaitingForStage(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, _, endObservation := s.operations.updateChangesetWaitingForStage.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(cs.ID)),
+		attribute.Int("waitingForStage", int(cs.WaitingForStage)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		updateChangesetWaitingForStageFmtstr,
+		dbutil.NullInt32Column(cs.WaitingForStage),
+		cs.ID,
+	)
+	return s.Exec(ctx, q)
+}
+
+const updateChangesetWaitingForStageFmtstr = `
+UPDATE changesets
+SET waiting_for_stage = %s
+WHERE id = %s
+`
+
 // UpdateChangesetSCommitVerification records the commit verification object for a commit
 // to the Changeset if it was signed and verified.
 func (s *Store) UpdateChangesetCommitVerification(ctx context.Context, cs *btypes.Changeset, commit *github.RestCommit) (err error) {
@@ -1486,6 +1515,7 @@ func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &syncErrorMessage},
 		&dbutil.NullTime{Time: &t.DetachedAt},
 		&dbutil.NullString{S: &previousFailureMessage},
+		&dbutil.NullInt32{N: &t.WaitingForStage},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1606,6 +1636,133 @@ WHERE
 	%s
 `
 
+// GetBatchChangeStagesStats returns statistics on the changesets associated
+// to the given batch change, grouped by the stage of their current changeset
+// spec. Changesets that don't belong to a stage are not included.
+func (s *Store) GetBatchChangeStagesStats(ctx context.Context, batchChangeID int64) (stats []btypes.BatchChangeStageStats, err error) {
+	ctx, _, endObservation := s.operations.getBatchChangeStagesStats.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	batchChangeIDStr := strconv.Itoa(int(batchChangeID))
+	q := sqlf.Sprintf(
+		getBatchChangeStagesStatsFmtstr,
+		btypes.ChangesetExternalStateMerged,
+		btypes.ChangesetPublicationStateUnpublished,
+		batchChangeIDStr,
+		archivedInBatchChange(batchChangeIDStr),
+	)
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var st btypes.BatchChangeStageStats
+		if err := sc.Scan(
+			&st.Stage,
+			&st.Total,
+			&st.Merged,
+			&st.Unpublished,
+			&st.Waiting,
+		); err != nil {
+			return err
+		}
+		stats = append(stats, st)
+		return nil
+	})
+	return stats, err
+}
+
+const getBatchChangeStagesStatsFmtstr = `
+SELECT
+	changeset_specs.stage,
+	COUNT(*) AS total,
+	COUNT(*) FILTER (WHERE changesets.external_state = %s) AS merged,
+	COUNT(*) FILTER (WHERE changesets.publication_state = %s) AS unpublished,
+	COUNT(*) FILTER (WHERE changesets.waiting_for_stage IS NOT NULL) AS waiting
+FROM changesets
+INNER JOIN changeset_specs ON changeset_specs.id = changesets.current_spec_id
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE
+	repo.deleted_at IS NULL
+	AND changesets.batch_change_ids ? %s
+	AND NOT %s
+	AND changeset_specs.stage IS NOT NULL
+GROUP BY changeset_specs.stage
+ORDER BY changeset_specs.stage ASC
+`
+
+// CountChangesetsBlockingStage returns the number of changesets associated to
+// the given batch change that belong to a stage preceding the given stage and
+// haven't been merged yet. Changesets of the given stage may only be published
+// once this number is zero.
+func (s *Store) CountChangesetsBlockingStage(ctx context.Context, batchChangeID int64, stage int32) (count int, err error) {
+	ctx, _, endObservation := s.operations.countChangesetsBlockingStage.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("batchChangeID", int(batchChangeID)),
+		attribute.Int("stage", int(stage)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	batchChangeIDStr := strconv.Itoa(int(batchChangeID))
+	q := sqlf.Sprintf(
+		countChangesetsBlockingStageFmtstr,
+		batchChangeIDStr,
+		archivedInBatchChange(batchChangeIDStr),
+		stage,
+		btypes.ChangesetExternalStateMerged,
+	)
+	count, _, err = basestore.ScanFirstInt(s.Query(ctx, q))
+	return count, err
+}
+
+const countChangesetsBlockingStageFmtstr = `
+SELECT COUNT(*)
+FROM changesets
+INNER JOIN changeset_specs ON changeset_specs.id = changesets.current_spec_id
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE
+	repo.deleted_at IS NULL
+	AND changesets.batch_change_ids ? %s
+	AND NOT %s
+	AND changeset_specs.stage < %s
+	AND changesets.external_state IS DISTINCT FROM %s
+`
+
+// EnqueueChangesetsWaitingForStage enqueues all unpublished changesets owned
+// by the given batch change that belong to a stage after the first one and
+// that the reconciler previously held back. The reconciler checks again whether
+// the preceding stages are complete and publishes them if so.
+func (s *Store) EnqueueChangesetsWaitingForStage(ctx context.Context, batchChangeID int64, resetState btypes.ReconcilerState) (err error) {
+	ctx, _, endObservation := s.operations.enqueueChangesetsWaitingForStage.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		enqueueChangesetsWaitingForStageFmtstr,
+		resetState.ToDB(),
+		s.now(),
+		batchChangeID,
+		btypes.ChangesetPublicationStateUnpublished,
+		btypes.ReconcilerStateCompleted.ToDB(),
+	)
+	return s.Exec(ctx, q)
+}
+
+const enqueueChangesetsWaitingForStageFmtstr = `
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	failure_message = NULL,
+	num_resets = 0,
+	num_failures = 0,
+	updated_at = %s
+FROM changeset_specs
+WHERE
+	changeset_specs.id = changesets.current_spec_id
+	AND changesets.owned_by_batch_change_id = %s
+	AND changesets.publication_state = %s
+	AND changesets.reconciler_state = %s
+	AND changeset_specs.stage > 1
+`
+
 // GetRepoChangesetsStats returns statistics on all the changesets associated to the given repo.
 func (s *Store) GetRepoChangesetsStats(ctx context.Context, repoID api.RepoID) (stats *btypes.RepoChangesetsStats, err error) {
 	ctx, _, endObservation := s.operations.getRepoChangesetsStats.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
@@ -2642,6 +2642,112 @@ func TestEnqueueChangesetsToClose(t *testing.T) {
 	}
 }
 
+func TestChangesetStages(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(t))
+
+	s := New(db, observation.TestContextTB(t), nil)
+
+	user := bt.CreateTestUser(t, db, true)
+	batchSpec := bt.CreateBatchSpec(t, ctx, s, "test-batch-change", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "test-batch-change", user.ID, batchSpec.ID)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	createChangeset := func(stage int32, externalState btypes.ChangesetExternalState, publicationState btypes.ChangesetPublicationState, published bool) *btypes.Changeset {
+		spec := bt.CreateChangesetSpec(t, ctx, s, bt.TestSpecOpts{
+			User:      user.ID,
+			Repo:      repo.ID,
+			BatchSpec: batchSpec.ID,
+			HeadRef:   fmt.Sprintf("refs/heads/stage-%d-%s-%t", stage, externalState, published),
+			Published: published,
+			Stage:     stage,
+			Typ:       btypes.ChangesetSpecTypeBranch,
+		})
+		return bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			CurrentSpec:        spec.ID,
+			ExternalState:      externalState,
+			PublicationState:   publicationState,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+		})
+	}
+
+	createChangeset(1, btypes.ChangesetExternalStateMerged, btypes.ChangesetPublicationStatePublished, true)
+	firstStageOpen := createChangeset(1, btypes.ChangesetExternalStateOpen, btypes.ChangesetPublicationStatePublished, true)
+	// Closed changesets hold back the following stages, since they haven't
+	// been merged.
+	createChangeset(1, btypes.ChangesetExternalStateClosed, btypes.ChangesetPublicationStatePublished, true)
+	secondStage := createChangeset(2, "", btypes.ChangesetPublicationStateUnpublished, true)
+	// So do changesets that are not going to be published.
+	createChangeset(2, "", btypes.ChangesetPublicationStateUnpublished, false)
+	// Changesets without a stage never block and are never held back.
+	unstaged := createChangeset(0, "", btypes.ChangesetPublicationStateUnpublished, true)
+
+	t.Run("CountChangesetsBlockingStage", func(t *testing.T) {
+		for stage, want := range map[int32]int{1: 0, 2: 2, 3: 4} {
+			have, err := s.CountChangesetsBlockingStage(ctx, batchChange.ID, stage)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != want {
+				t.Errorf("wrong number of changesets blocking stage %d. want=%d, have=%d", stage, want, have)
+			}
+		}
+	})
+
+	t.Run("UpdateChangesetWaitingForStage", func(t *testing.T) {
+		secondStage.WaitingForStage = 2
+		if err := s.UpdateChangesetWaitingForStage(ctx, secondStage); err != nil {
+			t.Fatal(err)
+		}
+
+		reloaded, err := s.GetChangesetByID(ctx, secondStage.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloaded.WaitingForStage, int32(2); have != want {
+			t.Errorf("wrong stage the changeset is waiting for. want=%d, have=%d", want, have)
+		}
+	})
+
+	t.Run("GetBatchChangeStagesStats", func(t *testing.T) {
+		have, err := s.GetBatchChangeStagesStats(ctx, batchChange.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []btypes.BatchChangeStageStats{
+			{Stage: 1, Total: 3, Merged: 1},
+			{Stage: 2, Total: 2, Unpublished: 2, Waiting: 1},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("wrong stats (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("EnqueueChangesetsWaitingForStage", func(t *testing.T) {
+		if err := s.EnqueueChangesetsWaitingForStage(ctx, batchChange.ID, btypes.ReconcilerStateQueued); err != nil {
+			t.Fatal(err)
+		}
+
+		for ch, want := range map[*btypes.Changeset]btypes.ReconcilerState{
+			firstStageOpen: btypes.ReconcilerStateCompleted,
+			secondStage:    btypes.ReconcilerStateQueued,
+			unstaged:       btypes.ReconcilerStateCompleted,
+		} {
+			reloaded, err := s.GetChangesetByID(ctx, ch.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reloaded.ReconcilerState != want {
+				t.Errorf("wrong reconciler state for changeset %d. want=%s, have=%s", ch.ID, want, reloaded.ReconcilerState)
+			}
+		}
+	})
+}
+
 func TestCleanDetachedChangesets(t *testing.T) {
 	logger := logtest.Scoped(t)
 	ctx := context.Background()
@@ -246,10 +246,14 @@ type operations struct {
 	updateChangesetUIPublicationState *observation.Operation
 	updateChangesetCodeHostState      *observation.Operation
 	updateChangesetCommitVerification *observation.Operation
+	updateChangesetWaitingForStage    *observation.Operation
 	getChangesetExternalIDs           *observation.Operation
 	cancelQueuedBatchChangeChangesets *observation.Operation
 	enqueueChangesetsToClose          *observation.Operation
 	getChangesetsStats                *observation.Operation
+	getBatchChangeStagesStats         *observation.Operation
+	countChangesetsBlockingStage      *observation.Operation
+	enqueueChangesetsWaitingForStage  *observation.Operation
 	getRepoChangesetsStats            *observation.Operation
 	getGlobalChangesetsStats          *observation.Operation
 	enqueueNextScheduledChangeset     *observation.Operation
@@ -391,10 +395,14 @@ func newOperations(observationCtx *observation.Context) *operations {
 			updateChangesetUIPublicationState: op("UpdateChangesetUIPublicationState"),
 			updateChangesetCodeHostState:      op("UpdateChangesetCodeHostState"),
 			updateChangesetCommitVerification: op("UpdateChangesetCommitVerification"),
+			updateChangesetWaitingForStage:    op("UpdateChangesetWaitingForStage"),
 			getChangesetExternalIDs:           op("GetChangesetExternalIDs"),
 			cancelQueuedBatchChangeChangesets: op("CancelQueuedBatchChangeChangesets"),
 			enqueueChangesetsToClose:          op("EnqueueChangesetsToClose"),
 			getChangesetsStats:                op("GetChangesetsStats"),
+			getBatchChangeStagesStats:         op("GetBatchChangeStagesStats"),
+			countChangesetsBlockingStage:      op("CountChangesetsBlockingStage"),
+			enqueueChangesetsWaitingForStage:  op("EnqueueChangesetsWaitingForStage"),
 			getRepoChangesetsStats:            op("GetRepoChangesetsStats"),
 			getGlobalChangesetsStats:          op("GetGlobalChangesetsStats"),
 			enqueueNextScheduledChangeset:     op("EnqueueNextScheduledChangeset"),
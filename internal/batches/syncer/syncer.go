@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/global"
 	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
@@ -535,6 +536,7 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 	if err != nil {
 		return err
 	}
+	previousState := c.ExternalState
 	state.SetDerivedState(ctx, syncStore.Repos(), client, c, events)
 
 	tx, err := syncStore.Transact(ctx)
@@ -551,5 +553,13 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 		return err
 	}
 
+	// A newly merged changeset may complete a stage of its batch change, in
+	// which case the changesets of the following stages can be published.
+	if previousState != btypes.ChangesetExternalStateMerged && c.ExternalState == btypes.ChangesetExternalStateMerged && c.OwnedByBatchChangeID != 0 {
+		if err := tx.EnqueueChangesetsWaitingForStage(ctx, c.OwnedByBatchChangeID, global.DefaultReconcilerEnqueueState()); err != nil {
+			return err
+		}
+	}
+
 	return tx.UpsertChangesetEvents(ctx, events...)
 }
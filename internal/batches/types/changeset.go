@@ -313,6 +313,11 @@ type Changeset struct {
 
 	// DetachedAt is the time when the changeset became "detached".
 	DetachedAt time.Time
+
+	// WaitingForStage is the stage of the changeset's spec if the reconciler
+	// holds back its publication until the preceding stages are completed,
+	// and 0 otherwise.
+	WaitingForStage int32
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -1217,6 +1222,24 @@ type GlobalChangesetsStats struct {
 	CommonChangesetsStats
 }
 
+// BatchChangeStageStats holds stats information on the changesets of a single
+// stage of a batch change.
+type BatchChangeStageStats struct {
+	// Stage is the 1-based index of the stage in the batch spec.
+	Stage       int32
+	Total       int32
+	Merged      int32
+	Unpublished int32
+	// Waiting is the number of changesets whose publication is held back until
+	// the preceding stages are completed.
+	Waiting int32
+}
+
+// Complete returns true if all changesets of the stage have been merged.
+func (s BatchChangeStageStats) Complete() bool {
+	return s.Merged == s.Total
+}
+
 // ChangesetsStats holds additional stats information on a list of changesets.
 type ChangesetsStats struct {
 	CommonChangesetsStats
@@ -36,6 +36,7 @@ func NewChangesetSpecFromSpec(spec *batcheslib.ChangesetSpec) (*ChangesetSpec, e
 		Title:      spec.Title,
 		Body:       spec.Body,
 		Published:  spec.Published,
+		Stage:      int32(spec.Stage),
 	}
 
 	if spec.IsImportingExisting() {
@@ -116,6 +117,10 @@ type ChangesetSpec struct {
 	CommitAuthorEmail string
 
 	ForkNamespace *string
+
+	// Stage is the 1-based index of the batch spec stage this changeset spec
+	// belongs to, or 0 if it isn't part of a stage.
+	Stage int32
 }
 
 // Clone returns a clone of a ChangesetSpec.
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	envToken    = "SOURCEGRAPH_TOKEN"
+	envEndpoint = "SOURCEGRAPH_ENDPOINT"
+)
+
+// maxUsages bounds the number of usages fetched for a single request, across pages.
+const maxUsages = 1000
+
+type client struct {
+	token    string
+	endpoint string
+	client   httpcli.Doer
+}
+
+func newClient() (*client, error) {
+	token := os.Getenv(envToken)
+	if token == "" {
+		return nil, errors.Errorf("%s not set", envToken)
+	}
+	endpoint := os.Getenv(envEndpoint)
+	if endpoint == "" {
+		return nil, errors.Errorf("%s not set", envEndpoint)
+	}
+
+	return &client{
+		token:    token,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   httpcli.UncachedExternalDoer,
+	}, nil
+}
+
+// position is a zero-based line and UTF-16 character offset, which is the
+// encoding used by both the language server protocol and the Sourcegraph API.
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type sourceRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+type usage struct {
+	Repository string
+	Revision   string
+	Path       string
+	Range      sourceRange
+	Kind       string
+}
+
+// usages returns the usages of the symbol at the given position. Results are
+// precise if an index is available, and fall back to syntactic and search-based
+// results otherwise.
+func (c *client) usages(ctx context.Context, loc fileLocation, pos position) ([]usage, error) {
+	var (
+		usages []usage
+		after  *string
+	)
+
+	for len(usages) < maxUsages {
+		var payload struct {
+			UsagesForSymbol struct {
+				Nodes []struct {
+					UsageKind  string
+					UsageRange *struct {
+						Repository string
+						Revision   string
+						Path       string
+						Range      sourceRange
+					}
+				}
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   *string
+				}
+			}
+		}
+		if err := c.graphQL(ctx, usagesQuery, map[string]any{
+			"repository": loc.Repository,
+			"revision":   loc.Revision,
+			"path":       loc.Path,
+			"start":      pos,
+			"end":        position{Line: pos.Line, Character: pos.Character + 1},
+			"after":      after,
+		}, &payload); err != nil {
+			return nil, err
+		}
+
+		for _, node := range payload.UsagesForSymbol.Nodes {
+			if node.UsageRange == nil {
+				continue
+			}
+
+			usages = append(usages, usage{
+				Repository: node.UsageRange.Repository,
+				Revision:   node.UsageRange.Revision,
+				Path:       node.UsageRange.Path,
+				Range:      node.UsageRange.Range,
+				Kind:       node.UsageKind,
+			})
+		}
+
+		pageInfo := payload.UsagesForSymbol.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			break
+		}
+		after = pageInfo.EndCursor
+	}
+
+	return usages, nil
+}
+
+// hover returns the precise hover text of the symbol at the given position, and
+// the range of the hovered symbol.
+func (c *client) hover(ctx context.Context, loc fileLocation, pos position) (string, *sourceRange, error) {
+	var payload struct {
+		Repository *struct {
+			Commit *struct {
+				Blob *struct {
+					LSIF *struct {
+						Hover *struct {
+							Markdown struct{ Text string }
+							Range    *sourceRange
+						}
+					}
+				}
+			}
+		}
+	}
+	if err := c.graphQL(ctx, hoverQuery, map[string]any{
+		"repository": loc.Repository,
+		"revision":   loc.Revision,
+		"path":       loc.Path,
+		"line":       pos.Line,
+		"character":  pos.Character,
+	}, &payload); err != nil {
+		return "", nil, err
+	}
+
+	if r := payload.Repository; r != nil && r.Commit != nil && r.Commit.Blob != nil && r.Commit.Blob.LSIF != nil && r.Commit.Blob.LSIF.Hover != nil {
+		return r.Commit.Blob.LSIF.Hover.Markdown.Text, r.Commit.Blob.LSIF.Hover.Range, nil
+	}
+
+	return "", nil, nil
+}
+
+type symbol struct {
+	Name          string
+	ContainerName *string
+	Kind          string
+	Location      struct {
+		Resource struct{ Path string }
+		Range    *sourceRange
+	}
+}
+
+// documentSymbols returns the symbols defined in the given file.
+func (c *client) documentSymbols(ctx context.Context, loc fileLocation) ([]symbol, error) {
+	var payload struct {
+		Repository *struct {
+			Commit *struct {
+				Blob *struct {
+					Symbols struct{ Nodes []symbol }
+				}
+			}
+		}
+	}
+	if err := c.graphQL(ctx, documentSymbolsQuery, map[string]any{
+		"repository": loc.Repository,
+		"revision":   loc.Revision,
+		"path":       loc.Path,
+	}, &payload); err != nil {
+		return nil, err
+	}
+
+	if r := payload.Repository; r != nil && r.Commit != nil && r.Commit.Blob != nil {
+		return r.Commit.Blob.Symbols.Nodes, nil
+	}
+
+	return nil, nil
+}
+
+// workspaceSymbols returns the symbols matching the given query in a repository.
+func (c *client) workspaceSymbols(ctx context.Context, repository, revision, query string, limit int) ([]symbol, error) {
+	var payload struct {
+		Repository *struct {
+			Commit *struct {
+				Symbols struct{ Nodes []symbol }
+			}
+		}
+	}
+	if err := c.graphQL(ctx, workspaceSymbolsQuery, map[string]any{
+		"repository": repository,
+		"revision":   revision,
+		"query":      query,
+		"first":      limit,
+	}, &payload); err != nil {
+		return nil, err
+	}
+
+	if r := payload.Repository; r != nil && r.Commit != nil {
+		return r.Commit.Symbols.Nodes, nil
+	}
+
+	return nil, nil
+}
+
+// fileContent returns the content of the given file.
+func (c *client) fileContent(ctx context.Context, loc fileLocation) (string, error) {
+	var payload struct {
+		Repository *struct {
+			Commit *struct {
+				Blob *struct{ Content string }
+			}
+		}
+	}
+	if err := c.graphQL(ctx, fileContentQuery, map[string]any{
+		"repository": loc.Repository,
+		"revision":   loc.Revision,
+		"path":       loc.Path,
+	}, &payload); err != nil {
+		return "", err
+	}
+
+	if r := payload.Repository; r != nil && r.Commit != nil && r.Commit.Blob != nil {
+		return r.Commit.Blob.Content, nil
+	}
+
+	return "", errors.Newf("file %s not found in %s@%s", loc.Path, loc.Repository, loc.Revision)
+}
+
+func (c *client) graphQL(ctx context.Context, query string, variables map[string]any, target any) error {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(map[string]any{
+		"query":     query,
+		"variables": variables,
+	}); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/.api/graphql", &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+c.token)
+	req.Header.Set("User-Agent", "lsp-gateway")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("unexpected status %d: %s", resp.StatusCode, b)
+	}
+
+	var payload struct {
+		Data   json.RawMessage
+		Errors []struct{ Message string }
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return err
+	}
+	if len(payload.Errors) > 0 {
+		messages := make([]string, 0, len(payload.Errors))
+		for _, e := range payload.Errors {
+			messages = append(messages, e.Message)
+		}
+		return errors.Newf("graphql: %s", strings.Join(messages, "; "))
+	}
+
+	return json.Unmarshal(payload.Data, target)
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// JSON-RPC error codes used by the language server protocol.
+const (
+	codeParseError       = -32700
+	codeInvalidParams    = -32602
+	codeMethodNotFound   = -32601
+	codeInternalError    = -32603
+	codeRequestCancelled = -32800
+)
+
+// message is a JSON-RPC 2.0 request, notification, or response. Requests and
+// notifications are distinguished by the presence of an identifier.
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  any              `json:"result,omitempty"`
+	Error   *rpcError        `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("jsonrpc2: code %d message: %s", e.Code, e.Message)
+}
+
+// readMessage reads a single message framed by a Content-Length header as
+// described by the base protocol of the language server protocol.
+func readMessage(r *bufio.Reader) (*message, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	contentLength, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Content-Length header")
+	}
+
+	payload := make([]byte, contentLength)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+
+	var m message
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return nil, &rpcError{Code: codeParseError, Message: err.Error()}
+	}
+
+	return &m, nil
+}
+
+// writeMessage writes a single message framed by a Content-Length header.
+func writeMessage(w io.Writer, m *message) error {
+	m.JSONRPC = "2.0"
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(payload)); err != nil {
+		return err
+	}
+	_, err = w.Write(payload)
+	return err
+}
+
+// handlerFunc handles a request or notification. The result of notifications
+// is discarded.
+type handlerFunc func(ctx context.Context, method string, params json.RawMessage) (any, error)
+
+// conn serves requests read from a stream until the stream is closed or the
+// handler requests an exit. Requests are handled concurrently so that a slow
+// request (e.g. references) does not block the editor, and can be cancelled
+// by the client via $/cancelRequest.
+type conn struct {
+	r       *bufio.Reader
+	w       io.Writer
+	handler handlerFunc
+
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func newConn(r io.Reader, w io.Writer, handler handlerFunc) *conn {
+	return &conn{
+		r:       bufio.NewReader(r),
+		w:       w,
+		handler: handler,
+		cancels: map[string]context.CancelFunc{},
+	}
+}
+
+func (c *conn) serve(ctx context.Context) error {
+	defer c.wg.Wait()
+
+	for {
+		m, err := readMessage(c.r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			var rpcErr *rpcError
+			if errors.As(err, &rpcErr) {
+				if err := c.reply(nil, nil, rpcErr); err != nil {
+					return err
+				}
+				continue
+			}
+
+			return err
+		}
+
+		if m.Method == "" {
+			// Responses to server-initiated requests are not used
+			continue
+		}
+		if m.Method == "$/cancelRequest" {
+			c.cancel(m.Params)
+			continue
+		}
+		if m.Method == "exit" {
+			return nil
+		}
+
+		if m.ID == nil {
+			// Notifications are handled in order and never replied to
+			_, _ = c.handler(ctx, m.Method, m.Params)
+			continue
+		}
+
+		requestCtx, cancel := context.WithCancel(ctx)
+		c.mu.Lock()
+		c.cancels[string(*m.ID)] = cancel
+		c.mu.Unlock()
+
+		c.wg.Add(1)
+		go func(m *message) {
+			defer c.wg.Done()
+			defer func() {
+				c.mu.Lock()
+				delete(c.cancels, string(*m.ID))
+				c.mu.Unlock()
+				cancel()
+			}()
+
+			result, err := c.handler(requestCtx, m.Method, m.Params)
+			if err != nil {
+				var rpcErr *rpcError
+				if !errors.As(err, &rpcErr) {
+					rpcErr = &rpcError{Code: codeInternalError, Message: err.Error()}
+					if requestCtx.Err() != nil {
+						rpcErr.Code = codeRequestCancelled
+					}
+				}
+
+				_ = c.reply(m.ID, nil, rpcErr)
+				return
+			}
+
+			_ = c.reply(m.ID, result, nil)
+		}(m)
+	}
+}
+
+func (c *conn) cancel(params json.RawMessage) {
+	var p struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cancel, ok := c.cancels[string(p.ID)]; ok {
+		cancel()
+	}
+}
+
+func (c *conn) reply(id *json.RawMessage, result any, err *rpcError) error {
+	if id == nil {
+		null := json.RawMessage("null")
+		id = &null
+	}
+
+	m := &message{ID: id, Error: err}
+	if err == nil {
+		if result == nil {
+			// A successful response must contain a result, even if empty
+			result = json.RawMessage("null")
+		}
+		m.Result = result
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return writeMessage(c.w, m)
+}
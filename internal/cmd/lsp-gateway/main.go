@@ -0,0 +1,49 @@
+// Command lsp-gateway is a language server that answers code navigation requests
+// for a local checkout using the code intelligence of a Sourcegraph instance.
+// It speaks the language server protocol over stdio, so any editor with an LSP
+// client can navigate across repositories without a dedicated plugin.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/sourcegraph/log"
+)
+
+func main() {
+	repository := flag.String("repo", "", "The name of the repository on the Sourcegraph instance (inferred from the origin remote by default).")
+	revision := flag.String("rev", "", "The revision to answer requests for (the HEAD of the local checkout by default).")
+	cacheDir := flag.String("cache-dir", defaultCacheDir(), "The directory into which files of other repositories are fetched.")
+	flag.Parse()
+
+	// Logs are written to stderr, stdout is reserved for the protocol
+	liblog := log.Init(log.Resource{Name: "lsp-gateway"})
+	logger := log.Scoped("lsp-gateway")
+
+	client, err := newClient()
+	if err != nil {
+		liblog.Sync()
+		logger.Fatal("failed to create client", log.Error(err))
+	}
+
+	s := newServer(client, *repository, *revision, *cacheDir)
+	if err := newConn(os.Stdin, os.Stdout, s.handle).serve(context.Background()); err != nil {
+		liblog.Sync()
+		logger.Fatal("failed to serve", log.Error(err))
+	}
+
+	liblog.Sync()
+	os.Exit(s.exitCode())
+}
+
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, "sourcegraph-lsp-gateway")
+}
@@ -0,0 +1,104 @@
+package main
+
+const usagesQuery = `
+query LSPGatewayUsages($repository: String!, $revision: String, $path: String!, $start: PositionInput!, $end: PositionInput!, $after: String) {
+	usagesForSymbol(range: {repository: $repository, revision: $revision, path: $path, start: $start, end: $end}, first: 100, after: $after) {
+		nodes {
+			usageKind
+			usageRange {
+				repository
+				revision
+				path
+				range {
+					start { line character }
+					end { line character }
+				}
+			}
+		}
+		pageInfo {
+			hasNextPage
+			endCursor
+		}
+	}
+}
+`
+
+const hoverQuery = `
+query LSPGatewayHover($repository: String!, $revision: String!, $path: String!, $line: Int!, $character: Int!) {
+	repository(name: $repository) {
+		commit(rev: $revision) {
+			blob(path: $path) {
+				lsif {
+					hover(line: $line, character: $character) {
+						markdown { text }
+						range {
+							start { line character }
+							end { line character }
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+const documentSymbolsQuery = `
+query LSPGatewayDocumentSymbols($repository: String!, $revision: String!, $path: String!) {
+	repository(name: $repository) {
+		commit(rev: $revision) {
+			blob(path: $path) {
+				symbols(first: 1000) {
+					nodes {
+						name
+						containerName
+						kind
+						location {
+							resource { path }
+							range {
+								start { line character }
+								end { line character }
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+const workspaceSymbolsQuery = `
+query LSPGatewayWorkspaceSymbols($repository: String!, $revision: String!, $query: String!, $first: Int!) {
+	repository(name: $repository) {
+		commit(rev: $revision) {
+			symbols(query: $query, first: $first) {
+				nodes {
+					name
+					containerName
+					kind
+					location {
+						resource { path }
+						range {
+							start { line character }
+							end { line character }
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+const fileContentQuery = `
+query LSPGatewayFileContent($repository: String!, $revision: String!, $path: String!) {
+	repository(name: $repository) {
+		commit(rev: $revision) {
+			blob(path: $path) {
+				content
+			}
+		}
+	}
+}
+`
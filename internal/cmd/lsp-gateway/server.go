@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/sourcegraph/go-lsp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// defaultWorkspaceSymbolLimit is used when the client does not limit the number
+// of workspace symbols.
+const defaultWorkspaceSymbolLimit = 100
+
+type server struct {
+	client     *client
+	repository string
+	revision   string
+	cacheDir   string
+
+	mu        sync.Mutex
+	workspace *workspace
+	shutdown  bool
+}
+
+func newServer(client *client, repository, revision, cacheDir string) *server {
+	return &server{
+		client:     client,
+		repository: repository,
+		revision:   revision,
+		cacheDir:   cacheDir,
+	}
+}
+
+func (s *server) handle(ctx context.Context, method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		var p lsp.InitializeParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.initialize(p)
+
+	case "initialized", "textDocument/didOpen", "textDocument/didChange", "textDocument/didSave", "textDocument/didClose":
+		// Results are based on the indexed revision, not on the editor contents
+		return nil, nil
+
+	case "shutdown":
+		s.mu.Lock()
+		s.shutdown = true
+		s.mu.Unlock()
+		return nil, nil
+	}
+
+	w, err := s.initializedWorkspace()
+	if err != nil {
+		return nil, err
+	}
+
+	switch method {
+	case "textDocument/definition":
+		var p lsp.TextDocumentPositionParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.locations(ctx, w, p, "DEFINITION")
+
+	case "textDocument/references":
+		var p lsp.ReferenceParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		if p.Context.IncludeDeclaration {
+			return s.locations(ctx, w, p.TextDocumentPositionParams, "REFERENCE", "DEFINITION")
+		}
+		return s.locations(ctx, w, p.TextDocumentPositionParams, "REFERENCE")
+
+	case "textDocument/implementation":
+		var p lsp.TextDocumentPositionParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.locations(ctx, w, p, "IMPLEMENTATION")
+
+	case "textDocument/hover":
+		var p lsp.TextDocumentPositionParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.hover(ctx, w, p)
+
+	case "textDocument/documentSymbol":
+		var p lsp.DocumentSymbolParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.documentSymbols(ctx, w, p)
+
+	case "workspace/symbol":
+		var p lsp.WorkspaceSymbolParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.workspaceSymbols(ctx, w, p)
+	}
+
+	return nil, &rpcError{Code: codeMethodNotFound, Message: "method not supported: " + method}
+}
+
+func (s *server) initialize(p lsp.InitializeParams) (any, error) {
+	root, err := uriToPath(p.Root())
+	if err != nil {
+		return nil, err
+	}
+
+	repository := s.repository
+	if repository == "" {
+		remote, err := gitOutput(root, "remote", "get-url", "origin")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to infer repository, pass -repo explicitly")
+		}
+		if repository, err = repositoryFromRemote(remote); err != nil {
+			return nil, err
+		}
+	}
+
+	revision := s.revision
+	if revision == "" {
+		if revision, err = gitOutput(root, "rev-parse", "HEAD"); err != nil {
+			return nil, errors.Wrap(err, "failed to infer revision, pass -rev explicitly")
+		}
+	}
+
+	s.mu.Lock()
+	s.workspace = &workspace{
+		root:       root,
+		repository: repository,
+		revision:   revision,
+		cacheDir:   s.cacheDir,
+		client:     s.client,
+	}
+	s.mu.Unlock()
+
+	syncKind := lsp.TDSKNone
+	return lsp.InitializeResult{
+		Capabilities: lsp.ServerCapabilities{
+			TextDocumentSync:        &lsp.TextDocumentSyncOptionsOrKind{Kind: &syncKind},
+			HoverProvider:           true,
+			DefinitionProvider:      true,
+			ReferencesProvider:      true,
+			ImplementationProvider:  true,
+			DocumentSymbolProvider:  true,
+			WorkspaceSymbolProvider: true,
+		},
+	}, nil
+}
+
+func (s *server) initializedWorkspace() (*workspace, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.workspace == nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "server not initialized"}
+	}
+
+	return s.workspace, nil
+}
+
+func (s *server) locations(ctx context.Context, w *workspace, p lsp.TextDocumentPositionParams, kinds ...string) ([]lsp.Location, error) {
+	loc, err := w.locationForURI(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := s.client.usages(ctx, loc, position{Line: p.Position.Line, Character: p.Position.Character})
+	if err != nil {
+		return nil, err
+	}
+
+	locations := []lsp.Location{}
+	for _, usage := range usages {
+		if !slices.Contains(kinds, usage.Kind) {
+			continue
+		}
+
+		uri, err := w.uriForLocation(ctx, fileLocation{Repository: usage.Repository, Revision: usage.Revision, Path: usage.Path})
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, lsp.Location{URI: uri, Range: toLSPRange(usage.Range)})
+	}
+
+	return locations, nil
+}
+
+func (s *server) hover(ctx context.Context, w *workspace, p lsp.TextDocumentPositionParams) (*lsp.Hover, error) {
+	loc, err := w.locationForURI(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	text, r, err := s.client.hover(ctx, loc, position{Line: p.Position.Line, Character: p.Position.Character})
+	if err != nil || text == "" {
+		return nil, err
+	}
+
+	hover := &lsp.Hover{Contents: []lsp.MarkedString{lsp.RawMarkedString(text)}}
+	if r != nil {
+		lspRange := toLSPRange(*r)
+		hover.Range = &lspRange
+	}
+
+	return hover, nil
+}
+
+func (s *server) documentSymbols(ctx context.Context, w *workspace, p lsp.DocumentSymbolParams) ([]lsp.SymbolInformation, error) {
+	loc, err := w.locationForURI(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols, err := s.client.documentSymbols(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]lsp.SymbolInformation, 0, len(symbols))
+	for _, symbol := range symbols {
+		infos = append(infos, toSymbolInformation(symbol, p.TextDocument.URI))
+	}
+
+	return infos, nil
+}
+
+func (s *server) workspaceSymbols(ctx context.Context, w *workspace, p lsp.WorkspaceSymbolParams) ([]lsp.SymbolInformation, error) {
+	limit := p.Limit
+	if limit <= 0 {
+		limit = defaultWorkspaceSymbolLimit
+	}
+
+	symbols, err := s.client.workspaceSymbols(ctx, w.repository, w.revision, p.Query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]lsp.SymbolInformation, 0, len(symbols))
+	for _, symbol := range symbols {
+		uri, err := w.uriForLocation(ctx, fileLocation{Repository: w.repository, Revision: w.revision, Path: symbol.Location.Resource.Path})
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, toSymbolInformation(symbol, uri))
+	}
+
+	return infos, nil
+}
+
+func (s *server) exitCode() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The protocol requires a non-zero exit code if exit was not preceded by shutdown
+	if s.shutdown {
+		return 0
+	}
+	return 1
+}
+
+func unmarshalParams(params json.RawMessage, v any) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	return nil
+}
+
+func toLSPRange(r sourceRange) lsp.Range {
+	return lsp.Range{
+		Start: lsp.Position{Line: r.Start.Line, Character: r.Start.Character},
+		End:   lsp.Position{Line: r.End.Line, Character: r.End.Character},
+	}
+}
+
+// symbolKinds are the values of the SymbolKind GraphQL enum, which shares its
+// order with the LSP symbol kinds (UNKNOWN has no LSP equivalent).
+var symbolKinds = []string{
+	"UNKNOWN", "FILE", "MODULE", "NAMESPACE", "PACKAGE", "CLASS", "METHOD", "PROPERTY", "FIELD",
+	"CONSTRUCTOR", "ENUM", "INTERFACE", "FUNCTION", "VARIABLE", "CONSTANT", "STRING", "NUMBER",
+	"BOOLEAN", "ARRAY", "OBJECT", "KEY", "NULL", "ENUMMEMBER", "STRUCT", "EVENT", "OPERATOR",
+	"TYPEPARAMETER",
+}
+
+func toSymbolInformation(symbol symbol, uri lsp.DocumentURI) lsp.SymbolInformation {
+	kind := lsp.SKVariable
+	for i, name := range symbolKinds {
+		if name == symbol.Kind && i > 0 {
+			kind = lsp.SymbolKind(i)
+		}
+	}
+
+	info := lsp.SymbolInformation{
+		Name:     symbol.Name,
+		Kind:     kind,
+		Location: lsp.Location{URI: uri},
+	}
+	if symbol.ContainerName != nil {
+		info.ContainerName = *symbol.ContainerName
+	}
+	if symbol.Location.Range != nil {
+		info.Location.Range = toLSPRange(*symbol.Location.Range)
+	}
+
+	return info
+}
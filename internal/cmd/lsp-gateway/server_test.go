@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/go-lsp"
+)
+
+func TestServer(t *testing.T) {
+	root := t.TempDir()
+	cacheDir := t.TempDir()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string
+			Variables map[string]any
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+
+		switch {
+		case strings.Contains(req.Query, "LSPGatewayUsages"):
+			if req.Variables["repository"] != "github.com/example/app" || req.Variables["revision"] != "deadbeef" || req.Variables["path"] != "cmd/main.go" {
+				t.Errorf("unexpected variables %v", req.Variables)
+			}
+			_, _ = io.WriteString(w, `{"data": {"usagesForSymbol": {"nodes": [
+				{"usageKind": "DEFINITION", "usageRange": {"repository": "github.com/example/lib", "revision": "cafebabe", "path": "lib.go", "range": {"start": {"line": 3, "character": 5}, "end": {"line": 3, "character": 8}}}},
+				{"usageKind": "REFERENCE", "usageRange": {"repository": "github.com/example/app", "revision": "deadbeef", "path": "cmd/main.go", "range": {"start": {"line": 10, "character": 6}, "end": {"line": 10, "character": 9}}}}
+			], "pageInfo": {"hasNextPage": false}}}}`)
+
+		case strings.Contains(req.Query, "LSPGatewayFileContent"):
+			_, _ = io.WriteString(w, `{"data": {"repository": {"commit": {"blob": {"content": "package lib\n"}}}}}`)
+
+		default:
+			t.Errorf("unexpected query %q", req.Query)
+		}
+	}))
+	defer api.Close()
+
+	s := newServer(&client{endpoint: api.URL, client: api.Client()}, "github.com/example/app", "deadbeef", cacheDir)
+
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+	done := make(chan error, 1)
+	go func() { done <- newConn(serverR, serverW, s.handle).serve(context.Background()) }()
+
+	responses := bufio.NewReader(clientR)
+	call := func(id int, method string, params any) *message {
+		raw, err := json.Marshal(params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rawID := json.RawMessage(mustMarshal(t, id))
+		if err := writeMessage(clientW, &message{ID: &rawID, Method: method, Params: raw}); err != nil {
+			t.Fatal(err)
+		}
+
+		response, err := readMessage(responses)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if response.Error != nil {
+			t.Fatalf("unexpected error for %s: %s", method, response.Error.Message)
+		}
+		return response
+	}
+
+	call(1, "initialize", lsp.InitializeParams{RootURI: pathToURI(root)})
+
+	var definitions []lsp.Location
+	decodeResult(t, call(2, "textDocument/definition", lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: pathToURI(filepath.Join(root, "cmd", "main.go"))},
+		Position:     lsp.Position{Line: 10, Character: 7},
+	}), &definitions)
+
+	cachedFile := filepath.Join(cacheDir, "github.com", "example", "lib", "-", "cafebabe", "lib.go")
+	expectedDefinitions := []lsp.Location{{
+		URI:   pathToURI(cachedFile),
+		Range: lsp.Range{Start: lsp.Position{Line: 3, Character: 5}, End: lsp.Position{Line: 3, Character: 8}},
+	}}
+	if diff := cmp.Diff(expectedDefinitions, definitions); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+	if content, err := os.ReadFile(cachedFile); err != nil || string(content) != "package lib\n" {
+		t.Errorf("unexpected cached file content %q (err=%v)", content, err)
+	}
+
+	var references []lsp.Location
+	decodeResult(t, call(3, "textDocument/references", lsp.ReferenceParams{
+		TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{URI: pathToURI(filepath.Join(root, "cmd", "main.go"))},
+			Position:     lsp.Position{Line: 10, Character: 7},
+		},
+	}), &references)
+
+	expectedReferences := []lsp.Location{{
+		URI:   pathToURI(filepath.Join(root, "cmd", "main.go")),
+		Range: lsp.Range{Start: lsp.Position{Line: 10, Character: 6}, End: lsp.Position{Line: 10, Character: 9}},
+	}}
+	if diff := cmp.Diff(expectedReferences, references); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+
+	call(4, "shutdown", nil)
+	if err := writeMessage(clientW, &message{Method: "exit"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if code := s.exitCode(); code != 0 {
+		t.Errorf("unexpected exit code %d", code)
+	}
+}
+
+func TestLocationForURI(t *testing.T) {
+	w := &workspace{root: "/src/app", repository: "github.com/example/app", revision: "deadbeef", cacheDir: "/cache"}
+
+	testCases := map[lsp.DocumentURI]fileLocation{
+		"file:///src/app/cmd/main.go":                            {Repository: "github.com/example/app", Revision: "deadbeef", Path: "cmd/main.go"},
+		"file:///cache/github.com/example/lib/-/cafebabe/x/y.go": {Repository: "github.com/example/lib", Revision: "cafebabe", Path: "x/y.go"},
+	}
+	for uri, expected := range testCases {
+		loc, err := w.locationForURI(uri)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %s", uri, err)
+		}
+		if diff := cmp.Diff(expected, loc); diff != "" {
+			t.Errorf("unexpected location for %s (-want +got):\n%s", uri, diff)
+		}
+	}
+
+	if _, err := w.locationForURI("file:///src/other/main.go"); err == nil {
+		t.Error("expected error for file outside of the workspace")
+	}
+}
+
+func TestRepositoryFromRemote(t *testing.T) {
+	testCases := map[string]string{
+		"git@github.com:example/app.git":        "github.com/example/app",
+		"https://github.com/example/app":        "github.com/example/app",
+		"https://user@gitlab.com/a/b/c.git":     "gitlab.com/a/b/c",
+		"ssh://git@example.com:2222/team/x.git": "example.com/team/x",
+	}
+	for remote, expected := range testCases {
+		repository, err := repositoryFromRemote(remote)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %s", remote, err)
+		}
+		if repository != expected {
+			t.Errorf("unexpected repository for %s: want %q, got %q", remote, expected, repository)
+		}
+	}
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func decodeResult(t *testing.T, m *message, v any) {
+	if err := json.Unmarshal(mustMarshal(t, m.Result), v); err != nil {
+		t.Fatal(err)
+	}
+}
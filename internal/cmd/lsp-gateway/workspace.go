@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sourcegraph/go-lsp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// fileLocation identifies a file at a revision of a repository on the
+// Sourcegraph instance.
+type fileLocation struct {
+	Repository string
+	Revision   string
+	Path       string
+}
+
+// workspace maps between the files an editor knows about and files on the
+// Sourcegraph instance. Files under the root of the local checkout map to the
+// configured repository and revision. Files of other repositories are fetched
+// into the cache directory on first use so that any editor can open them, and
+// map back to their repository and revision through their cache path.
+type workspace struct {
+	root       string
+	repository string
+	revision   string
+	cacheDir   string
+	client     *client
+}
+
+// cacheSeparator separates the repository name from the revision and path in
+// cache paths. Sourcegraph uses the same separator in URLs, so repository names
+// never contain it.
+const cacheSeparator = "/-/"
+
+func (w *workspace) locationForURI(uri lsp.DocumentURI) (fileLocation, error) {
+	filename, err := uriToPath(uri)
+	if err != nil {
+		return fileLocation{}, err
+	}
+
+	if rel, ok := relativePath(w.cacheDir, filename); ok {
+		repository, rest, ok := strings.Cut(rel, cacheSeparator)
+		if ok {
+			if revision, filePath, ok := strings.Cut(rest, "/"); ok {
+				return fileLocation{Repository: repository, Revision: revision, Path: filePath}, nil
+			}
+		}
+
+		return fileLocation{}, errors.Newf("unexpected file %q in cache directory", filename)
+	}
+
+	if rel, ok := relativePath(w.root, filename); ok {
+		return fileLocation{Repository: w.repository, Revision: w.revision, Path: rel}, nil
+	}
+
+	return fileLocation{}, errors.Newf("file %q is outside of the workspace", filename)
+}
+
+func (w *workspace) uriForLocation(ctx context.Context, loc fileLocation) (lsp.DocumentURI, error) {
+	if loc.Repository == w.repository {
+		return pathToURI(filepath.Join(w.root, filepath.FromSlash(loc.Path))), nil
+	}
+
+	filename := filepath.Join(w.cacheDir, filepath.FromSlash(loc.Repository+cacheSeparator+loc.Revision), filepath.FromSlash(loc.Path))
+	if _, err := os.Stat(filename); err == nil {
+		return pathToURI(filename), nil
+	}
+
+	content, err := w.client.fileContent(ctx, loc)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return "", err
+	}
+	// Files are written read-only as edits would not be reflected on the instance
+	if err := os.WriteFile(filename, []byte(content), 0o444); err != nil {
+		return "", err
+	}
+
+	return pathToURI(filename), nil
+}
+
+// relativePath returns the slash-separated path of filename relative to dir, if
+// filename is within dir.
+func relativePath(dir, filename string) (string, bool) {
+	if dir == "" {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(dir, filename)
+	if err != nil || rel == "." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || rel == ".." {
+		return "", false
+	}
+
+	return filepath.ToSlash(rel), true
+}
+
+func uriToPath(uri lsp.DocumentURI) (string, error) {
+	u, err := url.Parse(string(uri))
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", errors.Newf("unsupported URI scheme %q", u.Scheme)
+	}
+
+	return filepath.FromSlash(u.Path), nil
+}
+
+func pathToURI(filename string) lsp.DocumentURI {
+	return lsp.DocumentURI((&url.URL{Scheme: "file", Path: filepath.ToSlash(filename)}).String())
+}
+
+// gitOutput runs a git command in the given directory and returns its trimmed output.
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "git %s", strings.Join(args, " "))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+var scpLikeRemotePattern = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// repositoryFromRemote returns the name Sourcegraph gives by default to the
+// repository cloned from the given remote URL, e.g. github.com/org/repo for
+// both git@github.com:org/repo.git and https://github.com/org/repo.
+func repositoryFromRemote(remote string) (string, error) {
+	var host, repoPath string
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		host, repoPath = u.Hostname(), u.Path
+	} else if match := scpLikeRemotePattern.FindStringSubmatch(remote); match != nil {
+		host, repoPath = match[1], match[2]
+	} else {
+		return "", errors.Newf("unsupported remote URL %q", remote)
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(path.Clean("/"+repoPath), "/"), ".git")
+	if repoPath == "" {
+		return "", errors.Newf("unsupported remote URL %q", remote)
+	}
+
+	return host + "/" + repoPath, nil
+}
@@ -70,6 +70,9 @@ var schemeToExternalService = map[string]string{
 	dependencies.PythonPackagesScheme: extsvc.KindPythonPackages,
 	dependencies.RustPackagesScheme:   extsvc.KindRustPackages,
 	dependencies.RubyPackagesScheme:   extsvc.KindRubyPackages,
+	dependencies.DotNetPackagesScheme: extsvc.KindDotNetPackages,
+	dependencies.PHPPackagesScheme:    extsvc.KindPHPPackages,
+	dependencies.ElixirPackagesScheme: extsvc.KindElixirPackages,
 }
 
 func (h *dependencySyncSchedulerHandler) Handle(ctx context.Context, logger log.Logger, job dependencySyncingJob) error {
@@ -19,6 +19,9 @@ func InferRepositoryAndRevision(pkg dependencies.MinimialVersionedPackageRepo) (
 		inferRustRepositoryAndRevision,
 		inferPythonRepositoryAndRevision,
 		inferRubyRepositoryAndRevision,
+		inferDotNetRepositoryAndRevision,
+		inferPHPRepositoryAndRevision,
+		inferElixirRepositoryAndRevision,
 	} {
 		if repoName, gitTagOrCommit, ok := fn(pkg); ok {
 			return repoName, gitTagOrCommit, true
@@ -99,3 +102,36 @@ func inferRubyRepositoryAndRevision(pkg dependencies.MinimialVersionedPackageRep
 
 	return rubyPkg.RepoName(), pkg.Version, true
 }
+
+func inferDotNetRepositoryAndRevision(pkg dependencies.MinimialVersionedPackageRepo) (api.RepoName, string, bool) {
+	if pkg.Scheme != dependencies.DotNetPackagesScheme {
+		return "", "", false
+	}
+
+	dotnetPkg := reposource.NewDotNetVersionedPackage(pkg.Name, pkg.Version)
+	return dotnetPkg.RepoName(), dotnetPkg.GitTagFromVersion(), true
+}
+
+func inferPHPRepositoryAndRevision(pkg dependencies.MinimialVersionedPackageRepo) (api.RepoName, string, bool) {
+	if pkg.Scheme != dependencies.PHPPackagesScheme {
+		return "", "", false
+	}
+
+	logger := log.Scoped("inferPHPRepositoryAndRevision")
+	phpPkg, err := reposource.ParsePHPPackageFromName(pkg.Name)
+	if err != nil {
+		logger.Error("invalid PHP package name in database", log.Error(err))
+		return "", "", false
+	}
+	phpPkg.Version = pkg.Version
+	return phpPkg.RepoName(), phpPkg.GitTagFromVersion(), true
+}
+
+func inferElixirRepositoryAndRevision(pkg dependencies.MinimialVersionedPackageRepo) (api.RepoName, string, bool) {
+	if pkg.Scheme != dependencies.ElixirPackagesScheme {
+		return "", "", false
+	}
+
+	elixirPkg := reposource.NewElixirVersionedPackage(pkg.Name, pkg.Version)
+	return elixirPkg.RepoName(), elixirPkg.GitTagFromVersion(), true
+}
@@ -58,6 +58,33 @@ func TestInferRepositoryAndRevision(t *testing.T) {
 				repoName: "npm/myscope/mypackage",
 				revision: "v1.0.0",
 			},
+			{
+				pkg: dependencies.MinimialVersionedPackageRepo{
+					Scheme:  "scip-dotnet",
+					Name:    "Newtonsoft.Json",
+					Version: "13.0.3",
+				},
+				repoName: "nuget/Newtonsoft.Json",
+				revision: "v13.0.3",
+			},
+			{
+				pkg: dependencies.MinimialVersionedPackageRepo{
+					Scheme:  "scip-php",
+					Name:    "monolog/monolog",
+					Version: "3.5.0",
+				},
+				repoName: "packagist/monolog/monolog",
+				revision: "v3.5.0",
+			},
+			{
+				pkg: dependencies.MinimialVersionedPackageRepo{
+					Scheme:  "hex",
+					Name:    "phoenix",
+					Version: "1.7.10",
+				},
+				repoName: "hex/phoenix",
+				revision: "v1.7.10",
+			},
 		}
 
 		for _, testCase := range testCases {
@@ -74,4 +101,15 @@ func TestInferRepositoryAndRevision(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("invalid PHP package name", func(t *testing.T) {
+		pkg := dependencies.MinimialVersionedPackageRepo{
+			Scheme:  "scip-php",
+			Name:    "monolog",
+			Version: "3.5.0",
+		}
+		if _, _, ok := InferRepositoryAndRevision(pkg); ok {
+			t.Fatalf("expected repository not to be inferred")
+		}
+	})
 }
@@ -0,0 +1,71 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestCppGenerator(t *testing.T) {
+	t.Run("no indexer configured", func(t *testing.T) {
+		testGenerators(t,
+			generatorTestCase{
+				description: "cpp without configured indexer",
+				repositoryContents: map[string]string{
+					"CMakeLists.txt":        "",
+					"compile_commands.json": "",
+				},
+			},
+		)
+	})
+
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			CodeIntelAutoIndexingIndexerMap: map[string]string{"cpp": "example/scip-clang"},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "cpp compile_commands.json",
+			repositoryContents: map[string]string{
+				"CMakeLists.txt":                   "",
+				"compile_commands.json":            "",
+				"tools/lint/compile_commands.json": "",
+			},
+		},
+		generatorTestCase{
+			description: "cpp cmake",
+			repositoryContents: map[string]string{
+				"CMakeLists.txt":               "",
+				"src/CMakeLists.txt":           "",
+				"src/lib/CMakeLists.txt":       "",
+				"tools/codegen/CMakeLists.txt": "",
+			},
+		},
+		generatorTestCase{
+			description: "cpp cmake without root project",
+			repositoryContents: map[string]string{
+				"server/CMakeLists.txt":     "",
+				"server/net/CMakeLists.txt": "",
+				"client/CMakeLists.txt":     "",
+			},
+		},
+		generatorTestCase{
+			description: "cpp bazel with compile commands extractor",
+			repositoryContents: map[string]string{
+				"MODULE.bazel": `bazel_dep(name = "hedron_compile_commands", dev_dependency = True)`,
+				"src/BUILD":    "",
+			},
+		},
+		generatorTestCase{
+			description: "cpp bazel without compile commands extractor",
+			repositoryContents: map[string]string{
+				"WORKSPACE": `workspace(name = "example")`,
+				"src/BUILD": "",
+			},
+		},
+	)
+}
@@ -52,20 +52,26 @@ func DefaultIndexerForLang(language string) (string, bool) {
 
 func (api indexesAPI) LuaAPI() map[string]lua.LGFunction {
 	return map[string]lua.LGFunction{
-		"get": util.WrapLuaFunction(func(state *lua.LState) error {
-			language := state.CheckString(1)
+		"get": util.WrapLuaFunction(getIndexer),
+		// lookup behaves like get, but returns nil and an error message instead of raising
+		// when no indexer is registered for the language. This lets recognizers for languages
+		// without a default indexer stay inert until one is set in codeIntelAutoIndexing.indexerMap.
+		"lookup": util.WrapSoftFailingLuaFunction(getIndexer),
+	}
+}
 
-			if indexer, ok := conf.SiteConfig().CodeIntelAutoIndexingIndexerMap[language]; ok {
-				state.Push(luar.New(state, indexer))
-				return nil
-			}
+func getIndexer(state *lua.LState) error {
+	language := state.CheckString(1)
 
-			if indexer, ok := DefaultIndexerForLang(language); ok {
-				state.Push(luar.New(state, indexer))
-				return nil
-			}
+	if indexer, ok := conf.SiteConfig().CodeIntelAutoIndexingIndexerMap[language]; ok {
+		state.Push(luar.New(state, indexer))
+		return nil
+	}
 
-			return errors.Newf("no indexer is registered for %q", language)
-		}),
+	if indexer, ok := DefaultIndexerForLang(language); ok {
+		state.Push(luar.New(state, indexer))
+		return nil
 	}
+
+	return errors.Newf("no indexer is registered for %q", language)
 }
@@ -0,0 +1,155 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+local util = require "sg.autoindex.util"
+
+-- There is no default scip-clang image, as the indexer must run in an image that
+-- also contains the toolchain and system headers the project is built with. Jobs
+-- are only inferred once an image is set for "cpp" in codeIntelAutoIndexing.indexerMap.
+local indexer = require("sg.autoindex.indexes").lookup "cpp"
+local outfile = "index.scip"
+
+local build_dir = "build"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "third_party",
+  pattern.new_path_segment "vendor",
+})
+
+local bazel_workspace_files = { "MODULE.bazel", "WORKSPACE", "WORKSPACE.bazel" }
+
+local scip_clang_args = function(compdb_path)
+  return { "scip-clang", "--compdb-path=" .. compdb_path }
+end
+
+-- Returns the directories of the given CMakeLists.txt paths which are not nested
+-- within another CMake project. Nested projects are typically pulled in by their
+-- parent via add_subdirectory, so they are indexed as part of the parent.
+local cmake_project_roots = function(paths)
+  local roots = {}
+  for i = 1, #paths do
+    local root = path.dirname(paths[i])
+
+    local nested = false
+    if root ~= "" then
+      for _, ancestor in ipairs(path.ancestors(root)) do
+        if util.contains(paths, path.join(ancestor, "CMakeLists.txt")) then
+          nested = true
+          break
+        end
+      end
+    end
+
+    if not nested then
+      table.insert(roots, root)
+    end
+  end
+
+  return roots
+end
+
+local compdb_recognizer = recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "compile_commands.json",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when a compilation database is checked into the repository
+  generate = function(_, paths)
+    local jobs = {}
+    for i = 1, #paths do
+      table.insert(jobs, {
+        steps = {},
+        root = path.dirname(paths[i]),
+        indexer = indexer,
+        indexer_args = scip_clang_args "compile_commands.json",
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
+
+local cmake_recognizer = recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "CMakeLists.txt",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when CMakeLists.txt files exist. Each project is configured first so
+  -- that CMake emits a compilation database for scip-clang to consume.
+  generate = function(_, paths)
+    local jobs = {}
+    for _, root in ipairs(cmake_project_roots(paths)) do
+      table.insert(jobs, {
+        steps = {
+          {
+            root = root,
+            image = indexer,
+            commands = { "cmake -B " .. build_dir .. " -DCMAKE_EXPORT_COMPILE_COMMANDS=ON" },
+          },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = scip_clang_args(path.join(build_dir, "compile_commands.json")),
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
+
+local bazel_workspace_patterns = {}
+for _, workspace_file in ipairs(bazel_workspace_files) do
+  table.insert(bazel_workspace_patterns, pattern.new_path_literal(workspace_file))
+end
+
+local bazel_recognizer = recognizer.new_path_recognizer {
+  patterns = bazel_workspace_patterns,
+  patterns_for_content = bazel_workspace_patterns,
+
+  -- Invoked when a Bazel workspace exists at the root of the repository. Bazel does
+  -- not emit a compilation database by itself, so we only infer a job when the
+  -- workspace depends on hedron_compile_commands, which can extract one.
+  generate = function(_, paths, contents_by_path)
+    for _, workspace_file in ipairs(bazel_workspace_files) do
+      local contents = contents_by_path[workspace_file]
+      if contents and string.find(contents, "hedron_compile_commands", 1, true) then
+        return {
+          steps = {
+            {
+              root = "",
+              image = indexer,
+              commands = { "bazel run @hedron_compile_commands//:refresh_all" },
+            },
+          },
+          root = "",
+          indexer = indexer,
+          indexer_args = scip_clang_args "compile_commands.json",
+          outfile = outfile,
+        }
+      end
+    end
+
+    return {}
+  end,
+}
+
+-- A compilation database checked into the repository is the most precise description
+-- of how the code is built, so it is preferred over generating one. Otherwise we fall
+-- back to configuring the CMake project, and then to extracting one from Bazel.
+local cpp_recognizer = recognizer.new_fallback_recognizer {
+  compdb_recognizer,
+  cmake_recognizer,
+  bazel_recognizer,
+}
+
+if indexer == nil then
+  return recognizer.new_fallback_recognizer {}
+end
+
+return cpp_recognizer
@@ -1,6 +1,7 @@
 local config = require("sg.autoindex.config").new({})
 
 for _, name in ipairs({
+  "cpp",
   "go",
   "java",
   "python",
@@ -100,6 +100,12 @@ func (s *store) DeleteLsifDataByUploadIds(ctx context.Context, bundleIDs ...int)
 		if err := tx.db.Exec(ctx, sqlf.Sprintf(deleteSCIPSymbolsSchemaVersionsQuery, pq.Array(bundleIDs))); err != nil {
 			return err
 		}
+		if err := tx.db.Exec(ctx, sqlf.Sprintf(deleteUnusedSymbolsQuery, pq.Array(bundleIDs))); err != nil {
+			return err
+		}
+		if err := tx.db.Exec(ctx, sqlf.Sprintf(deleteUnusedSymbolReportsQuery, pq.Array(bundleIDs))); err != nil {
+			return err
+		}
 
 		if err := s.db.Exec(ctx, sqlf.Sprintf(deleteLastReconcileQuery, pq.Array(bundleIDs))); err != nil {
 			return err
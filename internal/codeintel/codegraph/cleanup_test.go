@@ -11,6 +11,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/scip/bindings/go/scip"
 
 	codeintelshared "github.com/sourcegraph/sourcegraph/internal/codeintel/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -219,6 +220,81 @@ func TestDeleteUnreferencedDocuments(t *testing.T) {
 	}
 }
 
+func TestDeleteUnreferencedDocumentsSharedBetweenUploads(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(t))
+	internalStore := basestore.NewWithHandle(codeIntelDB.Handle())
+	store := New(observation.TestContextTB(t), codeIntelDB)
+	ctx := context.Background()
+
+	document := func(symbol string) *scip.Document {
+		return &scip.Document{Symbols: []*scip.SymbolInformation{{Symbol: symbol}}}
+	}
+
+	// Uploads 1 and 2 share the payload of util.go; main.go differs between them
+	insertTestDocuments(t, store, 1, map[string]*scip.Document{
+		"util.go": document("util"),
+		"main.go": document("main v1"),
+	})
+	insertTestDocuments(t, store, 2, map[string]*scip.Document{
+		"util.go": document("util"),
+		"main.go": document("main v2"),
+	})
+
+	countDocuments := func() int {
+		count, _, err := basestore.ScanFirstInt(internalStore.Query(ctx, sqlf.Sprintf(`SELECT COUNT(*) FROM codeintel_scip_documents`)))
+		if err != nil {
+			t.Fatalf("unexpected error counting documents: %s", err)
+		}
+		return count
+	}
+	countReadableDocuments := func(uploadID int) int {
+		count, _, err := basestore.ScanFirstInt(internalStore.Query(ctx, sqlf.Sprintf(`
+			SELECT COUNT(*)
+			FROM codeintel_scip_document_lookup sdl
+			JOIN codeintel_scip_documents sd ON sd.id = sdl.document_id
+			WHERE sdl.upload_id = %s
+		`, uploadID)))
+		if err != nil {
+			t.Fatalf("unexpected error counting readable documents: %s", err)
+		}
+		return count
+	}
+	deleteUnreferencedDocuments := func() int {
+		_, count, err := store.DeleteUnreferencedDocuments(ctx, 100, time.Minute, time.Now().Add(time.Minute*5))
+		if err != nil {
+			t.Fatalf("unexpected error deleting unreferenced documents: %s", err)
+		}
+		return count
+	}
+
+	if count := countDocuments(); count != 3 {
+		t.Fatalf("unexpected number of documents. want=%d have=%d", 3, count)
+	}
+
+	// Only the document referenced exclusively by upload 1 is reclaimed
+	if err := store.DeleteLsifDataByUploadIds(ctx, 1); err != nil {
+		t.Fatalf("unexpected error clearing bundle data: %s", err)
+	}
+	if count := deleteUnreferencedDocuments(); count != 1 {
+		t.Fatalf("unexpected number of unreferenced documents deleted. want=%d have=%d", 1, count)
+	}
+	if count := countReadableDocuments(2); count != 2 {
+		t.Fatalf("unexpected number of readable documents for upload 2. want=%d have=%d", 2, count)
+	}
+
+	// The shared document is reclaimed once its last reference is removed
+	if err := store.DeleteLsifDataByUploadIds(ctx, 2); err != nil {
+		t.Fatalf("unexpected error clearing bundle data: %s", err)
+	}
+	if count := deleteUnreferencedDocuments(); count != 2 {
+		t.Fatalf("unexpected number of unreferenced documents deleted. want=%d have=%d", 2, count)
+	}
+	if count := countDocuments(); count != 0 {
+		t.Fatalf("unexpected number of documents. want=%d have=%d", 0, count)
+	}
+}
+
 func TestIDsWithMeta(t *testing.T) {
 	logger := logtest.Scoped(t)
 	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(t))
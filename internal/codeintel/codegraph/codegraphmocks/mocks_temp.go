@@ -32,12 +32,18 @@ type MockDataStore struct {
 	// object controlling the behavior of the method
 	// DeleteUnreferencedDocuments.
 	DeleteUnreferencedDocumentsFunc *DataStoreDeleteUnreferencedDocumentsFunc
+	// GetUnusedSymbolReportFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUnusedSymbolReport.
+	GetUnusedSymbolReportFunc *DataStoreGetUnusedSymbolReportFunc
 	// IDsWithMetaFunc is an instance of a mock function object controlling
 	// the behavior of the method IDsWithMeta.
 	IDsWithMetaFunc *DataStoreIDsWithMetaFunc
 	// InsertMetadataFunc is an instance of a mock function object
 	// controlling the behavior of the method InsertMetadata.
 	InsertMetadataFunc *DataStoreInsertMetadataFunc
+	// InsertUnusedSymbolReportFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertUnusedSymbolReport.
+	InsertUnusedSymbolReportFunc *DataStoreInsertUnusedSymbolReportFunc
 	// NewPreciseSCIPWriterFunc is an instance of a mock function object
 	// controlling the behavior of the method NewPreciseSCIPWriter.
 	NewPreciseSCIPWriterFunc *DataStoreNewPreciseSCIPWriterFunc
@@ -51,6 +57,16 @@ type MockDataStore struct {
 	// object controlling the behavior of the method
 	// ReconcileCandidatesWithTime.
 	ReconcileCandidatesWithTimeFunc *DataStoreReconcileCandidatesWithTimeFunc
+	// ReferencedSymbolNamesFunc is an instance of a mock function object
+	// controlling the behavior of the method ReferencedSymbolNames.
+	ReferencedSymbolNamesFunc *DataStoreReferencedSymbolNamesFunc
+	// ScanDocumentsFunc is an instance of a mock function object
+	// controlling the behavior of the method ScanDocuments.
+	ScanDocumentsFunc *DataStoreScanDocumentsFunc
+	// UnusedSymbolReportCandidatesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UnusedSymbolReportCandidates.
+	UnusedSymbolReportCandidatesFunc *DataStoreUnusedSymbolReportCandidatesFunc
 	// WithTransactionFunc is an instance of a mock function object
 	// controlling the behavior of the method WithTransaction.
 	WithTransactionFunc *DataStoreWithTransactionFunc
@@ -75,6 +91,11 @@ func NewMockDataStore() *MockDataStore {
 				return
 			},
 		},
+		GetUnusedSymbolReportFunc: &DataStoreGetUnusedSymbolReportFunc{
+			defaultHook: func(context.Context, int, int, int) (r0 codegraph.UnusedSymbolReport, r1 bool, r2 error) {
+				return
+			},
+		},
 		IDsWithMetaFunc: &DataStoreIDsWithMetaFunc{
 			defaultHook: func(context.Context, []int) (r0 []int, r1 error) {
 				return
@@ -85,6 +106,11 @@ func NewMockDataStore() *MockDataStore {
 				return
 			},
 		},
+		InsertUnusedSymbolReportFunc: &DataStoreInsertUnusedSymbolReportFunc{
+			defaultHook: func(context.Context, int, []codegraph.UnusedSymbol, time.Time) (r0 error) {
+				return
+			},
+		},
 		NewPreciseSCIPWriterFunc: &DataStoreNewPreciseSCIPWriterFunc{
 			defaultHook: func(context.Context, int) (r0 codegraph.SCIPWriter, r1 error) {
 				return
@@ -105,6 +131,21 @@ func NewMockDataStore() *MockDataStore {
 				return
 			},
 		},
+		ReferencedSymbolNamesFunc: &DataStoreReferencedSymbolNamesFunc{
+			defaultHook: func(context.Context, []int, []string) (r0 []string, r1 error) {
+				return
+			},
+		},
+		ScanDocumentsFunc: &DataStoreScanDocumentsFunc{
+			defaultHook: func(context.Context, int, func(path string, document *scip.Document) error) (r0 error) {
+				return
+			},
+		},
+		UnusedSymbolReportCandidatesFunc: &DataStoreUnusedSymbolReportCandidatesFunc{
+			defaultHook: func(context.Context, int, time.Duration, time.Time) (r0 []int, r1 error) {
+				return
+			},
+		},
 		WithTransactionFunc: &DataStoreWithTransactionFunc{
 			defaultHook: func(context.Context, func(s codegraph.DataStore) error) (r0 error) {
 				return
@@ -132,6 +173,11 @@ func NewStrictMockDataStore() *MockDataStore {
 				panic("unexpected invocation of MockDataStore.DeleteUnreferencedDocuments")
 			},
 		},
+		GetUnusedSymbolReportFunc: &DataStoreGetUnusedSymbolReportFunc{
+			defaultHook: func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error) {
+				panic("unexpected invocation of MockDataStore.GetUnusedSymbolReport")
+			},
+		},
 		IDsWithMetaFunc: &DataStoreIDsWithMetaFunc{
 			defaultHook: func(context.Context, []int) ([]int, error) {
 				panic("unexpected invocation of MockDataStore.IDsWithMeta")
@@ -142,6 +188,11 @@ func NewStrictMockDataStore() *MockDataStore {
 				panic("unexpected invocation of MockDataStore.InsertMetadata")
 			},
 		},
+		InsertUnusedSymbolReportFunc: &DataStoreInsertUnusedSymbolReportFunc{
+			defaultHook: func(context.Context, int, []codegraph.UnusedSymbol, time.Time) error {
+				panic("unexpected invocation of MockDataStore.InsertUnusedSymbolReport")
+			},
+		},
 		NewPreciseSCIPWriterFunc: &DataStoreNewPreciseSCIPWriterFunc{
 			defaultHook: func(context.Context, int) (codegraph.SCIPWriter, error) {
 				panic("unexpected invocation of MockDataStore.NewPreciseSCIPWriter")
@@ -162,6 +213,21 @@ func NewStrictMockDataStore() *MockDataStore {
 				panic("unexpected invocation of MockDataStore.ReconcileCandidatesWithTime")
 			},
 		},
+		ReferencedSymbolNamesFunc: &DataStoreReferencedSymbolNamesFunc{
+			defaultHook: func(context.Context, []int, []string) ([]string, error) {
+				panic("unexpected invocation of MockDataStore.ReferencedSymbolNames")
+			},
+		},
+		ScanDocumentsFunc: &DataStoreScanDocumentsFunc{
+			defaultHook: func(context.Context, int, func(path string, document *scip.Document) error) error {
+				panic("unexpected invocation of MockDataStore.ScanDocuments")
+			},
+		},
+		UnusedSymbolReportCandidatesFunc: &DataStoreUnusedSymbolReportCandidatesFunc{
+			defaultHook: func(context.Context, int, time.Duration, time.Time) ([]int, error) {
+				panic("unexpected invocation of MockDataStore.UnusedSymbolReportCandidates")
+			},
+		},
 		WithTransactionFunc: &DataStoreWithTransactionFunc{
 			defaultHook: func(context.Context, func(s codegraph.DataStore) error) error {
 				panic("unexpected invocation of MockDataStore.WithTransaction")
@@ -183,12 +249,18 @@ func NewMockDataStoreFrom(i codegraph.DataStore) *MockDataStore {
 		DeleteUnreferencedDocumentsFunc: &DataStoreDeleteUnreferencedDocumentsFunc{
 			defaultHook: i.DeleteUnreferencedDocuments,
 		},
+		GetUnusedSymbolReportFunc: &DataStoreGetUnusedSymbolReportFunc{
+			defaultHook: i.GetUnusedSymbolReport,
+		},
 		IDsWithMetaFunc: &DataStoreIDsWithMetaFunc{
 			defaultHook: i.IDsWithMeta,
 		},
 		InsertMetadataFunc: &DataStoreInsertMetadataFunc{
 			defaultHook: i.InsertMetadata,
 		},
+		InsertUnusedSymbolReportFunc: &DataStoreInsertUnusedSymbolReportFunc{
+			defaultHook: i.InsertUnusedSymbolReport,
+		},
 		NewPreciseSCIPWriterFunc: &DataStoreNewPreciseSCIPWriterFunc{
 			defaultHook: i.NewPreciseSCIPWriter,
 		},
@@ -201,6 +273,15 @@ func NewMockDataStoreFrom(i codegraph.DataStore) *MockDataStore {
 		ReconcileCandidatesWithTimeFunc: &DataStoreReconcileCandidatesWithTimeFunc{
 			defaultHook: i.ReconcileCandidatesWithTime,
 		},
+		ReferencedSymbolNamesFunc: &DataStoreReferencedSymbolNamesFunc{
+			defaultHook: i.ReferencedSymbolNames,
+		},
+		ScanDocumentsFunc: &DataStoreScanDocumentsFunc{
+			defaultHook: i.ScanDocuments,
+		},
+		UnusedSymbolReportCandidatesFunc: &DataStoreUnusedSymbolReportCandidatesFunc{
+			defaultHook: i.UnusedSymbolReportCandidates,
+		},
 		WithTransactionFunc: &DataStoreWithTransactionFunc{
 			defaultHook: i.WithTransaction,
 		},
@@ -553,6 +634,125 @@ func (c DataStoreDeleteUnreferencedDocumentsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// DataStoreGetUnusedSymbolReportFunc describes the behavior when the
+// GetUnusedSymbolReport method of the parent MockDataStore instance is
+// invoked.
+type DataStoreGetUnusedSymbolReportFunc struct {
+	defaultHook func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error)
+	hooks       []func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error)
+	history     []DataStoreGetUnusedSymbolReportFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUnusedSymbolReport delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDataStore) GetUnusedSymbolReport(v0 context.Context, v1 int, v2 int, v3 int) (codegraph.UnusedSymbolReport, bool, error) {
+	r0, r1, r2 := m.GetUnusedSymbolReportFunc.nextHook()(v0, v1, v2, v3)
+	m.GetUnusedSymbolReportFunc.appendCall(DataStoreGetUnusedSymbolReportFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUnusedSymbolReport method of the parent MockDataStore instance is
+// invoked and the hook queue is empty.
+func (f *DataStoreGetUnusedSymbolReportFunc) SetDefaultHook(hook func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUnusedSymbolReport method of the parent MockDataStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DataStoreGetUnusedSymbolReportFunc) PushHook(hook func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DataStoreGetUnusedSymbolReportFunc) SetDefaultReturn(r0 codegraph.UnusedSymbolReport, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DataStoreGetUnusedSymbolReportFunc) PushReturn(r0 codegraph.UnusedSymbolReport, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *DataStoreGetUnusedSymbolReportFunc) nextHook() func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DataStoreGetUnusedSymbolReportFunc) appendCall(r0 DataStoreGetUnusedSymbolReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DataStoreGetUnusedSymbolReportFuncCall
+// objects describing the invocations of this function.
+func (f *DataStoreGetUnusedSymbolReportFunc) History() []DataStoreGetUnusedSymbolReportFuncCall {
+	f.mutex.Lock()
+	history := make([]DataStoreGetUnusedSymbolReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DataStoreGetUnusedSymbolReportFuncCall is an object that describes an
+// invocation of method GetUnusedSymbolReport on an instance of
+// MockDataStore.
+type DataStoreGetUnusedSymbolReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 codegraph.UnusedSymbolReport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DataStoreGetUnusedSymbolReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DataStoreGetUnusedSymbolReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // DataStoreIDsWithMetaFunc describes the behavior when the IDsWithMeta
 // method of the parent MockDataStore instance is invoked.
 type DataStoreIDsWithMetaFunc struct {
@@ -769,6 +969,120 @@ func (c DataStoreInsertMetadataFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DataStoreInsertUnusedSymbolReportFunc describes the behavior when the
+// InsertUnusedSymbolReport method of the parent MockDataStore instance is
+// invoked.
+type DataStoreInsertUnusedSymbolReportFunc struct {
+	defaultHook func(context.Context, int, []codegraph.UnusedSymbol, time.Time) error
+	hooks       []func(context.Context, int, []codegraph.UnusedSymbol, time.Time) error
+	history     []DataStoreInsertUnusedSymbolReportFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertUnusedSymbolReport delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDataStore) InsertUnusedSymbolReport(v0 context.Context, v1 int, v2 []codegraph.UnusedSymbol, v3 time.Time) error {
+	r0 := m.InsertUnusedSymbolReportFunc.nextHook()(v0, v1, v2, v3)
+	m.InsertUnusedSymbolReportFunc.appendCall(DataStoreInsertUnusedSymbolReportFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertUnusedSymbolReport method of the parent MockDataStore instance is
+// invoked and the hook queue is empty.
+func (f *DataStoreInsertUnusedSymbolReportFunc) SetDefaultHook(hook func(context.Context, int, []codegraph.UnusedSymbol, time.Time) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertUnusedSymbolReport method of the parent MockDataStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DataStoreInsertUnusedSymbolReportFunc) PushHook(hook func(context.Context, int, []codegraph.UnusedSymbol, time.Time) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DataStoreInsertUnusedSymbolReportFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []codegraph.UnusedSymbol, time.Time) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DataStoreInsertUnusedSymbolReportFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []codegraph.UnusedSymbol, time.Time) error {
+		return r0
+	})
+}
+
+func (f *DataStoreInsertUnusedSymbolReportFunc) nextHook() func(context.Context, int, []codegraph.UnusedSymbol, time.Time) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DataStoreInsertUnusedSymbolReportFunc) appendCall(r0 DataStoreInsertUnusedSymbolReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DataStoreInsertUnusedSymbolReportFuncCall
+// objects describing the invocations of this function.
+func (f *DataStoreInsertUnusedSymbolReportFunc) History() []DataStoreInsertUnusedSymbolReportFuncCall {
+	f.mutex.Lock()
+	history := make([]DataStoreInsertUnusedSymbolReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DataStoreInsertUnusedSymbolReportFuncCall is an object that describes an
+// invocation of method InsertUnusedSymbolReport on an instance of
+// MockDataStore.
+type DataStoreInsertUnusedSymbolReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []codegraph.UnusedSymbol
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DataStoreInsertUnusedSymbolReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DataStoreInsertUnusedSymbolReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DataStoreNewPreciseSCIPWriterFunc describes the behavior when the
 // NewPreciseSCIPWriter method of the parent MockDataStore instance is
 // invoked.
@@ -1211,6 +1525,345 @@ func (c DataStoreReconcileCandidatesWithTimeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// DataStoreReferencedSymbolNamesFunc describes the behavior when the
+// ReferencedSymbolNames method of the parent MockDataStore instance is
+// invoked.
+type DataStoreReferencedSymbolNamesFunc struct {
+	defaultHook func(context.Context, []int, []string) ([]string, error)
+	hooks       []func(context.Context, []int, []string) ([]string, error)
+	history     []DataStoreReferencedSymbolNamesFuncCall
+	mutex       sync.Mutex
+}
+
+// ReferencedSymbolNames delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDataStore) ReferencedSymbolNames(v0 context.Context, v1 []int, v2 []string) ([]string, error) {
+	r0, r1 := m.ReferencedSymbolNamesFunc.nextHook()(v0, v1, v2)
+	m.ReferencedSymbolNamesFunc.appendCall(DataStoreReferencedSymbolNamesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ReferencedSymbolNames method of the parent MockDataStore instance is
+// invoked and the hook queue is empty.
+func (f *DataStoreReferencedSymbolNamesFunc) SetDefaultHook(hook func(context.Context, []int, []string) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReferencedSymbolNames method of the parent MockDataStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DataStoreReferencedSymbolNamesFunc) PushHook(hook func(context.Context, []int, []string) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DataStoreReferencedSymbolNamesFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int, []string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DataStoreReferencedSymbolNamesFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, []int, []string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *DataStoreReferencedSymbolNamesFunc) nextHook() func(context.Context, []int, []string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DataStoreReferencedSymbolNamesFunc) appendCall(r0 DataStoreReferencedSymbolNamesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DataStoreReferencedSymbolNamesFuncCall
+// objects describing the invocations of this function.
+func (f *DataStoreReferencedSymbolNamesFunc) History() []DataStoreReferencedSymbolNamesFuncCall {
+	f.mutex.Lock()
+	history := make([]DataStoreReferencedSymbolNamesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DataStoreReferencedSymbolNamesFuncCall is an object that describes an
+// invocation of method ReferencedSymbolNames on an instance of
+// MockDataStore.
+type DataStoreReferencedSymbolNamesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DataStoreReferencedSymbolNamesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DataStoreReferencedSymbolNamesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// DataStoreScanDocumentsFunc describes the behavior when the ScanDocuments
+// method of the parent MockDataStore instance is invoked.
+type DataStoreScanDocumentsFunc struct {
+	defaultHook func(context.Context, int, func(path string, document *scip.Document) error) error
+	hooks       []func(context.Context, int, func(path string, document *scip.Document) error) error
+	history     []DataStoreScanDocumentsFuncCall
+	mutex       sync.Mutex
+}
+
+// ScanDocuments delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDataStore) ScanDocuments(v0 context.Context, v1 int, v2 func(path string, document *scip.Document) error) error {
+	r0 := m.ScanDocumentsFunc.nextHook()(v0, v1, v2)
+	m.ScanDocumentsFunc.appendCall(DataStoreScanDocumentsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ScanDocuments method
+// of the parent MockDataStore instance is invoked and the hook queue is
+// empty.
+func (f *DataStoreScanDocumentsFunc) SetDefaultHook(hook func(context.Context, int, func(path string, document *scip.Document) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScanDocuments method of the parent MockDataStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DataStoreScanDocumentsFunc) PushHook(hook func(context.Context, int, func(path string, document *scip.Document) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DataStoreScanDocumentsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(path string, document *scip.Document) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DataStoreScanDocumentsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(path string, document *scip.Document) error) error {
+		return r0
+	})
+}
+
+func (f *DataStoreScanDocumentsFunc) nextHook() func(context.Context, int, func(path string, document *scip.Document) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DataStoreScanDocumentsFunc) appendCall(r0 DataStoreScanDocumentsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DataStoreScanDocumentsFuncCall objects
+// describing the invocations of this function.
+func (f *DataStoreScanDocumentsFunc) History() []DataStoreScanDocumentsFuncCall {
+	f.mutex.Lock()
+	history := make([]DataStoreScanDocumentsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DataStoreScanDocumentsFuncCall is an object that describes an invocation
+// of method ScanDocuments on an instance of MockDataStore.
+type DataStoreScanDocumentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(path string, document *scip.Document) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DataStoreScanDocumentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DataStoreScanDocumentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// DataStoreUnusedSymbolReportCandidatesFunc describes the behavior when the
+// UnusedSymbolReportCandidates method of the parent MockDataStore instance
+// is invoked.
+type DataStoreUnusedSymbolReportCandidatesFunc struct {
+	defaultHook func(context.Context, int, time.Duration, time.Time) ([]int, error)
+	hooks       []func(context.Context, int, time.Duration, time.Time) ([]int, error)
+	history     []DataStoreUnusedSymbolReportCandidatesFuncCall
+	mutex       sync.Mutex
+}
+
+// UnusedSymbolReportCandidates delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockDataStore) UnusedSymbolReportCandidates(v0 context.Context, v1 int, v2 time.Duration, v3 time.Time) ([]int, error) {
+	r0, r1 := m.UnusedSymbolReportCandidatesFunc.nextHook()(v0, v1, v2, v3)
+	m.UnusedSymbolReportCandidatesFunc.appendCall(DataStoreUnusedSymbolReportCandidatesFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UnusedSymbolReportCandidates method of the parent MockDataStore instance
+// is invoked and the hook queue is empty.
+func (f *DataStoreUnusedSymbolReportCandidatesFunc) SetDefaultHook(hook func(context.Context, int, time.Duration, time.Time) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UnusedSymbolReportCandidates method of the parent MockDataStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DataStoreUnusedSymbolReportCandidatesFunc) PushHook(hook func(context.Context, int, time.Duration, time.Time) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DataStoreUnusedSymbolReportCandidatesFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, time.Duration, time.Time) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DataStoreUnusedSymbolReportCandidatesFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, int, time.Duration, time.Time) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *DataStoreUnusedSymbolReportCandidatesFunc) nextHook() func(context.Context, int, time.Duration, time.Time) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DataStoreUnusedSymbolReportCandidatesFunc) appendCall(r0 DataStoreUnusedSymbolReportCandidatesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// DataStoreUnusedSymbolReportCandidatesFuncCall objects describing the
+// invocations of this function.
+func (f *DataStoreUnusedSymbolReportCandidatesFunc) History() []DataStoreUnusedSymbolReportCandidatesFuncCall {
+	f.mutex.Lock()
+	history := make([]DataStoreUnusedSymbolReportCandidatesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DataStoreUnusedSymbolReportCandidatesFuncCall is an object that describes
+// an invocation of method UnusedSymbolReportCandidates on an instance of
+// MockDataStore.
+type DataStoreUnusedSymbolReportCandidatesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Duration
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DataStoreUnusedSymbolReportCandidatesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DataStoreUnusedSymbolReportCandidatesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DataStoreWithTransactionFunc describes the behavior when the
 // WithTransaction method of the parent MockDataStore instance is invoked.
 type DataStoreWithTransactionFunc struct {
@@ -31,6 +31,13 @@ type DataStore interface {
 	DeleteLsifDataByUploadIds(ctx context.Context, bundleIDs ...int) (err error)
 	DeleteAbandonedSchemaVersionsRecords(ctx context.Context) (_ int, err error)
 	DeleteUnreferencedDocuments(ctx context.Context, batchSize int, maxAge time.Duration, now time.Time) (numScanned, numDeleted int, err error)
+
+	// Unused symbols
+	UnusedSymbolReportCandidates(ctx context.Context, batchSize int, maxAge time.Duration, now time.Time) ([]int, error)
+	ScanDocuments(ctx context.Context, uploadID int, f func(path string, document *scip.Document) error) error
+	ReferencedSymbolNames(ctx context.Context, uploadIDs []int, symbolNames []string) ([]string, error)
+	InsertUnusedSymbolReport(ctx context.Context, uploadID int, symbols []UnusedSymbol, now time.Time) error
+	GetUnusedSymbolReport(ctx context.Context, uploadID, limit, offset int) (UnusedSymbolReport, bool, error)
 }
 
 type SCIPWriter interface {
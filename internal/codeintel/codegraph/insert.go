@@ -214,15 +214,12 @@ func (s *scipWriter) InsertDocument(ctx context.Context, path string, scipDocume
 		return err
 	}
 
-	compressedPayload, err := shared.Compressor.Compress(bytes.NewReader(payload))
-	if err != nil {
-		return err
-	}
-
+	// Compression is deferred until flush, where it is skipped for documents
+	// whose content is already stored by a previous upload.
 	s.batch = append(s.batch, bufferedDocument{
 		path:         path,
 		scipDocument: scipDocument,
-		payload:      compressedPayload,
+		payload:      payload,
 		payloadHash:  hashPayload(payload),
 	})
 	s.batchPayloadSum += len(payload)
@@ -271,15 +268,89 @@ func (s *scipWriter) flushDocuments(ctx context.Context) error {
 	return nil
 }
 
+// insertDocuments returns the identifiers of the codeintel_scip_documents rows holding
+// the payload of each of the given documents. Documents are content-addressed by the
+// hash of their uncompressed payload, so documents unchanged since a previous upload
+// (typically of a nearby commit) reuse the existing row and are never re-compressed
+// or re-written. Rows no longer referenced by any upload are reclaimed by the janitor
+// via DeleteUnreferencedDocuments. Reused rows are locked until the enclosing transaction
+// commits, so the janitor cannot reclaim them before this upload references them.
+//
 // Post-condition: len(documentIDs) == len(documents)
 func (s *scipWriter) insertDocuments(ctx context.Context, documents []bufferedDocument, totalDocumentSize int) (documentIDs []int, err error) {
-	ctx, _, endObservation := s.operations.insertDocuments.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+	ctx, trace, endObservation := s.operations.insertDocuments.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
 		attribute.Int("numDocuments", len(documents)),
 		attribute.Int("totalDocumentSize", totalDocumentSize),
 	}})
 	defer endObservation(1, observation.Args{})
 
-	documentIDs, err = batch.WithInserterForIdentifiers(
+	hashes := make([][]byte, 0, len(documents))
+	hashSet := make(map[string]struct{}, len(documents))
+	for _, document := range documents {
+		key := hex.EncodeToString(document.payloadHash)
+		if _, ok := hashSet[key]; !ok {
+			hashSet[key] = struct{}{}
+			hashes = append(hashes, document.payloadHash)
+		}
+	}
+
+	idsByHash, err := scanIDsByHash(s.db.Query(ctx, sqlf.Sprintf(scipWriterWriteFetchDocumentsQuery, pq.Array(hashes))))
+	if err != nil {
+		return nil, err
+	}
+	numReusedDocuments := len(idsByHash)
+
+	newDocuments := make([]bufferedDocument, 0, len(hashes)-numReusedDocuments)
+	for _, document := range documents {
+		key := hex.EncodeToString(document.payloadHash)
+		if _, ok := idsByHash[key]; ok {
+			continue
+		}
+		if _, ok := hashSet[key]; !ok {
+			continue
+		}
+		delete(hashSet, key)
+		newDocuments = append(newDocuments, document)
+	}
+
+	trace.AddEvent("reusedDocuments", attribute.Int("numReusedDocuments", numReusedDocuments), attribute.Int("numNewDocuments", len(newDocuments)))
+
+	if len(newDocuments) > 0 {
+		newIDsByHash, err := s.insertNewDocuments(ctx, newDocuments)
+		if err != nil {
+			return nil, err
+		}
+		for hash, id := range newIDsByHash {
+			idsByHash[hash] = id
+		}
+	}
+
+	documentIDs = make([]int, 0, len(documents))
+	for _, document := range documents {
+		id, ok := idsByHash[hex.EncodeToString(document.payloadHash)]
+		if !ok {
+			return nil, errors.New("unexpected number of document records inserted/retrieved")
+		}
+		documentIDs = append(documentIDs, id)
+	}
+
+	return documentIDs, nil
+}
+
+// insertNewDocuments compresses and inserts the given documents, which must have
+// distinct payload hashes, and returns the resulting identifiers keyed by hex-encoded
+// payload hash. Rows concurrently inserted by another upload are fetched instead.
+func (s *scipWriter) insertNewDocuments(ctx context.Context, documents []bufferedDocument) (map[string]int, error) {
+	compressedPayloads := make([][]byte, 0, len(documents))
+	for _, document := range documents {
+		compressedPayload, err := shared.Compressor.Compress(bytes.NewReader(document.payload))
+		if err != nil {
+			return nil, err
+		}
+		compressedPayloads = append(compressedPayloads, compressedPayload)
+	}
+
+	documentIDs, err := batch.WithInserterForIdentifiers(
 		ctx,
 		s.db.Handle(),
 		"codeintel_scip_documents",
@@ -292,8 +363,8 @@ func (s *scipWriter) insertDocuments(ctx context.Context, documents []bufferedDo
 		"ON CONFLICT DO NOTHING",
 		"id",
 		func(inserter *batch.Inserter) error {
-			for _, document := range documents {
-				if err := inserter.Insert(ctx, 1, document.payloadHash, document.payload); err != nil {
+			for i, document := range documents {
+				if err := inserter.Insert(ctx, 1, document.payloadHash, compressedPayloads[i]); err != nil {
 					return err
 				}
 			}
@@ -304,30 +375,29 @@ func (s *scipWriter) insertDocuments(ctx context.Context, documents []bufferedDo
 		return nil, err
 	}
 
-	if len(documentIDs) != len(documents) {
-		hashes := make([][]byte, 0, len(documents))
-		hashSet := make(map[string]struct{}, len(documents))
-		for _, document := range documents {
-			key := hex.EncodeToString(document.payloadHash)
-			if _, ok := hashSet[key]; !ok {
-				hashSet[key] = struct{}{}
-				hashes = append(hashes, document.payloadHash)
-			}
-		}
-		idsByHash, err := scanIDsByHash(s.db.Query(ctx, sqlf.Sprintf(scipWriterWriteFetchDocumentsQuery, pq.Array(hashes))))
-		if err != nil {
-			return nil, err
-		}
-		documentIDs = documentIDs[:0]
-		for _, document := range documents {
-			documentIDs = append(documentIDs, idsByHash[hex.EncodeToString(document.payloadHash)])
-		}
-		if len(idsByHash) != len(hashes) {
-			return nil, errors.New("unexpected number of document records inserted/retrieved")
+	if len(documentIDs) == len(documents) {
+		idsByHash := make(map[string]int, len(documents))
+		for i, document := range documents {
+			idsByHash[hex.EncodeToString(document.payloadHash)] = documentIDs[i]
 		}
+		return idsByHash, nil
 	}
 
-	return documentIDs, nil
+	// Some rows conflicted with documents written concurrently by another upload
+	// after we looked them up; fetch all identifiers by hash instead.
+	hashes := make([][]byte, 0, len(documents))
+	for _, document := range documents {
+		hashes = append(hashes, document.payloadHash)
+	}
+	idsByHash, err := scanIDsByHash(s.db.Query(ctx, sqlf.Sprintf(scipWriterWriteFetchDocumentsQuery, pq.Array(hashes))))
+	if err != nil {
+		return nil, err
+	}
+	if len(idsByHash) != len(hashes) {
+		return nil, errors.New("unexpected number of document records inserted/retrieved")
+	}
+
+	return idsByHash, nil
 }
 
 // Pre-condition: len(documents) == len(documentIDs)
@@ -502,6 +572,8 @@ SELECT
 	id
 FROM codeintel_scip_documents
 WHERE payload_hash = ANY(%s)
+ORDER BY id
+FOR KEY SHARE
 `
 
 func (s *scipWriter) Flush(ctx context.Context) (uint32, error) {
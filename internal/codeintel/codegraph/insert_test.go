@@ -107,6 +107,38 @@ func TestInsertSharedDocumentsConcurrently(t *testing.T) {
 	}
 }
 
+func TestInsertDuplicateDocumentsWithinUpload(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(t))
+	store := New(observation.TestContextTB(t), codeIntelDB)
+	ctx := context.Background()
+
+	document := &scip.Document{
+		Symbols: []*scip.SymbolInformation{
+			{Symbol: "lorem ipsum dolor sit amet"},
+		},
+	}
+	insertTestDocuments(t, store, 24, map[string]*scip.Document{
+		"a/doc.go": document,
+		"b/doc.go": document,
+		"c/doc.go": document,
+	})
+
+	documentCount, _, err := basestore.ScanFirstInt(codeIntelDB.Handle().QueryContext(ctx, `SELECT COUNT(*) FROM codeintel_scip_documents`))
+	if err != nil {
+		t.Fatalf("failed to query number of SCIP documents: %s", err)
+	} else if expected := 1; documentCount != expected {
+		t.Fatalf("unexpected number of documents. want=%d have=%d", expected, documentCount)
+	}
+
+	lookupCount, _, err := basestore.ScanFirstInt(codeIntelDB.Handle().QueryContext(ctx, `SELECT COUNT(*) FROM codeintel_scip_document_lookup WHERE upload_id = 24`))
+	if err != nil {
+		t.Fatalf("failed to query number of SCIP document lookups: %s", err)
+	} else if expected := 3; lookupCount != expected {
+		t.Fatalf("unexpected number of document lookups. want=%d have=%d", expected, lookupCount)
+	}
+}
+
 func TestInsertDocumentWithSymbols(t *testing.T) {
 	logger := logtest.Scoped(t)
 	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(t))
@@ -175,3 +207,24 @@ func TestInsertDocumentWithSymbols(t *testing.T) {
 		t.Fatalf("unexpected number of symbols inserted. want=%d have=%d", expected, n)
 	}
 }
+
+// insertTestDocuments writes the given documents for the given upload in a single transaction.
+func insertTestDocuments(t *testing.T, store DataStore, uploadID int, documents map[string]*scip.Document) {
+	ctx := context.Background()
+
+	if err := store.WithTransaction(ctx, func(tx DataStore) error {
+		scipWriter, err := tx.NewPreciseSCIPWriter(ctx, uploadID)
+		if err != nil {
+			return err
+		}
+		for path, document := range documents {
+			if err := scipWriter.InsertDocument(ctx, path, document); err != nil {
+				return err
+			}
+		}
+		_, err = scipWriter.Flush(ctx)
+		return err
+	}); err != nil {
+		t.Fatalf("failed to write SCIP documents: %s", err)
+	}
+}
@@ -14,6 +14,12 @@ type operations struct {
 	deleteLsifDataByUploadIds   *observation.Operation
 	deleteUnreferencedDocuments *observation.Operation
 	writerOperations            writerOperations
+
+	unusedSymbolReportCandidates *observation.Operation
+	scanDocuments                *observation.Operation
+	referencedSymbolNames        *observation.Operation
+	insertUnusedSymbolReport     *observation.Operation
+	getUnusedSymbolReport        *observation.Operation
 }
 
 type writerOperations struct {
@@ -61,5 +67,11 @@ func newOperations(observationCtx *observation.Context) *operations {
 			flushSymbolNames:      op("flushSymbolNames"),
 			flushSymbols:          op("flushSymbols"),
 		},
+
+		unusedSymbolReportCandidates: op("UnusedSymbolReportCandidates"),
+		scanDocuments:                op("ScanDocuments"),
+		referencedSymbolNames:        op("ReferencedSymbolNames"),
+		insertUnusedSymbolReport:     op("InsertUnusedSymbolReport"),
+		getUnusedSymbolReport:        op("GetUnusedSymbolReport"),
 	}
 }
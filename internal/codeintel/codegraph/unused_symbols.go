@@ -0,0 +1,302 @@
+package codegraph
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// UnusedSymbol is a global symbol defined by an upload that is not referenced
+// by the upload itself or by any upload depending on it.
+type UnusedSymbol struct {
+	Symbol string
+	// Kind is the name of the SCIP symbol kind, or the empty string if the
+	// indexer did not emit one.
+	Kind string
+	// Path is the path of the defining document, relative to the upload root.
+	Path  string
+	Range scip.Range
+}
+
+// UnusedSymbolReport is a page of the unused symbols computed for an upload.
+type UnusedSymbolReport struct {
+	UploadID   int
+	ComputedAt time.Time
+	TotalCount int
+	Symbols    []UnusedSymbol
+}
+
+func (s *store) UnusedSymbolReportCandidates(ctx context.Context, batchSize int, maxAge time.Duration, now time.Time) (_ []int, err error) {
+	ctx, _, endObservation := s.operations.unusedSymbolReportCandidates.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("batchSize", batchSize),
+		attribute.Stringer("maxAge", maxAge),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return basestore.ScanInts(s.db.Query(ctx, sqlf.Sprintf(unusedSymbolReportCandidatesQuery, batchSize, now, maxAge/time.Second, batchSize)))
+}
+
+const unusedSymbolReportCandidatesQuery = `
+(
+	SELECT m.upload_id
+	FROM codeintel_scip_metadata m
+	WHERE NOT EXISTS (SELECT 1 FROM codeintel_unused_symbol_reports r WHERE r.upload_id = m.upload_id)
+	ORDER BY m.upload_id
+	LIMIT %s
+) UNION ALL (
+	SELECT r.upload_id
+	FROM codeintel_unused_symbol_reports r
+	WHERE %s - r.computed_at > (%s * interval '1 second')
+	ORDER BY r.computed_at, r.upload_id
+)
+LIMIT %s
+`
+
+func (s *store) ScanDocuments(ctx context.Context, uploadID int, f func(path string, document *scip.Document) error) (err error) {
+	ctx, _, endObservation := s.operations.scanDocuments.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rows, err := s.db.Query(ctx, sqlf.Sprintf(scanDocumentsQuery, uploadID))
+	if err != nil {
+		return err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var path string
+		var compressedSCIPPayload []byte
+		if err := rows.Scan(&path, &compressedSCIPPayload); err != nil {
+			return err
+		}
+
+		scipPayload, err := shared.Decompressor.Decompress(bytes.NewReader(compressedSCIPPayload))
+		if err != nil {
+			return err
+		}
+
+		var document scip.Document
+		if err := proto.Unmarshal(scipPayload, &document); err != nil {
+			return err
+		}
+		if err := f(path, &document); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const scanDocumentsQuery = `
+SELECT
+	sid.document_path,
+	sd.raw_scip_payload
+FROM codeintel_scip_document_lookup sid
+JOIN codeintel_scip_documents sd ON sd.id = sid.document_id
+WHERE sid.upload_id = %s
+ORDER BY sid.document_path
+`
+
+func (s *store) ReferencedSymbolNames(ctx context.Context, uploadIDs []int, symbolNames []string) (_ []string, err error) {
+	ctx, _, endObservation := s.operations.referencedSymbolNames.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("numUploadIDs", len(uploadIDs)),
+		attribute.Int("numSymbolNames", len(symbolNames)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(uploadIDs) == 0 || len(symbolNames) == 0 {
+		return nil, nil
+	}
+
+	return basestore.ScanStrings(s.db.Query(ctx, sqlf.Sprintf(
+		referencedSymbolNamesQuery,
+		pq.Array(symbolNames),
+		pq.Array(uploadIDs),
+	)))
+}
+
+const referencedSymbolNamesQuery = `
+WITH RECURSIVE
+-- Walk the symbol name tries of the given uploads, only following the paths
+-- that remain a prefix of one of the given symbol names.
+matching_prefixes(upload_id, id, prefix, search) AS (
+	(
+		SELECT
+			ssn.upload_id,
+			ssn.id,
+			ssn.name_segment,
+			substring(t.name from length(ssn.name_segment) + 1) AS search
+		FROM codeintel_scip_symbol_names ssn
+		JOIN unnest(%s::text[]) AS t(name) ON t.name LIKE ssn.name_segment || '%%'
+		WHERE
+			ssn.upload_id = ANY(%s) AND
+			ssn.prefix_id IS NULL
+	) UNION (
+		SELECT
+			ssn.upload_id,
+			ssn.id,
+			mp.prefix || ssn.name_segment,
+			substring(mp.search from length(ssn.name_segment) + 1) AS search
+		FROM matching_prefixes mp
+		JOIN codeintel_scip_symbol_names ssn ON
+			ssn.upload_id = mp.upload_id AND
+			ssn.prefix_id = mp.id
+		WHERE
+			mp.search != '' AND
+			mp.search LIKE ssn.name_segment || '%%'
+	)
+)
+SELECT DISTINCT mp.prefix
+FROM matching_prefixes mp
+JOIN codeintel_scip_symbols ss ON ss.upload_id = mp.upload_id AND ss.symbol_id = mp.id
+WHERE
+	mp.search = '' AND
+	ss.reference_ranges IS NOT NULL
+ORDER BY mp.prefix
+`
+
+func (s *store) InsertUnusedSymbolReport(ctx context.Context, uploadID int, symbols []UnusedSymbol, now time.Time) (err error) {
+	ctx, _, endObservation := s.operations.insertUnusedSymbolReport.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+		attribute.Int("numSymbols", len(symbols)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.withTransaction(ctx, func(tx *store) error {
+		if err := tx.db.Exec(ctx, sqlf.Sprintf(deleteUnusedSymbolsQuery, pq.Array([]int{uploadID}))); err != nil {
+			return err
+		}
+
+		inserter := func(inserter *batch.Inserter) error {
+			for _, symbol := range symbols {
+				if err := inserter.Insert(
+					ctx,
+					uploadID,
+					symbol.Symbol,
+					symbol.Kind,
+					symbol.Path,
+					symbol.Range.Start.Line,
+					symbol.Range.Start.Character,
+					symbol.Range.End.Line,
+					symbol.Range.End.Character,
+				); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		if err := batch.WithInserter(
+			ctx,
+			tx.db.Handle(),
+			"codeintel_unused_symbols",
+			batch.MaxNumPostgresParameters,
+			[]string{
+				"upload_id",
+				"symbol_name",
+				"kind",
+				"document_path",
+				"start_line",
+				"start_character",
+				"end_line",
+				"end_character",
+			},
+			inserter,
+		); err != nil {
+			return err
+		}
+
+		return tx.db.Exec(ctx, sqlf.Sprintf(upsertUnusedSymbolReportQuery, uploadID, now, now))
+	})
+}
+
+const upsertUnusedSymbolReportQuery = `
+INSERT INTO codeintel_unused_symbol_reports (upload_id, computed_at)
+VALUES (%s, %s)
+ON CONFLICT (upload_id) DO UPDATE SET computed_at = %s
+`
+
+func (s *store) GetUnusedSymbolReport(ctx context.Context, uploadID, limit, offset int) (_ UnusedSymbolReport, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getUnusedSymbolReport.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+		attribute.Int("limit", limit),
+		attribute.Int("offset", offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	report, ok, err := scanFirstUnusedSymbolReport(s.db.Query(ctx, sqlf.Sprintf(getUnusedSymbolReportQuery, uploadID)))
+	if err != nil || !ok {
+		return UnusedSymbolReport{}, false, err
+	}
+
+	report.Symbols, err = scanUnusedSymbols(s.db.Query(ctx, sqlf.Sprintf(getUnusedSymbolsQuery, uploadID, limit, offset)))
+	if err != nil {
+		return UnusedSymbolReport{}, false, err
+	}
+
+	return report, true, nil
+}
+
+const getUnusedSymbolReportQuery = `
+SELECT
+	r.upload_id,
+	r.computed_at,
+	(SELECT COUNT(*) FROM codeintel_unused_symbols s WHERE s.upload_id = r.upload_id)
+FROM codeintel_unused_symbol_reports r
+WHERE r.upload_id = %s
+`
+
+const getUnusedSymbolsQuery = `
+SELECT
+	s.symbol_name,
+	s.kind,
+	s.document_path,
+	s.start_line,
+	s.start_character,
+	s.end_line,
+	s.end_character
+FROM codeintel_unused_symbols s
+WHERE s.upload_id = %s
+ORDER BY s.document_path, s.start_line, s.start_character, s.symbol_name
+LIMIT %s OFFSET %s
+`
+
+var scanFirstUnusedSymbolReport = basestore.NewFirstScanner(func(s dbutil.Scanner) (report UnusedSymbolReport, _ error) {
+	err := s.Scan(&report.UploadID, &report.ComputedAt, &report.TotalCount)
+	return report, err
+})
+
+var scanUnusedSymbols = basestore.NewSliceScanner(func(s dbutil.Scanner) (symbol UnusedSymbol, _ error) {
+	err := s.Scan(
+		&symbol.Symbol,
+		&symbol.Kind,
+		&symbol.Path,
+		&symbol.Range.Start.Line,
+		&symbol.Range.Start.Character,
+		&symbol.Range.End.Line,
+		&symbol.Range.End.Character,
+	)
+	return symbol, err
+})
+
+const deleteUnusedSymbolsQuery = `
+DELETE FROM codeintel_unused_symbols WHERE upload_id = ANY(%s)
+`
+
+const deleteUnusedSymbolReportsQuery = `
+DELETE FROM codeintel_unused_symbol_reports WHERE upload_id = ANY(%s)
+`
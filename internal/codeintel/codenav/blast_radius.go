@@ -0,0 +1,336 @@
+package codenav
+
+import (
+	"cmp"
+	"context"
+	"io"
+	"slices"
+
+	genslices "github.com/life4/genesis/slices"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/internal/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/core"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+// DiffBlastRadiusArgs describes a change to a repository between two commits.
+type DiffBlastRadiusArgs struct {
+	RepositoryID api.RepoID
+	RepoName     api.RepoName
+	Base         api.CommitID
+	Head         api.CommitID
+	// ReferencesLimit bounds the number of references returned for each
+	// affected symbol. It does not affect the reported reference counts.
+	ReferencesLimit int
+}
+
+func (args *DiffBlastRadiusArgs) Attrs() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int("repositoryID", int(args.RepositoryID)),
+		attribute.String("base", string(args.Base)),
+		attribute.String("head", string(args.Head)),
+		attribute.Int("referencesLimit", args.ReferencesLimit),
+	}
+}
+
+// AffectedSymbol is a symbol whose definition is touched by a change.
+type AffectedSymbol struct {
+	Symbol string
+	// Definition is the location of the definition in the base commit.
+	Definition shared.UploadLocation
+	// ReferenceCount is the total number of references to the symbol across
+	// all indexes referencing it, including those of other repositories.
+	ReferenceCount int
+	// References holds up to DiffBlastRadiusArgs.ReferencesLimit references.
+	References []shared.UploadLocation
+}
+
+// maximumBlastRadiusPaths bounds the number of changed files inspected for a
+// single change. Files beyond this limit are ignored.
+const maximumBlastRadiusPaths = 1000
+
+// maximumBlastRadiusSymbols bounds the number of affected symbols for which
+// references are resolved.
+const maximumBlastRadiusSymbols = 500
+
+// GetDiffBlastRadius returns the global symbols whose definitions in the base
+// commit are modified or deleted by the change between the base and head
+// commits, along with the references to them.
+//
+// A definition is affected when a diff hunk intersects its enclosing range, or
+// its range if the indexer does not emit enclosing ranges. Definitions are read
+// from the precise indexes closest to the base commit; definitions that have
+// themselves moved between the indexed commit and the base commit are skipped.
+func (s *Service) GetDiffBlastRadius(ctx context.Context, args DiffBlastRadiusArgs, requestState RequestState) (_ []AffectedSymbol, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getDiffBlastRadius, serviceObserverThreshold,
+		observation.Args{Attrs: observation.MergeAttributes(args.Attrs(), requestState.Attrs()...)})
+	defer endObservation()
+
+	changes, err := s.changedBaseLines(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	trace.AddEvent("ChangedBaseLines", attribute.Int("numPaths", len(changes)))
+
+	paths := make([]core.RepoRelPath, 0, len(changes))
+	for path := range changes {
+		paths = append(paths, path)
+	}
+	slices.SortFunc(paths, func(a, b core.RepoRelPath) int { return cmp.Compare(a.RawValue(), b.RawValue()) })
+
+	requestArgs := RequestArgs{RepositoryID: args.RepositoryID, Commit: args.Base}
+
+	var symbols []AffectedSymbol
+	seen := collections.NewSet[string]()
+	for _, path := range paths {
+		uploads, err := s.GetClosestCompletedUploadsForBlob(ctx, uploadsshared.UploadMatchingOptions{
+			RepositoryID:       args.RepositoryID,
+			Commit:             args.Base,
+			Path:               path,
+			RootToPathMatching: uploadsshared.RootMustEnclosePath,
+		})
+		if err != nil {
+			return nil, err
+		}
+		requestState.dataLoader.SetUploadInCacheMap(uploads)
+
+		for _, upload := range uploads {
+			definitions, err := s.affectedDefinitions(ctx, args, requestState, upload, path, changes[path])
+			if err != nil {
+				return nil, err
+			}
+
+			for _, definition := range definitions {
+				if seen.Has(definition.Symbol) || len(symbols) >= maximumBlastRadiusSymbols {
+					continue
+				}
+
+				locations, err := s.getUploadLocations(ctx, requestArgs, requestState, []shared.Location{{
+					UploadID: upload.ID,
+					Path:     core.NewUploadRelPath(upload, path),
+					Range:    shared.TranslateRange(scip.NewRangeUnchecked(definition.Range)),
+				}}, true)
+				if err != nil {
+					return nil, err
+				}
+				if len(locations) == 0 {
+					// Filtered out by sub-repository permissions
+					continue
+				}
+
+				seen.Add(definition.Symbol)
+				symbols = append(symbols, AffectedSymbol{Symbol: definition.Symbol, Definition: locations[0]})
+			}
+		}
+	}
+	trace.AddEvent("AffectedDefinitions", attribute.Int("numSymbols", len(symbols)))
+
+	for i := range symbols {
+		count, references, err := s.getBlastRadiusReferences(ctx, args, requestArgs, requestState, symbols[i])
+		if err != nil {
+			return nil, err
+		}
+		symbols[i].ReferenceCount = count
+		symbols[i].References = references
+	}
+
+	return symbols, nil
+}
+
+// changedLines describes the lines of a file in the base commit that are
+// modified by a change.
+type changedLines struct {
+	// deleted is true if the whole file is removed by the change.
+	deleted bool
+	hunks   []compactHunk
+}
+
+// touches reports whether the given 0-based, inclusive range of lines of the
+// base commit is modified.
+func (c changedLines) touches(startLine, endLine int32) bool {
+	if c.deleted {
+		return true
+	}
+	for _, hunk := range c.hunks {
+		if hunk.touchesLines(startLine, endLine) {
+			return true
+		}
+	}
+	return false
+}
+
+// changedBaseLines returns the lines of files existing in the base commit that
+// are modified or removed by the change. Renames are not detected, so a renamed
+// file is removed from the base commit along with all of its definitions.
+func (s *Service) changedBaseLines(ctx context.Context, args DiffBlastRadiusArgs) (map[core.RepoRelPath]changedLines, error) {
+	it, err := s.gitserver.ChangedFiles(ctx, args.RepoName, string(args.Base), string(args.Head))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	changes := map[core.RepoRelPath]changedLines{}
+	var modifiedPaths []string
+	for len(changes) < maximumBlastRadiusPaths {
+		file, err := it.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		switch file.Status {
+		case gitdomain.StatusAdded:
+			// Nothing was defined in this file in the base commit
+		case gitdomain.StatusDeleted:
+			changes[core.NewRepoRelPathUnchecked(file.Path)] = changedLines{deleted: true}
+		default:
+			changes[core.NewRepoRelPathUnchecked(file.Path)] = changedLines{}
+			modifiedPaths = append(modifiedPaths, file.Path)
+		}
+	}
+	if len(modifiedPaths) == 0 {
+		return changes, nil
+	}
+
+	r, err := s.gitserver.Diff(ctx, args.RepoName, gitserver.DiffOptions{
+		Base:             string(args.Base),
+		Head:             string(args.Head),
+		Paths:            modifiedPaths,
+		RangeType:        "..",
+		InterHunkContext: pointers.Ptr(0),
+		ContextLines:     pointers.Ptr(0),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	for {
+		fileDiff, err := r.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return changes, nil
+			}
+			return nil, err
+		}
+
+		path := core.NewRepoRelPathUnchecked(fileDiff.OrigName)
+		if _, ok := changes[path]; ok {
+			changes[path] = changedLines{hunks: genslices.Map(fileDiff.Hunks, newCompactHunk)}
+		}
+	}
+}
+
+// affectedDefinitions returns the definitions of global symbols in the given
+// upload's document for path that intersect the changed lines.
+func (s *Service) affectedDefinitions(
+	ctx context.Context,
+	args DiffBlastRadiusArgs,
+	requestState RequestState,
+	upload uploadsshared.CompletedUpload,
+	path core.RepoRelPath,
+	changes changedLines,
+) ([]*scip.Occurrence, error) {
+	optDocument, err := s.lsifstore.SCIPDocument(ctx, upload.ID, core.NewUploadRelPath(upload, path))
+	if err != nil {
+		return nil, err
+	}
+	document, ok := optDocument.Get()
+	if !ok {
+		return nil, nil
+	}
+
+	var definitions []*scip.Occurrence
+	for _, occurrence := range document.Occurrences {
+		if !isDefinition(occurrence) || occurrence.Symbol == "" || scip.IsLocalSymbol(occurrence.Symbol) {
+			continue
+		}
+
+		extent := occurrence.Range
+		if len(occurrence.EnclosingRange) > 0 {
+			extent = occurrence.EnclosingRange
+		}
+		baseExtent := scip.NewRangeUnchecked(extent)
+		if upload.Commit != string(args.Base) {
+			translated, err := requestState.GitTreeTranslator.TranslateRange(ctx, api.CommitID(upload.Commit), args.Base, path, baseExtent)
+			if err != nil {
+				return nil, err
+			}
+			if baseExtent, ok = translated.Get(); !ok {
+				continue
+			}
+		}
+
+		if changes.touches(baseExtent.Start.Line, baseExtent.End.Line) {
+			definitions = append(definitions, occurrence)
+		}
+	}
+
+	return definitions, nil
+}
+
+// getBlastRadiusReferences returns the total number of references to the given
+// symbol and up to args.ReferencesLimit of them. References are searched in the
+// upload defining the symbol and in the uploads referencing it via monikers.
+func (s *Service) getBlastRadiusReferences(
+	ctx context.Context,
+	args DiffBlastRadiusArgs,
+	requestArgs RequestArgs,
+	requestState RequestState,
+	symbol AffectedSymbol,
+) (int, []shared.UploadLocation, error) {
+	monikers, err := symbolsToMonikers([]string{symbol.Symbol})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	uploadIDs := []int{symbol.Definition.Upload.ID}
+	if len(monikers) > 0 {
+		ids, _, _, err := s.uploadSvc.GetUploadIDsWithReferences(
+			ctx,
+			monikers,
+			uploadIDs,
+			int(args.RepositoryID),
+			string(args.Base),
+			requestState.maximumIndexesPerMonikerSearch,
+			0,
+		)
+		if err != nil {
+			return 0, nil, errors.Wrap(err, "uploadSvc.GetUploadIDsWithReferences")
+		}
+		uploadIDs = append(uploadIDs, ids...)
+	}
+
+	if _, err := s.getUploadsByIDs(ctx, uploadIDs, requestState); err != nil {
+		return 0, nil, err
+	}
+
+	usages, totalCount, err := s.lsifstore.GetSymbolUsages(ctx, lsifstore.SymbolUsagesOptions{
+		UsageKind:     shared.UsageKindReference,
+		UploadIDs:     uploadIDs,
+		LookupSymbols: []string{symbol.Symbol},
+		Limit:         args.ReferencesLimit,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	references, err := s.getUploadLocations(ctx, requestArgs, requestState, genslices.Map(usages, shared.Usage.ToLocation), true)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return totalCount, references, nil
+}
@@ -0,0 +1,579 @@
+package codenav
+
+import (
+	"context"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/internal/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/core"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// CallHierarchyItem is a function-like symbol taking part in a call hierarchy.
+type CallHierarchyItem struct {
+	// Symbol is the SCIP symbol name of the function.
+	Symbol string
+	// Definition is the location of the definition of the function. It is None
+	// if no index containing the definition could be found.
+	Definition core.Option[shared.UploadLocation]
+}
+
+// CallHierarchyCall groups the calls made from one function to another.
+type CallHierarchyCall struct {
+	// Item is the calling function for incoming calls, and the called function
+	// for outgoing calls.
+	Item CallHierarchyItem
+	// CallSites are the locations of the calls. They are always located in the
+	// body of the calling function.
+	CallSites []shared.UploadLocation
+}
+
+// calleeDefinitionsLimit bounds the number of definitions fetched from other
+// indexes when resolving the callees of a single function.
+const calleeDefinitionsLimit = 1000
+
+// GetIncomingCalls returns the functions calling the function at the given
+// position, grouped by caller.
+//
+// References are gathered the same way as GetReferences, across uploads and
+// repositories, and are attributed to the innermost function definition whose
+// enclosing range contains them. The page size limits the number of call
+// sites, so the same caller may be returned again on a subsequent page.
+// References outside of any function (e.g. in package-level initializers) and
+// references in indexes without enclosing ranges are skipped.
+func (s *Service) GetIncomingCalls(
+	ctx context.Context,
+	args PositionalRequestArgs,
+	requestState RequestState,
+	cursor Cursor,
+) (_ []CallHierarchyCall, nextCursor Cursor, err error) {
+	references, nextCursor, err := s.gatherLocations(
+		ctx, args, requestState, cursor,
+		s.operations.getIncomingCalls, // operation
+		shared.UsageKindReference,
+		true, // includeReferencingIndexes
+		LocationExtractorNamedFunc{shared.UsageKindReference, s.lsifstore.ExtractReferenceLocationsFromPosition},
+	)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	calls, err := s.groupReferencesByCaller(ctx, args.RequestArgs, requestState, references)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return calls, nextCursor, nil
+}
+
+// GetOutgoingCalls returns the functions called by the function at the given
+// position, grouped by callee.
+//
+// The position may either be the definition of the function or a reference
+// to it, in which case its definition is looked up in the indexes providing
+// the symbol. The body of the function is determined by the enclosing range of
+// its definition. The page size limits the number of callees.
+func (s *Service) GetOutgoingCalls(
+	ctx context.Context,
+	args PositionalRequestArgs,
+	requestState RequestState,
+	cursor Cursor,
+) (_ []CallHierarchyCall, nextCursor Cursor, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getOutgoingCalls, serviceObserverThreshold,
+		observation.Args{Attrs: observation.MergeAttributes(args.Attrs(), requestState.Attrs()...)})
+	defer endObservation()
+
+	if cursor.Phase == "done" {
+		return nil, exhaustedCursor, nil
+	}
+	cursor.Phase = "local"
+
+	// N.B.: cursor is purposefully re-assigned here
+	visibleUploads, cursor, err := s.getVisibleUploadsFromCursor(ctx, args, requestState, cursor)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	calls, err := s.gatherOutgoingCalls(ctx, trace, args.RequestArgs, requestState, visibleUploads)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+	trace.AddEvent("GatherOutgoingCalls", attribute.Int("numCallees", len(calls)))
+
+	// The callees of a single function are bounded by the size of its body, so
+	// we compute all of them and page over the result. The cursor keeps the
+	// visible uploads stable between pages.
+	totalCount := len(calls)
+	calls = pageSlice(calls, args.Limit, cursor.LocalLocationOffset)
+	cursor.LocalLocationOffset += len(calls)
+	if cursor.LocalLocationOffset >= totalCount {
+		return calls, exhaustedCursor, nil
+	}
+
+	return calls, cursor, nil
+}
+
+// groupReferencesByCaller attributes each reference to the function enclosing
+// it and groups them by that function, preserving the order of references.
+func (s *Service) groupReferencesByCaller(
+	ctx context.Context,
+	args RequestArgs,
+	requestState RequestState,
+	references []shared.UploadLocation,
+) ([]CallHierarchyCall, error) {
+	type callerKey struct {
+		uploadID int
+		symbol   string
+	}
+
+	documents := map[documentKey]*callGraphDocument{}
+	callsByCaller := map[callerKey]*CallHierarchyCall{}
+	var callers []callerKey
+
+	for _, reference := range references {
+		referenceRange, ok, err := s.indexRange(ctx, requestState, reference)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		document, err := s.callGraphDocument(ctx, documents, reference.Upload.ID, core.NewUploadRelPath(reference.Upload, reference.Path))
+		if err != nil {
+			return nil, err
+		}
+		if document == nil || document.isDefinitionAt(referenceRange) {
+			continue
+		}
+
+		caller := document.enclosingFunction(referenceRange)
+		if caller == nil {
+			continue
+		}
+
+		key := callerKey{uploadID: reference.Upload.ID, symbol: caller.Symbol}
+		call, ok := callsByCaller[key]
+		if !ok {
+			definition, _, err := s.getUploadLocation(ctx, args, requestState, reference.Upload, shared.Location{
+				UploadID: reference.Upload.ID,
+				Path:     document.path,
+				Range:    shared.TranslateRange(scip.NewRangeUnchecked(caller.Range)),
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			call = &CallHierarchyCall{Item: CallHierarchyItem{Symbol: caller.Symbol, Definition: core.Some(definition)}}
+			callsByCaller[key] = call
+			callers = append(callers, key)
+		}
+		call.CallSites = append(call.CallSites, reference)
+	}
+
+	calls := make([]CallHierarchyCall, 0, len(callers))
+	for _, key := range callers {
+		calls = append(calls, *callsByCaller[key])
+	}
+	return calls, nil
+}
+
+// gatherOutgoingCalls returns the callees of the function at the target position
+// of the first visible upload in which the body of that function can be found.
+func (s *Service) gatherOutgoingCalls(
+	ctx context.Context,
+	trace observation.TraceLogger,
+	args RequestArgs,
+	requestState RequestState,
+	visibleUploads []visibleUpload,
+) ([]CallHierarchyCall, error) {
+	documents := map[documentKey]*callGraphDocument{}
+
+	for _, visibleUpload := range visibleUploads {
+		document, err := s.callGraphDocument(ctx, documents, visibleUpload.Upload.ID, visibleUpload.TargetPathWithoutRoot())
+		if err != nil {
+			return nil, err
+		}
+		if document == nil {
+			continue
+		}
+
+		symbol, ok := document.functionAt(visibleUpload.TargetPosition)
+		if !ok {
+			continue
+		}
+
+		body, err := s.findFunctionBody(ctx, requestState, documents, visibleUpload.Upload, document, symbol)
+		if err != nil {
+			return nil, err
+		}
+		if body == nil {
+			continue
+		}
+		trace.AddEvent("FoundFunctionBody", attribute.Int("uploadID", body.upload.ID), attribute.String("symbol", symbol))
+
+		return s.resolveCallees(ctx, args, requestState, body)
+	}
+
+	return nil, nil
+}
+
+// functionBody is the definition of a function along with the document it is
+// defined in.
+type functionBody struct {
+	upload     uploadsshared.CompletedUpload
+	document   *callGraphDocument
+	definition *scip.Occurrence
+}
+
+// findFunctionBody returns the definition of the given symbol, which has been
+// found in the given document. If the document doesn't define the symbol, the
+// uploads defining it are searched.
+func (s *Service) findFunctionBody(
+	ctx context.Context,
+	requestState RequestState,
+	documents map[documentKey]*callGraphDocument,
+	upload uploadsshared.CompletedUpload,
+	document *callGraphDocument,
+	symbol string,
+) (*functionBody, error) {
+	if definition := document.functionDefinition(symbol); definition != nil {
+		return &functionBody{upload: upload, document: document, definition: definition}, nil
+	}
+
+	usages, err := s.getRemoteDefinitions(ctx, requestState, []string{symbol}, []int{upload.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, usage := range usages {
+		definitionUpload, ok := requestState.dataLoader.GetUploadFromCacheMap(usage.UploadID)
+		if !ok {
+			continue
+		}
+		definitionDocument, err := s.callGraphDocument(ctx, documents, usage.UploadID, usage.Path)
+		if err != nil {
+			return nil, err
+		}
+		if definitionDocument == nil {
+			continue
+		}
+		if definition := definitionDocument.functionDefinition(symbol); definition != nil {
+			return &functionBody{upload: definitionUpload, document: definitionDocument, definition: definition}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveCallees groups the calls made in the given function body by callee
+// and resolves the definition of each callee.
+func (s *Service) resolveCallees(
+	ctx context.Context,
+	args RequestArgs,
+	requestState RequestState,
+	body *functionBody,
+) ([]CallHierarchyCall, error) {
+	bodyRange := scip.NewRangeUnchecked(body.definition.EnclosingRange)
+	definitionRange := scip.NewRangeUnchecked(body.definition.Range)
+
+	var callees []string
+	callSitesByCallee := map[string][]shared.Location{}
+	for _, occurrence := range body.document.document.Occurrences {
+		occurrenceRange := scip.NewRangeUnchecked(occurrence.Range)
+		if isDefinition(occurrence) || occurrenceRange.CompareStrict(definitionRange) == 0 || !containsRange(bodyRange, occurrenceRange) {
+			continue
+		}
+		if !body.document.isFunction(occurrence.Symbol) {
+			continue
+		}
+
+		if _, ok := callSitesByCallee[occurrence.Symbol]; !ok {
+			callees = append(callees, occurrence.Symbol)
+		}
+		callSitesByCallee[occurrence.Symbol] = append(callSitesByCallee[occurrence.Symbol], shared.Location{
+			UploadID: body.upload.ID,
+			Path:     body.document.path,
+			Range:    shared.TranslateRange(occurrenceRange),
+		})
+	}
+
+	// Resolve callee definitions in the same document first, then look up the
+	// remaining global symbols in the indexes providing them.
+	definitions := map[string]shared.Location{}
+	var remoteCallees []string
+	for _, callee := range callees {
+		if definition := body.document.definition(callee); definition != nil {
+			definitions[callee] = shared.Location{
+				UploadID: body.upload.ID,
+				Path:     body.document.path,
+				Range:    shared.TranslateRange(scip.NewRangeUnchecked(definition.Range)),
+			}
+		} else if !scip.IsLocalSymbol(callee) {
+			remoteCallees = append(remoteCallees, callee)
+		}
+	}
+	if len(remoteCallees) > 0 {
+		usages, err := s.getRemoteDefinitions(ctx, requestState, remoteCallees, []int{body.upload.ID})
+		if err != nil {
+			return nil, err
+		}
+		for _, usage := range usages {
+			if _, ok := definitions[usage.Symbol]; !ok {
+				definitions[usage.Symbol] = usage.ToLocation()
+			}
+		}
+	}
+
+	calls := make([]CallHierarchyCall, 0, len(callees))
+	for _, callee := range callees {
+		callSites, err := s.getUploadLocations(ctx, args, requestState, callSitesByCallee[callee], true)
+		if err != nil {
+			return nil, err
+		}
+		if len(callSites) == 0 {
+			// All call sites were filtered out by sub-repository permissions.
+			continue
+		}
+
+		item := CallHierarchyItem{Symbol: callee, Definition: core.None[shared.UploadLocation]()}
+		if definition, ok := definitions[callee]; ok {
+			adjustedDefinitions, err := s.getUploadLocations(ctx, args, requestState, []shared.Location{definition}, true)
+			if err != nil {
+				return nil, err
+			}
+			if len(adjustedDefinitions) > 0 {
+				item.Definition = core.Some(adjustedDefinitions[0])
+			}
+		}
+
+		calls = append(calls, CallHierarchyCall{Item: item, CallSites: callSites})
+	}
+
+	return calls, nil
+}
+
+// getRemoteDefinitions returns the definitions of the given global symbols in
+// the given uploads and the uploads providing the symbols. All uploads referred
+// to by the returned usages are loaded in the request data loader.
+func (s *Service) getRemoteDefinitions(
+	ctx context.Context,
+	requestState RequestState,
+	symbolNames []string,
+	uploadIDs []int,
+) ([]shared.Usage, error) {
+	monikers, err := symbolsToMonikers(symbolNames)
+	if err != nil {
+		return nil, err
+	}
+
+	idSet := collections.NewSet(uploadIDs...)
+	if len(monikers) > 0 {
+		uploads, err := s.getUploadsWithDefinitionsForMonikers(ctx, monikers, requestState)
+		if err != nil {
+			return nil, err
+		}
+		for _, upload := range uploads {
+			idSet.Add(upload.ID)
+		}
+	}
+	ids := collections.SortedSetValues(idSet)
+
+	if _, err := s.getUploadsByIDs(ctx, ids, requestState); err != nil {
+		return nil, err
+	}
+
+	usages, _, err := s.lsifstore.GetSymbolUsages(ctx, lsifstore.SymbolUsagesOptions{
+		UsageKind:     shared.UsageKindDefinition,
+		UploadIDs:     ids,
+		LookupSymbols: symbolNames,
+		Limit:         calleeDefinitionsLimit,
+	})
+	return usages, err
+}
+
+// indexRange returns the range of the given location in the commit of its
+// upload, undoing the adjustment made by getUploadLocation. It returns false
+// if the range cannot be translated.
+func (s *Service) indexRange(ctx context.Context, requestState RequestState, location shared.UploadLocation) (scip.Range, bool, error) {
+	targetRange := location.TargetRange.ToSCIPRange()
+	if location.TargetCommit == location.Upload.Commit {
+		return targetRange, true, nil
+	}
+
+	indexRange, err := requestState.GitTreeTranslator.TranslateRange(
+		ctx, api.CommitID(location.TargetCommit), api.CommitID(location.Upload.Commit), location.Path, targetRange,
+	)
+	if err != nil {
+		return scip.Range{}, false, err
+	}
+	r, ok := indexRange.Get()
+	return r, ok, nil
+}
+
+type documentKey struct {
+	uploadID int
+	path     string
+}
+
+// callGraphDocument loads the given document, caching it in documents. It
+// returns nil if the upload does not contain the document.
+func (s *Service) callGraphDocument(
+	ctx context.Context,
+	documents map[documentKey]*callGraphDocument,
+	uploadID int,
+	path core.UploadRelPath,
+) (*callGraphDocument, error) {
+	key := documentKey{uploadID: uploadID, path: path.RawValue()}
+	if document, ok := documents[key]; ok {
+		return document, nil
+	}
+
+	optDocument, err := s.lsifstore.SCIPDocument(ctx, uploadID, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document *callGraphDocument
+	if scipDocument, ok := optDocument.Get(); ok {
+		document = newCallGraphDocument(path, scipDocument)
+	}
+	documents[key] = document
+	return document, nil
+}
+
+// callGraphDocument wraps a SCIP document with the lookups needed to build a
+// call hierarchy.
+type callGraphDocument struct {
+	path        core.UploadRelPath
+	document    *scip.Document
+	symbolKinds map[string]scip.SymbolInformation_Kind
+	isFunctions map[string]bool
+}
+
+func newCallGraphDocument(path core.UploadRelPath, document *scip.Document) *callGraphDocument {
+	symbolKinds := make(map[string]scip.SymbolInformation_Kind, len(document.Symbols))
+	for _, info := range document.Symbols {
+		symbolKinds[info.Symbol] = info.Kind
+	}
+
+	return &callGraphDocument{
+		path:        path,
+		document:    document,
+		symbolKinds: symbolKinds,
+		isFunctions: map[string]bool{},
+	}
+}
+
+// isFunction reports whether the given symbol is function-like. The symbol
+// kind is used if the document carries symbol information for it, otherwise
+// we fall back to the suffix of the symbol's last descriptor.
+func (d *callGraphDocument) isFunction(symbol string) bool {
+	if symbol == "" {
+		return false
+	}
+	if isFunction, ok := d.isFunctions[symbol]; ok {
+		return isFunction
+	}
+
+	var isFunction bool
+	if kind, ok := d.symbolKinds[symbol]; ok && kind != scip.SymbolInformation_UnspecifiedKind {
+		isFunction = functionSymbolKinds.Has(kind)
+	} else if !scip.IsLocalSymbol(symbol) {
+		if parsed, err := scip.ParseSymbol(symbol); err == nil && len(parsed.Descriptors) > 0 {
+			isFunction = parsed.Descriptors[len(parsed.Descriptors)-1].Suffix == scip.Descriptor_Method
+		}
+	}
+
+	d.isFunctions[symbol] = isFunction
+	return isFunction
+}
+
+var functionSymbolKinds = collections.NewSet(
+	scip.SymbolInformation_AbstractMethod,
+	scip.SymbolInformation_Constructor,
+	scip.SymbolInformation_Function,
+	scip.SymbolInformation_Method,
+	scip.SymbolInformation_MethodSpecification,
+	scip.SymbolInformation_ProtocolMethod,
+	scip.SymbolInformation_PureVirtualMethod,
+	scip.SymbolInformation_SingletonMethod,
+	scip.SymbolInformation_StaticMethod,
+	scip.SymbolInformation_TraitMethod,
+)
+
+// functionAt returns the function-like symbol occurring at the given position.
+func (d *callGraphDocument) functionAt(position shared.Position) (string, bool) {
+	for _, occurrence := range scip.FindOccurrences(d.document.Occurrences, int32(position.Line), int32(position.Character)) {
+		if d.isFunction(occurrence.Symbol) {
+			return occurrence.Symbol, true
+		}
+	}
+	return "", false
+}
+
+// definition returns the definition of the given symbol in this document.
+func (d *callGraphDocument) definition(symbol string) *scip.Occurrence {
+	for _, occurrence := range d.document.Occurrences {
+		if occurrence.Symbol == symbol && isDefinition(occurrence) {
+			return occurrence
+		}
+	}
+	return nil
+}
+
+// functionDefinition returns the definition of the given symbol in this
+// document if it has an enclosing range, which we need to delimit its body.
+func (d *callGraphDocument) functionDefinition(symbol string) *scip.Occurrence {
+	for _, occurrence := range d.document.Occurrences {
+		if occurrence.Symbol == symbol && isDefinition(occurrence) && len(occurrence.EnclosingRange) > 0 {
+			return occurrence
+		}
+	}
+	return nil
+}
+
+// isDefinitionAt reports whether a definition occurs at exactly the given range.
+func (d *callGraphDocument) isDefinitionAt(r scip.Range) bool {
+	for _, occurrence := range d.document.Occurrences {
+		if isDefinition(occurrence) && scip.NewRangeUnchecked(occurrence.Range).CompareStrict(r) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// enclosingFunction returns the definition of the innermost function whose
+// enclosing range contains the given range.
+func (d *callGraphDocument) enclosingFunction(r scip.Range) *scip.Occurrence {
+	var innermost *scip.Occurrence
+	var innermostRange scip.Range
+	for _, occurrence := range d.document.Occurrences {
+		if !isDefinition(occurrence) || len(occurrence.EnclosingRange) == 0 || !d.isFunction(occurrence.Symbol) {
+			continue
+		}
+		enclosingRange := scip.NewRangeUnchecked(occurrence.EnclosingRange)
+		if !containsRange(enclosingRange, r) {
+			continue
+		}
+		if innermost == nil || containsRange(innermostRange, enclosingRange) {
+			innermost, innermostRange = occurrence, enclosingRange
+		}
+	}
+	return innermost
+}
+
+func isDefinition(occurrence *scip.Occurrence) bool {
+	return scip.SymbolRole_Definition.Matches(occurrence)
+}
+
+// containsRange reports whether inner lies within outer.
+func containsRange(outer, inner scip.Range) bool {
+	return !inner.Start.Less(outer.Start) && !outer.End.Less(inner.End)
+}
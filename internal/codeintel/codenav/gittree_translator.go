@@ -291,6 +291,18 @@ func (h *compactHunk) overlapsLine(line int32) bool {
 	return h.origStartLine <= line+1 && line+1 < h.origStartLine+h.origLines
 }
 
+// touchesLines reports whether the hunk modifies any of the given 0-based,
+// inclusive range of lines in the original file. A pure insertion touches the
+// range only if it is inserted strictly inside of it.
+func (h *compactHunk) touchesLines(startLine, endLine int32) bool {
+	// git diff hunks are 1-based, vs our 0-based scip ranges
+	start := h.origStartLine - 1
+	if h.origLines == 0 {
+		return startLine < start && start <= endLine
+	}
+	return start <= endLine && startLine < start+h.origLines
+}
+
 func (h *compactHunk) shiftLine(line int32) core.Option[int32] {
 	if h.overlapsLine(line) {
 		return core.None[int32]()
@@ -20,6 +20,9 @@ type operations struct {
 	getDefinitions                    *observation.Operation
 	getRanges                         *observation.Operation
 	getStencil                        *observation.Operation
+	getIncomingCalls                  *observation.Operation
+	getOutgoingCalls                  *observation.Operation
+	getDiffBlastRadius                *observation.Operation
 	getClosestCompletedUploadsForBlob *observation.Operation
 	snapshotForDocument               *observation.Operation
 	visibleUploadsForPath             *observation.Operation
@@ -56,6 +59,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getDefinitions:                    op("getDefinitions"),
 		getRanges:                         op("getRanges"),
 		getStencil:                        op("getStencil"),
+		getIncomingCalls:                  op("getIncomingCalls"),
+		getOutgoingCalls:                  op("getOutgoingCalls"),
+		getDiffBlastRadius:                op("getDiffBlastRadius"),
 		getClosestCompletedUploadsForBlob: op("GetClosestCompletedUploadsForBlob"),
 		snapshotForDocument:               op("SnapshotForDocument"),
 		visibleUploadsForPath:             op("VisibleUploadsForPath"),
@@ -0,0 +1,245 @@
+package codenav
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	lsifstoremocks "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/internal/lsifstore/mocks"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/core"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+)
+
+// helperDiff changes the body of helper in the file modeled by callHierarchyDocument.
+const helperDiff = `
+diff --git s1/main.go s1/main.go
+index 1111111111111111111111111111111111111111..2222222222222222222222222222222222222222 100644
+--- s1/main.go
++++ s1/main.go
+@@ -7 +7 @@ func main() {
+-func helper() {}
++func helper() { println() }
+`
+
+func TestGetDiffBlastRadius(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := lsifstoremocks.NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	mockSearchClient := client.NewMockSearchClient()
+
+	// Init service
+	svc := newService(observation.TestContextTB(t), mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSearchClient, log.NoOp())
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.GitTreeTranslator = noopTranslator()
+	mockRequestState.SetUploadsDataLoader(nil)
+	mockRequestState.SetMaximumIndexesPerMonikerSearch(50)
+
+	mockGitserverClient.ChangedFilesFunc.SetDefaultReturn(gitserver.NewChangedFilesIteratorFromSlice([]gitdomain.PathStatus{
+		{Path: "s1/main.go", Status: gitdomain.StatusModified},
+		{Path: "s1/new.go", Status: gitdomain.StatusAdded},
+	}), nil)
+	mockGitserverClient.DiffFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, opts gitserver.DiffOptions) (*gitserver.DiffFileIterator, error) {
+		if diff := cmp.Diff([]string{"s1/main.go"}, opts.Paths); diff != "" {
+			t.Errorf("unexpected diff paths (-want +got):\n%s", diff)
+		}
+		return gitserver.NewDiffFileIterator(io.NopCloser(bytes.NewReader([]byte(helperDiff)))), nil
+	})
+	mockGitserverClient.GetCommitFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, commit api.CommitID) (*gitdomain.Commit, error) {
+		return &gitdomain.Commit{ID: commit}, nil
+	})
+
+	definingUpload := uploadsshared.CompletedUpload{ID: 50, RepositoryID: 42, Commit: string(mockCommit), Root: "s1/"}
+	referencingUpload := uploadsshared.CompletedUpload{ID: 51, RepositoryID: 43, Commit: "cafebabe"}
+	mockUploadSvc.InferClosestUploadsFunc.SetDefaultReturn([]uploadsshared.CompletedUpload{definingUpload}, nil)
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.SetDefaultReturn([]int{51}, 1, 1, nil)
+	mockUploadSvc.GetCompletedUploadsByIDsFunc.SetDefaultReturn([]uploadsshared.CompletedUpload{referencingUpload}, nil)
+	mockLsifStore.FindDocumentIDsFunc.SetDefaultHook(findDocumentIDsFuncAllowAny())
+	mockLsifStore.SCIPDocumentFunc.SetDefaultReturn(core.Some(callHierarchyDocument()), nil)
+	mockLsifStore.GetSymbolUsagesFunc.SetDefaultReturn([]shared.Usage{
+		{UploadID: 51, Path: uploadRelPath("lib.go"), Range: testRange1, Symbol: callHierarchyHelper, Kind: shared.UsageKindReference},
+	}, 3, nil)
+
+	symbols, err := svc.GetDiffBlastRadius(context.Background(), DiffBlastRadiusArgs{
+		RepositoryID:    42,
+		RepoName:        "github.com/test/repo",
+		Base:            mockCommit,
+		Head:            "f00dcafe",
+		ReferencesLimit: 1,
+	}, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying blast radius: %s", err)
+	}
+
+	expectedSymbols := []AffectedSymbol{{
+		Symbol:         callHierarchyHelper,
+		Definition:     shared.UploadLocation{Upload: definingUpload, Path: repoRelPath("s1/main.go"), TargetCommit: string(mockCommit), TargetRange: shared.NewRange(6, 5, 6, 11)},
+		ReferenceCount: 3,
+		References: []shared.UploadLocation{
+			{Upload: referencingUpload, Path: repoRelPath("lib.go"), TargetCommit: "cafebabe", TargetRange: testRange1},
+		},
+	}}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected affected symbols (-want +got):\n%s", diff)
+	}
+
+	if history := mockLsifStore.GetSymbolUsagesFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count for lsifstore.GetSymbolUsages. want=%d have=%d", 1, len(history))
+	} else if diff := cmp.Diff([]int{50, 51}, history[0].Arg1.UploadIDs); diff != "" {
+		t.Errorf("unexpected upload ids (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetDiffBlastRadiusChanges(t *testing.T) {
+	testCases := []struct {
+		name         string
+		changedFiles []gitdomain.PathStatus
+		// diff is the diff of the modified files. Diff must not be called
+		// when it is empty.
+		diff            string
+		expectedSymbols []string
+	}{
+		{
+			name:         "several hunks",
+			changedFiles: []gitdomain.PathStatus{{Path: "s1/main.go", Status: gitdomain.StatusModified}},
+			diff: `
+diff --git s1/main.go s1/main.go
+index 1111111111111111111111111111111111111111..2222222222222222222222222222222222222222 100644
+--- s1/main.go
++++ s1/main.go
+@@ -2 +2 @@ func main() {
+-	helper()
++	helper2()
+@@ -7 +7 @@ func main() {
+-func helper() {}
++func helper() { println() }
+@@ -8,0 +9,2 @@ func helper() {}
++
++func other() {}
+`,
+			expectedSymbols: []string{callHierarchyMain, callHierarchyHelper},
+		},
+		{
+			name:         "pure deletions",
+			changedFiles: []gitdomain.PathStatus{{Path: "s1/main.go", Status: gitdomain.StatusModified}},
+			diff: `
+diff --git s1/main.go s1/main.go
+index 1111111111111111111111111111111111111111..2222222222222222222222222222222222222222 100644
+--- s1/main.go
++++ s1/main.go
+@@ -6 +5,0 @@ }
+-
+@@ -7 +5,0 @@ }
+-func helper() {}
+`,
+			expectedSymbols: []string{callHierarchyHelper},
+		},
+		{
+			name:         "insertion between definitions",
+			changedFiles: []gitdomain.PathStatus{{Path: "s1/main.go", Status: gitdomain.StatusModified}},
+			diff: `
+diff --git s1/main.go s1/main.go
+index 1111111111111111111111111111111111111111..2222222222222222222222222222222222222222 100644
+--- s1/main.go
++++ s1/main.go
+@@ -5,0 +6 @@ }
++// helper does nothing.
+`,
+			expectedSymbols: nil,
+		},
+		{
+			name:            "deleted file",
+			changedFiles:    []gitdomain.PathStatus{{Path: "s1/main.go", Status: gitdomain.StatusDeleted}},
+			expectedSymbols: []string{callHierarchyMain, callHierarchyHelper},
+		},
+		{
+			// Renames are not detected, so every definition of the old path
+			// is affected.
+			name: "renamed file",
+			changedFiles: []gitdomain.PathStatus{
+				{Path: "s1/cmd.go", Status: gitdomain.StatusAdded},
+				{Path: "s1/main.go", Status: gitdomain.StatusDeleted},
+			},
+			expectedSymbols: []string{callHierarchyMain, callHierarchyHelper},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Set up mocks
+			mockRepoStore := defaultMockRepoStore()
+			mockLsifStore := lsifstoremocks.NewMockLsifStore()
+			mockUploadSvc := NewMockUploadService()
+			mockGitserverClient := gitserver.NewMockClient()
+			mockSearchClient := client.NewMockSearchClient()
+
+			// Init service
+			svc := newService(observation.TestContextTB(t), mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSearchClient, log.NoOp())
+
+			// Set up request state
+			mockRequestState := RequestState{}
+			mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+			mockRequestState.GitTreeTranslator = noopTranslator()
+			mockRequestState.SetUploadsDataLoader(nil)
+			mockRequestState.SetMaximumIndexesPerMonikerSearch(50)
+
+			mockGitserverClient.ChangedFilesFunc.SetDefaultReturn(gitserver.NewChangedFilesIteratorFromSlice(testCase.changedFiles), nil)
+			mockGitserverClient.DiffFunc.SetDefaultHook(func(context.Context, api.RepoName, gitserver.DiffOptions) (*gitserver.DiffFileIterator, error) {
+				if testCase.diff == "" {
+					t.Error("unexpected call to gitserver.Diff")
+				}
+				return gitserver.NewDiffFileIterator(io.NopCloser(bytes.NewReader([]byte(testCase.diff)))), nil
+			})
+			mockGitserverClient.GetCommitFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, commit api.CommitID) (*gitdomain.Commit, error) {
+				return &gitdomain.Commit{ID: commit}, nil
+			})
+
+			definingUpload := uploadsshared.CompletedUpload{ID: 50, RepositoryID: 42, Commit: string(mockCommit), Root: "s1/"}
+			mockUploadSvc.InferClosestUploadsFunc.SetDefaultReturn([]uploadsshared.CompletedUpload{definingUpload}, nil)
+			mockLsifStore.FindDocumentIDsFunc.SetDefaultHook(findDocumentIDsFuncAllowAny())
+			mockLsifStore.SCIPDocumentFunc.SetDefaultHook(func(_ context.Context, _ int, path core.UploadRelPath) (core.Option[*scip.Document], error) {
+				if path.RawValue() != "main.go" {
+					return core.None[*scip.Document](), nil
+				}
+				return core.Some(callHierarchyDocument()), nil
+			})
+
+			symbols, err := svc.GetDiffBlastRadius(context.Background(), DiffBlastRadiusArgs{
+				RepositoryID:    42,
+				RepoName:        "github.com/test/repo",
+				Base:            mockCommit,
+				Head:            "f00dcafe",
+				ReferencesLimit: 1,
+			}, mockRequestState)
+			if err != nil {
+				t.Fatalf("unexpected error querying blast radius: %s", err)
+			}
+
+			var names []string
+			for _, symbol := range symbols {
+				names = append(names, symbol.Symbol)
+				if path := symbol.Definition.Path.RawValue(); path != "s1/main.go" {
+					t.Errorf("unexpected definition path for %s. want=%q have=%q", symbol.Symbol, "s1/main.go", path)
+				}
+			}
+			if diff := cmp.Diff(testCase.expectedSymbols, names); diff != "" {
+				t.Errorf("unexpected affected symbols (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	lsifstoremocks "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/internal/lsifstore/mocks"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/core"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+)
+
+const (
+	callHierarchyMain   = "scip-go gomod example v1.0.0 example/main()."
+	callHierarchyHelper = "scip-go gomod example v1.0.0 example/helper()."
+	callHierarchyConfig = "scip-go gomod example v1.0.0 example/config."
+)
+
+// callHierarchyDocument models the following file:
+//
+//	func main() {
+//		helper()
+//		helper()
+//		_ = config
+//	}
+//
+//	func helper() {}
+func callHierarchyDocument() *scip.Document {
+	return &scip.Document{
+		RelativePath: "main.go",
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{0, 5, 9}, EnclosingRange: []int32{0, 0, 4, 1}, Symbol: callHierarchyMain, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{1, 1, 7}, Symbol: callHierarchyHelper},
+			{Range: []int32{2, 1, 7}, Symbol: callHierarchyHelper},
+			{Range: []int32{3, 5, 11}, Symbol: callHierarchyConfig},
+			{Range: []int32{6, 5, 11}, EnclosingRange: []int32{6, 0, 6, 16}, Symbol: callHierarchyHelper, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		},
+		Symbols: []*scip.SymbolInformation{
+			{Symbol: callHierarchyMain, Kind: scip.SymbolInformation_Function},
+			{Symbol: callHierarchyHelper, Kind: scip.SymbolInformation_Function},
+			{Symbol: callHierarchyConfig, Kind: scip.SymbolInformation_Variable},
+		},
+	}
+}
+
+func TestGetIncomingCalls(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := lsifstoremocks.NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	mockSearchClient := client.NewMockSearchClient()
+
+	// Init service
+	svc := newService(observation.TestContextTB(t), mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSearchClient, log.NoOp())
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.GitTreeTranslator = noopTranslator()
+	uploads := []uploadsshared.CompletedUpload{{ID: 50, Commit: string(mockCommit), Root: "s1/"}}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	mockLsifStore.SCIPDocumentFunc.SetDefaultReturn(core.Some(callHierarchyDocument()), nil)
+	mockLsifStore.ExtractReferenceLocationsFromPositionFunc.PushReturn([]shared.Location{
+		{UploadID: 50, Path: uploadRelPath("main.go"), Range: shared.NewRange(1, 1, 1, 7)},
+		{UploadID: 50, Path: uploadRelPath("main.go"), Range: shared.NewRange(2, 1, 2, 7)},
+		{UploadID: 50, Path: uploadRelPath("main.go"), Range: shared.NewRange(6, 5, 6, 11)},
+	}, nil, nil)
+
+	mockRequest := PositionalRequestArgs{
+		RequestArgs: RequestArgs{RepositoryID: 51, Commit: mockCommit, Limit: 50},
+		Path:        mockPath,
+		Line:        6,
+		Character:   6,
+	}
+	cursor := Cursor{VisibleUploads: []CursorVisibleUpload{
+		{UploadID: 50, TargetPath: "s1/main.go", TargetPosition: shared.Position{Line: 6, Character: 6}},
+	}}
+
+	calls, _, err := svc.GetIncomingCalls(context.Background(), mockRequest, mockRequestState, cursor)
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("unexpected number of callers. want=%d have=%d", 1, len(calls))
+	}
+
+	if calls[0].Item.Symbol != callHierarchyMain {
+		t.Errorf("unexpected caller. want=%q have=%q", callHierarchyMain, calls[0].Item.Symbol)
+	}
+	expectedDefinition := shared.UploadLocation{Upload: uploads[0], Path: repoRelPath("s1/main.go"), TargetCommit: string(mockCommit), TargetRange: shared.NewRange(0, 5, 0, 9)}
+	if definition, ok := calls[0].Item.Definition.Get(); !ok {
+		t.Errorf("expected caller definition")
+	} else if diff := cmp.Diff(expectedDefinition, definition); diff != "" {
+		t.Errorf("unexpected caller definition (-want +got):\n%s", diff)
+	}
+
+	expectedCallSites := []shared.UploadLocation{
+		{Upload: uploads[0], Path: repoRelPath("s1/main.go"), TargetCommit: string(mockCommit), TargetRange: shared.NewRange(1, 1, 1, 7)},
+		{Upload: uploads[0], Path: repoRelPath("s1/main.go"), TargetCommit: string(mockCommit), TargetRange: shared.NewRange(2, 1, 2, 7)},
+	}
+	if diff := cmp.Diff(expectedCallSites, calls[0].CallSites); diff != "" {
+		t.Errorf("unexpected call sites (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetOutgoingCalls(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := lsifstoremocks.NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	mockSearchClient := client.NewMockSearchClient()
+
+	// Init service
+	svc := newService(observation.TestContextTB(t), mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSearchClient, log.NoOp())
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.GitTreeTranslator = noopTranslator()
+	uploads := []uploadsshared.CompletedUpload{{ID: 50, Commit: string(mockCommit), Root: "s1/"}}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	mockLsifStore.SCIPDocumentFunc.SetDefaultReturn(core.Some(callHierarchyDocument()), nil)
+
+	mockRequest := PositionalRequestArgs{
+		RequestArgs: RequestArgs{RepositoryID: 51, Commit: mockCommit, Limit: 50},
+		Path:        mockPath,
+		Line:        0,
+		Character:   6,
+	}
+	cursor := Cursor{VisibleUploads: []CursorVisibleUpload{
+		{UploadID: 50, TargetPath: "s1/main.go", TargetPosition: shared.Position{Line: 0, Character: 6}},
+	}}
+
+	calls, nextCursor, err := svc.GetOutgoingCalls(context.Background(), mockRequest, mockRequestState, cursor)
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+	if nextCursor.Phase != "done" {
+		t.Errorf("expected exhausted cursor, got phase %q", nextCursor.Phase)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("unexpected number of callees. want=%d have=%d", 1, len(calls))
+	}
+
+	if calls[0].Item.Symbol != callHierarchyHelper {
+		t.Errorf("unexpected callee. want=%q have=%q", callHierarchyHelper, calls[0].Item.Symbol)
+	}
+	expectedDefinition := shared.UploadLocation{Upload: uploads[0], Path: repoRelPath("s1/main.go"), TargetCommit: string(mockCommit), TargetRange: shared.NewRange(6, 5, 6, 11)}
+	if definition, ok := calls[0].Item.Definition.Get(); !ok {
+		t.Errorf("expected callee definition")
+	} else if diff := cmp.Diff(expectedDefinition, definition); diff != "" {
+		t.Errorf("unexpected callee definition (-want +got):\n%s", diff)
+	}
+
+	expectedCallSites := []shared.UploadLocation{
+		{Upload: uploads[0], Path: repoRelPath("s1/main.go"), TargetCommit: string(mockCommit), TargetRange: shared.NewRange(1, 1, 1, 7)},
+		{Upload: uploads[0], Path: repoRelPath("s1/main.go"), TargetCommit: string(mockCommit), TargetRange: shared.NewRange(2, 1, 2, 7)},
+	}
+	if diff := cmp.Diff(expectedCallSites, calls[0].CallSites); diff != "" {
+		t.Errorf("unexpected call sites (-want +got):\n%s", diff)
+	}
+}
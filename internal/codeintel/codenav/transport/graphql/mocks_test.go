@@ -191,6 +191,9 @@ type MockCodeNavService struct {
 	// GetDiagnosticsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDiagnostics.
 	GetDiagnosticsFunc *CodeNavServiceGetDiagnosticsFunc
+	// GetDiffBlastRadiusFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDiffBlastRadius.
+	GetDiffBlastRadiusFunc *CodeNavServiceGetDiffBlastRadiusFunc
 	// GetHoverFunc is an instance of a mock function object controlling the
 	// behavior of the method GetHover.
 	GetHoverFunc *CodeNavServiceGetHoverFunc
@@ -245,6 +248,11 @@ func NewMockCodeNavService() *MockCodeNavService {
 				return
 			},
 		},
+		GetDiffBlastRadiusFunc: &CodeNavServiceGetDiffBlastRadiusFunc{
+			defaultHook: func(context.Context, codenav.DiffBlastRadiusArgs, codenav.RequestState) (r0 []codenav.AffectedSymbol, r1 error) {
+				return
+			},
+		},
 		GetHoverFunc: &CodeNavServiceGetHoverFunc{
 			defaultHook: func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) (r0 string, r1 shared1.Range, r2 bool, r3 error) {
 				return
@@ -322,6 +330,11 @@ func NewStrictMockCodeNavService() *MockCodeNavService {
 				panic("unexpected invocation of MockCodeNavService.GetDiagnostics")
 			},
 		},
+		GetDiffBlastRadiusFunc: &CodeNavServiceGetDiffBlastRadiusFunc{
+			defaultHook: func(context.Context, codenav.DiffBlastRadiusArgs, codenav.RequestState) ([]codenav.AffectedSymbol, error) {
+				panic("unexpected invocation of MockCodeNavService.GetDiffBlastRadius")
+			},
+		},
 		GetHoverFunc: &CodeNavServiceGetHoverFunc{
 			defaultHook: func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) (string, shared1.Range, bool, error) {
 				panic("unexpected invocation of MockCodeNavService.GetHover")
@@ -394,6 +407,9 @@ func NewMockCodeNavServiceFrom(i CodeNavService) *MockCodeNavService {
 		GetDiagnosticsFunc: &CodeNavServiceGetDiagnosticsFunc{
 			defaultHook: i.GetDiagnostics,
 		},
+		GetDiffBlastRadiusFunc: &CodeNavServiceGetDiffBlastRadiusFunc{
+			defaultHook: i.GetDiffBlastRadius,
+		},
 		GetHoverFunc: &CodeNavServiceGetHoverFunc{
 			defaultHook: i.GetHover,
 		},
@@ -775,6 +791,113 @@ func (c CodeNavServiceGetDiagnosticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// CodeNavServiceGetDiffBlastRadiusFunc describes the behavior when the
+// GetDiffBlastRadius method of the parent MockCodeNavService instance is
+// invoked.
+type CodeNavServiceGetDiffBlastRadiusFunc struct {
+	defaultHook func(context.Context, codenav.DiffBlastRadiusArgs, codenav.RequestState) ([]codenav.AffectedSymbol, error)
+	hooks       []func(context.Context, codenav.DiffBlastRadiusArgs, codenav.RequestState) ([]codenav.AffectedSymbol, error)
+	history     []CodeNavServiceGetDiffBlastRadiusFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDiffBlastRadius delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeNavService) GetDiffBlastRadius(v0 context.Context, v1 codenav.DiffBlastRadiusArgs, v2 codenav.RequestState) ([]codenav.AffectedSymbol, error) {
+	r0, r1 := m.GetDiffBlastRadiusFunc.nextHook()(v0, v1, v2)
+	m.GetDiffBlastRadiusFunc.appendCall(CodeNavServiceGetDiffBlastRadiusFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDiffBlastRadius
+// method of the parent MockCodeNavService instance is invoked and the hook
+// queue is empty.
+func (f *CodeNavServiceGetDiffBlastRadiusFunc) SetDefaultHook(hook func(context.Context, codenav.DiffBlastRadiusArgs, codenav.RequestState) ([]codenav.AffectedSymbol, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDiffBlastRadius method of the parent MockCodeNavService instance invokes
+// the hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *CodeNavServiceGetDiffBlastRadiusFunc) PushHook(hook func(context.Context, codenav.DiffBlastRadiusArgs, codenav.RequestState) ([]codenav.AffectedSymbol, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the given
+// values.
+func (f *CodeNavServiceGetDiffBlastRadiusFunc) SetDefaultReturn(r0 []codenav.AffectedSymbol, r1 error) {
+	f.SetDefaultHook(func(context.Context, codenav.DiffBlastRadiusArgs, codenav.RequestState) ([]codenav.AffectedSymbol, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeNavServiceGetDiffBlastRadiusFunc) PushReturn(r0 []codenav.AffectedSymbol, r1 error) {
+	f.PushHook(func(context.Context, codenav.DiffBlastRadiusArgs, codenav.RequestState) ([]codenav.AffectedSymbol, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeNavServiceGetDiffBlastRadiusFunc) nextHook() func(context.Context, codenav.DiffBlastRadiusArgs, codenav.RequestState) ([]codenav.AffectedSymbol, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeNavServiceGetDiffBlastRadiusFunc) appendCall(r0 CodeNavServiceGetDiffBlastRadiusFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeNavServiceGetDiffBlastRadiusFuncCall
+// objects describing the invocations of this function.
+func (f *CodeNavServiceGetDiffBlastRadiusFunc) History() []CodeNavServiceGetDiffBlastRadiusFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeNavServiceGetDiffBlastRadiusFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeNavServiceGetDiffBlastRadiusFuncCall is an object that describes an
+// invocation of method GetDiffBlastRadius on an instance of MockCodeNavService.
+type CodeNavServiceGetDiffBlastRadiusFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 codenav.DiffBlastRadiusArgs
+	// Arg2 is the value of the 3rd argument passed to this method invocation.
+	Arg2 codenav.RequestState
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []codenav.AffectedSymbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this invocation.
+func (c CodeNavServiceGetDiffBlastRadiusFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this invocation.
+func (c CodeNavServiceGetDiffBlastRadiusFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeNavServiceGetHoverFunc describes the behavior when the GetHover
 // method of the parent MockCodeNavService instance is invoked.
 type CodeNavServiceGetHoverFunc struct {
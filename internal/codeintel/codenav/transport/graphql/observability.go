@@ -26,6 +26,7 @@ type operations struct {
 	snapshot        *observation.Operation
 	visibleIndexes  *observation.Operation
 	usagesForSymbol *observation.Operation
+	diffBlastRadius *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -59,6 +60,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		snapshot:        op("Snapshot"),
 		visibleIndexes:  op("VisibleIndexes"),
 		usagesForSymbol: op("UsagesForSymbol"),
+		diffBlastRadius: op("DiffBlastRadius"),
 	}
 }
 
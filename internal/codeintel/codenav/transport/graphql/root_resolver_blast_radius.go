@@ -0,0 +1,104 @@
+package graphql
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/core"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/shared/resolvers/gitresolvers"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+const DefaultBlastRadiusReferencesPageSize = 100
+
+// 🚨 SECURITY: dbstore layer handles authz for query resolution
+func (r *rootResolver) DiffBlastRadius(ctx context.Context, args *resolverstubs.DiffBlastRadiusArgs) (_ []resolverstubs.AffectedSymbolResolver, err error) {
+	ctx, _, endObservation := r.operations.diffBlastRadius.WithErrors(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("repository", string(args.Repository)),
+		attribute.String("base", args.Base),
+		attribute.String("head", args.Head),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	limit := int(pointers.Deref(args.ReferencesFirst, DefaultBlastRadiusReferencesPageSize))
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	repositoryID, err := resolverstubs.UnmarshalID[api.RepoID](args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := r.repoStore.Get(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := r.gitserverClient.ResolveRevision(ctx, repo.Name, args.Base, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving base revision")
+	}
+	head, err := r.gitserverClient.ResolveRevision(ctx, repo.Name, args.Head, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving head revision")
+	}
+
+	reqState := codenav.NewRequestState(
+		nil,
+		r.repoStore,
+		authz.DefaultSubRepoPermsChecker,
+		r.gitserverClient,
+		repo,
+		base,
+		core.NewRepoRelPathUnchecked(""),
+		r.maximumIndexesPerMonikerSearch,
+	)
+
+	symbols, err := r.svc.GetDiffBlastRadius(ctx, codenav.DiffBlastRadiusArgs{
+		RepositoryID:    repo.ID,
+		RepoName:        repo.Name,
+		Base:            base,
+		Head:            head,
+		ReferencesLimit: limit,
+	}, reqState)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.GetDiffBlastRadius")
+	}
+
+	locationResolver := r.locationResolverFactory.Create()
+	resolvers := make([]resolverstubs.AffectedSymbolResolver, 0, len(symbols))
+	for _, symbol := range symbols {
+		resolvers = append(resolvers, &affectedSymbolResolver{symbol: symbol, locationResolver: locationResolver})
+	}
+
+	return resolvers, nil
+}
+
+type affectedSymbolResolver struct {
+	symbol           codenav.AffectedSymbol
+	locationResolver *gitresolvers.CachedLocationResolver
+}
+
+func (r *affectedSymbolResolver) Symbol() string {
+	return r.symbol.Symbol
+}
+
+func (r *affectedSymbolResolver) Definition(ctx context.Context) (resolverstubs.LocationResolver, error) {
+	return resolveLocation(ctx, r.locationResolver, r.symbol.Definition)
+}
+
+func (r *affectedSymbolResolver) ReferenceCount() int32 {
+	return int32(r.symbol.ReferenceCount)
+}
+
+func (r *affectedSymbolResolver) References() resolverstubs.LocationConnectionResolver {
+	return newLocationConnectionResolver(r.symbol.References, nil, r.locationResolver)
+}
@@ -9,4 +9,7 @@ const (
 	PythonPackagesScheme = shared.PythonPackagesScheme
 	RustPackagesScheme   = shared.RustPackagesScheme
 	RubyPackagesScheme   = shared.RubyPackagesScheme
+	DotNetPackagesScheme = shared.DotNetPackagesScheme
+	PHPPackagesScheme    = shared.PHPPackagesScheme
+	ElixirPackagesScheme = shared.ElixirPackagesScheme
 )
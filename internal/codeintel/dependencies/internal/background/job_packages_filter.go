@@ -104,7 +104,7 @@ func (j *packagesFilterApplicatorJob) handle(ctx context.Context) (err error) {
 	nextSyncAt := time.Now()
 
 	extsvcs, err := j.extsvcStore.List(ctx, database.ExternalServicesListOptions{
-		Kinds: []string{extsvc.KindJVMPackages, extsvc.KindNpmPackages, extsvc.KindGoPackages, extsvc.KindRustPackages, extsvc.KindRubyPackages, extsvc.KindPythonPackages},
+		Kinds: []string{extsvc.KindJVMPackages, extsvc.KindNpmPackages, extsvc.KindGoPackages, extsvc.KindRustPackages, extsvc.KindRubyPackages, extsvc.KindPythonPackages, extsvc.KindDotNetPackages, extsvc.KindPHPPackages, extsvc.KindElixirPackages},
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to list package repo external services")
@@ -7,4 +7,7 @@ const (
 	PythonPackagesScheme = "python"
 	RustPackagesScheme   = "rust-analyzer"
 	RubyPackagesScheme   = "scip-ruby"
+	DotNetPackagesScheme = "scip-dotnet"
+	PHPPackagesScheme    = "scip-php"
+	ElixirPackagesScheme = "hex"
 )
@@ -2,8 +2,11 @@ package policies
 
 import (
 	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 )
 
 type UploadService interface {
+	GetUploads(ctx context.Context, opts shared.GetUploadsOptions) ([]shared.Upload, int, error)
 	GetCommitsVisibleToUpload(ctx context.Context, uploadID, limit int, token *string) (_ []string, nextToken *string, err error)
 }
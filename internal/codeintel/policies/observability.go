@@ -12,6 +12,8 @@ type operations struct {
 	getRetentionPolicyOverview *observation.Operation
 	getPreviewRepositoryFilter *observation.Operation
 	getPreviewGitObjectFilter  *observation.Operation
+
+	simulateConfigurationPolicies *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -39,5 +41,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getRetentionPolicyOverview: op("GetRetentionPolicyOverview"),
 		getPreviewRepositoryFilter: op("GetPreviewRepositoryFilter"),
 		getPreviewGitObjectFilter:  op("GetPreviewGitObjectFilter"),
+
+		simulateConfigurationPolicies: op("SimulateConfigurationPolicies"),
 	}
 }
@@ -0,0 +1,305 @@
+package policies
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	policiesshared "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+const simulationUploadBatchSize = 100
+
+// simulationSchedulerInterval is the time between two passes of the auto-indexing scheduler over
+// a repository. This matches the default of CODEINTEL_AUTOINDEXING_SCHEDULER_REPOSITORY_PROCESS_DELAY.
+const simulationSchedulerInterval = 24 * time.Hour
+
+// PolicySimulation describes the effect a draft set of configuration policies would have on
+// a repository if it replaced the policies that currently apply to it.
+type PolicySimulation struct {
+	// RetainedUploads are the live uploads protected by the draft policies. Uploads that remain
+	// protected now but not at the end of the simulated horizon have ExpiresWithinHorizon set.
+	RetainedUploads []SimulatedUpload
+
+	// ExpiredUploads are the live uploads no draft policy protects. The expirer would mark these
+	// as expired on its next pass over the repository.
+	ExpiredUploads []SimulatedUpload
+
+	// IndexedCommits are the commits matching a draft precise indexing policy that do not yet have a
+	// completed upload. Commits that the scheduler would no longer consider by its last pass within
+	// the simulated horizon have AgesOutWithinHorizon set. Commits pushed later cannot be predicted
+	// and are not included.
+	IndexedCommits []SimulatedCommit
+
+	// ExpiredBytes is the estimated storage released by the expired uploads, and ExpiringBytes
+	// the estimated storage released by the retained uploads expiring within the horizon.
+	ExpiredBytes  int64
+	ExpiringBytes int64
+
+	// IndexedBytes is the estimated storage required by the indexed commits, based on the
+	// average size of the live uploads in the repository.
+	IndexedBytes int64
+}
+
+// SimulatedUpload is a live upload along with the draft policies protecting it, if any.
+type SimulatedUpload struct {
+	Upload               shared.Upload
+	Policies             []policiesshared.ConfigurationPolicy
+	ExpiresWithinHorizon bool
+}
+
+// SimulatedCommit is a commit along with the draft policies that would cause it to be indexed.
+type SimulatedCommit struct {
+	Commit               string
+	CommittedAt          time.Time
+	Policies             []policiesshared.ConfigurationPolicy
+	AgesOutWithinHorizon bool
+}
+
+// SimulateConfigurationPolicies reports which of the given repository's uploads the expirer would
+// retain or expire, and which of its commits the auto-indexing scheduler would index, if the given
+// draft policies were the only configuration policies applying to the repository. As with the real
+// policies, the tip of the default branch is always retained. Uploads are evaluated both at the
+// given time and at the end of the given horizon. Commits are evaluated at the scheduler passes
+// over the repository within the horizon, starting at the given time.
+//
+// This method does not mutate any state.
+func (s *Service) SimulateConfigurationPolicies(
+	ctx context.Context,
+	repositoryID int,
+	policies []policiesshared.ConfigurationPolicy,
+	horizon time.Duration,
+	now time.Time,
+) (_ PolicySimulation, err error) {
+	ctx, _, endObservation := s.operations.simulateConfigurationPolicies.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", repositoryID),
+		attribute.Int("numPolicies", len(policies)),
+		attribute.String("horizon", horizon.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	repo, err := s.repoStore.Get(ctx, api.RepoID(repositoryID))
+	if err != nil {
+		return PolicySimulation{}, err
+	}
+
+	// Draft policies are not persisted and need not have identifiers, so we number them by
+	// position for the matcher and resolve matches back to the given policies.
+	var retentionPolicies, indexingPolicies []policiesshared.ConfigurationPolicy
+	for i, policy := range policies {
+		policy.ID = i + 1
+		if policy.RetentionEnabled {
+			retentionPolicies = append(retentionPolicies, policy)
+		}
+		if policy.PreciseIndexingEnabled {
+			indexingPolicies = append(indexingPolicies, policy)
+		}
+	}
+
+	// Mirror the matchers used by the upload expirer and the auto-indexing scheduler
+	retentionMatches, err := s.getPolicyMatcherFromFactory(RetentionExtractor, true, false).CommitsDescribedByPolicy(ctx, repositoryID, repo.Name, retentionPolicies, now)
+	if err != nil {
+		return PolicySimulation{}, err
+	}
+	indexingMatcher := s.getPolicyMatcherFromFactory(IndexingExtractor, false, true)
+	indexingMatches, err := indexingMatcher.CommitsDescribedByPolicy(ctx, repositoryID, repo.Name, indexingPolicies, now)
+	if err != nil {
+		return PolicySimulation{}, err
+	}
+
+	// The scheduler passes over the repository at regular intervals. Existing commits only ever
+	// age out of indexing policies, so the first pass describes every commit that any later pass
+	// describes, and the last pass within the horizon describes only the commits that every
+	// earlier pass describes.
+	lastIndexingMatches := indexingMatches
+	if lastPass := now.Add(horizon.Truncate(simulationSchedulerInterval)); lastPass.After(now) {
+		lastIndexingMatches, err = indexingMatcher.CommitsDescribedByPolicy(ctx, repositoryID, repo.Name, indexingPolicies, lastPass)
+		if err != nil {
+			return PolicySimulation{}, err
+		}
+	}
+
+	uploads, err := s.getLiveUploads(ctx, repositoryID)
+	if err != nil {
+		return PolicySimulation{}, err
+	}
+
+	var (
+		simulation      PolicySimulation
+		totalUploadSize int64
+		uploadedCommits = make(map[string]struct{}, len(uploads))
+	)
+
+	for _, upload := range uploads {
+		uploadedCommits[upload.Commit] = struct{}{}
+		totalUploadSize += estimatedUploadSize(upload)
+
+		visibleCommits, err := s.getCommitsVisibleToUpload(ctx, upload)
+		if err != nil {
+			return PolicySimulation{}, err
+		}
+
+		protectingNow := protectingPolicies(retentionMatches, policies, visibleCommits, upload, now)
+		if protectingNow == nil {
+			simulation.ExpiredUploads = append(simulation.ExpiredUploads, SimulatedUpload{Upload: upload})
+			simulation.ExpiredBytes += estimatedUploadSize(upload)
+			continue
+		}
+
+		expiresWithinHorizon := protectingPolicies(retentionMatches, policies, visibleCommits, upload, now.Add(horizon)) == nil
+		if expiresWithinHorizon {
+			simulation.ExpiringBytes += estimatedUploadSize(upload)
+		}
+
+		simulation.RetainedUploads = append(simulation.RetainedUploads, SimulatedUpload{
+			Upload:               upload,
+			Policies:             protectingNow,
+			ExpiresWithinHorizon: expiresWithinHorizon,
+		})
+	}
+
+	for commit, policyMatches := range indexingMatches {
+		if _, ok := uploadedCommits[commit]; ok || len(policyMatches) == 0 {
+			continue
+		}
+
+		simulation.IndexedCommits = append(simulation.IndexedCommits, SimulatedCommit{
+			Commit:               commit,
+			CommittedAt:          policyMatches[0].CommittedAt,
+			Policies:             matchedPolicies(policyMatches, policies),
+			AgesOutWithinHorizon: len(lastIndexingMatches[commit]) == 0,
+		})
+	}
+	sort.Slice(simulation.IndexedCommits, func(i, j int) bool {
+		if !simulation.IndexedCommits[i].CommittedAt.Equal(simulation.IndexedCommits[j].CommittedAt) {
+			return simulation.IndexedCommits[i].CommittedAt.After(simulation.IndexedCommits[j].CommittedAt)
+		}
+
+		return simulation.IndexedCommits[i].Commit < simulation.IndexedCommits[j].Commit
+	})
+
+	if len(uploads) > 0 {
+		simulation.IndexedBytes = int64(len(simulation.IndexedCommits)) * (totalUploadSize / int64(len(uploads)))
+	}
+
+	return simulation, nil
+}
+
+// getLiveUploads returns the completed and unexpired uploads of the given repository that the
+// expirer would consider, oldest first.
+func (s *Service) getLiveUploads(ctx context.Context, repositoryID int) (uploads []shared.Upload, err error) {
+	for offset := 0; ; {
+		batch, totalCount, err := s.uploadSvc.GetUploads(ctx, shared.GetUploadsOptions{
+			State:         "completed",
+			RepositoryID:  repositoryID,
+			AllowExpired:  false,
+			OldestFirst:   true,
+			InCommitGraph: true,
+			Limit:         simulationUploadBatchSize,
+			Offset:        offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		offset += len(batch)
+		uploads = append(uploads, batch...)
+
+		if len(batch) == 0 || offset >= totalCount {
+			return uploads, nil
+		}
+	}
+}
+
+// protectingPolicies returns the set of policies that protect the given upload at the given time
+// via a match on any of its visible commits, or nil if the upload is unprotected. A match for the
+// tip of the default branch is represented by a zero-valued policy.
+func protectingPolicies(
+	commitMap map[string][]PolicyMatch,
+	policies []policiesshared.ConfigurationPolicy,
+	visibleCommits []string,
+	upload shared.Upload,
+	now time.Time,
+) []policiesshared.ConfigurationPolicy {
+	// The upload's own commit is always visible to it
+	commits := append([]string{upload.Commit}, visibleCommits...)
+
+	var (
+		protecting []policiesshared.ConfigurationPolicy
+		seen       = map[int]struct{}{}
+	)
+	for _, commit := range commits {
+		for _, policyMatch := range commitMap[commit] {
+			if policyMatch.PolicyDuration != nil && now.Sub(upload.UploadedAt) >= *policyMatch.PolicyDuration {
+				continue
+			}
+
+			policyID := -1
+			if policyMatch.PolicyID != nil {
+				policyID = *policyMatch.PolicyID
+			}
+			if _, ok := seen[policyID]; ok {
+				continue
+			}
+			seen[policyID] = struct{}{}
+
+			if policy := draftPolicyByID(policies, policyID); policy != nil {
+				protecting = append(protecting, *policy)
+			} else {
+				protecting = append(protecting, policiesshared.ConfigurationPolicy{})
+			}
+		}
+	}
+
+	return protecting
+}
+
+// matchedPolicies returns the distinct policies referenced by the given matches.
+func matchedPolicies(policyMatches []PolicyMatch, policies []policiesshared.ConfigurationPolicy) []policiesshared.ConfigurationPolicy {
+	var (
+		matched []policiesshared.ConfigurationPolicy
+		seen    = map[int]struct{}{}
+	)
+	for _, policyMatch := range policyMatches {
+		if policyMatch.PolicyID == nil {
+			continue
+		}
+		if _, ok := seen[*policyMatch.PolicyID]; ok {
+			continue
+		}
+		seen[*policyMatch.PolicyID] = struct{}{}
+
+		if policy := draftPolicyByID(policies, *policyMatch.PolicyID); policy != nil {
+			matched = append(matched, *policy)
+		}
+	}
+
+	return matched
+}
+
+// draftPolicyByID returns the draft policy numbered with the given identifier, if any.
+func draftPolicyByID(policies []policiesshared.ConfigurationPolicy, id int) *policiesshared.ConfigurationPolicy {
+	if id < 1 || id > len(policies) {
+		return nil
+	}
+
+	return &policies[id-1]
+}
+
+// estimatedUploadSize returns the uncompressed size of the given upload, which approximates the
+// space its processed data occupies in the codeintel database, falling back to its upload size.
+func estimatedUploadSize(upload shared.Upload) int64 {
+	if upload.UncompressedSize != nil {
+		return *upload.UncompressedSize
+	}
+	if upload.UploadSize != nil {
+		return *upload.UploadSize
+	}
+
+	return 0
+}
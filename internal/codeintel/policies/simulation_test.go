@@ -0,0 +1,108 @@
+package policies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/google/go-cmp/cmp"
+
+	policiesshared "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestSimulateConfigurationPolicies(t *testing.T) {
+	mockStore := NewMockStore()
+	mockRepoStore := defaultMockRepoStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+
+	svc := newService(observation.TestContextTB(t), mockStore, mockRepoStore, mockUploadSvc, mockGitserverClient)
+
+	now := glock.NewMockClock().Now()
+	day := 24 * time.Hour
+
+	// deadbeef0 -- deadbeef1 (v1.0.0) -- deadbeef2 -- deadbeef3 (main)
+	mockGitserverClient.ListRefsFunc.SetDefaultReturn([]gitdomain.Ref{
+		{Name: "refs/heads/main", ShortName: "main", Type: gitdomain.RefTypeBranch, IsHead: true, CommitID: "deadbeef3", CreatedDate: now.Add(-time.Hour)},
+		{Name: "refs/tags/v1.0.0", ShortName: "v1.0.0", Type: gitdomain.RefTypeTag, CommitID: "deadbeef1", CreatedDate: now.Add(-100 * day)},
+	}, nil)
+
+	uploads := []shared.Upload{
+		{ID: 1, Commit: "deadbeef0", UploadedAt: now.Add(-40 * day), UncompressedSize: pointers.Ptr(int64(200))},
+		{ID: 2, Commit: "deadbeef1", UploadedAt: now.Add(-29 * day), UncompressedSize: pointers.Ptr(int64(100))},
+		{ID: 3, Commit: "deadbeef2", UploadedAt: now.Add(-1 * day), UploadSize: pointers.Ptr(int64(300))},
+	}
+	mockUploadSvc.GetUploadsFunc.PushReturn(uploads, len(uploads), nil)
+	mockUploadSvc.GetCommitsVisibleToUploadFunc.SetDefaultHook(func(_ context.Context, uploadID, _ int, _ *string) ([]string, *string, error) {
+		switch uploadID {
+		case 1:
+			return []string{"deadbeef0"}, nil, nil
+		case 2:
+			return []string{"deadbeef1"}, nil, nil
+		default:
+			return []string{"deadbeef2", "deadbeef3"}, nil, nil
+		}
+	})
+
+	tagPolicy := policiesshared.ConfigurationPolicy{
+		Name:              "tags",
+		Type:              policiesshared.GitObjectTypeTag,
+		Pattern:           "*",
+		RetentionEnabled:  true,
+		RetentionDuration: pointers.Ptr(30 * day),
+	}
+	branchPolicy := policiesshared.ConfigurationPolicy{
+		Name:                   "branches",
+		Type:                   policiesshared.GitObjectTypeTree,
+		Pattern:                "*",
+		PreciseIndexingEnabled: true,
+		IndexCommitMaxAge:      pointers.Ptr(36 * time.Hour),
+	}
+
+	simulation, err := svc.SimulateConfigurationPolicies(context.Background(), 50, []policiesshared.ConfigurationPolicy{tagPolicy, branchPolicy}, 2*day, now)
+	if err != nil {
+		t.Fatalf("unexpected error simulating policies: %s", err)
+	}
+
+	expected := PolicySimulation{
+		RetainedUploads: []SimulatedUpload{
+			{Upload: uploads[1], Policies: []policiesshared.ConfigurationPolicy{tagPolicy}, ExpiresWithinHorizon: true},
+			{Upload: uploads[2], Policies: []policiesshared.ConfigurationPolicy{{}}},
+		},
+		ExpiredUploads: []SimulatedUpload{
+			{Upload: uploads[0]},
+		},
+		IndexedCommits: []SimulatedCommit{
+			// main is 49 hours old by the last scheduler pass within the horizon
+			{Commit: "deadbeef3", CommittedAt: now.Add(-time.Hour), Policies: []policiesshared.ConfigurationPolicy{branchPolicy}, AgesOutWithinHorizon: true},
+		},
+		ExpiredBytes:  200,
+		ExpiringBytes: 100,
+		IndexedBytes:  200,
+	}
+	if diff := cmp.Diff(expected, simulation); diff != "" {
+		t.Errorf("unexpected simulation (-want +got):\n%s", diff)
+	}
+
+	// main is only 25 hours old by the last scheduler pass within a shorter horizon
+	simulation, err = svc.SimulateConfigurationPolicies(context.Background(), 50, []policiesshared.ConfigurationPolicy{tagPolicy, branchPolicy}, 36*time.Hour, now)
+	if err != nil {
+		t.Fatalf("unexpected error simulating policies: %s", err)
+	}
+	expectedIndexedCommits := []SimulatedCommit{
+		{Commit: "deadbeef3", CommittedAt: now.Add(-time.Hour), Policies: []policiesshared.ConfigurationPolicy{branchPolicy}},
+	}
+	if diff := cmp.Diff(expectedIndexedCommits, simulation.IndexedCommits); diff != "" {
+		t.Errorf("unexpected indexed commits (-want +got):\n%s", diff)
+	}
+
+	if history := mockStore.GetConfigurationPoliciesFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected calls to GetConfigurationPolicies: %d", len(history))
+	}
+}
@@ -2,10 +2,14 @@ package graphql
 
 import (
 	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
 
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/policies"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/policies/shared"
 	policiesshared "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/shared"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 )
 
 type PoliciesService interface {
@@ -21,4 +25,11 @@ type PoliciesService interface {
 	// Filter previews
 	GetPreviewRepositoryFilter(ctx context.Context, patterns []string, limit int) (_ []int, totalCount int, matchesAll bool, repositoryMatchLimit *int, _ error)
 	GetPreviewGitObjectFilter(ctx context.Context, repositoryID int, gitObjectType shared.GitObjectType, pattern string, limit int, countObjectsYoungerThanHours *int32) (_ []policies.GitObject, totalCount int, totalCountYoungerThanThreshold *int, _ error)
+
+	// Policy simulation
+	SimulateConfigurationPolicies(ctx context.Context, repositoryID int, configurationPolicies []shared.ConfigurationPolicy, horizon time.Duration, now time.Time) (policies.PolicySimulation, error)
+}
+
+type PreciseIndexResolver interface {
+	PreciseIndexByID(ctx context.Context, id graphql.ID) (resolverstubs.PreciseIndexResolver, error)
 }
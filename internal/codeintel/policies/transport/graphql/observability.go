@@ -15,6 +15,8 @@ type operations struct {
 	previewGitObjectFilter    *observation.Operation
 	previewRepoFilter         *observation.Operation
 	updateConfigurationPolicy *observation.Operation
+
+	simulateConfigurationPolicies *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -41,5 +43,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		previewGitObjectFilter:    op("PreviewGitObjectFilter"),
 		previewRepoFilter:         op("PreviewRepoFilter"),
 		updateConfigurationPolicy: op("UpdateConfigurationPolicy"),
+
+		simulateConfigurationPolicies: op("SimulateConfigurationPolicies"),
 	}
 }
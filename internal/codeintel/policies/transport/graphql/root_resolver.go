@@ -9,10 +9,11 @@ import (
 )
 
 type rootResolver struct {
-	policySvc        PoliciesService
-	repoStore        database.RepoStore
-	siteAdminChecker sharedresolvers.SiteAdminChecker
-	operations       *operations
+	policySvc            PoliciesService
+	repoStore            database.RepoStore
+	siteAdminChecker     sharedresolvers.SiteAdminChecker
+	preciseIndexResolver PreciseIndexResolver
+	operations           *operations
 }
 
 func NewRootResolver(
@@ -20,11 +21,13 @@ func NewRootResolver(
 	policySvc *policies.Service,
 	repoStore database.RepoStore,
 	siteAdminChecker sharedresolvers.SiteAdminChecker,
+	preciseIndexResolver PreciseIndexResolver,
 ) resolverstubs.PoliciesServiceResolver {
 	return &rootResolver{
-		policySvc:        policySvc,
-		repoStore:        repoStore,
-		siteAdminChecker: siteAdminChecker,
-		operations:       newOperations(observationCtx),
+		policySvc:            policySvc,
+		repoStore:            repoStore,
+		siteAdminChecker:     siteAdminChecker,
+		preciseIndexResolver: preciseIndexResolver,
+		operations:           newOperations(observationCtx),
 	}
 }
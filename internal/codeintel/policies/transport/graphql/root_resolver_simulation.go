@@ -0,0 +1,240 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/policies"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/policies/shared"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+const (
+	DefaultPolicySimulationPageSize = 50
+	maxPolicySimulationHorizonDays  = 3650 // 10 years
+)
+
+// 🚨 SECURITY: Only site admins may simulate code intelligence configuration policies
+func (r *rootResolver) SimulateCodeIntelligenceConfigurationPolicies(ctx context.Context, id graphql.ID, args *resolverstubs.SimulateCodeIntelligenceConfigurationPoliciesArgs) (_ resolverstubs.CodeIntelligenceConfigurationPolicySimulationResolver, err error) {
+	ctx, _, endObservation := r.operations.simulateConfigurationPolicies.WithErrors(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("repository", string(id)),
+		attribute.Int("numPolicies", len(args.Policies)),
+		attribute.Int("horizonDays", int(args.HorizonDays)),
+		attribute.Int("first", int(pointers.Deref(args.First, 0))),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	if err := r.siteAdminChecker.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	repositoryID, err := resolverstubs.UnmarshalID[int](id)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.HorizonDays < 0 || args.HorizonDays > maxPolicySimulationHorizonDays {
+		return nil, errors.Errorf("illegal horizon '%d'", args.HorizonDays)
+	}
+
+	drafts := make([]shared.ConfigurationPolicy, 0, len(args.Policies))
+	for _, draft := range args.Policies {
+		if err := validateConfigurationPolicy(resolverstubs.CodeIntelConfigurationPolicy{
+			Name:                      draft.Name,
+			Type:                      draft.Type,
+			Pattern:                   draft.Pattern,
+			RetentionEnabled:          draft.RetentionEnabled,
+			RetentionDurationHours:    draft.RetentionDurationHours,
+			RetainIntermediateCommits: draft.RetainIntermediateCommits,
+			IndexingEnabled:           draft.IndexingEnabled,
+			SyntacticIndexingEnabled:  pointers.Ptr(false),
+			IndexCommitMaxAgeHours:    draft.IndexCommitMaxAgeHours,
+			IndexIntermediateCommits:  draft.IndexIntermediateCommits,
+		}); err != nil {
+			return nil, err
+		}
+
+		drafts = append(drafts, shared.ConfigurationPolicy{
+			RepositoryID:              &repositoryID,
+			Name:                      draft.Name,
+			Type:                      shared.GitObjectType(draft.Type),
+			Pattern:                   draft.Pattern,
+			RetentionEnabled:          draft.RetentionEnabled,
+			RetentionDuration:         toDuration(draft.RetentionDurationHours),
+			RetainIntermediateCommits: draft.RetainIntermediateCommits,
+			PreciseIndexingEnabled:    draft.IndexingEnabled,
+			IndexCommitMaxAge:         toDuration(draft.IndexCommitMaxAgeHours),
+			IndexIntermediateCommits:  draft.IndexIntermediateCommits,
+		})
+	}
+
+	simulation, err := r.policySvc.SimulateConfigurationPolicies(ctx, repositoryID, drafts, time.Duration(args.HorizonDays)*24*time.Hour, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(args.Limit(DefaultPolicySimulationPageSize))
+	retainedUploads := truncate(simulation.RetainedUploads, limit)
+	expiredUploads := truncate(simulation.ExpiredUploads, limit)
+	indexedCommits := truncate(simulation.IndexedCommits, limit)
+
+	newIndexResolvers := func(simulatedUploads []policies.SimulatedUpload) []resolverstubs.CodeIntelligencePolicySimulationIndexResolver {
+		resolvers := make([]resolverstubs.CodeIntelligencePolicySimulationIndexResolver, 0, len(simulatedUploads))
+		for _, simulatedUpload := range simulatedUploads {
+			resolvers = append(resolvers, &policySimulationIndexResolver{
+				preciseIndexResolver: r.preciseIndexResolver,
+				uploadID:             simulatedUpload.Upload.ID,
+				protectingPolicies:   policyNames(simulatedUpload.Policies),
+				expiresWithinHorizon: simulatedUpload.ExpiresWithinHorizon,
+			})
+		}
+
+		return resolvers
+	}
+
+	commitResolvers := make([]resolverstubs.CodeIntelligencePolicySimulationCommitResolver, 0, len(indexedCommits))
+	for _, indexedCommit := range indexedCommits {
+		commitResolvers = append(commitResolvers, &policySimulationCommitResolver{
+			rev:                  indexedCommit.Commit,
+			committedAt:          indexedCommit.CommittedAt,
+			policies:             policyNames(indexedCommit.Policies),
+			agesOutWithinHorizon: indexedCommit.AgesOutWithinHorizon,
+		})
+	}
+
+	return &policySimulationResolver{
+		retainedIndexes:      newIndexResolvers(retainedUploads),
+		retainedIndexesCount: len(simulation.RetainedUploads),
+		expiredIndexes:       newIndexResolvers(expiredUploads),
+		expiredIndexesCount:  len(simulation.ExpiredUploads),
+		indexedCommits:       commitResolvers,
+		indexedCommitsCount:  len(simulation.IndexedCommits),
+		expiredBytes:         simulation.ExpiredBytes,
+		expiringBytes:        simulation.ExpiringBytes,
+		indexedBytes:         simulation.IndexedBytes,
+	}, nil
+}
+
+func truncate[T any](values []T, limit int) []T {
+	if limit < len(values) {
+		return values[:limit]
+	}
+
+	return values
+}
+
+func policyNames(configurationPolicies []shared.ConfigurationPolicy) []string {
+	names := make([]string, 0, len(configurationPolicies))
+	for _, policy := range configurationPolicies {
+		names = append(names, policy.Name)
+	}
+
+	return names
+}
+
+//
+//
+
+type policySimulationResolver struct {
+	retainedIndexes      []resolverstubs.CodeIntelligencePolicySimulationIndexResolver
+	retainedIndexesCount int
+	expiredIndexes       []resolverstubs.CodeIntelligencePolicySimulationIndexResolver
+	expiredIndexesCount  int
+	indexedCommits       []resolverstubs.CodeIntelligencePolicySimulationCommitResolver
+	indexedCommitsCount  int
+	expiredBytes         int64
+	expiringBytes        int64
+	indexedBytes         int64
+}
+
+func (r *policySimulationResolver) RetainedIndexes() []resolverstubs.CodeIntelligencePolicySimulationIndexResolver {
+	return r.retainedIndexes
+}
+
+func (r *policySimulationResolver) RetainedIndexesCount() int32 {
+	return int32(r.retainedIndexesCount)
+}
+
+func (r *policySimulationResolver) ExpiredIndexes() []resolverstubs.CodeIntelligencePolicySimulationIndexResolver {
+	return r.expiredIndexes
+}
+
+func (r *policySimulationResolver) ExpiredIndexesCount() int32 {
+	return int32(r.expiredIndexesCount)
+}
+
+func (r *policySimulationResolver) IndexedCommits() []resolverstubs.CodeIntelligencePolicySimulationCommitResolver {
+	return r.indexedCommits
+}
+
+func (r *policySimulationResolver) IndexedCommitsCount() int32 {
+	return int32(r.indexedCommitsCount)
+}
+
+func (r *policySimulationResolver) ExpiredBytes() float64 {
+	return float64(r.expiredBytes)
+}
+
+func (r *policySimulationResolver) ExpiringBytes() float64 {
+	return float64(r.expiringBytes)
+}
+
+func (r *policySimulationResolver) IndexedBytes() float64 {
+	return float64(r.indexedBytes)
+}
+
+//
+//
+
+type policySimulationIndexResolver struct {
+	preciseIndexResolver PreciseIndexResolver
+	uploadID             int
+	protectingPolicies   []string
+	expiresWithinHorizon bool
+}
+
+func (r *policySimulationIndexResolver) Index(ctx context.Context) (resolverstubs.PreciseIndexResolver, error) {
+	return r.preciseIndexResolver.PreciseIndexByID(ctx, resolverstubs.MarshalID("PreciseIndex", fmt.Sprintf("U:%d", r.uploadID)))
+}
+
+func (r *policySimulationIndexResolver) ProtectingPolicies() []string {
+	return r.protectingPolicies
+}
+
+func (r *policySimulationIndexResolver) ExpiresWithinHorizon() bool {
+	return r.expiresWithinHorizon
+}
+
+//
+//
+
+type policySimulationCommitResolver struct {
+	rev                  string
+	committedAt          time.Time
+	policies             []string
+	agesOutWithinHorizon bool
+}
+
+func (r *policySimulationCommitResolver) Rev() string {
+	return r.rev
+}
+
+func (r *policySimulationCommitResolver) CommittedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.committedAt}
+}
+
+func (r *policySimulationCommitResolver) Policies() []string {
+	return r.policies
+}
+
+func (r *policySimulationCommitResolver) AgesOutWithinHorizon() bool {
+	return r.agesOutWithinHorizon
+}
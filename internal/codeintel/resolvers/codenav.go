@@ -35,6 +35,7 @@ type CodeNavServiceResolver interface {
 	// CodeGraphDataByID materializes a CodeGraphDataResolver purely from a graphql.ID.
 	CodeGraphDataByID(ctx context.Context, id graphql.ID) (CodeGraphDataResolver, error)
 	UsagesForSymbol(ctx context.Context, args *UsagesForSymbolArgs) (UsageConnectionResolver, error)
+	DiffBlastRadius(ctx context.Context, args *DiffBlastRadiusArgs) ([]AffectedSymbolResolver, error)
 }
 
 const CodeGraphDataIDKind = "CodeGraphData"
@@ -80,6 +81,20 @@ type GitBlobLSIFDataResolver interface {
 	Snapshot(ctx context.Context, args *struct{ IndexID graphql.ID }) (_ *[]SnapshotDataResolver, err error)
 }
 
+type DiffBlastRadiusArgs struct {
+	Repository      graphql.ID
+	Base            string
+	Head            string
+	ReferencesFirst *int32
+}
+
+type AffectedSymbolResolver interface {
+	Symbol() string
+	Definition(ctx context.Context) (LocationResolver, error)
+	ReferenceCount() int32
+	References() LocationConnectionResolver
+}
+
 type SnapshotDataResolver interface {
 	Offset() int32
 	Data() string
@@ -21,6 +21,9 @@ type PoliciesServiceResolver interface {
 	// Filter previews
 	PreviewRepositoryFilter(ctx context.Context, args *PreviewRepositoryFilterArgs) (RepositoryFilterPreviewResolver, error)
 	PreviewGitObjectFilter(ctx context.Context, id graphql.ID, args *PreviewGitObjectFilterArgs) (GitObjectFilterPreviewResolver, error)
+
+	// Policy simulation
+	SimulateCodeIntelligenceConfigurationPolicies(ctx context.Context, id graphql.ID, args *SimulateCodeIntelligenceConfigurationPoliciesArgs) (CodeIntelligenceConfigurationPolicySimulationResolver, error)
 }
 
 type CodeIntelligenceConfigurationPoliciesArgs struct {
@@ -78,6 +81,24 @@ type PreviewGitObjectFilterArgs struct {
 	CountObjectsYoungerThanHours *int32
 }
 
+type SimulateCodeIntelligenceConfigurationPoliciesArgs struct {
+	ConnectionArgs
+	Policies    []CodeIntelConfigurationPolicyDraft
+	HorizonDays int32
+}
+
+type CodeIntelConfigurationPolicyDraft struct {
+	Name                      string
+	Type                      GitObjectType
+	Pattern                   string
+	RetentionEnabled          bool
+	RetentionDurationHours    *int32
+	RetainIntermediateCommits bool
+	IndexingEnabled           bool
+	IndexCommitMaxAgeHours    *int32
+	IndexIntermediateCommits  bool
+}
+
 type (
 	CodeIntelligenceConfigurationPolicyConnectionResolver = PagedConnectionWithTotalCountResolver[CodeIntelligenceConfigurationPolicyResolver]
 )
@@ -114,6 +135,31 @@ type GitObjectFilterPreviewResolver interface {
 	TotalCountYoungerThanThreshold() *int32
 }
 
+type CodeIntelligenceConfigurationPolicySimulationResolver interface {
+	RetainedIndexes() []CodeIntelligencePolicySimulationIndexResolver
+	RetainedIndexesCount() int32
+	ExpiredIndexes() []CodeIntelligencePolicySimulationIndexResolver
+	ExpiredIndexesCount() int32
+	IndexedCommits() []CodeIntelligencePolicySimulationCommitResolver
+	IndexedCommitsCount() int32
+	ExpiredBytes() float64
+	ExpiringBytes() float64
+	IndexedBytes() float64
+}
+
+type CodeIntelligencePolicySimulationIndexResolver interface {
+	Index(ctx context.Context) (PreciseIndexResolver, error)
+	ProtectingPolicies() []string
+	ExpiresWithinHorizon() bool
+}
+
+type CodeIntelligencePolicySimulationCommitResolver interface {
+	Rev() string
+	CommittedAt() gqlutil.DateTime
+	Policies() []string
+	AgesOutWithinHorizon() bool
+}
+
 type CodeIntelGitObjectResolver interface {
 	Name() string
 	Rev() string
@@ -96,6 +96,14 @@ func (r *Resolver) PreciseIndexByID(ctx context.Context, id graphql.ID) (_ Preci
 	return r.uploadsRootResolver.PreciseIndexByID(ctx, id)
 }
 
+func (r *Resolver) PreciseIndexAPIDiff(ctx context.Context, args *PreciseIndexAPIDiffArgs) (_ PreciseIndexAPIDiffResolver, err error) {
+	return r.uploadsRootResolver.PreciseIndexAPIDiff(ctx, args)
+}
+
+func (r *Resolver) CommitAPIDiff(ctx context.Context, args *CommitAPIDiffArgs) (_ PreciseIndexAPIDiffResolver, err error) {
+	return r.uploadsRootResolver.CommitAPIDiff(ctx, args)
+}
+
 func (r *Resolver) DeletePreciseIndex(ctx context.Context, args *struct{ ID graphql.ID }) (*EmptyResponse, error) {
 	return r.uploadsRootResolver.DeletePreciseIndex(ctx, args)
 }
@@ -140,6 +148,10 @@ func (r *Resolver) UsagesForSymbol(ctx context.Context, args *UsagesForSymbolArg
 	return r.codenavResolver.UsagesForSymbol(ctx, args)
 }
 
+func (r *Resolver) DiffBlastRadius(ctx context.Context, args *DiffBlastRadiusArgs) ([]AffectedSymbolResolver, error) {
+	return r.codenavResolver.DiffBlastRadius(ctx, args)
+}
+
 func (r *Resolver) ConfigurationPolicyByID(ctx context.Context, id graphql.ID) (_ CodeIntelligenceConfigurationPolicyResolver, err error) {
 	return r.policiesRootResolver.ConfigurationPolicyByID(ctx, id)
 }
@@ -192,6 +204,10 @@ func (r *Resolver) PreviewGitObjectFilter(ctx context.Context, id graphql.ID, ar
 	return r.policiesRootResolver.PreviewGitObjectFilter(ctx, id, args)
 }
 
+func (r *Resolver) SimulateCodeIntelligenceConfigurationPolicies(ctx context.Context, id graphql.ID, args *SimulateCodeIntelligenceConfigurationPoliciesArgs) (_ CodeIntelligenceConfigurationPolicySimulationResolver, err error) {
+	return r.policiesRootResolver.SimulateCodeIntelligenceConfigurationPolicies(ctx, id, args)
+}
+
 func (r *Resolver) RankingSummary(ctx context.Context) (_ GlobalRankingSummaryResolver, err error) {
 	return r.rankingServiceResolver.RankingSummary(ctx)
 }
@@ -14,6 +14,10 @@ type UploadsServiceResolver interface {
 	PreciseIndexByID(ctx context.Context, id graphql.ID) (PreciseIndexResolver, error)
 	IndexerKeys(ctx context.Context, args *IndexerKeyQueryArgs) ([]string, error)
 
+	// Compare precise indexes
+	PreciseIndexAPIDiff(ctx context.Context, args *PreciseIndexAPIDiffArgs) (PreciseIndexAPIDiffResolver, error)
+	CommitAPIDiff(ctx context.Context, args *CommitAPIDiffArgs) (PreciseIndexAPIDiffResolver, error)
+
 	// Modify precise indexes
 	DeletePreciseIndex(ctx context.Context, args *struct{ ID graphql.ID }) (*EmptyResponse, error)
 	DeletePreciseIndexes(ctx context.Context, args *DeletePreciseIndexesArgs) (*EmptyResponse, error)
@@ -43,6 +47,19 @@ type IndexerKeyQueryArgs struct {
 	Repo *graphql.ID
 }
 
+type PreciseIndexAPIDiffArgs struct {
+	Base graphql.ID
+	Head graphql.ID
+}
+
+type CommitAPIDiffArgs struct {
+	Repository graphql.ID
+	Base       string
+	Head       string
+	Root       string
+	Indexer    *string
+}
+
 type DeletePreciseIndexesArgs struct {
 	Query           *string
 	States          *[]string
@@ -59,6 +76,26 @@ type ReindexPreciseIndexesArgs struct {
 	IsLatestForRepo *bool
 }
 
+type PreciseIndexAPIDiffResolver interface {
+	Added() []APISymbolResolver
+	Removed() []APISymbolResolver
+	Changed() []APISymbolChangeResolver
+	HasBreakingChanges() bool
+}
+
+type APISymbolResolver interface {
+	Symbol() string
+	Kind() *string
+	Signature() *string
+	Path() string
+	Range() RangeResolver
+}
+
+type APISymbolChangeResolver interface {
+	Base() APISymbolResolver
+	Head() APISymbolResolver
+}
+
 type CodeIntelligenceCommitGraphResolver interface {
 	Stale() bool
 	UpdatedAt() *gqlutil.DateTime
@@ -90,6 +127,23 @@ type PreciseIndexResolver interface {
 	IsLatestForRepo() bool
 	RetentionPolicyOverview(ctx context.Context, args *LSIFUploadRetentionPolicyMatchesArgs) (CodeIntelligenceRetentionPolicyMatchesConnectionResolver, error)
 	AuditLogs(ctx context.Context) (*[]LSIFUploadsAuditLogsResolver, error)
+	UnusedSymbols(ctx context.Context, args *UnusedSymbolsArgs) (UnusedSymbolConnectionResolver, error)
+}
+
+type UnusedSymbolsArgs struct {
+	PagedConnectionArgs
+}
+
+type UnusedSymbolConnectionResolver interface {
+	PagedConnectionWithTotalCountResolver[UnusedSymbolResolver]
+	ComputedAt() gqlutil.DateTime
+}
+
+type UnusedSymbolResolver interface {
+	Symbol() string
+	Kind() *string
+	Path() string
+	Range() RangeResolver
 }
 
 type LSIFUploadRetentionPolicyMatchesArgs struct {
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/background/expirer"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/background/janitor"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/background/processor"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/background/unusedsymbols"
 	uploadsstore "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -42,11 +43,12 @@ func NewService(
 }
 
 var (
-	BackfillerConfigInst  = &backfiller.Config{}
-	CommitGraphConfigInst = &commitgraph.Config{}
-	ExpirerConfigInst     = &expirer.Config{}
-	JanitorConfigInst     = &janitor.Config{}
-	ProcessorConfigInst   = &processor.Config{}
+	BackfillerConfigInst    = &backfiller.Config{}
+	CommitGraphConfigInst   = &commitgraph.Config{}
+	ExpirerConfigInst       = &expirer.Config{}
+	JanitorConfigInst       = &janitor.Config{}
+	ProcessorConfigInst     = &processor.Config{}
+	UnusedSymbolsConfigInst = &unusedsymbols.Config{}
 )
 
 func NewUploadProcessorJob(
@@ -130,6 +132,18 @@ func NewExpirationTasks(
 	)
 }
 
+func NewUnusedSymbolReporter(
+	observationCtx *observation.Context,
+	uploadSvc *Service,
+) []goroutine.BackgroundRoutine {
+	return background.NewUnusedSymbolReporter(
+		scopedContext("unused-symbols", observationCtx),
+		uploadSvc.store,
+		uploadSvc.codeGraphDataStore,
+		UnusedSymbolsConfigInst,
+	)
+}
+
 func scopedContext(component string, parent *observation.Context) *observation.Context {
 	return observation.ScopedContext("codeintel", "uploads", component, parent)
 }
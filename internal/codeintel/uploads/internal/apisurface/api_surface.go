@@ -0,0 +1,204 @@
+// Package apisurface extracts the public API of an upload from its SCIP documents
+// and compares the public APIs of two uploads.
+package apisurface
+
+import (
+	"cmp"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+)
+
+// Surface is the set of public symbols defined by the documents of an upload.
+type Surface struct {
+	root    string
+	symbols map[string]shared.APISymbol
+}
+
+// New creates an empty surface for an upload with the given root.
+func New(root string) *Surface {
+	return &Surface{
+		root:    root,
+		symbols: map[string]shared.APISymbol{},
+	}
+}
+
+// AddDocument adds the public symbols defined in the given document. The path is
+// relative to the upload root.
+func (s *Surface) AddDocument(documentPath string, document *scip.Document) {
+	symbolInformation := make(map[string]*scip.SymbolInformation, len(document.Symbols))
+	for _, symbol := range document.Symbols {
+		symbolInformation[symbol.Symbol] = symbol
+	}
+
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == "" || scip.IsLocalSymbol(occurrence.Symbol) || !scip.SymbolRole_Definition.Matches(occurrence) {
+			continue
+		}
+
+		info, ok := symbolInformation[occurrence.Symbol]
+		if !ok {
+			// Symbols without information in this document are defined elsewhere
+			continue
+		}
+
+		key, ok := publicKey(documentPath, info)
+		if !ok {
+			continue
+		}
+		if _, ok := s.symbols[key]; ok {
+			continue
+		}
+
+		kind := ""
+		if info.Kind != scip.SymbolInformation_UnspecifiedKind {
+			kind = info.Kind.String()
+		}
+
+		s.symbols[key] = shared.APISymbol{
+			Symbol:    occurrence.Symbol,
+			Kind:      kind,
+			Signature: signature(info),
+			Path:      path.Join(s.root, documentPath),
+			Range:     scip.NewRangeUnchecked(occurrence.Range),
+		}
+	}
+}
+
+// Diff returns the public symbols added, removed, and changed from base to head.
+// Each list is ordered by symbol name.
+func Diff(base, head *Surface) (diff shared.APISurfaceDiff) {
+	for _, key := range sortedKeys(base.symbols) {
+		baseSymbol := base.symbols[key]
+
+		headSymbol, ok := head.symbols[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, baseSymbol)
+			continue
+		}
+		if changed(baseSymbol, headSymbol) {
+			diff.Changed = append(diff.Changed, shared.APISymbolChange{Base: baseSymbol, Head: headSymbol})
+		}
+	}
+
+	for _, key := range sortedKeys(head.symbols) {
+		if _, ok := base.symbols[key]; !ok {
+			diff.Added = append(diff.Added, head.symbols[key])
+		}
+	}
+
+	return diff
+}
+
+// changed returns true if the kind or signature of a symbol differs. Attributes
+// missing on either side are not considered a change, as older indexers may not
+// emit them.
+func changed(base, head shared.APISymbol) bool {
+	if base.Kind != "" && head.Kind != "" && base.Kind != head.Kind {
+		return true
+	}
+
+	return base.Signature != "" && head.Signature != "" && normalizeSignature(base.Signature) != normalizeSignature(head.Signature)
+}
+
+// versionlessFormatter formats symbols without their package version so that the
+// same symbol can be matched across two releases of a package.
+var versionlessFormatter = scip.SymbolFormatter{
+	OnError:               func(err error) error { return err },
+	IncludeScheme:         func(_ string) bool { return true },
+	IncludePackageManager: func(_ string) bool { return true },
+	IncludePackageName:    func(_ string) bool { return true },
+	IncludePackageVersion: func(_ string) bool { return false },
+	IncludeDescriptor:     func(_ string) bool { return true },
+	IncludeRawDescriptor:  func(_ *scip.Descriptor) bool { return true },
+	IncludeDisambiguator:  func(_ string) bool { return true },
+}
+
+// publicKey returns the version-independent name of the given symbol if it is
+// part of the public API of its package.
+func publicKey(documentPath string, info *scip.SymbolInformation) (string, bool) {
+	symbol, err := scip.ParseSymbol(info.Symbol)
+	if err != nil || shared.IsAuxiliarySymbol(symbol, info.Kind) {
+		return "", false
+	}
+	if !isPublic(symbol, documentPath) {
+		return "", false
+	}
+
+	return versionlessFormatter.FormatSymbol(symbol), true
+}
+
+// isPublic applies the visibility conventions of the languages whose indexers
+// do not otherwise encode visibility in symbol names. Symbols of other languages
+// are assumed to be public.
+func isPublic(symbol *scip.Symbol, documentPath string) bool {
+	switch symbol.Scheme {
+	case "scip-go":
+		// Test files are not compiled into the package
+		if strings.HasSuffix(documentPath, "_test.go") {
+			return false
+		}
+
+		for _, descriptor := range symbol.Descriptors {
+			if descriptor.Suffix == scip.Descriptor_Namespace {
+				continue
+			}
+			if r, _ := utf8.DecodeRuneInString(descriptor.Name); !unicode.IsUpper(r) {
+				return false
+			}
+		}
+
+	case "scip-python":
+		for _, descriptor := range symbol.Descriptors {
+			if strings.HasPrefix(descriptor.Name, "_") && !(strings.HasPrefix(descriptor.Name, "__") && strings.HasSuffix(descriptor.Name, "__")) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// signature returns the signature of the given symbol, preferring the dedicated
+// signature field over the first documentation entry, which most indexers use to
+// render the signature as a fenced code block.
+func signature(info *scip.SymbolInformation) string {
+	if info.SignatureDocumentation != nil && info.SignatureDocumentation.Text != "" {
+		return info.SignatureDocumentation.Text
+	}
+	if len(info.Documentation) > 0 && strings.HasPrefix(info.Documentation[0], "```") {
+		return info.Documentation[0]
+	}
+
+	return ""
+}
+
+// normalizeSignature strips code fences and collapses whitespace so that
+// formatting differences do not register as changes.
+func normalizeSignature(signature string) string {
+	lines := strings.Split(signature, "\n")
+	filtered := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "```") {
+			filtered = append(filtered, line)
+		}
+	}
+
+	return strings.Join(strings.Fields(strings.Join(filtered, " ")), " ")
+}
+
+func sortedKeys(m map[string]shared.APISymbol) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, cmp.Compare[string])
+
+	return keys
+}
@@ -0,0 +1,80 @@
+package apisurface
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+)
+
+func TestDiff(t *testing.T) {
+	base := New("lib")
+	base.AddDocument("lib.go", document("v1.2.0", map[string]string{
+		"Stable().":      "```go\nfunc Stable()\n```",
+		"Reformatted().": "```go\nfunc Reformatted(a int,\n\tb int)\n```",
+		"Changed().":     "```go\nfunc Changed(a int)\n```",
+		"Removed().":     "```go\nfunc Removed()\n```",
+		"unexported().":  "```go\nfunc unexported()\n```",
+	}))
+
+	head := New("lib")
+	head.AddDocument("lib.go", document("v1.3.0", map[string]string{
+		"Stable().":      "```go\nfunc Stable()\n```",
+		"Reformatted().": "```go\nfunc Reformatted(a int, b int)\n```",
+		"Changed().":     "```go\nfunc Changed(a int, b string)\n```",
+		"Added().":       "```go\nfunc Added()\n```",
+		"unexported().":  "```go\nfunc unexported(a int)\n```",
+	}))
+	head.AddDocument("lib_test.go", document("v1.3.0", map[string]string{
+		"TestAdded().": "```go\nfunc TestAdded(t *testing.T)\n```",
+	}))
+
+	apiSymbol := func(version, name, signature string) shared.APISymbol {
+		return shared.APISymbol{
+			Symbol:    "scip-go gomod example " + version + " lib/" + name,
+			Kind:      "Function",
+			Signature: signature,
+			Path:      "lib/lib.go",
+			Range:     scip.NewRangeUnchecked([]int32{0, 0, 1}),
+		}
+	}
+
+	expected := shared.APISurfaceDiff{
+		Added: []shared.APISymbol{
+			apiSymbol("v1.3.0", "Added().", "```go\nfunc Added()\n```"),
+		},
+		Removed: []shared.APISymbol{
+			apiSymbol("v1.2.0", "Removed().", "```go\nfunc Removed()\n```"),
+		},
+		Changed: []shared.APISymbolChange{
+			{
+				Base: apiSymbol("v1.2.0", "Changed().", "```go\nfunc Changed(a int)\n```"),
+				Head: apiSymbol("v1.3.0", "Changed().", "```go\nfunc Changed(a int, b string)\n```"),
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, Diff(base, head)); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func document(version string, signatures map[string]string) *scip.Document {
+	document := &scip.Document{}
+	for name, signature := range signatures {
+		symbol := "scip-go gomod example " + version + " lib/" + name
+		document.Occurrences = append(document.Occurrences, &scip.Occurrence{
+			Range:       []int32{0, 0, 1},
+			Symbol:      symbol,
+			SymbolRoles: int32(scip.SymbolRole_Definition),
+		})
+		document.Symbols = append(document.Symbols, &scip.SymbolInformation{
+			Symbol:        symbol,
+			Kind:          scip.SymbolInformation_Function,
+			Documentation: []string{signature},
+		})
+	}
+
+	return document
+}
@@ -615,7 +615,7 @@ func NewMockStore() *MockStore {
 			},
 		},
 		DeleteOverlappingCompletedUploadsFunc: &StoreDeleteOverlappingCompletedUploadsFunc{
-			defaultHook: func(context.Context, int, string, string, string) (r0 error) {
+			defaultHook: func(context.Context, int, string, string, string, string) (r0 error) {
 				return
 			},
 		},
@@ -942,7 +942,7 @@ func NewStrictMockStore() *MockStore {
 			},
 		},
 		DeleteOverlappingCompletedUploadsFunc: &StoreDeleteOverlappingCompletedUploadsFunc{
-			defaultHook: func(context.Context, int, string, string, string) error {
+			defaultHook: func(context.Context, int, string, string, string, string) error {
 				panic("unexpected invocation of MockStore.DeleteOverlappingCompletedUploads")
 			},
 		},
@@ -1993,24 +1993,24 @@ func (c StoreDeleteOldAuditLogsFuncCall) Results() []interface{} {
 // the DeleteOverlappingCompletedUploads method of the parent MockStore
 // instance is invoked.
 type StoreDeleteOverlappingCompletedUploadsFunc struct {
-	defaultHook func(context.Context, int, string, string, string) error
-	hooks       []func(context.Context, int, string, string, string) error
+	defaultHook func(context.Context, int, string, string, string, string) error
+	hooks       []func(context.Context, int, string, string, string, string) error
 	history     []StoreDeleteOverlappingCompletedUploadsFuncCall
 	mutex       sync.Mutex
 }
 
 // DeleteOverlappingCompletedUploads delegates to the next hook function in
 // the queue and stores the parameter and result values of this invocation.
-func (m *MockStore) DeleteOverlappingCompletedUploads(v0 context.Context, v1 int, v2 string, v3 string, v4 string) error {
-	r0 := m.DeleteOverlappingCompletedUploadsFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.DeleteOverlappingCompletedUploadsFunc.appendCall(StoreDeleteOverlappingCompletedUploadsFuncCall{v0, v1, v2, v3, v4, r0})
+func (m *MockStore) DeleteOverlappingCompletedUploads(v0 context.Context, v1 int, v2 string, v3 string, v4 string, v5 string) error {
+	r0 := m.DeleteOverlappingCompletedUploadsFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.DeleteOverlappingCompletedUploadsFunc.appendCall(StoreDeleteOverlappingCompletedUploadsFuncCall{v0, v1, v2, v3, v4, v5, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
 // DeleteOverlappingCompletedUploads method of the parent MockStore instance
 // is invoked and the hook queue is empty.
-func (f *StoreDeleteOverlappingCompletedUploadsFunc) SetDefaultHook(hook func(context.Context, int, string, string, string) error) {
+func (f *StoreDeleteOverlappingCompletedUploadsFunc) SetDefaultHook(hook func(context.Context, int, string, string, string, string) error) {
 	f.defaultHook = hook
 }
 
@@ -2019,7 +2019,7 @@ func (f *StoreDeleteOverlappingCompletedUploadsFunc) SetDefaultHook(hook func(co
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *StoreDeleteOverlappingCompletedUploadsFunc) PushHook(hook func(context.Context, int, string, string, string) error) {
+func (f *StoreDeleteOverlappingCompletedUploadsFunc) PushHook(hook func(context.Context, int, string, string, string, string) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2028,19 +2028,19 @@ func (f *StoreDeleteOverlappingCompletedUploadsFunc) PushHook(hook func(context.
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *StoreDeleteOverlappingCompletedUploadsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int, string, string, string) error {
+	f.SetDefaultHook(func(context.Context, int, string, string, string, string) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *StoreDeleteOverlappingCompletedUploadsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int, string, string, string) error {
+	f.PushHook(func(context.Context, int, string, string, string, string) error {
 		return r0
 	})
 }
 
-func (f *StoreDeleteOverlappingCompletedUploadsFunc) nextHook() func(context.Context, int, string, string, string) error {
+func (f *StoreDeleteOverlappingCompletedUploadsFunc) nextHook() func(context.Context, int, string, string, string, string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2090,6 +2090,9 @@ type StoreDeleteOverlappingCompletedUploadsFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -2098,7 +2101,7 @@ type StoreDeleteOverlappingCompletedUploadsFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c StoreDeleteOverlappingCompletedUploadsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/background/expirer"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/background/janitor"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/background/processor"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/background/unusedsymbols"
 	uploadsstore "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -140,3 +141,19 @@ func NewExpirationTasks(
 		),
 	}
 }
+
+func NewUnusedSymbolReporter(
+	observationCtx *observation.Context,
+	store uploadsstore.Store,
+	dataStore codegraph.DataStore,
+	config *unusedsymbols.Config,
+) []goroutine.BackgroundRoutine {
+	return []goroutine.BackgroundRoutine{
+		unusedsymbols.NewUnusedSymbolReporter(
+			store,
+			dataStore,
+			config,
+			observationCtx,
+		),
+	}
+}
@@ -317,9 +317,11 @@ func (h *handler) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 		// any other changes but still commit the transaction as a whole.
 		return inTransaction(ctx, h.store, func(tx store.Store) error {
 			// Before we mark the upload as complete, we need to delete any existing completed uploads
-			// that have the same repository_id, commit, root, and indexer values. Otherwise, the transaction
-			// will fail as these values form a unique constraint.
-			if err := tx.DeleteOverlappingCompletedUploads(ctx, upload.RepositoryID, upload.Commit, upload.Root, upload.Indexer); err != nil {
+			// that have the same repository_id, commit, root, indexer, and shard values. Otherwise, the
+			// transaction will fail as these values form a unique constraint. Uploads of other shards are
+			// left in place, as together with this upload they form a single logical index, but a complete
+			// index and shards of the same commit replace one another.
+			if err := tx.DeleteOverlappingCompletedUploads(ctx, upload.RepositoryID, upload.Commit, upload.Root, upload.Indexer, upload.Shard); err != nil {
 				return errors.Wrap(err, "store.DeleteOverlappingCompletedUploads")
 			}
 
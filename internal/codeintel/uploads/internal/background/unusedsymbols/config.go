@@ -0,0 +1,53 @@
+package unusedsymbols
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type Config struct {
+	env.BaseConfig
+
+	Interval                  time.Duration
+	BatchSize                 int
+	ReportMaxAge              time.Duration
+	MaximumReferencingUploads int
+	TestFilePatterns          []*regexp.Regexp
+	Allowlist                 []*regexp.Regexp
+}
+
+const defaultTestFilePatterns = `(^|/)(test|tests|testdata|__tests__)/,_test\.go$,\.(test|spec)\.[cm]?[jt]sx?$,(^|/)test_[^/]*\.py$,_test\.py$,(Test|Tests)\.(java|kt|cs)$`
+
+// defaultAllowlist matches program entrypoints, which are never referenced.
+const defaultAllowlist = `[/#.](main|init)\(\)\.$`
+
+func (c *Config) Load() {
+	c.Interval = c.GetInterval("CODEINTEL_UPLOADS_UNUSED_SYMBOLS_INTERVAL", "1m", "How frequently to compute unused symbol reports.")
+	c.BatchSize = c.GetInt("CODEINTEL_UPLOADS_UNUSED_SYMBOLS_BATCH_SIZE", "10", "The number of uploads to compute unused symbol reports for at a time.")
+	c.ReportMaxAge = c.GetInterval("CODEINTEL_UPLOADS_UNUSED_SYMBOLS_REPORT_MAX_AGE", "24h", "The maximum time before an unused symbol report is recomputed to account for new dependent uploads.")
+	c.MaximumReferencingUploads = c.GetInt("CODEINTEL_UPLOADS_UNUSED_SYMBOLS_MAXIMUM_REFERENCING_UPLOADS", "1000", "The maximum number of dependent uploads searched for references to the symbols of an upload.")
+	c.TestFilePatterns = c.getPatterns("CODEINTEL_UPLOADS_UNUSED_SYMBOLS_TEST_FILE_PATTERNS", defaultTestFilePatterns, "A comma-separated list of regular expressions matching test file paths. Symbols defined in test files are never reported.")
+	c.Allowlist = c.getPatterns("CODEINTEL_UPLOADS_UNUSED_SYMBOLS_ALLOWLIST", defaultAllowlist, "A comma-separated list of regular expressions matching SCIP symbol names that are never reported.")
+}
+
+func (c *Config) getPatterns(name, defaultValue, description string) []*regexp.Regexp {
+	var patterns []*regexp.Regexp
+	for _, pattern := range strings.Split(c.Get(name, defaultValue, description), ",") {
+		if pattern = strings.TrimSpace(pattern); pattern == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			c.AddError(errors.Wrapf(err, "invalid pattern %q in %s", pattern, name))
+			continue
+		}
+		patterns = append(patterns, re)
+	}
+
+	return patterns
+}
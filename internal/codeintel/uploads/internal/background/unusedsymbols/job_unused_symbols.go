@@ -0,0 +1,255 @@
+package unusedsymbols
+
+import (
+	"cmp"
+	"context"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codegraph"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/shared/background"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func NewUnusedSymbolReporter(
+	store store.Store,
+	dataStore codegraph.DataStore,
+	config *Config,
+	observationCtx *observation.Context,
+) goroutine.BackgroundRoutine {
+	name := "codeintel.uploads.unused-symbols"
+	reporter := &reporter{
+		store:     store,
+		dataStore: dataStore,
+		config:    config,
+	}
+
+	return background.NewJanitorJob(context.Background(), background.JanitorOptions{
+		Name:        name,
+		Description: "Computes the global symbols defined by an upload that are never referenced.",
+		Interval:    config.Interval,
+		Metrics:     background.NewJanitorMetrics(observationCtx, name),
+		CleanupFunc: func(ctx context.Context) (numRecordsScanned, numRecordsAltered int, _ error) {
+			return reporter.handle(ctx, time.Now())
+		},
+	})
+}
+
+type reporter struct {
+	store     store.Store
+	dataStore codegraph.DataStore
+	config    *Config
+}
+
+// handle computes the unused symbol reports for a batch of uploads that have not
+// been analyzed yet or whose report is stale. It returns the number of uploads
+// analyzed and the number of unused symbols found.
+func (r *reporter) handle(ctx context.Context, now time.Time) (numUploads, numSymbols int, _ error) {
+	ids, err := r.dataStore.UnusedSymbolReportCandidates(ctx, r.config.BatchSize, r.config.ReportMaxAge, now)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "dataStore.UnusedSymbolReportCandidates")
+	}
+	if len(ids) == 0 {
+		return 0, 0, nil
+	}
+
+	uploads, err := r.store.GetUploadsByIDs(ctx, ids...)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "store.GetUploadsByIDs")
+	}
+	completed := map[int]struct{}{}
+	for _, upload := range uploads {
+		if upload.State == "completed" {
+			completed[upload.ID] = struct{}{}
+		}
+	}
+
+	for _, id := range ids {
+		var symbols []codegraph.UnusedSymbol
+		if _, ok := completed[id]; ok {
+			if symbols, err = r.unusedSymbols(ctx, id); err != nil {
+				return numUploads, numSymbols, err
+			}
+		}
+
+		// Uploads that are not (or no longer) completed get an empty report so
+		// that they are not reconsidered until their code graph data is removed.
+		if err := r.dataStore.InsertUnusedSymbolReport(ctx, id, symbols, now); err != nil {
+			return numUploads, numSymbols, errors.Wrap(err, "dataStore.InsertUnusedSymbolReport")
+		}
+
+		numUploads++
+		numSymbols += len(symbols)
+	}
+
+	return numUploads, numSymbols, nil
+}
+
+// unusedSymbols returns the global symbols defined by the given upload that are
+// referenced neither by the upload itself nor by any completed upload visible at
+// the tip of its repository that depends on a package provided by the upload.
+func (r *reporter) unusedSymbols(ctx context.Context, uploadID int) ([]codegraph.UnusedSymbol, error) {
+	a := newAnalysis(r.config.TestFilePatterns, r.config.Allowlist)
+	if err := r.dataStore.ScanDocuments(ctx, uploadID, func(path string, document *scip.Document) error {
+		a.addDocument(path, document)
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "dataStore.ScanDocuments")
+	}
+
+	candidates := a.unused()
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	dependents, _, err := r.store.GetUploads(ctx, shared.GetUploadsOptions{
+		State:        "completed",
+		DependentOf:  uploadID,
+		VisibleAtTip: true,
+		Limit:        r.config.MaximumReferencingUploads,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "store.GetUploads")
+	}
+	if len(dependents) == 0 {
+		return candidates, nil
+	}
+
+	dependentIDs := make([]int, 0, len(dependents))
+	for _, upload := range dependents {
+		dependentIDs = append(dependentIDs, upload.ID)
+	}
+	symbolNames := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		symbolNames = append(symbolNames, candidate.Symbol)
+	}
+
+	referenced, err := r.dataStore.ReferencedSymbolNames(ctx, dependentIDs, symbolNames)
+	if err != nil {
+		return nil, errors.Wrap(err, "dataStore.ReferencedSymbolNames")
+	}
+	referencedSet := collections.NewSet(referenced...)
+
+	return slices.DeleteFunc(candidates, func(symbol codegraph.UnusedSymbol) bool {
+		return referencedSet.Has(symbol.Symbol)
+	}), nil
+}
+
+// analysis accumulates the definitions and references of the documents of a
+// single upload.
+type analysis struct {
+	testFilePatterns []*regexp.Regexp
+	allowlist        []*regexp.Regexp
+	definitions      map[string]codegraph.UnusedSymbol
+	referenced       collections.Set[string]
+	ignored          collections.Set[string]
+}
+
+func newAnalysis(testFilePatterns, allowlist []*regexp.Regexp) *analysis {
+	return &analysis{
+		testFilePatterns: testFilePatterns,
+		allowlist:        allowlist,
+		definitions:      map[string]codegraph.UnusedSymbol{},
+		referenced:       collections.NewSet[string](),
+		ignored:          collections.NewSet[string](),
+	}
+}
+
+func (a *analysis) addDocument(path string, document *scip.Document) {
+	isTestFile := matchesAny(a.testFilePatterns, path)
+
+	kinds := map[string]scip.SymbolInformation_Kind{}
+	for _, symbol := range document.Symbols {
+		kinds[symbol.Symbol] = symbol.Kind
+
+		for _, relationship := range symbol.Relationships {
+			// A symbol implementing another one may only be used through the
+			// symbol it implements, which is in turn considered referenced
+			if relationship.IsImplementation {
+				a.ignored.Add(symbol.Symbol)
+			}
+			a.referenced.Add(relationship.Symbol)
+		}
+	}
+
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == "" || scip.IsLocalSymbol(occurrence.Symbol) {
+			continue
+		}
+
+		if occurrence.SymbolRoles&int32(scip.SymbolRole_Definition) == 0 {
+			a.referenced.Add(occurrence.Symbol)
+			continue
+		}
+		if isTestFile || a.isIgnored(occurrence.Symbol, kinds[occurrence.Symbol]) {
+			a.ignored.Add(occurrence.Symbol)
+			continue
+		}
+		if _, ok := a.definitions[occurrence.Symbol]; ok {
+			continue
+		}
+
+		kind := ""
+		if k, ok := kinds[occurrence.Symbol]; ok && k != scip.SymbolInformation_UnspecifiedKind {
+			kind = k.String()
+		}
+		a.definitions[occurrence.Symbol] = codegraph.UnusedSymbol{
+			Symbol: occurrence.Symbol,
+			Kind:   kind,
+			Path:   path,
+			Range:  scip.NewRangeUnchecked(occurrence.Range),
+		}
+	}
+}
+
+func (a *analysis) isIgnored(symbolName string, kind scip.SymbolInformation_Kind) bool {
+	if matchesAny(a.allowlist, symbolName) {
+		return true
+	}
+
+	symbol, err := scip.ParseSymbol(symbolName)
+	if err != nil {
+		// Not a symbol we can reason about
+		return true
+	}
+	return shared.IsAuxiliarySymbol(symbol, kind)
+}
+
+// unused returns the definitions that are not referenced within the upload,
+// ordered by location.
+func (a *analysis) unused() []codegraph.UnusedSymbol {
+	var symbols []codegraph.UnusedSymbol
+	for name, symbol := range a.definitions {
+		if !a.referenced.Has(name) && !a.ignored.Has(name) {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	slices.SortFunc(symbols, func(a, b codegraph.UnusedSymbol) int {
+		return cmp.Or(
+			cmp.Compare(a.Path, b.Path),
+			a.Range.CompareStrict(b.Range),
+			cmp.Compare(a.Symbol, b.Symbol),
+		)
+	})
+
+	return symbols
+}
+
+func matchesAny(patterns []*regexp.Regexp, s string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(s) {
+			return true
+		}
+	}
+
+	return false
+}
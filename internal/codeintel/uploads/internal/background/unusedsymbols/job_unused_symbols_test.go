@@ -0,0 +1,65 @@
+package unusedsymbols
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codegraph"
+)
+
+const (
+	exampleUsed        = "scip-go gomod example v1.0.0 example/Used()."
+	exampleUnused      = "scip-go gomod example v1.0.0 example/Unused()."
+	exampleMain        = "scip-go gomod example v1.0.0 example/main()."
+	exampleInterface   = "scip-go gomod example v1.0.0 example/Reader#Read()."
+	exampleImplementor = "scip-go gomod example v1.0.0 example/File#Read()."
+	exampleParameter   = "scip-go gomod example v1.0.0 example/Unused().(x)"
+	exampleTestHelper  = "scip-go gomod example v1.0.0 example/TestHelper()."
+)
+
+func TestAnalysis(t *testing.T) {
+	a := newAnalysis(
+		[]*regexp.Regexp{regexp.MustCompile(`_test\.go$`)},
+		[]*regexp.Regexp{regexp.MustCompile(`[/#.](main|init)\(\)\.$`)},
+	)
+
+	definition := int32(scip.SymbolRole_Definition)
+	a.addDocument("main.go", &scip.Document{
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{0, 5, 9}, Symbol: exampleMain, SymbolRoles: definition},
+			{Range: []int32{1, 1, 5}, Symbol: exampleUsed},
+			{Range: []int32{4, 5, 9}, Symbol: exampleUsed, SymbolRoles: definition},
+			{Range: []int32{6, 5, 11}, Symbol: exampleUnused, SymbolRoles: definition},
+			{Range: []int32{6, 12, 13}, Symbol: exampleParameter, SymbolRoles: definition},
+			{Range: []int32{7, 1, 2}, Symbol: "local 0", SymbolRoles: definition},
+		},
+		Symbols: []*scip.SymbolInformation{
+			{Symbol: exampleUnused, Kind: scip.SymbolInformation_Function},
+			{Symbol: exampleParameter, Kind: scip.SymbolInformation_Parameter},
+		},
+	})
+	a.addDocument("file.go", &scip.Document{
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{0, 5, 9}, Symbol: exampleInterface, SymbolRoles: definition},
+			{Range: []int32{2, 5, 9}, Symbol: exampleImplementor, SymbolRoles: definition},
+		},
+		Symbols: []*scip.SymbolInformation{
+			{Symbol: exampleImplementor, Relationships: []*scip.Relationship{{Symbol: exampleInterface, IsImplementation: true}}},
+		},
+	})
+	a.addDocument("main_test.go", &scip.Document{
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{0, 5, 15}, Symbol: exampleTestHelper, SymbolRoles: definition},
+		},
+	})
+
+	expected := []codegraph.UnusedSymbol{
+		{Symbol: exampleUnused, Kind: "Function", Path: "main.go", Range: scip.NewRangeUnchecked([]int32{6, 5, 11})},
+	}
+	if diff := cmp.Diff(expected, a.unused()); diff != "" {
+		t.Errorf("unexpected unused symbols (-want +got):\n%s", diff)
+	}
+}
@@ -10,7 +10,7 @@ type Graph struct {
 	commitGraphView *CommitGraphView
 	graph           map[api.CommitID][]api.CommitID
 	commits         []api.CommitID
-	ancestorUploads map[api.CommitID]map[string]UploadMeta
+	ancestorUploads map[api.CommitID]map[string][]UploadMeta
 }
 
 type Envelope struct {
@@ -63,7 +63,7 @@ func (g *Graph) Stream() <-chan Envelope {
 
 		for _, commit := range g.commits {
 			if ancestorCommit, ancestorDistance, found := traverseForCommit(g.graph, g.ancestorUploads, commit); found {
-				if ancestorVisibleUploads := g.ancestorUploads[ancestorCommit]; ancestorDistance == 0 || len(ancestorVisibleUploads) == 1 {
+				if ancestorVisibleUploads := g.ancestorUploads[ancestorCommit]; ancestorDistance == 0 || countUploads(ancestorVisibleUploads) == 1 {
 					// We have either a single upload (which is cheap enough to store), or we have
 					// multiple uploads but we were assigned a value in  ancestorVisibleUploads. The
 					// later case means that the visible uploads for this commit is data required to
@@ -75,7 +75,7 @@ func (g *Graph) Stream() <-chan Envelope {
 							Uploads: adjustVisibleUploads(ancestorVisibleUploads, ancestorDistance),
 						},
 					}
-				} else if len(ancestorVisibleUploads) > 1 {
+				} else if countUploads(ancestorVisibleUploads) > 1 {
 					// We have more than a single upload. Because we also have a very cheap way of
 					// reconstructing this particular commit's visible uploads from the ancestor,
 					// we store that relationship which is much smaller when the number of distinct
@@ -133,7 +133,7 @@ func reverseGraph(graph map[api.CommitID][]api.CommitID) map[api.CommitID][]api.
 }
 
 // populateUploadsByTraversal populates a map from select commits (see below) to another map from
-// tokens to upload meta value. Select commits are any commits that satisfy one of the following
+// tokens to the set of upload meta values visible for that token. Select commits are any commits that satisfy one of the following
 // properties:
 //
 //  1. They define an upload,
@@ -143,10 +143,10 @@ func reverseGraph(graph map[api.CommitID][]api.CommitID) map[api.CommitID][]api.
 // For all remaining commits, we can easily re-calculate the visible uploads without storing them.
 // All such commits have a single, unambiguous path to an ancestor that does store data. These
 // commits have the same visibility (the descendant is just farther away).
-func populateUploadsByTraversal(graph map[api.CommitID][]api.CommitID, order []api.CommitID, commitGraphView *CommitGraphView) map[api.CommitID]map[string]UploadMeta {
+func populateUploadsByTraversal(graph map[api.CommitID][]api.CommitID, order []api.CommitID, commitGraphView *CommitGraphView) map[api.CommitID]map[string][]UploadMeta {
 	reverseGraph := reverseGraph(graph)
 
-	uploads := make(map[api.CommitID]map[string]UploadMeta, len(order))
+	uploads := make(map[api.CommitID]map[string][]UploadMeta, len(order))
 	for _, commit := range order {
 		parents := graph[commit]
 
@@ -191,11 +191,14 @@ func populateUploadsByTraversal(graph map[api.CommitID][]api.CommitID, order []a
 //  2. the set of uploads visible from the ancestors with the minimum distance
 //     for equivalent root and indexer values.
 //
-// If two ancestors have different uploads visible for the same root and indexer, the one with the
-// smaller distance to the source commit will shadow the other. Similarly, If an ancestor and the
-// child commit define uploads for the same root and indexer pair, the upload defined on the commit
-// will shadow the upload defined on the ancestor.
-func populateUploadsForCommit(uploads map[api.CommitID]map[string]UploadMeta, ancestors []api.CommitID, distance uint32, commitGraphView *CommitGraphView, commit api.CommitID) map[string]UploadMeta {
+// Several uploads may share a root and indexer when an index is split into shards. Such a shard
+// set is visible or shadowed as a unit. If two ancestors have different sets visible for the same
+// root and indexer, the one with the smaller distance to the source commit will shadow the other.
+// Similarly, if an ancestor and the child commit define uploads for the same root and indexer, the
+// uploads defined on the commit will shadow the uploads defined on the ancestor. The exception are
+// commits that re-upload only some shards of an index: they inherit the remaining shards of the set
+// visible from their ancestors, see inheritShards.
+func populateUploadsForCommit(uploads map[api.CommitID]map[string][]UploadMeta, ancestors []api.CommitID, distance uint32, commitGraphView *CommitGraphView, commit api.CommitID) map[string][]UploadMeta {
 	// The capacity chosen here is an underestimate, but seems to perform well in benchmarks using
 	// live user data. We have attempted to make this value more precise to minimize the number of
 	// re-hash operations, but any counting we do requires auxiliary space and takes additional CPU
@@ -206,36 +209,76 @@ func populateUploadsForCommit(uploads map[api.CommitID]map[string]UploadMeta, an
 			capacity = temp
 		}
 	}
-	uploadsByToken := make(map[string]UploadMeta, capacity)
+	uploadsByToken := make(map[string][]UploadMeta, capacity)
 
 	// Populate uploads defined here
 	for _, upload := range commitGraphView.Meta[commit] {
 		token := commitGraphView.Tokens[upload.UploadID]
-		uploadsByToken[token] = upload
+		uploadsByToken[token] = append(uploadsByToken[token], upload)
 	}
 
-	// Combine with uploads visible from the nearest ancestors
+	// Select the uploads visible from the nearest ancestors
+	ancestorUploadsByToken := make(map[string][]UploadMeta, capacity)
 	for _, ancestor := range ancestors {
-		for _, upload := range uploads[ancestor] {
-			token := commitGraphView.Tokens[upload.UploadID]
-
+		for token, ancestorUploads := range uploads[ancestor] {
 			// Increase distance from source before comparison
-			upload.Distance += distance
+			candidate := make([]UploadMeta, 0, len(ancestorUploads))
+			for _, upload := range ancestorUploads {
+				upload.Distance += distance
+				candidate = append(candidate, upload)
+			}
 
-			// Only update upload for this token if distance of new upload is less than current one
-			if currentUpload, ok := uploadsByToken[token]; !ok || replaces(upload, currentUpload) {
-				uploadsByToken[token] = upload
+			// Only update uploads for this token if distance of new set is less than current one
+			if currentUploads, ok := ancestorUploadsByToken[token]; !ok || replacesSet(candidate, currentUploads) {
+				ancestorUploadsByToken[token] = candidate
 			}
 		}
 	}
 
+	// Combine with uploads defined here, which shadow the uploads of the ancestors
+	for token, ancestorUploads := range ancestorUploadsByToken {
+		if definedUploads, ok := uploadsByToken[token]; ok {
+			uploadsByToken[token] = append(definedUploads, inheritShards(commitGraphView, definedUploads, ancestorUploads)...)
+		} else {
+			uploadsByToken[token] = ancestorUploads
+		}
+	}
+
 	return uploadsByToken
 }
 
+// inheritShards returns the uploads of the given ancestor set whose shards are not re-uploaded by
+// the given set of uploads defined on a commit. This lets a commit upload only the shards of an index
+// that changed since its ancestor was indexed. Nothing is inherited if either set is a complete
+// (unsharded) index.
+func inheritShards(commitGraphView *CommitGraphView, uploads, ancestorUploads []UploadMeta) []UploadMeta {
+	shards := make(map[string]struct{}, len(uploads))
+	for _, upload := range uploads {
+		shard, ok := commitGraphView.Shards[upload.UploadID]
+		if !ok {
+			return nil
+		}
+		shards[shard] = struct{}{}
+	}
+
+	var inherited []UploadMeta
+	for _, upload := range ancestorUploads {
+		shard, ok := commitGraphView.Shards[upload.UploadID]
+		if !ok {
+			return nil
+		}
+		if _, ok := shards[shard]; !ok {
+			inherited = append(inherited, upload)
+		}
+	}
+
+	return inherited
+}
+
 // traverseForUploads returns the value in the given uploads map whose key matches the first ancestor
 // in the graph with a value present in the map. The distance in the graph between the original commit
 // and the ancestor is also returned.
-func traverseForUploads(graph map[api.CommitID][]api.CommitID, uploads map[api.CommitID]map[string]UploadMeta, commit api.CommitID) (map[string]UploadMeta, uint32) {
+func traverseForUploads(graph map[api.CommitID][]api.CommitID, uploads map[api.CommitID]map[string][]UploadMeta, commit api.CommitID) (map[string][]UploadMeta, uint32) {
 	commit, distance, _ := traverseForCommit(graph, uploads, commit)
 	return uploads[commit], distance
 }
@@ -246,7 +289,7 @@ func traverseForUploads(graph map[api.CommitID][]api.CommitID, uploads map[api.C
 //
 // NOTE: We assume that each commit with multiple parents have been assigned data while walking
 // the graph in topological order. If that is not the case, one parent will be chosen arbitrarily.
-func traverseForCommit(graph map[api.CommitID][]api.CommitID, uploads map[api.CommitID]map[string]UploadMeta, commit api.CommitID) (api.CommitID, uint32, bool) {
+func traverseForCommit(graph map[api.CommitID][]api.CommitID, uploads map[api.CommitID]map[string][]UploadMeta, commit api.CommitID) (api.CommitID, uint32, bool) {
 	for distance := uint32(0); ; distance++ {
 		if _, ok := uploads[commit]; ok {
 			return commit, distance, true
@@ -264,16 +307,48 @@ func traverseForCommit(graph map[api.CommitID][]api.CommitID, uploads map[api.Co
 // adjustVisibleUploads returns a copy of the given uploads map with the distance adjusted by
 // the given amount. This returns the uploads "inherited" from a the nearest ancestor with
 // commit data.
-func adjustVisibleUploads(ancestorVisibleUploads map[string]UploadMeta, ancestorDistance uint32) []UploadMeta {
-	uploads := make([]UploadMeta, 0, len(ancestorVisibleUploads))
-	for _, ancestorUpload := range ancestorVisibleUploads {
-		ancestorUpload.Distance += ancestorDistance
-		uploads = append(uploads, ancestorUpload)
+func adjustVisibleUploads(ancestorVisibleUploads map[string][]UploadMeta, ancestorDistance uint32) []UploadMeta {
+	uploads := make([]UploadMeta, 0, countUploads(ancestorVisibleUploads))
+	for _, ancestorUploads := range ancestorVisibleUploads {
+		for _, ancestorUpload := range ancestorUploads {
+			ancestorUpload.Distance += ancestorDistance
+			uploads = append(uploads, ancestorUpload)
+		}
 	}
 
 	return uploads
 }
 
+// countUploads returns the total number of uploads in the given map.
+func countUploads(uploadsByToken map[string][]UploadMeta) int {
+	count := 0
+	for _, uploads := range uploadsByToken {
+		count += len(uploads)
+	}
+
+	return count
+}
+
+// replacesSet returns true if the set uploads1 has a smaller distance than the set uploads2. The
+// distance of a set is the distance of its nearest upload, as shards inherited from an ancestor are
+// farther away than the shards defined alongside them. Ties are broken by the minimum upload
+// identifier to remain determinstic.
+func replacesSet(uploads1, uploads2 []UploadMeta) bool {
+	return replaces(nearestUpload(uploads1), nearestUpload(uploads2))
+}
+
+// nearestUpload returns the upload with the minimum distance in the given non-empty set.
+func nearestUpload(uploads []UploadMeta) UploadMeta {
+	upload := uploads[0]
+	for _, candidate := range uploads[1:] {
+		if replaces(candidate, upload) {
+			upload = candidate
+		}
+	}
+
+	return upload
+}
+
 // replaces returns true if upload1 has a smaller distance than upload2.
 // Ties are broken by the minimum upload identifier to remain determinstic.
 func replaces(upload1, upload2 UploadMeta) bool {
@@ -89,6 +89,61 @@ func TestCalculateVisibleUploads(t *testing.T) {
 	}
 }
 
+func TestCalculateVisibleUploadsShardSets(t *testing.T) {
+	// testGraph has the following layout:
+	//
+	//               +-- [c] -- [d] -- e --+
+	//               |                     |
+	// [a] --- b ----+                     +-- g
+	//               |                     |
+	//               +-------- [f] --------+
+	//
+	// Commit a defines the shards s1 and s2 of lib/, c re-indexes lib/ without
+	// shards, d uploads only the shard s1 of lib/, and f re-uploads only the
+	// shard s2 of lib/. f inherits s1 from a, but d inherits nothing from the
+	// complete index of c. The uploads of app/ on a are never shadowed.
+	//
+	// NOTE: The input to ParseCommitGraph must match the order and format
+	// of `git log --pretty="%H %P" --topo-order`.
+	testGraph := ParseCommitGraph([]*gitdomain.Commit{
+		gitCommit("g", "e", "f"),
+		gitCommit("f", "b"),
+		gitCommit("e", "d"),
+		gitCommit("d", "c"),
+		gitCommit("c", "b"),
+		gitCommit("b", "a"),
+	})
+
+	commitGraphView := NewCommitGraphView()
+	commitGraphView.AddShard(UploadMeta{UploadID: 10}, "a", "lib/:scip-clang", "s1")
+	commitGraphView.AddShard(UploadMeta{UploadID: 11}, "a", "lib/:scip-clang", "s2")
+	commitGraphView.Add(UploadMeta{UploadID: 20}, "a", "app/:scip-clang")
+	commitGraphView.Add(UploadMeta{UploadID: 12}, "c", "lib/:scip-clang")
+	commitGraphView.AddShard(UploadMeta{UploadID: 13}, "d", "lib/:scip-clang", "s1")
+	commitGraphView.AddShard(UploadMeta{UploadID: 14}, "f", "lib/:scip-clang", "s2")
+
+	visibleUploads, links := makeTestGraph(testGraph, commitGraphView)
+
+	expectedVisibleUploads := map[api.CommitID][]UploadMeta{
+		"a": {{UploadID: 10, Distance: 0}, {UploadID: 11, Distance: 0}, {UploadID: 20, Distance: 0}},
+		"c": {{UploadID: 12, Distance: 0}, {UploadID: 20, Distance: 2}},
+		"d": {{UploadID: 13, Distance: 0}, {UploadID: 20, Distance: 3}},
+		"e": {{UploadID: 13, Distance: 1}, {UploadID: 20, Distance: 4}},
+		"f": {{UploadID: 10, Distance: 2}, {UploadID: 14, Distance: 0}, {UploadID: 20, Distance: 2}},
+		"g": {{UploadID: 10, Distance: 3}, {UploadID: 14, Distance: 1}, {UploadID: 20, Distance: 3}},
+	}
+	if diff := cmp.Diff(expectedVisibleUploads, visibleUploads); diff != "" {
+		t.Errorf("unexpected visible uploads (-want +got):\n%s", diff)
+	}
+
+	expectedLinks := map[api.CommitID]LinkRelationship{
+		"b": {Commit: "b", AncestorCommit: "a", Distance: 1},
+	}
+	if diff := cmp.Diff(expectedLinks, links); diff != "" {
+		t.Errorf("unexpected links (-want +got):\n%s", diff)
+	}
+}
+
 func TestCalculateVisibleUploadsAlternateCommitGraph(t *testing.T) {
 	// testGraph has the following layout:
 	//
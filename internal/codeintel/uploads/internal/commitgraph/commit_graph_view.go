@@ -9,10 +9,15 @@ type CommitGraphView struct {
 	// commit's location in the commit graph.
 	Meta map[api.CommitID][]UploadMeta
 
-	// Tokens is a map from upload identifiers to a hash of their root an indexer
+	// Tokens is a map from upload identifiers to a hash of their root and indexer
 	// field. Equality of this token for two uploads means that they are able to
-	// "shadow" one another.
+	// "shadow" one another. Uploads with the same token on the same commit are
+	// shards of one index and are visible together.
 	Tokens map[int]string
+
+	// Shards is a map from upload identifiers to the shard of sharded uploads.
+	// Unsharded uploads are not present in this map.
+	Shards map[int]string
 }
 
 // UploadMeta represents the visibility of an LSIF upload from a particular location
@@ -26,6 +31,7 @@ func NewCommitGraphView() *CommitGraphView {
 	return &CommitGraphView{
 		Meta:   map[api.CommitID][]UploadMeta{},
 		Tokens: map[int]string{},
+		Shards: map[int]string{},
 	}
 }
 
@@ -33,3 +39,11 @@ func (v *CommitGraphView) Add(meta UploadMeta, commit api.CommitID, token string
 	v.Meta[commit] = append(v.Meta[commit], meta)
 	v.Tokens[meta.UploadID] = token
 }
+
+// AddShard adds an upload like Add, recording the shard of sharded uploads.
+func (v *CommitGraphView) AddShard(meta UploadMeta, commit api.CommitID, token, shard string) {
+	v.Add(meta, commit, token)
+	if shard != "" {
+		v.Shards[meta.UploadID] = shard
+	}
+}
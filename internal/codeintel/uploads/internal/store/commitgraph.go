@@ -168,7 +168,7 @@ func (s *store) UpdateUploadsVisibleToCommits(
 }
 
 const calculateVisibleUploadsCommitGraphQuery = `
-SELECT id, commit, md5(root || ':' || indexer) as token, 0 as distance FROM lsif_uploads WHERE state = 'completed' AND repository_id = %s
+SELECT id, commit, md5(root || ':' || indexer) as token, shard, 0 as distance FROM lsif_uploads WHERE state = 'completed' AND repository_id = %s
 `
 
 const calculateVisibleUploadsDirtyRepositoryQuery = `
@@ -422,6 +422,7 @@ SELECT
 	vu.upload_id,
 	encode(vu.commit_bytea, 'hex'),
 	md5(u.root || ':' || u.indexer) as token,
+	u.shard,
 	vu.distance
 FROM visible_uploads vu
 JOIN lsif_uploads u ON u.id = vu.upload_id
@@ -463,12 +464,13 @@ func scanCommitGraphView(rows *sql.Rows, queryErr error) (_ *commitgraph.CommitG
 		var meta commitgraph.UploadMeta
 		var commit api.CommitID
 		var token string
+		var shard string
 
-		if err := rows.Scan(&meta.UploadID, &commit, &token, &meta.Distance); err != nil {
+		if err := rows.Scan(&meta.UploadID, &commit, &token, &shard, &meta.Distance); err != nil {
 			return nil, err
 		}
 
-		commitGraphView.Add(meta, commit, token)
+		commitGraphView.AddShard(meta, commit, token, shard)
 	}
 
 	return commitGraphView, nil
@@ -1407,7 +1407,7 @@ func deleteRepo(t testing.TB, db database.DB, id int, deleted_at time.Time) {
 func toCommitGraphView(uploads []shared.Upload) *commitgraph.CommitGraphView {
 	commitGraphView := commitgraph.NewCommitGraphView()
 	for _, upload := range uploads {
-		commitGraphView.Add(commitgraph.UploadMeta{UploadID: upload.ID}, api.CommitID(upload.Commit), fmt.Sprintf("%s:%s", upload.Root, upload.Indexer))
+		commitGraphView.AddShard(commitgraph.UploadMeta{UploadID: upload.ID}, api.CommitID(upload.Commit), fmt.Sprintf("%s:%s", upload.Root, upload.Indexer), upload.Shard)
 	}
 
 	return commitGraphView
@@ -44,6 +44,7 @@ func (s *store) InsertUpload(ctx context.Context, upload shared.Upload) (id int,
 			upload.AssociatedIndexID,
 			upload.ContentType,
 			upload.UncompressedSize,
+			upload.Shard,
 		),
 	))
 
@@ -63,8 +64,9 @@ INSERT INTO lsif_uploads (
 	upload_size,
 	associated_index_id,
 	content_type,
-	uncompressed_size
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+	uncompressed_size,
+	shard
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
@@ -126,20 +128,24 @@ WHERE
 `
 
 // DeleteOverlapapingCompletedUploads deletes all completed uploads for the given repository with the same
-// commit, root, and indexer. This is necessary to perform during conversions before changing
-// the state of a processing upload to completed as there is a unique index on these four columns.
-func (s *store) DeleteOverlappingCompletedUploads(ctx context.Context, repositoryID int, commit, root, indexer string) (err error) {
+// commit, root, indexer, and shard. This is necessary to perform during conversions before changing
+// the state of a processing upload to completed as there is a unique index on these five columns.
+//
+// A complete (unsharded) index replaces every shard of the same commit, root, and indexer, and a shard
+// replaces a complete index, so that a commit never mixes the two.
+func (s *store) DeleteOverlappingCompletedUploads(ctx context.Context, repositoryID int, commit, root, indexer, shard string) (err error) {
 	ctx, trace, endObservation := s.operations.deleteOverlappingCompletedUploads.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
 		attribute.Int("repositoryID", repositoryID),
 		attribute.String("commit", commit),
 		attribute.String("root", root),
 		attribute.String("indexer", indexer),
+		attribute.String("shard", shard),
 	}})
 	defer endObservation(1, observation.Args{})
 
 	unset, _ := s.db.SetLocal(ctx, "codeintel.lsif_uploads_audit.reason", "upload overlapping with a newer upload")
 	defer unset(ctx)
-	count, _, err := basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(deleteOverlappingCompletedUploadsQuery, repositoryID, commit, root, indexer)))
+	count, _, err := basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(deleteOverlappingCompletedUploadsQuery, repositoryID, commit, root, indexer, shard, shard)))
 	if err != nil {
 		return err
 	}
@@ -158,7 +164,8 @@ candidates AS (
 		u.repository_id = %s AND
 		u.commit = %s AND
 		u.root = %s AND
-		u.indexer = %s
+		u.indexer = %s AND
+		(%s = '' OR u.shard = %s OR u.shard = '')
 
 	-- Lock these rows in a deterministic order so that we don't
 	-- deadlock with other processes updating the lsif_uploads table.
@@ -216,6 +223,7 @@ var uploadColumnsWithNullRank = []*sqlf.Query{
 	sqlf.Sprintf("u.should_reindex"),
 	sqlf.Sprintf("NULL"),
 	sqlf.Sprintf("u.uncompressed_size"),
+	sqlf.Sprintf("u.shard"),
 }
 
 var UploadWorkerStoreOptions = dbworkerstore.Options[shared.Upload]{
@@ -290,7 +290,7 @@ func TestDeleteOverlappingCompletedUploads(t *testing.T) {
 		Indexer: "lsif-go",
 	})
 
-	err := store.DeleteOverlappingCompletedUploads(context.Background(), 50, makeCommit(1), "cmd/", "lsif-go")
+	err := store.DeleteOverlappingCompletedUploads(context.Background(), 50, makeCommit(1), "cmd/", "lsif-go", "")
 	if err != nil {
 		t.Fatalf("unexpected error deleting dump: %s", err)
 	}
@@ -320,14 +320,15 @@ func TestDeleteOverlappingCompletedUploadsNoMatches(t *testing.T) {
 		commit  string
 		root    string
 		indexer string
+		shard   string
 	}{
-		{makeCommit(2), "cmd/", "lsif-go"},
-		{makeCommit(1), "cmds/", "lsif-go"},
-		{makeCommit(1), "cmd/", "scip-typescript"},
+		{makeCommit(2), "cmd/", "lsif-go", ""},
+		{makeCommit(1), "cmds/", "lsif-go", ""},
+		{makeCommit(1), "cmd/", "scip-typescript", ""},
 	}
 
 	for _, testCase := range testCases {
-		err := store.DeleteOverlappingCompletedUploads(context.Background(), 50, testCase.commit, testCase.root, testCase.indexer)
+		err := store.DeleteOverlappingCompletedUploads(context.Background(), 50, testCase.commit, testCase.root, testCase.indexer, testCase.shard)
 		if err != nil {
 			t.Fatalf("unexpected error deleting dump: %s", err)
 		}
@@ -341,6 +342,39 @@ func TestDeleteOverlappingCompletedUploadsNoMatches(t *testing.T) {
 	}
 }
 
+func TestDeleteOverlappingCompletedUploadsSharded(t *testing.T) {
+	logger := logtest.Scoped(t)
+	sqlDB := dbtest.NewDB(t)
+	db := database.NewDB(logger, sqlDB)
+	store := New(observation.TestContextTB(t), db)
+
+	insertUploads(t, db,
+		shared.Upload{ID: 1, Commit: makeCommit(1), Root: "cmd/", Indexer: "scip-clang", Shard: "//cmd/server"},
+		shared.Upload{ID: 2, Commit: makeCommit(1), Root: "cmd/", Indexer: "scip-clang", Shard: "//cmd/client"},
+		shared.Upload{ID: 3, Commit: makeCommit(1), Root: "cmd/", Indexer: "scip-clang"},
+		shared.Upload{ID: 4, Commit: makeCommit(2), Root: "cmd/", Indexer: "scip-clang", Shard: "//cmd/server"},
+		shared.Upload{ID: 5, Commit: makeCommit(2), Root: "cmd/", Indexer: "scip-clang", Shard: "//cmd/client"},
+	)
+
+	// A shard replaces the same shard and a complete index of the same commit
+	err := store.DeleteOverlappingCompletedUploads(context.Background(), 50, makeCommit(1), "cmd/", "scip-clang", "//cmd/server")
+	if err != nil {
+		t.Fatalf("unexpected error deleting dump: %s", err)
+	}
+
+	// A complete index replaces every shard of the same commit
+	err = store.DeleteOverlappingCompletedUploads(context.Background(), 50, makeCommit(2), "cmd/", "scip-clang", "")
+	if err != nil {
+		t.Fatalf("unexpected error deleting dump: %s", err)
+	}
+
+	if states, err := getUploadStates(db, 1, 2, 3, 4, 5); err != nil {
+		t.Fatalf("unexpected error getting states: %s", err)
+	} else if diff := cmp.Diff(map[int]string{1: "deleting", 2: "completed", 3: "deleting", 4: "deleting", 5: "deleting"}, states); diff != "" {
+		t.Errorf("unexpected dump (-want +got):\n%s", diff)
+	}
+}
+
 func TestDeleteOverlappingCompletedUploadsIgnoresIncompleteUploads(t *testing.T) {
 	logger := logtest.Scoped(t)
 	sqlDB := dbtest.NewDB(t)
@@ -355,7 +389,7 @@ func TestDeleteOverlappingCompletedUploadsIgnoresIncompleteUploads(t *testing.T)
 		State:   "queued",
 	})
 
-	err := store.DeleteOverlappingCompletedUploads(context.Background(), 50, makeCommit(1), "cmd/", "lsif-go")
+	err := store.DeleteOverlappingCompletedUploads(context.Background(), 50, makeCommit(1), "cmd/", "lsif-go", "")
 	if err != nil {
 		t.Fatalf("unexpected error deleting dump: %s", err)
 	}
@@ -51,7 +51,7 @@ type Store interface {
 	AddUploadPart(ctx context.Context, uploadID, partIndex int) error
 	MarkQueued(ctx context.Context, id int, uploadSize *int64) error
 	MarkFailed(ctx context.Context, id int, reason string) error
-	DeleteOverlappingCompletedUploads(ctx context.Context, repositoryID int, commit, root, indexer string) error
+	DeleteOverlappingCompletedUploads(ctx context.Context, repositoryID int, commit, root, indexer, shard string) error
 	WorkerutilStore(observationCtx *observation.Context) dbworkerstore.Store[shared.Upload]
 
 	// Dependencies
@@ -71,10 +71,10 @@ type Store interface {
 	GetDirtyRepositories(ctx context.Context) ([]shared.DirtyRepository, error)
 	UpdateUploadsVisibleToCommits(ctx context.Context, repositoryID int, graph *commitgraph.CommitGraph, refs map[string][]gitdomain.Ref, maxAgeForNonStaleBranches, maxAgeForNonStaleTags time.Duration, dirtyToken int, now time.Time) error
 	GetCommitsVisibleToUpload(ctx context.Context, uploadID, limit int, token *string) ([]string, *string, error)
-	// The resulting uploads are guaranteed to be unique per (indexer, root) pair,
+	// The resulting uploads are guaranteed to be unique per (indexer, root, shard) triple,
 	// see NOTE(id: closest-uploads-postcondition).
 	FindClosestCompletedUploads(context.Context, shared.UploadMatchingOptions) ([]shared.CompletedUpload, error)
-	// The resulting uploads are guaranteed to be unique per (indexer, root) pair,
+	// The resulting uploads are guaranteed to be unique per (indexer, root, shard) triple,
 	// see NOTE(id: closest-uploads-postcondition).
 	FindClosestCompletedUploadsFromGraphFragment(_ context.Context, _ shared.UploadMatchingOptions, commitGraph *commitgraph.CommitGraph) ([]shared.CompletedUpload, error)
 	GetRepositoriesMaxStaleAge(ctx context.Context) (time.Duration, error)
@@ -216,8 +216,9 @@ func insertUploads(t testing.TB, db database.DB, uploads ...shared.Upload) {
 				upload_size,
 				associated_index_id,
 				content_type,
-				should_reindex
-			) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+				should_reindex,
+				shard
+			) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 		`,
 			upload.ID,
 			upload.Commit,
@@ -239,6 +240,7 @@ func insertUploads(t testing.TB, db database.DB, uploads ...shared.Upload) {
 			upload.AssociatedIndexID,
 			upload.ContentType,
 			upload.ShouldReindex,
+			upload.Shard,
 		)
 
 		if _, err := db.ExecContext(context.Background(), query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
@@ -161,7 +161,8 @@ SELECT
 	u.content_type,
 	u.should_reindex,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.shard
 FROM lsif_uploads_with_repository_name u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
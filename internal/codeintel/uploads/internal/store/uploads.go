@@ -110,7 +110,8 @@ SELECT
 	u.content_type,
 	u.should_reindex,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.shard
 FROM %s
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -155,6 +156,7 @@ func scanCompleteUpload(s dbutil.Scanner) (upload shared.Upload, _ error) {
 		&upload.ShouldReindex,
 		&upload.Rank,
 		&upload.UncompressedSize,
+		&upload.Shard,
 	); err != nil {
 		return upload, err
 	}
@@ -210,7 +212,8 @@ SELECT
 	u.content_type,
 	u.should_reindex,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.shard
 FROM lsif_uploads u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -330,7 +333,8 @@ SELECT
 	u.content_type,
 	u.should_reindex,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.shard
 FROM lsif_uploads u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -490,7 +494,7 @@ visible_uploads AS (
 		-- NB: A commit should be present in at most one of these tables.
 		SELECT
 			t.upload_id,
-			row_number() OVER (PARTITION BY root, indexer ORDER BY distance) AS r
+			row_number() OVER (PARTITION BY root, indexer, shard ORDER BY distance) AS r
 		FROM (
 			SELECT
 				upload_id::integer,
@@ -1087,7 +1091,8 @@ func buildGetConditionsAndCte(opts shared.GetUploadsOptions) (*sqlf.Query, []*sq
 				content_type,
 				should_reindex,
 				expired,
-				uncompressed_size
+				uncompressed_size,
+				shard
 			FROM lsif_uploads
 			UNION ALL
 			SELECT *
@@ -1179,7 +1184,8 @@ SELECT
 	au.upload_size, au.associated_index_id, au.content_type,
 	false AS should_reindex, -- TODO
 	COALESCE((snapshot->'expired')::boolean, false) AS expired,
-	NULL::bigint AS uncompressed_size
+	NULL::bigint AS uncompressed_size,
+	'' AS shard
 FROM (
 	SELECT upload_id, snapshot_transition_columns(transition_columns ORDER BY sequence ASC) AS snapshot
 	FROM lsif_uploads_audit_logs
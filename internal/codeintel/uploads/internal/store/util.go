@@ -82,8 +82,9 @@ FROM (
 	SELECT
 		t.*,
 		-- NOTE(id: closest-uploads-postcondition) Only return a single result
-		-- for an (indexer, root) pair, see also the WHERE clause at the end.
-		row_number() OVER (PARTITION BY root, indexer ORDER BY distance) AS r
+		-- for an (indexer, root, shard) triple, see also the WHERE clause at the end.
+		-- Shards of one index may be inherited from different commits.
+		row_number() OVER (PARTITION BY root, indexer, shard ORDER BY distance) AS r
 	FROM (
 		-- Select the set of uploads visible from the given commit. This is done by looking
 		-- at each commit's row in the lsif_nearest_uploads table, and the (adjusted) set of
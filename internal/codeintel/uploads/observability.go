@@ -14,7 +14,9 @@ import (
 )
 
 type operations struct {
-	inferClosestUploads *observation.Operation
+	inferClosestUploads     *observation.Operation
+	diffAPISurface          *observation.Operation
+	diffAPISurfaceAtCommits *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -38,7 +40,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		inferClosestUploads: op("InferClosestUploads"),
+		inferClosestUploads:     op("InferClosestUploads"),
+		diffAPISurface:          op("DiffAPISurface"),
+		diffAPISurfaceAtCommits: op("DiffAPISurfaceAtCommits"),
 	}
 }
 
@@ -2,10 +2,19 @@ package uploads
 
 import (
 	"context"
+	"path"
+	"strings"
 	"time"
 
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codegraph"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/core"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/apisurface"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/commitgraph"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
@@ -180,6 +189,127 @@ func (s *Service) GetAuditLogsForUpload(ctx context.Context, uploadID int) ([]sh
 	return s.store.GetAuditLogsForUpload(ctx, uploadID)
 }
 
+func (s *Service) GetUnusedSymbolReport(ctx context.Context, uploadID, limit, offset int) (codegraph.UnusedSymbolReport, bool, error) {
+	return s.codeGraphDataStore.GetUnusedSymbolReport(ctx, uploadID, limit, offset)
+}
+
+// DiffAPISurface compares the public API of two completed uploads, typically of
+// two releases of the same library. Symbols defined in files the current actor
+// cannot read due to sub-repository permissions are left out.
+func (s *Service) DiffAPISurface(ctx context.Context, checker authz.SubRepoPermissionChecker, baseUploadID, headUploadID int) (_ shared.APISurfaceDiff, err error) {
+	ctx, _, endObservation := s.operations.diffAPISurface.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("baseUploadID", baseUploadID),
+		attribute.Int("headUploadID", headUploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	uploads, err := s.store.GetUploadsByIDs(ctx, baseUploadID, headUploadID)
+	if err != nil {
+		return shared.APISurfaceDiff{}, err
+	}
+	uploadsByID := make(map[int]shared.Upload, len(uploads))
+	for _, upload := range uploads {
+		uploadsByID[upload.ID] = upload
+	}
+
+	surfaces := make([]*apisurface.Surface, 0, 2)
+	for _, id := range []int{baseUploadID, headUploadID} {
+		upload, ok := uploadsByID[id]
+		if !ok {
+			return shared.APISurfaceDiff{}, errors.Newf("upload %d not found", id)
+		}
+		if upload.State != "completed" {
+			return shared.APISurfaceDiff{}, errors.Newf("upload %d is not completed", id)
+		}
+
+		repo := api.RepoName(upload.RepositoryName)
+		checkPaths, err := authz.SubRepoEnabledForRepo(ctx, checker, repo)
+		if err != nil {
+			return shared.APISurfaceDiff{}, err
+		}
+
+		surface := apisurface.New(upload.Root)
+		if err := s.codeGraphDataStore.ScanDocuments(ctx, id, func(documentPath string, document *scip.Document) error {
+			if checkPaths {
+				ok, err := authz.FilterActorPath(ctx, checker, actor.FromContext(ctx), repo, path.Join(upload.Root, documentPath))
+				if err != nil || !ok {
+					return err
+				}
+			}
+
+			surface.AddDocument(documentPath, document)
+			return nil
+		}); err != nil {
+			return shared.APISurfaceDiff{}, err
+		}
+		surfaces = append(surfaces, surface)
+	}
+
+	return apisurface.Diff(surfaces[0], surfaces[1]), nil
+}
+
+// DiffAPISurfaceAtCommits compares the public API of the completed uploads with
+// the given root and indexer that are closest to two commits of a repository.
+// An empty indexer matches any precise indexer, but must then identify a single
+// upload per commit. The returned flag is false when either commit has no such
+// upload.
+func (s *Service) DiffAPISurfaceAtCommits(ctx context.Context, checker authz.SubRepoPermissionChecker, repositoryID int, baseCommit, headCommit api.CommitID, root, indexer string) (_ shared.APISurfaceDiff, _ bool, err error) {
+	ctx, _, endObservation := s.operations.diffAPISurfaceAtCommits.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", repositoryID),
+		attribute.String("baseCommit", string(baseCommit)),
+		attribute.String("headCommit", string(headCommit)),
+		attribute.String("root", root),
+		attribute.String("indexer", indexer),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	uploadIDs := make([]int, 0, 2)
+	for _, commit := range []api.CommitID{baseCommit, headCommit} {
+		uploads, err := s.InferClosestUploads(ctx, shared.UploadMatchingOptions{
+			RepositoryID:       api.RepoID(repositoryID),
+			Commit:             commit,
+			Path:               core.NewRepoRelPathUnchecked(root),
+			RootToPathMatching: shared.RootMustEnclosePath,
+			Indexer:            indexer,
+		})
+		if err != nil {
+			return shared.APISurfaceDiff{}, false, err
+		}
+
+		var matching []shared.CompletedUpload
+		for _, upload := range uploads {
+			if normalizeRoot(upload.Root) == normalizeRoot(root) {
+				matching = append(matching, upload)
+			}
+		}
+		if len(matching) == 0 {
+			return shared.APISurfaceDiff{}, false, nil
+		}
+		if len(matching) > 1 {
+			return shared.APISurfaceDiff{}, false, errors.Newf("multiple precise indexes with root %q are visible from commit %s; specify an indexer", root, commit)
+		}
+		uploadIDs = append(uploadIDs, matching[0].ID)
+	}
+
+	diff, err := s.DiffAPISurface(ctx, checker, uploadIDs[0], uploadIDs[1])
+	if err != nil {
+		return shared.APISurfaceDiff{}, false, err
+	}
+
+	return diff, true, nil
+}
+
+// normalizeRoot returns the given upload root with a single trailing slash, or
+// the empty string for the repository root.
+func normalizeRoot(root string) string {
+	root = strings.Trim(root, "/")
+	if root == "" || root == "." {
+		return ""
+	}
+
+	return root + "/"
+}
+
 // func (s *Service) GetUploadDocumentsForPath(ctx context.Context, bundleID int, pathPattern string) ([]string, int, error) {
 // 	return s.lsifstore.GetUploadDocumentsForPath(ctx, bundleID, pathPattern)
 // }
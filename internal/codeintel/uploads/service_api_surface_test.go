@@ -0,0 +1,172 @@
+package uploads
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codegraph/codegraphmocks"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestDiffAPISurfaceSubRepoPermissions(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	mockStore := NewMockStore()
+	mockStore.GetUploadsByIDsFunc.SetDefaultReturn([]shared.Upload{
+		{ID: 1, State: "completed", RepositoryName: "github.com/test/lib", Root: "lib/"},
+		{ID: 2, State: "completed", RepositoryName: "github.com/test/lib", Root: "lib/"},
+	}, nil)
+
+	documents := map[int]map[string]*scip.Document{
+		1: {
+			"api.go":           apiSurfaceDocument("v1.0.0", "Public().", "func Public()"),
+			"secret/secret.go": apiSurfaceDocument("v1.0.0", "Secret().", "func Secret()"),
+		},
+		2: {
+			"api.go":           apiSurfaceDocument("v1.1.0", "Public().", "func Public()", "Added().", "func Added()"),
+			"secret/secret.go": apiSurfaceDocument("v1.1.0", "Secret().", "func Secret(a int)"),
+		},
+	}
+	mockDataStore := codegraphmocks.NewMockDataStore()
+	mockDataStore.ScanDocumentsFunc.SetDefaultHook(func(_ context.Context, uploadID int, f func(string, *scip.Document) error) error {
+		for path, document := range documents[uploadID] {
+			if err := f(path, document); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.EnabledForRepoFunc.SetDefaultReturn(true, nil)
+	checker.PermissionsFunc.SetDefaultHook(func(_ context.Context, _ int32, content authz.RepoContent) (authz.Perms, error) {
+		if strings.HasPrefix(content.Path, "lib/secret/") {
+			return authz.None, nil
+		}
+		return authz.Read, nil
+	})
+
+	svc := newService(observation.TestContextTB(t), mockStore, NewMockRepoStore(), mockDataStore, gitserver.NewMockClient())
+
+	diff, err := svc.DiffAPISurface(ctx, checker, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(diff.Removed) != 0 || len(diff.Changed) != 0 {
+		t.Errorf("unexpected removed or changed symbols: %+v", diff)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].Path != "lib/api.go" {
+		t.Errorf("unexpected added symbols: %+v", diff.Added)
+	}
+
+	// Without sub-repo permissions the change to Secret is visible
+	diff, err = svc.DiffAPISurface(ctx, nil, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Base.Path != "lib/secret/secret.go" {
+		t.Errorf("unexpected changed symbols: %+v", diff.Changed)
+	}
+}
+
+func TestDiffAPISurfaceAtCommits(t *testing.T) {
+	ctx := context.Background()
+
+	uploadsByCommit := map[api.CommitID][]shared.CompletedUpload{
+		"base": {
+			{ID: 1, Commit: "base", Root: "lib/", Indexer: "scip-go"},
+			{ID: 3, Commit: "base", Root: "", Indexer: "scip-go"},
+		},
+		"head": {
+			{ID: 2, Commit: "head", Root: "lib/", Indexer: "scip-go"},
+			{ID: 4, Commit: "head", Root: "lib/", Indexer: "scip-typescript"},
+		},
+	}
+	mockStore := NewMockStore()
+	mockStore.FindClosestCompletedUploadsFunc.SetDefaultHook(func(_ context.Context, opts shared.UploadMatchingOptions) ([]shared.CompletedUpload, error) {
+		var uploads []shared.CompletedUpload
+		for _, upload := range uploadsByCommit[opts.Commit] {
+			if opts.Indexer == "" || upload.Indexer == opts.Indexer {
+				uploads = append(uploads, upload)
+			}
+		}
+		return uploads, nil
+	})
+	mockStore.GetUploadsByIDsFunc.SetDefaultHook(func(_ context.Context, ids ...int) ([]shared.Upload, error) {
+		uploads := make([]shared.Upload, 0, len(ids))
+		for _, id := range ids {
+			uploads = append(uploads, shared.Upload{ID: id, State: "completed", RepositoryName: "github.com/test/lib", Root: "lib/"})
+		}
+		return uploads, nil
+	})
+
+	documents := map[int]map[string]*scip.Document{
+		1: {"api.go": apiSurfaceDocument("v1.0.0", "Public().", "func Public()")},
+		2: {"api.go": apiSurfaceDocument("v1.1.0", "Public().", "func Public(a int)")},
+	}
+	mockDataStore := codegraphmocks.NewMockDataStore()
+	mockDataStore.ScanDocumentsFunc.SetDefaultHook(func(_ context.Context, uploadID int, f func(string, *scip.Document) error) error {
+		for path, document := range documents[uploadID] {
+			if err := f(path, document); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	svc := newService(observation.TestContextTB(t), mockStore, NewMockRepoStore(), mockDataStore, gitserver.NewMockClient())
+
+	diff, ok, err := svc.DiffAPISurfaceAtCommits(ctx, nil, 42, "base", "head", "lib", "scip-go")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected precise indexes to be found")
+	}
+	if len(diff.Changed) != 1 || !strings.Contains(diff.Changed[0].Head.Signature, "func Public(a int)") {
+		t.Errorf("unexpected changed symbols: %+v", diff.Changed)
+	}
+	if history := mockDataStore.ScanDocumentsFunc.History(); len(history) != 2 || history[0].Arg1 != 1 || history[1].Arg1 != 2 {
+		t.Errorf("unexpected uploads scanned: %+v", history)
+	}
+
+	// Two indexers have a precise index with the root at the head commit
+	if _, _, err := svc.DiffAPISurfaceAtCommits(ctx, nil, 42, "base", "head", "lib/", ""); err == nil {
+		t.Errorf("expected an error for ambiguous precise indexes")
+	}
+
+	// No precise index with the root exists at the head commit
+	if _, ok, err := svc.DiffAPISurfaceAtCommits(ctx, nil, 42, "base", "head", "", "scip-go"); err != nil || ok {
+		t.Errorf("unexpected result for a missing precise index: ok=%v err=%v", ok, err)
+	}
+}
+
+// apiSurfaceDocument returns a document defining the given pairs of Go function
+// descriptors and signatures.
+func apiSurfaceDocument(version string, descriptorsAndSignatures ...string) *scip.Document {
+	document := &scip.Document{}
+	for i := 0; i < len(descriptorsAndSignatures); i += 2 {
+		symbol := "scip-go gomod example " + version + " lib/" + descriptorsAndSignatures[i]
+		document.Occurrences = append(document.Occurrences, &scip.Occurrence{
+			Range:       []int32{0, 0, 1},
+			Symbol:      symbol,
+			SymbolRoles: int32(scip.SymbolRole_Definition),
+		})
+		document.Symbols = append(document.Symbols, &scip.SymbolInformation{
+			Symbol:        symbol,
+			Kind:          scip.SymbolInformation_Function,
+			Documentation: []string{"```go\n" + descriptorsAndSignatures[i+1] + "\n```"},
+		})
+	}
+
+	return document
+}
@@ -0,0 +1,36 @@
+package shared
+
+import "github.com/sourcegraph/scip/bindings/go/scip"
+
+// APISymbol is a global symbol that is part of the public API of an upload.
+type APISymbol struct {
+	Symbol string
+	// Kind is the name of the SCIP symbol kind, or the empty string if the
+	// indexer did not emit one.
+	Kind string
+	// Signature is the signature of the symbol as rendered by the indexer, or
+	// the empty string if the indexer did not emit one.
+	Signature string
+	// Path is the path of the defining document, relative to the repository root.
+	Path  string
+	Range scip.Range
+}
+
+// APISymbolChange is a symbol whose kind or signature differs between two uploads.
+type APISymbolChange struct {
+	Base APISymbol
+	Head APISymbol
+}
+
+// APISurfaceDiff describes how the public API of a base upload changed in a
+// head upload. Symbols are matched by name ignoring the package version.
+type APISurfaceDiff struct {
+	Added   []APISymbol
+	Removed []APISymbol
+	Changed []APISymbolChange
+}
+
+// HasBreakingChanges returns true if a symbol was removed or changed.
+func (d APISurfaceDiff) HasBreakingChanges() bool {
+	return len(d.Removed) > 0 || len(d.Changed) > 0
+}
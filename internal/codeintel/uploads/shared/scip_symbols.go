@@ -100,3 +100,47 @@ func collapseRanges(ranges []scip.Range) []int32 {
 
 	return rangeComponents
 }
+
+// auxiliaryKinds are the kinds of symbols that are not meaningful on their own,
+// either because they are used implicitly (e.g. receivers) or because they only
+// group other symbols (e.g. packages).
+var auxiliaryKinds = map[scip.SymbolInformation_Kind]struct{}{
+	scip.SymbolInformation_File:           {},
+	scip.SymbolInformation_Lang:           {},
+	scip.SymbolInformation_Library:        {},
+	scip.SymbolInformation_MethodReceiver: {},
+	scip.SymbolInformation_Module:         {},
+	scip.SymbolInformation_Namespace:      {},
+	scip.SymbolInformation_Package:        {},
+	scip.SymbolInformation_PackageObject:  {},
+	scip.SymbolInformation_Parameter:      {},
+	scip.SymbolInformation_ParameterLabel: {},
+	scip.SymbolInformation_SelfParameter:  {},
+	scip.SymbolInformation_ThisParameter:  {},
+	scip.SymbolInformation_TypeParameter:  {},
+}
+
+// auxiliarySuffixes are the descriptor suffixes of the same symbols, used when
+// the indexer does not emit symbol kinds.
+var auxiliarySuffixes = map[scip.Descriptor_Suffix]struct{}{
+	scip.Descriptor_Namespace:     {},
+	scip.Descriptor_TypeParameter: {},
+	scip.Descriptor_Parameter:     {},
+	scip.Descriptor_Meta:          {},
+	scip.Descriptor_Local:         {},
+}
+
+// IsAuxiliarySymbol returns true if the given symbol is not meaningful on its
+// own, such as a package, parameter or receiver. These symbols are not part of
+// the public API of a package and are never reported as unused.
+func IsAuxiliarySymbol(symbol *scip.Symbol, kind scip.SymbolInformation_Kind) bool {
+	if _, ok := auxiliaryKinds[kind]; ok {
+		return true
+	}
+	if len(symbol.Descriptors) == 0 {
+		return true
+	}
+
+	_, ok := auxiliarySuffixes[symbol.Descriptors[len(symbol.Descriptors)-1].Suffix]
+	return ok
+}
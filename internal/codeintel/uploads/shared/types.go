@@ -61,6 +61,11 @@ type Upload struct {
 	AssociatedIndexID *int
 	ContentType       string
 	ShouldReindex     bool
+	// Shard names the partial index this upload provides for its commit, root, and
+	// indexer. Uploads of different shards are visible together as a single logical
+	// index. A commit that uploads only some shards inherits the remaining shards from
+	// its nearest indexed ancestor. The empty shard denotes a complete index.
+	Shard string
 }
 
 func (u Upload) RecordID() int {
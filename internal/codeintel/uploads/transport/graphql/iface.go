@@ -4,7 +4,10 @@ import (
 	"context"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	autoindexingshared "github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codegraph"
 	policiesshared "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/shared"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	uploadshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
@@ -17,6 +20,9 @@ type UploadsService interface {
 	GetAutoIndexJobs(ctx context.Context, opts uploadshared.GetAutoIndexJobsOptions) (_ []uploadsshared.AutoIndexJob, _ int, err error)
 	GetUploads(ctx context.Context, opts uploadshared.GetUploadsOptions) (uploads []shared.Upload, totalCount int, err error)
 	GetAuditLogsForUpload(ctx context.Context, uploadID int) (_ []shared.UploadLog, err error)
+	DiffAPISurface(ctx context.Context, checker authz.SubRepoPermissionChecker, baseUploadID, headUploadID int) (_ uploadshared.APISurfaceDiff, err error)
+	DiffAPISurfaceAtCommits(ctx context.Context, checker authz.SubRepoPermissionChecker, repositoryID int, baseCommit, headCommit api.CommitID, root, indexer string) (_ uploadshared.APISurfaceDiff, _ bool, err error)
+	GetUnusedSymbolReport(ctx context.Context, uploadID, limit, offset int) (_ codegraph.UnusedSymbolReport, _ bool, err error)
 	GetAutoIndexJobByID(ctx context.Context, id int) (_ uploadsshared.AutoIndexJob, _ bool, err error)
 	DeleteAutoIndexJobByID(ctx context.Context, id int) (_ bool, err error)
 	DeleteAutoIndexJobs(ctx context.Context, opts uploadshared.DeleteAutoIndexJobsOptions) (err error)
@@ -11,6 +11,9 @@ import (
 	"sync"
 	"time"
 
+	api "github.com/sourcegraph/sourcegraph/internal/api"
+	authz "github.com/sourcegraph/sourcegraph/internal/authz"
+	codegraph "github.com/sourcegraph/sourcegraph/internal/codeintel/codegraph"
 	shared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 )
 
@@ -31,6 +34,12 @@ type MockUploadsService struct {
 	// DeleteUploadsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteUploads.
 	DeleteUploadsFunc *UploadsServiceDeleteUploadsFunc
+	// DiffAPISurfaceFunc is an instance of a mock function object
+	// controlling the behavior of the method DiffAPISurface.
+	DiffAPISurfaceFunc *UploadsServiceDiffAPISurfaceFunc
+	// DiffAPISurfaceAtCommitsFunc is an instance of a mock function object
+	// controlling the behavior of the method DiffAPISurfaceAtCommits.
+	DiffAPISurfaceAtCommitsFunc *UploadsServiceDiffAPISurfaceAtCommitsFunc
 	// GetAuditLogsForUploadFunc is an instance of a mock function object
 	// controlling the behavior of the method GetAuditLogsForUpload.
 	GetAuditLogsForUploadFunc *UploadsServiceGetAuditLogsForUploadFunc
@@ -60,6 +69,9 @@ type MockUploadsService struct {
 	// GetRecentUploadsSummaryFunc is an instance of a mock function object
 	// controlling the behavior of the method GetRecentUploadsSummary.
 	GetRecentUploadsSummaryFunc *UploadsServiceGetRecentUploadsSummaryFunc
+	// GetUnusedSymbolReportFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUnusedSymbolReport.
+	GetUnusedSymbolReportFunc *UploadsServiceGetUnusedSymbolReportFunc
 	// GetUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadByID.
 	GetUploadByIDFunc *UploadsServiceGetUploadByIDFunc
@@ -114,6 +126,16 @@ func NewMockUploadsService() *MockUploadsService {
 				return
 			},
 		},
+		DiffAPISurfaceFunc: &UploadsServiceDiffAPISurfaceFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, int, int) (r0 shared.APISurfaceDiff, r1 error) {
+				return
+			},
+		},
+		DiffAPISurfaceAtCommitsFunc: &UploadsServiceDiffAPISurfaceAtCommitsFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, int, api.CommitID, api.CommitID, string, string) (r0 shared.APISurfaceDiff, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetAuditLogsForUploadFunc: &UploadsServiceGetAuditLogsForUploadFunc{
 			defaultHook: func(context.Context, int) (r0 []shared.UploadLog, r1 error) {
 				return
@@ -159,6 +181,11 @@ func NewMockUploadsService() *MockUploadsService {
 				return
 			},
 		},
+		GetUnusedSymbolReportFunc: &UploadsServiceGetUnusedSymbolReportFunc{
+			defaultHook: func(context.Context, int, int, int) (r0 codegraph.UnusedSymbolReport, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetUploadByIDFunc: &UploadsServiceGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (r0 shared.Upload, r1 bool, r2 error) {
 				return
@@ -231,6 +258,16 @@ func NewStrictMockUploadsService() *MockUploadsService {
 				panic("unexpected invocation of MockUploadsService.DeleteUploads")
 			},
 		},
+		DiffAPISurfaceFunc: &UploadsServiceDiffAPISurfaceFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, int, int) (shared.APISurfaceDiff, error) {
+				panic("unexpected invocation of MockUploadsService.DiffAPISurface")
+			},
+		},
+		DiffAPISurfaceAtCommitsFunc: &UploadsServiceDiffAPISurfaceAtCommitsFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, int, api.CommitID, api.CommitID, string, string) (shared.APISurfaceDiff, bool, error) {
+				panic("unexpected invocation of MockUploadsService.DiffAPISurfaceAtCommits")
+			},
+		},
 		GetAuditLogsForUploadFunc: &UploadsServiceGetAuditLogsForUploadFunc{
 			defaultHook: func(context.Context, int) ([]shared.UploadLog, error) {
 				panic("unexpected invocation of MockUploadsService.GetAuditLogsForUpload")
@@ -276,6 +313,11 @@ func NewStrictMockUploadsService() *MockUploadsService {
 				panic("unexpected invocation of MockUploadsService.GetRecentUploadsSummary")
 			},
 		},
+		GetUnusedSymbolReportFunc: &UploadsServiceGetUnusedSymbolReportFunc{
+			defaultHook: func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error) {
+				panic("unexpected invocation of MockUploadsService.GetUnusedSymbolReport")
+			},
+		},
 		GetUploadByIDFunc: &UploadsServiceGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (shared.Upload, bool, error) {
 				panic("unexpected invocation of MockUploadsService.GetUploadByID")
@@ -341,6 +383,12 @@ func NewMockUploadsServiceFrom(i UploadsService) *MockUploadsService {
 		DeleteUploadsFunc: &UploadsServiceDeleteUploadsFunc{
 			defaultHook: i.DeleteUploads,
 		},
+		DiffAPISurfaceFunc: &UploadsServiceDiffAPISurfaceFunc{
+			defaultHook: i.DiffAPISurface,
+		},
+		DiffAPISurfaceAtCommitsFunc: &UploadsServiceDiffAPISurfaceAtCommitsFunc{
+			defaultHook: i.DiffAPISurfaceAtCommits,
+		},
 		GetAuditLogsForUploadFunc: &UploadsServiceGetAuditLogsForUploadFunc{
 			defaultHook: i.GetAuditLogsForUpload,
 		},
@@ -368,6 +416,9 @@ func NewMockUploadsServiceFrom(i UploadsService) *MockUploadsService {
 		GetRecentUploadsSummaryFunc: &UploadsServiceGetRecentUploadsSummaryFunc{
 			defaultHook: i.GetRecentUploadsSummary,
 		},
+		GetUnusedSymbolReportFunc: &UploadsServiceGetUnusedSymbolReportFunc{
+			defaultHook: i.GetUnusedSymbolReport,
+		},
 		GetUploadByIDFunc: &UploadsServiceGetUploadByIDFunc{
 			defaultHook: i.GetUploadByID,
 		},
@@ -834,6 +885,244 @@ func (c UploadsServiceDeleteUploadsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// UploadsServiceDiffAPISurfaceFunc describes the behavior when the
+// DiffAPISurface method of the parent MockUploadsService instance is
+// invoked.
+type UploadsServiceDiffAPISurfaceFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, int, int) (shared.APISurfaceDiff, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, int, int) (shared.APISurfaceDiff, error)
+	history     []UploadsServiceDiffAPISurfaceFuncCall
+	mutex       sync.Mutex
+}
+
+// DiffAPISurface delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUploadsService) DiffAPISurface(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 int, v3 int) (shared.APISurfaceDiff, error) {
+	r0, r1 := m.DiffAPISurfaceFunc.nextHook()(v0, v1, v2, v3)
+	m.DiffAPISurfaceFunc.appendCall(UploadsServiceDiffAPISurfaceFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DiffAPISurface
+// method of the parent MockUploadsService instance is invoked and the hook
+// queue is empty.
+func (f *UploadsServiceDiffAPISurfaceFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, int, int) (shared.APISurfaceDiff, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DiffAPISurface method of the parent MockUploadsService instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UploadsServiceDiffAPISurfaceFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, int, int) (shared.APISurfaceDiff, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadsServiceDiffAPISurfaceFunc) SetDefaultReturn(r0 shared.APISurfaceDiff, r1 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, int, int) (shared.APISurfaceDiff, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadsServiceDiffAPISurfaceFunc) PushReturn(r0 shared.APISurfaceDiff, r1 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, int, int) (shared.APISurfaceDiff, error) {
+		return r0, r1
+	})
+}
+
+func (f *UploadsServiceDiffAPISurfaceFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, int, int) (shared.APISurfaceDiff, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadsServiceDiffAPISurfaceFunc) appendCall(r0 UploadsServiceDiffAPISurfaceFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadsServiceDiffAPISurfaceFuncCall
+// objects describing the invocations of this function.
+func (f *UploadsServiceDiffAPISurfaceFunc) History() []UploadsServiceDiffAPISurfaceFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadsServiceDiffAPISurfaceFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadsServiceDiffAPISurfaceFuncCall is an object that describes an
+// invocation of method DiffAPISurface on an instance of MockUploadsService.
+type UploadsServiceDiffAPISurfaceFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared.APISurfaceDiff
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadsServiceDiffAPISurfaceFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadsServiceDiffAPISurfaceFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UploadsServiceDiffAPISurfaceAtCommitsFunc describes the behavior when the
+// DiffAPISurfaceAtCommits method of the parent MockUploadsService instance
+// is invoked.
+type UploadsServiceDiffAPISurfaceAtCommitsFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, int, api.CommitID, api.CommitID, string, string) (shared.APISurfaceDiff, bool, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, int, api.CommitID, api.CommitID, string, string) (shared.APISurfaceDiff, bool, error)
+	history     []UploadsServiceDiffAPISurfaceAtCommitsFuncCall
+	mutex       sync.Mutex
+}
+
+// DiffAPISurfaceAtCommits delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUploadsService) DiffAPISurfaceAtCommits(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 int, v3 api.CommitID, v4 api.CommitID, v5 string, v6 string) (shared.APISurfaceDiff, bool, error) {
+	r0, r1, r2 := m.DiffAPISurfaceAtCommitsFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6)
+	m.DiffAPISurfaceAtCommitsFunc.appendCall(UploadsServiceDiffAPISurfaceAtCommitsFuncCall{v0, v1, v2, v3, v4, v5, v6, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// DiffAPISurfaceAtCommits method of the parent MockUploadsService instance
+// is invoked and the hook queue is empty.
+func (f *UploadsServiceDiffAPISurfaceAtCommitsFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, int, api.CommitID, api.CommitID, string, string) (shared.APISurfaceDiff, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DiffAPISurfaceAtCommits method of the parent MockUploadsService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UploadsServiceDiffAPISurfaceAtCommitsFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, int, api.CommitID, api.CommitID, string, string) (shared.APISurfaceDiff, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadsServiceDiffAPISurfaceAtCommitsFunc) SetDefaultReturn(r0 shared.APISurfaceDiff, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, int, api.CommitID, api.CommitID, string, string) (shared.APISurfaceDiff, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadsServiceDiffAPISurfaceAtCommitsFunc) PushReturn(r0 shared.APISurfaceDiff, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, int, api.CommitID, api.CommitID, string, string) (shared.APISurfaceDiff, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *UploadsServiceDiffAPISurfaceAtCommitsFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, int, api.CommitID, api.CommitID, string, string) (shared.APISurfaceDiff, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadsServiceDiffAPISurfaceAtCommitsFunc) appendCall(r0 UploadsServiceDiffAPISurfaceAtCommitsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UploadsServiceDiffAPISurfaceAtCommitsFuncCall objects describing the
+// invocations of this function.
+func (f *UploadsServiceDiffAPISurfaceAtCommitsFunc) History() []UploadsServiceDiffAPISurfaceAtCommitsFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadsServiceDiffAPISurfaceAtCommitsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadsServiceDiffAPISurfaceAtCommitsFuncCall is an object that describes
+// an invocation of method DiffAPISurfaceAtCommits on an instance of
+// MockUploadsService.
+type UploadsServiceDiffAPISurfaceAtCommitsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method invocation.
+	Arg3 api.CommitID
+	// Arg4 is the value of the 5th argument passed to this method invocation.
+	Arg4 api.CommitID
+	// Arg5 is the value of the 6th argument passed to this method invocation.
+	Arg5 string
+	// Arg6 is the value of the 7th argument passed to this method invocation.
+	Arg6 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared.APISurfaceDiff
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadsServiceDiffAPISurfaceAtCommitsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadsServiceDiffAPISurfaceAtCommitsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // UploadsServiceGetAuditLogsForUploadFunc describes the behavior when the
 // GetAuditLogsForUpload method of the parent MockUploadsService instance is
 // invoked.
@@ -1851,6 +2140,126 @@ func (c UploadsServiceGetRecentUploadsSummaryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// UploadsServiceGetUnusedSymbolReportFunc describes the behavior when the
+// GetUnusedSymbolReport method of the parent MockUploadsService instance is
+// invoked.
+type UploadsServiceGetUnusedSymbolReportFunc struct {
+	defaultHook func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error)
+	hooks       []func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error)
+	history     []UploadsServiceGetUnusedSymbolReportFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUnusedSymbolReport delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUploadsService) GetUnusedSymbolReport(v0 context.Context, v1 int, v2 int, v3 int) (codegraph.UnusedSymbolReport, bool, error) {
+	r0, r1, r2 := m.GetUnusedSymbolReportFunc.nextHook()(v0, v1, v2, v3)
+	m.GetUnusedSymbolReportFunc.appendCall(UploadsServiceGetUnusedSymbolReportFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUnusedSymbolReport method of the parent MockUploadsService instance is
+// invoked and the hook queue is empty.
+func (f *UploadsServiceGetUnusedSymbolReportFunc) SetDefaultHook(hook func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUnusedSymbolReport method of the parent MockUploadsService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UploadsServiceGetUnusedSymbolReportFunc) PushHook(hook func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadsServiceGetUnusedSymbolReportFunc) SetDefaultReturn(r0 codegraph.UnusedSymbolReport, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadsServiceGetUnusedSymbolReportFunc) PushReturn(r0 codegraph.UnusedSymbolReport, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *UploadsServiceGetUnusedSymbolReportFunc) nextHook() func(context.Context, int, int, int) (codegraph.UnusedSymbolReport, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadsServiceGetUnusedSymbolReportFunc) appendCall(r0 UploadsServiceGetUnusedSymbolReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadsServiceGetUnusedSymbolReportFuncCall
+// objects describing the invocations of this function.
+func (f *UploadsServiceGetUnusedSymbolReportFunc) History() []UploadsServiceGetUnusedSymbolReportFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadsServiceGetUnusedSymbolReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadsServiceGetUnusedSymbolReportFuncCall is an object that describes
+// an invocation of method GetUnusedSymbolReport on an instance of
+// MockUploadsService.
+type UploadsServiceGetUnusedSymbolReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 codegraph.UnusedSymbolReport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadsServiceGetUnusedSymbolReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadsServiceGetUnusedSymbolReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // UploadsServiceGetUploadByIDFunc describes the behavior when the
 // GetUploadByID method of the parent MockUploadsService instance is
 // invoked.
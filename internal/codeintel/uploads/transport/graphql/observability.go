@@ -9,9 +9,11 @@ import (
 
 type operations struct {
 	codeIntelSummary      *observation.Operation
+	commitAPIDiff         *observation.Operation
 	commitGraph           *observation.Operation
 	deletePreciseIndex    *observation.Operation
 	deletePreciseIndexes  *observation.Operation
+	preciseIndexAPIDiff   *observation.Operation
 	preciseIndexByID      *observation.Operation
 	preciseIndexes        *observation.Operation
 	reindexPreciseIndex   *observation.Operation
@@ -37,9 +39,11 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 	return &operations{
 		codeIntelSummary:      op("CodeIntelSummary"),
+		commitAPIDiff:         op("CommitAPIDiff"),
 		commitGraph:           op("CommitGraph"),
 		deletePreciseIndex:    op("DeletePreciseIndex"),
 		deletePreciseIndexes:  op("DeletePreciseIndexes"),
+		preciseIndexAPIDiff:   op("PreciseIndexAPIDiff"),
 		preciseIndexByID:      op("PreciseIndexByID"),
 		preciseIndexes:        op("PreciseIndexes"),
 		reindexPreciseIndex:   op("ReindexPreciseIndex"),
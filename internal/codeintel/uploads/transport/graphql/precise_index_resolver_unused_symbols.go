@@ -0,0 +1,127 @@
+package graphql
+
+import (
+	"context"
+	"path"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codegraph"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+const DefaultUnusedSymbolsPageSize = 100
+
+func (r *preciseIndexResolver) UnusedSymbols(ctx context.Context, args *resolverstubs.UnusedSymbolsArgs) (resolverstubs.UnusedSymbolConnectionResolver, error) {
+	if r.upload == nil {
+		return nil, nil
+	}
+
+	limit, offset, err := args.ParseLimitOffset(DefaultUnusedSymbolsPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	report, ok, err := r.getUnusedSymbolReport(ctx, authz.DefaultSubRepoPermsChecker, int(limit), int(offset))
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	resolvers := make([]resolverstubs.UnusedSymbolResolver, 0, len(report.Symbols))
+	for _, symbol := range report.Symbols {
+		resolvers = append(resolvers, &unusedSymbolResolver{root: r.upload.Root, symbol: symbol})
+	}
+
+	cursor := ""
+	if nextOffset := int(offset) + len(report.Symbols); nextOffset < report.TotalCount {
+		cursor = strconv.Itoa(nextOffset)
+	}
+
+	return &unusedSymbolConnectionResolver{
+		PagedConnectionWithTotalCountResolver: resolverstubs.NewCursorWithTotalCountConnectionResolver(resolvers, cursor, int32(report.TotalCount)),
+		computedAt:                            gqlutil.DateTime{Time: report.ComputedAt},
+	}, nil
+}
+
+// getUnusedSymbolReport returns the requested page of the unused symbol report
+// of the upload. If sub-repository permissions are enabled for the repository,
+// the whole report is read so that symbols in files the current actor cannot
+// read are neither returned nor counted.
+func (r *preciseIndexResolver) getUnusedSymbolReport(ctx context.Context, checker authz.SubRepoPermissionChecker, limit, offset int) (codegraph.UnusedSymbolReport, bool, error) {
+	repo := api.RepoName(r.upload.RepositoryName)
+	checkPaths, err := authz.SubRepoEnabledForRepo(ctx, checker, repo)
+	if err != nil {
+		return codegraph.UnusedSymbolReport{}, false, err
+	}
+	if !checkPaths {
+		return r.uploadsSvc.GetUnusedSymbolReport(ctx, r.upload.ID, limit, offset)
+	}
+
+	report, ok, err := r.uploadsSvc.GetUnusedSymbolReport(ctx, r.upload.ID, 0, 0)
+	if err != nil || !ok {
+		return codegraph.UnusedSymbolReport{}, ok, err
+	}
+	report, ok, err = r.uploadsSvc.GetUnusedSymbolReport(ctx, r.upload.ID, report.TotalCount, 0)
+	if err != nil || !ok {
+		return codegraph.UnusedSymbolReport{}, ok, err
+	}
+
+	a := actor.FromContext(ctx)
+	visible := make([]codegraph.UnusedSymbol, 0, len(report.Symbols))
+	for _, symbol := range report.Symbols {
+		canRead, err := authz.FilterActorPath(ctx, checker, a, repo, path.Join(r.upload.Root, symbol.Path))
+		if err != nil {
+			return codegraph.UnusedSymbolReport{}, false, err
+		}
+		if canRead {
+			visible = append(visible, symbol)
+		}
+	}
+
+	report.TotalCount = len(visible)
+	report.Symbols = visible[min(offset, len(visible)):min(offset+limit, len(visible))]
+	return report, true, nil
+}
+
+//
+//
+
+type unusedSymbolConnectionResolver struct {
+	resolverstubs.PagedConnectionWithTotalCountResolver[resolverstubs.UnusedSymbolResolver]
+	computedAt gqlutil.DateTime
+}
+
+func (r *unusedSymbolConnectionResolver) ComputedAt() gqlutil.DateTime {
+	return r.computedAt
+}
+
+//
+//
+
+type unusedSymbolResolver struct {
+	root   string
+	symbol codegraph.UnusedSymbol
+}
+
+func (r *unusedSymbolResolver) Symbol() string {
+	return r.symbol.Symbol
+}
+
+func (r *unusedSymbolResolver) Kind() *string {
+	if r.symbol.Kind == "" {
+		return nil
+	}
+
+	return &r.symbol.Kind
+}
+
+func (r *unusedSymbolResolver) Path() string {
+	return path.Join(r.root, r.symbol.Path)
+}
+
+func (r *unusedSymbolResolver) Range() resolverstubs.RangeResolver {
+	return newRangeResolver(r.symbol.Range)
+}
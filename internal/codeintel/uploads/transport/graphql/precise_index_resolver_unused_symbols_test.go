@@ -0,0 +1,78 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codegraph"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+)
+
+func TestGetUnusedSymbolReportSubRepoPermissions(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	symbols := []codegraph.UnusedSymbol{
+		{Symbol: "a", Path: "public/a.go"},
+		{Symbol: "b", Path: "secret/b.go"},
+		{Symbol: "c", Path: "public/c.go"},
+		{Symbol: "d", Path: "public/d.go"},
+	}
+	uploadsSvc := NewMockUploadsService()
+	uploadsSvc.GetUnusedSymbolReportFunc.SetDefaultHook(func(_ context.Context, _, limit, offset int) (codegraph.UnusedSymbolReport, bool, error) {
+		return codegraph.UnusedSymbolReport{
+			UploadID:   42,
+			TotalCount: len(symbols),
+			Symbols:    symbols[min(offset, len(symbols)):min(offset+limit, len(symbols))],
+		}, true, nil
+	})
+
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.EnabledForRepoFunc.SetDefaultReturn(true, nil)
+	checker.PermissionsFunc.SetDefaultHook(func(_ context.Context, _ int32, content authz.RepoContent) (authz.Perms, error) {
+		if content.Repo != "github.com/test/repo" {
+			t.Errorf("unexpected repository %q", content.Repo)
+		}
+		if strings.HasPrefix(content.Path, "sub/secret/") {
+			return authz.None, nil
+		}
+		return authz.Read, nil
+	})
+
+	r := &preciseIndexResolver{
+		uploadsSvc: uploadsSvc,
+		upload:     &shared.Upload{ID: 42, RepositoryName: "github.com/test/repo", Root: "sub/"},
+	}
+
+	report, ok, err := r.getUnusedSymbolReport(ctx, checker, 2, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !ok {
+		t.Fatal("expected a report")
+	}
+	if report.TotalCount != 3 {
+		t.Errorf("unexpected total count. want=%d have=%d", 3, report.TotalCount)
+	}
+	var names []string
+	for _, symbol := range report.Symbols {
+		names = append(names, symbol.Symbol)
+	}
+	if diff := cmp.Diff([]string{"c", "d"}, names); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	checker.EnabledForRepoFunc.SetDefaultReturn(false, nil)
+	report, _, err = r.getUnusedSymbolReport(ctx, checker, 2, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if report.TotalCount != 4 || len(report.Symbols) != 2 || report.Symbols[0].Symbol != "b" {
+		t.Errorf("unexpected unfiltered report: %+v", report)
+	}
+}
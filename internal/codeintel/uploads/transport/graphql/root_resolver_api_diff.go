@@ -0,0 +1,160 @@
+package graphql
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func (r *rootResolver) PreciseIndexAPIDiff(ctx context.Context, args *resolverstubs.PreciseIndexAPIDiffArgs) (_ resolverstubs.PreciseIndexAPIDiffResolver, err error) {
+	ctx, _, endObservation := r.operations.preciseIndexAPIDiff.WithErrors(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("base", string(args.Base)),
+		attribute.String("head", string(args.Head)),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	baseUploadID, _, err := UnmarshalPreciseIndexGQLID(args.Base)
+	if err != nil {
+		return nil, err
+	}
+	headUploadID, _, err := UnmarshalPreciseIndexGQLID(args.Head)
+	if err != nil {
+		return nil, err
+	}
+	if baseUploadID == 0 || headUploadID == 0 {
+		return nil, errors.New("precise indexes must be processed before they can be compared")
+	}
+
+	diff, err := r.uploadSvc.DiffAPISurface(ctx, authz.DefaultSubRepoPermsChecker, baseUploadID, headUploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &preciseIndexAPIDiffResolver{diff: diff}, nil
+}
+
+func (r *rootResolver) CommitAPIDiff(ctx context.Context, args *resolverstubs.CommitAPIDiffArgs) (_ resolverstubs.PreciseIndexAPIDiffResolver, err error) {
+	ctx, _, endObservation := r.operations.commitAPIDiff.WithErrors(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("repository", string(args.Repository)),
+		attribute.String("base", args.Base),
+		attribute.String("head", args.Head),
+		attribute.String("root", args.Root),
+		attribute.String("indexer", pointers.Deref(args.Indexer, "")),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	repositoryID, err := resolverstubs.UnmarshalID[int](args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, ok, err := r.uploadSvc.DiffAPISurfaceAtCommits(
+		ctx,
+		authz.DefaultSubRepoPermsChecker,
+		repositoryID,
+		api.CommitID(args.Base),
+		api.CommitID(args.Head),
+		args.Root,
+		pointers.Deref(args.Indexer, ""),
+	)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &preciseIndexAPIDiffResolver{diff: diff}, nil
+}
+
+//
+//
+
+type preciseIndexAPIDiffResolver struct {
+	diff shared.APISurfaceDiff
+}
+
+func (r *preciseIndexAPIDiffResolver) Added() []resolverstubs.APISymbolResolver {
+	return newAPISymbolResolvers(r.diff.Added)
+}
+
+func (r *preciseIndexAPIDiffResolver) Removed() []resolverstubs.APISymbolResolver {
+	return newAPISymbolResolvers(r.diff.Removed)
+}
+
+func (r *preciseIndexAPIDiffResolver) Changed() []resolverstubs.APISymbolChangeResolver {
+	resolvers := make([]resolverstubs.APISymbolChangeResolver, 0, len(r.diff.Changed))
+	for _, change := range r.diff.Changed {
+		resolvers = append(resolvers, &apiSymbolChangeResolver{change: change})
+	}
+
+	return resolvers
+}
+
+func (r *preciseIndexAPIDiffResolver) HasBreakingChanges() bool {
+	return r.diff.HasBreakingChanges()
+}
+
+//
+//
+
+type apiSymbolChangeResolver struct {
+	change shared.APISymbolChange
+}
+
+func (r *apiSymbolChangeResolver) Base() resolverstubs.APISymbolResolver {
+	return &apiSymbolResolver{symbol: r.change.Base}
+}
+
+func (r *apiSymbolChangeResolver) Head() resolverstubs.APISymbolResolver {
+	return &apiSymbolResolver{symbol: r.change.Head}
+}
+
+//
+//
+
+type apiSymbolResolver struct {
+	symbol shared.APISymbol
+}
+
+func newAPISymbolResolvers(symbols []shared.APISymbol) []resolverstubs.APISymbolResolver {
+	resolvers := make([]resolverstubs.APISymbolResolver, 0, len(symbols))
+	for _, symbol := range symbols {
+		resolvers = append(resolvers, &apiSymbolResolver{symbol: symbol})
+	}
+
+	return resolvers
+}
+
+func (r *apiSymbolResolver) Symbol() string {
+	return r.symbol.Symbol
+}
+
+func (r *apiSymbolResolver) Kind() *string {
+	if r.symbol.Kind == "" {
+		return nil
+	}
+
+	return &r.symbol.Kind
+}
+
+func (r *apiSymbolResolver) Signature() *string {
+	if r.symbol.Signature == "" {
+		return nil
+	}
+
+	return &r.symbol.Signature
+}
+
+func (r *apiSymbolResolver) Path() string {
+	return r.symbol.Path
+}
+
+func (r *apiSymbolResolver) Range() resolverstubs.RangeResolver {
+	return newRangeResolver(r.symbol.Range)
+}
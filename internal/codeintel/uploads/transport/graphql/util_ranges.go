@@ -0,0 +1,30 @@
+package graphql
+
+import (
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+)
+
+type rangeResolver struct {
+	r scip.Range
+}
+
+func newRangeResolver(r scip.Range) resolverstubs.RangeResolver {
+	return &rangeResolver{r: r}
+}
+
+func (r *rangeResolver) Start() resolverstubs.PositionResolver {
+	return &positionResolver{p: r.r.Start}
+}
+
+func (r *rangeResolver) End() resolverstubs.PositionResolver {
+	return &positionResolver{p: r.r.End}
+}
+
+type positionResolver struct {
+	p scip.Position
+}
+
+func (r *positionResolver) Line() int32      { return r.p.Line }
+func (r *positionResolver) Character() int32 { return r.p.Character }
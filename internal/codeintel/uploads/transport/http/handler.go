@@ -55,6 +55,7 @@ func newHandler(
 			IndexerVersion:    getQuery(r, "indexerVersion"),
 			AssociatedIndexID: getQueryInt(r, "associatedIndexId"),
 			ContentType:       contentType,
+			Shard:             getQuery(r, "shard"),
 		}, 0, nil
 	}
 
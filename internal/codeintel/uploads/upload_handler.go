@@ -16,6 +16,7 @@ type UploadMetadata struct {
 	IndexerVersion    string
 	AssociatedIndexID int
 	ContentType       string
+	Shard             string
 }
 
 type uploadHandlerShim struct {
@@ -50,6 +51,7 @@ func (s *uploadHandlerShim) InsertUpload(ctx context.Context, upload uploadhandl
 		IndexerVersion:    upload.Metadata.IndexerVersion,
 		AssociatedIndexID: associatedIndexID,
 		ContentType:       upload.Metadata.ContentType,
+		Shard:             upload.Metadata.Shard,
 	})
 }
 
@@ -75,6 +77,7 @@ func (s *uploadHandlerShim) GetUploadByID(ctx context.Context, uploadID int) (up
 			Root:           upload.Root,
 			Indexer:        upload.Indexer,
 			IndexerVersion: upload.IndexerVersion,
+			Shard:          upload.Shard,
 		},
 	}
 
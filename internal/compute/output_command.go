@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/grafana/regexp"
 	"github.com/sourcegraph/log"
@@ -103,6 +104,13 @@ func resultChunks(r result.Match, kind string, onlyPath bool) []string {
 		return []string{content}
 	case *result.OwnerMatch:
 		return []string{m.ResolvedOwner.Identifier()}
+	case *result.CommitHistogramMatch:
+		// One line per bucket, e.g. "2023-03-13 42"
+		lines := make([]string, 0, len(m.Buckets))
+		for _, b := range m.Buckets {
+			lines = append(lines, fmt.Sprintf("%s %d", b.Start.Format(time.DateOnly), b.Count))
+		}
+		return lines
 	default:
 		panic("unsupported result kind in compute output command")
 	}
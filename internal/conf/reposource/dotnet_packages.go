@@ -0,0 +1,89 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const dotnetPackagesPrefix = "nuget/"
+
+type DotNetVersionedPackage struct {
+	Name    PackageName
+	Version string
+}
+
+func NewDotNetVersionedPackage(name PackageName, version string) *DotNetVersionedPackage {
+	return &DotNetVersionedPackage{
+		Name:    name,
+		Version: version,
+	}
+}
+
+// ParseDotNetVersionedPackage parses a string in a '<id>(@version>)?' format into a
+// DotNetVersionedPackage.
+func ParseDotNetVersionedPackage(dependency string) *DotNetVersionedPackage {
+	var dep DotNetVersionedPackage
+	if i := strings.LastIndex(dependency, "@"); i == -1 {
+		dep.Name = PackageName(dependency)
+	} else {
+		dep.Name = PackageName(strings.TrimSpace(dependency[:i]))
+		dep.Version = strings.TrimSpace(dependency[i+1:])
+	}
+	return &dep
+}
+
+func ParseDotNetPackageFromName(name PackageName) *DotNetVersionedPackage {
+	return ParseDotNetVersionedPackage(string(name))
+}
+
+// ParseDotNetPackageFromRepoName is a convenience function to parse a repo name in a
+// 'nuget/<id>(@<version>)?' format into a DotNetVersionedPackage.
+func ParseDotNetPackageFromRepoName(name api.RepoName) (*DotNetVersionedPackage, error) {
+	dependency := strings.TrimPrefix(string(name), dotnetPackagesPrefix)
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid .NET dependency repo name, missing %s prefix '%s'", dotnetPackagesPrefix, name)
+	}
+	return ParseDotNetVersionedPackage(dependency), nil
+}
+
+func (p *DotNetVersionedPackage) Scheme() string {
+	return "scip-dotnet"
+}
+
+func (p *DotNetVersionedPackage) PackageSyntax() PackageName {
+	return p.Name
+}
+
+func (p *DotNetVersionedPackage) VersionedPackageSyntax() string {
+	if p.Version == "" {
+		return string(p.Name)
+	}
+	return string(p.Name) + "@" + p.Version
+}
+
+func (p *DotNetVersionedPackage) PackageVersion() string {
+	return p.Version
+}
+
+func (p *DotNetVersionedPackage) Description() string { return "" }
+
+func (p *DotNetVersionedPackage) RepoName() api.RepoName {
+	return api.RepoName(dotnetPackagesPrefix + p.Name)
+}
+
+func (p *DotNetVersionedPackage) GitTagFromVersion() string {
+	version := strings.TrimPrefix(p.Version, "v")
+	return "v" + version
+}
+
+func (p *DotNetVersionedPackage) Less(other VersionedPackage) bool {
+	o := other.(*DotNetVersionedPackage)
+
+	if p.Name == o.Name {
+		return versionGreaterThan(p.Version, o.Version)
+	}
+
+	return p.Name > o.Name
+}
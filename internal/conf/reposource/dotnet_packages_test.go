@@ -0,0 +1,62 @@
+package reposource
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseDotNetVersionedPackage(t *testing.T) {
+	table := []struct {
+		dependency string
+		want       *DotNetVersionedPackage
+	}{
+		{"Newtonsoft.Json@13.0.3", &DotNetVersionedPackage{Name: "Newtonsoft.Json", Version: "13.0.3"}},
+		{"Serilog @ 3.1.1", &DotNetVersionedPackage{Name: "Serilog", Version: "3.1.1"}},
+		{"Microsoft.Extensions.Logging", &DotNetVersionedPackage{Name: "Microsoft.Extensions.Logging"}},
+		{"NUnit@4.0.0-beta.1", &DotNetVersionedPackage{Name: "NUnit", Version: "4.0.0-beta.1"}},
+	}
+	for _, entry := range table {
+		t.Run(entry.dependency, func(t *testing.T) {
+			assert.Equal(t, entry.want, ParseDotNetVersionedPackage(entry.dependency))
+		})
+	}
+}
+
+func TestParseDotNetPackageFromRepoName(t *testing.T) {
+	dep, err := ParseDotNetPackageFromRepoName("nuget/Newtonsoft.Json")
+	require.NoError(t, err)
+	assert.Equal(t, PackageName("Newtonsoft.Json"), dep.PackageSyntax())
+	assert.Equal(t, api.RepoName("nuget/Newtonsoft.Json"), dep.RepoName())
+	assert.Equal(t, "scip-dotnet", dep.Scheme())
+
+	_, err = ParseDotNetPackageFromRepoName("Newtonsoft.Json")
+	require.Error(t, err)
+}
+
+func TestDotNetVersionedPackage_GitTagFromVersion(t *testing.T) {
+	assert.Equal(t, "v13.0.3", ParseDotNetVersionedPackage("Newtonsoft.Json@13.0.3").GitTagFromVersion())
+	assert.Equal(t, "v13.0.3", ParseDotNetVersionedPackage("Newtonsoft.Json@v13.0.3").GitTagFromVersion())
+	assert.Equal(t, "Newtonsoft.Json@13.0.3", ParseDotNetVersionedPackage("Newtonsoft.Json@13.0.3").VersionedPackageSyntax())
+	assert.Equal(t, "Newtonsoft.Json", ParseDotNetVersionedPackage("Newtonsoft.Json").VersionedPackageSyntax())
+}
+
+func TestDotNetDependency_Less(t *testing.T) {
+	deps := []VersionedPackage{
+		ParseDotNetVersionedPackage("A.B@1.2.0"),
+		ParseDotNetVersionedPackage("A.B@1.11.0"),
+		ParseDotNetVersionedPackage("A.C@1.0.0"),
+		ParseDotNetVersionedPackage("A.B@1.10.0"),
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Less(deps[j]) })
+
+	var got []string
+	for _, dep := range deps {
+		got = append(got, dep.VersionedPackageSyntax())
+	}
+	assert.Equal(t, []string{"A.C@1.0.0", "A.B@1.11.0", "A.B@1.10.0", "A.B@1.2.0"}, got)
+}
@@ -0,0 +1,62 @@
+package reposource
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseElixirVersionedPackage(t *testing.T) {
+	table := []struct {
+		dependency string
+		want       *ElixirVersionedPackage
+	}{
+		{"phoenix@1.7.10", &ElixirVersionedPackage{Name: "phoenix", Version: "1.7.10"}},
+		{"ecto_sql @ 3.11.1", &ElixirVersionedPackage{Name: "ecto_sql", Version: "3.11.1"}},
+		{"cowboy", &ElixirVersionedPackage{Name: "cowboy"}},
+		{"jason@1.5.0-alpha.2", &ElixirVersionedPackage{Name: "jason", Version: "1.5.0-alpha.2"}},
+	}
+	for _, entry := range table {
+		t.Run(entry.dependency, func(t *testing.T) {
+			assert.Equal(t, entry.want, ParseElixirVersionedPackage(entry.dependency))
+		})
+	}
+}
+
+func TestParseElixirPackageFromRepoName(t *testing.T) {
+	dep, err := ParseElixirPackageFromRepoName("hex/phoenix")
+	require.NoError(t, err)
+	assert.Equal(t, PackageName("phoenix"), dep.PackageSyntax())
+	assert.Equal(t, api.RepoName("hex/phoenix"), dep.RepoName())
+	assert.Equal(t, "hex", dep.Scheme())
+
+	_, err = ParseElixirPackageFromRepoName("phoenix")
+	require.Error(t, err)
+}
+
+func TestElixirVersionedPackage_GitTagFromVersion(t *testing.T) {
+	assert.Equal(t, "v1.7.10", ParseElixirVersionedPackage("phoenix@1.7.10").GitTagFromVersion())
+	assert.Equal(t, "v1.7.10", ParseElixirVersionedPackage("phoenix@v1.7.10").GitTagFromVersion())
+	assert.Equal(t, "phoenix@1.7.10", ParseElixirVersionedPackage("phoenix@1.7.10").VersionedPackageSyntax())
+	assert.Equal(t, "phoenix", ParseElixirVersionedPackage("phoenix").VersionedPackageSyntax())
+}
+
+func TestElixirDependency_Less(t *testing.T) {
+	deps := []VersionedPackage{
+		ParseElixirVersionedPackage("ecto@3.2.0"),
+		ParseElixirVersionedPackage("ecto@3.11.0"),
+		ParseElixirVersionedPackage("plug@1.0.0"),
+		ParseElixirVersionedPackage("ecto@3.10.0"),
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Less(deps[j]) })
+
+	var got []string
+	for _, dep := range deps {
+		got = append(got, dep.VersionedPackageSyntax())
+	}
+	assert.Equal(t, []string{"plug@1.0.0", "ecto@3.11.0", "ecto@3.10.0", "ecto@3.2.0"}, got)
+}
@@ -0,0 +1,96 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const phpPackagesPrefix = "packagist/"
+
+// PHPVersionedPackage is a Composer package, which is named '<vendor>/<package>'.
+type PHPVersionedPackage struct {
+	Name    PackageName
+	Version string
+}
+
+func NewPHPVersionedPackage(name PackageName, version string) *PHPVersionedPackage {
+	return &PHPVersionedPackage{
+		Name:    name,
+		Version: version,
+	}
+}
+
+// ParsePHPVersionedPackage parses a string in a '<vendor>/<package>(@version>)?' format
+// into a PHPVersionedPackage. Composer package names are case-insensitive, so the name
+// is lowercased.
+func ParsePHPVersionedPackage(dependency string) (*PHPVersionedPackage, error) {
+	var dep PHPVersionedPackage
+	if i := strings.LastIndex(dependency, "@"); i == -1 {
+		dep.Name = PackageName(strings.ToLower(strings.TrimSpace(dependency)))
+	} else {
+		dep.Name = PackageName(strings.ToLower(strings.TrimSpace(dependency[:i])))
+		dep.Version = strings.TrimSpace(dependency[i+1:])
+	}
+
+	vendor, pkg, ok := strings.Cut(string(dep.Name), "/")
+	if !ok || vendor == "" || pkg == "" || strings.Contains(pkg, "/") {
+		return nil, errors.Newf("invalid PHP package name %q, expected '<vendor>/<package>'", dep.Name)
+	}
+	return &dep, nil
+}
+
+func ParsePHPPackageFromName(name PackageName) (*PHPVersionedPackage, error) {
+	return ParsePHPVersionedPackage(string(name))
+}
+
+// ParsePHPPackageFromRepoName is a convenience function to parse a repo name in a
+// 'packagist/<vendor>/<package>(@<version>)?' format into a PHPVersionedPackage.
+func ParsePHPPackageFromRepoName(name api.RepoName) (*PHPVersionedPackage, error) {
+	dependency := strings.TrimPrefix(string(name), phpPackagesPrefix)
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid PHP dependency repo name, missing %s prefix '%s'", phpPackagesPrefix, name)
+	}
+	return ParsePHPVersionedPackage(dependency)
+}
+
+func (p *PHPVersionedPackage) Scheme() string {
+	return "scip-php"
+}
+
+func (p *PHPVersionedPackage) PackageSyntax() PackageName {
+	return p.Name
+}
+
+func (p *PHPVersionedPackage) VersionedPackageSyntax() string {
+	if p.Version == "" {
+		return string(p.Name)
+	}
+	return string(p.Name) + "@" + p.Version
+}
+
+func (p *PHPVersionedPackage) PackageVersion() string {
+	return p.Version
+}
+
+func (p *PHPVersionedPackage) Description() string { return "" }
+
+func (p *PHPVersionedPackage) RepoName() api.RepoName {
+	return api.RepoName(phpPackagesPrefix + p.Name)
+}
+
+func (p *PHPVersionedPackage) GitTagFromVersion() string {
+	version := strings.TrimPrefix(p.Version, "v")
+	return "v" + version
+}
+
+func (p *PHPVersionedPackage) Less(other VersionedPackage) bool {
+	o := other.(*PHPVersionedPackage)
+
+	if p.Name == o.Name {
+		return versionGreaterThan(strings.TrimPrefix(p.Version, "v"), strings.TrimPrefix(o.Version, "v"))
+	}
+
+	return p.Name > o.Name
+}
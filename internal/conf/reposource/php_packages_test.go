@@ -0,0 +1,67 @@
+package reposource
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParsePHPVersionedPackage(t *testing.T) {
+	table := []struct {
+		dependency string
+		want       *PHPVersionedPackage
+	}{
+		{"monolog/monolog@3.5.0", &PHPVersionedPackage{Name: "monolog/monolog", Version: "3.5.0"}},
+		{"Symfony/Console@v6.4.1", &PHPVersionedPackage{Name: "symfony/console", Version: "v6.4.1"}},
+		{"laravel/framework", &PHPVersionedPackage{Name: "laravel/framework"}},
+		{"monolog@3.5.0", nil},
+		{"/monolog@3.5.0", nil},
+		{"a/b/c@1.0.0", nil},
+	}
+	for _, entry := range table {
+		t.Run(entry.dependency, func(t *testing.T) {
+			dep, err := ParsePHPVersionedPackage(entry.dependency)
+			if entry.want == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, entry.want, dep)
+		})
+	}
+}
+
+func TestParsePHPPackageFromRepoName(t *testing.T) {
+	dep, err := ParsePHPPackageFromRepoName("packagist/monolog/monolog")
+	require.NoError(t, err)
+	assert.Equal(t, api.RepoName("packagist/monolog/monolog"), dep.RepoName())
+	assert.Equal(t, "scip-php", dep.Scheme())
+
+	_, err = ParsePHPPackageFromRepoName("monolog/monolog")
+	require.Error(t, err)
+}
+
+func TestPHPDependency_Less(t *testing.T) {
+	parse := func(s string) *PHPVersionedPackage {
+		dep, err := ParsePHPVersionedPackage(s)
+		require.NoError(t, err)
+		return dep
+	}
+	deps := []VersionedPackage{
+		parse("a/b@1.2.0"),
+		parse("a/b@v1.11.0"),
+		parse("a/c@1.0.0"),
+		parse("a/b@1.10.0"),
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Less(deps[j]) })
+
+	var got []string
+	for _, dep := range deps {
+		got = append(got, dep.VersionedPackageSyntax())
+	}
+	assert.Equal(t, []string{"a/c@1.0.0", "a/b@v1.11.0", "a/b@1.10.0", "a/b@1.2.0"}, got)
+}
@@ -25,4 +25,7 @@ var (
 	_ VersionedPackage = (*GoVersionedPackage)(nil)
 	_ VersionedPackage = (*PythonVersionedPackage)(nil)
 	_ VersionedPackage = (*RustVersionedPackage)(nil)
+	_ VersionedPackage = (*DotNetVersionedPackage)(nil)
+	_ VersionedPackage = (*PHPVersionedPackage)(nil)
+	_ VersionedPackage = (*ElixirVersionedPackage)(nil)
 )
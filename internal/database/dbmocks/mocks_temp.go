@@ -52334,6 +52334,9 @@ type MockPermsStore struct {
 	// RepoIDsWithNoPermsFunc is an instance of a mock function object
 	// controlling the behavior of the method RepoIDsWithNoPerms.
 	RepoIDsWithNoPermsFunc *PermsStoreRepoIDsWithNoPermsFunc
+	// ReposBreachingPermsSLOFunc is an instance of a mock function object
+	// controlling the behavior of the method ReposBreachingPermsSLO.
+	ReposBreachingPermsSLOFunc *PermsStoreReposBreachingPermsSLOFunc
 	// ReposIDsWithOldestPermsFunc is an instance of a mock function object
 	// controlling the behavior of the method ReposIDsWithOldestPerms.
 	ReposIDsWithOldestPermsFunc *PermsStoreReposIDsWithOldestPermsFunc
@@ -52361,6 +52364,12 @@ type MockPermsStore struct {
 	// UserIDsWithOldestPermsFunc is an instance of a mock function object
 	// controlling the behavior of the method UserIDsWithOldestPerms.
 	UserIDsWithOldestPermsFunc *PermsStoreUserIDsWithOldestPermsFunc
+	// UserPermsFreshnessFunc is an instance of a mock function object
+	// controlling the behavior of the method UserPermsFreshness.
+	UserPermsFreshnessFunc *PermsStoreUserPermsFreshnessFunc
+	// UsersBreachingPermsSLOFunc is an instance of a mock function object
+	// controlling the behavior of the method UsersBreachingPermsSLO.
+	UsersBreachingPermsSLOFunc *PermsStoreUsersBreachingPermsSLOFunc
 	// WithFunc is an instance of a mock function object controlling the
 	// behavior of the method With.
 	WithFunc *PermsStoreWithFunc
@@ -52475,6 +52484,11 @@ func NewMockPermsStore() *MockPermsStore {
 				return
 			},
 		},
+		ReposBreachingPermsSLOFunc: &PermsStoreReposBreachingPermsSLOFunc{
+			defaultHook: func(context.Context, time.Duration, int) (r0 []*database.PermsFreshness, r1 int, r2 error) {
+				return
+			},
+		},
 		ReposIDsWithOldestPermsFunc: &PermsStoreReposIDsWithOldestPermsFunc{
 			defaultHook: func(context.Context, int, time.Duration) (r0 map[api.RepoID]time.Time, r1 error) {
 				return
@@ -52515,6 +52529,16 @@ func NewMockPermsStore() *MockPermsStore {
 				return
 			},
 		},
+		UserPermsFreshnessFunc: &PermsStoreUserPermsFreshnessFunc{
+			defaultHook: func(context.Context, int32) (r0 *database.PermsFreshness, r1 error) {
+				return
+			},
+		},
+		UsersBreachingPermsSLOFunc: &PermsStoreUsersBreachingPermsSLOFunc{
+			defaultHook: func(context.Context, time.Duration, int) (r0 []*database.PermsFreshness, r1 int, r2 error) {
+				return
+			},
+		},
 		WithFunc: &PermsStoreWithFunc{
 			defaultHook: func(basestore.ShareableStore) (r0 database.PermsStore) {
 				return
@@ -52632,6 +52656,11 @@ func NewStrictMockPermsStore() *MockPermsStore {
 				panic("unexpected invocation of MockPermsStore.RepoIDsWithNoPerms")
 			},
 		},
+		ReposBreachingPermsSLOFunc: &PermsStoreReposBreachingPermsSLOFunc{
+			defaultHook: func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error) {
+				panic("unexpected invocation of MockPermsStore.ReposBreachingPermsSLO")
+			},
+		},
 		ReposIDsWithOldestPermsFunc: &PermsStoreReposIDsWithOldestPermsFunc{
 			defaultHook: func(context.Context, int, time.Duration) (map[api.RepoID]time.Time, error) {
 				panic("unexpected invocation of MockPermsStore.ReposIDsWithOldestPerms")
@@ -52672,6 +52701,16 @@ func NewStrictMockPermsStore() *MockPermsStore {
 				panic("unexpected invocation of MockPermsStore.UserIDsWithOldestPerms")
 			},
 		},
+		UserPermsFreshnessFunc: &PermsStoreUserPermsFreshnessFunc{
+			defaultHook: func(context.Context, int32) (*database.PermsFreshness, error) {
+				panic("unexpected invocation of MockPermsStore.UserPermsFreshness")
+			},
+		},
+		UsersBreachingPermsSLOFunc: &PermsStoreUsersBreachingPermsSLOFunc{
+			defaultHook: func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error) {
+				panic("unexpected invocation of MockPermsStore.UsersBreachingPermsSLO")
+			},
+		},
 		WithFunc: &PermsStoreWithFunc{
 			defaultHook: func(basestore.ShareableStore) database.PermsStore {
 				panic("unexpected invocation of MockPermsStore.With")
@@ -52747,6 +52786,9 @@ func NewMockPermsStoreFrom(i database.PermsStore) *MockPermsStore {
 		RepoIDsWithNoPermsFunc: &PermsStoreRepoIDsWithNoPermsFunc{
 			defaultHook: i.RepoIDsWithNoPerms,
 		},
+		ReposBreachingPermsSLOFunc: &PermsStoreReposBreachingPermsSLOFunc{
+			defaultHook: i.ReposBreachingPermsSLO,
+		},
 		ReposIDsWithOldestPermsFunc: &PermsStoreReposIDsWithOldestPermsFunc{
 			defaultHook: i.ReposIDsWithOldestPerms,
 		},
@@ -52771,6 +52813,12 @@ func NewMockPermsStoreFrom(i database.PermsStore) *MockPermsStore {
 		UserIDsWithOldestPermsFunc: &PermsStoreUserIDsWithOldestPermsFunc{
 			defaultHook: i.UserIDsWithOldestPerms,
 		},
+		UserPermsFreshnessFunc: &PermsStoreUserPermsFreshnessFunc{
+			defaultHook: i.UserPermsFreshness,
+		},
+		UsersBreachingPermsSLOFunc: &PermsStoreUsersBreachingPermsSLOFunc{
+			defaultHook: i.UsersBreachingPermsSLO,
+		},
 		WithFunc: &PermsStoreWithFunc{
 			defaultHook: i.With,
 		},
@@ -55063,6 +55111,123 @@ func (c PermsStoreRepoIDsWithNoPermsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// PermsStoreReposBreachingPermsSLOFunc describes the behavior when the
+// ReposBreachingPermsSLO method of the parent MockPermsStore instance is
+// invoked.
+type PermsStoreReposBreachingPermsSLOFunc struct {
+	defaultHook func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error)
+	hooks       []func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error)
+	history     []PermsStoreReposBreachingPermsSLOFuncCall
+	mutex       sync.Mutex
+}
+
+// ReposBreachingPermsSLO delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockPermsStore) ReposBreachingPermsSLO(v0 context.Context, v1 time.Duration, v2 int) ([]*database.PermsFreshness, int, error) {
+	r0, r1, r2 := m.ReposBreachingPermsSLOFunc.nextHook()(v0, v1, v2)
+	m.ReposBreachingPermsSLOFunc.appendCall(PermsStoreReposBreachingPermsSLOFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// ReposBreachingPermsSLO method of the parent MockPermsStore instance is
+// invoked and the hook queue is empty.
+func (f *PermsStoreReposBreachingPermsSLOFunc) SetDefaultHook(hook func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReposBreachingPermsSLO method of the parent MockPermsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *PermsStoreReposBreachingPermsSLOFunc) PushHook(hook func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PermsStoreReposBreachingPermsSLOFunc) SetDefaultReturn(r0 []*database.PermsFreshness, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PermsStoreReposBreachingPermsSLOFunc) PushReturn(r0 []*database.PermsFreshness, r1 int, r2 error) {
+	f.PushHook(func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *PermsStoreReposBreachingPermsSLOFunc) nextHook() func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PermsStoreReposBreachingPermsSLOFunc) appendCall(r0 PermsStoreReposBreachingPermsSLOFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of PermsStoreReposBreachingPermsSLOFuncCall
+// objects describing the invocations of this function.
+func (f *PermsStoreReposBreachingPermsSLOFunc) History() []PermsStoreReposBreachingPermsSLOFuncCall {
+	f.mutex.Lock()
+	history := make([]PermsStoreReposBreachingPermsSLOFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PermsStoreReposBreachingPermsSLOFuncCall is an object that describes an
+// invocation of method ReposBreachingPermsSLO on an instance of
+// MockPermsStore.
+type PermsStoreReposBreachingPermsSLOFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Duration
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*database.PermsFreshness
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PermsStoreReposBreachingPermsSLOFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PermsStoreReposBreachingPermsSLOFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // PermsStoreReposIDsWithOldestPermsFunc describes the behavior when the
 // ReposIDsWithOldestPerms method of the parent MockPermsStore instance is
 // invoked.
@@ -55957,34 +56122,36 @@ func (c PermsStoreUserIDsWithOldestPermsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// PermsStoreWithFunc describes the behavior when the With method of the
-// parent MockPermsStore instance is invoked.
-type PermsStoreWithFunc struct {
-	defaultHook func(basestore.ShareableStore) database.PermsStore
-	hooks       []func(basestore.ShareableStore) database.PermsStore
-	history     []PermsStoreWithFuncCall
+// PermsStoreUserPermsFreshnessFunc describes the behavior when the
+// UserPermsFreshness method of the parent MockPermsStore instance is
+// invoked.
+type PermsStoreUserPermsFreshnessFunc struct {
+	defaultHook func(context.Context, int32) (*database.PermsFreshness, error)
+	hooks       []func(context.Context, int32) (*database.PermsFreshness, error)
+	history     []PermsStoreUserPermsFreshnessFuncCall
 	mutex       sync.Mutex
 }
 
-// With delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockPermsStore) With(v0 basestore.ShareableStore) database.PermsStore {
-	r0 := m.WithFunc.nextHook()(v0)
-	m.WithFunc.appendCall(PermsStoreWithFuncCall{v0, r0})
-	return r0
+// UserPermsFreshness delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPermsStore) UserPermsFreshness(v0 context.Context, v1 int32) (*database.PermsFreshness, error) {
+	r0, r1 := m.UserPermsFreshnessFunc.nextHook()(v0, v1)
+	m.UserPermsFreshnessFunc.appendCall(PermsStoreUserPermsFreshnessFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the With method of the
-// parent MockPermsStore instance is invoked and the hook queue is empty.
-func (f *PermsStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) database.PermsStore) {
+// SetDefaultHook sets function that is called when the UserPermsFreshness
+// method of the parent MockPermsStore instance is invoked and the hook
+// queue is empty.
+func (f *PermsStoreUserPermsFreshnessFunc) SetDefaultHook(hook func(context.Context, int32) (*database.PermsFreshness, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// With method of the parent MockPermsStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *PermsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) database.PermsStore) {
+// UserPermsFreshness method of the parent MockPermsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *PermsStoreUserPermsFreshnessFunc) PushHook(hook func(context.Context, int32) (*database.PermsFreshness, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -55992,20 +56159,20 @@ func (f *PermsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) databa
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *PermsStoreWithFunc) SetDefaultReturn(r0 database.PermsStore) {
-	f.SetDefaultHook(func(basestore.ShareableStore) database.PermsStore {
-		return r0
+func (f *PermsStoreUserPermsFreshnessFunc) SetDefaultReturn(r0 *database.PermsFreshness, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*database.PermsFreshness, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *PermsStoreWithFunc) PushReturn(r0 database.PermsStore) {
-	f.PushHook(func(basestore.ShareableStore) database.PermsStore {
-		return r0
+func (f *PermsStoreUserPermsFreshnessFunc) PushReturn(r0 *database.PermsFreshness, r1 error) {
+	f.PushHook(func(context.Context, int32) (*database.PermsFreshness, error) {
+		return r0, r1
 	})
 }
 
-func (f *PermsStoreWithFunc) nextHook() func(basestore.ShareableStore) database.PermsStore {
+func (f *PermsStoreUserPermsFreshnessFunc) nextHook() func(context.Context, int32) (*database.PermsFreshness, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -56018,217 +56185,83 @@ func (f *PermsStoreWithFunc) nextHook() func(basestore.ShareableStore) database.
 	return hook
 }
 
-func (f *PermsStoreWithFunc) appendCall(r0 PermsStoreWithFuncCall) {
+func (f *PermsStoreUserPermsFreshnessFunc) appendCall(r0 PermsStoreUserPermsFreshnessFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of PermsStoreWithFuncCall objects describing
-// the invocations of this function.
-func (f *PermsStoreWithFunc) History() []PermsStoreWithFuncCall {
+// History returns a sequence of PermsStoreUserPermsFreshnessFuncCall
+// objects describing the invocations of this function.
+func (f *PermsStoreUserPermsFreshnessFunc) History() []PermsStoreUserPermsFreshnessFuncCall {
 	f.mutex.Lock()
-	history := make([]PermsStoreWithFuncCall, len(f.history))
+	history := make([]PermsStoreUserPermsFreshnessFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// PermsStoreWithFuncCall is an object that describes an invocation of
-// method With on an instance of MockPermsStore.
-type PermsStoreWithFuncCall struct {
+// PermsStoreUserPermsFreshnessFuncCall is an object that describes an
+// invocation of method UserPermsFreshness on an instance of MockPermsStore.
+type PermsStoreUserPermsFreshnessFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 basestore.ShareableStore
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 database.PermsStore
+	Result0 *database.PermsFreshness
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c PermsStoreWithFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c PermsStoreUserPermsFreshnessFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c PermsStoreWithFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c PermsStoreUserPermsFreshnessFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockPhabricatorStore is a mock implementation of the PhabricatorStore
-// interface (from the package
-// github.com/sourcegraph/sourcegraph/internal/database) used for unit
-// testing.
-type MockPhabricatorStore struct {
-	// CreateFunc is an instance of a mock function object controlling the
-	// behavior of the method Create.
-	CreateFunc *PhabricatorStoreCreateFunc
-	// CreateIfNotExistsFunc is an instance of a mock function object
-	// controlling the behavior of the method CreateIfNotExists.
-	CreateIfNotExistsFunc *PhabricatorStoreCreateIfNotExistsFunc
-	// CreateOrUpdateFunc is an instance of a mock function object
-	// controlling the behavior of the method CreateOrUpdate.
-	CreateOrUpdateFunc *PhabricatorStoreCreateOrUpdateFunc
-	// GetByNameFunc is an instance of a mock function object controlling
-	// the behavior of the method GetByName.
-	GetByNameFunc *PhabricatorStoreGetByNameFunc
-	// HandleFunc is an instance of a mock function object controlling the
-	// behavior of the method Handle.
-	HandleFunc *PhabricatorStoreHandleFunc
-	// WithFunc is an instance of a mock function object controlling the
-	// behavior of the method With.
-	WithFunc *PhabricatorStoreWithFunc
-	// WithTransactFunc is an instance of a mock function object controlling
-	// the behavior of the method WithTransact.
-	WithTransactFunc *PhabricatorStoreWithTransactFunc
+// PermsStoreUsersBreachingPermsSLOFunc describes the behavior when the
+// UsersBreachingPermsSLO method of the parent MockPermsStore instance is
+// invoked.
+type PermsStoreUsersBreachingPermsSLOFunc struct {
+	defaultHook func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error)
+	hooks       []func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error)
+	history     []PermsStoreUsersBreachingPermsSLOFuncCall
+	mutex       sync.Mutex
 }
 
-// NewMockPhabricatorStore creates a new mock of the PhabricatorStore
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockPhabricatorStore() *MockPhabricatorStore {
-	return &MockPhabricatorStore{
-		CreateFunc: &PhabricatorStoreCreateFunc{
-			defaultHook: func(context.Context, string, api.RepoName, string) (r0 *types.PhabricatorRepo, r1 error) {
-				return
-			},
-		},
-		CreateIfNotExistsFunc: &PhabricatorStoreCreateIfNotExistsFunc{
-			defaultHook: func(context.Context, string, api.RepoName, string) (r0 *types.PhabricatorRepo, r1 error) {
-				return
-			},
-		},
-		CreateOrUpdateFunc: &PhabricatorStoreCreateOrUpdateFunc{
-			defaultHook: func(context.Context, string, api.RepoName, string) (r0 *types.PhabricatorRepo, r1 error) {
-				return
-			},
-		},
-		GetByNameFunc: &PhabricatorStoreGetByNameFunc{
-			defaultHook: func(context.Context, api.RepoName) (r0 *types.PhabricatorRepo, r1 error) {
-				return
-			},
-		},
-		HandleFunc: &PhabricatorStoreHandleFunc{
-			defaultHook: func() (r0 basestore.TransactableHandle) {
-				return
-			},
-		},
-		WithFunc: &PhabricatorStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) (r0 database.PhabricatorStore) {
-				return
-			},
-		},
-		WithTransactFunc: &PhabricatorStoreWithTransactFunc{
-			defaultHook: func(context.Context, func(database.PhabricatorStore) error) (r0 error) {
-				return
-			},
-		},
-	}
+// UsersBreachingPermsSLO delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockPermsStore) UsersBreachingPermsSLO(v0 context.Context, v1 time.Duration, v2 int) ([]*database.PermsFreshness, int, error) {
+	r0, r1, r2 := m.UsersBreachingPermsSLOFunc.nextHook()(v0, v1, v2)
+	m.UsersBreachingPermsSLOFunc.appendCall(PermsStoreUsersBreachingPermsSLOFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// NewStrictMockPhabricatorStore creates a new mock of the PhabricatorStore
-// interface. All methods panic on invocation, unless overwritten.
-func NewStrictMockPhabricatorStore() *MockPhabricatorStore {
-	return &MockPhabricatorStore{
-		CreateFunc: &PhabricatorStoreCreateFunc{
-			defaultHook: func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
-				panic("unexpected invocation of MockPhabricatorStore.Create")
-			},
-		},
-		CreateIfNotExistsFunc: &PhabricatorStoreCreateIfNotExistsFunc{
-			defaultHook: func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
-				panic("unexpected invocation of MockPhabricatorStore.CreateIfNotExists")
-			},
-		},
-		CreateOrUpdateFunc: &PhabricatorStoreCreateOrUpdateFunc{
-			defaultHook: func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
-				panic("unexpected invocation of MockPhabricatorStore.CreateOrUpdate")
-			},
-		},
-		GetByNameFunc: &PhabricatorStoreGetByNameFunc{
-			defaultHook: func(context.Context, api.RepoName) (*types.PhabricatorRepo, error) {
-				panic("unexpected invocation of MockPhabricatorStore.GetByName")
-			},
-		},
-		HandleFunc: &PhabricatorStoreHandleFunc{
-			defaultHook: func() basestore.TransactableHandle {
-				panic("unexpected invocation of MockPhabricatorStore.Handle")
-			},
-		},
-		WithFunc: &PhabricatorStoreWithFunc{
-			defaultHook: func(basestore.ShareableStore) database.PhabricatorStore {
-				panic("unexpected invocation of MockPhabricatorStore.With")
-			},
-		},
-		WithTransactFunc: &PhabricatorStoreWithTransactFunc{
-			defaultHook: func(context.Context, func(database.PhabricatorStore) error) error {
-				panic("unexpected invocation of MockPhabricatorStore.WithTransact")
-			},
-		},
-	}
-}
-
-// NewMockPhabricatorStoreFrom creates a new mock of the
-// MockPhabricatorStore interface. All methods delegate to the given
-// implementation, unless overwritten.
-func NewMockPhabricatorStoreFrom(i database.PhabricatorStore) *MockPhabricatorStore {
-	return &MockPhabricatorStore{
-		CreateFunc: &PhabricatorStoreCreateFunc{
-			defaultHook: i.Create,
-		},
-		CreateIfNotExistsFunc: &PhabricatorStoreCreateIfNotExistsFunc{
-			defaultHook: i.CreateIfNotExists,
-		},
-		CreateOrUpdateFunc: &PhabricatorStoreCreateOrUpdateFunc{
-			defaultHook: i.CreateOrUpdate,
-		},
-		GetByNameFunc: &PhabricatorStoreGetByNameFunc{
-			defaultHook: i.GetByName,
-		},
-		HandleFunc: &PhabricatorStoreHandleFunc{
-			defaultHook: i.Handle,
-		},
-		WithFunc: &PhabricatorStoreWithFunc{
-			defaultHook: i.With,
-		},
-		WithTransactFunc: &PhabricatorStoreWithTransactFunc{
-			defaultHook: i.WithTransact,
-		},
-	}
-}
-
-// PhabricatorStoreCreateFunc describes the behavior when the Create method
-// of the parent MockPhabricatorStore instance is invoked.
-type PhabricatorStoreCreateFunc struct {
-	defaultHook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)
-	hooks       []func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)
-	history     []PhabricatorStoreCreateFuncCall
-	mutex       sync.Mutex
-}
-
-// Create delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockPhabricatorStore) Create(v0 context.Context, v1 string, v2 api.RepoName, v3 string) (*types.PhabricatorRepo, error) {
-	r0, r1 := m.CreateFunc.nextHook()(v0, v1, v2, v3)
-	m.CreateFunc.appendCall(PhabricatorStoreCreateFuncCall{v0, v1, v2, v3, r0, r1})
-	return r0, r1
-}
-
-// SetDefaultHook sets function that is called when the Create method of the
-// parent MockPhabricatorStore instance is invoked and the hook queue is
-// empty.
-func (f *PhabricatorStoreCreateFunc) SetDefaultHook(hook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)) {
+// SetDefaultHook sets function that is called when the
+// UsersBreachingPermsSLO method of the parent MockPermsStore instance is
+// invoked and the hook queue is empty.
+func (f *PermsStoreUsersBreachingPermsSLOFunc) SetDefaultHook(hook func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Create method of the parent MockPhabricatorStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *PhabricatorStoreCreateFunc) PushHook(hook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)) {
+// UsersBreachingPermsSLO method of the parent MockPermsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *PermsStoreUsersBreachingPermsSLOFunc) PushHook(hook func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -56236,20 +56269,20 @@ func (f *PhabricatorStoreCreateFunc) PushHook(hook func(context.Context, string,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *PhabricatorStoreCreateFunc) SetDefaultReturn(r0 *types.PhabricatorRepo, r1 error) {
-	f.SetDefaultHook(func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
-		return r0, r1
+func (f *PermsStoreUsersBreachingPermsSLOFunc) SetDefaultReturn(r0 []*database.PermsFreshness, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *PhabricatorStoreCreateFunc) PushReturn(r0 *types.PhabricatorRepo, r1 error) {
-	f.PushHook(func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
-		return r0, r1
+func (f *PermsStoreUsersBreachingPermsSLOFunc) PushReturn(r0 []*database.PermsFreshness, r1 int, r2 error) {
+	f.PushHook(func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *PhabricatorStoreCreateFunc) nextHook() func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
+func (f *PermsStoreUsersBreachingPermsSLOFunc) nextHook() func(context.Context, time.Duration, int) ([]*database.PermsFreshness, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -56262,89 +56295,87 @@ func (f *PhabricatorStoreCreateFunc) nextHook() func(context.Context, string, ap
 	return hook
 }
 
-func (f *PhabricatorStoreCreateFunc) appendCall(r0 PhabricatorStoreCreateFuncCall) {
+func (f *PermsStoreUsersBreachingPermsSLOFunc) appendCall(r0 PermsStoreUsersBreachingPermsSLOFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of PhabricatorStoreCreateFuncCall objects
-// describing the invocations of this function.
-func (f *PhabricatorStoreCreateFunc) History() []PhabricatorStoreCreateFuncCall {
+// History returns a sequence of PermsStoreUsersBreachingPermsSLOFuncCall
+// objects describing the invocations of this function.
+func (f *PermsStoreUsersBreachingPermsSLOFunc) History() []PermsStoreUsersBreachingPermsSLOFuncCall {
 	f.mutex.Lock()
-	history := make([]PhabricatorStoreCreateFuncCall, len(f.history))
+	history := make([]PermsStoreUsersBreachingPermsSLOFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// PhabricatorStoreCreateFuncCall is an object that describes an invocation
-// of method Create on an instance of MockPhabricatorStore.
-type PhabricatorStoreCreateFuncCall struct {
+// PermsStoreUsersBreachingPermsSLOFuncCall is an object that describes an
+// invocation of method UsersBreachingPermsSLO on an instance of
+// MockPermsStore.
+type PermsStoreUsersBreachingPermsSLOFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 string
+	Arg1 time.Duration
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 api.RepoName
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 string
+	Arg2 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.PhabricatorRepo
+	Result0 []*database.PermsFreshness
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c PhabricatorStoreCreateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c PermsStoreUsersBreachingPermsSLOFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c PhabricatorStoreCreateFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c PermsStoreUsersBreachingPermsSLOFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// PhabricatorStoreCreateIfNotExistsFunc describes the behavior when the
-// CreateIfNotExists method of the parent MockPhabricatorStore instance is
-// invoked.
-type PhabricatorStoreCreateIfNotExistsFunc struct {
-	defaultHook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)
-	hooks       []func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)
-	history     []PhabricatorStoreCreateIfNotExistsFuncCall
+// PermsStoreWithFunc describes the behavior when the With method of the
+// parent MockPermsStore instance is invoked.
+type PermsStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) database.PermsStore
+	hooks       []func(basestore.ShareableStore) database.PermsStore
+	history     []PermsStoreWithFuncCall
 	mutex       sync.Mutex
 }
 
-// CreateIfNotExists delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockPhabricatorStore) CreateIfNotExists(v0 context.Context, v1 string, v2 api.RepoName, v3 string) (*types.PhabricatorRepo, error) {
-	r0, r1 := m.CreateIfNotExistsFunc.nextHook()(v0, v1, v2, v3)
-	m.CreateIfNotExistsFunc.appendCall(PhabricatorStoreCreateIfNotExistsFuncCall{v0, v1, v2, v3, r0, r1})
-	return r0, r1
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockPermsStore) With(v0 basestore.ShareableStore) database.PermsStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(PermsStoreWithFuncCall{v0, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the CreateIfNotExists
-// method of the parent MockPhabricatorStore instance is invoked and the
-// hook queue is empty.
-func (f *PhabricatorStoreCreateIfNotExistsFunc) SetDefaultHook(hook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)) {
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockPermsStore instance is invoked and the hook queue is empty.
+func (f *PermsStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) database.PermsStore) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CreateIfNotExists method of the parent MockPhabricatorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *PhabricatorStoreCreateIfNotExistsFunc) PushHook(hook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)) {
+// With method of the parent MockPermsStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *PermsStoreWithFunc) PushHook(hook func(basestore.ShareableStore) database.PermsStore) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -56352,20 +56383,20 @@ func (f *PhabricatorStoreCreateIfNotExistsFunc) PushHook(hook func(context.Conte
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *PhabricatorStoreCreateIfNotExistsFunc) SetDefaultReturn(r0 *types.PhabricatorRepo, r1 error) {
-	f.SetDefaultHook(func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
-		return r0, r1
+func (f *PermsStoreWithFunc) SetDefaultReturn(r0 database.PermsStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) database.PermsStore {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *PhabricatorStoreCreateIfNotExistsFunc) PushReturn(r0 *types.PhabricatorRepo, r1 error) {
-	f.PushHook(func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
-		return r0, r1
+func (f *PermsStoreWithFunc) PushReturn(r0 database.PermsStore) {
+	f.PushHook(func(basestore.ShareableStore) database.PermsStore {
+		return r0
 	})
 }
 
-func (f *PhabricatorStoreCreateIfNotExistsFunc) nextHook() func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
+func (f *PermsStoreWithFunc) nextHook() func(basestore.ShareableStore) database.PermsStore {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -56378,89 +56409,217 @@ func (f *PhabricatorStoreCreateIfNotExistsFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *PhabricatorStoreCreateIfNotExistsFunc) appendCall(r0 PhabricatorStoreCreateIfNotExistsFuncCall) {
+func (f *PermsStoreWithFunc) appendCall(r0 PermsStoreWithFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of PhabricatorStoreCreateIfNotExistsFuncCall
-// objects describing the invocations of this function.
-func (f *PhabricatorStoreCreateIfNotExistsFunc) History() []PhabricatorStoreCreateIfNotExistsFuncCall {
+// History returns a sequence of PermsStoreWithFuncCall objects describing
+// the invocations of this function.
+func (f *PermsStoreWithFunc) History() []PermsStoreWithFuncCall {
 	f.mutex.Lock()
-	history := make([]PhabricatorStoreCreateIfNotExistsFuncCall, len(f.history))
+	history := make([]PermsStoreWithFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// PhabricatorStoreCreateIfNotExistsFuncCall is an object that describes an
-// invocation of method CreateIfNotExists on an instance of
-// MockPhabricatorStore.
-type PhabricatorStoreCreateIfNotExistsFuncCall struct {
+// PermsStoreWithFuncCall is an object that describes an invocation of
+// method With on an instance of MockPermsStore.
+type PermsStoreWithFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 string
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 api.RepoName
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 string
+	Arg0 basestore.ShareableStore
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.PhabricatorRepo
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 database.PermsStore
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c PhabricatorStoreCreateIfNotExistsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c PermsStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c PhabricatorStoreCreateIfNotExistsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c PermsStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// PhabricatorStoreCreateOrUpdateFunc describes the behavior when the
-// CreateOrUpdate method of the parent MockPhabricatorStore instance is
-// invoked.
-type PhabricatorStoreCreateOrUpdateFunc struct {
-	defaultHook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)
-	hooks       []func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)
-	history     []PhabricatorStoreCreateOrUpdateFuncCall
-	mutex       sync.Mutex
+// MockPhabricatorStore is a mock implementation of the PhabricatorStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockPhabricatorStore struct {
+	// CreateFunc is an instance of a mock function object controlling the
+	// behavior of the method Create.
+	CreateFunc *PhabricatorStoreCreateFunc
+	// CreateIfNotExistsFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateIfNotExists.
+	CreateIfNotExistsFunc *PhabricatorStoreCreateIfNotExistsFunc
+	// CreateOrUpdateFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateOrUpdate.
+	CreateOrUpdateFunc *PhabricatorStoreCreateOrUpdateFunc
+	// GetByNameFunc is an instance of a mock function object controlling
+	// the behavior of the method GetByName.
+	GetByNameFunc *PhabricatorStoreGetByNameFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *PhabricatorStoreHandleFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *PhabricatorStoreWithFunc
+	// WithTransactFunc is an instance of a mock function object controlling
+	// the behavior of the method WithTransact.
+	WithTransactFunc *PhabricatorStoreWithTransactFunc
 }
 
-// CreateOrUpdate delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockPhabricatorStore) CreateOrUpdate(v0 context.Context, v1 string, v2 api.RepoName, v3 string) (*types.PhabricatorRepo, error) {
-	r0, r1 := m.CreateOrUpdateFunc.nextHook()(v0, v1, v2, v3)
-	m.CreateOrUpdateFunc.appendCall(PhabricatorStoreCreateOrUpdateFuncCall{v0, v1, v2, v3, r0, r1})
-	return r0, r1
-}
+// NewMockPhabricatorStore creates a new mock of the PhabricatorStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockPhabricatorStore() *MockPhabricatorStore {
+	return &MockPhabricatorStore{
+		CreateFunc: &PhabricatorStoreCreateFunc{
+			defaultHook: func(context.Context, string, api.RepoName, string) (r0 *types.PhabricatorRepo, r1 error) {
+				return
+			},
+		},
+		CreateIfNotExistsFunc: &PhabricatorStoreCreateIfNotExistsFunc{
+			defaultHook: func(context.Context, string, api.RepoName, string) (r0 *types.PhabricatorRepo, r1 error) {
+				return
+			},
+		},
+		CreateOrUpdateFunc: &PhabricatorStoreCreateOrUpdateFunc{
+			defaultHook: func(context.Context, string, api.RepoName, string) (r0 *types.PhabricatorRepo, r1 error) {
+				return
+			},
+		},
+		GetByNameFunc: &PhabricatorStoreGetByNameFunc{
+			defaultHook: func(context.Context, api.RepoName) (r0 *types.PhabricatorRepo, r1 error) {
+				return
+			},
+		},
+		HandleFunc: &PhabricatorStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		WithFunc: &PhabricatorStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) (r0 database.PhabricatorStore) {
+				return
+			},
+		},
+		WithTransactFunc: &PhabricatorStoreWithTransactFunc{
+			defaultHook: func(context.Context, func(database.PhabricatorStore) error) (r0 error) {
+				return
+			},
+		},
+	}
+}
 
-// SetDefaultHook sets function that is called when the CreateOrUpdate
-// method of the parent MockPhabricatorStore instance is invoked and the
-// hook queue is empty.
-func (f *PhabricatorStoreCreateOrUpdateFunc) SetDefaultHook(hook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)) {
+// NewStrictMockPhabricatorStore creates a new mock of the PhabricatorStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockPhabricatorStore() *MockPhabricatorStore {
+	return &MockPhabricatorStore{
+		CreateFunc: &PhabricatorStoreCreateFunc{
+			defaultHook: func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
+				panic("unexpected invocation of MockPhabricatorStore.Create")
+			},
+		},
+		CreateIfNotExistsFunc: &PhabricatorStoreCreateIfNotExistsFunc{
+			defaultHook: func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
+				panic("unexpected invocation of MockPhabricatorStore.CreateIfNotExists")
+			},
+		},
+		CreateOrUpdateFunc: &PhabricatorStoreCreateOrUpdateFunc{
+			defaultHook: func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
+				panic("unexpected invocation of MockPhabricatorStore.CreateOrUpdate")
+			},
+		},
+		GetByNameFunc: &PhabricatorStoreGetByNameFunc{
+			defaultHook: func(context.Context, api.RepoName) (*types.PhabricatorRepo, error) {
+				panic("unexpected invocation of MockPhabricatorStore.GetByName")
+			},
+		},
+		HandleFunc: &PhabricatorStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockPhabricatorStore.Handle")
+			},
+		},
+		WithFunc: &PhabricatorStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) database.PhabricatorStore {
+				panic("unexpected invocation of MockPhabricatorStore.With")
+			},
+		},
+		WithTransactFunc: &PhabricatorStoreWithTransactFunc{
+			defaultHook: func(context.Context, func(database.PhabricatorStore) error) error {
+				panic("unexpected invocation of MockPhabricatorStore.WithTransact")
+			},
+		},
+	}
+}
+
+// NewMockPhabricatorStoreFrom creates a new mock of the
+// MockPhabricatorStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockPhabricatorStoreFrom(i database.PhabricatorStore) *MockPhabricatorStore {
+	return &MockPhabricatorStore{
+		CreateFunc: &PhabricatorStoreCreateFunc{
+			defaultHook: i.Create,
+		},
+		CreateIfNotExistsFunc: &PhabricatorStoreCreateIfNotExistsFunc{
+			defaultHook: i.CreateIfNotExists,
+		},
+		CreateOrUpdateFunc: &PhabricatorStoreCreateOrUpdateFunc{
+			defaultHook: i.CreateOrUpdate,
+		},
+		GetByNameFunc: &PhabricatorStoreGetByNameFunc{
+			defaultHook: i.GetByName,
+		},
+		HandleFunc: &PhabricatorStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		WithFunc: &PhabricatorStoreWithFunc{
+			defaultHook: i.With,
+		},
+		WithTransactFunc: &PhabricatorStoreWithTransactFunc{
+			defaultHook: i.WithTransact,
+		},
+	}
+}
+
+// PhabricatorStoreCreateFunc describes the behavior when the Create method
+// of the parent MockPhabricatorStore instance is invoked.
+type PhabricatorStoreCreateFunc struct {
+	defaultHook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)
+	hooks       []func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)
+	history     []PhabricatorStoreCreateFuncCall
+	mutex       sync.Mutex
+}
+
+// Create delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockPhabricatorStore) Create(v0 context.Context, v1 string, v2 api.RepoName, v3 string) (*types.PhabricatorRepo, error) {
+	r0, r1 := m.CreateFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateFunc.appendCall(PhabricatorStoreCreateFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockPhabricatorStore instance is invoked and the hook queue is
+// empty.
+func (f *PhabricatorStoreCreateFunc) SetDefaultHook(hook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CreateOrUpdate method of the parent MockPhabricatorStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *PhabricatorStoreCreateOrUpdateFunc) PushHook(hook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)) {
+// Create method of the parent MockPhabricatorStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *PhabricatorStoreCreateFunc) PushHook(hook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -56468,20 +56627,20 @@ func (f *PhabricatorStoreCreateOrUpdateFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *PhabricatorStoreCreateOrUpdateFunc) SetDefaultReturn(r0 *types.PhabricatorRepo, r1 error) {
+func (f *PhabricatorStoreCreateFunc) SetDefaultReturn(r0 *types.PhabricatorRepo, r1 error) {
 	f.SetDefaultHook(func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *PhabricatorStoreCreateOrUpdateFunc) PushReturn(r0 *types.PhabricatorRepo, r1 error) {
+func (f *PhabricatorStoreCreateFunc) PushReturn(r0 *types.PhabricatorRepo, r1 error) {
 	f.PushHook(func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
 		return r0, r1
 	})
 }
 
-func (f *PhabricatorStoreCreateOrUpdateFunc) nextHook() func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
+func (f *PhabricatorStoreCreateFunc) nextHook() func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -56494,27 +56653,26 @@ func (f *PhabricatorStoreCreateOrUpdateFunc) nextHook() func(context.Context, st
 	return hook
 }
 
-func (f *PhabricatorStoreCreateOrUpdateFunc) appendCall(r0 PhabricatorStoreCreateOrUpdateFuncCall) {
+func (f *PhabricatorStoreCreateFunc) appendCall(r0 PhabricatorStoreCreateFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of PhabricatorStoreCreateOrUpdateFuncCall
-// objects describing the invocations of this function.
-func (f *PhabricatorStoreCreateOrUpdateFunc) History() []PhabricatorStoreCreateOrUpdateFuncCall {
+// History returns a sequence of PhabricatorStoreCreateFuncCall objects
+// describing the invocations of this function.
+func (f *PhabricatorStoreCreateFunc) History() []PhabricatorStoreCreateFuncCall {
 	f.mutex.Lock()
-	history := make([]PhabricatorStoreCreateOrUpdateFuncCall, len(f.history))
+	history := make([]PhabricatorStoreCreateFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// PhabricatorStoreCreateOrUpdateFuncCall is an object that describes an
-// invocation of method CreateOrUpdate on an instance of
-// MockPhabricatorStore.
-type PhabricatorStoreCreateOrUpdateFuncCall struct {
+// PhabricatorStoreCreateFuncCall is an object that describes an invocation
+// of method Create on an instance of MockPhabricatorStore.
+type PhabricatorStoreCreateFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -56537,45 +56695,47 @@ type PhabricatorStoreCreateOrUpdateFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c PhabricatorStoreCreateOrUpdateFuncCall) Args() []interface{} {
+func (c PhabricatorStoreCreateFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c PhabricatorStoreCreateOrUpdateFuncCall) Results() []interface{} {
+func (c PhabricatorStoreCreateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// PhabricatorStoreGetByNameFunc describes the behavior when the GetByName
-// method of the parent MockPhabricatorStore instance is invoked.
-type PhabricatorStoreGetByNameFunc struct {
-	defaultHook func(context.Context, api.RepoName) (*types.PhabricatorRepo, error)
-	hooks       []func(context.Context, api.RepoName) (*types.PhabricatorRepo, error)
-	history     []PhabricatorStoreGetByNameFuncCall
+// PhabricatorStoreCreateIfNotExistsFunc describes the behavior when the
+// CreateIfNotExists method of the parent MockPhabricatorStore instance is
+// invoked.
+type PhabricatorStoreCreateIfNotExistsFunc struct {
+	defaultHook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)
+	hooks       []func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)
+	history     []PhabricatorStoreCreateIfNotExistsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByName delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockPhabricatorStore) GetByName(v0 context.Context, v1 api.RepoName) (*types.PhabricatorRepo, error) {
-	r0, r1 := m.GetByNameFunc.nextHook()(v0, v1)
-	m.GetByNameFunc.appendCall(PhabricatorStoreGetByNameFuncCall{v0, v1, r0, r1})
+// CreateIfNotExists delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPhabricatorStore) CreateIfNotExists(v0 context.Context, v1 string, v2 api.RepoName, v3 string) (*types.PhabricatorRepo, error) {
+	r0, r1 := m.CreateIfNotExistsFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateIfNotExistsFunc.appendCall(PhabricatorStoreCreateIfNotExistsFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByName method of
-// the parent MockPhabricatorStore instance is invoked and the hook queue is
-// empty.
-func (f *PhabricatorStoreGetByNameFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (*types.PhabricatorRepo, error)) {
+// SetDefaultHook sets function that is called when the CreateIfNotExists
+// method of the parent MockPhabricatorStore instance is invoked and the
+// hook queue is empty.
+func (f *PhabricatorStoreCreateIfNotExistsFunc) SetDefaultHook(hook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByName method of the parent MockPhabricatorStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *PhabricatorStoreGetByNameFunc) PushHook(hook func(context.Context, api.RepoName) (*types.PhabricatorRepo, error)) {
+// CreateIfNotExists method of the parent MockPhabricatorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *PhabricatorStoreCreateIfNotExistsFunc) PushHook(hook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -56583,20 +56743,20 @@ func (f *PhabricatorStoreGetByNameFunc) PushHook(hook func(context.Context, api.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *PhabricatorStoreGetByNameFunc) SetDefaultReturn(r0 *types.PhabricatorRepo, r1 error) {
-	f.SetDefaultHook(func(context.Context, api.RepoName) (*types.PhabricatorRepo, error) {
+func (f *PhabricatorStoreCreateIfNotExistsFunc) SetDefaultReturn(r0 *types.PhabricatorRepo, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *PhabricatorStoreGetByNameFunc) PushReturn(r0 *types.PhabricatorRepo, r1 error) {
-	f.PushHook(func(context.Context, api.RepoName) (*types.PhabricatorRepo, error) {
+func (f *PhabricatorStoreCreateIfNotExistsFunc) PushReturn(r0 *types.PhabricatorRepo, r1 error) {
+	f.PushHook(func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
 		return r0, r1
 	})
 }
 
-func (f *PhabricatorStoreGetByNameFunc) nextHook() func(context.Context, api.RepoName) (*types.PhabricatorRepo, error) {
+func (f *PhabricatorStoreCreateIfNotExistsFunc) nextHook() func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -56609,32 +56769,39 @@ func (f *PhabricatorStoreGetByNameFunc) nextHook() func(context.Context, api.Rep
 	return hook
 }
 
-func (f *PhabricatorStoreGetByNameFunc) appendCall(r0 PhabricatorStoreGetByNameFuncCall) {
+func (f *PhabricatorStoreCreateIfNotExistsFunc) appendCall(r0 PhabricatorStoreCreateIfNotExistsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of PhabricatorStoreGetByNameFuncCall objects
-// describing the invocations of this function.
-func (f *PhabricatorStoreGetByNameFunc) History() []PhabricatorStoreGetByNameFuncCall {
+// History returns a sequence of PhabricatorStoreCreateIfNotExistsFuncCall
+// objects describing the invocations of this function.
+func (f *PhabricatorStoreCreateIfNotExistsFunc) History() []PhabricatorStoreCreateIfNotExistsFuncCall {
 	f.mutex.Lock()
-	history := make([]PhabricatorStoreGetByNameFuncCall, len(f.history))
+	history := make([]PhabricatorStoreCreateIfNotExistsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// PhabricatorStoreGetByNameFuncCall is an object that describes an
-// invocation of method GetByName on an instance of MockPhabricatorStore.
-type PhabricatorStoreGetByNameFuncCall struct {
+// PhabricatorStoreCreateIfNotExistsFuncCall is an object that describes an
+// invocation of method CreateIfNotExists on an instance of
+// MockPhabricatorStore.
+type PhabricatorStoreCreateIfNotExistsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 api.RepoName
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *types.PhabricatorRepo
@@ -56645,18 +56812,242 @@ type PhabricatorStoreGetByNameFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c PhabricatorStoreGetByNameFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c PhabricatorStoreCreateIfNotExistsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c PhabricatorStoreGetByNameFuncCall) Results() []interface{} {
+func (c PhabricatorStoreCreateIfNotExistsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// PhabricatorStoreHandleFunc describes the behavior when the Handle method
-// of the parent MockPhabricatorStore instance is invoked.
+// PhabricatorStoreCreateOrUpdateFunc describes the behavior when the
+// CreateOrUpdate method of the parent MockPhabricatorStore instance is
+// invoked.
+type PhabricatorStoreCreateOrUpdateFunc struct {
+	defaultHook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)
+	hooks       []func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)
+	history     []PhabricatorStoreCreateOrUpdateFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateOrUpdate delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPhabricatorStore) CreateOrUpdate(v0 context.Context, v1 string, v2 api.RepoName, v3 string) (*types.PhabricatorRepo, error) {
+	r0, r1 := m.CreateOrUpdateFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateOrUpdateFunc.appendCall(PhabricatorStoreCreateOrUpdateFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateOrUpdate
+// method of the parent MockPhabricatorStore instance is invoked and the
+// hook queue is empty.
+func (f *PhabricatorStoreCreateOrUpdateFunc) SetDefaultHook(hook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateOrUpdate method of the parent MockPhabricatorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *PhabricatorStoreCreateOrUpdateFunc) PushHook(hook func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PhabricatorStoreCreateOrUpdateFunc) SetDefaultReturn(r0 *types.PhabricatorRepo, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PhabricatorStoreCreateOrUpdateFunc) PushReturn(r0 *types.PhabricatorRepo, r1 error) {
+	f.PushHook(func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
+		return r0, r1
+	})
+}
+
+func (f *PhabricatorStoreCreateOrUpdateFunc) nextHook() func(context.Context, string, api.RepoName, string) (*types.PhabricatorRepo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PhabricatorStoreCreateOrUpdateFunc) appendCall(r0 PhabricatorStoreCreateOrUpdateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of PhabricatorStoreCreateOrUpdateFuncCall
+// objects describing the invocations of this function.
+func (f *PhabricatorStoreCreateOrUpdateFunc) History() []PhabricatorStoreCreateOrUpdateFuncCall {
+	f.mutex.Lock()
+	history := make([]PhabricatorStoreCreateOrUpdateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PhabricatorStoreCreateOrUpdateFuncCall is an object that describes an
+// invocation of method CreateOrUpdate on an instance of
+// MockPhabricatorStore.
+type PhabricatorStoreCreateOrUpdateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.PhabricatorRepo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PhabricatorStoreCreateOrUpdateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PhabricatorStoreCreateOrUpdateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// PhabricatorStoreGetByNameFunc describes the behavior when the GetByName
+// method of the parent MockPhabricatorStore instance is invoked.
+type PhabricatorStoreGetByNameFunc struct {
+	defaultHook func(context.Context, api.RepoName) (*types.PhabricatorRepo, error)
+	hooks       []func(context.Context, api.RepoName) (*types.PhabricatorRepo, error)
+	history     []PhabricatorStoreGetByNameFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByName delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockPhabricatorStore) GetByName(v0 context.Context, v1 api.RepoName) (*types.PhabricatorRepo, error) {
+	r0, r1 := m.GetByNameFunc.nextHook()(v0, v1)
+	m.GetByNameFunc.appendCall(PhabricatorStoreGetByNameFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByName method of
+// the parent MockPhabricatorStore instance is invoked and the hook queue is
+// empty.
+func (f *PhabricatorStoreGetByNameFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (*types.PhabricatorRepo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByName method of the parent MockPhabricatorStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *PhabricatorStoreGetByNameFunc) PushHook(hook func(context.Context, api.RepoName) (*types.PhabricatorRepo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PhabricatorStoreGetByNameFunc) SetDefaultReturn(r0 *types.PhabricatorRepo, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName) (*types.PhabricatorRepo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PhabricatorStoreGetByNameFunc) PushReturn(r0 *types.PhabricatorRepo, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName) (*types.PhabricatorRepo, error) {
+		return r0, r1
+	})
+}
+
+func (f *PhabricatorStoreGetByNameFunc) nextHook() func(context.Context, api.RepoName) (*types.PhabricatorRepo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PhabricatorStoreGetByNameFunc) appendCall(r0 PhabricatorStoreGetByNameFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of PhabricatorStoreGetByNameFuncCall objects
+// describing the invocations of this function.
+func (f *PhabricatorStoreGetByNameFunc) History() []PhabricatorStoreGetByNameFuncCall {
+	f.mutex.Lock()
+	history := make([]PhabricatorStoreGetByNameFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PhabricatorStoreGetByNameFuncCall is an object that describes an
+// invocation of method GetByName on an instance of MockPhabricatorStore.
+type PhabricatorStoreGetByNameFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.PhabricatorRepo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PhabricatorStoreGetByNameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PhabricatorStoreGetByNameFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// PhabricatorStoreHandleFunc describes the behavior when the Handle method
+// of the parent MockPhabricatorStore instance is invoked.
 type PhabricatorStoreHandleFunc struct {
 	defaultHook func() basestore.TransactableHandle
 	hooks       []func() basestore.TransactableHandle
@@ -66687,18 +67078,33 @@ type MockSavedSearchStore struct {
 	// CreateFunc is an instance of a mock function object controlling the
 	// behavior of the method Create.
 	CreateFunc *SavedSearchStoreCreateFunc
+	// CreateSnapshotFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateSnapshot.
+	CreateSnapshotFunc *SavedSearchStoreCreateSnapshotFunc
 	// DeleteFunc is an instance of a mock function object controlling the
 	// behavior of the method Delete.
 	DeleteFunc *SavedSearchStoreDeleteFunc
+	// DeleteSnapshotsBeyondFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteSnapshotsBeyond.
+	DeleteSnapshotsBeyondFunc *SavedSearchStoreDeleteSnapshotsBeyondFunc
 	// GetByIDFunc is an instance of a mock function object controlling the
 	// behavior of the method GetByID.
 	GetByIDFunc *SavedSearchStoreGetByIDFunc
+	// GetSnapshotByIDFunc is an instance of a mock function object
+	// controlling the behavior of the method GetSnapshotByID.
+	GetSnapshotByIDFunc *SavedSearchStoreGetSnapshotByIDFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *SavedSearchStoreHandleFunc
 	// ListFunc is an instance of a mock function object controlling the
 	// behavior of the method List.
 	ListFunc *SavedSearchStoreListFunc
+	// ListDueForSnapshotFunc is an instance of a mock function object
+	// controlling the behavior of the method ListDueForSnapshot.
+	ListDueForSnapshotFunc *SavedSearchStoreListDueForSnapshotFunc
+	// ListSnapshotsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListSnapshots.
+	ListSnapshotsFunc *SavedSearchStoreListSnapshotsFunc
 	// MarshalToCursorFunc is an instance of a mock function object
 	// controlling the behavior of the method MarshalToCursor.
 	MarshalToCursorFunc *SavedSearchStoreMarshalToCursorFunc
@@ -66712,6 +67118,9 @@ type MockSavedSearchStore struct {
 	// UpdateOwnerFunc is an instance of a mock function object controlling
 	// the behavior of the method UpdateOwner.
 	UpdateOwnerFunc *SavedSearchStoreUpdateOwnerFunc
+	// UpdateSnapshotIntervalFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateSnapshotInterval.
+	UpdateSnapshotIntervalFunc *SavedSearchStoreUpdateSnapshotIntervalFunc
 	// UpdateVisibilityFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateVisibility.
 	UpdateVisibilityFunc *SavedSearchStoreUpdateVisibilityFunc
@@ -66738,16 +67147,31 @@ func NewMockSavedSearchStore() *MockSavedSearchStore {
 				return
 			},
 		},
+		CreateSnapshotFunc: &SavedSearchStoreCreateSnapshotFunc{
+			defaultHook: func(context.Context, *types.SavedSearchSnapshot) (r0 *types.SavedSearchSnapshot, r1 error) {
+				return
+			},
+		},
 		DeleteFunc: &SavedSearchStoreDeleteFunc{
 			defaultHook: func(context.Context, int32) (r0 error) {
 				return
 			},
 		},
+		DeleteSnapshotsBeyondFunc: &SavedSearchStoreDeleteSnapshotsBeyondFunc{
+			defaultHook: func(context.Context, int32, int) (r0 []string, r1 error) {
+				return
+			},
+		},
 		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
 			defaultHook: func(context.Context, int32) (r0 *types.SavedSearch, r1 error) {
 				return
 			},
 		},
+		GetSnapshotByIDFunc: &SavedSearchStoreGetSnapshotByIDFunc{
+			defaultHook: func(context.Context, int32) (r0 *types.SavedSearchSnapshot, r1 error) {
+				return
+			},
+		},
 		HandleFunc: &SavedSearchStoreHandleFunc{
 			defaultHook: func() (r0 basestore.TransactableHandle) {
 				return
@@ -66758,6 +67182,16 @@ func NewMockSavedSearchStore() *MockSavedSearchStore {
 				return
 			},
 		},
+		ListDueForSnapshotFunc: &SavedSearchStoreListDueForSnapshotFunc{
+			defaultHook: func(context.Context) (r0 []*types.SavedSearch, r1 error) {
+				return
+			},
+		},
+		ListSnapshotsFunc: &SavedSearchStoreListSnapshotsFunc{
+			defaultHook: func(context.Context, int32, int) (r0 []*types.SavedSearchSnapshot, r1 error) {
+				return
+			},
+		},
 		MarshalToCursorFunc: &SavedSearchStoreMarshalToCursorFunc{
 			defaultHook: func(*types.SavedSearch, database.OrderBy) (r0 types.MultiCursor, r1 error) {
 				return
@@ -66778,6 +67212,11 @@ func NewMockSavedSearchStore() *MockSavedSearchStore {
 				return
 			},
 		},
+		UpdateSnapshotIntervalFunc: &SavedSearchStoreUpdateSnapshotIntervalFunc{
+			defaultHook: func(context.Context, int32, *int32) (r0 *types.SavedSearch, r1 error) {
+				return
+			},
+		},
 		UpdateVisibilityFunc: &SavedSearchStoreUpdateVisibilityFunc{
 			defaultHook: func(context.Context, int32, bool) (r0 *types.SavedSearch, r1 error) {
 				return
@@ -66810,16 +67249,31 @@ func NewStrictMockSavedSearchStore() *MockSavedSearchStore {
 				panic("unexpected invocation of MockSavedSearchStore.Create")
 			},
 		},
+		CreateSnapshotFunc: &SavedSearchStoreCreateSnapshotFunc{
+			defaultHook: func(context.Context, *types.SavedSearchSnapshot) (*types.SavedSearchSnapshot, error) {
+				panic("unexpected invocation of MockSavedSearchStore.CreateSnapshot")
+			},
+		},
 		DeleteFunc: &SavedSearchStoreDeleteFunc{
 			defaultHook: func(context.Context, int32) error {
 				panic("unexpected invocation of MockSavedSearchStore.Delete")
 			},
 		},
+		DeleteSnapshotsBeyondFunc: &SavedSearchStoreDeleteSnapshotsBeyondFunc{
+			defaultHook: func(context.Context, int32, int) ([]string, error) {
+				panic("unexpected invocation of MockSavedSearchStore.DeleteSnapshotsBeyond")
+			},
+		},
 		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
 			defaultHook: func(context.Context, int32) (*types.SavedSearch, error) {
 				panic("unexpected invocation of MockSavedSearchStore.GetByID")
 			},
 		},
+		GetSnapshotByIDFunc: &SavedSearchStoreGetSnapshotByIDFunc{
+			defaultHook: func(context.Context, int32) (*types.SavedSearchSnapshot, error) {
+				panic("unexpected invocation of MockSavedSearchStore.GetSnapshotByID")
+			},
+		},
 		HandleFunc: &SavedSearchStoreHandleFunc{
 			defaultHook: func() basestore.TransactableHandle {
 				panic("unexpected invocation of MockSavedSearchStore.Handle")
@@ -66830,6 +67284,16 @@ func NewStrictMockSavedSearchStore() *MockSavedSearchStore {
 				panic("unexpected invocation of MockSavedSearchStore.List")
 			},
 		},
+		ListDueForSnapshotFunc: &SavedSearchStoreListDueForSnapshotFunc{
+			defaultHook: func(context.Context) ([]*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListDueForSnapshot")
+			},
+		},
+		ListSnapshotsFunc: &SavedSearchStoreListSnapshotsFunc{
+			defaultHook: func(context.Context, int32, int) ([]*types.SavedSearchSnapshot, error) {
+				panic("unexpected invocation of MockSavedSearchStore.ListSnapshots")
+			},
+		},
 		MarshalToCursorFunc: &SavedSearchStoreMarshalToCursorFunc{
 			defaultHook: func(*types.SavedSearch, database.OrderBy) (types.MultiCursor, error) {
 				panic("unexpected invocation of MockSavedSearchStore.MarshalToCursor")
@@ -66850,6 +67314,11 @@ func NewStrictMockSavedSearchStore() *MockSavedSearchStore {
 				panic("unexpected invocation of MockSavedSearchStore.UpdateOwner")
 			},
 		},
+		UpdateSnapshotIntervalFunc: &SavedSearchStoreUpdateSnapshotIntervalFunc{
+			defaultHook: func(context.Context, int32, *int32) (*types.SavedSearch, error) {
+				panic("unexpected invocation of MockSavedSearchStore.UpdateSnapshotInterval")
+			},
+		},
 		UpdateVisibilityFunc: &SavedSearchStoreUpdateVisibilityFunc{
 			defaultHook: func(context.Context, int32, bool) (*types.SavedSearch, error) {
 				panic("unexpected invocation of MockSavedSearchStore.UpdateVisibility")
@@ -66879,18 +67348,33 @@ func NewMockSavedSearchStoreFrom(i database.SavedSearchStore) *MockSavedSearchSt
 		CreateFunc: &SavedSearchStoreCreateFunc{
 			defaultHook: i.Create,
 		},
+		CreateSnapshotFunc: &SavedSearchStoreCreateSnapshotFunc{
+			defaultHook: i.CreateSnapshot,
+		},
 		DeleteFunc: &SavedSearchStoreDeleteFunc{
 			defaultHook: i.Delete,
 		},
+		DeleteSnapshotsBeyondFunc: &SavedSearchStoreDeleteSnapshotsBeyondFunc{
+			defaultHook: i.DeleteSnapshotsBeyond,
+		},
 		GetByIDFunc: &SavedSearchStoreGetByIDFunc{
 			defaultHook: i.GetByID,
 		},
+		GetSnapshotByIDFunc: &SavedSearchStoreGetSnapshotByIDFunc{
+			defaultHook: i.GetSnapshotByID,
+		},
 		HandleFunc: &SavedSearchStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
 		ListFunc: &SavedSearchStoreListFunc{
 			defaultHook: i.List,
 		},
+		ListDueForSnapshotFunc: &SavedSearchStoreListDueForSnapshotFunc{
+			defaultHook: i.ListDueForSnapshot,
+		},
+		ListSnapshotsFunc: &SavedSearchStoreListSnapshotsFunc{
+			defaultHook: i.ListSnapshots,
+		},
 		MarshalToCursorFunc: &SavedSearchStoreMarshalToCursorFunc{
 			defaultHook: i.MarshalToCursor,
 		},
@@ -66903,6 +67387,9 @@ func NewMockSavedSearchStoreFrom(i database.SavedSearchStore) *MockSavedSearchSt
 		UpdateOwnerFunc: &SavedSearchStoreUpdateOwnerFunc{
 			defaultHook: i.UpdateOwner,
 		},
+		UpdateSnapshotIntervalFunc: &SavedSearchStoreUpdateSnapshotIntervalFunc{
+			defaultHook: i.UpdateSnapshotInterval,
+		},
 		UpdateVisibilityFunc: &SavedSearchStoreUpdateVisibilityFunc{
 			defaultHook: i.UpdateVisibility,
 		},
@@ -66958,13 +67445,559 @@ func (f *SavedSearchStoreCountFunc) SetDefaultReturn(r0 int, r1 error) {
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreCountFunc) PushReturn(r0 int, r1 error) {
-	f.PushHook(func(context.Context, database.SavedSearchListArgs) (int, error) {
+func (f *SavedSearchStoreCountFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, database.SavedSearchListArgs) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreCountFunc) nextHook() func(context.Context, database.SavedSearchListArgs) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreCountFunc) appendCall(r0 SavedSearchStoreCountFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreCountFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreCountFunc) History() []SavedSearchStoreCountFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreCountFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreCountFuncCall is an object that describes an invocation
+// of method Count on an instance of MockSavedSearchStore.
+type SavedSearchStoreCountFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 database.SavedSearchListArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreCountFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreCountFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreCreateFunc describes the behavior when the Create method
+// of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreCreateFunc struct {
+	defaultHook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	hooks       []func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	history     []SavedSearchStoreCreateFuncCall
+	mutex       sync.Mutex
+}
+
+// Create delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) Create(v0 context.Context, v1 *types.SavedSearch) (*types.SavedSearch, error) {
+	r0, r1 := m.CreateFunc.nextHook()(v0, v1)
+	m.CreateFunc.appendCall(SavedSearchStoreCreateFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreCreateFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Create method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreCreateFunc) PushHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreCreateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreCreateFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreCreateFunc) nextHook() func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreCreateFunc) appendCall(r0 SavedSearchStoreCreateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreCreateFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreCreateFunc) History() []SavedSearchStoreCreateFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreCreateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreCreateFuncCall is an object that describes an invocation
+// of method Create on an instance of MockSavedSearchStore.
+type SavedSearchStoreCreateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.SavedSearch
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SavedSearch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreCreateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreCreateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreCreateSnapshotFunc describes the behavior when the
+// CreateSnapshot method of the parent MockSavedSearchStore instance is
+// invoked.
+type SavedSearchStoreCreateSnapshotFunc struct {
+	defaultHook func(context.Context, *types.SavedSearchSnapshot) (*types.SavedSearchSnapshot, error)
+	hooks       []func(context.Context, *types.SavedSearchSnapshot) (*types.SavedSearchSnapshot, error)
+	history     []SavedSearchStoreCreateSnapshotFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateSnapshot delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) CreateSnapshot(v0 context.Context, v1 *types.SavedSearchSnapshot) (*types.SavedSearchSnapshot, error) {
+	r0, r1 := m.CreateSnapshotFunc.nextHook()(v0, v1)
+	m.CreateSnapshotFunc.appendCall(SavedSearchStoreCreateSnapshotFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateSnapshot
+// method of the parent MockSavedSearchStore instance is invoked and the
+// hook queue is empty.
+func (f *SavedSearchStoreCreateSnapshotFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearchSnapshot) (*types.SavedSearchSnapshot, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateSnapshot method of the parent MockSavedSearchStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SavedSearchStoreCreateSnapshotFunc) PushHook(hook func(context.Context, *types.SavedSearchSnapshot) (*types.SavedSearchSnapshot, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreCreateSnapshotFunc) SetDefaultReturn(r0 *types.SavedSearchSnapshot, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.SavedSearchSnapshot) (*types.SavedSearchSnapshot, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreCreateSnapshotFunc) PushReturn(r0 *types.SavedSearchSnapshot, r1 error) {
+	f.PushHook(func(context.Context, *types.SavedSearchSnapshot) (*types.SavedSearchSnapshot, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreCreateSnapshotFunc) nextHook() func(context.Context, *types.SavedSearchSnapshot) (*types.SavedSearchSnapshot, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreCreateSnapshotFunc) appendCall(r0 SavedSearchStoreCreateSnapshotFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreCreateSnapshotFuncCall
+// objects describing the invocations of this function.
+func (f *SavedSearchStoreCreateSnapshotFunc) History() []SavedSearchStoreCreateSnapshotFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreCreateSnapshotFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreCreateSnapshotFuncCall is an object that describes an
+// invocation of method CreateSnapshot on an instance of
+// MockSavedSearchStore.
+type SavedSearchStoreCreateSnapshotFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.SavedSearchSnapshot
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SavedSearchSnapshot
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreCreateSnapshotFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreCreateSnapshotFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreDeleteFunc describes the behavior when the Delete method
+// of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreDeleteFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []SavedSearchStoreDeleteFuncCall
+	mutex       sync.Mutex
+}
+
+// Delete delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) Delete(v0 context.Context, v1 int32) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1)
+	m.DeleteFunc.appendCall(SavedSearchStoreDeleteFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int32) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Delete method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreDeleteFunc) PushHook(hook func(context.Context, int32) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
+		return r0
+	})
+}
+
+func (f *SavedSearchStoreDeleteFunc) nextHook() func(context.Context, int32) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreDeleteFunc) appendCall(r0 SavedSearchStoreDeleteFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SavedSearchStoreDeleteFuncCall objects
+// describing the invocations of this function.
+func (f *SavedSearchStoreDeleteFunc) History() []SavedSearchStoreDeleteFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreDeleteFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreDeleteFuncCall is an object that describes an invocation
+// of method Delete on an instance of MockSavedSearchStore.
+type SavedSearchStoreDeleteFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SavedSearchStoreDeleteSnapshotsBeyondFunc describes the behavior when the
+// DeleteSnapshotsBeyond method of the parent MockSavedSearchStore instance
+// is invoked.
+type SavedSearchStoreDeleteSnapshotsBeyondFunc struct {
+	defaultHook func(context.Context, int32, int) ([]string, error)
+	hooks       []func(context.Context, int32, int) ([]string, error)
+	history     []SavedSearchStoreDeleteSnapshotsBeyondFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteSnapshotsBeyond delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) DeleteSnapshotsBeyond(v0 context.Context, v1 int32, v2 int) ([]string, error) {
+	r0, r1 := m.DeleteSnapshotsBeyondFunc.nextHook()(v0, v1, v2)
+	m.DeleteSnapshotsBeyondFunc.appendCall(SavedSearchStoreDeleteSnapshotsBeyondFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteSnapshotsBeyond method of the parent MockSavedSearchStore instance
+// is invoked and the hook queue is empty.
+func (f *SavedSearchStoreDeleteSnapshotsBeyondFunc) SetDefaultHook(hook func(context.Context, int32, int) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteSnapshotsBeyond method of the parent MockSavedSearchStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SavedSearchStoreDeleteSnapshotsBeyondFunc) PushHook(hook func(context.Context, int32, int) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreDeleteSnapshotsBeyondFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, int) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreDeleteSnapshotsBeyondFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int32, int) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreDeleteSnapshotsBeyondFunc) nextHook() func(context.Context, int32, int) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreDeleteSnapshotsBeyondFunc) appendCall(r0 SavedSearchStoreDeleteSnapshotsBeyondFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SavedSearchStoreDeleteSnapshotsBeyondFuncCall objects describing the
+// invocations of this function.
+func (f *SavedSearchStoreDeleteSnapshotsBeyondFunc) History() []SavedSearchStoreDeleteSnapshotsBeyondFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreDeleteSnapshotsBeyondFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreDeleteSnapshotsBeyondFuncCall is an object that describes
+// an invocation of method DeleteSnapshotsBeyond on an instance of
+// MockSavedSearchStore.
+type SavedSearchStoreDeleteSnapshotsBeyondFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreDeleteSnapshotsBeyondFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreDeleteSnapshotsBeyondFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SavedSearchStoreGetByIDFunc describes the behavior when the GetByID
+// method of the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreGetByIDFunc struct {
+	defaultHook func(context.Context, int32) (*types.SavedSearch, error)
+	hooks       []func(context.Context, int32) (*types.SavedSearch, error)
+	history     []SavedSearchStoreGetByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByID delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSavedSearchStore) GetByID(v0 context.Context, v1 int32) (*types.SavedSearch, error) {
+	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
+	m.GetByIDFunc.appendCall(SavedSearchStoreGetByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByID method of
+// the parent MockSavedSearchStore instance is invoked and the hook queue is
+// empty.
+func (f *SavedSearchStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int32) (*types.SavedSearch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByID method of the parent MockSavedSearchStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SavedSearchStoreGetByIDFunc) PushHook(hook func(context.Context, int32) (*types.SavedSearch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreGetByIDFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreGetByIDFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, int32) (*types.SavedSearch, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreCountFunc) nextHook() func(context.Context, database.SavedSearchListArgs) (int, error) {
+func (f *SavedSearchStoreGetByIDFunc) nextHook() func(context.Context, int32) (*types.SavedSearch, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -66977,35 +68010,35 @@ func (f *SavedSearchStoreCountFunc) nextHook() func(context.Context, database.Sa
 	return hook
 }
 
-func (f *SavedSearchStoreCountFunc) appendCall(r0 SavedSearchStoreCountFuncCall) {
+func (f *SavedSearchStoreGetByIDFunc) appendCall(r0 SavedSearchStoreGetByIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreCountFuncCall objects
+// History returns a sequence of SavedSearchStoreGetByIDFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreCountFunc) History() []SavedSearchStoreCountFuncCall {
+func (f *SavedSearchStoreGetByIDFunc) History() []SavedSearchStoreGetByIDFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreCountFuncCall, len(f.history))
+	history := make([]SavedSearchStoreGetByIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreCountFuncCall is an object that describes an invocation
-// of method Count on an instance of MockSavedSearchStore.
-type SavedSearchStoreCountFuncCall struct {
+// SavedSearchStoreGetByIDFuncCall is an object that describes an invocation
+// of method GetByID on an instance of MockSavedSearchStore.
+type SavedSearchStoreGetByIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 database.SavedSearchListArgs
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int
+	Result0 *types.SavedSearch
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -67013,45 +68046,47 @@ type SavedSearchStoreCountFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreCountFuncCall) Args() []interface{} {
+func (c SavedSearchStoreGetByIDFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreCountFuncCall) Results() []interface{} {
+func (c SavedSearchStoreGetByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreCreateFunc describes the behavior when the Create method
-// of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreCreateFunc struct {
-	defaultHook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
-	hooks       []func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
-	history     []SavedSearchStoreCreateFuncCall
+// SavedSearchStoreGetSnapshotByIDFunc describes the behavior when the
+// GetSnapshotByID method of the parent MockSavedSearchStore instance is
+// invoked.
+type SavedSearchStoreGetSnapshotByIDFunc struct {
+	defaultHook func(context.Context, int32) (*types.SavedSearchSnapshot, error)
+	hooks       []func(context.Context, int32) (*types.SavedSearchSnapshot, error)
+	history     []SavedSearchStoreGetSnapshotByIDFuncCall
 	mutex       sync.Mutex
 }
 
-// Create delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Create(v0 context.Context, v1 *types.SavedSearch) (*types.SavedSearch, error) {
-	r0, r1 := m.CreateFunc.nextHook()(v0, v1)
-	m.CreateFunc.appendCall(SavedSearchStoreCreateFuncCall{v0, v1, r0, r1})
+// GetSnapshotByID delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) GetSnapshotByID(v0 context.Context, v1 int32) (*types.SavedSearchSnapshot, error) {
+	r0, r1 := m.GetSnapshotByIDFunc.nextHook()(v0, v1)
+	m.GetSnapshotByIDFunc.appendCall(SavedSearchStoreGetSnapshotByIDFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Create method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreCreateFunc) SetDefaultHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+// SetDefaultHook sets function that is called when the GetSnapshotByID
+// method of the parent MockSavedSearchStore instance is invoked and the
+// hook queue is empty.
+func (f *SavedSearchStoreGetSnapshotByIDFunc) SetDefaultHook(hook func(context.Context, int32) (*types.SavedSearchSnapshot, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Create method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreCreateFunc) PushHook(hook func(context.Context, *types.SavedSearch) (*types.SavedSearch, error)) {
+// GetSnapshotByID method of the parent MockSavedSearchStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SavedSearchStoreGetSnapshotByIDFunc) PushHook(hook func(context.Context, int32) (*types.SavedSearchSnapshot, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -67059,20 +68094,20 @@ func (f *SavedSearchStoreCreateFunc) PushHook(hook func(context.Context, *types.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreCreateFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+func (f *SavedSearchStoreGetSnapshotByIDFunc) SetDefaultReturn(r0 *types.SavedSearchSnapshot, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*types.SavedSearchSnapshot, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreCreateFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+func (f *SavedSearchStoreGetSnapshotByIDFunc) PushReturn(r0 *types.SavedSearchSnapshot, r1 error) {
+	f.PushHook(func(context.Context, int32) (*types.SavedSearchSnapshot, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreCreateFunc) nextHook() func(context.Context, *types.SavedSearch) (*types.SavedSearch, error) {
+func (f *SavedSearchStoreGetSnapshotByIDFunc) nextHook() func(context.Context, int32) (*types.SavedSearchSnapshot, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -67085,35 +68120,36 @@ func (f *SavedSearchStoreCreateFunc) nextHook() func(context.Context, *types.Sav
 	return hook
 }
 
-func (f *SavedSearchStoreCreateFunc) appendCall(r0 SavedSearchStoreCreateFuncCall) {
+func (f *SavedSearchStoreGetSnapshotByIDFunc) appendCall(r0 SavedSearchStoreGetSnapshotByIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreCreateFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreCreateFunc) History() []SavedSearchStoreCreateFuncCall {
+// History returns a sequence of SavedSearchStoreGetSnapshotByIDFuncCall
+// objects describing the invocations of this function.
+func (f *SavedSearchStoreGetSnapshotByIDFunc) History() []SavedSearchStoreGetSnapshotByIDFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreCreateFuncCall, len(f.history))
+	history := make([]SavedSearchStoreGetSnapshotByIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreCreateFuncCall is an object that describes an invocation
-// of method Create on an instance of MockSavedSearchStore.
-type SavedSearchStoreCreateFuncCall struct {
+// SavedSearchStoreGetSnapshotByIDFuncCall is an object that describes an
+// invocation of method GetSnapshotByID on an instance of
+// MockSavedSearchStore.
+type SavedSearchStoreGetSnapshotByIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 *types.SavedSearch
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.SavedSearch
+	Result0 *types.SavedSearchSnapshot
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -67121,45 +68157,45 @@ type SavedSearchStoreCreateFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreCreateFuncCall) Args() []interface{} {
+func (c SavedSearchStoreGetSnapshotByIDFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreCreateFuncCall) Results() []interface{} {
+func (c SavedSearchStoreGetSnapshotByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreDeleteFunc describes the behavior when the Delete method
+// SavedSearchStoreHandleFunc describes the behavior when the Handle method
 // of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreDeleteFunc struct {
-	defaultHook func(context.Context, int32) error
-	hooks       []func(context.Context, int32) error
-	history     []SavedSearchStoreDeleteFuncCall
+type SavedSearchStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []SavedSearchStoreHandleFuncCall
 	mutex       sync.Mutex
 }
 
-// Delete delegates to the next hook function in the queue and stores the
+// Handle delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Delete(v0 context.Context, v1 int32) error {
-	r0 := m.DeleteFunc.nextHook()(v0, v1)
-	m.DeleteFunc.appendCall(SavedSearchStoreDeleteFuncCall{v0, v1, r0})
+func (m *MockSavedSearchStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(SavedSearchStoreHandleFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Delete method of the
+// SetDefaultHook sets function that is called when the Handle method of the
 // parent MockSavedSearchStore instance is invoked and the hook queue is
 // empty.
-func (f *SavedSearchStoreDeleteFunc) SetDefaultHook(hook func(context.Context, int32) error) {
+func (f *SavedSearchStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Delete method of the parent MockSavedSearchStore instance invokes the
+// Handle method of the parent MockSavedSearchStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *SavedSearchStoreDeleteFunc) PushHook(hook func(context.Context, int32) error) {
+func (f *SavedSearchStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -67167,20 +68203,20 @@ func (f *SavedSearchStoreDeleteFunc) PushHook(hook func(context.Context, int32)
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreDeleteFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32) error {
+func (f *SavedSearchStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreDeleteFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32) error {
+func (f *SavedSearchStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
 		return r0
 	})
 }
 
-func (f *SavedSearchStoreDeleteFunc) nextHook() func(context.Context, int32) error {
+func (f *SavedSearchStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -67193,78 +68229,72 @@ func (f *SavedSearchStoreDeleteFunc) nextHook() func(context.Context, int32) err
 	return hook
 }
 
-func (f *SavedSearchStoreDeleteFunc) appendCall(r0 SavedSearchStoreDeleteFuncCall) {
+func (f *SavedSearchStoreHandleFunc) appendCall(r0 SavedSearchStoreHandleFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreDeleteFuncCall objects
+// History returns a sequence of SavedSearchStoreHandleFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreDeleteFunc) History() []SavedSearchStoreDeleteFuncCall {
+func (f *SavedSearchStoreHandleFunc) History() []SavedSearchStoreHandleFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreDeleteFuncCall, len(f.history))
+	history := make([]SavedSearchStoreHandleFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreDeleteFuncCall is an object that describes an invocation
-// of method Delete on an instance of MockSavedSearchStore.
-type SavedSearchStoreDeleteFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int32
+// SavedSearchStoreHandleFuncCall is an object that describes an invocation
+// of method Handle on an instance of MockSavedSearchStore.
+type SavedSearchStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 basestore.TransactableHandle
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreDeleteFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c SavedSearchStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreDeleteFuncCall) Results() []interface{} {
+func (c SavedSearchStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// SavedSearchStoreGetByIDFunc describes the behavior when the GetByID
-// method of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreGetByIDFunc struct {
-	defaultHook func(context.Context, int32) (*types.SavedSearch, error)
-	hooks       []func(context.Context, int32) (*types.SavedSearch, error)
-	history     []SavedSearchStoreGetByIDFuncCall
+// SavedSearchStoreListFunc describes the behavior when the List method of
+// the parent MockSavedSearchStore instance is invoked.
+type SavedSearchStoreListFunc struct {
+	defaultHook func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error)
+	hooks       []func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error)
+	history     []SavedSearchStoreListFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByID delegates to the next hook function in the queue and stores the
+// List delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockSavedSearchStore) GetByID(v0 context.Context, v1 int32) (*types.SavedSearch, error) {
-	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
-	m.GetByIDFunc.appendCall(SavedSearchStoreGetByIDFuncCall{v0, v1, r0, r1})
+func (m *MockSavedSearchStore) List(v0 context.Context, v1 database.SavedSearchListArgs, v2 *database.PaginationArgs) ([]*types.SavedSearch, error) {
+	r0, r1 := m.ListFunc.nextHook()(v0, v1, v2)
+	m.ListFunc.appendCall(SavedSearchStoreListFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByID method of
-// the parent MockSavedSearchStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockSavedSearchStore instance is invoked and the hook queue is
 // empty.
-func (f *SavedSearchStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int32) (*types.SavedSearch, error)) {
+func (f *SavedSearchStoreListFunc) SetDefaultHook(hook func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByID method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreGetByIDFunc) PushHook(hook func(context.Context, int32) (*types.SavedSearch, error)) {
+// List method of the parent MockSavedSearchStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SavedSearchStoreListFunc) PushHook(hook func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -67272,20 +68302,20 @@ func (f *SavedSearchStoreGetByIDFunc) PushHook(hook func(context.Context, int32)
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreGetByIDFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) (*types.SavedSearch, error) {
+func (f *SavedSearchStoreListFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreGetByIDFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, int32) (*types.SavedSearch, error) {
+func (f *SavedSearchStoreListFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreGetByIDFunc) nextHook() func(context.Context, int32) (*types.SavedSearch, error) {
+func (f *SavedSearchStoreListFunc) nextHook() func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -67298,35 +68328,38 @@ func (f *SavedSearchStoreGetByIDFunc) nextHook() func(context.Context, int32) (*
 	return hook
 }
 
-func (f *SavedSearchStoreGetByIDFunc) appendCall(r0 SavedSearchStoreGetByIDFuncCall) {
+func (f *SavedSearchStoreListFunc) appendCall(r0 SavedSearchStoreListFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreGetByIDFuncCall objects
+// History returns a sequence of SavedSearchStoreListFuncCall objects
 // describing the invocations of this function.
-func (f *SavedSearchStoreGetByIDFunc) History() []SavedSearchStoreGetByIDFuncCall {
+func (f *SavedSearchStoreListFunc) History() []SavedSearchStoreListFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreGetByIDFuncCall, len(f.history))
+	history := make([]SavedSearchStoreListFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreGetByIDFuncCall is an object that describes an invocation
-// of method GetByID on an instance of MockSavedSearchStore.
-type SavedSearchStoreGetByIDFuncCall struct {
+// SavedSearchStoreListFuncCall is an object that describes an invocation of
+// method List on an instance of MockSavedSearchStore.
+type SavedSearchStoreListFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 database.SavedSearchListArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *database.PaginationArgs
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.SavedSearch
+	Result0 []*types.SavedSearch
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -67334,45 +68367,47 @@ type SavedSearchStoreGetByIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreGetByIDFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c SavedSearchStoreListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreGetByIDFuncCall) Results() []interface{} {
+func (c SavedSearchStoreListFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreHandleFunc describes the behavior when the Handle method
-// of the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreHandleFunc struct {
-	defaultHook func() basestore.TransactableHandle
-	hooks       []func() basestore.TransactableHandle
-	history     []SavedSearchStoreHandleFuncCall
+// SavedSearchStoreListDueForSnapshotFunc describes the behavior when the
+// ListDueForSnapshot method of the parent MockSavedSearchStore instance is
+// invoked.
+type SavedSearchStoreListDueForSnapshotFunc struct {
+	defaultHook func(context.Context) ([]*types.SavedSearch, error)
+	hooks       []func(context.Context) ([]*types.SavedSearch, error)
+	history     []SavedSearchStoreListDueForSnapshotFuncCall
 	mutex       sync.Mutex
 }
 
-// Handle delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSavedSearchStore) Handle() basestore.TransactableHandle {
-	r0 := m.HandleFunc.nextHook()()
-	m.HandleFunc.appendCall(SavedSearchStoreHandleFuncCall{r0})
-	return r0
+// ListDueForSnapshot delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) ListDueForSnapshot(v0 context.Context) ([]*types.SavedSearch, error) {
+	r0, r1 := m.ListDueForSnapshotFunc.nextHook()(v0)
+	m.ListDueForSnapshotFunc.appendCall(SavedSearchStoreListDueForSnapshotFuncCall{v0, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Handle method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+// SetDefaultHook sets function that is called when the ListDueForSnapshot
+// method of the parent MockSavedSearchStore instance is invoked and the
+// hook queue is empty.
+func (f *SavedSearchStoreListDueForSnapshotFunc) SetDefaultHook(hook func(context.Context) ([]*types.SavedSearch, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Handle method of the parent MockSavedSearchStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *SavedSearchStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+// ListDueForSnapshot method of the parent MockSavedSearchStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SavedSearchStoreListDueForSnapshotFunc) PushHook(hook func(context.Context) ([]*types.SavedSearch, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -67380,20 +68415,20 @@ func (f *SavedSearchStoreHandleFunc) PushHook(hook func() basestore.Transactable
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
-	f.SetDefaultHook(func() basestore.TransactableHandle {
-		return r0
+func (f *SavedSearchStoreListDueForSnapshotFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]*types.SavedSearch, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
-	f.PushHook(func() basestore.TransactableHandle {
-		return r0
+func (f *SavedSearchStoreListDueForSnapshotFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context) ([]*types.SavedSearch, error) {
+		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+func (f *SavedSearchStoreListDueForSnapshotFunc) nextHook() func(context.Context) ([]*types.SavedSearch, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -67406,72 +68441,80 @@ func (f *SavedSearchStoreHandleFunc) nextHook() func() basestore.TransactableHan
 	return hook
 }
 
-func (f *SavedSearchStoreHandleFunc) appendCall(r0 SavedSearchStoreHandleFuncCall) {
+func (f *SavedSearchStoreListDueForSnapshotFunc) appendCall(r0 SavedSearchStoreListDueForSnapshotFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreHandleFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreHandleFunc) History() []SavedSearchStoreHandleFuncCall {
+// History returns a sequence of SavedSearchStoreListDueForSnapshotFuncCall
+// objects describing the invocations of this function.
+func (f *SavedSearchStoreListDueForSnapshotFunc) History() []SavedSearchStoreListDueForSnapshotFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreHandleFuncCall, len(f.history))
+	history := make([]SavedSearchStoreListDueForSnapshotFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreHandleFuncCall is an object that describes an invocation
-// of method Handle on an instance of MockSavedSearchStore.
-type SavedSearchStoreHandleFuncCall struct {
+// SavedSearchStoreListDueForSnapshotFuncCall is an object that describes an
+// invocation of method ListDueForSnapshot on an instance of
+// MockSavedSearchStore.
+type SavedSearchStoreListDueForSnapshotFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 basestore.TransactableHandle
+	Result0 []*types.SavedSearch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreHandleFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c SavedSearchStoreListDueForSnapshotFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreHandleFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c SavedSearchStoreListDueForSnapshotFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// SavedSearchStoreListFunc describes the behavior when the List method of
-// the parent MockSavedSearchStore instance is invoked.
-type SavedSearchStoreListFunc struct {
-	defaultHook func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error)
-	hooks       []func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error)
-	history     []SavedSearchStoreListFuncCall
+// SavedSearchStoreListSnapshotsFunc describes the behavior when the
+// ListSnapshots method of the parent MockSavedSearchStore instance is
+// invoked.
+type SavedSearchStoreListSnapshotsFunc struct {
+	defaultHook func(context.Context, int32, int) ([]*types.SavedSearchSnapshot, error)
+	hooks       []func(context.Context, int32, int) ([]*types.SavedSearchSnapshot, error)
+	history     []SavedSearchStoreListSnapshotsFuncCall
 	mutex       sync.Mutex
 }
 
-// List delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockSavedSearchStore) List(v0 context.Context, v1 database.SavedSearchListArgs, v2 *database.PaginationArgs) ([]*types.SavedSearch, error) {
-	r0, r1 := m.ListFunc.nextHook()(v0, v1, v2)
-	m.ListFunc.appendCall(SavedSearchStoreListFuncCall{v0, v1, v2, r0, r1})
+// ListSnapshots delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) ListSnapshots(v0 context.Context, v1 int32, v2 int) ([]*types.SavedSearchSnapshot, error) {
+	r0, r1 := m.ListSnapshotsFunc.nextHook()(v0, v1, v2)
+	m.ListSnapshotsFunc.appendCall(SavedSearchStoreListSnapshotsFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the List method of the
-// parent MockSavedSearchStore instance is invoked and the hook queue is
-// empty.
-func (f *SavedSearchStoreListFunc) SetDefaultHook(hook func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error)) {
+// SetDefaultHook sets function that is called when the ListSnapshots method
+// of the parent MockSavedSearchStore instance is invoked and the hook queue
+// is empty.
+func (f *SavedSearchStoreListSnapshotsFunc) SetDefaultHook(hook func(context.Context, int32, int) ([]*types.SavedSearchSnapshot, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// List method of the parent MockSavedSearchStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *SavedSearchStoreListFunc) PushHook(hook func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error)) {
+// ListSnapshots method of the parent MockSavedSearchStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SavedSearchStoreListSnapshotsFunc) PushHook(hook func(context.Context, int32, int) ([]*types.SavedSearchSnapshot, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -67479,20 +68522,20 @@ func (f *SavedSearchStoreListFunc) PushHook(hook func(context.Context, database.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SavedSearchStoreListFunc) SetDefaultReturn(r0 []*types.SavedSearch, r1 error) {
-	f.SetDefaultHook(func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error) {
+func (f *SavedSearchStoreListSnapshotsFunc) SetDefaultReturn(r0 []*types.SavedSearchSnapshot, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, int) ([]*types.SavedSearchSnapshot, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SavedSearchStoreListFunc) PushReturn(r0 []*types.SavedSearch, r1 error) {
-	f.PushHook(func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error) {
+func (f *SavedSearchStoreListSnapshotsFunc) PushReturn(r0 []*types.SavedSearchSnapshot, r1 error) {
+	f.PushHook(func(context.Context, int32, int) ([]*types.SavedSearchSnapshot, error) {
 		return r0, r1
 	})
 }
 
-func (f *SavedSearchStoreListFunc) nextHook() func(context.Context, database.SavedSearchListArgs, *database.PaginationArgs) ([]*types.SavedSearch, error) {
+func (f *SavedSearchStoreListSnapshotsFunc) nextHook() func(context.Context, int32, int) ([]*types.SavedSearchSnapshot, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -67505,38 +68548,39 @@ func (f *SavedSearchStoreListFunc) nextHook() func(context.Context, database.Sav
 	return hook
 }
 
-func (f *SavedSearchStoreListFunc) appendCall(r0 SavedSearchStoreListFuncCall) {
+func (f *SavedSearchStoreListSnapshotsFunc) appendCall(r0 SavedSearchStoreListSnapshotsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of SavedSearchStoreListFuncCall objects
-// describing the invocations of this function.
-func (f *SavedSearchStoreListFunc) History() []SavedSearchStoreListFuncCall {
+// History returns a sequence of SavedSearchStoreListSnapshotsFuncCall
+// objects describing the invocations of this function.
+func (f *SavedSearchStoreListSnapshotsFunc) History() []SavedSearchStoreListSnapshotsFuncCall {
 	f.mutex.Lock()
-	history := make([]SavedSearchStoreListFuncCall, len(f.history))
+	history := make([]SavedSearchStoreListSnapshotsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SavedSearchStoreListFuncCall is an object that describes an invocation of
-// method List on an instance of MockSavedSearchStore.
-type SavedSearchStoreListFuncCall struct {
+// SavedSearchStoreListSnapshotsFuncCall is an object that describes an
+// invocation of method ListSnapshots on an instance of
+// MockSavedSearchStore.
+type SavedSearchStoreListSnapshotsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 database.SavedSearchListArgs
+	Arg1 int32
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 *database.PaginationArgs
+	Arg2 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*types.SavedSearch
+	Result0 []*types.SavedSearchSnapshot
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -67544,13 +68588,13 @@ type SavedSearchStoreListFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SavedSearchStoreListFuncCall) Args() []interface{} {
+func (c SavedSearchStoreListSnapshotsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SavedSearchStoreListFuncCall) Results() []interface{} {
+func (c SavedSearchStoreListSnapshotsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -67994,6 +69038,121 @@ func (c SavedSearchStoreUpdateOwnerFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// SavedSearchStoreUpdateSnapshotIntervalFunc describes the behavior when
+// the UpdateSnapshotInterval method of the parent MockSavedSearchStore
+// instance is invoked.
+type SavedSearchStoreUpdateSnapshotIntervalFunc struct {
+	defaultHook func(context.Context, int32, *int32) (*types.SavedSearch, error)
+	hooks       []func(context.Context, int32, *int32) (*types.SavedSearch, error)
+	history     []SavedSearchStoreUpdateSnapshotIntervalFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateSnapshotInterval delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSavedSearchStore) UpdateSnapshotInterval(v0 context.Context, v1 int32, v2 *int32) (*types.SavedSearch, error) {
+	r0, r1 := m.UpdateSnapshotIntervalFunc.nextHook()(v0, v1, v2)
+	m.UpdateSnapshotIntervalFunc.appendCall(SavedSearchStoreUpdateSnapshotIntervalFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateSnapshotInterval method of the parent MockSavedSearchStore instance
+// is invoked and the hook queue is empty.
+func (f *SavedSearchStoreUpdateSnapshotIntervalFunc) SetDefaultHook(hook func(context.Context, int32, *int32) (*types.SavedSearch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateSnapshotInterval method of the parent MockSavedSearchStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SavedSearchStoreUpdateSnapshotIntervalFunc) PushHook(hook func(context.Context, int32, *int32) (*types.SavedSearch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SavedSearchStoreUpdateSnapshotIntervalFunc) SetDefaultReturn(r0 *types.SavedSearch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, *int32) (*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SavedSearchStoreUpdateSnapshotIntervalFunc) PushReturn(r0 *types.SavedSearch, r1 error) {
+	f.PushHook(func(context.Context, int32, *int32) (*types.SavedSearch, error) {
+		return r0, r1
+	})
+}
+
+func (f *SavedSearchStoreUpdateSnapshotIntervalFunc) nextHook() func(context.Context, int32, *int32) (*types.SavedSearch, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SavedSearchStoreUpdateSnapshotIntervalFunc) appendCall(r0 SavedSearchStoreUpdateSnapshotIntervalFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SavedSearchStoreUpdateSnapshotIntervalFuncCall objects describing the
+// invocations of this function.
+func (f *SavedSearchStoreUpdateSnapshotIntervalFunc) History() []SavedSearchStoreUpdateSnapshotIntervalFuncCall {
+	f.mutex.Lock()
+	history := make([]SavedSearchStoreUpdateSnapshotIntervalFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SavedSearchStoreUpdateSnapshotIntervalFuncCall is an object that
+// describes an invocation of method UpdateSnapshotInterval on an instance
+// of MockSavedSearchStore.
+type SavedSearchStoreUpdateSnapshotIntervalFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SavedSearch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SavedSearchStoreUpdateSnapshotIntervalFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SavedSearchStoreUpdateSnapshotIntervalFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SavedSearchStoreUpdateVisibilityFunc describes the behavior when the
 // UpdateVisibility method of the parent MockSavedSearchStore instance is
 // invoked.
@@ -68324,6 +69483,10 @@ type MockSearchContextsStore struct {
 	// CountSearchContextsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountSearchContexts.
 	CountSearchContextsFunc *SearchContextsStoreCountSearchContextsFunc
+	// CreateSearchContextRefreshFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// CreateSearchContextRefresh.
+	CreateSearchContextRefreshFunc *SearchContextsStoreCreateSearchContextRefreshFunc
 	// CreateSearchContextStarForUserFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// CreateSearchContextStarForUser.
@@ -68365,9 +69528,20 @@ type MockSearchContextsStore struct {
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *SearchContextsStoreHandleFunc
+	// ListSearchContextRefreshesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListSearchContextRefreshes.
+	ListSearchContextRefreshesFunc *SearchContextsStoreListSearchContextRefreshesFunc
 	// ListSearchContextsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListSearchContexts.
 	ListSearchContextsFunc *SearchContextsStoreListSearchContextsFunc
+	// ListSearchContextsDueForRefreshFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListSearchContextsDueForRefresh.
+	ListSearchContextsDueForRefreshFunc *SearchContextsStoreListSearchContextsDueForRefreshFunc
+	// MaterializeSearchContextFunc is an instance of a mock function object
+	// controlling the behavior of the method MaterializeSearchContext.
+	MaterializeSearchContextFunc *SearchContextsStoreMaterializeSearchContextFunc
 	// SetSearchContextRepositoryRevisionsFunc is an instance of a mock
 	// function object controlling the behavior of the method
 	// SetSearchContextRepositoryRevisions.
@@ -68395,6 +69569,11 @@ func NewMockSearchContextsStore() *MockSearchContextsStore {
 				return
 			},
 		},
+		CreateSearchContextRefreshFunc: &SearchContextsStoreCreateSearchContextRefreshFunc{
+			defaultHook: func(context.Context, *types.SearchContextRefresh) (r0 *types.SearchContextRefresh, r1 error) {
+				return
+			},
+		},
 		CreateSearchContextStarForUserFunc: &SearchContextsStoreCreateSearchContextStarForUserFunc{
 			defaultHook: func(context.Context, int32, int64) (r0 error) {
 				return
@@ -68455,11 +69634,26 @@ func NewMockSearchContextsStore() *MockSearchContextsStore {
 				return
 			},
 		},
+		ListSearchContextRefreshesFunc: &SearchContextsStoreListSearchContextRefreshesFunc{
+			defaultHook: func(context.Context, int64, int) (r0 []*types.SearchContextRefresh, r1 error) {
+				return
+			},
+		},
 		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
 			defaultHook: func(context.Context, database.ListSearchContextsPageOptions, database.ListSearchContextsOptions) (r0 []*types.SearchContext, r1 error) {
 				return
 			},
 		},
+		ListSearchContextsDueForRefreshFunc: &SearchContextsStoreListSearchContextsDueForRefreshFunc{
+			defaultHook: func(context.Context) (r0 []*types.SearchContext, r1 error) {
+				return
+			},
+		},
+		MaterializeSearchContextFunc: &SearchContextsStoreMaterializeSearchContextFunc{
+			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions, *types.SearchContextRefresh) (r0 *types.SearchContextRefresh, r1 error) {
+				return
+			},
+		},
 		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
 			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) (r0 error) {
 				return
@@ -68493,6 +69687,11 @@ func NewStrictMockSearchContextsStore() *MockSearchContextsStore {
 				panic("unexpected invocation of MockSearchContextsStore.CountSearchContexts")
 			},
 		},
+		CreateSearchContextRefreshFunc: &SearchContextsStoreCreateSearchContextRefreshFunc{
+			defaultHook: func(context.Context, *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+				panic("unexpected invocation of MockSearchContextsStore.CreateSearchContextRefresh")
+			},
+		},
 		CreateSearchContextStarForUserFunc: &SearchContextsStoreCreateSearchContextStarForUserFunc{
 			defaultHook: func(context.Context, int32, int64) error {
 				panic("unexpected invocation of MockSearchContextsStore.CreateSearchContextStarForUser")
@@ -68553,11 +69752,26 @@ func NewStrictMockSearchContextsStore() *MockSearchContextsStore {
 				panic("unexpected invocation of MockSearchContextsStore.Handle")
 			},
 		},
+		ListSearchContextRefreshesFunc: &SearchContextsStoreListSearchContextRefreshesFunc{
+			defaultHook: func(context.Context, int64, int) ([]*types.SearchContextRefresh, error) {
+				panic("unexpected invocation of MockSearchContextsStore.ListSearchContextRefreshes")
+			},
+		},
 		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
 			defaultHook: func(context.Context, database.ListSearchContextsPageOptions, database.ListSearchContextsOptions) ([]*types.SearchContext, error) {
 				panic("unexpected invocation of MockSearchContextsStore.ListSearchContexts")
 			},
 		},
+		ListSearchContextsDueForRefreshFunc: &SearchContextsStoreListSearchContextsDueForRefreshFunc{
+			defaultHook: func(context.Context) ([]*types.SearchContext, error) {
+				panic("unexpected invocation of MockSearchContextsStore.ListSearchContextsDueForRefresh")
+			},
+		},
+		MaterializeSearchContextFunc: &SearchContextsStoreMaterializeSearchContextFunc{
+			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions, *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+				panic("unexpected invocation of MockSearchContextsStore.MaterializeSearchContext")
+			},
+		},
 		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
 			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
 				panic("unexpected invocation of MockSearchContextsStore.SetSearchContextRepositoryRevisions")
@@ -68589,6 +69803,9 @@ func NewMockSearchContextsStoreFrom(i database.SearchContextsStore) *MockSearchC
 		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
 			defaultHook: i.CountSearchContexts,
 		},
+		CreateSearchContextRefreshFunc: &SearchContextsStoreCreateSearchContextRefreshFunc{
+			defaultHook: i.CreateSearchContextRefresh,
+		},
 		CreateSearchContextStarForUserFunc: &SearchContextsStoreCreateSearchContextStarForUserFunc{
 			defaultHook: i.CreateSearchContextStarForUser,
 		},
@@ -68625,9 +69842,18 @@ func NewMockSearchContextsStoreFrom(i database.SearchContextsStore) *MockSearchC
 		HandleFunc: &SearchContextsStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
+		ListSearchContextRefreshesFunc: &SearchContextsStoreListSearchContextRefreshesFunc{
+			defaultHook: i.ListSearchContextRefreshes,
+		},
 		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
 			defaultHook: i.ListSearchContexts,
 		},
+		ListSearchContextsDueForRefreshFunc: &SearchContextsStoreListSearchContextsDueForRefreshFunc{
+			defaultHook: i.ListSearchContextsDueForRefresh,
+		},
+		MaterializeSearchContextFunc: &SearchContextsStoreMaterializeSearchContextFunc{
+			defaultHook: i.MaterializeSearchContext,
+		},
 		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
 			defaultHook: i.SetSearchContextRepositoryRevisions,
 		},
@@ -68981,7 +70207,119 @@ func (c SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) A
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) Results() []interface{} {
+func (c SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreCreateSearchContextRefreshFunc describes the behavior
+// when the CreateSearchContextRefresh method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreCreateSearchContextRefreshFunc struct {
+	defaultHook func(context.Context, *types.SearchContextRefresh) (*types.SearchContextRefresh, error)
+	hooks       []func(context.Context, *types.SearchContextRefresh) (*types.SearchContextRefresh, error)
+	history     []SearchContextsStoreCreateSearchContextRefreshFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateSearchContextRefresh delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) CreateSearchContextRefresh(v0 context.Context, v1 *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+	r0, r1 := m.CreateSearchContextRefreshFunc.nextHook()(v0, v1)
+	m.CreateSearchContextRefreshFunc.appendCall(SearchContextsStoreCreateSearchContextRefreshFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CreateSearchContextRefresh method of the parent MockSearchContextsStore
+// instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreCreateSearchContextRefreshFunc) SetDefaultHook(hook func(context.Context, *types.SearchContextRefresh) (*types.SearchContextRefresh, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateSearchContextRefresh method of the parent MockSearchContextsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SearchContextsStoreCreateSearchContextRefreshFunc) PushHook(hook func(context.Context, *types.SearchContextRefresh) (*types.SearchContextRefresh, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreCreateSearchContextRefreshFunc) SetDefaultReturn(r0 *types.SearchContextRefresh, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreCreateSearchContextRefreshFunc) PushReturn(r0 *types.SearchContextRefresh, r1 error) {
+	f.PushHook(func(context.Context, *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreCreateSearchContextRefreshFunc) nextHook() func(context.Context, *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreCreateSearchContextRefreshFunc) appendCall(r0 SearchContextsStoreCreateSearchContextRefreshFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreCreateSearchContextRefreshFuncCall objects describing
+// the invocations of this function.
+func (f *SearchContextsStoreCreateSearchContextRefreshFunc) History() []SearchContextsStoreCreateSearchContextRefreshFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreCreateSearchContextRefreshFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreCreateSearchContextRefreshFuncCall is an object that
+// describes an invocation of method CreateSearchContextRefresh on an
+// instance of MockSearchContextsStore.
+type SearchContextsStoreCreateSearchContextRefreshFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.SearchContextRefresh
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SearchContextRefresh
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreCreateSearchContextRefreshFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreCreateSearchContextRefreshFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -70068,6 +71406,121 @@ func (c SearchContextsStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// SearchContextsStoreListSearchContextRefreshesFunc describes the behavior
+// when the ListSearchContextRefreshes method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreListSearchContextRefreshesFunc struct {
+	defaultHook func(context.Context, int64, int) ([]*types.SearchContextRefresh, error)
+	hooks       []func(context.Context, int64, int) ([]*types.SearchContextRefresh, error)
+	history     []SearchContextsStoreListSearchContextRefreshesFuncCall
+	mutex       sync.Mutex
+}
+
+// ListSearchContextRefreshes delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) ListSearchContextRefreshes(v0 context.Context, v1 int64, v2 int) ([]*types.SearchContextRefresh, error) {
+	r0, r1 := m.ListSearchContextRefreshesFunc.nextHook()(v0, v1, v2)
+	m.ListSearchContextRefreshesFunc.appendCall(SearchContextsStoreListSearchContextRefreshesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListSearchContextRefreshes method of the parent MockSearchContextsStore
+// instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreListSearchContextRefreshesFunc) SetDefaultHook(hook func(context.Context, int64, int) ([]*types.SearchContextRefresh, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListSearchContextRefreshes method of the parent MockSearchContextsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SearchContextsStoreListSearchContextRefreshesFunc) PushHook(hook func(context.Context, int64, int) ([]*types.SearchContextRefresh, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreListSearchContextRefreshesFunc) SetDefaultReturn(r0 []*types.SearchContextRefresh, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int) ([]*types.SearchContextRefresh, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreListSearchContextRefreshesFunc) PushReturn(r0 []*types.SearchContextRefresh, r1 error) {
+	f.PushHook(func(context.Context, int64, int) ([]*types.SearchContextRefresh, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreListSearchContextRefreshesFunc) nextHook() func(context.Context, int64, int) ([]*types.SearchContextRefresh, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreListSearchContextRefreshesFunc) appendCall(r0 SearchContextsStoreListSearchContextRefreshesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreListSearchContextRefreshesFuncCall objects describing
+// the invocations of this function.
+func (f *SearchContextsStoreListSearchContextRefreshesFunc) History() []SearchContextsStoreListSearchContextRefreshesFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreListSearchContextRefreshesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreListSearchContextRefreshesFuncCall is an object that
+// describes an invocation of method ListSearchContextRefreshes on an
+// instance of MockSearchContextsStore.
+type SearchContextsStoreListSearchContextRefreshesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.SearchContextRefresh
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreListSearchContextRefreshesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreListSearchContextRefreshesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SearchContextsStoreListSearchContextsFunc describes the behavior when the
 // ListSearchContexts method of the parent MockSearchContextsStore instance
 // is invoked.
@@ -70409,6 +71862,233 @@ func (c SearchContextsStoreSetUserDefaultSearchContextIDFuncCall) Results() []in
 	return []interface{}{c.Result0}
 }
 
+// SearchContextsStoreListSearchContextsDueForRefreshFunc describes the
+// behavior when the ListSearchContextsDueForRefresh method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreListSearchContextsDueForRefreshFunc struct {
+	defaultHook func(context.Context) ([]*types.SearchContext, error)
+	hooks       []func(context.Context) ([]*types.SearchContext, error)
+	history     []SearchContextsStoreListSearchContextsDueForRefreshFuncCall
+	mutex       sync.Mutex
+}
+
+// ListSearchContextsDueForRefresh delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) ListSearchContextsDueForRefresh(v0 context.Context) ([]*types.SearchContext, error) {
+	r0, r1 := m.ListSearchContextsDueForRefreshFunc.nextHook()(v0)
+	m.ListSearchContextsDueForRefreshFunc.appendCall(SearchContextsStoreListSearchContextsDueForRefreshFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListSearchContextsDueForRefresh method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreListSearchContextsDueForRefreshFunc) SetDefaultHook(hook func(context.Context) ([]*types.SearchContext, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListSearchContextsDueForRefresh method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreListSearchContextsDueForRefreshFunc) PushHook(hook func(context.Context) ([]*types.SearchContext, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreListSearchContextsDueForRefreshFunc) SetDefaultReturn(r0 []*types.SearchContext, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]*types.SearchContext, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreListSearchContextsDueForRefreshFunc) PushReturn(r0 []*types.SearchContext, r1 error) {
+	f.PushHook(func(context.Context) ([]*types.SearchContext, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreListSearchContextsDueForRefreshFunc) nextHook() func(context.Context) ([]*types.SearchContext, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreListSearchContextsDueForRefreshFunc) appendCall(r0 SearchContextsStoreListSearchContextsDueForRefreshFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreListSearchContextsDueForRefreshFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreListSearchContextsDueForRefreshFunc) History() []SearchContextsStoreListSearchContextsDueForRefreshFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreListSearchContextsDueForRefreshFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreListSearchContextsDueForRefreshFuncCall is an object
+// that describes an invocation of method ListSearchContextsDueForRefresh on
+// an instance of MockSearchContextsStore.
+type SearchContextsStoreListSearchContextsDueForRefreshFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.SearchContext
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreListSearchContextsDueForRefreshFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreListSearchContextsDueForRefreshFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreMaterializeSearchContextFunc describes the behavior
+// when the MaterializeSearchContext method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreMaterializeSearchContextFunc struct {
+	defaultHook func(context.Context, int64, []*types.SearchContextRepositoryRevisions, *types.SearchContextRefresh) (*types.SearchContextRefresh, error)
+	hooks       []func(context.Context, int64, []*types.SearchContextRepositoryRevisions, *types.SearchContextRefresh) (*types.SearchContextRefresh, error)
+	history     []SearchContextsStoreMaterializeSearchContextFuncCall
+	mutex       sync.Mutex
+}
+
+// MaterializeSearchContext delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) MaterializeSearchContext(v0 context.Context, v1 int64, v2 []*types.SearchContextRepositoryRevisions, v3 *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+	r0, r1 := m.MaterializeSearchContextFunc.nextHook()(v0, v1, v2, v3)
+	m.MaterializeSearchContextFunc.appendCall(SearchContextsStoreMaterializeSearchContextFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// MaterializeSearchContext method of the parent MockSearchContextsStore
+// instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreMaterializeSearchContextFunc) SetDefaultHook(hook func(context.Context, int64, []*types.SearchContextRepositoryRevisions, *types.SearchContextRefresh) (*types.SearchContextRefresh, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MaterializeSearchContext method of the parent MockSearchContextsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SearchContextsStoreMaterializeSearchContextFunc) PushHook(hook func(context.Context, int64, []*types.SearchContextRepositoryRevisions, *types.SearchContextRefresh) (*types.SearchContextRefresh, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreMaterializeSearchContextFunc) SetDefaultReturn(r0 *types.SearchContextRefresh, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, []*types.SearchContextRepositoryRevisions, *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreMaterializeSearchContextFunc) PushReturn(r0 *types.SearchContextRefresh, r1 error) {
+	f.PushHook(func(context.Context, int64, []*types.SearchContextRepositoryRevisions, *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreMaterializeSearchContextFunc) nextHook() func(context.Context, int64, []*types.SearchContextRepositoryRevisions, *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreMaterializeSearchContextFunc) appendCall(r0 SearchContextsStoreMaterializeSearchContextFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreMaterializeSearchContextFuncCall objects describing
+// the invocations of this function.
+func (f *SearchContextsStoreMaterializeSearchContextFunc) History() []SearchContextsStoreMaterializeSearchContextFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreMaterializeSearchContextFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreMaterializeSearchContextFuncCall is an object that
+// describes an invocation of method MaterializeSearchContext on an instance
+// of MockSearchContextsStore.
+type SearchContextsStoreMaterializeSearchContextFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*types.SearchContextRepositoryRevisions
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 *types.SearchContextRefresh
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SearchContextRefresh
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreMaterializeSearchContextFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreMaterializeSearchContextFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SearchContextsStoreTransactFunc describes the behavior when the Transact
 // method of the parent MockSearchContextsStore instance is invoked.
 type SearchContextsStoreTransactFunc struct {
@@ -221,6 +221,10 @@ var ExternalServiceKinds = map[string]ExternalServiceKind{
 	extsvc.KindPythonPackages:  {CodeHost: true, JSONSchema: schema.PythonPackagesSchemaJSON},
 	extsvc.KindRustPackages:    {CodeHost: true, JSONSchema: schema.RustPackagesSchemaJSON},
 	extsvc.KindRubyPackages:    {CodeHost: true, JSONSchema: schema.RubyPackagesSchemaJSON},
+
+	extsvc.KindDotNetPackages: {CodeHost: true, JSONSchema: schema.DotNetPackagesSchemaJSON},
+	extsvc.KindPHPPackages:    {CodeHost: true, JSONSchema: schema.PHPPackagesSchemaJSON},
+	extsvc.KindElixirPackages: {CodeHost: true, JSONSchema: schema.ElixirPackagesSchemaJSON},
 }
 
 // ExternalServiceKind describes a kind of external service.
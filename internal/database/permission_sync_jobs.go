@@ -110,6 +110,7 @@ var ReasonGroupToReasons = map[PermissionsSyncJobReasonGroup][]PermissionsSyncJo
 		ReasonUserAddedToOrg,
 		ReasonUserRemovedFromOrg,
 		ReasonUserAcceptedOrgInvite,
+		ReasonUserPermsFreshnessSLOBreached,
 	},
 }
 
@@ -165,7 +166,8 @@ func (r PermissionsSyncJobReason) ResolveGroup() PermissionsSyncJobReasonGroup {
 		ReasonUserRemovedFromOrg,
 		ReasonUserAcceptedOrgInvite,
 		ReasonExternalAccountAdded,
-		ReasonExternalAccountDeleted:
+		ReasonExternalAccountDeleted,
+		ReasonUserPermsFreshnessSLOBreached:
 		return PermissionsSyncJobReasonGroupSourcegraph
 	default:
 		return PermissionsSyncJobReasonGroupUnknown
@@ -183,14 +185,15 @@ const (
 
 	// ReasonUserEmailRemoved and below are reasons of permission syncs scheduled due
 	// to Sourcegraph internal events.
-	ReasonUserEmailRemoved       PermissionsSyncJobReason = "REASON_USER_EMAIL_REMOVED"
-	ReasonUserEmailVerified      PermissionsSyncJobReason = "REASON_USER_EMAIL_VERIFIED"
-	ReasonUserAdded              PermissionsSyncJobReason = "REASON_USER_ADDED"
-	ReasonUserAddedToOrg         PermissionsSyncJobReason = "REASON_USER_ADDED_TO_ORG"
-	ReasonUserRemovedFromOrg     PermissionsSyncJobReason = "REASON_USER_REMOVED_FROM_ORG"
-	ReasonUserAcceptedOrgInvite  PermissionsSyncJobReason = "REASON_USER_ACCEPTED_ORG_INVITE"
-	ReasonExternalAccountAdded   PermissionsSyncJobReason = "REASON_EXTERNAL_ACCOUNT_ADDED"
-	ReasonExternalAccountDeleted PermissionsSyncJobReason = "REASON_EXTERNAL_ACCOUNT_DELETED"
+	ReasonUserEmailRemoved              PermissionsSyncJobReason = "REASON_USER_EMAIL_REMOVED"
+	ReasonUserEmailVerified             PermissionsSyncJobReason = "REASON_USER_EMAIL_VERIFIED"
+	ReasonUserAdded                     PermissionsSyncJobReason = "REASON_USER_ADDED"
+	ReasonUserAddedToOrg                PermissionsSyncJobReason = "REASON_USER_ADDED_TO_ORG"
+	ReasonUserRemovedFromOrg            PermissionsSyncJobReason = "REASON_USER_REMOVED_FROM_ORG"
+	ReasonUserAcceptedOrgInvite         PermissionsSyncJobReason = "REASON_USER_ACCEPTED_ORG_INVITE"
+	ReasonExternalAccountAdded          PermissionsSyncJobReason = "REASON_EXTERNAL_ACCOUNT_ADDED"
+	ReasonExternalAccountDeleted        PermissionsSyncJobReason = "REASON_EXTERNAL_ACCOUNT_DELETED"
+	ReasonUserPermsFreshnessSLOBreached PermissionsSyncJobReason = "REASON_USER_PERMS_FRESHNESS_SLO_BREACHED"
 
 	// ReasonGitHubUserEvent and below are reasons of permission syncs triggered by
 	// webhook events.
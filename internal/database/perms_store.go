@@ -167,6 +167,17 @@ type PermsStore interface {
 	// recent synced permissions in the database. If a repo's permissions have been recently
 	// synced, based on "age" they are ignored.
 	CountReposWithStalePerms(ctx context.Context, age time.Duration) (int, error)
+	// UserPermsFreshness returns when the permissions of the given user were last
+	// synced successfully and whether a sync job is currently pending for them.
+	UserPermsFreshness(ctx context.Context, userID int32) (*PermsFreshness, error)
+	// UsersBreachingPermsSLO returns up to limit users whose permissions have not
+	// been synced successfully within maxAge, oldest first, along with the total
+	// number of such users.
+	UsersBreachingPermsSLO(ctx context.Context, maxAge time.Duration, limit int) ([]*PermsFreshness, int, error)
+	// ReposBreachingPermsSLO returns up to limit private repositories whose
+	// permissions have not been synced successfully within maxAge, oldest first,
+	// along with the total number of such repositories.
+	ReposBreachingPermsSLO(ctx context.Context, maxAge time.Duration, limit int) ([]*PermsFreshness, int, error)
 	// Metrics returns calculated metrics values by querying the database. The
 	// "staleDur" argument indicates how long ago was the last update to be
 	// considered as stale.
@@ -1669,6 +1680,104 @@ func (s *permsStore) loadIDsWithTime(ctx context.Context, q *sqlf.Query) (map[in
 	return scanIDsWithTime(s.Query(ctx, q))
 }
 
+// PermsFreshness describes how recently the permissions of a user or a
+// repository were synced successfully.
+type PermsFreshness struct {
+	// ID is the ID of the user or the repository.
+	ID int32
+	// SyncedAt is the time the last successful sync finished. It is the zero
+	// value if the permissions have never been synced.
+	SyncedAt time.Time
+	// SyncPending is true if a sync job is queued or processing.
+	SyncPending bool
+}
+
+const userPermsFreshnessQuery = `
+SELECT
+	(SELECT MAX(finished_at) FROM permission_sync_jobs WHERE user_id = %s AND state = 'completed'),
+	EXISTS (SELECT 1 FROM permission_sync_jobs WHERE user_id = %s AND state IN ('queued', 'processing'))
+`
+
+func (s *permsStore) UserPermsFreshness(ctx context.Context, userID int32) (*PermsFreshness, error) {
+	f := &PermsFreshness{ID: userID}
+	err := s.QueryRow(ctx, sqlf.Sprintf(userPermsFreshnessQuery, userID, userID)).Scan(&dbutil.NullTime{Time: &f.SyncedAt}, &f.SyncPending)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+const usersBreachingPermsSLOQuery = `
+SELECT
+	u.id,
+	MAX(p.finished_at) FILTER (WHERE p.state = 'completed') AS synced_at,
+	BOOL_OR(COALESCE(p.state IN ('queued', 'processing'), FALSE)) AS sync_pending,
+	COUNT(*) OVER() AS total
+FROM users u
+LEFT JOIN permission_sync_jobs p ON p.user_id = u.id
+WHERE u.deleted_at IS NULL AND %s
+GROUP BY u.id
+HAVING MAX(p.finished_at) FILTER (WHERE p.state = 'completed') IS NULL
+	OR MAX(p.finished_at) FILTER (WHERE p.state = 'completed') < %s
+ORDER BY synced_at ASC NULLS FIRST, u.id ASC
+LIMIT %d
+`
+
+// UsersBreachingPermsSLO lists the users whose permissions were last synced
+// successfully before maxAge ago, or never. Site admins are skipped unless
+// authorization is enforced for them since their permissions are not checked.
+func (s *permsStore) UsersBreachingPermsSLO(ctx context.Context, maxAge time.Duration, limit int) ([]*PermsFreshness, int, error) {
+	filterSiteAdmins := sqlf.Sprintf("u.site_admin = FALSE")
+	if conf.Get().AuthzEnforceForSiteAdmins {
+		filterSiteAdmins = sqlf.Sprintf("TRUE")
+	}
+	q := sqlf.Sprintf(usersBreachingPermsSLOQuery, filterSiteAdmins, s.clock().Add(-maxAge), limit)
+	return s.loadPermsFreshness(ctx, q)
+}
+
+const reposBreachingPermsSLOQuery = `
+SELECT
+	r.id,
+	MAX(p.finished_at) FILTER (WHERE p.state = 'completed') AS synced_at,
+	BOOL_OR(COALESCE(p.state IN ('queued', 'processing'), FALSE)) AS sync_pending,
+	COUNT(*) OVER() AS total
+FROM repo r
+LEFT JOIN permission_sync_jobs p ON p.repository_id = r.id
+WHERE r.private AND r.deleted_at IS NULL
+GROUP BY r.id
+HAVING MAX(p.finished_at) FILTER (WHERE p.state = 'completed') IS NULL
+	OR MAX(p.finished_at) FILTER (WHERE p.state = 'completed') < %s
+ORDER BY synced_at ASC NULLS FIRST, r.id ASC
+LIMIT %d
+`
+
+// ReposBreachingPermsSLO lists the private repositories whose permissions were
+// last synced successfully before maxAge ago, or never.
+func (s *permsStore) ReposBreachingPermsSLO(ctx context.Context, maxAge time.Duration, limit int) ([]*PermsFreshness, int, error) {
+	q := sqlf.Sprintf(reposBreachingPermsSLOQuery, s.clock().Add(-maxAge), limit)
+	return s.loadPermsFreshness(ctx, q)
+}
+
+// loadPermsFreshness runs the query and returns the scanned rows along with the
+// total count, which is expected in the last column of each row.
+func (s *permsStore) loadPermsFreshness(ctx context.Context, q *sqlf.Query) (_ []*PermsFreshness, total int, err error) {
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var results []*PermsFreshness
+	for rows.Next() {
+		f := &PermsFreshness{}
+		if err := rows.Scan(&f.ID, &dbutil.NullTime{Time: &f.SyncedAt}, &f.SyncPending, &total); err != nil {
+			return nil, 0, err
+		}
+		results = append(results, f)
+	}
+	return results, total, nil
+}
+
 // PermsMetrics contains metrics values calculated by querying the database.
 type PermsMetrics struct {
 	// The number of users with stale permissions.
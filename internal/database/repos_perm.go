@@ -22,9 +22,16 @@ type AuthzQueryParameters struct {
 	UsePermissionsUserMapping bool
 	AuthenticatedUserID       int32
 	AuthzEnforceForSiteAdmins bool
+	// StalePermissions is true when the permissions of the authenticated user
+	// are known to be stale, in which case only public and unrestricted
+	// repositories are visible. See authz.WithStalePermissions.
+	StalePermissions bool
 }
 
 func (p *AuthzQueryParameters) ToAuthzQuery() *sqlf.Query {
+	if p.StalePermissions && !p.BypassAuthz {
+		return unrestrictedAuthzQuery()
+	}
 	return authzQuery(p.BypassAuthz, p.AuthenticatedUserID)
 }
 
@@ -67,6 +74,7 @@ func GetAuthzQueryParameters(ctx context.Context, db DB) (params *AuthzQueryPara
 		}
 
 		params.AuthenticatedUserID = currentUser.ID
+		params.StalePermissions = authz.HasStalePermissions(ctx)
 
 		if currentUser.SiteAdmin && !params.AuthzEnforceForSiteAdmins {
 			params.BypassAuthz = true
@@ -143,3 +151,12 @@ func authzQuery(bypassAuthz bool, authenticatedUserID int32) *sqlf.Query {
 	// Have to manually wrap the result in parenthesis so that they're evaluated together
 	return sqlf.Sprintf("(%s)", sqlf.Join(conditions, "\nOR\n"))
 }
+
+// unrestrictedAuthzQuery only allows repositories that are visible without
+// checking the permissions of a user.
+func unrestrictedAuthzQuery() *sqlf.Query {
+	conditions := []*sqlf.Query{GetUnrestrictedReposCond(), ExternalServiceUnrestrictedCondition}
+
+	// Have to manually wrap the result in parenthesis so that they're evaluated together
+	return sqlf.Sprintf("(%s)", sqlf.Join(conditions, "\nOR\n"))
+}
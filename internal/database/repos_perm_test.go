@@ -167,6 +167,24 @@ func TestAuthzQueryConds(t *testing.T) {
 			},
 			wantQuery: authzQuery(false, int32(1)),
 		},
+		{
+			name: "authenticated user has stale permissions",
+			setup: func(_ *testing.T) (context.Context, DB) {
+				require.NoError(t, db.Users().SetIsSiteAdmin(context.Background(), u.ID, false))
+				ctx := actor.WithActor(context.Background(), &actor.Actor{UID: u.ID})
+				return authz.WithStalePermissions(ctx), db
+			},
+			wantQuery: unrestrictedAuthzQuery(),
+		},
+		{
+			name: "authenticated site admin with stale permissions bypasses checks",
+			setup: func(_ *testing.T) (context.Context, DB) {
+				require.NoError(t, db.Users().SetIsSiteAdmin(context.Background(), u.ID, true))
+				ctx := actor.WithActor(context.Background(), &actor.Actor{UID: u.ID})
+				return authz.WithStalePermissions(ctx), db
+			},
+			wantQuery: authzQuery(true, int32(1)),
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	errSavedSearchSnapshotNotFound = resourceNotFoundError{noun: "saved search snapshot"}
+	savedSearchSnapshotColumns     = sqlf.Sprintf("saved_search_id, query, object_key, entry_count, limit_hit, created_by, created_at")
+)
+
+// CreateSnapshot records a snapshot of the results of a saved search. The ID field must be zero,
+// or an error will be returned.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the user is an admin. It is
+// the caller's responsibility to ensure the user has proper permissions to snapshot the saved
+// search.
+func (s *savedSearchStore) CreateSnapshot(ctx context.Context, newSnapshot *types.SavedSearchSnapshot) (created *types.SavedSearchSnapshot, err error) {
+	if newSnapshot.ID != 0 {
+		return nil, errors.New("newSnapshot.ID must be zero")
+	}
+
+	tr, ctx := trace.New(ctx, "database.SavedSearches.CreateSnapshot")
+	defer tr.EndWithErr(&err)
+
+	return scanSavedSearchSnapshot(
+		s.QueryRow(ctx,
+			sqlf.Sprintf(`INSERT INTO saved_search_snapshots(%v) VALUES(%v, %v, %v, %v, %v, %v, DEFAULT) RETURNING id, %v`,
+				savedSearchSnapshotColumns,
+				newSnapshot.SavedSearchID,
+				newSnapshot.Query,
+				newSnapshot.ObjectKey,
+				newSnapshot.EntryCount,
+				newSnapshot.LimitHit,
+				newSnapshot.CreatedByUser,
+				savedSearchSnapshotColumns,
+			),
+		))
+}
+
+// GetSnapshotByID returns the saved search snapshot with the given ID.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the user is an admin. It is
+// the caller's responsibility to ensure this response only makes it to users with proper
+// permissions to access the saved search.
+func (s *savedSearchStore) GetSnapshotByID(ctx context.Context, id int32) (_ *types.SavedSearchSnapshot, err error) {
+	tr, ctx := trace.New(ctx, "database.SavedSearches.GetSnapshotByID")
+	defer tr.EndWithErr(&err)
+
+	return scanSavedSearchSnapshot(s.QueryRow(ctx, sqlf.Sprintf(`SELECT id, %v FROM saved_search_snapshots WHERE id=%v`, savedSearchSnapshotColumns, id)))
+}
+
+// ListSnapshots lists the most recent snapshots of a saved search, newest first. If limit is zero,
+// all snapshots are returned.
+//
+// 🚨 SECURITY: This method does NOT perform authorization checks.
+func (s *savedSearchStore) ListSnapshots(ctx context.Context, savedSearchID int32, limit int) (_ []*types.SavedSearchSnapshot, err error) {
+	tr, ctx := trace.New(ctx, "database.SavedSearches.ListSnapshots")
+	defer tr.EndWithErr(&err)
+
+	limitQuery := sqlf.Sprintf("")
+	if limit > 0 {
+		limitQuery = sqlf.Sprintf("LIMIT %v", limit)
+	}
+	return scanSavedSearchSnapshots(s.Query(ctx, sqlf.Sprintf(
+		`SELECT id, %v FROM saved_search_snapshots WHERE saved_search_id=%v ORDER BY created_at DESC, id DESC %v`,
+		savedSearchSnapshotColumns,
+		savedSearchID,
+		limitQuery,
+	)))
+}
+
+// DeleteSnapshotsBeyond deletes all but the given number of most recent snapshots of a saved
+// search. It returns the object keys of the deleted snapshots, so the caller can remove their
+// results from the object store.
+//
+// 🚨 SECURITY: This method does NOT perform authorization checks.
+func (s *savedSearchStore) DeleteSnapshotsBeyond(ctx context.Context, savedSearchID int32, keep int) (_ []string, err error) {
+	tr, ctx := trace.New(ctx, "database.SavedSearches.DeleteSnapshotsBeyond")
+	defer tr.EndWithErr(&err)
+
+	return basestore.ScanStrings(s.Query(ctx, sqlf.Sprintf(`
+DELETE FROM saved_search_snapshots
+WHERE id IN (
+	SELECT id FROM saved_search_snapshots
+	WHERE saved_search_id=%v
+	ORDER BY created_at DESC, id DESC
+	OFFSET %v
+)
+RETURNING object_key`,
+		savedSearchID,
+		keep,
+	)))
+}
+
+// ListDueForSnapshot lists the saved searches with scheduled snapshots whose most recent snapshot
+// is older than their snapshot interval.
+//
+// 🚨 SECURITY: This method does NOT perform authorization checks.
+func (s *savedSearchStore) ListDueForSnapshot(ctx context.Context) (_ []*types.SavedSearch, err error) {
+	tr, ctx := trace.New(ctx, "database.SavedSearches.ListDueForSnapshot")
+	defer tr.EndWithErr(&err)
+
+	return scanSavedSearches(s.Query(ctx, sqlf.Sprintf(`
+SELECT id, %v FROM saved_searches
+WHERE
+	snapshot_interval_hours IS NOT NULL AND
+	snapshot_user_id IS NOT NULL AND
+	NOT EXISTS (
+		SELECT 1 FROM saved_search_snapshots
+		WHERE
+			saved_search_snapshots.saved_search_id = saved_searches.id AND
+			saved_search_snapshots.created_at > now() - make_interval(hours => saved_searches.snapshot_interval_hours)
+	)
+ORDER BY id`,
+		savedSearchColumns,
+	)))
+}
+
+var scanSavedSearchSnapshots = basestore.NewSliceScanner(scanSavedSearchSnapshot)
+
+func scanSavedSearchSnapshot(s dbutil.Scanner) (*types.SavedSearchSnapshot, error) {
+	var row types.SavedSearchSnapshot
+	if err := s.Scan(
+		&row.ID,
+		&row.SavedSearchID,
+		&row.Query,
+		&row.ObjectKey,
+		&row.EntryCount,
+		&row.LimitHit,
+		&row.CreatedByUser,
+		&row.CreatedAt,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errSavedSearchSnapshotNotFound
+		}
+		return nil, errors.Wrap(err, "Scan")
+	}
+	return &row, nil
+}
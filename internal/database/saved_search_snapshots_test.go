@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestSavedSearchSnapshots(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "u"})
+	require.NoError(t, err)
+	ctx = actor.WithActor(ctx, &actor.Actor{UID: user.ID})
+
+	ss, err := db.SavedSearches().Create(ctx, &types.SavedSearch{
+		Description: "d",
+		Query:       "q",
+		Owner:       types.NamespaceUser(user.ID),
+	})
+	require.NoError(t, err)
+
+	createSnapshot := func(key string) *types.SavedSearchSnapshot {
+		t.Helper()
+		snapshot, err := db.SavedSearches().CreateSnapshot(ctx, &types.SavedSearchSnapshot{
+			SavedSearchID: ss.ID,
+			Query:         ss.Query,
+			ObjectKey:     key,
+			EntryCount:    3,
+			CreatedByUser: &user.ID,
+		})
+		require.NoError(t, err)
+		return snapshot
+	}
+
+	t.Run("not scheduled", func(t *testing.T) {
+		due, err := db.SavedSearches().ListDueForSnapshot(ctx)
+		require.NoError(t, err)
+		require.Empty(t, due)
+	})
+
+	t.Run("schedule", func(t *testing.T) {
+		updated, err := db.SavedSearches().UpdateSnapshotInterval(ctx, ss.ID, pointers.Ptr(int32(24)))
+		require.NoError(t, err)
+		require.Equal(t, int32(24), *updated.SnapshotIntervalHours)
+		require.Equal(t, user.ID, *updated.SnapshotUserID)
+
+		// Never snapshotted, so it is due
+		due, err := db.SavedSearches().ListDueForSnapshot(ctx)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		require.Equal(t, ss.ID, due[0].ID)
+	})
+
+	first := createSnapshot("a")
+	second := createSnapshot("b")
+	third := createSnapshot("c")
+
+	t.Run("recent snapshot is not due", func(t *testing.T) {
+		due, err := db.SavedSearches().ListDueForSnapshot(ctx)
+		require.NoError(t, err)
+		require.Empty(t, due)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		got, err := db.SavedSearches().GetSnapshotByID(ctx, first.ID)
+		require.NoError(t, err)
+		require.Equal(t, first, got)
+
+		_, err = db.SavedSearches().GetSnapshotByID(ctx, third.ID+1)
+		require.True(t, errcode.IsNotFound(err))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		got, err := db.SavedSearches().ListSnapshots(ctx, ss.ID, 2)
+		require.NoError(t, err)
+		require.Equal(t, []*types.SavedSearchSnapshot{third, second}, got)
+	})
+
+	t.Run("delete beyond", func(t *testing.T) {
+		keys, err := db.SavedSearches().DeleteSnapshotsBeyond(ctx, ss.ID, 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a"}, keys)
+
+		got, err := db.SavedSearches().ListSnapshots(ctx, ss.ID, 0)
+		require.NoError(t, err)
+		require.Equal(t, []*types.SavedSearchSnapshot{third, second}, got)
+	})
+
+	t.Run("unschedule", func(t *testing.T) {
+		updated, err := db.SavedSearches().UpdateSnapshotInterval(ctx, ss.ID, nil)
+		require.NoError(t, err)
+		require.Nil(t, updated.SnapshotIntervalHours)
+		require.Nil(t, updated.SnapshotUserID)
+	})
+}
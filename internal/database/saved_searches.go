@@ -21,12 +21,18 @@ type SavedSearchStore interface {
 	Update(_ context.Context, _ *types.SavedSearch) (*types.SavedSearch, error)
 	UpdateOwner(_ context.Context, id int32, newOwner types.Namespace) (*types.SavedSearch, error)
 	UpdateVisibility(_ context.Context, id int32, secret bool) (*types.SavedSearch, error)
+	UpdateSnapshotInterval(_ context.Context, id int32, intervalHours *int32) (*types.SavedSearch, error)
 	Delete(context.Context, int32) error
 	GetByID(context.Context, int32) (*types.SavedSearch, error)
 	List(context.Context, SavedSearchListArgs, *PaginationArgs) ([]*types.SavedSearch, error)
 	Count(context.Context, SavedSearchListArgs) (int, error)
 	MarshalToCursor(*types.SavedSearch, OrderBy) (types.MultiCursor, error)
 	UnmarshalValuesFromCursor(types.MultiCursor) ([]any, error)
+	CreateSnapshot(context.Context, *types.SavedSearchSnapshot) (*types.SavedSearchSnapshot, error)
+	GetSnapshotByID(context.Context, int32) (*types.SavedSearchSnapshot, error)
+	ListSnapshots(_ context.Context, savedSearchID int32, limit int) ([]*types.SavedSearchSnapshot, error)
+	DeleteSnapshotsBeyond(_ context.Context, savedSearchID int32, keep int) (objectKeys []string, _ error)
+	ListDueForSnapshot(context.Context) ([]*types.SavedSearch, error)
 	WithTransact(context.Context, func(SavedSearchStore) error) error
 	With(basestore.ShareableStore) SavedSearchStore
 	basestore.ShareableStore
@@ -53,7 +59,7 @@ func (s *savedSearchStore) WithTransact(ctx context.Context, f func(SavedSearchS
 
 var (
 	errSavedSearchNotFound = resourceNotFoundError{noun: "saved search"}
-	savedSearchColumns     = sqlf.Sprintf("description, query, draft, user_id, org_id, visibility_secret, created_by, created_at, updated_by, updated_at")
+	savedSearchColumns     = sqlf.Sprintf("description, query, draft, user_id, org_id, visibility_secret, created_by, created_at, updated_by, updated_at, snapshot_interval_hours, snapshot_user_id")
 )
 
 // Create creates a new saved search with the specified parameters. The ID field must be zero, or an
@@ -73,7 +79,7 @@ func (s *savedSearchStore) Create(ctx context.Context, newSavedSearch *types.Sav
 
 	return scanSavedSearch(
 		s.QueryRow(ctx,
-			sqlf.Sprintf(`INSERT INTO saved_searches(%v) VALUES(%v, %v, %v, %v, %v, %v, %v, DEFAULT, %v, DEFAULT) RETURNING id, %v`,
+			sqlf.Sprintf(`INSERT INTO saved_searches(%v) VALUES(%v, %v, %v, %v, %v, %v, %v, DEFAULT, %v, DEFAULT, %v, %v) RETURNING id, %v`,
 				savedSearchColumns,
 				newSavedSearch.Description,
 				newSavedSearch.Query,
@@ -83,6 +89,8 @@ func (s *savedSearchStore) Create(ctx context.Context, newSavedSearch *types.Sav
 				newSavedSearch.VisibilitySecret,
 				actorUID,
 				actorUID,
+				newSavedSearch.SnapshotIntervalHours,
+				newSavedSearch.SnapshotUserID,
 				savedSearchColumns,
 			),
 		))
@@ -126,6 +134,28 @@ func (s *savedSearchStore) UpdateVisibility(ctx context.Context, id int32, secre
 	return s.update(ctx, id, []*sqlf.Query{sqlf.Sprintf("visibility_secret=%v", secret)})
 }
 
+// UpdateSnapshotInterval updates how often snapshots of the results of an existing saved search
+// are taken, or disables scheduled snapshots if intervalHours is nil. Scheduled snapshots search
+// with the permissions of the current actor.
+//
+// 🚨 SECURITY: This method does NOT verify that the user has permissions to do this. The caller
+// MUST do so.
+func (s *savedSearchStore) UpdateSnapshotInterval(ctx context.Context, id int32, intervalHours *int32) (updated *types.SavedSearch, err error) {
+	tr, ctx := trace.New(ctx, "database.SavedSearches.UpdateSnapshotInterval")
+	defer tr.EndWithErr(&err)
+
+	var snapshotUserID *int32
+	if intervalHours != nil {
+		if uid := actor.FromContext(ctx).UID; uid != 0 {
+			snapshotUserID = &uid
+		}
+	}
+	return s.update(ctx, id, []*sqlf.Query{
+		sqlf.Sprintf("snapshot_interval_hours=%v", intervalHours),
+		sqlf.Sprintf("snapshot_user_id=%v", snapshotUserID),
+	})
+}
+
 func (s *savedSearchStore) update(ctx context.Context, id int32, updates []*sqlf.Query) (updated *types.SavedSearch, err error) {
 	actorUID := actor.FromContext(ctx).UID
 	updates = append(updates, sqlf.Sprintf("updated_at=now()"), sqlf.Sprintf("updated_by=%v", actorUID))
@@ -284,6 +314,8 @@ func scanSavedSearch(s dbutil.Scanner) (*types.SavedSearch, error) {
 		&row.CreatedAt,
 		&row.UpdatedByUser,
 		&row.UpdatedAt,
+		&row.SnapshotIntervalHours,
+		&row.SnapshotUserID,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errSavedSearchNotFound
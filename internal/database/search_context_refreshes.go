@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"math"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// MaxSearchContextRefreshes is the number of most recent refreshes retained per search context.
+// Older refreshes are deleted when a new refresh is recorded.
+const MaxSearchContextRefreshes = 100
+
+var searchContextRefreshColumns = sqlf.Sprintf("search_context_id, repo_count, changes, error, created_at")
+
+// searchContextsDueForRefreshCond matches the contexts with a refresh interval that have not been
+// refreshed within their interval or since they were last updated.
+const searchContextsDueForRefreshCond = `
+refresh_interval_hours IS NOT NULL
+AND NOT EXISTS (
+	SELECT 1 FROM search_context_refreshes scr
+	WHERE
+		scr.search_context_id = t.id
+		AND scr.created_at > GREATEST(now() - make_interval(hours => t.refresh_interval_hours), t.updated_at)
+)
+`
+
+// ListSearchContextsDueForRefresh lists the query-based search contexts whose repositories have to
+// be materialized again.
+func (s *searchContextsStore) ListSearchContextsDueForRefresh(ctx context.Context) ([]*types.SearchContext, error) {
+	if a := actor.FromContext(ctx); !a.IsInternal() {
+		return nil, errors.New("ListSearchContextsDueForRefresh can only be accessed by an internal actor")
+	}
+
+	return s.listSearchContexts(
+		ctx,
+		sqlf.Sprintf(searchContextsDueForRefreshCond),
+		getSearchContextOrderByClause(SearchContextsOrderByID, false),
+		math.MaxInt32, // limit
+		0,             // offset
+	)
+}
+
+// MaterializeSearchContext replaces the repository revisions of a search context with the ones
+// resolved from its query and records the refresh in the history of the context.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is an internal actor or has permission to update the search context.
+func (s *searchContextsStore) MaterializeSearchContext(ctx context.Context, searchContextID int64, repositoryRevisions []*types.SearchContextRepositoryRevisions, refresh *types.SearchContextRefresh) (_ *types.SearchContextRefresh, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.SetSearchContextRepositoryRevisions(ctx, searchContextID, repositoryRevisions); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Exec(ctx, sqlf.Sprintf("UPDATE search_contexts SET last_refreshed_at = now() WHERE id = %d", searchContextID)); err != nil {
+		return nil, err
+	}
+
+	refresh.SearchContextID = searchContextID
+	return tx.CreateSearchContextRefresh(ctx, refresh)
+}
+
+// CreateSearchContextRefresh records a refresh of a search context without changing its
+// repositories, e.g. when the refresh failed. The ID field must be zero, or an error will be
+// returned. Refreshes beyond the MaxSearchContextRefreshes most recent ones are deleted.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is an internal actor or has permission to update the search context.
+func (s *searchContextsStore) CreateSearchContextRefresh(ctx context.Context, refresh *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+	if refresh.ID != 0 {
+		return nil, errors.New("refresh.ID must be zero")
+	}
+
+	changes := refresh.Changes
+	if changes == nil {
+		changes = []types.SearchContextRefreshChange{}
+	}
+	created, err := scanSearchContextRefresh(s.QueryRow(ctx, sqlf.Sprintf(
+		`INSERT INTO search_context_refreshes(%s) VALUES(%s, %s, %s, %s, DEFAULT) RETURNING id, %s`,
+		searchContextRefreshColumns,
+		refresh.SearchContextID,
+		refresh.RepoCount,
+		dbutil.JSONMessage(&changes),
+		dbutil.NullStringColumn(refresh.Error),
+		searchContextRefreshColumns,
+	)))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Exec(ctx, sqlf.Sprintf(
+		deleteSearchContextRefreshesBeyondFmtstr,
+		refresh.SearchContextID,
+		MaxSearchContextRefreshes,
+	)); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+const deleteSearchContextRefreshesBeyondFmtstr = `
+DELETE FROM search_context_refreshes
+WHERE id IN (
+	SELECT id FROM search_context_refreshes
+	WHERE search_context_id = %d
+	ORDER BY created_at DESC, id DESC
+	OFFSET %d
+)
+`
+
+// ListSearchContextRefreshes lists the most recent refreshes of a search context, newest first. If
+// limit is zero, all refreshes are returned.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has access to the search context.
+func (s *searchContextsStore) ListSearchContextRefreshes(ctx context.Context, searchContextID int64, limit int) ([]*types.SearchContextRefresh, error) {
+	limitQuery := sqlf.Sprintf("")
+	if limit > 0 {
+		limitQuery = sqlf.Sprintf("LIMIT %d", limit)
+	}
+	return scanSearchContextRefreshes(s.Query(ctx, sqlf.Sprintf(
+		`SELECT id, %s FROM search_context_refreshes WHERE search_context_id = %d ORDER BY created_at DESC, id DESC %s`,
+		searchContextRefreshColumns,
+		searchContextID,
+		limitQuery,
+	)))
+}
+
+var scanSearchContextRefreshes = basestore.NewSliceScanner(scanSearchContextRefresh)
+
+func scanSearchContextRefresh(s dbutil.Scanner) (*types.SearchContextRefresh, error) {
+	var row types.SearchContextRefresh
+	if err := s.Scan(
+		&row.ID,
+		&row.SearchContextID,
+		&row.RepoCount,
+		dbutil.JSONMessage(&row.Changes),
+		&dbutil.NullString{S: &row.Error},
+		&row.CreatedAt,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("search context refresh not found")
+		}
+		return nil, errors.Wrap(err, "Scan")
+	}
+	return &row, nil
+}
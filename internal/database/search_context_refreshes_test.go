@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSearchContexts_Refreshes(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	ctx := actor.WithInternalActor(context.Background())
+	sc := db.SearchContexts()
+
+	err := db.Repos().Create(ctx, &types.Repo{Name: "testA", URI: "https://example.com/a"})
+	require.NoError(t, err)
+	repo, err := db.Repos().GetByName(ctx, "testA")
+	require.NoError(t, err)
+
+	created, err := createSearchContexts(ctx, sc, []*types.SearchContext{
+		{Name: "static", Public: true},
+		{Name: "dynamic", Public: true, Query: "repo:^testA$"},
+		{
+			Name:                 "materialized",
+			Public:               true,
+			Query:                "repo:^test",
+			RefreshIntervalHours: 24,
+			RevisionRules:        []types.SearchContextRevisionRule{{Repo: "^test", Tag: "v*"}},
+		},
+	})
+	require.NoError(t, err)
+	materialized := created[2]
+	require.Equal(t, int32(24), materialized.RefreshIntervalHours)
+	require.Equal(t, []types.SearchContextRevisionRule{{Repo: "^test", Tag: "v*"}}, materialized.RevisionRules)
+	require.Nil(t, materialized.LastRefreshedAt)
+
+	t.Run("due before the first refresh", func(t *testing.T) {
+		due, err := sc.ListSearchContextsDueForRefresh(ctx)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		require.Equal(t, materialized.ID, due[0].ID)
+	})
+
+	t.Run("requires internal actor", func(t *testing.T) {
+		_, err := sc.ListSearchContextsDueForRefresh(context.Background())
+		require.Error(t, err)
+	})
+
+	change := types.SearchContextRefreshChange{RepoID: repo.ID, RepoName: repo.Name, Revisions: []string{"v1.0.0"}}
+
+	t.Run("materialize", func(t *testing.T) {
+		refresh, err := sc.MaterializeSearchContext(ctx, materialized.ID, []*types.SearchContextRepositoryRevisions{
+			{Repo: types.MinimalRepo{ID: repo.ID, Name: repo.Name}, Revisions: []string{"v1.0.0"}},
+		}, &types.SearchContextRefresh{RepoCount: 1, Changes: []types.SearchContextRefreshChange{change}})
+		require.NoError(t, err)
+		require.Equal(t, materialized.ID, refresh.SearchContextID)
+		require.Equal(t, []types.SearchContextRefreshChange{change}, refresh.Changes)
+
+		repoRevs, err := sc.GetSearchContextRepositoryRevisions(ctx, materialized.ID)
+		require.NoError(t, err)
+		require.Len(t, repoRevs, 1)
+		require.Equal(t, []string{"v1.0.0"}, repoRevs[0].Revisions)
+
+		got, err := sc.GetSearchContext(ctx, GetSearchContextOptions{Name: materialized.Name})
+		require.NoError(t, err)
+		require.NotNil(t, got.LastRefreshedAt)
+
+		due, err := sc.ListSearchContextsDueForRefresh(ctx)
+		require.NoError(t, err)
+		require.Empty(t, due)
+	})
+
+	t.Run("failed refresh", func(t *testing.T) {
+		_, err := sc.CreateSearchContextRefresh(ctx, &types.SearchContextRefresh{SearchContextID: materialized.ID, RepoCount: 1, Error: "boom"})
+		require.NoError(t, err)
+
+		refreshes, err := sc.ListSearchContextRefreshes(ctx, materialized.ID, 0)
+		require.NoError(t, err)
+		require.Len(t, refreshes, 2)
+		require.Equal(t, "boom", refreshes[0].Error)
+		require.Empty(t, refreshes[0].Changes)
+		require.Equal(t, []types.SearchContextRefreshChange{change}, refreshes[1].Changes)
+
+		refreshes, err = sc.ListSearchContextRefreshes(ctx, materialized.ID, 1)
+		require.NoError(t, err)
+		require.Len(t, refreshes, 1)
+	})
+
+	t.Run("prunes history", func(t *testing.T) {
+		for i := range MaxSearchContextRefreshes {
+			_, err := sc.CreateSearchContextRefresh(ctx, &types.SearchContextRefresh{SearchContextID: materialized.ID, RepoCount: int32(i)})
+			require.NoError(t, err)
+		}
+
+		refreshes, err := sc.ListSearchContextRefreshes(ctx, materialized.ID, 0)
+		require.NoError(t, err)
+		require.Len(t, refreshes, MaxSearchContextRefreshes)
+		require.Equal(t, int32(MaxSearchContextRefreshes-1), refreshes[0].RepoCount)
+		require.Equal(t, int32(0), refreshes[len(refreshes)-1].RepoCount)
+	})
+
+	t.Run("update resets materialization", func(t *testing.T) {
+		got, err := sc.GetSearchContext(ctx, GetSearchContextOptions{Name: materialized.Name})
+		require.NoError(t, err)
+		got.Query = "repo:^testA$"
+
+		updated, err := sc.UpdateSearchContextWithRepositoryRevisions(ctx, got, nil)
+		require.NoError(t, err)
+		require.Nil(t, updated.LastRefreshedAt)
+
+		due, err := sc.ListSearchContextsDueForRefresh(ctx)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		require.Equal(t, materialized.ID, due[0].ID)
+	})
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"sort"
 
@@ -44,6 +45,10 @@ type SearchContextsStore interface {
 	GetDefaultSearchContextForCurrentUser(ctx context.Context) (*types.SearchContext, error)
 	CreateSearchContextStarForUser(ctx context.Context, userID int32, searchContextID int64) error
 	DeleteSearchContextStarForUser(ctx context.Context, userID int32, searchContextID int64) error
+	ListSearchContextsDueForRefresh(ctx context.Context) ([]*types.SearchContext, error)
+	MaterializeSearchContext(ctx context.Context, searchContextID int64, repositoryRevisions []*types.SearchContextRepositoryRevisions, refresh *types.SearchContextRefresh) (*types.SearchContextRefresh, error)
+	CreateSearchContextRefresh(ctx context.Context, refresh *types.SearchContextRefresh) (*types.SearchContextRefresh, error)
+	ListSearchContextRefreshes(ctx context.Context, searchContextID int64, limit int) ([]*types.SearchContextRefresh, error)
 }
 
 type searchContextsStore struct {
@@ -91,6 +96,9 @@ const searchContextQueryFmtStr = `
 		NULL as namespace_org_id,
 		TIMESTAMP WITH TIME ZONE 'epoch' as updated_at, -- Timestamp is not used for global context, but we need to return something.
 		NULL as query,
+		NULL as refresh_interval_hours,
+		'[]'::jsonb as revision_rules,
+		NULL as last_refreshed_at,
 		NULL as namespace_name,
 		NULL as namespace_username,
 		NULL as namespace_org_name,
@@ -107,6 +115,9 @@ const searchContextQueryFmtStr = `
 		sc.namespace_org_id as namespace_org_id,
 		sc.updated_at as updated_at,
 		sc.query as query,
+		sc.refresh_interval_hours as refresh_interval_hours,
+		sc.revision_rules as revision_rules,
+		sc.last_refreshed_at as last_refreshed_at,
 		COALESCE(u.username, o.name) as namespace_name,
 		u.username as namespace_username,
 		o.name as namespace_org_name,
@@ -132,6 +143,9 @@ SELECT
 	namespace_org_id,
 	updated_at,
 	query,
+	refresh_interval_hours,
+	revision_rules,
+	last_refreshed_at,
 	namespace_username,
 	namespace_org_name,
 	user_default,
@@ -355,8 +369,8 @@ func (s *searchContextsStore) DeleteSearchContext(ctx context.Context, searchCon
 
 const insertSearchContextFmtStr = `
 INSERT INTO search_contexts
-(name, description, public, namespace_user_id, namespace_org_id, query)
-VALUES (%s, %s, %s, %s, %s, %s)
+(name, description, public, namespace_user_id, namespace_org_id, query, refresh_interval_hours, revision_rules)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
 `
 
 // 🚨 SECURITY: The caller must ensure that the actor is a site admin or has permission to create the search context.
@@ -386,6 +400,10 @@ SET
 	description = %s,
 	public = %s,
 	query = %s,
+	refresh_interval_hours = %s,
+	revision_rules = %s,
+	-- Materialized repositories are replaced below, so the context has to be refreshed again.
+	last_refreshed_at = NULL,
 	updated_at = now()
 WHERE id = %d
 `
@@ -451,6 +469,8 @@ func createSearchContext(ctx context.Context, s SearchContextsStore, searchConte
 		dbutil.NullInt32Column(searchContext.NamespaceUserID),
 		dbutil.NullInt32Column(searchContext.NamespaceOrgID),
 		dbutil.NullStringColumn(searchContext.Query),
+		dbutil.NullInt32Column(searchContext.RefreshIntervalHours),
+		revisionRulesColumn(searchContext.RevisionRules),
 	)
 	_, err := s.Handle().ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
 	if err != nil {
@@ -470,6 +490,8 @@ func updateSearchContext(ctx context.Context, s SearchContextsStore, searchConte
 		searchContext.Description,
 		searchContext.Public,
 		dbutil.NullStringColumn(searchContext.Query),
+		dbutil.NullInt32Column(searchContext.RefreshIntervalHours),
+		revisionRulesColumn(searchContext.RevisionRules),
 		searchContext.ID,
 	)
 	_, err := s.Handle().ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
@@ -483,6 +505,13 @@ func updateSearchContext(ctx context.Context, s SearchContextsStore, searchConte
 	})
 }
 
+func revisionRulesColumn(rules []types.SearchContextRevisionRule) driver.Valuer {
+	if rules == nil {
+		rules = []types.SearchContextRevisionRule{}
+	}
+	return dbutil.JSONMessage(&rules)
+}
+
 func scanSingleSearchContext(rows *sql.Rows) (*types.SearchContext, error) {
 	searchContexts, err := scanSearchContexts(rows)
 	if err != nil {
@@ -508,6 +537,9 @@ func scanSearchContexts(rows *sql.Rows) ([]*types.SearchContext, error) {
 			&dbutil.NullInt32{N: &sc.NamespaceOrgID},
 			&sc.UpdatedAt,
 			&dbutil.NullString{S: &sc.Query},
+			&dbutil.NullInt32{N: &sc.RefreshIntervalHours},
+			dbutil.JSONMessage(&sc.RevisionRules),
+			&sc.LastRefreshedAt,
 			&dbutil.NullString{S: &sc.NamespaceUserName},
 			&dbutil.NullString{S: &sc.NamespaceOrgName},
 			&sc.Default,
@@ -19,7 +19,7 @@ import (
 const SubRepoPermsVersion = 1
 
 var (
-	SubRepoSupportedCodeHostTypes = []string{extsvc.TypePerforce}
+	SubRepoSupportedCodeHostTypes = []string{extsvc.TypePerforce, extsvc.TypeGerrit}
 	supportedTypesQuery           = make([]*sqlf.Query, len(SubRepoSupportedCodeHostTypes))
 )
 
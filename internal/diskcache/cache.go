@@ -52,6 +52,10 @@ type store struct {
 	// which can be used to attach fields to a Honeycomb event.
 	beforeEvict func(string, observation.TraceLogger)
 
+	// sidecarExts are the extensions of files written next to cache entries
+	// by the caller, see WithSidecarExt.
+	sidecarExts []string
+
 	observe *operations
 }
 
@@ -88,6 +92,14 @@ func WithBeforeEvict(f func(string, observation.TraceLogger)) func(*store) {
 	return func(s *store) { s.beforeEvict = f }
 }
 
+// WithSidecarExt declares that the caller writes files derived from cache
+// entries next to them, named like the entry with its ".zip" extension
+// replaced by ext. Sidecars count towards the cache size and are evicted along
+// with their entry, or on their own if their entry no longer exists.
+func WithSidecarExt(ext string) func(*store) {
+	return func(s *store) { s.sidecarExts = append(s.sidecarExts, ext) }
+}
+
 func WithobservationCtx(ctx *observation.Context) func(*store) {
 	return func(s *store) { s.observe = newOperations(ctx, s.component) }
 }
@@ -338,6 +350,21 @@ func (s *store) Evict(maxCacheSizeBytes int64) (stats EvictStats, err error) {
 	}
 	stats.CacheSize = size
 
+	// Sidecars are evicted with their entry. Sidecars whose entry is already
+	// gone, e.g. because the entry was evicted while the sidecar was being
+	// written, are evicted first.
+	sizes := make(map[string]int64, len(entries))
+	for _, entry := range entries {
+		sizes[entry.absPath] = entry.info.Size()
+	}
+	for _, entry := range entries {
+		if base, ok := s.sidecarBase(entry.absPath); ok {
+			if _, ok := sizes[base+".zip"]; !ok {
+				size -= s.removeSidecar(entry.absPath, entry.info.Size(), trace)
+			}
+		}
+	}
+
 	// Nothing to evict
 	if size <= maxCacheSizeBytes {
 		return stats, nil
@@ -367,6 +394,13 @@ func (s *store) Evict(maxCacheSizeBytes int64) (stats EvictStats, err error) {
 		}
 		stats.Evicted++
 		size -= entry.info.Size()
+
+		for _, ext := range s.sidecarExts {
+			sidecar := strings.TrimSuffix(path, ".zip") + ext
+			if sidecarSize, ok := sizes[sidecar]; ok {
+				size -= s.removeSidecar(sidecar, sidecarSize, trace)
+			}
+		}
 	}
 
 	trace.SetAttributes(
@@ -378,6 +412,27 @@ func (s *store) Evict(maxCacheSizeBytes int64) (stats EvictStats, err error) {
 	return stats, nil
 }
 
+// sidecarBase returns path without its extension if it is a sidecar.
+func (s *store) sidecarBase(path string) (string, bool) {
+	for _, ext := range s.sidecarExts {
+		if strings.HasSuffix(path, ext) {
+			return strings.TrimSuffix(path, ext), true
+		}
+	}
+	return "", false
+}
+
+// removeSidecar removes the sidecar at path and returns the number of bytes
+// freed. A sidecar which is already gone counts as freed.
+func (s *store) removeSidecar(path string, size int64, trace observation.TraceLogger) int64 {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		trace.AddEvent("failed to remove disk cache sidecar", attribute.String("path", path), internaltrace.Error(err))
+		log.Printf("failed to remove %s: %s", path, err)
+		return 0
+	}
+	return size
+}
+
 func copyAndClose(dst io.WriteCloser, src io.ReadCloser) error {
 	_, err := io.Copy(dst, src)
 	if err1 := src.Close(); err == nil {
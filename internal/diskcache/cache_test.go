@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -155,3 +156,80 @@ func TestEvict(t *testing.T) {
 	// disckcache.
 	expect(0, 1, 3)
 }
+
+func TestEvictSidecars(t *testing.T) {
+	dir := t.TempDir()
+
+	store := &store{
+		dir:         dir,
+		component:   "test",
+		sidecarExts: []string{".idx"},
+		observe:     newOperations(observation.TestContextTB(t), "test"),
+	}
+
+	var paths []string
+	for i, name := range []string{"key-first", "key-second"} {
+		f, err := store.Open(context.Background(), []string{name}, func(ctx context.Context) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader([]byte("x"))), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		// Make sure key-first is evicted first.
+		mtime := time.Now().Add(time.Duration(i-2) * time.Hour)
+		if err := os.Chtimes(f.Path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, f.Path)
+	}
+
+	sidecar := strings.TrimSuffix(paths[0], ".zip") + ".idx"
+	orphan := filepath.Join(dir, "orphan.idx")
+	if err := os.WriteFile(sidecar, []byte("xx"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(orphan, []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	exists := func(path string) bool {
+		t.Helper()
+		_, err := os.Stat(path)
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatal(err)
+		}
+		return err == nil
+	}
+
+	// The orphaned sidecar is removed even if the cache is small enough.
+	stats, err := store.Evict(10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.CacheSize != 5 || stats.Evicted != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if exists(orphan) {
+		t.Fatal("expected orphaned sidecar to be removed")
+	}
+	if !exists(sidecar) {
+		t.Fatal("expected sidecar to be kept")
+	}
+
+	// Evicting key-first frees the size of its sidecar, too, so key-second
+	// is kept.
+	stats, err = store.Evict(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.CacheSize != 4 || stats.Evicted != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if exists(paths[0]) || exists(sidecar) {
+		t.Fatal("expected key-first and its sidecar to be removed")
+	}
+	if !exists(paths[1]) {
+		t.Fatal("expected key-second to be kept")
+	}
+}
@@ -15,7 +15,8 @@ type CodeHost struct {
 
 func (c *CodeHost) IsPackageHost() bool {
 	switch c.ServiceType {
-	case TypeNpmPackages, TypeJVMPackages, TypeGoModules, TypePythonPackages, TypeRustPackages, TypeRubyPackages:
+	case TypeNpmPackages, TypeJVMPackages, TypeGoModules, TypePythonPackages, TypeRustPackages, TypeRubyPackages,
+		TypeDotNetPackages, TypePHPPackages, TypeElixirPackages:
 		return true
 	}
 	return false
@@ -49,6 +50,15 @@ var (
 	RubyURL      = &url.URL{Host: "rubygems"}
 	RubyPackages = NewCodeHost(RubyURL, TypeRubyPackages)
 
+	DotNetURL      = &url.URL{Host: "nuget"}
+	DotNetPackages = NewCodeHost(DotNetURL, TypeDotNetPackages)
+
+	PHPURL      = &url.URL{Host: "packagist"}
+	PHPPackages = NewCodeHost(PHPURL, TypePHPPackages)
+
+	ElixirURL      = &url.URL{Host: "hex"}
+	ElixirPackages = NewCodeHost(ElixirURL, TypeElixirPackages)
+
 	PublicCodeHosts = []*CodeHost{
 		GitHubDotCom,
 		GitLabDotCom,
@@ -58,6 +68,9 @@ var (
 		PythonPackages,
 		RustPackages,
 		RubyPackages,
+		DotNetPackages,
+		PHPPackages,
+		ElixirPackages,
 	}
 )
 
@@ -41,7 +41,9 @@ type Client interface {
 	Authenticator() auth.Authenticator
 	GetAuthenticatedUserAccount(ctx context.Context) (*Account, error)
 	GetGroup(ctx context.Context, groupName string) (Group, error)
+	ListAccountGroups(ctx context.Context) ([]Group, error)
 	ListProjects(ctx context.Context, opts ListProjectsArgs) (projects ListProjectsResponse, nextPage bool, err error)
+	GetProjectAccess(ctx context.Context, projectName string) (*ProjectAccessInfo, error)
 	GetChange(ctx context.Context, changeID string) (*Change, error)
 	AbandonChange(ctx context.Context, changeID string) (*Change, error)
 	DeleteChange(ctx context.Context, changeID string) error
@@ -166,6 +168,20 @@ func (c *client) GetGroup(ctx context.Context, groupName string) (Group, error)
 	return respGetGroup, nil
 }
 
+// ListAccountGroups returns the groups the authenticated user is a member of.
+func (c *client) ListAccountGroups(ctx context.Context) ([]Group, error) {
+	req, err := http.NewRequest("GET", "a/accounts/self/groups", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []Group
+	if _, err = c.do(ctx, req, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
 func (c *client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) { //nolint:unparam // http.Response is never used, but it makes sense API wise.
 	resp, err := c.doHTTP(ctx, req)
 	if err != nil {
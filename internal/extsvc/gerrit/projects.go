@@ -87,3 +87,18 @@ func (c *client) ListProjects(ctx context.Context, opts ListProjectsArgs) (proje
 
 	return c.listAllProjects(ctx, opts.Cursor)
 }
+
+// GetProjectAccess fetches the access rights configured locally on a project.
+func (c *client) GetProjectAccess(ctx context.Context, projectName string) (*ProjectAccessInfo, error) {
+	// Project names may contain slashes, which have to stay escaped in the path.
+	req, err := http.NewRequest("GET", "a/projects/"+url.PathEscape(projectName)+"/access", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var access ProjectAccessInfo
+	if _, err = c.do(ctx, req, &access); err != nil {
+		return nil, err
+	}
+	return &access, nil
+}
@@ -156,6 +156,48 @@ type Project struct {
 	SSHURLToRepo string `json:"ssh_url_to_repo"`
 }
 
+// ProjectAccessInfo is the access configuration of a project, as returned by
+// the project access endpoint.
+type ProjectAccessInfo struct {
+	Revision     string                       `json:"revision"`
+	InheritsFrom *Project                     `json:"inherits_from,omitempty"`
+	Local        map[string]AccessSectionInfo `json:"local"`
+	IsOwner      bool                         `json:"is_owner"`
+}
+
+// AccessSectionInfo describes the access rights that are assigned on a ref
+// pattern.
+type AccessSectionInfo struct {
+	Permissions map[string]PermissionInfo `json:"permissions"`
+}
+
+// PermissionInfo describes a single access right, e.g. "read", and the rules
+// assigned to groups for it.
+type PermissionInfo struct {
+	Label     string                        `json:"label,omitempty"`
+	Exclusive bool                          `json:"exclusive,omitempty"`
+	Rules     map[string]PermissionRuleInfo `json:"rules"`
+}
+
+// PermissionRuleInfo is the rule of a permission for a single group.
+type PermissionRuleInfo struct {
+	Action PermissionRuleAction `json:"action"`
+	Force  bool                 `json:"force,omitempty"`
+}
+
+type PermissionRuleAction string
+
+var (
+	PermissionRuleActionAllow       PermissionRuleAction = "ALLOW"
+	PermissionRuleActionDeny        PermissionRuleAction = "DENY"
+	PermissionRuleActionBlock       PermissionRuleAction = "BLOCK"
+	PermissionRuleActionInteractive PermissionRuleAction = "INTERACTIVE"
+	PermissionRuleActionBatch       PermissionRuleAction = "BATCH"
+)
+
+// PermissionRead is the name of the access right granting read access to refs.
+const PermissionRead = "read"
+
 type Label struct {
 	Values       map[string]string `json:"values"`
 	DefaultValue string            `json:"default_value"`
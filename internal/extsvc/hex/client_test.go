@@ -0,0 +1,46 @@
+package hex
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+)
+
+func TestGetPackageContents(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tarballs/phoenix-1.7.10.tar", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "tar")
+	})
+	mux.HandleFunc("/tarballs/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, err := NewClient("hex_urn", srv.URL, httpcli.NewFactory(nil))
+	require.NoError(t, err)
+	client.limiter = ratelimit.NewInstrumentedLimiter("hex", rate.NewLimiter(100, 10))
+
+	body, err := client.GetPackageContents(ctx, reposource.ParseElixirVersionedPackage("phoenix@1.7.10"))
+	require.NoError(t, err)
+	defer body.Close()
+	contents, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, "tar", string(contents))
+
+	_, err = client.GetPackageContents(ctx, reposource.ParseElixirVersionedPackage("phoenix@0.0.0"))
+	var e *Error
+	require.ErrorAs(t, err, &e)
+	require.True(t, e.NotFound())
+}
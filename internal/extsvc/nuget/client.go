@@ -0,0 +1,171 @@
+// Package nuget implements a client for NuGet V3 package feeds.
+package nuget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// packageBaseAddressType is the service index resource type of the endpoint
+// that serves package contents.
+const packageBaseAddressType = "PackageBaseAddress/3.0.0"
+
+type Client struct {
+	// serviceIndexURL is the URL of the service index of the feed, e.g.
+	// https://api.nuget.org/v3/index.json.
+	serviceIndexURL string
+
+	uncachedClient httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+
+	baseAddressMu sync.Mutex
+	baseAddress   string
+}
+
+func NewClient(urn string, serviceIndexURL string, httpfactory *httpcli.Factory) (*Client, error) {
+	uncached, err := httpfactory.Doer(httpcli.NewCachedTransportOpt(httpcli.NoopCache{}, false))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		serviceIndexURL: serviceIndexURL,
+		uncachedClient:  uncached,
+		limiter:         ratelimit.NewInstrumentedLimiter(urn, ratelimit.NewGlobalRateLimiter(log.Scoped("NuGetClient"), urn)),
+	}, nil
+}
+
+// Versions returns all versions of the package published to the feed, in
+// their normalized form.
+func (c *Client) Versions(ctx context.Context, name reposource.PackageName) ([]string, error) {
+	base, err := c.packageBaseAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.get(ctx, fmt.Sprintf("%s/%s/index.json", base, strings.ToLower(string(name))))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var index struct {
+		Versions []string `json:"versions"`
+	}
+	if err := json.NewDecoder(body).Decode(&index); err != nil {
+		return nil, errors.Wrap(err, "decoding package versions")
+	}
+	return index.Versions, nil
+}
+
+// GetPackageContents returns the .nupkg archive of the given package version.
+// Package IDs and versions are case-insensitive in NuGet, the feed expects
+// them lowercased.
+func (c *Client) GetPackageContents(ctx context.Context, dep reposource.VersionedPackage) (io.ReadCloser, error) {
+	base, err := c.packageBaseAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id := strings.ToLower(string(dep.PackageSyntax()))
+	version := strings.ToLower(dep.PackageVersion())
+	return c.get(ctx, fmt.Sprintf("%s/%s/%s/%s.%s.nupkg", base, id, version, id, version))
+}
+
+// packageBaseAddress resolves the PackageBaseAddress resource from the service
+// index of the feed. It is only resolved until the first success.
+func (c *Client) packageBaseAddress(ctx context.Context) (string, error) {
+	c.baseAddressMu.Lock()
+	defer c.baseAddressMu.Unlock()
+
+	if c.baseAddress != "" {
+		return c.baseAddress, nil
+	}
+	base, err := c.fetchPackageBaseAddress(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.baseAddress = base
+	return base, nil
+}
+
+func (c *Client) fetchPackageBaseAddress(ctx context.Context) (string, error) {
+	body, err := c.get(ctx, c.serviceIndexURL)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	var index struct {
+		Resources []struct {
+			ID   string `json:"@id"`
+			Type string `json:"@type"`
+		} `json:"resources"`
+	}
+	if err := json.NewDecoder(body).Decode(&index); err != nil {
+		return "", errors.Wrap(err, "decoding service index")
+	}
+	for _, r := range index.Resources {
+		if r.Type == packageBaseAddressType {
+			return strings.TrimSuffix(r.ID, "/"), nil
+		}
+	}
+	return "", errors.Newf("service index %s has no %s resource", c.serviceIndexURL, packageBaseAddressType)
+}
+
+func (c *Client) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-nuget-syncer (sourcegraph.com)")
+
+	return c.do(c.uncachedClient, req)
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound
+}
+
+func (c *Client) do(doer httpcli.Doer, req *http.Request) (io.ReadCloser, error) {
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		bs, err := io.ReadAll(resp.Body)
+		if err != nil {
+			bs = []byte(errors.Wrap(err, "failed to read body").Error())
+		}
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+	return resp.Body, nil
+}
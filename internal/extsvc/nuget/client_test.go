@@ -0,0 +1,66 @@
+package nuget
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+)
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	mux.HandleFunc("/v3/index.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"version": "3.0.0", "resources": [
+  {"@id": "`+srv.URL+`/v3/registration/", "@type": "RegistrationsBaseUrl"},
+  {"@id": "`+srv.URL+`/v3-flatcontainer/", "@type": "PackageBaseAddress/3.0.0"}
+]}`)
+	})
+	mux.HandleFunc("/v3-flatcontainer/newtonsoft.json/index.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"versions": ["12.0.1", "13.0.3"]}`)
+	})
+	mux.HandleFunc("/v3-flatcontainer/newtonsoft.json/13.0.3/newtonsoft.json.13.0.3.nupkg", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "nupkg")
+	})
+
+	client, err := NewClient("nuget_urn", srv.URL+"/v3/index.json", httpcli.NewFactory(nil))
+	require.NoError(t, err)
+	client.limiter = ratelimit.NewInstrumentedLimiter("nuget", rate.NewLimiter(100, 10))
+	return client
+}
+
+func TestVersions(t *testing.T) {
+	client := newTestClient(t)
+	versions, err := client.Versions(context.Background(), "Newtonsoft.Json")
+	require.NoError(t, err)
+	require.Equal(t, []string{"12.0.1", "13.0.3"}, versions)
+}
+
+func TestGetPackageContents(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	body, err := client.GetPackageContents(ctx, reposource.ParseDotNetVersionedPackage("Newtonsoft.Json@13.0.3"))
+	require.NoError(t, err)
+	defer body.Close()
+	contents, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, "nupkg", string(contents))
+
+	_, err = client.GetPackageContents(ctx, reposource.ParseDotNetVersionedPackage("Newtonsoft.Json@1.0.0"))
+	var e *Error
+	require.ErrorAs(t, err, &e)
+	require.True(t, e.NotFound())
+}
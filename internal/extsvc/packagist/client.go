@@ -0,0 +1,188 @@
+// Package packagist implements a client for Composer repositories such as
+// Packagist.
+package packagist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type Client struct {
+	repositoryURL string
+
+	uncachedClient httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+}
+
+func NewClient(urn string, repositoryURL string, httpfactory *httpcli.Factory) (*Client, error) {
+	uncached, err := httpfactory.Doer(httpcli.NewCachedTransportOpt(httpcli.NoopCache{}, false))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		repositoryURL:  strings.TrimSuffix(repositoryURL, "/"),
+		uncachedClient: uncached,
+		limiter:        ratelimit.NewInstrumentedLimiter(urn, ratelimit.NewGlobalRateLimiter(log.Scoped("PackagistClient"), urn)),
+	}, nil
+}
+
+// Version is a release of a Composer package.
+type Version struct {
+	Version           string `json:"version"`
+	VersionNormalized string `json:"version_normalized"`
+	Dist              *Dist  `json:"dist"`
+}
+
+// Dist describes the distribution archive of a Version.
+type Dist struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Versions returns the tagged releases of the package. Dev versions are not
+// included.
+func (c *Client) Versions(ctx context.Context, name reposource.PackageName) ([]*Version, error) {
+	body, err := c.get(ctx, fmt.Sprintf("%s/p2/%s.json", c.repositoryURL, name))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var resp struct {
+		Packages map[string][]map[string]json.RawMessage `json:"packages"`
+		Minified string                                  `json:"minified"`
+	}
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, errors.Wrap(err, "decoding package metadata")
+	}
+
+	entries := resp.Packages[string(name)]
+	if resp.Minified == "composer/2.0" {
+		entries = expandMinified(entries)
+	}
+
+	versions := make([]*Version, 0, len(entries))
+	for _, entry := range entries {
+		bs, err := json.Marshal(entry)
+		if err != nil {
+			return nil, err
+		}
+		var v Version
+		if err := json.Unmarshal(bs, &v); err != nil {
+			return nil, errors.Wrapf(err, "decoding version of %s", name)
+		}
+		versions = append(versions, &v)
+	}
+	return versions, nil
+}
+
+// expandMinified expands the composer/2.0 minified format, where every entry
+// only lists the keys that changed compared to the previous one and removed
+// keys are set to "__unset".
+func expandMinified(entries []map[string]json.RawMessage) []map[string]json.RawMessage {
+	expanded := make([]map[string]json.RawMessage, 0, len(entries))
+	var prev map[string]json.RawMessage
+	for _, entry := range entries {
+		cur := make(map[string]json.RawMessage, len(prev)+len(entry))
+		for k, v := range prev {
+			cur[k] = v
+		}
+		for k, v := range entry {
+			if string(v) == `"__unset"` {
+				delete(cur, k)
+				continue
+			}
+			cur[k] = v
+		}
+		expanded = append(expanded, cur)
+		prev = cur
+	}
+	return expanded
+}
+
+// GetPackageContents returns the zip archive of the given package version.
+// The version is matched with and without a "v" prefix, as Composer accepts
+// both.
+func (c *Client) GetPackageContents(ctx context.Context, dep reposource.VersionedPackage) (io.ReadCloser, error) {
+	versions, err := c.Versions(ctx, dep.PackageSyntax())
+	if err != nil {
+		return nil, err
+	}
+
+	want := strings.TrimPrefix(dep.PackageVersion(), "v")
+	var match *Version
+	for _, v := range versions {
+		if strings.TrimPrefix(v.Version, "v") == want || v.VersionNormalized == want {
+			match = v
+			break
+		}
+	}
+	if match == nil {
+		return nil, &Error{path: string(dep.PackageSyntax()), code: http.StatusNotFound, message: fmt.Sprintf("version %q not found", dep.PackageVersion())}
+	}
+	if match.Dist == nil || match.Dist.URL == "" {
+		return nil, errors.Newf("version %q of %s has no dist archive", match.Version, dep.PackageSyntax())
+	}
+	if match.Dist.Type != "zip" {
+		return nil, errors.Newf("unsupported dist type %q for %s", match.Dist.Type, dep.VersionedPackageSyntax())
+	}
+
+	return c.get(ctx, match.Dist.URL)
+}
+
+func (c *Client) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-packagist-syncer (sourcegraph.com)")
+
+	return c.do(c.uncachedClient, req)
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound
+}
+
+func (c *Client) do(doer httpcli.Doer, req *http.Request) (io.ReadCloser, error) {
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		bs, err := io.ReadAll(resp.Body)
+		if err != nil {
+			bs = []byte(errors.Wrap(err, "failed to read body").Error())
+		}
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+	return resp.Body, nil
+}
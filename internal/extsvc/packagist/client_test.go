@@ -0,0 +1,81 @@
+package packagist
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+)
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	mux.HandleFunc("/p2/monolog/monolog.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"minified": "composer/2.0", "packages": {"monolog/monolog": [
+  {"name": "monolog/monolog", "version": "3.5.0", "version_normalized": "3.5.0.0", "license": ["MIT"],
+   "dist": {"type": "zip", "url": "`+srv.URL+`/dist/3.5.0.zip"}},
+  {"version": "3.4.0", "version_normalized": "3.4.0.0", "license": "__unset",
+   "dist": {"type": "zip", "url": "`+srv.URL+`/dist/3.4.0.zip"}},
+  {"version": "v1.0.0", "version_normalized": "1.0.0.0", "dist": "__unset"}
+]}}`)
+	})
+	mux.HandleFunc("/dist/3.4.0.zip", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "zip")
+	})
+
+	client, err := NewClient("packagist_urn", srv.URL+"/", httpcli.NewFactory(nil))
+	require.NoError(t, err)
+	client.limiter = ratelimit.NewInstrumentedLimiter("packagist", rate.NewLimiter(100, 10))
+	return client
+}
+
+func TestVersions(t *testing.T) {
+	client := newTestClient(t)
+	versions, err := client.Versions(context.Background(), "monolog/monolog")
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+
+	require.Equal(t, "3.4.0", versions[1].Version)
+	require.Equal(t, "3.4.0.0", versions[1].VersionNormalized)
+	require.NotNil(t, versions[1].Dist)
+	require.Equal(t, "v1.0.0", versions[2].Version)
+	require.Nil(t, versions[2].Dist)
+}
+
+func TestGetPackageContents(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	dep, err := reposource.ParsePHPVersionedPackage("monolog/monolog@v3.4.0")
+	require.NoError(t, err)
+	body, err := client.GetPackageContents(ctx, dep)
+	require.NoError(t, err)
+	defer body.Close()
+	contents, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, "zip", string(contents))
+
+	dep, err = reposource.ParsePHPVersionedPackage("monolog/monolog@9.9.9")
+	require.NoError(t, err)
+	_, err = client.GetPackageContents(ctx, dep)
+	var e *Error
+	require.ErrorAs(t, err, &e)
+	require.True(t, e.NotFound())
+
+	dep, err = reposource.ParsePHPVersionedPackage("monolog/monolog@1.0.0")
+	require.NoError(t, err)
+	_, err = client.GetPackageContents(ctx, dep)
+	require.Error(t, err)
+}
@@ -207,6 +207,15 @@ const (
 	// VariantRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby packages.
 	VariantRubyPackages
 
+	// VariantDotNetPackages is the (api.ExternalRepoSpec).ServiceType value for .NET packages hosted on NuGet feeds.
+	VariantDotNetPackages
+
+	// VariantPHPPackages is the (api.ExternalRepoSpec).ServiceType value for PHP packages hosted on Composer repositories.
+	VariantPHPPackages
+
+	// VariantElixirPackages is the (api.ExternalRepoSpec).ServiceType value for Elixir and Erlang packages hosted on Hex repositories.
+	VariantElixirPackages
+
 	// VariantOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	VariantOther
 )
@@ -224,6 +233,8 @@ var variantValuesMap = map[Variant]variantValues{
 	VariantAzureDevOps:     {AsKind: "AZUREDEVOPS", AsType: "azuredevops", ConfigPrototype: func() any { return &schema.AzureDevOpsConnection{} }, SupportsRepoExclusion: true},
 	VariantBitbucketCloud:  {AsKind: "BITBUCKETCLOUD", AsType: "bitbucketCloud", ConfigPrototype: func() any { return &schema.BitbucketCloudConnection{} }, WebhookURLPath: "bitbucket-cloud-webhooks", SupportsRepoExclusion: true},
 	VariantBitbucketServer: {AsKind: "BITBUCKETSERVER", AsType: "bitbucketServer", ConfigPrototype: func() any { return &schema.BitbucketServerConnection{} }, WebhookURLPath: "bitbucket-server-webhooks", SupportsRepoExclusion: true},
+	VariantDotNetPackages:  {AsKind: "DOTNETPACKAGES", AsType: "dotnetPackages", ConfigPrototype: func() any { return &schema.DotNetPackagesConnection{} }},
+	VariantElixirPackages:  {AsKind: "ELIXIRPACKAGES", AsType: "elixirPackages", ConfigPrototype: func() any { return &schema.ElixirPackagesConnection{} }},
 	VariantGerrit:          {AsKind: "GERRIT", AsType: "gerrit", ConfigPrototype: func() any { return &schema.GerritConnection{} }, SupportsRepoExclusion: true},
 	VariantGitHub:          {AsKind: "GITHUB", AsType: "github", ConfigPrototype: func() any { return &schema.GitHubConnection{} }, WebhookURLPath: "github-webhooks", SupportsRepoExclusion: true},
 	VariantGitLab:          {AsKind: "GITLAB", AsType: "gitlab", ConfigPrototype: func() any { return &schema.GitLabConnection{} }, WebhookURLPath: "gitlab-webhooks", SupportsRepoExclusion: true},
@@ -235,6 +246,7 @@ var variantValuesMap = map[Variant]variantValues{
 	VariantPagure:          {AsKind: "PAGURE", AsType: "pagure", ConfigPrototype: func() any { return &schema.PagureConnection{} }},
 	VariantPerforce:        {AsKind: "PERFORCE", AsType: "perforce", ConfigPrototype: func() any { return &schema.PerforceConnection{} }},
 	VariantPhabricator:     {AsKind: "PHABRICATOR", AsType: "phabricator", ConfigPrototype: func() any { return &schema.PhabricatorConnection{} }},
+	VariantPHPPackages:     {AsKind: "PHPPACKAGES", AsType: "phpPackages", ConfigPrototype: func() any { return &schema.PHPPackagesConnection{} }},
 	VariantPythonPackages:  {AsKind: "PYTHONPACKAGES", AsType: "pythonPackages", ConfigPrototype: func() any { return &schema.PythonPackagesConnection{} }},
 	VariantRubyPackages:    {AsKind: "RUBYPACKAGES", AsType: "rubyPackages", ConfigPrototype: func() any { return &schema.RubyPackagesConnection{} }},
 	VariantRustPackages:    {AsKind: "RUSTPACKAGES", AsType: "rustPackages", ConfigPrototype: func() any { return &schema.RustPackagesConnection{} }},
@@ -301,6 +313,9 @@ var (
 	KindPythonPackages  = VariantPythonPackages.AsKind()
 	KindRustPackages    = VariantRustPackages.AsKind()
 	KindRubyPackages    = VariantRubyPackages.AsKind()
+	KindDotNetPackages  = VariantDotNetPackages.AsKind()
+	KindPHPPackages     = VariantPHPPackages.AsKind()
+	KindElixirPackages  = VariantElixirPackages.AsKind()
 	KindNpmPackages     = VariantNpmPackages.AsKind()
 	KindPagure          = VariantPagure.AsKind()
 	KindAzureDevOps     = VariantAzureDevOps.AsKind()
@@ -369,6 +384,15 @@ var (
 	// TypeRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby packages.
 	TypeRubyPackages = VariantRubyPackages.AsType()
 
+	// TypeDotNetPackages is the (api.ExternalRepoSpec).ServiceType value for .NET packages hosted on NuGet feeds.
+	TypeDotNetPackages = VariantDotNetPackages.AsType()
+
+	// TypePHPPackages is the (api.ExternalRepoSpec).ServiceType value for PHP packages hosted on Composer repositories.
+	TypePHPPackages = VariantPHPPackages.AsType()
+
+	// TypeElixirPackages is the (api.ExternalRepoSpec).ServiceType value for Elixir and Erlang packages hosted on Hex repositories.
+	TypeElixirPackages = VariantElixirPackages.AsType()
+
 	// TypeOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	TypeOther = VariantOther.AsType()
 )
@@ -668,6 +692,24 @@ func GetLimitFromConfig(config any, kind string) (limit rate.Limit, isDefault bo
 			isDefault = false
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.DotNetPackagesConnection:
+		limit = GetDefaultRateLimit(KindDotNetPackages)
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
+	case *schema.PHPPackagesConnection:
+		limit = GetDefaultRateLimit(KindPHPPackages)
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
+	case *schema.ElixirPackagesConnection:
+		limit = GetDefaultRateLimit(KindElixirPackages)
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.AzureDevOpsConnection:
 		limit = GetDefaultRateLimit(KindAzureDevOps)
 		if c != nil && c.RateLimit != nil {
@@ -718,6 +760,17 @@ func GetDefaultRateLimit(kind string) rate.Limit {
 	case KindRubyPackages:
 		// The rubygems.org API allows 10 rps https://guides.rubygems.org/rubygems-org-rate-limits/
 		return rate.Limit(10)
+	case KindDotNetPackages:
+		// Unlike the GitHub or GitLab APIs, nuget.org doesn't document an enforced
+		// req/s rate limit for the package content API, which is served from a CDN.
+		return rate.Limit(50)
+	case KindPHPPackages:
+		// repo.packagist.org metadata is served from a CDN. Archives are mostly
+		// downloaded from GitHub, which applies its own rate limits.
+		return rate.Limit(10)
+	case KindElixirPackages:
+		// repo.hex.pm is served from a CDN and doesn't document a rate limit.
+		return rate.Limit(100)
 	case KindAzureDevOps:
 		return rate.Inf
 	default:
@@ -847,6 +900,12 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		return VariantRustPackages.AsKind(), nil
 	case *schema.RubyPackagesConnection:
 		return VariantRubyPackages.AsKind(), nil
+	case *schema.DotNetPackagesConnection:
+		return KindDotNetPackages, nil
+	case *schema.PHPPackagesConnection:
+		return KindPHPPackages, nil
+	case *schema.ElixirPackagesConnection:
+		return KindElixirPackages, nil
 	case *schema.PagureConnection:
 		rawURL = c.Url
 	default:
@@ -34,6 +34,12 @@ func TestVariantConfigPrototypePointers(t *testing.T) {
 	if y, ok := VariantBitbucketServer.ConfigPrototype().(*schema.BitbucketServerConnection); !ok {
 		t.Errorf("wrong type for Bitbucket Server configuration prototype: %T", y)
 	}
+	if y, ok := VariantDotNetPackages.ConfigPrototype().(*schema.DotNetPackagesConnection); !ok {
+		t.Errorf("wrong type for .NET Packages configuration prototype: %T", y)
+	}
+	if y, ok := VariantElixirPackages.ConfigPrototype().(*schema.ElixirPackagesConnection); !ok {
+		t.Errorf("wrong type for Elixir Packages configuration prototype: %T", y)
+	}
 	if y, ok := VariantGerrit.ConfigPrototype().(*schema.GerritConnection); !ok {
 		t.Errorf("wrong type for Gerrit configuration prototype: %T", y)
 	}
@@ -67,6 +73,9 @@ func TestVariantConfigPrototypePointers(t *testing.T) {
 	if y, ok := VariantPhabricator.ConfigPrototype().(*schema.PhabricatorConnection); !ok {
 		t.Errorf("wrong type for Phabricator configuration prototype: %T", y)
 	}
+	if y, ok := VariantPHPPackages.ConfigPrototype().(*schema.PHPPackagesConnection); !ok {
+		t.Errorf("wrong type for PHP Packages configuration prototype: %T", y)
+	}
 	if y, ok := VariantPythonPackages.ConfigPrototype().(*schema.PythonPackagesConnection); !ok {
 		t.Errorf("wrong type for Python Packages configuration prototype: %T", y)
 	}
@@ -417,6 +417,12 @@ type Client interface {
 	// response.
 	Search(_ context.Context, _ *protocol.SearchRequest, onMatches func([]protocol.CommitMatch)) (limitHit bool, _ error)
 
+	// CommitHistogram executes a search as specified by args, but instead of
+	// returning the matching commits it returns the number of matching
+	// commits per time bucket as configured by args.Histogram. The counting
+	// happens on gitserver, so the matching commits are never transferred.
+	CommitHistogram(_ context.Context, args *protocol.SearchRequest) (*protocol.CommitHistogram, error)
+
 	// Stat returns a FileInfo describing the named file at commit.
 	Stat(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) (fs.FileInfo, error)
 
@@ -628,6 +634,58 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 	}
 }
 
+func (c *clientImplementor) CommitHistogram(ctx context.Context, args *protocol.SearchRequest) (_ *protocol.CommitHistogram, err error) {
+	if args.Histogram == nil {
+		return nil, errors.New("histogram options must be set")
+	}
+
+	ctx, _, endObservation := c.operations.commitHistogram.With(ctx, &err, observation.Args{
+		MetricLabelValues: []string{c.scope},
+		Attrs: []attribute.KeyValue{
+			args.Repo.Attr(),
+			attribute.Stringer("query", args.Query),
+			attribute.Stringer("interval", args.Histogram.Interval),
+			attribute.Stringer("groupBy", args.Histogram.GroupBy),
+		},
+	})
+	defer endObservation(1, observation.Args{})
+
+	client, err := c.clientSource.ClientForRepo(ctx, args.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := client.Search(ctx, args.ToProto())
+	if err != nil {
+		return nil, err
+	}
+
+	var histogram *protocol.CommitHistogram
+	for {
+		msg, err := cs.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		switch m := msg.Message.(type) {
+		case *proto.SearchResponse_LimitHit:
+			// Histograms count all matching commits, so there is no limit.
+		case *proto.SearchResponse_Histogram:
+			histogram = protocol.CommitHistogramFromProto(m.Histogram)
+		default:
+			return nil, errors.Newf("unexpected message type %T", m)
+		}
+	}
+
+	if histogram == nil {
+		return nil, errors.New("gitserver did not send a histogram")
+	}
+	return histogram, nil
+}
+
 // MockIsRepoCloneable mocks (*Client).IsRepoCloneable for tests.
 var MockIsRepoCloneable func(api.RepoName) error
 
@@ -39,6 +39,9 @@ type MockClient struct {
 	// CheckPerforceCredentialsFunc is an instance of a mock function object
 	// controlling the behavior of the method CheckPerforceCredentials.
 	CheckPerforceCredentialsFunc *ClientCheckPerforceCredentialsFunc
+	// CommitHistogramFunc is an instance of a mock function object
+	// controlling the behavior of the method CommitHistogram.
+	CommitHistogramFunc *ClientCommitHistogramFunc
 	// CommitsFunc is an instance of a mock function object controlling the
 	// behavior of the method Commits.
 	CommitsFunc *ClientCommitsFunc
@@ -163,6 +166,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		CommitHistogramFunc: &ClientCommitHistogramFunc{
+			defaultHook: func(context.Context, *protocol.SearchRequest) (r0 *protocol.CommitHistogram, r1 error) {
+				return
+			},
+		},
 		CommitsFunc: &ClientCommitsFunc{
 			defaultHook: func(context.Context, api.RepoName, CommitsOptions) (r0 []*gitdomain.Commit, r1 error) {
 				return
@@ -350,6 +358,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.CheckPerforceCredentials")
 			},
 		},
+		CommitHistogramFunc: &ClientCommitHistogramFunc{
+			defaultHook: func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error) {
+				panic("unexpected invocation of MockClient.CommitHistogram")
+			},
+		},
 		CommitsFunc: &ClientCommitsFunc{
 			defaultHook: func(context.Context, api.RepoName, CommitsOptions) ([]*gitdomain.Commit, error) {
 				panic("unexpected invocation of MockClient.Commits")
@@ -527,6 +540,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		CheckPerforceCredentialsFunc: &ClientCheckPerforceCredentialsFunc{
 			defaultHook: i.CheckPerforceCredentials,
 		},
+		CommitHistogramFunc: &ClientCommitHistogramFunc{
+			defaultHook: i.CommitHistogram,
+		},
 		CommitsFunc: &ClientCommitsFunc{
 			defaultHook: i.Commits,
 		},
@@ -1170,6 +1186,114 @@ func (c ClientCheckPerforceCredentialsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ClientCommitHistogramFunc describes the behavior when the CommitHistogram
+// method of the parent MockClient instance is invoked.
+type ClientCommitHistogramFunc struct {
+	defaultHook func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error)
+	hooks       []func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error)
+	history     []ClientCommitHistogramFuncCall
+	mutex       sync.Mutex
+}
+
+// CommitHistogram delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockClient) CommitHistogram(v0 context.Context, v1 *protocol.SearchRequest) (*protocol.CommitHistogram, error) {
+	r0, r1 := m.CommitHistogramFunc.nextHook()(v0, v1)
+	m.CommitHistogramFunc.appendCall(ClientCommitHistogramFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CommitHistogram
+// method of the parent MockClient instance is invoked and the hook queue is
+// empty.
+func (f *ClientCommitHistogramFunc) SetDefaultHook(hook func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitHistogram method of the parent MockClient instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ClientCommitHistogramFunc) PushHook(hook func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientCommitHistogramFunc) SetDefaultReturn(r0 *protocol.CommitHistogram, r1 error) {
+	f.SetDefaultHook(func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientCommitHistogramFunc) PushReturn(r0 *protocol.CommitHistogram, r1 error) {
+	f.PushHook(func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientCommitHistogramFunc) nextHook() func(context.Context, *protocol.SearchRequest) (*protocol.CommitHistogram, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientCommitHistogramFunc) appendCall(r0 ClientCommitHistogramFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientCommitHistogramFuncCall objects
+// describing the invocations of this function.
+func (f *ClientCommitHistogramFunc) History() []ClientCommitHistogramFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientCommitHistogramFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientCommitHistogramFuncCall is an object that describes an invocation
+// of method CommitHistogram on an instance of MockClient.
+type ClientCommitHistogramFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *protocol.SearchRequest
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *protocol.CommitHistogram
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientCommitHistogramFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientCommitHistogramFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientCommitsFunc describes the behavior when the Commits method of the
 // parent MockClient instance is invoked.
 type ClientCommitsFunc struct {
@@ -27,6 +27,7 @@ type operations struct {
 	resolveRevision          *observation.Operation
 	revAtTime                *observation.Operation
 	search                   *observation.Operation
+	commitHistogram          *observation.Operation
 	stat                     *observation.Operation
 	streamBlameFile          *observation.Operation
 	systemsInfo              *observation.Operation
@@ -109,6 +110,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		resolveRevision:          resolveRevisionOperation,
 		revAtTime:                op("RevAtTime"),
 		search:                   op("Search"),
+		commitHistogram:          op("CommitHistogram"),
 		stat:                     op("Stat"),
 		streamBlameFile:          op("StreamBlameFile"),
 		systemsInfo:              op("SystemsInfo"),
@@ -20,6 +20,9 @@ type SearchRequest struct {
 	IncludeDiff          bool
 	Limit                int
 	IncludeModifiedFiles bool
+	// Histogram, if set, makes the search count the matching commits per
+	// time bucket instead of returning them.
+	Histogram *HistogramOptions
 }
 
 func (r *SearchRequest) ToProto() *proto.SearchRequest {
@@ -34,6 +37,7 @@ func (r *SearchRequest) ToProto() *proto.SearchRequest {
 		IncludeDiff:          r.IncludeDiff,
 		Limit:                int64(r.Limit),
 		IncludeModifiedFiles: r.IncludeModifiedFiles,
+		Histogram:            r.Histogram.ToProto(),
 	}
 }
 
@@ -55,6 +59,7 @@ func SearchRequestFromProto(p *proto.SearchRequest) (*SearchRequest, error) {
 		IncludeDiff:          p.GetIncludeDiff(),
 		Limit:                int(p.GetLimit()),
 		IncludeModifiedFiles: p.GetIncludeModifiedFiles(),
+		Histogram:            HistogramOptionsFromProto(p.GetHistogram()),
 	}, nil
 }
 
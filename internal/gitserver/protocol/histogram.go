@@ -0,0 +1,188 @@
+package protocol
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+)
+
+// HistogramInterval is the width of the buckets of a commit histogram.
+type HistogramInterval int
+
+const (
+	HistogramIntervalDay HistogramInterval = iota + 1
+	HistogramIntervalWeek
+	HistogramIntervalMonth
+)
+
+func (i HistogramInterval) String() string {
+	switch i {
+	case HistogramIntervalDay:
+		return "day"
+	case HistogramIntervalWeek:
+		return "week"
+	case HistogramIntervalMonth:
+		return "month"
+	default:
+		return "unknown"
+	}
+}
+
+// Truncate returns the start of the bucket t falls into. Buckets are aligned
+// in UTC. Weeks start on Monday and months on their first day.
+func (i HistogramInterval) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch i {
+	case HistogramIntervalWeek:
+		// time.Weekday starts on Sunday, shift it so that Monday is 0.
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case HistogramIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// HistogramGroupBy determines how the commits of a histogram are split into
+// series.
+type HistogramGroupBy int
+
+const (
+	// HistogramGroupByRepo counts all commits of a repo in a single series.
+	HistogramGroupByRepo HistogramGroupBy = iota + 1
+	// HistogramGroupByAuthor counts the commits of each author in a series of
+	// its own.
+	HistogramGroupByAuthor
+)
+
+func (g HistogramGroupBy) String() string {
+	switch g {
+	case HistogramGroupByRepo:
+		return "repo"
+	case HistogramGroupByAuthor:
+		return "author"
+	default:
+		return "unknown"
+	}
+}
+
+// HistogramOptions makes a search count the matching commits per time bucket
+// instead of returning them.
+type HistogramOptions struct {
+	Interval HistogramInterval
+	GroupBy  HistogramGroupBy
+}
+
+func (o *HistogramOptions) ToProto() *proto.HistogramOptions {
+	if o == nil {
+		return nil
+	}
+
+	var interval proto.HistogramInterval
+	switch o.Interval {
+	case HistogramIntervalDay:
+		interval = proto.HistogramInterval_HISTOGRAM_INTERVAL_DAY
+	case HistogramIntervalWeek:
+		interval = proto.HistogramInterval_HISTOGRAM_INTERVAL_WEEK
+	case HistogramIntervalMonth:
+		interval = proto.HistogramInterval_HISTOGRAM_INTERVAL_MONTH
+	}
+
+	var groupBy proto.HistogramGroupBy
+	switch o.GroupBy {
+	case HistogramGroupByRepo:
+		groupBy = proto.HistogramGroupBy_HISTOGRAM_GROUP_BY_REPO
+	case HistogramGroupByAuthor:
+		groupBy = proto.HistogramGroupBy_HISTOGRAM_GROUP_BY_AUTHOR
+	}
+
+	return &proto.HistogramOptions{
+		Interval: interval,
+		GroupBy:  groupBy,
+	}
+}
+
+// HistogramOptionsFromProto converts p to HistogramOptions. Unset fields
+// default to weekly buckets grouped by repo.
+func HistogramOptionsFromProto(p *proto.HistogramOptions) *HistogramOptions {
+	if p == nil {
+		return nil
+	}
+
+	interval := HistogramIntervalWeek
+	switch p.GetInterval() {
+	case proto.HistogramInterval_HISTOGRAM_INTERVAL_DAY:
+		interval = HistogramIntervalDay
+	case proto.HistogramInterval_HISTOGRAM_INTERVAL_MONTH:
+		interval = HistogramIntervalMonth
+	}
+
+	groupBy := HistogramGroupByRepo
+	if p.GetGroupBy() == proto.HistogramGroupBy_HISTOGRAM_GROUP_BY_AUTHOR {
+		groupBy = HistogramGroupByAuthor
+	}
+
+	return &HistogramOptions{
+		Interval: interval,
+		GroupBy:  groupBy,
+	}
+}
+
+// CommitHistogram is the number of commits matching a search per time bucket.
+type CommitHistogram struct {
+	Series []HistogramSeries
+}
+
+// HistogramSeries is a series of buckets. Author is only set if the histogram
+// is grouped by author.
+type HistogramSeries struct {
+	Author  Signature `json:",omitempty"`
+	Buckets []HistogramBucket
+}
+
+// HistogramBucket is the number of commits in the bucket starting at Start.
+type HistogramBucket struct {
+	Start time.Time
+	Count int
+}
+
+func (h *CommitHistogram) ToProto() *proto.CommitHistogram {
+	series := make([]*proto.CommitHistogram_Series, 0, len(h.Series))
+	for _, s := range h.Series {
+		buckets := make([]*proto.CommitHistogram_Bucket, 0, len(s.Buckets))
+		for _, b := range s.Buckets {
+			buckets = append(buckets, &proto.CommitHistogram_Bucket{
+				Start: timestamppb.New(b.Start),
+				Count: int64(b.Count),
+			})
+		}
+		series = append(series, &proto.CommitHistogram_Series{
+			AuthorName:  s.Author.Name,
+			AuthorEmail: s.Author.Email,
+			Buckets:     buckets,
+		})
+	}
+	return &proto.CommitHistogram{Series: series}
+}
+
+func CommitHistogramFromProto(p *proto.CommitHistogram) *CommitHistogram {
+	series := make([]HistogramSeries, 0, len(p.GetSeries()))
+	for _, s := range p.GetSeries() {
+		buckets := make([]HistogramBucket, 0, len(s.GetBuckets()))
+		for _, b := range s.GetBuckets() {
+			buckets = append(buckets, HistogramBucket{
+				Start: b.GetStart().AsTime(),
+				Count: int(b.GetCount()),
+			})
+		}
+		series = append(series, HistogramSeries{
+			Author:  Signature{Name: s.GetAuthorName(), Email: s.GetAuthorEmail()},
+			Buckets: buckets,
+		})
+	}
+	return &CommitHistogram{Series: series}
+}
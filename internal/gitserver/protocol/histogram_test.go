@@ -0,0 +1,61 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogramIntervalTruncate(t *testing.T) {
+	// Wednesday
+	ts := time.Date(2023, 3, 15, 17, 4, 5, 0, time.FixedZone("UTC-8", -8*60*60))
+
+	cases := []struct {
+		interval HistogramInterval
+		want     time.Time
+	}{
+		{HistogramIntervalDay, time.Date(2023, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{HistogramIntervalWeek, time.Date(2023, 3, 13, 0, 0, 0, 0, time.UTC)},
+		{HistogramIntervalMonth, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.interval.String(), func(t *testing.T) {
+			require.Equal(t, tc.want, tc.interval.Truncate(ts))
+		})
+	}
+
+	// Sundays belong to the week that started on the Monday before
+	sunday := time.Date(2023, 3, 19, 23, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2023, 3, 13, 0, 0, 0, 0, time.UTC), HistogramIntervalWeek.Truncate(sunday))
+}
+
+func TestHistogramOptionsProtoRoundtrip(t *testing.T) {
+	req := &SearchRequest{
+		Repo:  "test1",
+		Query: &MessageMatches{Expr: "fix"},
+		Histogram: &HistogramOptions{
+			Interval: HistogramIntervalMonth,
+			GroupBy:  HistogramGroupByAuthor,
+		},
+	}
+
+	roundtripped, err := SearchRequestFromProto(req.ToProto())
+	require.NoError(t, err)
+	require.Equal(t, req.Histogram, roundtripped.Histogram)
+
+	// Unset options default to weekly buckets per repo
+	require.Equal(t, &HistogramOptions{Interval: HistogramIntervalWeek, GroupBy: HistogramGroupByRepo}, HistogramOptionsFromProto((&HistogramOptions{}).ToProto()))
+}
+
+func TestCommitHistogramProtoRoundtrip(t *testing.T) {
+	h := &CommitHistogram{Series: []HistogramSeries{{
+		Author: Signature{Name: "camden", Email: "camden@example.com"},
+		Buckets: []HistogramBucket{
+			{Start: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), Count: 3},
+			{Start: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), Count: 1},
+		},
+	}}}
+
+	require.Equal(t, h, CommitHistogramFromProto(h.ToProto()))
+}
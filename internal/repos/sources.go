@@ -82,6 +82,12 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewRustPackagesSource(ctx, svc, cf)
 	case extsvc.KindRubyPackages:
 		return NewRubyPackagesSource(ctx, svc, cf)
+	case extsvc.KindDotNetPackages:
+		return NewDotNetPackagesSource(ctx, svc, cf)
+	case extsvc.KindPHPPackages:
+		return NewPHPPackagesSource(ctx, svc, cf)
+	case extsvc.KindElixirPackages:
+		return NewElixirPackagesSource(ctx, svc, cf)
 	case extsvc.KindOther:
 		return NewOtherSource(ctx, svc, cf, logger.Scoped("OtherSource"))
 	default:
@@ -0,0 +1,80 @@
+package snapshots
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// Diff is the difference between the entries of two snapshots.
+type Diff struct {
+	// Added are the entries of the head snapshot that are not in the base snapshot.
+	Added []Entry
+
+	// Removed are the entries of the base snapshot that are not in the head snapshot.
+	Removed []Entry
+}
+
+// DiffEntries compares the entries of a base and head snapshot. Entries are compared by
+// repository, path and line content, counting duplicates: if a line occurs twice in the base
+// snapshot and three times in the head snapshot, one of its occurrences is added. The order of
+// entries within each snapshot is preserved.
+func DiffEntries(base, head []Entry) Diff {
+	baseCounts := countEntries(base)
+	headCounts := countEntries(head)
+
+	var diff Diff
+	for _, entry := range head {
+		if k := entry.key(); baseCounts[k] > 0 {
+			baseCounts[k]--
+		} else {
+			diff.Added = append(diff.Added, entry)
+		}
+	}
+	for _, entry := range base {
+		if k := entry.key(); headCounts[k] > 0 {
+			headCounts[k]--
+		} else {
+			diff.Removed = append(diff.Removed, entry)
+		}
+	}
+	return diff
+}
+
+func countEntries(entries []Entry) map[entryKey]int {
+	counts := make(map[entryKey]int, len(entries))
+	for _, entry := range entries {
+		counts[entry.key()]++
+	}
+	return counts
+}
+
+// WriteCSV writes the diff as CSV, with one row per added or removed entry.
+func WriteCSV(w io.Writer, diff Diff) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"change", "repository", "path", "line_number", "line"}); err != nil {
+		return err
+	}
+
+	write := func(change string, entries []Entry) error {
+		for _, entry := range entries {
+			lineNumber := ""
+			if entry.LineNumber > 0 {
+				lineNumber = strconv.Itoa(entry.LineNumber)
+			}
+			if err := cw.Write([]string{change, entry.Repo, entry.Path, lineNumber, entry.Line}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := write("added", diff.Added); err != nil {
+		return err
+	}
+	if err := write("removed", diff.Removed); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
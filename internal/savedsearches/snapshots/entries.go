@@ -0,0 +1,98 @@
+package snapshots
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Entry is a single result recorded in a snapshot. Content matches record one entry per matched
+// line, symbol matches one entry per symbol, path matches one entry per file and repository
+// matches one entry per repository.
+type Entry struct {
+	Repo string `json:"repo"`
+	Path string `json:"path,omitempty"`
+
+	// LineNumber is the 1-based line number of the match, or zero if the entry does not refer
+	// to a line.
+	LineNumber int `json:"lineNumber,omitempty"`
+
+	// Line is the content of the matched line, or the name of the matched symbol.
+	Line string `json:"line,omitempty"`
+}
+
+// entryKey identifies an entry across snapshots. Line numbers are not part of the key, so that
+// a line which only moved within its file is not reported as changed.
+type entryKey struct {
+	repo string
+	path string
+	line string
+}
+
+func (e Entry) key() entryKey {
+	return entryKey{repo: e.Repo, path: e.Path, line: e.Line}
+}
+
+// entriesFromMatch returns the entries recorded for a search match. Commit and diff matches are
+// not recorded.
+func entriesFromMatch(match result.Match) []Entry {
+	switch m := match.(type) {
+	case *result.FileMatch:
+		repo := string(m.Repo.Name)
+		if len(m.ChunkMatches) == 0 && len(m.Symbols) == 0 {
+			return []Entry{{Repo: repo, Path: m.Path}}
+		}
+
+		var entries []Entry
+		for _, lm := range m.ChunkMatches.AsLineMatches() {
+			entries = append(entries, Entry{
+				Repo:       repo,
+				Path:       m.Path,
+				LineNumber: int(lm.LineNumber) + 1,
+				Line:       lm.Preview,
+			})
+		}
+		for _, sm := range m.Symbols {
+			entries = append(entries, Entry{
+				Repo:       repo,
+				Path:       m.Path,
+				LineNumber: sm.Symbol.Line,
+				Line:       sm.Symbol.Name,
+			})
+		}
+		return entries
+
+	case *result.RepoMatch:
+		return []Entry{{Repo: string(m.Name)}}
+	}
+	return nil
+}
+
+// writeEntries writes the entries as JSON lines.
+func writeEntries(w io.Writer, entries []Entry) error {
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readEntries reads entries written by writeEntries.
+func readEntries(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.Wrapf(err, "decoding entry %d", len(entries)+1)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
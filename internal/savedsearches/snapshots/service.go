@@ -0,0 +1,294 @@
+// Package snapshots records the results of saved searches at points in time, so that the
+// results of two snapshots can be compared.
+package snapshots
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/object"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	searchquery "github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+const (
+	// MaxEntries bounds the number of entries recorded in a snapshot. Snapshots of searches with
+	// more results are truncated and marked as having hit the limit.
+	MaxEntries = 10000
+
+	// MaxSnapshots is the number of most recent snapshots retained per saved search. Older
+	// snapshots are deleted when a new snapshot is taken.
+	MaxSnapshots = 52
+
+	objectKeyPrefix = "saved-search-snapshots"
+)
+
+// Service takes snapshots of saved searches and compares them.
+type Service struct {
+	logger         log.Logger
+	db             database.DB
+	store          object.Storage
+	searchClient   client.SearchClient
+	subRepoChecker authz.SubRepoPermissionChecker
+}
+
+// NewService returns a service which stores the results of snapshots in the given object store.
+func NewService(logger log.Logger, db database.DB, store object.Storage, searchClient client.SearchClient) *Service {
+	return &Service{
+		logger:         logger,
+		db:             db,
+		store:          store,
+		searchClient:   searchClient,
+		subRepoChecker: authz.DefaultSubRepoPermsChecker,
+	}
+}
+
+// Snapshot runs the query of the saved search and records its results. The query runs with the
+// permissions of the actor in ctx, who is recorded as the creator of the snapshot.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has access to the saved search.
+func (s *Service) Snapshot(ctx context.Context, ss *types.SavedSearch) (*types.SavedSearchSnapshot, error) {
+	entries, limitHit, err := s.search(ctx, ss.Query)
+	if err != nil {
+		return nil, errors.Wrap(err, "running saved search")
+	}
+
+	var buf bytes.Buffer
+	if err := writeEntries(&buf, entries); err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%s/%d/%d.jsonl", objectKeyPrefix, ss.ID, time.Now().UnixNano())
+	if _, err := s.store.Upload(ctx, key, &buf); err != nil {
+		return nil, errors.Wrap(err, "uploading snapshot")
+	}
+
+	var createdBy *int32
+	if a := actor.FromContext(ctx); a.IsAuthenticated() {
+		createdBy = pointers.Ptr(a.UID)
+	}
+	snapshot, err := s.db.SavedSearches().CreateSnapshot(ctx, &types.SavedSearchSnapshot{
+		SavedSearchID: ss.ID,
+		Query:         ss.Query,
+		ObjectKey:     key,
+		EntryCount:    int32(len(entries)),
+		LimitHit:      limitHit,
+		CreatedByUser: createdBy,
+	})
+	if err != nil {
+		s.deleteObjects(ctx, key)
+		return nil, err
+	}
+
+	expired, err := s.db.SavedSearches().DeleteSnapshotsBeyond(ctx, ss.ID, MaxSnapshots)
+	if err != nil {
+		s.logger.Warn("failed to delete old saved search snapshots", log.Int32("savedSearchID", ss.ID), log.Error(err))
+	}
+	s.deleteObjects(ctx, expired...)
+
+	return snapshot, nil
+}
+
+// SnapshotDue takes a snapshot of every saved search whose scheduled snapshot is due. Each
+// query runs with the permissions of the user who scheduled its snapshots. Failures are logged
+// and do not prevent the snapshots of other saved searches.
+func (s *Service) SnapshotDue(ctx context.Context) error {
+	due, err := s.db.SavedSearches().ListDueForSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, ss := range due {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		userCtx := actor.WithActor(ctx, actor.FromUser(*ss.SnapshotUserID))
+		if _, err := s.Snapshot(userCtx, ss); err != nil {
+			s.logger.Warn("failed to take scheduled saved search snapshot", log.Int32("savedSearchID", ss.ID), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// Entries returns the entries recorded in a snapshot. Entries in repositories or paths the actor
+// in ctx cannot access are omitted, since the snapshot may have been taken by another user.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has access to the saved search.
+func (s *Service) Entries(ctx context.Context, snapshot *types.SavedSearchSnapshot) ([]Entry, error) {
+	r, err := s.store.Get(ctx, snapshot.ObjectKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading snapshot")
+	}
+	defer r.Close()
+
+	entries, err := readEntries(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.filterAccessible(ctx, entries)
+}
+
+// Diff returns the entries added and removed between two snapshots.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has access to the saved searches of both
+// snapshots.
+func (s *Service) Diff(ctx context.Context, base, head *types.SavedSearchSnapshot) (Diff, error) {
+	baseEntries, err := s.Entries(ctx, base)
+	if err != nil {
+		return Diff{}, err
+	}
+	headEntries, err := s.Entries(ctx, head)
+	if err != nil {
+		return Diff{}, err
+	}
+	return DiffEntries(baseEntries, headEntries), nil
+}
+
+// search runs the query and returns the entries of its results, up to MaxEntries.
+func (s *Service) search(ctx context.Context, query string) (entries []Entry, limitHit bool, err error) {
+	q, err := searchquery.ParseSearchType(query, searchquery.SearchTypeStandard)
+	if err != nil {
+		return nil, false, err
+	}
+	if !q.Exists(searchquery.FieldCount) {
+		query += " count:" + strconv.Itoa(MaxEntries)
+	}
+
+	inputs, err := s.searchClient.Plan(ctx, "V3", nil, query, search.Precise, search.Streaming, pointers.Ptr(int32(0)))
+	if err != nil {
+		return nil, false, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		canceled bool
+	)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if event.Stats.IsLimitHit {
+			limitHit = true
+		}
+		for _, match := range event.Results {
+			for _, entry := range entriesFromMatch(match) {
+				if len(entries) >= MaxEntries {
+					limitHit, canceled = true, true
+					cancel()
+					return
+				}
+				entries = append(entries, entry)
+			}
+		}
+	})
+
+	_, err = s.searchClient.Execute(ctx, stream, inputs)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if err != nil && !(canceled && errors.Is(err, context.Canceled)) {
+		return nil, false, err
+	}
+	return entries, limitHit, nil
+}
+
+// filterAccessible removes the entries in repositories the actor in ctx cannot access, and the
+// entries in paths hidden from the actor by sub-repository permissions.
+func (s *Service) filterAccessible(ctx context.Context, entries []Entry) ([]Entry, error) {
+	var names []string
+	seen := map[string]struct{}{}
+	for _, entry := range entries {
+		if _, ok := seen[entry.Repo]; !ok {
+			seen[entry.Repo] = struct{}{}
+			names = append(names, entry.Repo)
+		}
+	}
+	if len(names) == 0 {
+		return entries, nil
+	}
+
+	repos, err := s.db.Repos().ListMinimalRepos(ctx, database.ReposListOptions{Names: names})
+	if err != nil {
+		return nil, err
+	}
+	accessible := make(map[string]struct{}, len(repos))
+	for _, repo := range repos {
+		accessible[string(repo.Name)] = struct{}{}
+	}
+
+	readablePaths, err := s.readablePaths(ctx, entries, accessible)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := entries[:0]
+	for _, entry := range entries {
+		if _, ok := accessible[entry.Repo]; !ok {
+			continue
+		}
+		if entry.Path != "" {
+			if _, ok := readablePaths[entry.Repo][entry.Path]; !ok {
+				continue
+			}
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered, nil
+}
+
+// readablePaths returns the paths of the entries in the given repositories that the actor in ctx
+// can read, by repository.
+func (s *Service) readablePaths(ctx context.Context, entries []Entry, repos map[string]struct{}) (map[string]map[string]struct{}, error) {
+	pathsByRepo := map[string][]string{}
+	seen := map[Entry]struct{}{}
+	for _, entry := range entries {
+		if _, ok := repos[entry.Repo]; !ok || entry.Path == "" {
+			continue
+		}
+		key := Entry{Repo: entry.Repo, Path: entry.Path}
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			pathsByRepo[entry.Repo] = append(pathsByRepo[entry.Repo], entry.Path)
+		}
+	}
+
+	a := actor.FromContext(ctx)
+	readable := make(map[string]map[string]struct{}, len(pathsByRepo))
+	for repo, paths := range pathsByRepo {
+		filtered, err := authz.FilterActorPaths(ctx, s.subRepoChecker, a, api.RepoName(repo), paths)
+		if err != nil {
+			return nil, err
+		}
+
+		readable[repo] = make(map[string]struct{}, len(filtered))
+		for _, path := range filtered {
+			readable[repo][path] = struct{}{}
+		}
+	}
+	return readable, nil
+}
+
+func (s *Service) deleteObjects(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.store.Delete(context.WithoutCancel(ctx), key); err != nil {
+			s.logger.Warn("failed to delete saved search snapshot", log.String("key", key), log.Error(err))
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package snapshots
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/object/mocks"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// fileMatch returns a file match with one single-line chunk match per line, starting at the
+// 0-based line numbers given as keys.
+func fileMatch(repo, path string, lines map[int]string) *result.FileMatch {
+	fm := &result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: api.RepoName(repo)}, Path: path}}
+	for lineNumber := 0; len(fm.ChunkMatches) < len(lines); lineNumber++ {
+		line, ok := lines[lineNumber]
+		if !ok {
+			continue
+		}
+		fm.ChunkMatches = append(fm.ChunkMatches, result.ChunkMatch{
+			Content:      line,
+			ContentStart: result.Location{Line: lineNumber},
+			Ranges: result.Ranges{{
+				Start: result.Location{Line: lineNumber},
+				End:   result.Location{Line: lineNumber, Column: len(line)},
+			}},
+		})
+	}
+	return fm
+}
+
+func TestEntriesFromMatch(t *testing.T) {
+	fm := fileMatch("repo", "a.go", map[int]string{0: "foo", 4: "bar"})
+	require.Equal(t, []Entry{
+		{Repo: "repo", Path: "a.go", LineNumber: 1, Line: "foo"},
+		{Repo: "repo", Path: "a.go", LineNumber: 5, Line: "bar"},
+	}, entriesFromMatch(fm))
+
+	fm = &result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "repo"}, Path: "b.go"}}
+	fm.Symbols = []*result.SymbolMatch{{File: &fm.File, Symbol: result.Symbol{Name: "Parse", Line: 3}}}
+	require.Equal(t, []Entry{{Repo: "repo", Path: "b.go", LineNumber: 3, Line: "Parse"}}, entriesFromMatch(fm))
+
+	fm = &result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "repo"}, Path: "c.go"}}
+	require.Equal(t, []Entry{{Repo: "repo", Path: "c.go"}}, entriesFromMatch(fm))
+
+	require.Equal(t, []Entry{{Repo: "repo"}}, entriesFromMatch(&result.RepoMatch{Name: "repo"}))
+	require.Nil(t, entriesFromMatch(&result.CommitMatch{}))
+}
+
+func TestDiffEntries(t *testing.T) {
+	base := []Entry{
+		{Repo: "r", Path: "a.go", LineNumber: 1, Line: "foo"},
+		{Repo: "r", Path: "a.go", LineNumber: 2, Line: "bar"},
+		{Repo: "r", Path: "a.go", LineNumber: 3, Line: "bar"},
+		{Repo: "r", Path: "b.go", LineNumber: 1, Line: "baz"},
+	}
+	head := []Entry{
+		// Moved lines are not changes
+		{Repo: "r", Path: "a.go", LineNumber: 7, Line: "foo"},
+		{Repo: "r", Path: "a.go", LineNumber: 8, Line: "bar"},
+		{Repo: "r", Path: "a.go", LineNumber: 9, Line: "qux"},
+		{Repo: "r", Path: "c.go", LineNumber: 1, Line: "baz"},
+	}
+
+	diff := DiffEntries(base, head)
+	require.Equal(t, Diff{
+		Added: []Entry{
+			{Repo: "r", Path: "a.go", LineNumber: 9, Line: "qux"},
+			{Repo: "r", Path: "c.go", LineNumber: 1, Line: "baz"},
+		},
+		Removed: []Entry{
+			{Repo: "r", Path: "a.go", LineNumber: 3, Line: "bar"},
+			{Repo: "r", Path: "b.go", LineNumber: 1, Line: "baz"},
+		},
+	}, diff)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, diff))
+	require.Equal(t, `change,repository,path,line_number,line
+added,r,a.go,9,qux
+added,r,c.go,1,baz
+removed,r,a.go,3,bar
+removed,r,b.go,1,baz
+`, buf.String())
+}
+
+func TestEntriesRoundTrip(t *testing.T) {
+	entries := []Entry{
+		{Repo: "r", Path: "a.go", LineNumber: 1, Line: "foo\tbar"},
+		{Repo: "r"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, writeEntries(&buf, entries))
+	got, err := readEntries(&buf)
+	require.NoError(t, err)
+	require.Equal(t, entries, got)
+}
+
+func newSearchClient(matches ...result.Match) *client.MockSearchClient {
+	searchClient := client.NewMockSearchClient()
+	searchClient.PlanFunc.SetDefaultReturn(&search.Inputs{}, nil)
+	searchClient.ExecuteFunc.SetDefaultHook(func(_ context.Context, stream streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+		for _, match := range matches {
+			stream.Send(streaming.SearchEvent{Results: result.Matches{match}})
+		}
+		return nil, nil
+	})
+	return searchClient
+}
+
+func TestSnapshot(t *testing.T) {
+	savedSearches := dbmocks.NewMockSavedSearchStore()
+	savedSearches.CreateSnapshotFunc.SetDefaultHook(func(_ context.Context, snapshot *types.SavedSearchSnapshot) (*types.SavedSearchSnapshot, error) {
+		created := *snapshot
+		created.ID = 1
+		return &created, nil
+	})
+	savedSearches.DeleteSnapshotsBeyondFunc.SetDefaultReturn([]string{"old"}, nil)
+	db := dbmocks.NewMockDB()
+	db.SavedSearchesFunc.SetDefaultReturn(savedSearches)
+
+	var uploaded bytes.Buffer
+	store := mocks.NewMockStorage()
+	store.UploadFunc.SetDefaultHook(func(_ context.Context, _ string, r io.Reader) (int64, error) {
+		return io.Copy(&uploaded, r)
+	})
+
+	searchClient := newSearchClient(
+		fileMatch("repo", "a.go", map[int]string{0: "foo", 1: "bar"}),
+		&result.RepoMatch{Name: "other"},
+	)
+
+	svc := NewService(logtest.Scoped(t), db, store, searchClient)
+	ctx := actor.WithActor(context.Background(), actor.FromUser(42))
+	snapshot, err := svc.Snapshot(ctx, &types.SavedSearch{ID: 7, Query: "foo patternType:literal"})
+	require.NoError(t, err)
+
+	require.Equal(t, "foo patternType:literal count:10000", searchClient.PlanFunc.History()[0].Arg3)
+	require.Equal(t, int32(7), snapshot.SavedSearchID)
+	require.Equal(t, int32(3), snapshot.EntryCount)
+	require.False(t, snapshot.LimitHit)
+	require.Equal(t, int32(42), *snapshot.CreatedByUser)
+	require.Equal(t, snapshot.ObjectKey, store.UploadFunc.History()[0].Arg1)
+
+	entries, err := readEntries(&uploaded)
+	require.NoError(t, err)
+	require.Equal(t, []Entry{
+		{Repo: "repo", Path: "a.go", LineNumber: 1, Line: "foo"},
+		{Repo: "repo", Path: "a.go", LineNumber: 2, Line: "bar"},
+		{Repo: "other"},
+	}, entries)
+
+	// Snapshots beyond the retention limit are removed from the object store
+	require.Len(t, store.DeleteFunc.History(), 1)
+	require.Equal(t, "old", store.DeleteFunc.History()[0].Arg1)
+
+	// Only a count parameter, not a pattern mentioning count, overrides the default
+	for query, want := range map[string]string{
+		"foo count:5":        "foo count:5",
+		`"count:" foo`:       `"count:" foo count:10000`,
+		"foo count:all":      "foo count:all",
+		"repo:count: foo":    "repo:count: foo count:10000",
+		"file:a.go count:10": "file:a.go count:10",
+	} {
+		_, err := svc.Snapshot(ctx, &types.SavedSearch{ID: 7, Query: query})
+		require.NoError(t, err)
+		history := searchClient.PlanFunc.History()
+		require.Equal(t, want, history[len(history)-1].Arg3)
+	}
+}
+
+func TestEntries(t *testing.T) {
+	repos := dbmocks.NewMockRepoStore()
+	repos.ListMinimalReposFunc.SetDefaultHook(func(_ context.Context, opts database.ReposListOptions) ([]types.MinimalRepo, error) {
+		require.Equal(t, []string{"visible", "hidden"}, opts.Names)
+		return []types.MinimalRepo{{Name: "visible"}}, nil
+	})
+	db := dbmocks.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeEntries(&buf, []Entry{
+		{Repo: "visible", Path: "a.go"},
+		{Repo: "visible", Path: "secret/b.go", LineNumber: 1},
+		{Repo: "visible", Path: "secret/b.go", LineNumber: 2},
+		{Repo: "visible"},
+		{Repo: "hidden"},
+	}))
+	store := mocks.NewMockStorage()
+	store.GetFunc.SetDefaultReturn(io.NopCloser(&buf), nil)
+
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.EnabledForRepoFunc.SetDefaultReturn(true, nil)
+	checker.FilePermissionsFuncFunc.SetDefaultReturn(func(path string) (authz.Perms, error) {
+		if path == "secret/b.go" {
+			return authz.None, nil
+		}
+		return authz.Read, nil
+	}, nil)
+
+	svc := NewService(logtest.Scoped(t), db, store, client.NewMockSearchClient())
+	svc.subRepoChecker = checker
+	ctx := actor.WithActor(context.Background(), actor.FromUser(42))
+	entries, err := svc.Entries(ctx, &types.SavedSearchSnapshot{ObjectKey: "key"})
+	require.NoError(t, err)
+	// Entries in other repositories and in paths hidden by sub-repository permissions are omitted
+	require.Equal(t, []Entry{{Repo: "visible", Path: "a.go"}, {Repo: "visible"}}, entries)
+	require.Len(t, checker.FilePermissionsFuncFunc.History(), 1)
+}
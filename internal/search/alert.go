@@ -60,6 +60,10 @@ const (
 	// query. May be a number or string representing something approximate,
 	// like "500+".
 	ResultCount AnnotationName = "ResultCount"
+
+	// SmartSearchRules lists the names of the Smart Search rules defined in
+	// the site configuration that produced a query, separated by commas.
+	SmartSearchRules AnnotationName = "SmartSearchRules"
 )
 
 func (q *QueryDescription) QueryString() string {
@@ -186,3 +190,26 @@ func AlertForOwnershipSearchError() *Alert {
 		Priority:    1,
 	}
 }
+
+// AlertForReferencesUnavailable returns an alert for `select:symbol.references`
+// and `select:symbol.callers` searches when code intelligence is not available.
+func AlertForReferencesUnavailable() *Alert {
+	return &Alert{
+		Kind:        "references-unavailable",
+		Title:       "Code intelligence is not available",
+		Description: "Selecting references or callers of symbols requires code intelligence, which is not available for this search.",
+		Priority:    1,
+	}
+}
+
+// AlertForReferencesSearchError returns an alert for `select:symbol.references`
+// and `select:symbol.callers` searches when the references of some symbols
+// could not be found.
+func AlertForReferencesSearchError() *Alert {
+	return &Alert{
+		Kind:        "references-search-error",
+		Title:       "Error while finding references",
+		Description: "The references of some symbols could not be found, so results may be incomplete.",
+		Priority:    1,
+	}
+}
@@ -110,6 +110,11 @@ func (s *searchClient) Plan(
 		if err != nil {
 			return "", err
 		}
+		if searchcontexts.IsMaterializedSearchContext(sc) {
+			// Materialized contexts are resolved from their repository revisions like
+			// contexts without a query, so the context:foo term is kept.
+			return "", nil
+		}
 		tr.AddEvent("substituted context filter with query", attribute.String("query", sc.Query), attribute.String("context", context))
 		return sc.Query, nil
 	})
@@ -247,6 +252,8 @@ func SearchTypeFromString(patternType string) (query.SearchType, error) {
 		return query.SearchTypeCodyContext, nil
 	case "keyword":
 		return query.SearchTypeKeyword, nil
+	case "semantic":
+		return query.SearchTypeSemantic, nil
 	default:
 		return -1, errors.Errorf("unrecognized patternType %q", patternType)
 	}
@@ -299,6 +306,8 @@ func overrideSearchType(input string, searchType query.SearchType) query.SearchT
 			searchType = query.SearchTypeCodyContext
 		case "keyword":
 			searchType = query.SearchTypeKeyword
+		case "semantic":
+			searchType = query.SearchTypeSemantic
 		}
 	})
 	return searchType
@@ -51,6 +51,17 @@ func parseQuery(queryString string) (*contextQuery, error) {
 	return &contextQuery{symbolQuery, symbols, keywordQuery, keywords}, nil
 }
 
+// KeywordQuery maps a natural language query into a keyword query the same way Cody context
+// searches do, by dropping stop words, stemming the remaining terms and combining them through
+// an OR operator. It returns the keyword query along with the terms it searches for.
+func KeywordQuery(queryString string) (query.Basic, []string, error) {
+	q, err := parseQuery(queryString)
+	if err != nil {
+		return query.Basic{}, nil, err
+	}
+	return q.keywordQuery, q.patterns, nil
+}
+
 func newBasicQuery(parameters []query.Parameter, patterns []string) (query.Basic, error) {
 	var nodes []query.Node
 	for _, p := range parameters {
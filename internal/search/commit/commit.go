@@ -32,6 +32,10 @@ type SearchJob struct {
 	IncludeModifiedFiles bool
 	Concurrency          int
 
+	// Histogram, if set, makes the job send the number of matching commits
+	// per time bucket of each repo instead of the matching commits.
+	Histogram *gitprotocol.HistogramOptions
+
 	// CodeMonitorSearchWrapper, if set, will wrap the commit search with extra logic specific to code monitors.
 	CodeMonitorSearchWrapper CodeMonitorHook `json:"-"`
 }
@@ -65,6 +69,7 @@ func (j *SearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream
 			IncludeDiff:          j.Diff,
 			Limit:                j.Limit,
 			IncludeModifiedFiles: j.IncludeModifiedFiles,
+			Histogram:            j.Histogram,
 		}
 
 		onMatches := func(in []protocol.CommitMatch) {
@@ -78,6 +83,22 @@ func (j *SearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream
 		}
 
 		doSearch := func(args *gitprotocol.SearchRequest) error {
+			if args.Histogram != nil {
+				histogram, err := clients.Gitserver.CommitHistogram(ctx, args)
+				if err == nil {
+					stream.Send(streaming.SearchEvent{
+						Results: protocolHistogramToMatches(repoRev.Repo, *args.Histogram, histogram),
+					})
+				}
+				statusMap, err := search.HandleRepoSearchResult(repoRev.Repo.ID, repoRev.Revs, false, false, err)
+				stream.Send(streaming.SearchEvent{
+					Stats: streaming.Stats{
+						Status: statusMap,
+					},
+				})
+				return err
+			}
+
 			limitHit, err := clients.Gitserver.Search(ctx, args, onMatches)
 			statusMap, err := search.HandleRepoSearchResult(repoRev.Repo.ID, repoRev.Revs, limitHit, false, err)
 			stream.Send(streaming.SearchEvent{
@@ -127,6 +148,12 @@ func (j *SearchJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
 			attribute.Bool("diff", j.Diff),
 			attribute.Int("limit", j.Limit),
 		)
+		if j.Histogram != nil {
+			res = append(res,
+				attribute.Stringer("histogramInterval", j.Histogram.Interval),
+				attribute.Stringer("histogramGroupBy", j.Histogram.GroupBy),
+			)
+		}
 	}
 	return res
 }
@@ -352,3 +379,21 @@ func protocolMatchToCommitMatch(repo types.MinimalRepo, diff bool, in protocol.C
 		ModifiedFiles:  in.ModifiedFiles,
 	}
 }
+
+func protocolHistogramToMatches(repo types.MinimalRepo, opts protocol.HistogramOptions, in *protocol.CommitHistogram) []result.Match {
+	matches := make([]result.Match, 0, len(in.Series))
+	for _, series := range in.Series {
+		buckets := make([]result.CommitHistogramBucket, 0, len(series.Buckets))
+		for _, b := range series.Buckets {
+			buckets = append(buckets, result.CommitHistogramBucket{Start: b.Start, Count: b.Count})
+		}
+		matches = append(matches, &result.CommitHistogramMatch{
+			Repo:        repo,
+			Interval:    opts.Interval.String(),
+			AuthorName:  series.Author.Name,
+			AuthorEmail: series.Author.Email,
+			Buckets:     buckets,
+		})
+	}
+	return matches
+}
@@ -10,8 +10,13 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -107,3 +112,38 @@ func TestExpandUsernamesToEmails(t *testing.T) {
 		t.Errorf("got %q, want %q", x, want)
 	}
 }
+
+func TestSearchJobHistogram(t *testing.T) {
+	week := time.Date(2023, 3, 13, 0, 0, 0, 0, time.UTC)
+
+	gs := gitserver.NewStrictMockClient()
+	gs.CommitHistogramFunc.SetDefaultHook(func(_ context.Context, args *protocol.SearchRequest) (*protocol.CommitHistogram, error) {
+		require.Equal(t, &protocol.HistogramOptions{Interval: protocol.HistogramIntervalWeek, GroupBy: protocol.HistogramGroupByAuthor}, args.Histogram)
+		return &protocol.CommitHistogram{Series: []protocol.HistogramSeries{{
+			Author:  protocol.Signature{Name: "alice", Email: "alice@example.com"},
+			Buckets: []protocol.HistogramBucket{{Start: week, Count: 3}},
+		}}}, nil
+	})
+
+	repo := types.MinimalRepo{ID: 1, Name: "repo"}
+	j := &SearchJob{
+		Query:       &protocol.MessageMatches{Expr: "fix"},
+		Repos:       []*search.RepositoryRevisions{{Repo: repo, Revs: []string{"HEAD"}}},
+		Concurrency: 1,
+		Histogram:   &protocol.HistogramOptions{Interval: protocol.HistogramIntervalWeek, GroupBy: protocol.HistogramGroupByAuthor},
+	}
+
+	stream := streaming.NewAggregatingStream()
+	_, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gs}, stream)
+	require.NoError(t, err)
+
+	// Histograms are sent instead of commits
+	require.Empty(t, gs.SearchFunc.History())
+	require.Equal(t, result.Matches{&result.CommitHistogramMatch{
+		Repo:        repo,
+		Interval:    "week",
+		AuthorName:  "alice",
+		AuthorEmail: "alice@example.com",
+		Buckets:     []result.CommitHistogramBucket{{Start: week, Count: 3}},
+	}}, result.Matches(stream.Results))
+}
@@ -38,6 +38,22 @@ var validSelectors = object{
 			"added":   nil,
 			"removed": nil,
 		},
+		// histogram counts matching commits per interval (default week),
+		// grouped by repo (default) or author.
+		"histogram": object{
+			"day": object{
+				"repo":   nil,
+				"author": nil,
+			},
+			"week": object{
+				"repo":   nil,
+				"author": nil,
+			},
+			"month": object{
+				"repo":   nil,
+				"author": nil,
+			},
+		},
 	},
 	Content: nil,
 	File: {
@@ -74,9 +90,22 @@ var validSelectors = object{
 		"event":          nil,
 		"operator":       nil,
 		"type-parameter": nil,
+		// Not symbol kinds: these select the references to matched symbols
+		"references": nil,
+		"callers":    nil,
 	},
 }
 
+// IsSymbolKind returns true if kind is a symbol kind which can be selected
+// with select:symbol.<kind>.
+func IsSymbolKind(kind string) bool {
+	if kind == "references" || kind == "callers" {
+		return false
+	}
+	_, ok := validSelectors[Symbol][kind]
+	return ok
+}
+
 func SelectPathFromString(s string) (SelectPath, error) {
 	fields := strings.Split(s, ".")
 	cur := validSelectors
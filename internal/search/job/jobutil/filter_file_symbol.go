@@ -0,0 +1,206 @@
+package jobutil
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/grafana/regexp"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/search/symbol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxSymbolsPerFile bounds the number of symbols with a matching name we look
+// at to decide whether a file passes a file:has.symbol() predicate.
+const maxSymbolsPerFile = 1000
+
+// NewFileHasSymbolsJob creates a filter job to post-filter results for the file:has.symbol() predicate.
+//
+// Like file:has.contributor(), all predicates are AND'ed together. The symbols of a file are looked up
+// in Zoekt's symbol index if the revision of the file is indexed, and with the symbols service otherwise.
+func NewFileHasSymbolsJob(child job.Job, include, exclude []query.FileHasSymbolPredicate, isCaseSensitive bool) job.Job {
+	return &fileHasSymbolsJob{
+		child:   child,
+		include: toSymbolFilters(include, isCaseSensitive),
+		exclude: toSymbolFilters(exclude, isCaseSensitive),
+	}
+}
+
+type fileHasSymbolsJob struct {
+	child job.Job
+
+	include []symbolFilter
+	exclude []symbolFilter
+}
+
+// symbolFilter matches the symbols of a file against a file:has.symbol() predicate.
+type symbolFilter struct {
+	kind    string
+	pattern string
+	name    *regexp.Regexp
+}
+
+func toSymbolFilters(preds []query.FileHasSymbolPredicate, isCaseSensitive bool) []symbolFilter {
+	filters := make([]symbolFilter, 0, len(preds))
+	for _, pred := range preds {
+		pattern := pred.Symbol
+		if !isCaseSensitive {
+			pattern = query.CaseInsensitiveRegExp(pattern)
+		}
+		filters = append(filters, symbolFilter{
+			kind:    pred.Kind,
+			pattern: pred.Symbol,
+			name:    regexp.MustCompile(pattern), // Invariant: validated by the predicate
+		})
+	}
+	return filters
+}
+
+// matches returns true if any of symbols is defined in path and satisfies the filter.
+func (f symbolFilter) matches(symbols []*result.SymbolMatch, path string) bool {
+	for _, s := range symbols {
+		if s.File.Path != path {
+			continue
+		}
+		if f.kind != "" && result.ToSelectKind[strings.ToLower(s.Symbol.Kind)] != f.kind {
+			continue
+		}
+		if f.name.MatchString(s.Symbol.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f symbolFilter) String() string {
+	if f.kind == "" {
+		return f.name.String()
+	}
+	return f.kind + " " + f.name.String()
+}
+
+// fileSymbols returns the symbols defined in the file of fm whose names match
+// pattern, ignoring case. It is a variable so that tests can stub it.
+var fileSymbols = func(ctx context.Context, fm *result.FileMatch, pattern string) ([]*result.SymbolMatch, error) {
+	first := int32(maxSymbolsPerFile)
+	includePatterns := []string{"^" + regexp.QuoteMeta(fm.Path) + "$"}
+	return symbol.DefaultZoektSymbolsClient().Compute(ctx, fm.Repo, fm.CommitID, fm.InputRev, &pattern, &first, &includePatterns)
+}
+
+func (j *fileHasSymbolsJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer finish(alert, err)
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		filtered := event.Results[:0]
+		for _, res := range event.Results {
+			// Filter out any result that is not a file
+			if fm, ok := res.(*result.FileMatch); ok {
+				// We send one symbols request per file path and predicate.
+				// We should quit early on context deadline exceeded.
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					mu.Lock()
+					errs = errors.Append(errs, ctx.Err())
+					mu.Unlock()
+					break
+				}
+
+				ok, err := j.filtered(ctx, fm)
+				if err != nil {
+					mu.Lock()
+					errs = errors.Append(errs, err)
+					mu.Unlock()
+					continue
+				}
+				if !ok {
+					continue
+				}
+
+				filtered = append(filtered, fm)
+			}
+		}
+
+		event.Results = filtered
+
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+// filtered returns true if the file of fm passes all filters and should be returned with the
+// results page.
+func (j *fileHasSymbolsJob) filtered(ctx context.Context, fm *result.FileMatch) (bool, error) {
+	for _, f := range j.include {
+		symbols, err := fileSymbols(ctx, fm, f.pattern)
+		if err != nil {
+			return false, err
+		}
+		if !f.matches(symbols, fm.Path) {
+			return false, nil
+		}
+	}
+
+	for _, f := range j.exclude {
+		symbols, err := fileSymbols(ctx, fm, f.pattern)
+		if err != nil {
+			return false, err
+		}
+		if f.matches(symbols, fm.Path) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (j *fileHasSymbolsJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+func (j *fileHasSymbolsJob) Name() string {
+	return "FileHasSymbolsFilterJob"
+}
+
+func (j *fileHasSymbolsJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileHasSymbolsJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			attribute.StringSlice("includeSymbols", symbolFiltersToStr(j.include)),
+			attribute.StringSlice("excludeSymbols", symbolFiltersToStr(j.exclude)),
+		)
+	}
+	return res
+}
+
+func symbolFiltersToStr(filters []symbolFilter) []string {
+	var res []string
+	for _, f := range filters {
+		res = append(res, f.String())
+	}
+	return res
+}
@@ -0,0 +1,140 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+func TestFileHasSymbolsJob(t *testing.T) {
+	r := func(ms ...result.Match) (res result.Matches) {
+		for _, m := range ms {
+			res = append(res, m)
+		}
+		return res
+	}
+
+	fm := func() *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Path:     "path",
+				CommitID: "commitID",
+			},
+		}
+	}
+
+	sym := func(path, kind, name string) *result.SymbolMatch {
+		return &result.SymbolMatch{
+			File:   &result.File{Path: path},
+			Symbol: result.Symbol{Name: name, Kind: kind},
+		}
+	}
+
+	pred := func(kind, name string) []query.FileHasSymbolPredicate {
+		return []query.FileHasSymbolPredicate{{Kind: kind, Symbol: name}}
+	}
+
+	tests := []struct {
+		name          string
+		caseSensitive bool
+		include       []query.FileHasSymbolPredicate
+		exclude       []query.FileHasSymbolPredicate
+		matches       result.Match
+		symbols       []*result.SymbolMatch
+		outputEvent   streaming.SearchEvent
+	}{{
+		name:        "include matches name",
+		include:     pred("", "Handler"),
+		matches:     fm(),
+		symbols:     []*result.SymbolMatch{sym("path", "func", "NewHandler")},
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "include matches name and kind",
+		include:     pred("interface", "^Handler$"),
+		matches:     fm(),
+		symbols:     []*result.SymbolMatch{sym("path", "func", "Handler"), sym("path", "interface", "Handler")},
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "include maps ctags kinds",
+		include:     pred("class", "Handler"),
+		matches:     fm(),
+		symbols:     []*result.SymbolMatch{sym("path", "typedef", "Handler")},
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "include has no matching kind",
+		include:     pred("interface", "Handler"),
+		matches:     fm(),
+		symbols:     []*result.SymbolMatch{sym("path", "func", "Handler")},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "include ignores symbols in other files",
+		include:     pred("", "Handler"),
+		matches:     fm(),
+		symbols:     []*result.SymbolMatch{sym("path/other", "func", "Handler")},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "exclude matches name",
+		exclude:     pred("", "Handler"),
+		matches:     fm(),
+		symbols:     []*result.SymbolMatch{sym("path", "func", "Handler")},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "exclude has no matches",
+		exclude:     pred("", "Handler"),
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:          "include case sensitive has matches",
+		include:       pred("", "Handler"),
+		caseSensitive: true,
+		matches:       fm(),
+		symbols:       []*result.SymbolMatch{sym("path", "func", "Handler")},
+		outputEvent:   streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:          "include case sensitive has no matches",
+		include:       pred("", "Handler"),
+		caseSensitive: true,
+		matches:       fm(),
+		symbols:       []*result.SymbolMatch{sym("path", "func", "handler")},
+		outputEvent:   streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "not all matches are files",
+		include:     pred("", "Handler"),
+		matches:     &result.CommitMatch{},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			childJob := mockjob.NewMockJob()
+			childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: r(tc.matches)})
+				return nil, nil
+			})
+
+			origFileSymbols := fileSymbols
+			fileSymbols = func(_ context.Context, fm *result.FileMatch, pattern string) ([]*result.SymbolMatch, error) {
+				return tc.symbols, nil
+			}
+			t.Cleanup(func() { fileSymbols = origFileSymbols })
+
+			var resultEvent streaming.SearchEvent
+			streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+				resultEvent = ev
+			})
+
+			j := NewFileHasSymbolsJob(childJob, tc.include, tc.exclude, tc.caseSensitive)
+			alert, err := j.Run(context.Background(), job.RuntimeClients{}, streamCollector)
+			require.Nil(t, alert)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputEvent, resultEvent)
+		})
+	}
+}
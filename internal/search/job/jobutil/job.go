@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	ownsearch "github.com/sourcegraph/sourcegraph/internal/own/search"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/codycontext"
@@ -19,10 +20,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/limits"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/references"
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
 	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/semantic"
 	"github.com/sourcegraph/sourcegraph/internal/search/smartsearch"
 	"github.com/sourcegraph/sourcegraph/internal/search/structural"
 	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
@@ -49,7 +52,7 @@ func NewPlanJob(inputs *search.Inputs, plan query.Plan) (job.Job, error) {
 	}
 
 	if inputs.SearchMode == search.SmartSearch || inputs.PatternType == query.SearchTypeLucky {
-		if inputs.PatternType == query.SearchTypeCodyContext || inputs.PatternType == query.SearchTypeKeyword {
+		if inputs.PatternType == query.SearchTypeCodyContext || inputs.PatternType == query.SearchTypeKeyword || inputs.PatternType == query.SearchTypeSemantic {
 			return nil, errors.Newf("The '%s' patterntype is not compatible with Smart Search", inputs.PatternType)
 		}
 		jobTree = smartsearch.NewSmartSearchJob(jobTree, newJob, plan)
@@ -64,6 +67,15 @@ func NewPlanJob(inputs *search.Inputs, plan query.Plan) (job.Job, error) {
 		jobTree = newJobTree
 	}
 
+	if inputs.PatternType == query.SearchTypeSemantic {
+		newJobTree, err := semantic.NewSearchJob(plan, inputs, newJob)
+		if err != nil {
+			return nil, err
+		}
+
+		jobTree = newJobTree
+	}
+
 	alertJob := NewAlertJob(inputs, jobTree)
 	logJob := NewLogJob(inputs, alertJob)
 	return logJob, nil
@@ -177,6 +189,7 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 				Limit:                int(fileMatchLimit),
 				IncludeModifiedFiles: authz.SubRepoEnabled(authz.DefaultSubRepoPermsChecker) || own,
 				Concurrency:          4,
+				Histogram:            commitHistogramOptions(selector),
 			}
 
 			addJob(
@@ -230,6 +243,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.symbol() post-search filter
+		if includeSymbols, excludeSymbols, ok := isSymbolDefinitionSearch(b); ok {
+			basicJob = NewFileHasSymbolsJob(basicJob, includeSymbols, excludeSymbols, b.IsCaseSensitive())
+		}
+	}
+
 	{ // Apply subrepo permissions checks
 		checker := authz.DefaultSubRepoPermsChecker
 		if authz.SubRepoEnabled(checker) {
@@ -243,6 +262,13 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 			if isSelectOwnersSearch(sp) {
 				// the select owners job is ran separately as it requires state and can return multiple owners from one match.
 				basicJob = ownsearch.NewSelectOwnersJob(basicJob)
+			} else if isSelectReferencesSearch(sp) {
+				// References are resolved across files and repositories, so they are checked
+				// against sub-repo permissions again.
+				basicJob = references.NewSelectJob(sp[1] == "callers", basicJob)
+				if authz.SubRepoEnabled(authz.DefaultSubRepoPermsChecker) {
+					basicJob = NewFilterJob(basicJob)
+				}
 			} else {
 				basicJob = NewSelectJob(sp, basicJob)
 			}
@@ -565,6 +591,10 @@ func computeFileMatchLimit(b query.Basic, defaultLimit int) int {
 		// This is the int equivalent of count:all.
 		return query.CountAllLimit
 	}
+	if _, _, ok := isSymbolDefinitionSearch(b); ok {
+		// This is the int equivalent of count:all.
+		return query.CountAllLimit
+	}
 	if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 		sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
 		if isSelectOwnersSearch(sp) {
@@ -588,6 +618,37 @@ func isSelectOwnersSearch(sp filter.SelectPath) bool {
 	return sp.Root() == filter.File && len(sp) == 2 && sp[1] == "owners"
 }
 
+func isSelectReferencesSearch(sp filter.SelectPath) bool {
+	// select:symbol.references and select:symbol.callers replace symbol matches with their references.
+	return sp.Root() == filter.Symbol && len(sp) == 2 && (sp[1] == "references" || sp[1] == "callers")
+}
+
+// commitHistogramOptions returns the histogram options for a
+// select:commit.histogram[.<interval>[.<group>]] search, or nil if sp doesn't
+// select a commit histogram. Histograms default to weekly buckets per repo.
+func commitHistogramOptions(sp filter.SelectPath) *gitprotocol.HistogramOptions {
+	if sp.Root() != filter.Commit || len(sp) < 2 || sp[1] != "histogram" {
+		return nil
+	}
+
+	opts := &gitprotocol.HistogramOptions{
+		Interval: gitprotocol.HistogramIntervalWeek,
+		GroupBy:  gitprotocol.HistogramGroupByRepo,
+	}
+	if len(sp) > 2 {
+		switch sp[2] {
+		case "day":
+			opts.Interval = gitprotocol.HistogramIntervalDay
+		case "month":
+			opts.Interval = gitprotocol.HistogramIntervalMonth
+		}
+	}
+	if len(sp) > 3 && sp[3] == "author" {
+		opts.GroupBy = gitprotocol.HistogramGroupByAuthor
+	}
+	return opts
+}
+
 func isContributorSearch(b query.Basic) (include, exclude []string, ok bool) {
 	if includeContributors, excludeContributors := b.FileHasContributor(); len(includeContributors) > 0 || len(excludeContributors) > 0 {
 		return includeContributors, excludeContributors, true
@@ -595,6 +656,13 @@ func isContributorSearch(b query.Basic) (include, exclude []string, ok bool) {
 	return nil, nil, false
 }
 
+func isSymbolDefinitionSearch(b query.Basic) (include, exclude []query.FileHasSymbolPredicate, ok bool) {
+	if includeSymbols, excludeSymbols := b.FileHasSymbol(); len(includeSymbols) > 0 || len(excludeSymbols) > 0 {
+		return includeSymbols, excludeSymbols, true
+	}
+	return nil, nil, false
+}
+
 func contributorsAsRegexp(contributors []string, isCaseSensitive bool) (res []*regexp.Regexp) {
 	for _, pattern := range contributors {
 		if isCaseSensitive {
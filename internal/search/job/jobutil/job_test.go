@@ -25,8 +25,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/printer"
 	"github.com/sourcegraph/sourcegraph/internal/search/limits"
@@ -1655,6 +1657,27 @@ func Test_computeResultTypes(t *testing.T) {
 	})
 }
 
+func TestCommitHistogramOptions(t *testing.T) {
+	cases := []struct {
+		selector string
+		want     *gitprotocol.HistogramOptions
+	}{
+		{"commit", nil},
+		{"commit.diff", nil},
+		{"repo", nil},
+		{"commit.histogram", &gitprotocol.HistogramOptions{Interval: gitprotocol.HistogramIntervalWeek, GroupBy: gitprotocol.HistogramGroupByRepo}},
+		{"commit.histogram.day", &gitprotocol.HistogramOptions{Interval: gitprotocol.HistogramIntervalDay, GroupBy: gitprotocol.HistogramGroupByRepo}},
+		{"commit.histogram.month.author", &gitprotocol.HistogramOptions{Interval: gitprotocol.HistogramIntervalMonth, GroupBy: gitprotocol.HistogramGroupByAuthor}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.selector, func(t *testing.T) {
+			sp, err := filter.SelectPathFromString(tc.selector)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, commitHistogramOptions(sp))
+		})
+	}
+}
+
 func TestRepoSubsetTextSearch(t *testing.T) {
 	searcher.MockSearchFilesInRepo = func(ctx context.Context, repo types.MinimalRepo, gitserverRepo api.RepoName, rev string, info *search.TextPatternInfo, fetchTimeout time.Duration, stream streaming.Sender) (limitHit bool, err error) {
 		repoName := repo.Name
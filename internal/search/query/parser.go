@@ -1155,7 +1155,7 @@ func (p *parser) parseAnd() ([]Node, error) {
 		left, err = p.parseLeaves(Literal)
 	case SearchTypeStandard, SearchTypeLucky:
 		left, err = p.parseLeaves(Literal | Standard)
-	case SearchTypeKeyword:
+	case SearchTypeKeyword, SearchTypeSemantic:
 		left, err = p.parseLeaves(Literal | Standard | QuotesAsLiterals)
 	default:
 		left, err = p.parseLeaves(Literal | Standard)
@@ -1229,7 +1229,7 @@ func Parse(in string, searchType SearchType) ([]Node, error) {
 	}
 
 	switch searchType {
-	case SearchTypeKeyword:
+	case SearchTypeKeyword, SearchTypeSemantic:
 		parser.heuristics = balancedPattern | emptyParens
 	default:
 		parser.heuristics = balancedPattern | emptyParens | parensAsPatterns
@@ -9,6 +9,7 @@ import (
 	"github.com/grafana/regexp/syntax"
 
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/lib/pointers"
@@ -53,6 +54,7 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
 		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
 		"has.contributor":  func() Predicate { return &FileHasContributorPredicate{} },
+		"has.symbol":       func() Predicate { return &FileHasSymbolPredicate{} },
 	},
 	FieldRev: {
 		"at.time": func() Predicate { return &RevAtTimePredicate{} },
@@ -640,6 +642,44 @@ func (f *FileHasContributorPredicate) Unmarshal(params string, negated bool) err
 func (f FileHasContributorPredicate) Field() string { return FieldFile }
 func (f FileHasContributorPredicate) Name() string  { return "has.contributor" }
 
+/* file:has.symbol(kind name) */
+
+// FileHasSymbolPredicate represents the `file:has.symbol()` predicate, which filters to files
+// that define a symbol whose name matches the regular expression Symbol. If Kind is set, the symbol
+// must also be of that kind, as in `select:symbol.<kind>`.
+type FileHasSymbolPredicate struct {
+	Kind    string
+	Symbol  string
+	Negated bool
+}
+
+func (f *FileHasSymbolPredicate) Unmarshal(params string, negated bool) error {
+	var kind, name string
+	switch fields := strings.Fields(params); len(fields) {
+	case 1:
+		name = fields[0]
+	case 2:
+		kind, name = strings.ToLower(fields[0]), fields[1]
+		if !filter.IsSymbolKind(kind) {
+			return errors.Errorf("the file:has.symbol() predicate has invalid symbol kind %q", fields[0])
+		}
+	default:
+		return errors.New("the file:has.symbol() predicate expects a symbol name, optionally preceded by a symbol kind")
+	}
+
+	if _, err := syntax.Parse(name, syntax.Perl); err != nil {
+		return errors.Errorf("the file:has.symbol() predicate has invalid argument: %w", err)
+	}
+
+	f.Kind = kind
+	f.Symbol = name
+	f.Negated = negated
+	return nil
+}
+
+func (f FileHasSymbolPredicate) Field() string { return FieldFile }
+func (f FileHasSymbolPredicate) Name() string  { return "has.symbol" }
+
 type RevAtTimePredicate struct {
 	RevAtTime
 }
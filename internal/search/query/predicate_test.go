@@ -415,3 +415,44 @@ func TestFileHasContributorPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestFileHasSymbolPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			expected *FileHasSymbolPredicate
+			error    string
+		}
+
+		valid := []test{
+			{`name`, `Handler`, &FileHasSymbolPredicate{Symbol: "Handler"}, ""},
+			{`kind and name`, `interface ^Handler$`, &FileHasSymbolPredicate{Kind: "interface", Symbol: "^Handler$"}, ""},
+			{`kind is case insensitive`, `Class Foo`, &FileHasSymbolPredicate{Kind: "class", Symbol: "Foo"}, ""},
+			{`invalid kind`, `klass Foo`, &FileHasSymbolPredicate{}, `the file:has.symbol() predicate has invalid symbol kind "klass"`},
+			{`references is not a kind`, `references Foo`, &FileHasSymbolPredicate{}, `the file:has.symbol() predicate has invalid symbol kind "references"`},
+			{`empty`, ``, &FileHasSymbolPredicate{}, "the file:has.symbol() predicate expects a symbol name, optionally preceded by a symbol kind"},
+			{`error parsing regexp`, `(((Foo`, &FileHasSymbolPredicate{}, "the file:has.symbol() predicate has invalid argument: error parsing regexp: missing closing ): `(((Foo`"},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasSymbolPredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err != nil {
+					if tc.error == "" {
+						t.Fatalf("unexpected error: %s", err)
+					} else if tc.error != err.Error() {
+						t.Fatalf("expected error %s, got %s", tc.error, err.Error())
+					}
+				} else if tc.error != "" {
+					t.Fatalf("expected error %s, got none", tc.error)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+	})
+}
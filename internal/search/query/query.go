@@ -101,7 +101,7 @@ func For(searchType SearchType) step {
 		processType = succeeds(escapeParensHeuristic, substituteConcat(fuzzyRegexp))
 	case SearchTypeStructural:
 		processType = succeeds(labelStructural, ellipsesForHoles, substituteConcat(space))
-	case SearchTypeKeyword:
+	case SearchTypeKeyword, SearchTypeSemantic:
 		processType = succeeds(substituteConcatForKeyword(and))
 	}
 	normalize := succeeds(LowercaseFieldNames, SubstituteAliases(searchType), SubstituteCountAll)
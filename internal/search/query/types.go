@@ -43,6 +43,7 @@ const (
 	SearchTypeStandard
 	SearchTypeCodyContext
 	SearchTypeKeyword
+	SearchTypeSemantic
 )
 
 func (s SearchType) String() string {
@@ -61,6 +62,8 @@ func (s SearchType) String() string {
 		return "codycontext"
 	case SearchTypeKeyword:
 		return "keyword"
+	case SearchTypeSemantic:
+		return "semantic"
 	default:
 		return fmt.Sprintf("unknown{%d}", s)
 	}
@@ -514,6 +517,17 @@ func (p Parameters) FileHasContributor() (include []string, exclude []string) {
 	return include, exclude
 }
 
+func (p Parameters) FileHasSymbol() (include, exclude []FileHasSymbolPredicate) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasSymbolPredicate) {
+		if pred.Negated {
+			exclude = append(exclude, *pred)
+		} else {
+			include = append(include, *pred)
+		}
+	})
+	return include, exclude
+}
+
 // Exists returns whether a parameter exists in the query (whether negated or not).
 func (p Parameters) Exists(field string) bool {
 	found := false
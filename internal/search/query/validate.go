@@ -349,6 +349,10 @@ func validateCommitParameters(nodes []Node) error {
 		if field == FieldAuthor || field == FieldBefore || field == FieldAfter || field == FieldMessage {
 			seenCommitParam = field
 		}
+		// Commit histograms are computed by commit searches.
+		if field == FieldSelect && strings.HasPrefix(value, "commit.histogram") {
+			seenCommitParam = field + ":" + value
+		}
 		if field == FieldType && (value == "commit" || value == "diff") {
 			typeCommitExists = true
 		}
@@ -0,0 +1,161 @@
+// Package codenavfinder implements references.Finder with the code navigation service. It lives
+// apart from package references because code navigation depends on the search client, which in
+// turn depends on the search jobs.
+package codenavfinder
+
+import (
+	"context"
+
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/core"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search/references"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// New returns a references.Finder backed by the code navigation service, used by
+// `select:symbol.references` and `select:symbol.callers` searches.
+//
+// References are read from the precise indexes closest to the commit of the symbol. When there
+// is no such index, references fall back to search-based usages. Search-based usages cannot tell
+// calls apart from other references, so callers are only found with precise indexes.
+func New(svc *codenav.Service, repoStore database.RepoStore, gitserverClient gitserver.Client, maxIndexes int) references.Finder {
+	return &finder{
+		svc:             svc,
+		repoStore:       repoStore,
+		gitserverClient: gitserverClient,
+		maxIndexes:      maxIndexes,
+	}
+}
+
+type finder struct {
+	svc             *codenav.Service
+	repoStore       database.RepoStore
+	gitserverClient gitserver.Client
+	maxIndexes      int
+}
+
+func (f *finder) FindReferences(ctx context.Context, args references.Args) ([]references.Location, error) {
+	repo, err := f.repoStore.Get(ctx, args.Repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	path := core.NewRepoRelPathUnchecked(args.Path)
+
+	uploads, err := f.svc.GetClosestCompletedUploadsForBlob(ctx, uploadsshared.UploadMatchingOptions{
+		RepositoryID:       repo.ID,
+		Commit:             args.Commit,
+		Path:               path,
+		RootToPathMatching: uploadsshared.RootMustEnclosePath,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(uploads) == 0 {
+		if args.CallersOnly {
+			return nil, nil
+		}
+		return f.searchBasedReferences(ctx, repo, args, path)
+	}
+
+	requestState := codenav.NewRequestState(uploads, f.repoStore, authz.DefaultSubRepoPermsChecker, f.gitserverClient, repo, args.Commit, path, f.maxIndexes)
+	requestArgs := codenav.PositionalRequestArgs{
+		RequestArgs: codenav.RequestArgs{
+			RepositoryID: repo.ID,
+			Commit:       args.Commit,
+			Limit:        args.Limit,
+		},
+		Path:      path,
+		Line:      args.Range.Start.Line,
+		Character: args.Range.Start.Character,
+	}
+
+	var locations []references.Location
+	for cursor := (codenav.Cursor{}); cursor.Phase != "done" && len(locations) < args.Limit; {
+		var uploadLocations []shared.UploadLocation
+		if args.CallersOnly {
+			var calls []codenav.CallHierarchyCall
+			calls, cursor, err = f.svc.GetIncomingCalls(ctx, requestArgs, requestState, cursor)
+			for _, call := range calls {
+				uploadLocations = append(uploadLocations, call.CallSites...)
+			}
+		} else {
+			uploadLocations, cursor, err = f.svc.GetReferences(ctx, requestArgs, requestState, cursor)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, location := range uploadLocations {
+			locations = append(locations, referenceLocation(location))
+		}
+	}
+	if len(locations) > args.Limit {
+		locations = locations[:args.Limit]
+	}
+
+	return locations, nil
+}
+
+// searchBasedReferences returns the search-based usages of the symbol, excluding definitions.
+func (f *finder) searchBasedReferences(ctx context.Context, repo *types.Repo, args references.Args, path core.RepoRelPath) ([]references.Location, error) {
+	matches, err := f.svc.SearchBasedUsages(ctx, codenav.NewGitTreeTranslator(f.gitserverClient, *repo), codenav.UsagesForSymbolArgs{
+		Repo:   *repo,
+		Commit: args.Commit,
+		Path:   path,
+		SymbolRange: scip.NewRangeUnchecked([]int32{
+			int32(args.Range.Start.Line),
+			int32(args.Range.Start.Character),
+			int32(args.Range.End.Line),
+			int32(args.Range.End.Character),
+		}),
+	}, core.None[codenav.PreviousSyntacticSearch]())
+	if err != nil {
+		return nil, err
+	}
+
+	var locations []references.Location
+	for _, match := range matches {
+		if match.IsDefinition {
+			continue
+		}
+		if len(locations) >= args.Limit {
+			break
+		}
+
+		locations = append(locations, references.Location{
+			Repo:   args.Repo,
+			Commit: args.Commit,
+			Path:   match.Path.RawValue(),
+			Range: lsp.Range{
+				Start: lsp.Position{Line: int(match.Range.Start.Line), Character: int(match.Range.Start.Character)},
+				End:   lsp.Position{Line: int(match.Range.End.Line), Character: int(match.Range.End.Character)},
+			},
+		})
+	}
+
+	return locations, nil
+}
+
+func referenceLocation(location shared.UploadLocation) references.Location {
+	return references.Location{
+		Repo: types.MinimalRepo{
+			ID:   api.RepoID(location.Upload.RepositoryID),
+			Name: api.RepoName(location.Upload.RepositoryName),
+		},
+		Commit: api.CommitID(location.TargetCommit),
+		Path:   location.Path.RawValue(),
+		Range: lsp.Range{
+			Start: lsp.Position{Line: location.TargetRange.Start.Line, Character: location.TargetRange.Start.Character},
+			End:   lsp.Position{Line: location.TargetRange.End.Line, Character: location.TargetRange.End.Character},
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package references
+
+import (
+	"context"
+
+	"github.com/sourcegraph/go-lsp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// Finder finds the references to a symbol. It is implemented with the code navigation service in
+// package codenavfinder, which cannot be referenced by search jobs directly.
+type Finder interface {
+	// FindReferences returns up to args.Limit references to the symbol at the given range. The
+	// returned locations must be filtered by the repository and sub-repository permissions of the
+	// actor in the context.
+	FindReferences(ctx context.Context, args Args) ([]Location, error)
+}
+
+// DefaultFinder is the Finder used by `select:symbol.references` and `select:symbol.callers`
+// searches. It is set on startup by services that provide code intelligence, and is nil
+// otherwise.
+var DefaultFinder Finder
+
+// Args describes a symbol matched by a search.
+type Args struct {
+	Repo   types.MinimalRepo
+	Commit api.CommitID
+	Path   string
+
+	// Range is the zero-based range of the symbol name.
+	Range lsp.Range
+
+	// CallersOnly restricts the references to call sites of the symbol.
+	CallersOnly bool
+
+	Limit int
+}
+
+// Location is a reference to a symbol.
+type Location struct {
+	Repo   types.MinimalRepo
+	Commit api.CommitID
+	Path   string
+
+	// Range is the zero-based range of the reference.
+	Range lsp.Range
+}
@@ -0,0 +1,298 @@
+package references
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+const (
+	// maxSymbols bounds the number of matched symbols for which references are resolved.
+	maxSymbols = 100
+
+	// maxReferencesPerSymbol bounds the number of references resolved for a single symbol.
+	maxReferencesPerSymbol = 500
+
+	// maxFileSize bounds the number of bytes read from a file to display its references.
+	// References past this offset are dropped.
+	maxFileSize = 1 << 20
+)
+
+// NewSelectJob creates a job that replaces the symbol matches of its child with the references
+// to the matched symbols, found by DefaultFinder. If callersOnly is true, only call sites are
+// returned. Each file containing references is streamed as a file match with one chunk match per
+// referencing line.
+func NewSelectJob(callersOnly bool, child job.Job) job.Job {
+	return &selectJob{
+		child:       child,
+		callersOnly: callersOnly,
+	}
+}
+
+type selectJob struct {
+	child       job.Job
+	callersOnly bool
+}
+
+func (s *selectJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	finder := DefaultFinder
+	if finder == nil {
+		return search.AlertForReferencesUnavailable(), nil
+	}
+
+	var (
+		mu          sync.Mutex
+		numSymbols  int
+		seen        = map[referenceKey]struct{}{}
+		maxAlerter  search.MaxAlerter
+		selectedRef = streaming.StreamFunc(func(event streaming.SearchEvent) {
+			var symbols []symbolArgs
+			for _, match := range event.Results {
+				fm, ok := match.(*result.FileMatch)
+				if !ok {
+					continue
+				}
+				for _, sm := range fm.Symbols {
+					symbols = append(symbols, symbolArgs{fm: fm, symbol: sm.Symbol})
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			var locations []Location
+			for _, symbol := range symbols {
+				if numSymbols >= maxSymbols {
+					event.Stats.IsLimitHit = true
+					break
+				}
+				numSymbols++
+
+				found, err := finder.FindReferences(ctx, Args{
+					Repo:        symbol.fm.Repo,
+					Commit:      symbol.fm.CommitID,
+					Path:        symbol.fm.Path,
+					Range:       symbol.symbol.Range(),
+					CallersOnly: s.callersOnly,
+					Limit:       maxReferencesPerSymbol,
+				})
+				if err != nil {
+					if ctx.Err() == nil {
+						clients.Logger.Warn("failed to find references", log.String("repo", string(symbol.fm.Repo.Name)), log.String("path", symbol.fm.Path), log.Error(err))
+						maxAlerter.Add(search.AlertForReferencesSearchError())
+					}
+					continue
+				}
+
+				for _, location := range found {
+					key := keyOf(location)
+					if _, ok := seen[key]; ok {
+						continue
+					}
+					seen[key] = struct{}{}
+					locations = append(locations, location)
+				}
+			}
+
+			event.Results = referenceMatches(ctx, clients, locations)
+			stream.Send(event)
+		})
+	)
+
+	alert, err = s.child.Run(ctx, clients, selectedRef)
+	maxAlerter.Add(alert)
+	return maxAlerter.Alert, err
+}
+
+func (s *selectJob) Name() string {
+	return "SelectReferencesJob"
+}
+
+func (s *selectJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res, attribute.Bool("callersOnly", s.callersOnly))
+	}
+	return res
+}
+
+func (s *selectJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *selectJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
+
+type symbolArgs struct {
+	fm     *result.FileMatch
+	symbol result.Symbol
+}
+
+type referenceKey struct {
+	repo      api.RepoID
+	commit    api.CommitID
+	path      string
+	line      int
+	character int
+}
+
+func keyOf(location Location) referenceKey {
+	return referenceKey{
+		repo:      location.Repo.ID,
+		commit:    location.Commit,
+		path:      location.Path,
+		line:      location.Range.Start.Line,
+		character: location.Range.Start.Character,
+	}
+}
+
+type fileKey struct {
+	repo   api.RepoID
+	commit api.CommitID
+	path   string
+}
+
+// referenceMatches groups the given locations by file and reads the referencing lines from
+// gitserver. Files that cannot be read are skipped.
+func referenceMatches(ctx context.Context, clients job.RuntimeClients, locations []Location) result.Matches {
+	var (
+		files        []fileKey
+		fileMatches  = map[fileKey]*result.FileMatch{}
+		rangesByFile = map[fileKey][]lsp.Range{}
+	)
+	for _, location := range locations {
+		key := fileKey{repo: location.Repo.ID, commit: location.Commit, path: location.Path}
+		if _, ok := fileMatches[key]; !ok {
+			files = append(files, key)
+			fileMatches[key] = &result.FileMatch{
+				File: result.File{
+					Repo:     location.Repo,
+					CommitID: location.Commit,
+					Path:     location.Path,
+				},
+			}
+		}
+		rangesByFile[key] = append(rangesByFile[key], location.Range)
+	}
+
+	matches := make(result.Matches, 0, len(files))
+	for _, key := range files {
+		fm := fileMatches[key]
+
+		content, err := readFile(ctx, clients, fm.File)
+		if err != nil {
+			if ctx.Err() == nil {
+				clients.Logger.Warn("failed to read referencing file", log.String("repo", string(fm.Repo.Name)), log.String("path", fm.Path), log.Error(err))
+			}
+			continue
+		}
+
+		fm.ChunkMatches = chunkMatches(content, rangesByFile[key])
+		if len(fm.ChunkMatches) > 0 {
+			matches = append(matches, fm)
+		}
+	}
+	return matches
+}
+
+func readFile(ctx context.Context, clients job.RuntimeClients, file result.File) ([]byte, error) {
+	r, err := clients.Gitserver.NewFileReader(ctx, file.Repo.Name, file.CommitID, file.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(io.LimitReader(r, maxFileSize))
+}
+
+// chunkMatches returns one chunk match per line of content containing the start of one of the
+// given ranges. Ranges spanning several lines are truncated to the end of their first line.
+func chunkMatches(content []byte, ranges []lsp.Range) result.ChunkMatches {
+	var lineOffsets []int
+	for offset := 0; offset <= len(content); {
+		lineOffsets = append(lineOffsets, offset)
+		i := bytes.IndexByte(content[offset:], '\n')
+		if i < 0 {
+			break
+		}
+		offset += i + 1
+	}
+
+	rangesByLine := map[int][]lsp.Range{}
+	for _, r := range ranges {
+		if r.Start.Line < 0 || r.Start.Line >= len(lineOffsets) {
+			continue
+		}
+		rangesByLine[r.Start.Line] = append(rangesByLine[r.Start.Line], r)
+	}
+
+	lines := make([]int, 0, len(rangesByLine))
+	for line := range rangesByLine {
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+
+	chunks := make(result.ChunkMatches, 0, len(lines))
+	for _, line := range lines {
+		start := lineOffsets[line]
+		end := len(content)
+		if i := bytes.IndexByte(content[start:], '\n'); i >= 0 {
+			end = start + i
+		}
+		text := content[start:end]
+
+		lineRanges := rangesByLine[line]
+		sort.Slice(lineRanges, func(i, j int) bool { return lineRanges[i].Start.Character < lineRanges[j].Start.Character })
+
+		chunk := result.ChunkMatch{
+			Content:      string(text),
+			ContentStart: result.Location{Offset: start, Line: line},
+		}
+		for _, r := range lineRanges {
+			endCharacter := r.End.Character
+			if r.End.Line != line {
+				endCharacter = utf8.RuneCount(text)
+			}
+
+			chunk.Ranges = append(chunk.Ranges, result.Range{
+				Start: lineLocation(text, start, line, r.Start.Character),
+				End:   lineLocation(text, start, line, endCharacter),
+			})
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// lineLocation returns the location of the given character of a line, clamped to the end of
+// the line.
+func lineLocation(text []byte, lineOffset, line, character int) result.Location {
+	offset, column := 0, 0
+	for offset < len(text) && column < character {
+		_, size := utf8.DecodeRune(text[offset:])
+		offset += size
+		column++
+	}
+	return result.Location{Offset: lineOffset + offset, Line: line, Column: column}
+}
@@ -0,0 +1,163 @@
+package references
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type finderFunc func(context.Context, Args) ([]Location, error)
+
+func (f finderFunc) FindReferences(ctx context.Context, args Args) ([]Location, error) {
+	return f(ctx, args)
+}
+
+func setDefaultFinder(t *testing.T, finder Finder) {
+	old := DefaultFinder
+	DefaultFinder = finder
+	t.Cleanup(func() { DefaultFinder = old })
+}
+
+var repo = types.MinimalRepo{ID: 1, Name: "repo"}
+
+func symbolMatch(path string, symbols ...string) *result.FileMatch {
+	fm := &result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: path}}
+	for i, name := range symbols {
+		fm.Symbols = append(fm.Symbols, &result.SymbolMatch{
+			File:   &fm.File,
+			Symbol: result.Symbol{Name: name, Line: i + 1, Character: 5},
+		})
+	}
+	return fm
+}
+
+func location(path string, line, character, length int) Location {
+	return Location{
+		Repo:   repo,
+		Commit: "deadbeef",
+		Path:   path,
+		Range: lsp.Range{
+			Start: lsp.Position{Line: line, Character: character},
+			End:   lsp.Position{Line: line, Character: character + length},
+		},
+	}
+}
+
+func newChildJob(matches ...result.Match) *mockjob.MockJob {
+	child := mockjob.NewMockJob()
+	child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: matches})
+		return nil, nil
+	})
+	return child
+}
+
+func newClients(t *testing.T, files map[string]string) job.RuntimeClients {
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.NewFileReaderFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, _ api.CommitID, path string) (io.ReadCloser, error) {
+		content, ok := files[path]
+		if !ok {
+			return nil, errors.Newf("file not found: %s", path)
+		}
+		return io.NopCloser(strings.NewReader(content)), nil
+	})
+	return job.RuntimeClients{Logger: logtest.Scoped(t), Gitserver: gitserverClient}
+}
+
+func TestSelectJob(t *testing.T) {
+	files := map[string]string{
+		"main.go":  "package main\n\nfunc main() {\n\tx := Parse(Parse(\"a\"))\n}\n",
+		"other.go": "package main\n\n// héllo Parse\nvar y = Parse\n",
+	}
+
+	var calls []Args
+	setDefaultFinder(t, finderFunc(func(_ context.Context, args Args) ([]Location, error) {
+		calls = append(calls, args)
+		return []Location{
+			location("main.go", 3, 6, 5),
+			location("main.go", 3, 12, 5),
+			location("other.go", 2, 9, 5),
+			location("other.go", 3, 8, 5),
+			// Duplicates of references of the other symbol are dropped
+			location("main.go", 3, 6, 5),
+			// Files that cannot be read are skipped
+			location("missing.go", 0, 0, 5),
+		}, nil
+	}))
+
+	stream := streaming.NewAggregatingStream()
+	selectJob := NewSelectJob(true, newChildJob(symbolMatch("parse.go", "Parse", "ParseAll")))
+	alert, err := selectJob.Run(context.Background(), newClients(t, files), stream)
+	require.NoError(t, err)
+	require.Nil(t, alert)
+
+	require.Len(t, calls, 2)
+	require.True(t, calls[0].CallersOnly)
+	require.Equal(t, "parse.go", calls[0].Path)
+	require.Equal(t, lsp.Range{Start: lsp.Position{Line: 0, Character: 5}, End: lsp.Position{Line: 0, Character: 10}}, calls[0].Range)
+
+	require.Len(t, stream.Results, 2)
+
+	mainMatch := stream.Results[0].(*result.FileMatch)
+	require.Equal(t, "main.go", mainMatch.Path)
+	require.Equal(t, result.ChunkMatches{{
+		Content:      "\tx := Parse(Parse(\"a\"))",
+		ContentStart: result.Location{Offset: 28, Line: 3},
+		Ranges: result.Ranges{
+			{Start: result.Location{Offset: 34, Line: 3, Column: 6}, End: result.Location{Offset: 39, Line: 3, Column: 11}},
+			{Start: result.Location{Offset: 40, Line: 3, Column: 12}, End: result.Location{Offset: 45, Line: 3, Column: 17}},
+		},
+	}}, mainMatch.ChunkMatches)
+
+	// Columns are counted in characters, offsets in bytes
+	otherMatch := stream.Results[1].(*result.FileMatch)
+	require.Equal(t, "other.go", otherMatch.Path)
+	require.Len(t, otherMatch.ChunkMatches, 2)
+	require.Equal(t, result.Range{
+		Start: result.Location{Offset: 24, Line: 2, Column: 9},
+		End:   result.Location{Offset: 29, Line: 2, Column: 14},
+	}, otherMatch.ChunkMatches[0].Ranges[0])
+}
+
+func TestSelectJobUnavailable(t *testing.T) {
+	setDefaultFinder(t, nil)
+
+	child := newChildJob(symbolMatch("parse.go", "Parse"))
+	stream := streaming.NewAggregatingStream()
+	alert, err := NewSelectJob(false, child).Run(context.Background(), newClients(t, nil), stream)
+	require.NoError(t, err)
+	require.Equal(t, search.AlertForReferencesUnavailable(), alert)
+	require.Empty(t, stream.Results)
+	require.Empty(t, child.RunFunc.History())
+}
+
+func TestSelectJobFinderError(t *testing.T) {
+	setDefaultFinder(t, finderFunc(func(_ context.Context, args Args) ([]Location, error) {
+		if args.Path == "broken.go" {
+			return nil, errors.New("no index")
+		}
+		return []Location{location("main.go", 0, 0, 4)}, nil
+	}))
+
+	stream := streaming.NewAggregatingStream()
+	selectJob := NewSelectJob(false, newChildJob(symbolMatch("broken.go", "Broken"), symbolMatch("parse.go", "Parse")))
+	alert, err := selectJob.Run(context.Background(), newClients(t, map[string]string{"main.go": "func main() {}\n"}), stream)
+	require.NoError(t, err)
+	require.Equal(t, search.AlertForReferencesSearchError(), alert)
+	require.Len(t, stream.Results, 1)
+}
@@ -283,8 +283,9 @@ func (r *Resolver) doQueryDB(ctx context.Context, tr trace.Trace, op search.Repo
 	}
 
 	// Filter by search context repository revisions only if this search context doesn't have
-	// a query, which replaces the context:foo term at query parsing time.
-	if searchContext.Query == "" {
+	// a query, which replaces the context:foo term at query parsing time, or if its query has
+	// been materialized into repository revisions.
+	if searchContext.Query == "" || searchcontexts.IsMaterializedSearchContext(searchContext) {
 		options.SearchContextID = searchContext.ID
 	}
 
@@ -325,7 +326,7 @@ func (r *Resolver) doQueryDB(ctx context.Context, tr trace.Trace, op search.Repo
 	}
 
 	var searchContextRepositoryRevisions map[api.RepoID]RepoRevSpecs
-	if !searchcontexts.IsAutoDefinedSearchContext(searchContext) && searchContext.Query == "" {
+	if !searchcontexts.IsAutoDefinedSearchContext(searchContext) && (searchContext.Query == "" || searchcontexts.IsMaterializedSearchContext(searchContext)) {
 		scRepoRevs, err := searchcontexts.GetRepositoryRevisions(ctx, r.db, searchContext.ID)
 		if err != nil {
 			return dbResolved{}, nil, err
@@ -115,6 +115,9 @@ func (cm *CommitMatch) Select(path filter.SelectPath) Match {
 		}
 	case filter.Commit:
 		fields := path[1:]
+		if len(fields) > 0 && fields[0] == "histogram" {
+			return nil // Histograms are computed by the commit search itself.
+		}
 		if len(fields) > 0 && fields[0] == "diff" {
 			if cm.DiffPreview == nil {
 				return nil // Not a diff result.
@@ -0,0 +1,81 @@
+package result
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// CommitHistogramMatch is the number of commits in a repo matching a
+// select:commit.histogram search, counted per time bucket. If the histogram
+// is grouped by author, there is one match per author and repo.
+type CommitHistogramMatch struct {
+	Repo types.MinimalRepo
+
+	// Interval is the width of the buckets: "day", "week" or "month".
+	Interval string
+
+	// AuthorName and AuthorEmail are only set if the histogram is grouped by
+	// author.
+	AuthorName  string
+	AuthorEmail string
+
+	// Buckets are the non-empty buckets, ordered by their start.
+	Buckets []CommitHistogramBucket
+}
+
+// CommitHistogramBucket is the number of commits authored in the bucket
+// starting at Start.
+type CommitHistogramBucket struct {
+	Start time.Time
+	Count int
+}
+
+// TotalCount returns the number of commits across all buckets.
+func (m *CommitHistogramMatch) TotalCount() int {
+	total := 0
+	for _, b := range m.Buckets {
+		total += b.Count
+	}
+	return total
+}
+
+func (m *CommitHistogramMatch) RepoName() types.MinimalRepo {
+	return m.Repo
+}
+
+// ResultCount counts a histogram as a single result, regardless of how many
+// commits it counts, so that result limits don't truncate histograms.
+func (m *CommitHistogramMatch) ResultCount() int {
+	return 1
+}
+
+func (m *CommitHistogramMatch) Limit(limit int) int {
+	return limit - 1
+}
+
+func (m *CommitHistogramMatch) Select(path filter.SelectPath) Match {
+	switch path.Root() {
+	case filter.Repository:
+		return &RepoMatch{
+			Name: m.Repo.Name,
+			ID:   m.Repo.ID,
+		}
+	case filter.Commit:
+		if len(path) > 1 && path[1] == "histogram" {
+			return m
+		}
+	}
+	return nil
+}
+
+func (m *CommitHistogramMatch) Key() Key {
+	return Key{
+		Repo:            m.Repo.Name,
+		HistogramSeries: m.AuthorEmail,
+		TypeRank:        rankCommitHistogramMatch,
+	}
+}
+
+func (m *CommitHistogramMatch) searchResultMarker() {}
@@ -102,6 +102,10 @@ type FileMatch struct {
 	// Note: this is a pointer since usually this is unset. Pointer is 8 bytes
 	// vs an empty string which is 16 bytes.
 	Debug *string `json:"-"`
+
+	// RankingExplanation is set if the search requested ranking
+	// explanations (search.Features.ExplainRanking) or debug output.
+	RankingExplanation *RankingExplanation `json:"-"`
 }
 
 func (fm *FileMatch) RepoName() types.MinimalRepo {
@@ -251,6 +255,11 @@ type ChunkMatch struct {
 	// ordering of ranges, and also does not guarantee that the ranges are
 	// non-overlapping.
 	Ranges Ranges
+
+	// RankingExplanation explains the score of the chunk, if the search
+	// requested ranking explanations or debug output and the backend
+	// scores chunks.
+	RankingExplanation *RankingExplanation `json:"-"`
 }
 
 // MatchedContent returns the content matched by the ranges in this ChunkMatch.
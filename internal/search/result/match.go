@@ -9,7 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
-// Match is *FileMatch | *RepoMatch | *CommitMatch. We have a private method
+// Match is *FileMatch | *RepoMatch | *CommitMatch | *CommitHistogramMatch. We have a private method
 // to ensure only those types implement Match.
 type Match interface {
 	ResultCount() int
@@ -36,6 +36,7 @@ var (
 	_ Match = (*CommitMatch)(nil)
 	_ Match = (*CommitDiffMatch)(nil)
 	_ Match = (*OwnerMatch)(nil)
+	_ Match = (*CommitHistogramMatch)(nil)
 )
 
 // Match ranks are used for sorting the different match types.
@@ -47,6 +48,8 @@ const (
 	rankDiffMatch   = 2
 	rankRepoMatch   = 3
 	rankOwnerMatch  = 4
+
+	rankCommitHistogramMatch = 5
 )
 
 // Key is a sorting or deduplicating key for a Match. It contains all the
@@ -81,6 +84,10 @@ type Key struct {
 	// Empty if this is not a Key for an OwnerMatch.
 	OwnerMetadata string
 
+	// HistogramSeries identifies the series of a commit histogram within a
+	// repo. Empty if this is not a Key for a CommitHistogramMatch.
+	HistogramSeries string
+
 	// TypeRank is the sorting rank of the type this key belongs to.
 	TypeRank int
 }
@@ -116,6 +123,10 @@ func (k Key) Compare(other Key) int {
 		return v
 	}
 
+	if v := cmp.Compare(k.HistogramSeries, other.HistogramSeries); v != 0 {
+		return v
+	}
+
 	return cmp.Compare(k.TypeRank, other.TypeRank)
 }
 
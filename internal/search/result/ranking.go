@@ -0,0 +1,134 @@
+package result
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RankingExplanation explains the score a backend assigned to a match, to
+// help understand why results are ordered the way they are.
+type RankingExplanation struct {
+	// Backend is the backend which produced the match, "zoekt" or
+	// "searcher".
+	Backend string
+
+	// Scorer is the scoring function used by the backend, "default" or
+	// "bm25". It is empty if the match was not scored.
+	Scorer string
+
+	// Score is the score of the match. Higher is better.
+	Score float64
+
+	// Components are the signals which make up Score.
+	Components []ScoreComponent
+}
+
+// ScoreComponent is one of the signals which make up a score.
+type ScoreComponent struct {
+	// Name is the name of the signal, as reported by the backend. For
+	// example "file-rank" or "WordMatch".
+	Name string
+
+	// Value is what the signal contributes to the score. For scorers which
+	// do not add up their signals (BM25) it is the value of the signal.
+	Value float64
+
+	// Raw is the value of the signal before it was weighted, if the backend
+	// reports it.
+	Raw *float64
+}
+
+// Description returns a human readable description of the signal, or an
+// empty string if the signal is unknown.
+func (c ScoreComponent) Description() string {
+	if strings.HasPrefix(c.Name, "kind:") {
+		return "Boost for the kind of symbol matched, per language"
+	}
+	return scoreComponentDescriptions[c.Name]
+}
+
+// scoreComponentDescriptions describes the signals reported by Zoekt's
+// scorers, see score.go and contentprovider.go in Zoekt.
+var scoreComponentDescriptions = map[string]string{
+	// File signals of the default scorer
+	"atom":      "Boost for matching more than one atom of the query; raw is the number of atoms matched",
+	"fragment":  "Score of the best matching chunk in the file",
+	"file-rank": "Rank of the file computed by code intelligence ranking, based on references to the file",
+	"doc-order": "Position of the file in its index shard; files are indexed in order of importance and earlier files score higher",
+	"repo-rank": "Rank of the repository, based on its stars and priority",
+
+	// Chunk signals of the default scorer
+	"WordMatch":        "The match starts and ends at word boundaries",
+	"PartialWordMatch": "The match starts or ends at a word boundary",
+	"Base":             "The match is the base name of the file",
+	"EdgeBase":         "The match is a prefix or suffix of the base name of the file",
+	"InnerBase":        "The match is inside the base name of the file",
+	"Symbol":           "The match is a symbol definition",
+	"EdgeSymbol":       "The match is a prefix or suffix of a symbol definition",
+	"OverlapSymbol":    "The match overlaps a symbol definition",
+	"boost":            "Weight the query gives the match; it multiplies the score of the chunk",
+
+	// Signals of the BM25 scorer
+	"sum-termFrequencies": "Number of times the terms of the query occur in the file (file name matches count more)",
+	"length-ratio":        "Length of the file relative to the average file length",
+}
+
+// ParseZoektScoreDebug parses the debug strings Zoekt reports for files and
+// chunks when SearchOptions.DebugScore is set. For example
+//
+//	score: 7512.03 <- atom(2):250.00, fragment:7250.00, doc-order:12.03
+//	bm25-score: 3.46 <- sum-termFrequencies: 4, length-ratio: 0.71
+//	score:7250.00 <- WordMatch:500.00, Symbol:7000.00, kind:Go:func:50.00
+//
+// It returns nil if debug is not in this format.
+func ParseZoektScoreDebug(debug string) *RankingExplanation {
+	head, tail, ok := strings.Cut(debug, "<-")
+	if !ok {
+		return nil
+	}
+
+	scorer, score, ok := strings.Cut(head, ":")
+	if !ok {
+		return nil
+	}
+	e := &RankingExplanation{Backend: "zoekt"}
+	switch strings.TrimSpace(scorer) {
+	case "score":
+		e.Scorer = "default"
+	case "bm25-score":
+		e.Scorer = "bm25"
+	default:
+		return nil
+	}
+	var err error
+	if e.Score, err = strconv.ParseFloat(strings.TrimSpace(score), 64); err != nil {
+		return nil
+	}
+
+	for _, part := range strings.Split(tail, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		// Names can contain colons (kind:Go:func), values can not.
+		i := strings.LastIndexByte(part, ':')
+		if i < 0 {
+			return nil
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(part[i+1:]), 64)
+		if err != nil {
+			return nil
+		}
+		c := ScoreComponent{Name: strings.TrimSpace(part[:i]), Value: value}
+
+		// Raw values are reported in parentheses, atom(2).
+		if name, raw, ok := strings.Cut(c.Name, "("); ok && strings.HasSuffix(raw, ")") {
+			if v, err := strconv.ParseFloat(strings.TrimSuffix(raw, ")"), 64); err == nil {
+				c.Name, c.Raw = name, &v
+			}
+		}
+		e.Components = append(e.Components, c)
+	}
+	return e
+}
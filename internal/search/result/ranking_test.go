@@ -0,0 +1,83 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestParseZoektScoreDebug(t *testing.T) {
+	cases := []struct {
+		debug string
+		want  *RankingExplanation
+	}{{
+		debug: "score: 7512.03 <- atom(2):250.00, fragment:7250.00, doc-order:12.03",
+		want: &RankingExplanation{
+			Backend: "zoekt",
+			Scorer:  "default",
+			Score:   7512.03,
+			Components: []ScoreComponent{
+				{Name: "atom", Value: 250, Raw: pointers.Ptr(2.0)},
+				{Name: "fragment", Value: 7250},
+				{Name: "doc-order", Value: 12.03},
+			},
+		},
+	}, {
+		debug: "bm25-score: 3.46 <- sum-termFrequencies: 4, length-ratio: 0.71",
+		want: &RankingExplanation{
+			Backend: "zoekt",
+			Scorer:  "bm25",
+			Score:   3.46,
+			Components: []ScoreComponent{
+				{Name: "sum-termFrequencies", Value: 4},
+				{Name: "length-ratio", Value: 0.71},
+			},
+		},
+	}, {
+		debug: "score:7250.00 <- WordMatch:500.00, Symbol:7000.00, kind:Go:func:50.00",
+		want: &RankingExplanation{
+			Backend: "zoekt",
+			Scorer:  "default",
+			Score:   7250,
+			Components: []ScoreComponent{
+				{Name: "WordMatch", Value: 500},
+				{Name: "Symbol", Value: 7000},
+				{Name: "kind:Go:func", Value: 50},
+			},
+		},
+	}, {
+		debug: "score:0.00 <- ",
+		want: &RankingExplanation{
+			Backend: "zoekt",
+			Scorer:  "default",
+		},
+	}, {
+		debug: "",
+	}, {
+		debug: "unknown: 1.00 <- a:1.00",
+	}, {
+		debug: "score: 1.00 <- a:b",
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.debug, func(t *testing.T) {
+			got := ParseZoektScoreDebug(tc.debug)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected explanation (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScoreComponentDescription(t *testing.T) {
+	for _, name := range []string{"file-rank", "repo-rank", "Symbol", "kind:Go:func", "sum-termFrequencies"} {
+		if (ScoreComponent{Name: name}).Description() == "" {
+			t.Errorf("expected a description for %q", name)
+		}
+	}
+	if d := (ScoreComponent{Name: "unknown"}).Description(); d != "" {
+		t.Errorf("expected no description for unknown signal, got %q", d)
+	}
+}
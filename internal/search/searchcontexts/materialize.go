@@ -0,0 +1,312 @@
+package searchcontexts
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/gobwas/glob"
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// defaultRevision is searched in the repositories of a materialized search context that neither
+// match a revision rule nor have a revision in the query of the context.
+const defaultRevision = "HEAD"
+
+// errResolvingRepositories is recorded in place of errors that may name repositories, since the
+// refresh history of a search context is visible to everyone who can view the context, including
+// users without access to all of its repositories.
+var errResolvingRepositories = errors.New("failed to resolve the repositories of the search context")
+
+// repositoryError wraps an error that may name repositories. Only errResolvingRepositories is
+// recorded for it in the refresh history.
+type repositoryError struct{ error }
+
+func (e repositoryError) Unwrap() error { return e.error }
+
+// Materializer resolves the repositories matching the query of search contexts with a refresh
+// interval and stores them, pinned to the revisions selected by the revision rules of the
+// context, as the repository revisions of the context.
+type Materializer struct {
+	db        database.DB
+	gitserver gitserver.Client
+	logger    log.Logger
+}
+
+func NewMaterializer(db database.DB, gitserverClient gitserver.Client, logger log.Logger) *Materializer {
+	return &Materializer{
+		db:        db,
+		gitserver: gitserverClient,
+		logger:    logger,
+	}
+}
+
+// RefreshDue refreshes all search contexts that are due for a refresh and returns how many of
+// them were refreshed successfully. It must be called with an internal actor.
+func (m *Materializer) RefreshDue(ctx context.Context) (refreshed int, err error) {
+	searchContexts, err := m.db.SearchContexts().ListSearchContextsDueForRefresh(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs error
+	for _, sc := range searchContexts {
+		if _, err := m.Refresh(ctx, sc); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "refreshing search context %d", sc.ID))
+			continue
+		}
+		refreshed++
+	}
+	return refreshed, errs
+}
+
+// Refresh materializes the repositories of a search context and records the refresh in the
+// history of the context. A failed refresh is recorded too and leaves the repositories of the
+// context untouched.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is an internal actor or has permission to update the search context.
+func (m *Materializer) Refresh(ctx context.Context, searchContext *types.SearchContext) (*types.SearchContextRefresh, error) {
+	store := m.db.SearchContexts()
+
+	repositoryRevisions, err := m.resolveRepositoryRevisions(ctx, searchContext)
+	if err != nil {
+		// Errors in the query or revision rules are the context's own and can be shown as is
+		message := err.Error()
+		if errors.HasType[repositoryError](err) {
+			m.logger.Warn("failed to resolve the repositories of a search context",
+				log.Int64("searchContextID", searchContext.ID),
+				log.Error(err))
+			message = errResolvingRepositories.Error()
+		}
+
+		_, recordErr := store.CreateSearchContextRefresh(ctx, &types.SearchContextRefresh{
+			SearchContextID: searchContext.ID,
+			Error:           message,
+		})
+		return nil, errors.Append(err, recordErr)
+	}
+
+	previous, err := store.GetSearchContextRepositoryRevisions(ctx, searchContext.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, err := store.MaterializeSearchContext(ctx, searchContext.ID, repositoryRevisions, &types.SearchContextRefresh{
+		RepoCount: int32(len(repositoryRevisions)),
+		Changes:   diffRepositoryRevisions(previous, repositoryRevisions),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.logger.Debug("materialized search context",
+		log.Int64("searchContextID", searchContext.ID),
+		log.Int("repos", len(repositoryRevisions)),
+		log.Int("changes", len(refresh.Changes)))
+	return refresh, nil
+}
+
+func (m *Materializer) resolveRepositoryRevisions(ctx context.Context, searchContext *types.SearchContext) ([]*types.SearchContextRepositoryRevisions, error) {
+	rules, err := compileRevisionRules(searchContext.RevisionRules)
+	if err != nil {
+		return nil, err
+	}
+
+	repoOpts, err := ParseRepoOpts(searchContext.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[api.RepoID]*types.SearchContextRepositoryRevisions{}
+	// pinned holds the repositories whose revision was resolved by a rule. The rule takes
+	// precedence over the revisions of the query and only needs to be resolved once.
+	pinned := map[api.RepoID]struct{}{}
+	for _, opts := range repoOpts {
+		repos, err := m.db.Repos().ListMinimalRepos(ctx, opts.ReposListOptions)
+		if err != nil {
+			return nil, repositoryError{err}
+		}
+
+		for _, repo := range repos {
+			if _, ok := pinned[repo.ID]; ok {
+				continue
+			}
+
+			revisions := opts.RevSpecs
+			if rule := rules.match(repo.Name); rule != nil {
+				rev, err := m.resolveRule(ctx, repo.Name, rule)
+				if err != nil {
+					return nil, repositoryError{err}
+				}
+				pinned[repo.ID] = struct{}{}
+				if rev == "" {
+					// Repositories without a ref matching their rule are left out, rather than
+					// searched at a revision the rule was meant to avoid.
+					continue
+				}
+				revisions = []string{rev}
+			} else if len(revisions) == 0 {
+				revisions = []string{defaultRevision}
+			}
+
+			repoRevs, ok := byID[repo.ID]
+			if !ok {
+				repoRevs = &types.SearchContextRepositoryRevisions{Repo: repo}
+				byID[repo.ID] = repoRevs
+			}
+			for _, rev := range revisions {
+				if !slices.Contains(repoRevs.Revisions, rev) {
+					repoRevs.Revisions = append(repoRevs.Revisions, rev)
+				}
+			}
+		}
+	}
+
+	out := make([]*types.SearchContextRepositoryRevisions, 0, len(byID))
+	for _, repoRevs := range byID {
+		sort.Strings(repoRevs.Revisions)
+		out = append(out, repoRevs)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Repo.ID < out[j].Repo.ID })
+	return out, nil
+}
+
+// resolveRule returns the short name of the latest ref of the repository matching the rule, or an
+// empty string if there is none.
+func (m *Materializer) resolveRule(ctx context.Context, repo api.RepoName, rule *revisionRule) (string, error) {
+	refs, err := m.gitserver.ListRefs(ctx, repo, gitserver.ListRefsOpts{
+		TagsOnly:  rule.tags,
+		HeadsOnly: !rule.tags,
+	})
+	if err != nil {
+		if gitdomain.IsRepoNotExist(err) || gitdomain.IsCloneInProgress(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "listing refs of %s", repo)
+	}
+
+	var latest *gitdomain.Ref
+	for i := range refs {
+		if !rule.pattern.Match(refs[i].ShortName) {
+			continue
+		}
+		if latest == nil || refIsNewer(&refs[i], latest) {
+			latest = &refs[i]
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.ShortName, nil
+}
+
+// refIsNewer returns true if a is a later ref than b. Refs named after semantic versions are
+// ordered by version, all others by the date they were last modified.
+func refIsNewer(a, b *gitdomain.Ref) bool {
+	va, errA := semver.NewVersion(a.ShortName)
+	vb, errB := semver.NewVersion(b.ShortName)
+	if errA == nil && errB == nil && !va.Equal(vb) {
+		return va.GreaterThan(vb)
+	}
+	if !a.CreatedDate.Equal(b.CreatedDate) {
+		return a.CreatedDate.After(b.CreatedDate)
+	}
+	return a.ShortName > b.ShortName
+}
+
+type revisionRule struct {
+	repo    *regexp.Regexp // nil matches all repositories
+	pattern glob.Glob
+	tags    bool
+}
+
+type revisionRules []*revisionRule
+
+func compileRevisionRules(rules []types.SearchContextRevisionRule) (revisionRules, error) {
+	compiled := make(revisionRules, 0, len(rules))
+	for _, r := range rules {
+		if (r.Tag == "") == (r.Branch == "") {
+			return nil, errors.Errorf("search context revision rule for repo %q must set exactly one of tag and branch", r.Repo)
+		}
+
+		var c revisionRule
+		if r.Repo != "" {
+			// Repository names are matched case-insensitively, like the repo: filter.
+			re, err := regexp.Compile("(?i)" + r.Repo)
+			if err != nil {
+				return nil, errors.Errorf("search context revision rule repo %q is invalid: %v", r.Repo, err)
+			}
+			c.repo = re
+		}
+
+		pattern := r.Branch
+		if r.Tag != "" {
+			pattern, c.tags = r.Tag, true
+		}
+		g, err := glob.Compile(pattern)
+		if err != nil {
+			return nil, errors.Errorf("search context revision rule pattern %q is invalid: %v", pattern, err)
+		}
+		c.pattern = g
+
+		compiled = append(compiled, &c)
+	}
+	return compiled, nil
+}
+
+// match returns the first rule matching the repository, if any.
+func (rs revisionRules) match(repo api.RepoName) *revisionRule {
+	for _, r := range rs {
+		if r.repo == nil || r.repo.MatchString(string(repo)) {
+			return r
+		}
+	}
+	return nil
+}
+
+// diffRepositoryRevisions returns the repositories whose revisions differ between the previous
+// and the next repository revisions of a search context.
+func diffRepositoryRevisions(previous, next []*types.SearchContextRepositoryRevisions) []types.SearchContextRefreshChange {
+	previousByID := make(map[api.RepoID]*types.SearchContextRepositoryRevisions, len(previous))
+	for _, repoRevs := range previous {
+		previousByID[repoRevs.Repo.ID] = repoRevs
+	}
+
+	var changes []types.SearchContextRefreshChange
+	for _, repoRevs := range next {
+		prev, ok := previousByID[repoRevs.Repo.ID]
+		delete(previousByID, repoRevs.Repo.ID)
+		if ok && slices.Equal(prev.Revisions, repoRevs.Revisions) {
+			continue
+		}
+		change := types.SearchContextRefreshChange{
+			RepoID:    repoRevs.Repo.ID,
+			RepoName:  repoRevs.Repo.Name,
+			Revisions: repoRevs.Revisions,
+		}
+		if ok {
+			change.PreviousRevisions = prev.Revisions
+		}
+		changes = append(changes, change)
+	}
+
+	for _, prev := range previousByID {
+		changes = append(changes, types.SearchContextRefreshChange{
+			RepoID:            prev.Repo.ID,
+			RepoName:          prev.Repo.Name,
+			PreviousRevisions: prev.Revisions,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].RepoID < changes[j].RepoID })
+	return changes
+}
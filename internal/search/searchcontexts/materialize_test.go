@@ -0,0 +1,178 @@
+package searchcontexts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockrequire "github.com/derision-test/go-mockgen/v2/testutil/require"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestMaterializer_Refresh(t *testing.T) {
+	ctx := actor.WithInternalActor(context.Background())
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	repos := []types.MinimalRepo{
+		{ID: 1, Name: "github.com/sourcegraph/api-service"},
+		{ID: 2, Name: "github.com/sourcegraph/web-service"},
+		{ID: 3, Name: "github.com/sourcegraph/docs"},
+		{ID: 4, Name: "github.com/sourcegraph/unreleased-service"},
+	}
+	refs := map[api.RepoName][]gitdomain.Ref{
+		"github.com/sourcegraph/api-service": {
+			{ShortName: "v1.10.0", CreatedDate: day(1)},
+			{ShortName: "v1.9.0", CreatedDate: day(3)},
+			{ShortName: "nightly", CreatedDate: day(4)},
+		},
+		"github.com/sourcegraph/web-service": {
+			{ShortName: "v2.0.0", CreatedDate: day(2)},
+			{ShortName: "v2.1.0-rc.1", CreatedDate: day(3)},
+		},
+		"github.com/sourcegraph/unreleased-service": {
+			{ShortName: "nightly", CreatedDate: day(4)},
+		},
+	}
+
+	newDB := func(previous []*types.SearchContextRepositoryRevisions) (*dbmocks.MockDB, *dbmocks.MockSearchContextsStore) {
+		rs := dbmocks.NewMockRepoStore()
+		rs.ListMinimalReposFunc.SetDefaultReturn(repos, nil)
+
+		sc := dbmocks.NewMockSearchContextsStore()
+		sc.GetSearchContextRepositoryRevisionsFunc.SetDefaultReturn(previous, nil)
+		sc.MaterializeSearchContextFunc.SetDefaultHook(func(_ context.Context, _ int64, _ []*types.SearchContextRepositoryRevisions, refresh *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+			return refresh, nil
+		})
+
+		db := dbmocks.NewMockDB()
+		db.ReposFunc.SetDefaultReturn(rs)
+		db.SearchContextsFunc.SetDefaultReturn(sc)
+		return db, sc
+	}
+
+	searchContext := &types.SearchContext{
+		ID:                   42,
+		Query:                `repo:^github\.com/sourcegraph/`,
+		RefreshIntervalHours: 24,
+		RevisionRules:        []types.SearchContextRevisionRule{{Repo: "-service$", Tag: "v*"}},
+	}
+
+	t.Run("pins revisions", func(t *testing.T) {
+		gs := gitserver.NewMockClient()
+		gs.ListRefsFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, opts gitserver.ListRefsOpts) ([]gitdomain.Ref, error) {
+			require.True(t, opts.TagsOnly)
+			return refs[repo], nil
+		})
+
+		previous := []*types.SearchContextRepositoryRevisions{
+			{Repo: repos[0], Revisions: []string{"v1.9.0"}},
+			{Repo: repos[1], Revisions: []string{"v2.0.0"}},
+			{Repo: repos[3], Revisions: []string{"HEAD"}},
+		}
+		db, sc := newDB(previous)
+
+		refresh, err := NewMaterializer(db, gs, logtest.Scoped(t)).Refresh(ctx, searchContext)
+		require.NoError(t, err)
+
+		mockrequire.CalledOnce(t, sc.MaterializeSearchContextFunc)
+		call := sc.MaterializeSearchContextFunc.History()[0]
+		require.Equal(t, int64(42), call.Arg1)
+
+		wantRepoRevs := []*types.SearchContextRepositoryRevisions{
+			{Repo: repos[0], Revisions: []string{"v1.10.0"}},
+			{Repo: repos[1], Revisions: []string{"v2.1.0-rc.1"}},
+			{Repo: repos[2], Revisions: []string{"HEAD"}},
+		}
+		if diff := cmp.Diff(wantRepoRevs, call.Arg2); diff != "" {
+			t.Fatalf("unexpected repository revisions (-want +got):\n%s", diff)
+		}
+
+		wantChanges := []types.SearchContextRefreshChange{
+			{RepoID: 1, RepoName: repos[0].Name, Revisions: []string{"v1.10.0"}, PreviousRevisions: []string{"v1.9.0"}},
+			{RepoID: 2, RepoName: repos[1].Name, Revisions: []string{"v2.1.0-rc.1"}, PreviousRevisions: []string{"v2.0.0"}},
+			{RepoID: 3, RepoName: repos[2].Name, Revisions: []string{"HEAD"}},
+			{RepoID: 4, RepoName: repos[3].Name, PreviousRevisions: []string{"HEAD"}},
+		}
+		require.Equal(t, int32(3), refresh.RepoCount)
+		if diff := cmp.Diff(wantChanges, refresh.Changes); diff != "" {
+			t.Fatalf("unexpected changes (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("records failures", func(t *testing.T) {
+		gs := gitserver.NewMockClient()
+		gs.ListRefsFunc.SetDefaultReturn(nil, errors.New("boom"))
+
+		db, sc := newDB(nil)
+		sc.CreateSearchContextRefreshFunc.SetDefaultHook(func(_ context.Context, refresh *types.SearchContextRefresh) (*types.SearchContextRefresh, error) {
+			return refresh, nil
+		})
+
+		_, err := NewMaterializer(db, gs, logtest.Scoped(t)).Refresh(ctx, searchContext)
+		require.ErrorContains(t, err, "boom")
+
+		mockrequire.NotCalled(t, sc.MaterializeSearchContextFunc)
+		mockrequire.CalledOnce(t, sc.CreateSearchContextRefreshFunc)
+		refresh := sc.CreateSearchContextRefreshFunc.History()[0].Arg1
+		require.Equal(t, int64(42), refresh.SearchContextID)
+		// The recorded error is visible to all viewers of the context, so it must not name repositories
+		require.Equal(t, errResolvingRepositories.Error(), refresh.Error)
+	})
+
+	t.Run("refreshes due contexts", func(t *testing.T) {
+		gs := gitserver.NewMockClient()
+		gs.ListRefsFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, _ gitserver.ListRefsOpts) ([]gitdomain.Ref, error) {
+			return refs[repo], nil
+		})
+
+		db, sc := newDB(nil)
+		sc.ListSearchContextsDueForRefreshFunc.SetDefaultReturn([]*types.SearchContext{searchContext}, nil)
+
+		refreshed, err := NewMaterializer(db, gs, logtest.Scoped(t)).RefreshDue(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, refreshed)
+		mockrequire.CalledOnce(t, sc.MaterializeSearchContextFunc)
+	})
+}
+
+func TestRefIsNewer(t *testing.T) {
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	cases := []struct {
+		name string
+		a, b gitdomain.Ref
+		want bool
+	}{{
+		name: "semver",
+		a:    gitdomain.Ref{ShortName: "v1.10.0", CreatedDate: older},
+		b:    gitdomain.Ref{ShortName: "v1.9.0", CreatedDate: newer},
+		want: true,
+	}, {
+		name: "prerelease",
+		a:    gitdomain.Ref{ShortName: "v2.0.0-rc.1", CreatedDate: newer},
+		b:    gitdomain.Ref{ShortName: "v2.0.0", CreatedDate: older},
+		want: false,
+	}, {
+		name: "date",
+		a:    gitdomain.Ref{ShortName: "release/a", CreatedDate: newer},
+		b:    gitdomain.Ref{ShortName: "release/b", CreatedDate: older},
+		want: true,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, refIsNewer(&tc.a, &tc.b))
+		})
+	}
+}
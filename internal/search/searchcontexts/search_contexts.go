@@ -249,6 +249,41 @@ func validateSearchContextQuery(contextQuery string) error {
 	return errs
 }
 
+// validateSearchContextRefresh validates the refresh interval and revision rules of a search
+// context. Both only apply to query-based contexts, whose query is then only used to select the
+// repositories to materialize.
+func validateSearchContextRefresh(searchContext *types.SearchContext) error {
+	if searchContext.RefreshIntervalHours < 0 {
+		return errors.New("search context refresh interval must be positive")
+	}
+	if len(searchContext.RevisionRules) > 0 && searchContext.RefreshIntervalHours == 0 {
+		return errors.New("search context revision rules require a refresh interval")
+	}
+	if searchContext.RefreshIntervalHours == 0 {
+		return nil
+	}
+	if searchContext.Query == "" {
+		return errors.New("search context refresh interval requires a search context query")
+	}
+
+	var errs error
+	plan, err := query.Pipeline(query.Init(searchContext.Query, query.SearchTypeRegex))
+	if err != nil {
+		return err
+	}
+	query.VisitParameter(plan.ToQ(), func(field, _ string, _ bool, _ query.Annotation) {
+		if field == query.FieldFile || field == query.FieldLang {
+			errs = errors.Append(errs,
+				errors.Errorf("unsupported field in the query of a search context with a refresh interval: %q", field))
+		}
+	})
+
+	if _, err := compileRevisionRules(searchContext.RevisionRules); err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return errs
+}
+
 func validateSearchContextDoesNotExist(ctx context.Context, db database.DB, searchContext *types.SearchContext) error {
 	_, err := db.SearchContexts().GetSearchContext(ctx, database.GetSearchContextOptions{
 		Name:            searchContext.Name,
@@ -304,6 +339,11 @@ func CreateSearchContextWithRepositoryRevisions(
 		return nil, err
 	}
 
+	err = validateSearchContextRefresh(searchContext)
+	if err != nil {
+		return nil, err
+	}
+
 	err = validateSearchContextDoesNotExist(ctx, db, searchContext)
 	if err != nil {
 		return nil, err
@@ -350,6 +390,11 @@ func UpdateSearchContextWithRepositoryRevisions(ctx context.Context, db database
 		return nil, err
 	}
 
+	err = validateSearchContextRefresh(searchContext)
+	if err != nil {
+		return nil, err
+	}
+
 	searchContext, err = db.SearchContexts().UpdateSearchContextWithRepositoryRevisions(ctx, searchContext, repositoryRevisions)
 	if err != nil {
 		return nil, err
@@ -523,6 +568,13 @@ func IsAutoDefinedSearchContext(searchContext *types.SearchContext) bool {
 	return searchContext.AutoDefined
 }
 
+// IsMaterializedSearchContext returns true if the repositories matching the query of the search
+// context have been materialized into its repository revisions. Materialized contexts are searched
+// like contexts defined by a list of repository revisions.
+func IsMaterializedSearchContext(searchContext *types.SearchContext) bool {
+	return searchContext.Query != "" && searchContext.RefreshIntervalHours > 0 && searchContext.LastRefreshedAt != nil
+}
+
 func IsInstanceLevelSearchContext(searchContext *types.SearchContext) bool {
 	return searchContext.NamespaceUserID == 0 && searchContext.NamespaceOrgID == 0
 }
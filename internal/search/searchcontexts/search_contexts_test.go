@@ -783,3 +783,63 @@ func Test_validateSearchContextQuery(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateSearchContextRefresh(t *testing.T) {
+	cases := []struct {
+		name    string
+		sc      *types.SearchContext
+		wantErr bool
+	}{{
+		name: "no refresh",
+		sc:   &types.SearchContext{Query: "repo:foo"},
+	}, {
+		name: "refresh with rules",
+		sc: &types.SearchContext{
+			Query:                "repo:^github\\.com/sourcegraph/",
+			RefreshIntervalHours: 24,
+			RevisionRules: []types.SearchContextRevisionRule{
+				{Repo: "-service$", Tag: "v*"},
+				{Branch: "release/*"},
+			},
+		},
+	}, {
+		name:    "negative interval",
+		sc:      &types.SearchContext{Query: "repo:foo", RefreshIntervalHours: -1},
+		wantErr: true,
+	}, {
+		name:    "refresh without query",
+		sc:      &types.SearchContext{RefreshIntervalHours: 24},
+		wantErr: true,
+	}, {
+		name:    "rules without refresh",
+		sc:      &types.SearchContext{Query: "repo:foo", RevisionRules: []types.SearchContextRevisionRule{{Tag: "v*"}}},
+		wantErr: true,
+	}, {
+		name:    "file filter",
+		sc:      &types.SearchContext{Query: "repo:foo file:bar", RefreshIntervalHours: 24},
+		wantErr: true,
+	}, {
+		name:    "rule with tag and branch",
+		sc:      &types.SearchContext{Query: "repo:foo", RefreshIntervalHours: 24, RevisionRules: []types.SearchContextRevisionRule{{Tag: "v*", Branch: "main"}}},
+		wantErr: true,
+	}, {
+		name:    "rule with invalid repo",
+		sc:      &types.SearchContext{Query: "repo:foo", RefreshIntervalHours: 24, RevisionRules: []types.SearchContextRevisionRule{{Repo: "(", Tag: "v*"}}},
+		wantErr: true,
+	}, {
+		name:    "rule with invalid glob",
+		sc:      &types.SearchContext{Query: "repo:foo", RefreshIntervalHours: 24, RevisionRules: []types.SearchContextRevisionRule{{Tag: "v[*"}}},
+		wantErr: true,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSearchContextRefresh(tc.sc)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
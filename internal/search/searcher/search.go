@@ -201,8 +201,16 @@ func (s *TextSearchJob) searchFilesInRepo(
 	}
 
 	onMatch := func(searcherMatch *protocol.FileMatch) {
+		matches := convertMatches(repo, commit, &rev, []*protocol.FileMatch{searcherMatch}, s.PathRegexps)
+		if s.Features.ExplainRanking {
+			for _, m := range matches {
+				// Searcher does not score matches, they are streamed in
+				// the order they are found.
+				m.(*result.FileMatch).RankingExplanation = &result.RankingExplanation{Backend: "searcher"}
+			}
+		}
 		stream.Send(streaming.SearchEvent{
-			Results: convertMatches(repo, commit, &rev, []*protocol.FileMatch{searcherMatch}, s.PathRegexps),
+			Results: matches,
 		})
 	}
 
@@ -0,0 +1,78 @@
+package semantic
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// BM25 parameters, using the defaults that are also used by Zoekt.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// bm25Ranks returns the rank of each candidate when scored with BM25 against the given terms.
+// Results from different Zoekt replicas and from searcher arrive interleaved, so their scores
+// are not comparable and we recompute them over the candidate set. Since we only have access to
+// the matched chunks, they stand in for the full document. Ties keep their arrival order.
+func bm25Ranks(candidates []*result.FileMatch, terms []string) []int {
+	var (
+		termFrequencies = make([]map[string]int, len(candidates))
+		lengths         = make([]int, len(candidates))
+		documentCounts  = make(map[string]int, len(terms))
+		totalLength     int
+	)
+	for i, fm := range candidates {
+		text := strings.ToLower(documentText(fm))
+		lengths[i] = len(strings.Fields(text))
+		totalLength += lengths[i]
+
+		termFrequencies[i] = make(map[string]int, len(terms))
+		for _, term := range terms {
+			if n := strings.Count(text, term); n > 0 {
+				termFrequencies[i][term] = n
+				documentCounts[term]++
+			}
+		}
+	}
+
+	n := float64(len(candidates))
+	averageLength := math.Max(float64(totalLength)/n, 1)
+
+	scores := make([]float64, len(candidates))
+	for i := range candidates {
+		for term, tf := range termFrequencies[i] {
+			df := float64(documentCounts[term])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			f := float64(tf)
+			scores[i] += idf * f * (bm25K1 + 1) / (f + bm25K1*(1-bm25B+bm25B*float64(lengths[i])/averageLength))
+		}
+	}
+
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+
+	ranks := make([]int, len(candidates))
+	for rank, i := range order {
+		ranks[i] = rank
+	}
+	return ranks
+}
+
+// documentText returns the text of a file match available for scoring: its path and the content
+// of its matched chunks.
+func documentText(fm *result.FileMatch) string {
+	var b strings.Builder
+	b.WriteString(fm.Path)
+	for _, chunk := range fm.ChunkMatches {
+		b.WriteByte('\n')
+		b.WriteString(chunk.Content)
+	}
+	return b.String()
+}
@@ -0,0 +1,391 @@
+package semantic
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/codycontext"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultCandidateCount is the minimum number of keyword matches considered for reranking.
+const DefaultCandidateCount = 500
+
+// DefaultFlushDelay is how long candidates are buffered for reranking before they are streamed.
+const DefaultFlushDelay = 2 * time.Second
+
+// rrfConstant dampens the contribution of top ranks in reciprocal rank fusion. 60 is the value
+// recommended by the original paper and used by most hybrid search implementations.
+const rrfConstant = 60
+
+// NewSearchJob creates a new job for semantic searches. It maps the query into a keyword query the
+// same way Cody context searches do, and uses it to collect candidate file matches.
+//
+// When the job is run, the candidates are reranked by fusing their BM25 ranking with the ranking
+// of the embeddings search for the query. Embeddings are only consulted for repositories with an
+// embeddings index, so for other repositories the BM25 ranking is used as is. Candidates are
+// reranked in batches so results stream: see rankingStream.
+func NewSearchJob(plan query.Plan, inputs *search.Inputs, newJob func(query.Basic) (job.Job, error)) (job.Job, error) {
+	if len(plan) > 1 {
+		return nil, errors.New("The 'semantic' patterntype does not support multiple clauses")
+	}
+
+	basicQuery := plan[0]
+	naturalQuery := naturalLanguageQuery(basicQuery)
+
+	keywordQuery, terms, err := codycontext.KeywordQuery(query.StringHuman(basicQuery.ToParseTree()))
+	if err != nil {
+		return nil, err
+	}
+
+	resultCount := inputs.MaxResults()
+	candidateCount := max(resultCount, DefaultCandidateCount)
+
+	candidateQuery := keywordQuery.MapParameters(append(withoutCount(keywordQuery.Parameters),
+		query.Parameter{Field: query.FieldType, Value: "path"},
+		query.Parameter{Field: query.FieldType, Value: "file"},
+		query.Parameter{Field: query.FieldCount, Value: strconv.Itoa(candidateCount)}))
+	candidateJob, err := newJob(candidateQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var embeddingsClient embeddings.Client
+	if conf.EmbeddingsEnabled() {
+		embeddingsClient = embeddings.NewDefaultClient()
+	}
+
+	return &searchJob{
+		candidateJob:     candidateJob,
+		candidateCount:   candidateCount,
+		resultCount:      resultCount,
+		flushDelay:       DefaultFlushDelay,
+		query:            naturalQuery,
+		terms:            terms,
+		embeddingsClient: embeddingsClient,
+	}, nil
+}
+
+// naturalLanguageQuery returns the patterns of the query joined by spaces, which approximates
+// the user's intent better than the keyword query for embeddings search.
+func naturalLanguageQuery(b query.Basic) string {
+	var patterns []string
+	query.VisitPattern([]query.Node{b.Pattern}, func(value string, negated bool, _ query.Annotation) {
+		if !negated {
+			patterns = append(patterns, value)
+		}
+	})
+	return strings.Join(patterns, " ")
+}
+
+func withoutCount(parameters []query.Parameter) []query.Parameter {
+	filtered := make([]query.Parameter, 0, len(parameters))
+	for _, p := range parameters {
+		if p.Field != query.FieldCount {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+type searchJob struct {
+	candidateJob   job.Job
+	candidateCount int
+	resultCount    int
+	flushDelay     time.Duration
+
+	// query is the natural language query passed to the embeddings search, and terms are the
+	// keywords used to compute BM25 scores.
+	query string
+	terms []string
+
+	// embeddingsClient is nil if embeddings are disabled, in which case results are ranked by
+	// BM25 alone.
+	embeddingsClient embeddings.Client
+}
+
+func (j *searchJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	// The candidate job is canceled once enough candidates or results are collected. Ranking uses
+	// the parent context so that the last batch is still reranked with embeddings.
+	candidateCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rs := &rankingStream{
+		job:      j,
+		ctx:      ctx,
+		clients:  clients,
+		parent:   stream,
+		cancel:   cancel,
+		embedded: map[api.RepoID]bool{},
+	}
+
+	alert, err = j.candidateJob.Run(candidateCtx, clients, rs)
+	if errors.Is(err, context.Canceled) && ctx.Err() == nil {
+		// We canceled the candidate job ourselves, which is not a search error
+		err = nil
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.flush()
+
+	return alert, err
+}
+
+// rankingStream collects the file matches of the candidate job into batches. A batch is reranked
+// and sent to the parent stream once its oldest candidate has been buffered for flushDelay, on the
+// next event from the candidate job, and the last batch when the candidate job completes. Ranking
+// only applies within a batch, so results of a later batch always follow those of earlier ones.
+// This trades some ranking quality for latency, the same way Zoekt does when flushing its ranked
+// results. Statistics such as repository statuses are forwarded as they arrive.
+type rankingStream struct {
+	job     *searchJob
+	ctx     context.Context
+	clients job.RuntimeClients
+	parent  streaming.Sender
+	cancel  context.CancelFunc
+
+	mu         sync.Mutex
+	batch      []*result.FileMatch
+	batchStart time.Time
+	candidates int
+	sent       int
+	limitHit   bool
+
+	// embedded caches whether repositories have an embeddings index across batches.
+	embedded map[api.RepoID]bool
+}
+
+func (s *rankingStream) Send(e streaming.SearchEvent) {
+	s.parent.Send(streaming.SearchEvent{Stats: e.Stats})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, res := range e.Results {
+		if s.candidates >= s.job.candidateCount || s.sent >= s.job.resultCount {
+			s.limitHit = true
+			s.cancel()
+			break
+		}
+
+		if fm, ok := res.(*result.FileMatch); ok {
+			if len(s.batch) == 0 {
+				s.batchStart = time.Now()
+			}
+			s.batch = append(s.batch, fm)
+			s.candidates++
+		}
+	}
+
+	if len(s.batch) > 0 && time.Since(s.batchStart) >= s.job.flushDelay {
+		s.flush()
+	}
+}
+
+// flush reranks the current batch and sends it to the parent stream, truncated to the remaining
+// result count. The caller must hold s.mu.
+func (s *rankingStream) flush() {
+	batch := s.batch
+	s.batch = nil
+
+	var ranked result.Matches
+	if remaining := s.job.resultCount - s.sent; len(batch) > 0 && remaining > 0 {
+		embeddingRanks, err := s.embeddingRanks(batch)
+		if err != nil {
+			// Embeddings only refine the ranking, so we fall back to BM25 rather than failing the search
+			s.clients.Logger.Warn("failed to rerank semantic search results with embeddings", log.Error(err))
+		}
+
+		ranked = rank(batch, bm25Ranks(batch, s.job.terms), embeddingRanks)
+		if len(ranked) > remaining {
+			ranked = ranked[:remaining]
+			s.limitHit = true
+		}
+		s.sent += len(ranked)
+	} else if len(batch) > 0 {
+		s.limitHit = true
+	}
+
+	if s.limitHit {
+		s.cancel()
+	}
+	if len(ranked) > 0 || s.limitHit {
+		s.parent.Send(streaming.SearchEvent{
+			Results: ranked,
+			Stats:   streaming.Stats{IsLimitHit: s.limitHit},
+		})
+	}
+}
+
+// embeddingRanks returns the rank of each candidate in the embeddings search results for the
+// query, restricted to candidates from repositories with an embeddings index. Candidates without
+// an embeddings result are absent from the returned map.
+func (s *rankingStream) embeddingRanks(candidates []*result.FileMatch) (map[fileKey]int, error) {
+	if s.job.embeddingsClient == nil {
+		return nil, nil
+	}
+
+	var unknown []api.RepoID
+	for _, fm := range candidates {
+		if _, ok := s.embedded[fm.Repo.ID]; !ok {
+			s.embedded[fm.Repo.ID] = false
+			unknown = append(unknown, fm.Repo.ID)
+		}
+	}
+	if len(unknown) > 0 {
+		repos, err := s.clients.DB.Repos().ListMinimalRepos(s.ctx, database.ReposListOptions{
+			IDs:          unknown,
+			OnlyEmbedded: true,
+		})
+		if err != nil {
+			// Check again with the next batch rather than assuming there are no embeddings
+			for _, id := range unknown {
+				delete(s.embedded, id)
+			}
+			return nil, err
+		}
+		for _, repo := range repos {
+			s.embedded[repo.ID] = true
+		}
+	}
+
+	var (
+		repoNames []api.RepoName
+		repoIDs   []api.RepoID
+		seen      = map[api.RepoID]struct{}{}
+	)
+	for _, fm := range candidates {
+		if _, ok := seen[fm.Repo.ID]; ok || !s.embedded[fm.Repo.ID] {
+			continue
+		}
+		seen[fm.Repo.ID] = struct{}{}
+		repoNames = append(repoNames, fm.Repo.Name)
+		repoIDs = append(repoIDs, fm.Repo.ID)
+	}
+	if len(repoNames) == 0 {
+		return nil, nil
+	}
+
+	results, err := s.job.embeddingsClient.Search(s.ctx, embeddings.EmbeddingsSearchParameters{
+		RepoNames:        repoNames,
+		RepoIDs:          repoIDs,
+		Query:            s.job.query,
+		CodeResultsCount: len(candidates),
+		TextResultsCount: len(candidates),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Code and text results are scored on the same scale, so we can rank them together
+	var merged embeddings.EmbeddingSearchResults
+	merged.MergeTruncate(append(results.CodeResults, results.TextResults...), len(results.CodeResults)+len(results.TextResults))
+
+	candidateKeys := make(map[fileKey]struct{}, len(candidates))
+	for _, fm := range candidates {
+		candidateKeys[keyOf(fm)] = struct{}{}
+	}
+
+	// Files that were not keyword candidates are ignored, so ranks are relative to the candidates
+	ranks := make(map[fileKey]int, len(merged))
+	for _, r := range merged {
+		key := fileKey{repo: r.RepoName, path: r.FileName}
+		if _, ok := candidateKeys[key]; !ok {
+			continue
+		}
+		if _, ok := ranks[key]; !ok {
+			// A file may have several embedded chunks, the best one determines its rank
+			ranks[key] = len(ranks)
+		}
+	}
+	return ranks, nil
+}
+
+type fileKey struct {
+	repo api.RepoName
+	path string
+}
+
+func keyOf(fm *result.FileMatch) fileKey {
+	return fileKey{repo: fm.Repo.Name, path: fm.Path}
+}
+
+// rank orders the candidates by their reciprocal rank fusion score over the BM25 and embeddings
+// rankings. Ties are broken by BM25 rank.
+func rank(candidates []*result.FileMatch, bm25Ranks []int, embeddingRanks map[fileKey]int) result.Matches {
+	scores := make([]float64, len(candidates))
+	for i, fm := range candidates {
+		scores[i] = 1 / float64(rrfConstant+bm25Ranks[i])
+		if embeddingRank, ok := embeddingRanks[keyOf(fm)]; ok {
+			scores[i] += 1 / float64(rrfConstant+embeddingRank)
+		}
+	}
+
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		if scores[order[a]] != scores[order[b]] {
+			return scores[order[a]] > scores[order[b]]
+		}
+		return bm25Ranks[order[a]] < bm25Ranks[order[b]]
+	})
+
+	ranked := make(result.Matches, 0, len(candidates))
+	for _, i := range order {
+		ranked = append(ranked, candidates[i])
+	}
+	return ranked
+}
+
+func (j *searchJob) Name() string {
+	return "SemanticSearchJob"
+}
+
+func (j *searchJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			attribute.String("query", j.query),
+			attribute.StringSlice("terms", j.terms),
+			attribute.Int("candidateCount", j.candidateCount),
+			attribute.Int("resultCount", j.resultCount),
+			attribute.Stringer("flushDelay", j.flushDelay),
+			attribute.Bool("embeddingsEnabled", j.embeddingsClient != nil),
+		)
+	}
+	return res
+}
+
+func (j *searchJob) Children() []job.Describer {
+	return []job.Describer{j.candidateJob}
+}
+
+func (j *searchJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.candidateJob = job.Map(j.candidateJob, fn)
+	return &cp
+}
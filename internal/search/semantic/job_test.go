@@ -0,0 +1,278 @@
+package semantic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	embedded    = types.MinimalRepo{ID: 1, Name: "embedded"}
+	notEmbedded = types.MinimalRepo{ID: 2, Name: "not-embedded"}
+)
+
+func fileMatch(repo types.MinimalRepo, path string, content string) *result.FileMatch {
+	return &result.FileMatch{
+		File:         result.File{Repo: repo, Path: path},
+		ChunkMatches: result.ChunkMatches{{Content: content}},
+	}
+}
+
+func paths(matches result.Matches) []string {
+	var paths []string
+	for _, match := range matches {
+		paths = append(paths, match.(*result.FileMatch).Path)
+	}
+	return paths
+}
+
+func newCandidateJob(candidates result.Matches) *mockjob.MockJob {
+	candidateJob := mockjob.NewMockJob()
+	candidateJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, sender streaming.Sender) (*search.Alert, error) {
+		// Results usually arrive in several events, in no particular order
+		for _, candidate := range candidates {
+			sender.Send(streaming.SearchEvent{Results: result.Matches{candidate}})
+		}
+		return nil, nil
+	})
+	return candidateJob
+}
+
+func newClients(t *testing.T) job.RuntimeClients {
+	repos := dbmocks.NewMockRepoStore()
+	repos.ListMinimalReposFunc.SetDefaultHook(func(_ context.Context, opts database.ReposListOptions) ([]types.MinimalRepo, error) {
+		var repos []types.MinimalRepo
+		for _, id := range opts.IDs {
+			if id == embedded.ID {
+				repos = append(repos, embedded)
+			}
+		}
+		return repos, nil
+	})
+	db := dbmocks.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	return job.RuntimeClients{Logger: logtest.Scoped(t), DB: db}
+}
+
+func TestRunBM25(t *testing.T) {
+	candidates := result.Matches{
+		fileMatch(notEmbedded, "client/http.go", "func Do(req *http.Request)"),
+		fileMatch(notEmbedded, "README.md", "We retry failed HTTP calls"),
+		fileMatch(notEmbedded, "client/retry.go", "func retryHTTP() { retry(); retry() }"),
+	}
+
+	searchJob := &searchJob{
+		candidateJob:   newCandidateJob(candidates),
+		candidateCount: 10,
+		resultCount:    10,
+		flushDelay:     time.Hour,
+		terms:          []string{"retry", "http", "call"},
+	}
+
+	stream := streaming.NewAggregatingStream()
+	_, err := searchJob.Run(context.Background(), newClients(t), stream)
+	require.NoError(t, err)
+	// The README matches every term, including the rarest one
+	require.Equal(t, []string{"README.md", "client/retry.go", "client/http.go"}, paths(stream.Results))
+	require.False(t, stream.Stats.IsLimitHit)
+}
+
+func TestRunEmbeddingsRerank(t *testing.T) {
+	candidates := result.Matches{
+		fileMatch(embedded, "retry/retry.go", "retry retry retry"),
+		fileMatch(embedded, "client/backoff.go", "func backoff() { retry }"),
+		fileMatch(notEmbedded, "other/retry.go", "retry retry"),
+	}
+
+	embeddingsClient := embeddings.NewMockClient()
+	embeddingsClient.SearchFunc.SetDefaultReturn(&embeddings.EmbeddingCombinedSearchResults{
+		CodeResults: embeddings.EmbeddingSearchResults{
+			{RepoName: embedded.Name, FileName: "client/backoff.go", ScoreDetails: embeddings.SearchScoreDetails{SimilarityScore: 90}},
+			{RepoName: embedded.Name, FileName: "unrelated.go", ScoreDetails: embeddings.SearchScoreDetails{SimilarityScore: 80}},
+			{RepoName: embedded.Name, FileName: "retry/retry.go", ScoreDetails: embeddings.SearchScoreDetails{SimilarityScore: 10}},
+		},
+	}, nil)
+
+	searchJob := &searchJob{
+		candidateJob:     newCandidateJob(candidates),
+		candidateCount:   10,
+		flushDelay:       time.Hour,
+		resultCount:      2,
+		query:            "where do we retry",
+		terms:            []string{"retry"},
+		embeddingsClient: embeddingsClient,
+	}
+
+	stream := streaming.NewAggregatingStream()
+	_, err := searchJob.Run(context.Background(), newClients(t), stream)
+	require.NoError(t, err)
+
+	// The best embeddings match is promoted above a better keyword match from a repository without
+	// embeddings, and results are truncated to the result count
+	require.Equal(t, []string{"retry/retry.go", "client/backoff.go"}, paths(stream.Results))
+	require.True(t, stream.Stats.IsLimitHit)
+
+	// Only repositories with an embeddings index are searched
+	history := embeddingsClient.SearchFunc.History()
+	require.Len(t, history, 1)
+	require.Equal(t, []api.RepoName{embedded.Name}, history[0].Arg1.RepoNames)
+	require.Equal(t, "where do we retry", history[0].Arg1.Query)
+}
+
+func TestRunEmbeddingsError(t *testing.T) {
+	candidates := result.Matches{
+		fileMatch(embedded, "a.go", "retry"),
+		fileMatch(embedded, "b.go", "retry retry retry"),
+	}
+
+	embeddingsClient := embeddings.NewMockClient()
+	embeddingsClient.SearchFunc.SetDefaultReturn(nil, errors.New("embeddings unavailable"))
+
+	searchJob := &searchJob{
+		candidateJob:     newCandidateJob(candidates),
+		candidateCount:   10,
+		flushDelay:       time.Hour,
+		resultCount:      10,
+		terms:            []string{"retry"},
+		embeddingsClient: embeddingsClient,
+	}
+
+	// Failing to rerank with embeddings falls back to BM25 instead of failing the search
+	stream := streaming.NewAggregatingStream()
+	_, err := searchJob.Run(context.Background(), newClients(t), stream)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b.go", "a.go"}, paths(stream.Results))
+}
+
+func TestRunCandidateLimit(t *testing.T) {
+	candidates := result.Matches{
+		fileMatch(notEmbedded, "a.go", "retry"),
+		fileMatch(notEmbedded, "b.go", "retry"),
+		fileMatch(notEmbedded, "c.go", "retry"),
+	}
+
+	searchJob := &searchJob{
+		candidateJob:   newCandidateJob(candidates),
+		candidateCount: 2,
+		resultCount:    2,
+		terms:          []string{"retry"},
+	}
+
+	stream := streaming.NewAggregatingStream()
+	_, err := searchJob.Run(context.Background(), newClients(t), stream)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.go", "b.go"}, paths(stream.Results))
+	require.True(t, stream.Stats.IsLimitHit)
+}
+
+func TestRunEmbeddingsLookupBatched(t *testing.T) {
+	candidates := result.Matches{
+		fileMatch(embedded, "a.go", "retry"),
+		fileMatch(notEmbedded, "b.go", "retry"),
+		fileMatch(embedded, "c.go", "retry"),
+	}
+
+	embeddingsClient := embeddings.NewMockClient()
+	embeddingsClient.SearchFunc.SetDefaultReturn(&embeddings.EmbeddingCombinedSearchResults{}, nil)
+
+	searchJob := &searchJob{
+		candidateJob:     newCandidateJob(candidates),
+		candidateCount:   10,
+		resultCount:      10,
+		flushDelay:       time.Hour,
+		terms:            []string{"retry"},
+		embeddingsClient: embeddingsClient,
+	}
+
+	clients := newClients(t)
+	_, err := searchJob.Run(context.Background(), clients, streaming.NewAggregatingStream())
+	require.NoError(t, err)
+
+	// The repositories with an embeddings index are looked up in a single query
+	history := clients.DB.Repos().(*dbmocks.MockRepoStore).ListMinimalReposFunc.History()
+	require.Len(t, history, 1)
+	require.Equal(t, []api.RepoID{embedded.ID, notEmbedded.ID}, history[0].Arg1.IDs)
+	require.True(t, history[0].Arg1.OnlyEmbedded)
+}
+
+func TestRunStreamsBatches(t *testing.T) {
+	candidates := result.Matches{
+		fileMatch(notEmbedded, "a.go", "retry"),
+		fileMatch(notEmbedded, "b.go", "retry retry"),
+		fileMatch(notEmbedded, "c.go", "retry retry retry"),
+	}
+
+	searchJob := &searchJob{
+		candidateJob:   newCandidateJob(candidates),
+		candidateCount: 10,
+		resultCount:    2,
+		terms:          []string{"retry"},
+	}
+
+	var (
+		events   []streaming.SearchEvent
+		limitHit bool
+	)
+	stream := streaming.StreamFunc(func(e streaming.SearchEvent) {
+		if len(e.Results) > 0 {
+			events = append(events, e)
+		}
+		limitHit = limitHit || e.Stats.IsLimitHit
+	})
+	_, err := searchJob.Run(context.Background(), newClients(t), stream)
+	require.NoError(t, err)
+
+	// Without a flush delay, each batch is sent as soon as it is collected, even though a later
+	// candidate ranks higher
+	require.Len(t, events, 2)
+	require.Equal(t, []string{"a.go"}, paths(events[0].Results))
+	require.Equal(t, []string{"b.go"}, paths(events[1].Results))
+	require.True(t, limitHit)
+}
+
+func TestRunIgnoresOwnCancellation(t *testing.T) {
+	candidateJob := mockjob.NewMockJob()
+	candidateJob.RunFunc.SetDefaultHook(func(ctx context.Context, _ job.RuntimeClients, sender streaming.Sender) (*search.Alert, error) {
+		for _, path := range []string{"a.go", "b.go", "c.go"} {
+			sender.Send(streaming.SearchEvent{Results: result.Matches{fileMatch(notEmbedded, path, "retry")}})
+		}
+		return nil, ctx.Err()
+	})
+
+	searchJob := &searchJob{
+		candidateJob:   candidateJob,
+		candidateCount: 2,
+		resultCount:    10,
+		flushDelay:     time.Hour,
+		terms:          []string{"retry"},
+	}
+
+	// The candidate job is canceled once enough candidates are collected, which is not an error
+	stream := streaming.NewAggregatingStream()
+	_, err := searchJob.Run(context.Background(), newClients(t), stream)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.go", "b.go"}, paths(stream.Results))
+	require.True(t, stream.Stats.IsLimitHit)
+
+	// Cancellation by the caller is still reported
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = searchJob.Run(ctx, newClients(t), streaming.NewAggregatingStream())
+	require.ErrorIs(t, err, context.Canceled)
+}
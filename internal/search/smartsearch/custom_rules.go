@@ -0,0 +1,191 @@
+package smartsearch
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func init() {
+	conf.ContributeValidator(func(c conftypes.SiteConfigQuerier) (problems conf.Problems) {
+		seen := make(map[string]struct{}, len(c.SiteConfig().SearchSmartSearchRules))
+		for i, r := range c.SiteConfig().SearchSmartSearchRules {
+			if _, err := compileCustomRule(r); err != nil {
+				problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("search.smartSearch.rules[%d]: %s", i, err)))
+			}
+			if _, ok := seen[r.Name]; ok {
+				problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("search.smartSearch.rules[%d]: the name %q is used by more than one rule", i, r.Name)))
+			}
+			seen[r.Name] = struct{}{}
+		}
+		return problems
+	})
+}
+
+// customRules compiles the rules defined in the site configuration into
+// narrowing and widening rules. Rules that do not compile are skipped, they are
+// reported by the site configuration validator instead.
+func customRules(rules []*schema.SmartSearchRule) (narrow, widen []rule) {
+	for _, r := range rules {
+		compiled, err := compileCustomRule(r)
+		if err != nil {
+			continue
+		}
+		if r.Kind == "widen" {
+			widen = append(widen, compiled)
+		} else {
+			narrow = append(narrow, compiled)
+		}
+	}
+	return narrow, widen
+}
+
+// compileCustomRule converts a rule of the site configuration to a rule the
+// generator can apply.
+func compileCustomRule(r *schema.SmartSearchRule) (rule, error) {
+	if r.Name == "" {
+		return rule{}, errors.New("name must not be empty")
+	}
+	if r.Description == "" {
+		return rule{}, errors.Newf("rule %q: description must not be empty", r.Name)
+	}
+	switch r.Kind {
+	case "", "narrow", "widen":
+	default:
+		return rule{}, errors.Newf("rule %q: kind must be \"narrow\" or \"widen\", got %q", r.Name, r.Kind)
+	}
+
+	match, err := regexp.Compile(r.Match)
+	if err != nil {
+		return rule{}, errors.Newf("rule %q: match is not a valid regular expression: %s", r.Name, err)
+	}
+	for _, f := range r.Filters {
+		if _, err := parseFilters(f); err != nil {
+			return rule{}, errors.Newf("rule %q: filter %q is invalid: %s", r.Name, f, err)
+		}
+	}
+
+	c := &customRule{
+		match:   match,
+		replace: r.Replace,
+		filters: r.Filters,
+	}
+	return rule{
+		name:        r.Name,
+		description: r.Description,
+		transform:   []transform{c.apply},
+	}, nil
+}
+
+// customRule rewrites the first search term matching a regular expression,
+// and adds filters to the query.
+type customRule struct {
+	match   *regexp.Regexp
+	replace string
+	filters []string
+}
+
+func (r *customRule) apply(b query.Basic) *query.Basic {
+	if b.Pattern == nil {
+		return nil
+	}
+
+	rawPatternTree, err := query.Parse(query.StringHuman([]query.Node{b.Pattern}), query.SearchTypeStandard)
+	if err != nil {
+		return nil
+	}
+
+	changed := false
+	var filters []string
+	newPattern := query.MapPattern(rawPatternTree, func(value string, negated bool, annotation query.Annotation) query.Node {
+		unchanged := query.Pattern{
+			Value:      value,
+			Negated:    negated,
+			Annotation: annotation,
+		}
+		// Rewriting a negated term, like -paysvc, would turn its meaning
+		// around, so we leave those alone.
+		if changed || negated {
+			return unchanged
+		}
+
+		submatches := r.match.FindStringSubmatchIndex(value)
+		if submatches == nil {
+			return unchanged
+		}
+		replaced := r.match.ReplaceAllString(value, r.replace)
+		if replaced == value && len(r.filters) == 0 {
+			return unchanged
+		}
+
+		changed = true
+		for _, f := range r.filters {
+			filters = append(filters, string(r.match.ExpandString(nil, f, value, submatches)))
+		}
+		if replaced == "" {
+			// remove this node
+			return nil
+		}
+		return query.Pattern{
+			Value:      replaced,
+			Negated:    negated,
+			Annotation: annotation,
+		}
+	})
+
+	if !changed {
+		return nil
+	}
+
+	parameters := slices.Clone(b.Parameters)
+	for _, f := range filters {
+		params, err := parseFilters(f)
+		if err != nil {
+			// A capture group expanded to a value that is not a valid filter.
+			return nil
+		}
+		parameters = append(parameters, params...)
+	}
+
+	var pattern query.Node
+	if len(newPattern) > 0 {
+		// Process concat nodes
+		nodes, err := query.Sequence(query.For(query.SearchTypeStandard))(newPattern)
+		if err != nil {
+			return nil
+		}
+		pattern = nodes[0] // guaranteed root at first node
+	}
+
+	return &query.Basic{
+		Parameters: parameters,
+		Pattern:    pattern,
+	}
+}
+
+// parseFilters parses a string that must only consist of filters, like
+// `repo:foo lang:go`.
+func parseFilters(s string) ([]query.Parameter, error) {
+	q, err := query.ParseStandard(s)
+	if err != nil {
+		return nil, err
+	}
+	b, err := query.ToBasicQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	if b.Pattern != nil {
+		return nil, errors.Newf("%q is not a filter", query.StringHuman([]query.Node{b.Pattern}))
+	}
+	if len(b.Parameters) == 0 {
+		return nil, errors.New("no filters")
+	}
+	return b.Parameters, nil
+}
@@ -0,0 +1,101 @@
+package smartsearch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func Test_customRule(t *testing.T) {
+	test := func(r *schema.SmartSearchRule, input string) string {
+		compiled, err := compileCustomRule(r)
+		require.NoError(t, err)
+
+		q, _ := query.ParseStandard(input)
+		b, _ := query.ToBasicQuery(q)
+		out := applyTransformation(b, compiled.transform)
+		if out == nil {
+			return "DOES NOT APPLY"
+		}
+		return query.StringHuman(out.ToParseTree())
+	}
+
+	nickname := &schema.SmartSearchRule{
+		Name:        "paysvc",
+		Description: "search the payments service",
+		Match:       "^paysvc$",
+		Filters:     []string{`repo:^github\.com/acme/payments$`},
+	}
+	autogold.Expect(`repo:^github\.com/acme/payments$ charge`).Equal(t, test(nickname, `paysvc charge`))
+	autogold.Expect(`lang:go repo:^github\.com/acme/payments$`).Equal(t, test(nickname, `lang:go paysvc`))
+	autogold.Expect("DOES NOT APPLY").Equal(t, test(nickname, `paysvc-client charge`))
+	autogold.Expect("DOES NOT APPLY").Equal(t, test(nickname, `-paysvc charge`))
+	autogold.Expect("DOES NOT APPLY").Equal(t, test(nickname, `repo:paysvc`))
+
+	captures := &schema.SmartSearchRule{
+		Name:        "team-services",
+		Description: "search the services of a team",
+		Match:       `^(\w+)-svc$`,
+		Filters:     []string{`repo:^github\.com/acme/$1- lang:go`},
+	}
+	autogold.Expect(`repo:^github\.com/acme/billing- lang:go invoice`).Equal(t, test(captures, `billing-svc invoice`))
+
+	replace := &schema.SmartSearchRule{
+		Name:        "k8s",
+		Description: "spell out Kubernetes",
+		Match:       `^k8s$`,
+		Replace:     "kubernetes",
+		Kind:        "widen",
+	}
+	autogold.Expect("kubernetes operator").Equal(t, test(replace, `k8s operator`))
+	autogold.Expect("DOES NOT APPLY").Equal(t, test(replace, `kubernetes operator`))
+}
+
+func Test_compileCustomRule(t *testing.T) {
+	test := func(r *schema.SmartSearchRule) string {
+		_, err := compileCustomRule(r)
+		if err != nil {
+			return err.Error()
+		}
+		return ""
+	}
+
+	autogold.Expect("name must not be empty").Equal(t, test(&schema.SmartSearchRule{Description: "d", Match: "a"}))
+	autogold.Expect(`rule "r": description must not be empty`).Equal(t, test(&schema.SmartSearchRule{Name: "r", Match: "a"}))
+	autogold.Expect(`rule "r": kind must be "narrow" or "widen", got "wide"`).Equal(t, test(&schema.SmartSearchRule{Name: "r", Description: "d", Match: "a", Kind: "wide"}))
+	autogold.Expect("rule \"r\": match is not a valid regular expression: error parsing regexp: missing closing ): `(a`").Equal(t, test(&schema.SmartSearchRule{Name: "r", Description: "d", Match: "(a"}))
+	autogold.Expect(`rule "r": filter "repo:a foo" is invalid: "foo" is not a filter`).Equal(t, test(&schema.SmartSearchRule{Name: "r", Description: "d", Match: "a", Filters: []string{"repo:a foo"}}))
+	autogold.Expect("").Equal(t, test(&schema.SmartSearchRule{Name: "r", Description: "d", Match: "a", Filters: []string{"repo:a lang:go"}}))
+}
+
+func TestNewGenerator_customRules(t *testing.T) {
+	narrow, widen := customRules([]*schema.SmartSearchRule{{
+		Name:        "paysvc",
+		Description: "search the payments service",
+		Match:       "^paysvc$",
+		Filters:     []string{`repo:^github\.com/acme/payments$`},
+	}, {
+		Name:        "invalid",
+		Description: "is skipped",
+		Match:       "(",
+	}})
+	require.Len(t, narrow, 1)
+	require.Empty(t, widen)
+
+	q, _ := query.ParseStandard(`go paysvc charge`)
+	b, _ := query.ToBasicQuery(q)
+	g := NewGenerator(b, append(rulesNarrow, narrow...), rulesWiden)
+
+	var rules []string
+	for g != nil {
+		var autoQ *autoQuery
+		autoQ, g = g()
+		rules = append(rules, strings.Join(autoQ.rules, ","))
+	}
+	autogold.Expect([]string{"paysvc", "", "paysvc", "", "paysvc", ""}).Equal(t, rules)
+}
@@ -80,8 +80,7 @@ func NewGenerator(seed query.Basic, narrow, widen []rule) next {
 	// - w, the index of the widen rule to apply (-1 if empty)
 	var n func(phase PHASE, k int, c *cg, w int) next
 	n = func(phase PHASE, k int, c *cg, w int) next {
-		var transform []transform
-		var descriptions []string
+		var applied []rule
 		var generated *query.Basic
 
 		narrowing_exhausted := k == 0
@@ -97,8 +96,7 @@ func NewGenerator(seed query.Basic, narrow, widen []rule) next {
 				return nil
 			}
 
-			transform = append(transform, widen[w].transform...)
-			descriptions = append(descriptions, widen[w].description)
+			applied = append(applied, widen[w])
 			w += 1 // advance to next widening rule.
 
 		case TWO:
@@ -125,13 +123,11 @@ func NewGenerator(seed query.Basic, narrow, widen []rule) next {
 			}
 
 			for _, idx := range c.Combination(nil) {
-				transform = append(transform, narrow[idx].transform...)
-				descriptions = append(descriptions, narrow[idx].description)
+				applied = append(applied, narrow[idx])
 			}
 
 			// Compose narrow rules with a widen rule.
-			transform = append(transform, widen[w].transform...)
-			descriptions = append(descriptions, widen[w].description)
+			applied = append(applied, widen[w])
 
 		case ONE:
 			if narrowing_exhausted && !widening_active {
@@ -152,8 +148,17 @@ func NewGenerator(seed query.Basic, narrow, widen []rule) next {
 			}
 
 			for _, idx := range c.Combination(nil) {
-				transform = append(transform, narrow[idx].transform...)
-				descriptions = append(descriptions, narrow[idx].description)
+				applied = append(applied, narrow[idx])
+			}
+		}
+
+		var transform []transform
+		var descriptions, names []string
+		for _, r := range applied {
+			transform = append(transform, r.transform...)
+			descriptions = append(descriptions, r.description)
+			if r.name != "" {
+				names = append(names, r.name)
 			}
 		}
 
@@ -168,6 +173,7 @@ func NewGenerator(seed query.Basic, narrow, widen []rule) next {
 
 		q := autoQuery{
 			description: strings.Join(descriptions, " ⚬ "),
+			rules:       names,
 			query:       *generated,
 		}
 
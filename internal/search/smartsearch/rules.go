@@ -16,6 +16,9 @@ import (
 // Basic query, or they do not apply, in which case they return nil. See the
 // `unquotePatterns` rule for an example.
 type rule struct {
+	// name identifies rules defined in the site configuration. It is empty
+	// for built-in rules.
+	name        string
 	description string
 	transform   []transform
 }
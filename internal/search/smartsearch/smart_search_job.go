@@ -3,10 +3,13 @@ package smartsearch
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	alertobserver "github.com/sourcegraph/sourcegraph/internal/search/alert"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
@@ -19,7 +22,10 @@ import (
 // autoQuery is an automatically generated query with associated data (e.g., description).
 type autoQuery struct {
 	description string
-	query       query.Basic
+	// rules are the names of the rules defined in the site configuration
+	// that produced the query.
+	rules []string
+	query query.Basic
 }
 
 // newJob is a function that converts a query to a job, and one which lucky
@@ -32,11 +38,16 @@ type newJob func(query.Basic) (job.Job, error)
 // that apply various rules, transforming the original input plan into various
 // queries that alter its interpretation (e.g., search literally for quotes or
 // not, attempt to search the pattern as a regexp, and so on). There is no
-// random choice when applying rules.
+// random choice when applying rules. Rules defined in the site configuration
+// are applied after the built-in rules.
 func NewSmartSearchJob(initialJob job.Job, newJob newJob, plan query.Plan) *FeelingLuckySearchJob {
+	customNarrow, customWiden := customRules(conf.Get().SearchSmartSearchRules)
+	narrow := append(slices.Clip(rulesNarrow), customNarrow...)
+	widen := append(slices.Clip(rulesWiden), customWiden...)
+
 	generators := make([]next, 0, len(plan))
 	for _, b := range plan {
-		generators = append(generators, NewGenerator(b, rulesNarrow, rulesWiden))
+		generators = append(generators, NewGenerator(b, narrow, widen))
 	}
 
 	newGeneratedJob := func(autoQ *autoQuery) job.Job {
@@ -227,13 +238,14 @@ func (n *notifier) New(count int) error {
 	}
 	annotations := make(map[search.AnnotationName]string)
 	annotations[search.ResultCount] = resultCountString
+	if len(n.rules) > 0 {
+		annotations[search.SmartSearchRules] = strings.Join(n.rules, ",")
+	}
 
 	return &alertobserver.ErrLuckyQueries{
 		ProposedQueries: []*search.QueryDescription{{
 			Description: n.description,
-			Annotations: map[search.AnnotationName]string{
-				search.ResultCount: resultCountString,
-			},
+			Annotations: annotations,
 			Query:       query.StringHuman(n.query.ToParseTree()),
 			PatternType: query.SearchTypeLucky,
 		}},
@@ -48,12 +48,13 @@ func NewRequestWithVersion(baseURL, query string, version string, patternType *q
 
 // FrontendStreamDecoder decodes streaming events from the frontend service
 type FrontendStreamDecoder struct {
-	OnProgress func(*api.Progress)
-	OnMatches  func([]EventMatch)
-	OnFilters  func([]*EventFilter)
-	OnAlert    func(*EventAlert)
-	OnError    func(*EventError)
-	OnUnknown  func(event, data []byte)
+	OnProgress           func(*api.Progress)
+	OnMatches            func([]EventMatch)
+	OnFilters            func([]*EventFilter)
+	OnAlert              func(*EventAlert)
+	OnError              func(*EventError)
+	OnRankingExplanation func(*EventRankingExplanation)
+	OnUnknown            func(event, data []byte)
 }
 
 func (rr FrontendStreamDecoder) ReadAll(r io.Reader) error {
@@ -103,6 +104,15 @@ func (rr FrontendStreamDecoder) ReadAll(r io.Reader) error {
 				return errors.Errorf("failed to decode alert payload: %w", err)
 			}
 			rr.OnAlert(&d)
+		} else if bytes.Equal(event, []byte("explanation")) {
+			if rr.OnRankingExplanation == nil {
+				continue
+			}
+			var d EventRankingExplanation
+			if err := json.Unmarshal(data, &d); err != nil {
+				return errors.Errorf("failed to decode explanation payload: %w", err)
+			}
+			rr.OnRankingExplanation(&d)
 		} else if bytes.Equal(event, []byte("error")) {
 			if rr.OnError == nil {
 				continue
@@ -149,6 +159,8 @@ func (r *eventMatchUnmarshaller) UnmarshalJSON(b []byte) error {
 		r.EventMatch = &EventSymbolMatch{}
 	case CommitMatchType:
 		r.EventMatch = &EventCommitMatch{}
+	case CommitHistogramMatchType:
+		r.EventMatch = &EventCommitHistogramMatch{}
 	default:
 		return errors.Errorf("unknown MatchType %v", typeU.Type)
 	}
@@ -20,6 +20,12 @@ func TestFrontendClient(t *testing.T) {
 	}
 
 	want := []Event{{
+		Name: "explanation",
+		Value: &EventRankingExplanation{
+			Scorer:          "default",
+			FlushWallTimeMs: 500,
+		},
+	}, {
 		Name: "progress",
 		Value: &api.Progress{
 			MatchCount: 5,
@@ -35,6 +41,15 @@ func TestFrontendClient(t *testing.T) {
 			&EventContentMatch{
 				Type: ContentMatchType,
 				Path: "test",
+				Explanation: &RankingExplanation{
+					Backend: "zoekt",
+					Scorer:  "default",
+					Score:   10,
+					Components: []ScoreComponent{{
+						Name:  "fragment",
+						Value: 10,
+					}},
+				},
 			},
 			&EventPathMatch{
 				Type: PathMatchType,
@@ -112,6 +127,9 @@ func TestFrontendClient(t *testing.T) {
 		OnError: func(d *EventError) {
 			got = append(got, Event{Name: "error", Value: d})
 		},
+		OnRankingExplanation: func(d *EventRankingExplanation) {
+			got = append(got, Event{Name: "explanation", Value: d})
+		},
 		OnUnknown: func(event, data []byte) {
 			t.Fatalf("got unexpected event: %s %s", event, data)
 		},
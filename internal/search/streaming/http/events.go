@@ -32,6 +32,9 @@ type EventContentMatch struct {
 	ChunkMatches    []ChunkMatch     `json:"chunkMatches,omitempty"`
 	Language        string           `json:"language,omitempty"`
 	Debug           string           `json:"debug,omitempty"`
+
+	// Explanation is only set if the client requested ranking explanations.
+	Explanation *RankingExplanation `json:"explanation,omitempty"`
 }
 
 func (e *EventContentMatch) eventMatch() {}
@@ -54,6 +57,9 @@ type EventPathMatch struct {
 	Commit          string     `json:"commit,omitempty"`
 	Language        string     `json:"language,omitempty"`
 	Debug           string     `json:"debug,omitempty"`
+
+	// Explanation is only set if the client requested ranking explanations.
+	Explanation *RankingExplanation `json:"explanation,omitempty"`
 }
 
 func (e *EventPathMatch) eventMatch() {}
@@ -86,6 +92,29 @@ type ChunkMatch struct {
 	ContentStart     Location `json:"contentStart"`
 	Ranges           []Range  `json:"ranges"`
 	ContentTruncated bool     `json:"contentTruncated,omitempty"`
+
+	// Explanation is only set if the client requested ranking explanations
+	// and the backend scores chunks.
+	Explanation *RankingExplanation `json:"explanation,omitempty"`
+}
+
+// RankingExplanation explains the score a backend assigned to a match.
+type RankingExplanation struct {
+	// Backend is the backend which produced the match, "zoekt" or
+	// "searcher". Searcher does not score matches.
+	Backend string `json:"backend"`
+	// Scorer is "default" or "bm25", or empty if the match was not scored.
+	Scorer     string           `json:"scorer,omitempty"`
+	Score      float64          `json:"score"`
+	Components []ScoreComponent `json:"components,omitempty"`
+}
+
+// ScoreComponent is one of the signals which make up a score.
+type ScoreComponent struct {
+	Name        string   `json:"name"`
+	Value       float64  `json:"value"`
+	Raw         *float64 `json:"raw,omitempty"`
+	Description string   `json:"description,omitempty"`
 }
 
 // EventLineMatch is a subset of zoekt.LineMatch for our Event API.
@@ -132,6 +161,9 @@ type EventSymbolMatch struct {
 	Language        string     `json:"language,omitempty"`
 
 	Symbols []Symbol `json:"symbols"`
+
+	// Explanation is only set if the client requested ranking explanations.
+	Explanation *RankingExplanation `json:"explanation,omitempty"`
 }
 
 func (e *EventSymbolMatch) eventMatch() {}
@@ -172,6 +204,32 @@ type EventCommitMatch struct {
 
 func (e *EventCommitMatch) eventMatch() {}
 
+// EventCommitHistogramMatch is the number of commits in a repository matching
+// a select:commit.histogram search per time bucket.
+type EventCommitHistogramMatch struct {
+	// Type is always CommitHistogramMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	RepositoryID int32  `json:"repositoryID"`
+	Repository   string `json:"repository"`
+	// Interval is the width of the buckets: "day", "week" or "month".
+	Interval string `json:"interval"`
+	// AuthorName and AuthorEmail are only set if the histogram is grouped by
+	// author.
+	AuthorName  string                  `json:"authorName,omitempty"`
+	AuthorEmail string                  `json:"authorEmail,omitempty"`
+	Buckets     []CommitHistogramBucket `json:"buckets"`
+}
+
+// CommitHistogramBucket is the number of commits authored in the bucket
+// starting at Start.
+type CommitHistogramBucket struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+func (e *EventCommitHistogramMatch) eventMatch() {}
+
 type EventPersonMatch struct {
 	// Type is always PersonMatchType. Included here for marshalling.
 	Type MatchType `json:"type"`
@@ -236,6 +294,25 @@ type Annotation struct {
 	Value string `json:"value"`
 }
 
+// EventRankingExplanation explains how the results of a search are ordered.
+// It is sent once, before any matches, if the client requested ranking
+// explanations.
+type EventRankingExplanation struct {
+	// Scorer is the scoring function Zoekt uses for this search, "default"
+	// or "bm25".
+	Scorer string `json:"scorer"`
+	// DocumentRanks is true if file ranks computed by code intelligence
+	// ranking contribute to scores.
+	DocumentRanks bool `json:"documentRanks"`
+	// DocumentRanksWeight is the weight of file ranks, if configured.
+	DocumentRanksWeight float64 `json:"documentRanksWeight,omitempty"`
+	// FlushWallTimeMs is how long Zoekt collects results before ranking and
+	// sending them. Results are only ordered within these batches.
+	FlushWallTimeMs int64 `json:"flushWallTimeMs"`
+	// Notes are further remarks on how results are ordered.
+	Notes []string `json:"notes,omitempty"`
+}
+
 // EventError emulates a JavaScript error with a message property
 // as is returned when the search encounters an error.
 type EventError struct {
@@ -252,6 +329,7 @@ const (
 	PathMatchType
 	PersonMatchType
 	TeamMatchType
+	CommitHistogramMatchType
 )
 
 func (t MatchType) MarshalJSON() ([]byte, error) {
@@ -270,6 +348,8 @@ func (t MatchType) MarshalJSON() ([]byte, error) {
 		return []byte(`"person"`), nil
 	case TeamMatchType:
 		return []byte(`"team"`), nil
+	case CommitHistogramMatchType:
+		return []byte(`"commit-histogram"`), nil
 	default:
 		return nil, errors.Errorf("unknown MatchType: %d", t)
 	}
@@ -290,6 +370,8 @@ func (t *MatchType) UnmarshalJSON(b []byte) error {
 		*t = PersonMatchType
 	} else if bytes.Equal(b, []byte(`"team"`)) {
 		*t = TeamMatchType
+	} else if bytes.Equal(b, []byte(`"commit-histogram"`)) {
+		*t = CommitHistogramMatchType
 	} else {
 		return errors.Errorf("unknown MatchType: %s", b)
 	}
@@ -258,6 +258,9 @@ func (s *SearchFilters) Update(event SearchEvent) {
 
 			// =========== TODO: Jason Repo Metadata filters ============
 			// file paths are in v.ModifiedFiles which is a []string
+		case *result.CommitHistogramMatch:
+			addRepoFilter(v.Repo.Name, "", int32(v.TotalCount()))
+			s.Dirty = true
 		}
 	}
 }
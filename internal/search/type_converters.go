@@ -34,6 +34,8 @@ func FromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromCommit(v, repoCache)
 	case *result.OwnerMatch:
 		return fromOwner(v)
+	case *result.CommitHistogramMatch:
+		return fromCommitHistogram(v)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
@@ -72,6 +74,8 @@ func fromPathMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searche
 		pathEvent.Debug = *fm.Debug
 	}
 
+	pathEvent.Explanation = fromRankingExplanation(fm.RankingExplanation)
+
 	return pathEvent
 }
 
@@ -90,6 +94,28 @@ func fromChunkMatch(cm result.ChunkMatch, opts FromMatchOptions) http.ChunkMatch
 		ContentStart:     fromLocation(cm.ContentStart),
 		Ranges:           fromRanges(cm.Ranges),
 		ContentTruncated: truncated,
+		Explanation:      fromRankingExplanation(cm.RankingExplanation),
+	}
+}
+
+func fromRankingExplanation(e *result.RankingExplanation) *http.RankingExplanation {
+	if e == nil {
+		return nil
+	}
+	components := make([]http.ScoreComponent, 0, len(e.Components))
+	for _, c := range e.Components {
+		components = append(components, http.ScoreComponent{
+			Name:        c.Name,
+			Value:       c.Value,
+			Raw:         c.Raw,
+			Description: c.Description(),
+		})
+	}
+	return &http.RankingExplanation{
+		Backend:    e.Backend,
+		Scorer:     e.Scorer,
+		Score:      e.Score,
+		Components: components,
 	}
 }
 
@@ -158,6 +184,8 @@ func fromContentMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Sear
 		contentEvent.Debug = *fm.Debug
 	}
 
+	contentEvent.Explanation = fromRankingExplanation(fm.RankingExplanation)
+
 	return contentEvent
 }
 
@@ -198,6 +226,8 @@ func fromSymbolMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searc
 		symbolMatch.Branches = []string{*fm.InputRev}
 	}
 
+	symbolMatch.Explanation = fromRankingExplanation(fm.RankingExplanation)
+
 	return symbolMatch
 }
 
@@ -262,6 +292,23 @@ func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.Sear
 	return commitEvent
 }
 
+func fromCommitHistogram(histogram *result.CommitHistogramMatch) *http.EventCommitHistogramMatch {
+	buckets := make([]http.CommitHistogramBucket, 0, len(histogram.Buckets))
+	for _, b := range histogram.Buckets {
+		buckets = append(buckets, http.CommitHistogramBucket{Start: b.Start, Count: b.Count})
+	}
+
+	return &http.EventCommitHistogramMatch{
+		Type:         http.CommitHistogramMatchType,
+		RepositoryID: int32(histogram.Repo.ID),
+		Repository:   string(histogram.Repo.Name),
+		Interval:     histogram.Interval,
+		AuthorName:   histogram.AuthorName,
+		AuthorEmail:  histogram.AuthorEmail,
+		Buckets:      buckets,
+	}
+}
+
 func fromOwner(owner *result.OwnerMatch) http.EventMatch {
 	switch v := owner.ResolvedOwner.(type) {
 	case *result.OwnerPerson:
@@ -188,7 +188,7 @@ func (o *ZoektParameters) ToSearchOptions(ctx context.Context) (searchOpts *zoek
 		Trace:           policy.ShouldTrace(ctx),
 		MaxWallTime:     defaultTimeout,
 		ChunkMatches:    true,
-		UseBM25Scoring:  o.PatternType == query.SearchTypeCodyContext && o.Typ == TextRequest,
+		UseBM25Scoring:  useBM25Scoring(o.PatternType, o.Typ),
 		NumContextLines: o.NumContextLines,
 	}
 
@@ -198,7 +198,7 @@ func (o *ZoektParameters) ToSearchOptions(ctx context.Context) (searchOpts *zoek
 	searchOpts.TotalMaxMatchCount = 100_000
 	// Keyword searches tends to match much more broadly than code searches, so we need to
 	// consider more candidates to ensure we don't miss highly-ranked documents. The same
-	// holds for BM25 scoring, which is used for Cody context searches.
+	// holds for BM25 scoring, which is used for Cody context and semantic searches.
 	if searchOpts.UseBM25Scoring || o.PatternType == query.SearchTypeKeyword {
 		searchOpts.ShardMaxMatchCount *= 10
 		searchOpts.TotalMaxMatchCount *= 10
@@ -223,7 +223,7 @@ func (o *ZoektParameters) ToSearchOptions(ctx context.Context) (searchOpts *zoek
 		return searchOpts
 	}
 
-	if o.Features.Debug {
+	if o.Features.Debug || o.Features.ExplainRanking {
 		searchOpts.DebugScore = true
 	}
 
@@ -239,6 +239,55 @@ func (o *ZoektParameters) ToSearchOptions(ctx context.Context) (searchOpts *zoek
 	return searchOpts
 }
 
+// useBM25Scoring returns true if Zoekt scores matches of a request with BM25
+// instead of its default scoring.
+func useBM25Scoring(patternType query.SearchType, typ IndexedRequestType) bool {
+	return (patternType == query.SearchTypeCodyContext || patternType == query.SearchTypeSemantic) && typ == TextRequest
+}
+
+// RankingExplanation explains how the results of a search are ordered.
+type RankingExplanation struct {
+	// Scorer is the scoring function Zoekt uses for the search, "default"
+	// or "bm25".
+	Scorer string
+
+	// DocumentRanks is true if file ranks computed by code intelligence
+	// ranking contribute to scores, with weight DocumentRanksWeight.
+	DocumentRanks       bool
+	DocumentRanksWeight float64
+
+	// FlushWallTime is how long Zoekt collects results before ranking and
+	// sending them.
+	FlushWallTime time.Duration
+
+	// Notes are further remarks on how results are ordered.
+	Notes []string
+}
+
+// ExplainRanking explains how the text results of a search with patternType
+// are ordered. It mirrors the ranking options set by ToSearchOptions.
+func ExplainRanking(patternType query.SearchType) RankingExplanation {
+	bm25 := useBM25Scoring(patternType, TextRequest)
+	e := RankingExplanation{
+		Scorer:        "default",
+		DocumentRanks: !bm25 && conf.CodeIntelRankingDocumentReferenceCountsEnabled(),
+		FlushWallTime: conf.SearchFlushWallTime(bm25),
+		Notes: []string{
+			"Zoekt ranks the matches it finds within a flush window before sending them. Matches sent later are not ranked against matches sent earlier.",
+			"Matches in unindexed revisions are found by searcher, which does not score them. They are sent in the order they are found.",
+		},
+	}
+	if bm25 {
+		e.Scorer = "bm25"
+		e.Notes = append(e.Notes, "BM25 scores only depend on the terms of the query. File and repository ranks are not used.")
+	} else if e.DocumentRanks {
+		e.DocumentRanksWeight = conf.SearchDocumentRanksWeight()
+	} else {
+		e.Notes = append(e.Notes, "File ranks are not used since codeIntelRanking.documentReferenceCountsEnabled is disabled in the site configuration.")
+	}
+	return e
+}
+
 // SearcherParameters the inputs for a search fulfilled by the Searcher service
 // (cmd/searcher). Searcher fulfills (1) unindexed literal and regexp searches
 // and (2) structural search requests.
@@ -394,6 +443,11 @@ type Features struct {
 	// from here. For now we treat this like a feature flag for convenience.
 	Debug bool `json:"debug"`
 
+	// ExplainRanking when true will set RankingExplanation on FileMatches
+	// and their ChunkMatches. It is requested per search rather than being
+	// a feature flag.
+	ExplainRanking bool `json:"-"`
+
 	// ZoektSearchOptionsOverride is a JSON string that overrides the Zoekt search
 	// options. This should be used for quick interactive experiments only. An
 	// invalid JSON string or unknown fields will be ignored.
@@ -424,6 +424,7 @@ func sendMatches(event *zoekt.SearchResult, pathRegexps []*regexp.Regexp, getRep
 			}
 			if debug := file.Debug; debug != "" {
 				fm.Debug = &debug
+				fm.RankingExplanation = result.ParseZoektScoreDebug(debug)
 			}
 			matches = append(matches, &fm)
 		}
@@ -465,7 +466,8 @@ func zoektFileMatchToMultilineMatches(file *zoekt.FileMatch) result.ChunkMatches
 				Line:   int(cm.ContentStart.LineNumber) - 1,
 				Column: int(cm.ContentStart.Column) - 1,
 			},
-			Ranges: ranges,
+			Ranges:             ranges,
+			RankingExplanation: result.ParseZoektScoreDebug(cm.DebugScore),
 		})
 	}
 
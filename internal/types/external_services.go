@@ -4,6 +4,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
+type AWSCodeCommitConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.AWSCodeCommitConnection
+}
+
 type AzureDevOpsConnection struct {
 	URN string
 	*schema.AzureDevOpsConnection
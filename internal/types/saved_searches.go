@@ -11,8 +11,25 @@ type SavedSearch struct {
 	Owner            Namespace // the owner
 	VisibilitySecret bool      // the visibility state (if false, public)
 
+	SnapshotIntervalHours *int32 // how often to snapshot the results (if nil, only on demand)
+	SnapshotUserID        *int32 // the user whose permissions are used for scheduled snapshots
+
 	CreatedAt     time.Time // when this saved search was created
 	CreatedByUser *int32    // the user that created this saved search
 	UpdatedAt     time.Time // when this saved search was last updated
 	UpdatedByUser *int32    // the user that last updated this saved search
 }
+
+// SavedSearchSnapshot represents a snapshot of the results of a saved search. The results
+// themselves are stored in the object store.
+type SavedSearchSnapshot struct {
+	ID            int32
+	SavedSearchID int32
+	Query         string // the query of the saved search when the snapshot was taken
+	ObjectKey     string // the key of the object holding the results
+	EntryCount    int32  // the number of results in the snapshot
+	LimitHit      bool   // whether results were omitted because the snapshot size limit was hit
+
+	CreatedAt     time.Time // when this snapshot was taken
+	CreatedByUser *int32    // the user whose permissions were used to search
+}
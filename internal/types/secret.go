@@ -94,6 +94,12 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		// Nothing to redact
 	case *schema.RubyPackagesConnection:
 		es.redactString(c.Repository, "repository")
+	case *schema.DotNetPackagesConnection:
+		es.redactString(c.Repository, "repository")
+	case *schema.PHPPackagesConnection:
+		es.redactString(c.Repository, "repository")
+	case *schema.ElixirPackagesConnection:
+		es.redactString(c.Repository, "repository")
 	case *schema.JVMPackagesConnection:
 		es.redactString(c.Maven.Credentials, "maven", "credentials")
 	case *schema.PagureConnection:
@@ -237,6 +243,15 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 	case *schema.RubyPackagesConnection:
 		o := oldCfg.(*schema.RubyPackagesConnection)
 		es.unredactString(c.Repository, o.Repository, "repository")
+	case *schema.DotNetPackagesConnection:
+		o := oldCfg.(*schema.DotNetPackagesConnection)
+		es.unredactString(c.Repository, o.Repository, "repository")
+	case *schema.PHPPackagesConnection:
+		o := oldCfg.(*schema.PHPPackagesConnection)
+		es.unredactString(c.Repository, o.Repository, "repository")
+	case *schema.ElixirPackagesConnection:
+		o := oldCfg.(*schema.ElixirPackagesConnection)
+		es.unredactString(c.Repository, o.Repository, "repository")
 	case *schema.JVMPackagesConnection:
 		o := oldCfg.(*schema.JVMPackagesConnection)
 		// credentials didn't change check if repositories did
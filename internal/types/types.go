@@ -1969,6 +1969,50 @@ type SearchContext struct {
 
 	// Whether the user has starred the context. If the user is not authenticated, this field is always false.
 	Starred bool
+
+	// RefreshIntervalHours is how often the repositories matching Query are materialized into the
+	// repository revisions of the context. If zero, the query is evaluated at search time.
+	RefreshIntervalHours int32
+
+	// RevisionRules resolve the revision to search per repository when the context is materialized.
+	// The first rule matching a repository wins.
+	RevisionRules []SearchContextRevisionRule
+
+	// LastRefreshedAt is when the context was last materialized. Nil if it never was.
+	LastRefreshedAt *time.Time
+}
+
+// SearchContextRevisionRule pins the repositories whose name matches Repo to the latest tag
+// or branch whose name matches a glob pattern, e.g. the latest release tag matching "v*".
+// Exactly one of Tag and Branch is set.
+type SearchContextRevisionRule struct {
+	// Repo is a regular expression matched against repository names. An empty pattern matches
+	// all repositories.
+	Repo string `json:"repo,omitempty"`
+	// Tag is a glob pattern matched against tag names.
+	Tag string `json:"tag,omitempty"`
+	// Branch is a glob pattern matched against branch names.
+	Branch string `json:"branch,omitempty"`
+}
+
+// SearchContextRefresh records a materialization of a query-based search context.
+type SearchContextRefresh struct {
+	ID              int32
+	SearchContextID int64
+	RepoCount       int32                        // the number of repositories in the context after the refresh
+	Changes         []SearchContextRefreshChange // the repositories added, removed or re-pinned by the refresh
+	Error           string                       // why the refresh failed, if it did
+	CreatedAt       time.Time
+}
+
+// SearchContextRefreshChange is a repository whose revisions changed with a refresh. Revisions is
+// empty if the repository was removed from the context and PreviousRevisions is empty if it was
+// added.
+type SearchContextRefreshChange struct {
+	RepoID            api.RepoID   `json:"repoID"`
+	RepoName          api.RepoName `json:"repoName"`
+	Revisions         []string     `json:"revisions,omitempty"`
+	PreviousRevisions []string     `json:"previousRevisions,omitempty"`
 }
 
 // SearchContextRepositoryRevisions is a simple wrapper for a repository and its revisions
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gobwas/glob"
+
 	"github.com/sourcegraph/sourcegraph/lib/batches/env"
 	"github.com/sourcegraph/sourcegraph/lib/batches/overridable"
 	"github.com/sourcegraph/sourcegraph/lib/batches/schema"
@@ -38,6 +40,7 @@ type BatchSpec struct {
 	TransformChanges  *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
 	ImportChangesets  []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
 	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	Stages            []Stage                  `json:"stages,omitempty" yaml:"stages"`
 }
 
 type ChangesetTemplate struct {
@@ -130,6 +133,40 @@ type Group struct {
 	Repository string `json:"repository,omitempty" yaml:"repository"`
 }
 
+// Stage is a named group of repositories whose changesets are only published
+// once all changesets of the preceding stages have been merged.
+type Stage struct {
+	Name         string   `json:"name,omitempty" yaml:"name"`
+	Repositories []string `json:"repositories,omitempty" yaml:"repositories"`
+
+	// patterns holds the compiled Repositories. It's populated when the batch
+	// spec is parsed, so that matching repositories doesn't compile the globs
+	// again for every repository.
+	patterns []glob.Glob
+}
+
+// matches returns true if one of the repository patterns of the stage matches
+// the given repository name.
+func (s *Stage) matches(repoName string) bool {
+	patterns := s.patterns
+	if patterns == nil {
+		// The stage wasn't created by ParseBatchSpec. Invalid patterns are
+		// rejected when parsing the batch spec, so we can skip them here.
+		for _, pattern := range s.Repositories {
+			if g, err := glob.Compile(pattern); err == nil {
+				patterns = append(patterns, g)
+			}
+		}
+	}
+
+	for _, g := range patterns {
+		if g.Match(repoName) {
+			return true
+		}
+	}
+	return false
+}
+
 type Mount struct {
 	Mountpoint string `json:"mountpoint" yaml:"mountpoint"`
 	Path       string `json:"path" yaml:"path"`
@@ -178,11 +215,47 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 		}
 	}
 
+	seenStages := make(map[string]struct{}, len(spec.Stages))
+	for i := range spec.Stages {
+		stage := &spec.Stages[i]
+		if _, ok := seenStages[stage.Name]; ok {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("stage %d has the same name %q as a previous stage", i+1, stage.Name)))
+		}
+		seenStages[stage.Name] = struct{}{}
+
+		stage.patterns = make([]glob.Glob, 0, len(stage.Repositories))
+		for _, pattern := range stage.Repositories {
+			g, err := glob.Compile(pattern)
+			if err != nil {
+				errs = errors.Append(errs, NewValidationError(errors.Newf("stage %q contains invalid repository pattern %q: %s", stage.Name, pattern, err)))
+				continue
+			}
+			stage.patterns = append(stage.patterns, g)
+		}
+	}
+
 	return &spec, errs
 }
 
 const invalidMountCharacters = ","
 
+// StageForRepository returns the 1-based index of the first stage whose
+// repository patterns match the given repository name. It returns 0 if the
+// batch spec has no stages or no stage matches, in which case changesets in
+// the repository are not held back.
+func (s *BatchSpec) StageForRepository(repoName string) int {
+	return stageForRepository(s.Stages, repoName)
+}
+
+func stageForRepository(stages []Stage, repoName string) int {
+	for i := range stages {
+		if stages[i].matches(repoName) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
 func (on *OnQueryOrRepository) String() string {
 	if on.RepositoriesMatchingQuery != "" {
 		return on.RepositoriesMatchingQuery
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 )
@@ -286,6 +287,105 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("valid with stages", func(t *testing.T) {
+		const spec = `
+name: hello-world
+on:
+  - repositoriesMatchingQuery: file:go.mod
+steps:
+  - run: go get -u github.com/sourcegraph/lib
+    container: golang:1.22
+changesetTemplate:
+  title: Bump lib
+  branch: bump-lib
+  commit:
+    message: Bump lib
+stages:
+  - name: library
+    repositories: [github.com/sourcegraph/lib]
+  - name: consumers
+    repositories: ["github.com/sourcegraph/*"]
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+
+		want := []Stage{
+			{Name: "library", Repositories: []string{"github.com/sourcegraph/lib"}},
+			{Name: "consumers", Repositories: []string{"github.com/sourcegraph/*"}},
+		}
+		if diff := cmp.Diff(want, batchSpec.Stages, cmpopts.IgnoreUnexported(Stage{})); diff != "" {
+			t.Fatalf("wrong stages (-want +got):\n%s", diff)
+		}
+		// The repository patterns are compiled once, when parsing the spec.
+		for _, stage := range batchSpec.Stages {
+			assert.Len(t, stage.patterns, len(stage.Repositories))
+		}
+		assert.Equal(t, 2, batchSpec.StageForRepository("github.com/sourcegraph/frontend"))
+	})
+
+	t.Run("duplicate stage names", func(t *testing.T) {
+		const spec = `
+name: hello-world
+changesetTemplate:
+  title: Bump lib
+  branch: bump-lib
+  commit:
+    message: Bump lib
+stages:
+  - name: library
+    repositories: [github.com/sourcegraph/lib]
+  - name: library
+    repositories: ["github.com/sourcegraph/*"]
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, `stage 2 has the same name "library" as a previous stage`, err.Error())
+	})
+
+	t.Run("invalid stage repository pattern", func(t *testing.T) {
+		const spec = `
+name: hello-world
+changesetTemplate:
+  title: Bump lib
+  branch: bump-lib
+  commit:
+    message: Bump lib
+stages:
+  - name: library
+    repositories: ["github.com/sourcegraph/[lib"]
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+		assert.Contains(t, err.Error(), `stage "library" contains invalid repository pattern "github.com/sourcegraph/[lib"`)
+	})
+}
+
+func TestBatchSpec_StageForRepository(t *testing.T) {
+	spec := &BatchSpec{
+		Stages: []Stage{
+			{Name: "library", Repositories: []string{"github.com/sourcegraph/lib"}},
+			{Name: "consumers", Repositories: []string{"github.com/sourcegraph/*", "gitlab.com/sourcegraph/*"}},
+		},
+	}
+
+	for repoName, want := range map[string]int{
+		"github.com/sourcegraph/lib":      1,
+		"github.com/sourcegraph/frontend": 2,
+		"gitlab.com/sourcegraph/frontend": 2,
+		"github.com/other/frontend":       0,
+	} {
+		if have := spec.StageForRepository(repoName); have != want {
+			t.Errorf("wrong stage for %q. want=%d, have=%d", repoName, want, have)
+		}
+	}
+
+	if have := (&BatchSpec{}).StageForRepository("github.com/sourcegraph/lib"); have != 0 {
+		t.Errorf("wrong stage for spec without stages. want=0, have=%d", have)
+	}
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
@@ -81,6 +81,10 @@ type ChangesetSpec struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published PublishedValue `json:"published,omitempty"`
+
+	// Stage is the 1-based index of the batch spec stage this changeset
+	// belongs to, or 0 if the batch spec doesn't define stages.
+	Stage int `json:"stage,omitempty"`
 }
 
 // MarshalJSON overwrites the default behavior of the json lib while unmarshalling
@@ -104,6 +108,7 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Commits        []GitCommitDescription `json:"commits,omitempty"`
 		Published      *PublishedValue        `json:"published,omitempty"`
 		Fork           *bool                  `json:"fork,omitempty"`
+		Stage          int                    `json:"stage,omitempty"`
 	}{
 		BaseRepository: c.BaseRepository,
 		ExternalID:     c.ExternalID,
@@ -115,6 +120,7 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Body:           c.Body,
 		Commits:        c.Commits,
 		Fork:           c.Fork,
+		Stage:          c.Stage,
 	}
 	if !c.Published.Nil() {
 		v.Published = &c.Published
@@ -30,6 +30,7 @@ type ChangesetSpecInput struct {
 	BatchChangeAttributes *template.BatchChangeAttributes `json:"-"`
 	Template              *ChangesetTemplate              `json:"-"`
 	TransformChanges      *TransformChanges               `json:"-"`
+	Stages                []Stage                         `json:"-"`
 	Path                  string
 
 	Result execution.AfterStepResult
@@ -102,6 +103,8 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool, fallbackAu
 		return nil, err
 	}
 
+	stage := stageForRepository(input.Stages, input.Repository.Name)
+
 	newSpec := func(branch string, diff []byte) *ChangesetSpec {
 		var published any = nil
 		if input.Template.Published != nil {
@@ -135,6 +138,7 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool, fallbackAu
 				},
 			},
 			Published: PublishedValue{Val: published},
+			Stage:     stage,
 		}
 	}
 
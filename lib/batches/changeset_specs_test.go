@@ -158,6 +158,22 @@ func TestCreateChangesetSpecs(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "with stages",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Stages = []Stage{
+					{Name: "library", Repositories: []string{"github.com/sourcegraph/lib"}},
+					{Name: "consumers", Repositories: []string{"github.com/sourcegraph/*"}},
+				}
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.Stage = 2
+				}),
+			},
+			wantErr: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -166,6 +166,7 @@ func ChangesetSpecsFromCache(spec *batches.BatchSpec, r batches.Repository, resu
 		},
 		Template:         spec.ChangesetTemplate,
 		TransformChanges: spec.TransformChanges,
+		Stages:           spec.Stages,
 		Result:           result,
 		Path:             path,
 	}
@@ -2,7 +2,7 @@
 
 package schema
 
-// BatchSpecJSON is the content of the file "schema/batch_spec.schema.json".
+// BatchSpecJSON is the content of the file "/root/module/schema/batch_spec.schema.json".
 const BatchSpecJSON = `{
   "$id": "batch_spec.schema.json#",
   "$schema": "http://json-schema.org/draft-07/schema#",
@@ -406,6 +406,34 @@ const BatchSpecJSON = `{
           ]
         }
       }
+    },
+    "stages": {
+      "type": ["array", "null"],
+      "description": "An ordered list of stages. Changesets in repositories matched by a stage are only published once all changesets of the preceding stages have been merged. Changesets in repositories not matched by any stage are not held back.",
+      "items": {
+        "title": "Stage",
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["name", "repositories"],
+        "properties": {
+          "name": {
+            "type": "string",
+            "description": "The name of the stage, which must be unique within the batch spec.",
+            "minLength": 1,
+            "examples": ["library", "consumers"]
+          },
+          "repositories": {
+            "type": "array",
+            "description": "A list of glob patterns to match repository names. A repository belongs to the first stage with a matching pattern.",
+            "minItems": 1,
+            "items": {
+              "type": "string",
+              "minLength": 1
+            },
+            "examples": [["github.com/sourcegraph/lib"], ["github.com/sourcegraph/*"]]
+          }
+        }
+      }
     }
   }
 }
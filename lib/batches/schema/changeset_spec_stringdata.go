@@ -2,7 +2,7 @@
 
 package schema
 
-// ChangesetSpecJSON is the content of the file "schema/changeset_spec.schema.json".
+// ChangesetSpecJSON is the content of the file "/root/module/schema/changeset_spec.schema.json".
 const ChangesetSpecJSON = `{
   "$schema": "http://json-schema.org/draft-07/schema#",
   "title": "ChangesetSpec",
@@ -111,6 +111,11 @@ const ChangesetSpecJSON = `{
         "published": {
           "oneOf": [{ "type": "boolean" }, { "type": "string", "pattern": "^draft$" }, { "type": "null" }],
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host."
+        },
+        "stage": {
+          "type": "integer",
+          "description": "The 1-based index of the batch spec stage this changeset belongs to. The changeset is only published once all changesets of the preceding stages have been merged.",
+          "minimum": 1
         }
       },
       "required": ["baseRepository", "baseRef", "baseRev", "headRepository", "headRef", "title", "body", "commits"],
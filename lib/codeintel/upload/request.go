@@ -168,6 +168,9 @@ func makeUploadURL(opts uploadRequestOptions) (*url.URL, error) {
 	if opts.UploadRecordOptions.AssociatedIndexID != nil {
 		qs.Add("associatedIndexId", formatInt(*opts.UploadRecordOptions.AssociatedIndexID))
 	}
+	if opts.UploadRecordOptions.Shard != "" {
+		qs.Add("shard", opts.UploadRecordOptions.Shard)
+	}
 	if opts.MultiPart {
 		qs.Add("multiPart", "true")
 	}
@@ -44,4 +44,10 @@ type UploadRecordOptions struct {
 	Indexer           string
 	IndexerVersion    string
 	AssociatedIndexID *int
+	// Shard names the partial index being uploaded. Indexes for the same commit, root,
+	// and indexer but with distinct shards are merged into a single logical index. A
+	// later commit may re-upload only the shards that changed: the shards it omits are
+	// inherited from the nearest indexed ancestor. Uploading a complete (unsharded)
+	// index replaces all shards.
+	Shard string
 }
@@ -35,6 +35,10 @@ func TestUploadIndex(t *testing.T) {
 			t.Fatalf("Authorization header expected to be '%s', got '%s'", "token hunter2", r.Header.Get("Authorization"))
 		}
 
+		if r.URL.Query().Get("shard") != "//proj:lib" {
+			t.Fatalf("shard query parameter expected to be '%s', got '%s'", "//proj:lib", r.URL.Query().Get("shard"))
+		}
+
 		gzipReader, err := gzip.NewReader(bytes.NewReader(payload))
 		if err != nil {
 			t.Fatalf("unexpected error creating gzip.Reader: %s", err)
@@ -67,6 +71,7 @@ func TestUploadIndex(t *testing.T) {
 			Commit:  "deadbeef",
 			Root:    "proj/",
 			Indexer: "lsif-go",
+			Shard:   "//proj:lib",
 		},
 		SourcegraphInstanceOptions: SourcegraphInstanceOptions{
 			SourcegraphURL:      ts.URL,
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS codeintel_unused_symbols;
+DROP TABLE IF EXISTS codeintel_unused_symbol_reports;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS codeintel_unused_symbol_reports (
+    upload_id integer PRIMARY KEY,
+    computed_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE codeintel_unused_symbol_reports IS 'Tracks the uploads for which the set of unused symbols has been computed.';
+COMMENT ON COLUMN codeintel_unused_symbol_reports.upload_id IS 'The identifier of the upload that was analyzed.';
+COMMENT ON COLUMN codeintel_unused_symbol_reports.computed_at IS 'The time the set of unused symbols was last computed.';
+
+CREATE TABLE IF NOT EXISTS codeintel_unused_symbols (
+    upload_id integer NOT NULL,
+    symbol_name text NOT NULL,
+    kind text NOT NULL,
+    document_path text NOT NULL,
+    start_line integer NOT NULL,
+    start_character integer NOT NULL,
+    end_line integer NOT NULL,
+    end_character integer NOT NULL,
+    PRIMARY KEY (upload_id, symbol_name)
+);
+
+COMMENT ON TABLE codeintel_unused_symbols IS 'Global symbols defined by an upload that are not referenced by the upload itself or by any upload depending on it.';
+COMMENT ON COLUMN codeintel_unused_symbols.upload_id IS 'The identifier of the upload defining the symbol.';
+COMMENT ON COLUMN codeintel_unused_symbols.symbol_name IS 'The SCIP symbol name.';
+COMMENT ON COLUMN codeintel_unused_symbols.kind IS 'The SCIP symbol kind, or the empty string if the indexer did not emit one.';
+COMMENT ON COLUMN codeintel_unused_symbols.document_path IS 'The path of the document defining the symbol, relative to the upload root.';
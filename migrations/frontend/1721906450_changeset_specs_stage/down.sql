@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS changeset_specs DROP COLUMN IF EXISTS stage;
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS changeset_specs
+    ADD COLUMN IF NOT EXISTS stage integer;
@@ -0,0 +1,58 @@
+DROP VIEW IF EXISTS lsif_uploads_with_repository_name;
+
+CREATE VIEW lsif_uploads_with_repository_name AS
+SELECT u.id,
+    u.commit,
+    u.root,
+    u.queued_at,
+    u.uploaded_at,
+    u.state,
+    u.failure_message,
+    u.started_at,
+    u.finished_at,
+    u.repository_id,
+    u.indexer,
+    u.indexer_version,
+    u.num_parts,
+    u.uploaded_parts,
+    u.process_after,
+    u.num_resets,
+    u.upload_size,
+    u.num_failures,
+    u.associated_index_id,
+    u.content_type,
+    u.should_reindex,
+    u.expired,
+    u.last_retention_scan_at,
+    r.name AS repository_name,
+    u.uncompressed_size
+FROM lsif_uploads u
+JOIN repo r ON r.id = u.repository_id
+WHERE r.deleted_at IS NULL;
+
+-- Without shards, a commit has at most one completed upload per root and indexer.
+-- Keep the most recent upload of each sharded index and mark the other shards for
+-- deletion, so that the unique index below can be created.
+SELECT set_config('codeintel.lsif_uploads_audit.reason', 'collapsing sharded uploads', true);
+
+UPDATE lsif_uploads u
+SET state = 'deleting'
+WHERE
+    u.state = 'completed' AND
+    EXISTS (
+        SELECT 1
+        FROM lsif_uploads o
+        WHERE
+            o.state = 'completed' AND
+            o.repository_id = u.repository_id AND
+            o.commit = u.commit AND
+            o.root = u.root AND
+            o.indexer = u.indexer AND
+            o.id > u.id
+    );
+
+DROP INDEX IF EXISTS lsif_uploads_repository_id_commit_root_indexer_shard;
+CREATE UNIQUE INDEX IF NOT EXISTS lsif_uploads_repository_id_commit_root_indexer ON lsif_uploads(repository_id, commit, root, indexer) WHERE state = 'completed';
+
+ALTER TABLE lsif_uploads
+DROP COLUMN IF EXISTS shard;
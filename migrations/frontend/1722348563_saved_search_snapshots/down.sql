@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS saved_search_snapshots;
+
+ALTER TABLE saved_searches DROP COLUMN IF EXISTS snapshot_user_id;
+ALTER TABLE saved_searches DROP COLUMN IF EXISTS snapshot_interval_hours;
@@ -0,0 +1,22 @@
+ALTER TABLE saved_searches ADD COLUMN IF NOT EXISTS snapshot_interval_hours integer CHECK (snapshot_interval_hours > 0);
+ALTER TABLE saved_searches ADD COLUMN IF NOT EXISTS snapshot_user_id integer REFERENCES users (id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN saved_searches.snapshot_interval_hours IS 'How often a snapshot of the results is taken. Snapshots are only taken on demand if NULL.';
+COMMENT ON COLUMN saved_searches.snapshot_user_id IS 'The user whose permissions are used to search when taking scheduled snapshots.';
+
+CREATE TABLE IF NOT EXISTS saved_search_snapshots (
+    id SERIAL PRIMARY KEY,
+    saved_search_id integer NOT NULL REFERENCES saved_searches (id) ON DELETE CASCADE,
+    query text NOT NULL,
+    object_key text NOT NULL,
+    entry_count integer NOT NULL,
+    limit_hit boolean NOT NULL DEFAULT false,
+    created_by integer REFERENCES users (id) ON DELETE SET NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE saved_search_snapshots IS 'Snapshots of the results of saved searches. The results are stored in the object store.';
+COMMENT ON COLUMN saved_search_snapshots.query IS 'The query of the saved search when the snapshot was taken.';
+COMMENT ON COLUMN saved_search_snapshots.object_key IS 'The key of the object holding the snapshot entries, one JSON object per line.';
+
+CREATE INDEX IF NOT EXISTS saved_search_snapshots_saved_search_id_created_at ON saved_search_snapshots (saved_search_id, created_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS search_context_refreshes;
+
+ALTER TABLE search_contexts DROP COLUMN IF EXISTS last_refreshed_at;
+ALTER TABLE search_contexts DROP COLUMN IF EXISTS revision_rules;
+ALTER TABLE search_contexts DROP COLUMN IF EXISTS refresh_interval_hours;
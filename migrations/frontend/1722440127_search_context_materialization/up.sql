@@ -0,0 +1,21 @@
+ALTER TABLE search_contexts ADD COLUMN IF NOT EXISTS refresh_interval_hours integer CHECK (refresh_interval_hours > 0);
+ALTER TABLE search_contexts ADD COLUMN IF NOT EXISTS revision_rules jsonb NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE search_contexts ADD COLUMN IF NOT EXISTS last_refreshed_at timestamp with time zone;
+
+COMMENT ON COLUMN search_contexts.refresh_interval_hours IS 'How often the repositories matching the query of the search context are materialized into search_context_repos. Query-based contexts are evaluated at search time if NULL.';
+COMMENT ON COLUMN search_contexts.revision_rules IS 'Rules resolving the revision to search per repository when the search context is materialized.';
+COMMENT ON COLUMN search_contexts.last_refreshed_at IS 'When the search context was last materialized.';
+
+CREATE TABLE IF NOT EXISTS search_context_refreshes (
+    id SERIAL PRIMARY KEY,
+    search_context_id bigint NOT NULL REFERENCES search_contexts (id) ON DELETE CASCADE,
+    repo_count integer NOT NULL,
+    changes jsonb NOT NULL DEFAULT '[]'::jsonb,
+    error text,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE search_context_refreshes IS 'History of the materializations of query-based search contexts.';
+COMMENT ON COLUMN search_context_refreshes.changes IS 'The repositories whose revisions changed with the refresh, along with their previous revisions.';
+
+CREATE INDEX IF NOT EXISTS search_context_refreshes_search_context_id_created_at ON search_context_refreshes (search_context_id, created_at);
@@ -8,10 +8,20 @@ import (
 	"fmt"
 )
 
+// AWSCodeCommitAuthorization description: If non-null, enforces AWS CodeCommit repository permissions. Sourcegraph users are mapped to IAM users or roles, whose identity-based policies are evaluated to determine which repositories they can read (the `codecommit:GitPull` action). The credentials in `accessKeyID` and `secretAccessKey` must additionally be allowed the `iam:GetAccountAuthorizationDetails` action.
+type AWSCodeCommitAuthorization struct {
+	// AccountID description: The ID of the AWS account that owns the repositories and IAM principals. It must be the account of the credentials in `accessKeyID` and `secretAccessKey`: this is verified when validating the connection, and permissions syncs fail for repositories of other accounts.
+	AccountID string `json:"accountID"`
+	// IdentityProvider description: The source of identity to map Sourcegraph users to IAM principals.
+	IdentityProvider AWSCodeCommitIdentityProvider `json:"identityProvider"`
+}
+
 // AWSCodeCommitConnection description: Configuration for a connection to AWS CodeCommit.
 type AWSCodeCommitConnection struct {
 	// AccessKeyID description: The AWS access key ID to use when listing and updating repositories from AWS CodeCommit. Must have the AWSCodeCommitReadOnly IAM policy.
 	AccessKeyID string `json:"accessKeyID"`
+	// Authorization description: If non-null, enforces AWS CodeCommit repository permissions. Sourcegraph users are mapped to IAM users or roles, whose identity-based policies are evaluated to determine which repositories they can read (the `codecommit:GitPull` action). The credentials in `accessKeyID` and `secretAccessKey` must additionally be allowed the `iam:GetAccountAuthorizationDetails` action.
+	Authorization *AWSCodeCommitAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from AWS CodeCommit.
 	//
 	// Supports excluding by name ({"name": "git-codecommit.us-west-1.amazonaws.com/repo-name"}) or by ARN ({"id": "arn:aws:codecommit:us-west-1:999999999999:name"}).
@@ -46,6 +56,18 @@ type AWSCodeCommitGitCredentials struct {
 	Username string `json:"username"`
 }
 
+// AWSCodeCommitIdentityProvider description: The source of identity to map Sourcegraph users to IAM principals.
+type AWSCodeCommitIdentityProvider struct {
+	// Attribute description: The SAML assertion attribute holding the IAM principal, e.g. "https://aws.amazon.com/SAML/Attributes/Role". If not set, the account ID of the external account is used.
+	Attribute string `json:"attribute,omitempty"`
+	// AuthProviderID description: The value of the `configID` field of the targeted authentication provider. Required for the "external" type.
+	AuthProviderID string `json:"authProviderID,omitempty"`
+	// AuthProviderType description: The `type` field of the targeted authentication provider. Required for the "external" type.
+	AuthProviderType string `json:"authProviderType,omitempty"`
+	// Type description: "username" maps a Sourcegraph user to the IAM user of the same name. "external" uses the user's account of the given authentication provider: its account ID, or the value of `attribute` for SAML accounts, must be the name or ARN of an IAM user or role.
+	Type string `json:"type"`
+}
+
 // AWSKMSEncryptionKey description: AWS KMS Encryption Key, used to encrypt data in AWS environments
 type AWSKMSEncryptionKey struct {
 	CredentialsFile string `json:"credentialsFile,omitempty"`
@@ -311,6 +333,8 @@ type BatchSpec struct {
 	Name string `json:"name"`
 	// On description: The set of repositories (and branches) to run the batch change on, specified as a list of search queries (that match repositories) and/or specific repositories.
 	On []any `json:"on,omitempty"`
+	// Stages description: An ordered list of stages. Changesets in repositories matched by a stage are only published once all changesets of the preceding stages have been merged. Changesets in repositories not matched by any stage are not held back.
+	Stages []*Stage `json:"stages,omitempty"`
 	// Steps description: The sequence of commands to run (for each repository branch matched in the `on` property) to produce the workspace changes that will be included in the batch change.
 	Steps []*Step `json:"steps,omitempty"`
 	// TransformChanges description: Optional transformations to apply to the changes produced in each repository.
@@ -546,6 +570,8 @@ type BranchChangesetSpec struct {
 	HeadRepository string `json:"headRepository"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host.
 	Published any `json:"published,omitempty"`
+	// Stage description: The 1-based index of the batch spec stage this changeset belongs to. The changeset is only published once all changesets of the preceding stages have been merged.
+	Stage int `json:"stage,omitempty"`
 	// Title description: The title of the changeset on the code host.
 	Title string `json:"title"`
 	// Version description: A field for versioning the payload.
@@ -877,6 +903,24 @@ type DoNotUsePhonyDiscriminantType struct {
 	Type                 string `json:"type"`
 }
 
+// DotNetPackagesConnection description: Configuration for a connection to .NET packages hosted on a NuGet V3 feed
+type DotNetPackagesConnection struct {
+	// Dependencies description: An array of strings specifying .NET packages to mirror in Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the configured NuGet feed.
+	RateLimit *DotNetRateLimit `json:"rateLimit,omitempty"`
+	// Repository description: The URL of the NuGet V3 service index of the feed to fetch packages from.
+	Repository string `json:"repository,omitempty"`
+}
+
+// DotNetRateLimit description: Rate limit applied when making background API requests to the configured NuGet feed.
+type DotNetRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // Dotcom description: Configuration options for Sourcegraph.com only.
 type Dotcom struct {
 	// CodyGateway description: Configuration related to the Cody Gateway service management. This should only be used on sourcegraph.com.
@@ -909,6 +953,24 @@ type Dotcom struct {
 	// SrcCliVersionCache description: Configuration related to the src-cli version cache. This should only be used on sourcegraph.com.
 	SrcCliVersionCache *SrcCliVersionCache `json:"srcCliVersionCache,omitempty"`
 }
+
+// ElixirPackagesConnection description: Configuration for a connection to Elixir and Erlang packages hosted on a Hex repository
+type ElixirPackagesConnection struct {
+	// Dependencies description: An array of strings specifying Elixir packages to mirror in Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the configured Hex repository.
+	RateLimit *ElixirRateLimit `json:"rateLimit,omitempty"`
+	// Repository description: The URL of the Hex repository to fetch package tarballs from.
+	Repository string `json:"repository,omitempty"`
+}
+
+// ElixirRateLimit description: Rate limit applied when making background API requests to the configured Hex repository.
+type ElixirRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type EmailTemplate struct {
 	// Html description: Template for HTML body
 	Html string `json:"html"`
@@ -1131,6 +1193,10 @@ type ExperimentalFeatures struct {
 	CustomGitFetch []*CustomGitFetchMapping `json:"customGitFetch,omitempty"`
 	// DebugLog description: Turns on debug logging for specific debugging scenarios.
 	DebugLog *DebugLog `json:"debug.log,omitempty"`
+	// DotnetPackages description: Allow adding .NET package host connections
+	DotnetPackages string `json:"dotnetPackages,omitempty"`
+	// ElixirPackages description: Allow adding Elixir package host connections
+	ElixirPackages string `json:"elixirPackages,omitempty"`
 	// EnableGithubInternalRepoVisibility description: Enable support for visibility of internal Github repositories
 	EnableGithubInternalRepoVisibility bool `json:"enableGithubInternalRepoVisibility,omitempty"`
 	// EnablePermissionsWebhooks description: DEPRECATED: No longer has any effect.
@@ -1163,6 +1229,8 @@ type ExperimentalFeatures struct {
 	Perforce string `json:"perforce,omitempty"`
 	// PerforceChangelistMapping description: Allow mapping of Perforce changelists to their commit SHAs in the DB
 	PerforceChangelistMapping string `json:"perforceChangelistMapping,omitempty"`
+	// PhpPackages description: Allow adding PHP package host connections
+	PhpPackages string `json:"phpPackages,omitempty"`
 	// PythonPackages description: Allow adding Python package code host connections
 	PythonPackages string `json:"pythonPackages,omitempty"`
 	// Ranking description: Experimental search result ranking options.
@@ -1338,6 +1406,12 @@ type GerritAuthProvider struct {
 type GerritAuthorization struct {
 	// IdentityProvider description: The identity provider to use for user information. If not set, the `url` field is used.
 	IdentityProvider string `json:"identityProvider,omitempty"`
+	// SubRepoPermissions description: Experimental: infer sub-repository permissions from the read access rights of project access sections.
+	//
+	// Gerrit only knows access rights on refs, so paths are configured on pseudo-refs in the `refs/paths/` namespace: an access section on `refs/paths/<path>` (or `refs/paths/<path>/*`) restricts the file or directory `<path>`, relative to the repository root, to the groups its `read` permission allows. For example, a section on `refs/paths/docs/internal` that grants `read` to the `Employees` group hides `docs/internal` from all other users.
+	//
+	// Sections are evaluated like Gerrit evaluates ref permissions: sections inherited from parent projects, up to All-Projects, apply as well; a BLOCK rule always wins; an exclusive `read` permission ignores the ALLOW rules of parent projects; and a section on a nested path takes precedence over one on its parent directory. Sections without a `read` permission are ignored. The project access is read with the credentials of this connection, which therefore need permission to read the access configuration of all projects.
+	SubRepoPermissions bool `json:"subRepoPermissions,omitempty"`
 }
 
 // GerritConnection description: Configuration for a connection to Gerrit.
@@ -2183,6 +2257,24 @@ type OutputVariable struct {
 	Value string `json:"value"`
 }
 
+// PHPPackagesConnection description: Configuration for a connection to PHP packages hosted on a Composer repository such as Packagist
+type PHPPackagesConnection struct {
+	// Dependencies description: An array of strings specifying PHP packages to mirror in Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the configured Composer repository.
+	RateLimit *PHPRateLimit `json:"rateLimit,omitempty"`
+	// Repository description: The URL of the Composer repository to fetch package metadata from. The repository must serve the metadata-url API at /p2/<vendor>/<package>.json.
+	Repository string `json:"repository,omitempty"`
+}
+
+// PHPRateLimit description: Rate limit applied when making background API requests to the configured Composer repository.
+type PHPRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // PagureConnection description: Configuration for a connection to Pagure.
 type PagureConnection struct {
 	// Forks description: If true, it includes forks in the returned projects.
@@ -2262,6 +2354,16 @@ type PerforceConnection struct {
 	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
 }
 
+// PermissionsFreshnessSLO description: Enforces a maximum age for user permissions. When a user whose permissions are older than the SLO makes a request, a high-priority permissions sync is scheduled for them and, optionally, private repository results are withheld until it completes.
+type PermissionsFreshnessSLO struct {
+	// BlockPrivateResults description: Whether repository, search and file content requests wait for the just-in-time sync to complete. If the sync fails or does not complete within blockTimeoutSeconds, only public and unrestricted repositories are visible, and later requests of the user are restricted without waiting until their permissions are fresh. Site admins are exempt.
+	BlockPrivateResults bool `json:"blockPrivateResults,omitempty"`
+	// BlockTimeoutSeconds description: How long (in seconds) a request waits for a just-in-time permissions sync when blockPrivateResults is enabled.
+	BlockTimeoutSeconds int `json:"blockTimeoutSeconds,omitempty"`
+	// MaxAgeSeconds description: The maximum age (in seconds) of a user's permissions before a just-in-time sync is triggered. A value of 0 disables freshness enforcement.
+	MaxAgeSeconds int `json:"maxAgeSeconds,omitempty"`
+}
+
 // PermissionsUserMapping description: Settings for Sourcegraph explicit permissions, which allow the site admin to explicitly manage repository permissions via the GraphQL API. This will mark repositories as restricted by default.
 type PermissionsUserMapping struct {
 	// BindID description: The type of identifier to identify a user. The default is "email", which uses the email address to identify a user. Use "username" to identify a user by their username. Changing this setting will erase any permissions created for users that do not yet exist.
@@ -3165,7 +3267,7 @@ type SiteConfiguration struct {
 	CodeIntelAutoIndexingAllowGlobalPolicies *bool `json:"codeIntelAutoIndexing.allowGlobalPolicies,omitempty"`
 	// CodeIntelAutoIndexingEnabled description: Enables/disables the code intel auto-indexing feature. Currently experimental.
 	CodeIntelAutoIndexingEnabled *bool `json:"codeIntelAutoIndexing.enabled,omitempty"`
-	// CodeIntelAutoIndexingIndexerMap description: Overrides the default Docker images used by auto-indexing.
+	// CodeIntelAutoIndexingIndexerMap description: Overrides the default Docker images used by auto-indexing. Languages without a default image, such as C/C++ (key "cpp", an image containing scip-clang and the project's build toolchain), are only auto-indexed once an image is set here.
 	CodeIntelAutoIndexingIndexerMap map[string]string `json:"codeIntelAutoIndexing.indexerMap,omitempty"`
 	// CodeIntelAutoIndexingPolicyRepositoryMatchLimit description: The maximum number of repositories to which a single auto-indexing policy can apply. Default is -1, which is unlimited.
 	CodeIntelAutoIndexingPolicyRepositoryMatchLimit *int `json:"codeIntelAutoIndexing.policyRepositoryMatchLimit,omitempty"`
@@ -3331,6 +3433,8 @@ type SiteConfiguration struct {
 	OwnBestEffortTeamMatching *bool `json:"own.bestEffortTeamMatching,omitempty"`
 	// ParentSourcegraph description: URL to fetch unreachable repository details from. Defaults to "https://sourcegraph.com"
 	ParentSourcegraph *ParentSourcegraph `json:"parentSourcegraph,omitempty"`
+	// PermissionsFreshnessSLO description: Enforces a maximum age for user permissions. When a user whose permissions are older than the SLO makes a request, a high-priority permissions sync is scheduled for them and, optionally, private repository results are withheld until it completes.
+	PermissionsFreshnessSLO *PermissionsFreshnessSLO `json:"permissions.freshnessSLO,omitempty"`
 	// PermissionsSyncJobCleanupInterval description: Time interval (in seconds) of how often cleanup worker should remove old jobs from permissions sync jobs table.
 	PermissionsSyncJobCleanupInterval int `json:"permissions.syncJobCleanupInterval,omitempty"`
 	// PermissionsSyncJobsHistorySize description: The number of last repo/user permission jobs to keep for history.
@@ -3372,6 +3476,8 @@ type SiteConfiguration struct {
 	SearchLargeFiles []string `json:"search.largeFiles,omitempty"`
 	// SearchLimits description: Limits that search applies for number of repositories searched and timeouts.
 	SearchLimits *SearchLimits `json:"search.limits,omitempty"`
+	// SearchSmartSearchRules description: Additional query rewrite rules for Smart Search. When a query finds no results, Smart Search runs alternative queries produced by its built-in rules and by these rules, and proposes the ones that find results. A rule matches a search term and rewrites it, for example to turn the nickname of an internal service into a repository filter.
+	SearchSmartSearchRules []*SmartSearchRule `json:"search.smartSearch.rules,omitempty"`
 	// SscApiBaseUrl description: The base URL of the Self-Serve Cody API.
 	SscApiBaseUrl string `json:"ssc.apiBaseUrl,omitempty"`
 	// SscSamsHostName description: The hostname of SAMS instance to connect.
@@ -3551,6 +3657,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "search.index.symbols.enabled")
 	delete(m, "search.largeFiles")
 	delete(m, "search.limits")
+	delete(m, "search.smartSearch.rules")
 	delete(m, "ssc.apiBaseUrl")
 	delete(m, "ssc.samsHostName")
 	delete(m, "syntaxHighlighting")
@@ -3579,6 +3686,22 @@ type SiteModelConfiguration struct {
 	Sourcegraph       *SourcegraphModelConfig `json:"sourcegraph,omitempty"`
 }
 
+// SmartSearchRule description: A rule that rewrites a search term of a query into an alternative query.
+type SmartSearchRule struct {
+	// Description description: Describes the rewrite to users next to the queries produced by the rule, for example "search the payments service".
+	Description string `json:"description"`
+	// Filters description: Filters added to the query when the rule applies, like "repo:^github\.com/acme/payments$". They may reference capture groups of match.
+	Filters []string `json:"filters,omitempty"`
+	// Kind description: Whether the rule narrows the query, making it more specific, or widens it. Narrowing rules are combined with each other before widening rules are tried.
+	Kind string `json:"kind,omitempty"`
+	// Match description: Regular expression matched against each search term of the query. The rule applies to the first term it matches.
+	Match string `json:"match"`
+	// Name description: A unique identifier for the rule. It is attached to the queries produced by the rule and recorded in telemetry when a user clicks on one of them.
+	Name string `json:"name"`
+	// Replace description: Replacement for the parts of the term matched by match. It may reference capture groups of match, like $1. The term is removed from the query if nothing is left of it.
+	Replace string `json:"replace,omitempty"`
+}
+
 // SourcegraphModelConfig description: If null, Cody will not use Sourcegraph's servers for model discovery.
 type SourcegraphModelConfig struct {
 	// AccessToken description: The Cody gateway access token to use. If null, an access token will be automatically generated based on the product license.
@@ -3600,6 +3723,13 @@ type SrcCliVersionCache struct {
 	Interval string `json:"interval,omitempty"`
 }
 
+type Stage struct {
+	// Name description: The name of the stage, which must be unique within the batch spec.
+	Name string `json:"name"`
+	// Repositories description: A list of glob patterns to match repository names. A repository belongs to the first stage with a matching pattern.
+	Repositories []string `json:"repositories"`
+}
+
 // Step description: A command to run (as part of a sequence) in a repository branch to produce the required changes.
 type Step struct {
 	// Container description: The Docker image used to launch the Docker container in which the shell command is run.
@@ -70,6 +70,15 @@ var RustPackagesSchemaJSON string
 //go:embed ruby-packages.schema.json
 var RubyPackagesSchemaJSON string
 
+//go:embed dotnet-packages.schema.json
+var DotNetPackagesSchemaJSON string
+
+//go:embed php-packages.schema.json
+var PHPPackagesSchemaJSON string
+
+//go:embed elixir-packages.schema.json
+var ElixirPackagesSchemaJSON string
+
 // OtherExternalServiceSchemaJSON is the content of the file "other_external_service.schema.json".
 //
 //go:embed other_external_service.schema.json